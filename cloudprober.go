@@ -37,6 +37,7 @@ import (
 	"github.com/cloudprober/cloudprober/config"
 	configpb "github.com/cloudprober/cloudprober/config/proto"
 	"github.com/cloudprober/cloudprober/config/runconfig"
+	rdsfile "github.com/cloudprober/cloudprober/internal/rds/file"
 	"github.com/cloudprober/cloudprober/internal/servers"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
 	"github.com/cloudprober/cloudprober/internal/tlsconfig"
@@ -138,6 +139,7 @@ func setDebugHandlers(srvMux *http.ServeMux) {
 	srvMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
 	srvMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 	srvMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srvMux.HandleFunc(rdsfile.SnapshotURL, rdsfile.SnapshotHandler)
 }
 
 // InitFromConfig initializes Cloudprober using the provided config.
@@ -290,6 +292,21 @@ func Start(ctx context.Context) {
 	})
 }
 
+// Shutdown shuts down the running Cloudprober gracefully: it stops the
+// probes after their in-flight runs complete, and flushes the surfacers, all within
+// the context's deadline. It should be called before canceling the context
+// passed to Start. See prober.Shutdown for more details.
+func Shutdown(ctx context.Context) error {
+	cloudProber.RLock()
+	pr := cloudProber.prober
+	cloudProber.RUnlock()
+
+	if pr == nil {
+		return fmt.Errorf("prober is not initialized")
+	}
+	return pr.Shutdown(ctx)
+}
+
 // GetConfig returns the prober config.
 func GetConfig() *configpb.ProberConfig {
 	cloudProber.RLock()
@@ -318,6 +335,19 @@ func GetInfo() (map[string]*probes.ProbeInfo, []*surfacers.SurfacerInfo, []*serv
 	return cloudProber.prober.Probes, cloudProber.prober.Surfacers, cloudProber.prober.Servers
 }
 
+// AddResultSink registers a result sink with the running prober, to receive
+// the probe results programmatically. It returns a function to remove the
+// sink. Cloudprober should be initialized before calling this function.
+// See prober.ResultSink for more details.
+func AddResultSink(sink prober.ResultSink) (remove func(), err error) {
+	cloudProber.RLock()
+	defer cloudProber.RUnlock()
+	if cloudProber.prober == nil {
+		return nil, fmt.Errorf("prober is not initialized")
+	}
+	return cloudProber.prober.AddResultSink(sink), nil
+}
+
 func GetProber() *prober.Prober {
 	cloudProber.RLock()
 	defer cloudProber.RUnlock()
@@ -42,7 +42,7 @@ import (
 var (
 	versionFlag      = flag.Bool("version", false, "Print version and exit")
 	buildInfoFlag    = flag.Bool("buildinfo", false, "Print build info and exit")
-	stopTime         = flag.Duration("stop_time", 0, "How long to wait for cleanup before process exits on SIGINT and SIGTERM")
+	stopTime         = flag.Duration("stop_time", 0, "How long to wait for graceful shutdown before process exits on SIGINT and SIGTERM")
 	cpuprofile       = flag.String("cpuprof", "", "Write cpu profile to file")
 	memprofile       = flag.String("memprof", "", "Write heap profile to file")
 	configTest       = flag.Bool("configtest", false, "Dry run to test config file")
@@ -166,9 +166,17 @@ func main() {
 
 		go func() {
 			sig := <-sigs
-			l.Warningf("Received signal \"%v\", canceling the start context and waiting for %v before closing", sig, *stopTime)
+			l.Warningf("Received signal \"%v\", shutting down gracefully, timeout: %v", sig, *stopTime)
+
+			// Shutdown returns as soon as the in-flight probe runs are
+			// complete and the surfacers are flushed, or when stop_time runs
+			// out.
+			shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), *stopTime)
+			if err := cloudprober.Shutdown(shutdownCtx); err != nil {
+				l.Warningf("Error during graceful shutdown: %v", err)
+			}
+			cancelShutdown()
 			cancelF()
-			time.Sleep(*stopTime)
 			os.Exit(0)
 		}()
 	}
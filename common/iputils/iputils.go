@@ -80,3 +80,14 @@ func ResolveIntfAddr(intfName string, ipVer int) (net.IP, error) {
 	}
 	return nil, fmt.Errorf("resolveIntfAddr(%v, %d) found no apprpriate IP addresses in %v", intfName, ipVer, addrs)
 }
+
+// CheckLocalIP verifies that the given IP address is available on this host,
+// i.e. it can be used as a source address for the outgoing packets. It does
+// so by binding a UDP socket to that address.
+func CheckLocalIP(ip net.IP) error {
+	c, err := net.ListenPacket("udp", net.JoinHostPort(ip.String(), "0"))
+	if err != nil {
+		return fmt.Errorf("source IP %s is not available on this host: %v", ip.String(), err)
+	}
+	return c.Close()
+}
@@ -36,3 +36,14 @@ func TestIPVersion(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckLocalIP(t *testing.T) {
+	if err := CheckLocalIP(net.ParseIP("127.0.0.1")); err != nil {
+		t.Errorf("CheckLocalIP(127.0.0.1): unexpected error: %v", err)
+	}
+	// 192.0.2.0/24 is reserved for documentation (TEST-NET-1), it should not
+	// be configured on any host.
+	if err := CheckLocalIP(net.ParseIP("192.0.2.123")); err == nil {
+		t.Error("CheckLocalIP(192.0.2.123): expected error, got nil")
+	}
+}
@@ -123,14 +123,53 @@ type ProberConfig struct {
 	// tools/cloudprober_startup.sh in the cloudprober directory for an example on
 	// how to use these variables.
 	SysvarsEnvVar *string `protobuf:"bytes,98,opt,name=sysvars_env_var,json=sysvarsEnvVar,def=SYSVARS" json:"sysvars_env_var,omitempty"`
-	// Time between triggering cancelation of various goroutines and exiting the
-	// process. If --stop_time flag is also configured, that gets priority.
+	// Graceful shutdown timeout. On SIGINT or SIGTERM, cloudprober stops
+	// starting new probe runs, waits for the in-flight probe runs to complete,
+	// sends the pending metrics to the surfacers and flushes the surfacers that
+	// batch metrics (e.g. cloudwatch, datadog, otel), before exiting the process.
+	// Process exits as soon as that's done, or when this timeout expires. If
+	// --stop_time flag is also configured, that gets priority.
 	// You may want to set it to 0 if cloudprober is running as a backend for
-	// the probes and you don't want time lost in stop and start.
+	// the probes and you don't want time lost in stop and start. With 0,
+	// process exits immediately on signals.
 	StopTimeSec *int32 `protobuf:"varint,99,opt,name=stop_time_sec,json=stopTimeSec,def=5" json:"stop_time_sec,omitempty"`
 	// Global targets options. Per-probe options are specified within the probe
 	// stanza.
 	GlobalTargetsOptions *proto5.GlobalTargetsOptions `protobuf:"bytes,100,opt,name=global_targets_options,json=globalTargetsOptions" json:"global_targets_options,omitempty"`
+	// Maximum number of requests per second across all probes. This limit is
+	// shared by all the active probes, i.e. all probe types except UDP_LISTENER
+	// (see max_requests_per_sec in the probe config).
+	GlobalMaxRequestsPerSec *float32 `protobuf:"fixed32,106,opt,name=global_max_requests_per_sec,json=globalMaxRequestsPerSec" json:"global_max_requests_per_sec,omitempty"`
+	// If set, log a warning for the EventMetrics that are not selected by any
+	// surfacer (based on the surfacers' label filters and label selectors).
+	// Internal surfacers, e.g. probestatus, are not considered for this check.
+	WarnOnUnroutedMetrics *bool `protobuf:"varint,107,opt,name=warn_on_unrouted_metrics,json=warnOnUnroutedMetrics" json:"warn_on_unrouted_metrics,omitempty"`
+	// Default labels to add to all the metrics, e.g. to identify this
+	// cloudprober instance. Labels are added just before metrics are sent to
+	// the surfacers. Labels set by probes (including additional_label) with
+	// the same keys take precedence.
+	// Example:
+	//
+	//	default_labels {
+	//	  key: "datacenter"
+	//	  value: "us-east1"
+	//	}
+	DefaultLabels map[string]string `protobuf:"bytes,108,rep,name=default_labels,json=defaultLabels" json:"default_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Vantage point (source) identity of this cloudprober instance, e.g. the
+	// region it's running in. If set, it's added to all the metrics as a label
+	// with the key vantage_label_key. This makes it possible to run cloudprober
+	// in multiple locations, probing the same targets, and compare the results
+	// by source in a central monitoring system, e.g. in Prometheus:
+	//
+	//	sum by (vantage, dst) (rate(success[5m]))
+	//	  / sum by (vantage, dst) (rate(total[5m]))
+	//
+	// To avoid clashes, probes' additional labels and default_labels cannot
+	// use the vantage label key.
+	Vantage         *string `protobuf:"bytes,109,opt,name=vantage" json:"vantage,omitempty"`
+	VantageLabelKey *string `protobuf:"bytes,110,opt,name=vantage_label_key,json=vantageLabelKey,def=vantage" json:"vantage_label_key,omitempty"`
+	// Watchdog to monitor cloudprober's own health. See Watchdog below.
+	Watchdog *Watchdog `protobuf:"bytes,111,opt,name=watchdog" json:"watchdog,omitempty"`
 }
 
 // Default values for ProberConfig fields.
@@ -139,6 +178,7 @@ const (
 	Default_ProberConfig_SysvarsIntervalMsec = int32(10000)
 	Default_ProberConfig_SysvarsEnvVar       = string("SYSVARS")
 	Default_ProberConfig_StopTimeSec         = int32(5)
+	Default_ProberConfig_VantageLabelKey     = string("vantage")
 )
 
 func (x *ProberConfig) Reset() {
@@ -271,6 +311,152 @@ func (x *ProberConfig) GetGlobalTargetsOptions() *proto5.GlobalTargetsOptions {
 	return nil
 }
 
+func (x *ProberConfig) GetGlobalMaxRequestsPerSec() float32 {
+	if x != nil && x.GlobalMaxRequestsPerSec != nil {
+		return *x.GlobalMaxRequestsPerSec
+	}
+	return 0
+}
+
+func (x *ProberConfig) GetWarnOnUnroutedMetrics() bool {
+	if x != nil && x.WarnOnUnroutedMetrics != nil {
+		return *x.WarnOnUnroutedMetrics
+	}
+	return false
+}
+
+func (x *ProberConfig) GetDefaultLabels() map[string]string {
+	if x != nil {
+		return x.DefaultLabels
+	}
+	return nil
+}
+
+func (x *ProberConfig) GetVantage() string {
+	if x != nil && x.Vantage != nil {
+		return *x.Vantage
+	}
+	return ""
+}
+
+func (x *ProberConfig) GetVantageLabelKey() string {
+	if x != nil && x.VantageLabelKey != nil {
+		return *x.VantageLabelKey
+	}
+	return Default_ProberConfig_VantageLabelKey
+}
+
+func (x *ProberConfig) GetWatchdog() *Watchdog {
+	if x != nil {
+		return x.Watchdog
+	}
+	return nil
+}
+
+// Watchdog periodically emits a heartbeat through the metrics pipeline and
+// checks that cloudprober is making progress: that the metrics pipeline
+// (which carries the metrics from the probes to the surfacers) keeps
+// processing metrics and, optionally, that the probes keep exporting
+// metrics. Watchdog exports the following metrics, with labels
+// ptype="sysvars" and probe="watchdog":
+//
+//	watchdog_heartbeat: number of heartbeats emitted so far.
+//	watchdog_stalls:    number of checks that found a component stalled, by
+//	                    component: "pipeline" or the probe name.
+//
+// Stalls are logged as warnings. If exit_on_stall is set, cloudprober exits
+// instead, so that the orchestrator (e.g. Kubernetes) can restart it.
+//
+// To check the serving path as well, configure an HTTP probe for the
+// cloudprober's own /status endpoint.
+type Watchdog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often to emit the heartbeat and run the checks.
+	IntervalSec *int32 `protobuf:"varint,1,opt,name=interval_sec,json=intervalSec,def=30" json:"interval_sec,omitempty"`
+	// Metrics pipeline is considered stalled if it hasn't processed any
+	// metrics, including the heartbeat, for this long. It should be larger
+	// than interval_sec.
+	StallThresholdSec *int32 `protobuf:"varint,2,opt,name=stall_threshold_sec,json=stallThresholdSec,def=300" json:"stall_threshold_sec,omitempty"`
+	// If set, a probe is considered stalled if it hasn't exported any metrics
+	// for this long, e.g. because its goroutine is stuck. It should be larger
+	// than the probes' stats export interval, and probes that may not export
+	// metrics for a while (e.g. scheduled probes or probes without targets)
+	// will show up as stalled during that time. Probes are not checked by
+	// default.
+	ProbeStallThresholdSec *int32 `protobuf:"varint,3,opt,name=probe_stall_threshold_sec,json=probeStallThresholdSec" json:"probe_stall_threshold_sec,omitempty"`
+	// Exit if a stall is detected.
+	ExitOnStall *bool `protobuf:"varint,4,opt,name=exit_on_stall,json=exitOnStall" json:"exit_on_stall,omitempty"`
+}
+
+// Default values for Watchdog fields.
+const (
+	Default_Watchdog_IntervalSec       = int32(30)
+	Default_Watchdog_StallThresholdSec = int32(300)
+)
+
+func (x *Watchdog) Reset() {
+	*x = Watchdog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Watchdog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Watchdog) ProtoMessage() {}
+
+func (x *Watchdog) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Watchdog.ProtoReflect.Descriptor instead.
+func (*Watchdog) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Watchdog) GetIntervalSec() int32 {
+	if x != nil && x.IntervalSec != nil {
+		return *x.IntervalSec
+	}
+	return Default_Watchdog_IntervalSec
+}
+
+func (x *Watchdog) GetStallThresholdSec() int32 {
+	if x != nil && x.StallThresholdSec != nil {
+		return *x.StallThresholdSec
+	}
+	return Default_Watchdog_StallThresholdSec
+}
+
+func (x *Watchdog) GetProbeStallThresholdSec() int32 {
+	if x != nil && x.ProbeStallThresholdSec != nil {
+		return *x.ProbeStallThresholdSec
+	}
+	return 0
+}
+
+func (x *Watchdog) GetExitOnStall() bool {
+	if x != nil && x.ExitOnStall != nil {
+		return *x.ExitOnStall
+	}
+	return false
+}
+
 type SharedTargets struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -283,7 +469,7 @@ type SharedTargets struct {
 func (x *SharedTargets) Reset() {
 	*x = SharedTargets{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[1]
+		mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -296,7 +482,7 @@ func (x *SharedTargets) String() string {
 func (*SharedTargets) ProtoMessage() {}
 
 func (x *SharedTargets) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[1]
+	mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -309,7 +495,7 @@ func (x *SharedTargets) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SharedTargets.ProtoReflect.Descriptor instead.
 func (*SharedTargets) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZIP(), []int{1}
+	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SharedTargets) GetName() string {
@@ -338,7 +524,7 @@ type SurfacersConfig struct {
 func (x *SurfacersConfig) Reset() {
 	*x = SurfacersConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[2]
+		mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -351,7 +537,7 @@ func (x *SurfacersConfig) String() string {
 func (*SurfacersConfig) ProtoMessage() {}
 
 func (x *SurfacersConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[2]
+	mi := &file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +550,7 @@ func (x *SurfacersConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurfacersConfig.ProtoReflect.Descriptor instead.
 func (*SurfacersConfig) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZIP(), []int{2}
+	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *SurfacersConfig) GetSurfacer() []*proto1.SurfacerDef {
@@ -407,7 +593,7 @@ var file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDesc =
 	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
 	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67,
 	0x65, 0x74, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x05, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x62,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf9, 0x08, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x62,
 	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x32, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x62,
 	0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
 	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f,
@@ -454,21 +640,58 @@ var file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDesc =
 	0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e, 0x47, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x54,
 	0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x14, 0x67,
 	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x5e, 0x0a, 0x0d, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e,
-	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x44, 0x65, 0x66, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x73, 0x22, 0x50, 0x0a, 0x0f, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x08, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
-	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x44, 0x65, 0x66, 0x52, 0x08, 0x73, 0x75, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x72, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x6f, 0x6e, 0x73, 0x12, 0x3c, 0x0a, 0x1b, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73,
+	0x65, 0x63, 0x18, 0x6a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x17, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c,
+	0x4d, 0x61, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x12, 0x37, 0x0a, 0x18, 0x77, 0x61, 0x72, 0x6e, 0x5f, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x6b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x15, 0x77, 0x61, 0x72, 0x6e, 0x4f, 0x6e, 0x55, 0x6e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x64, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x53, 0x0a, 0x0e, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x6c, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x61, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x6d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x61, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x33, 0x0a, 0x11, 0x76, 0x61, 0x6e,
+	0x74, 0x61, 0x67, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x6e,
+	0x20, 0x01, 0x28, 0x09, 0x3a, 0x07, 0x76, 0x61, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x52, 0x0f, 0x76,
+	0x61, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x31,
+	0x0a, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x18, 0x6f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x52, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f,
+	0x67, 0x1a, 0x40, 0x0a, 0x12, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xc5, 0x01, 0x0a, 0x08, 0x57, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67,
+	0x12, 0x25, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33, 0x30, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x33, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x11, 0x73, 0x74, 0x61, 0x6c, 0x6c,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x53, 0x65, 0x63, 0x12, 0x39, 0x0a, 0x19,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x16, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x53, 0x74, 0x61, 0x6c, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x6f, 0x6c, 0x64, 0x53, 0x65, 0x63, 0x12, 0x22, 0x0a, 0x0d, 0x65, 0x78, 0x69, 0x74, 0x5f,
+	0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x65, 0x78, 0x69, 0x74, 0x4f, 0x6e, 0x53, 0x74, 0x61, 0x6c, 0x6c, 0x22, 0x5e, 0x0a, 0x0d, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x39, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x02, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x44,
+	0x65, 0x66, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x22, 0x50, 0x0a, 0x0f, 0x53,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d,
+	0x0a, 0x08, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x44, 0x65, 0x66, 0x52, 0x08, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x42, 0x31, 0x5a,
+	0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -483,34 +706,38 @@ func file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescGZ
 	return file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_github_com_cloudprober_cloudprober_config_proto_config_proto_goTypes = []any{
 	(*ProberConfig)(nil),                // 0: cloudprober.ProberConfig
-	(*SharedTargets)(nil),               // 1: cloudprober.SharedTargets
-	(*SurfacersConfig)(nil),             // 2: cloudprober.SurfacersConfig
-	(*proto.ProbeDef)(nil),              // 3: cloudprober.probes.ProbeDef
-	(*proto1.SurfacerDef)(nil),          // 4: cloudprober.surfacer.SurfacerDef
-	(*proto2.ServerDef)(nil),            // 5: cloudprober.servers.ServerDef
-	(*proto3.ServerConf)(nil),           // 6: cloudprober.rds.ServerConf
-	(*proto4.TLSConfig)(nil),            // 7: cloudprober.tlsconfig.TLSConfig
-	(*proto5.GlobalTargetsOptions)(nil), // 8: cloudprober.targets.GlobalTargetsOptions
-	(*proto5.TargetsDef)(nil),           // 9: cloudprober.targets.TargetsDef
+	(*Watchdog)(nil),                    // 1: cloudprober.Watchdog
+	(*SharedTargets)(nil),               // 2: cloudprober.SharedTargets
+	(*SurfacersConfig)(nil),             // 3: cloudprober.SurfacersConfig
+	nil,                                 // 4: cloudprober.ProberConfig.DefaultLabelsEntry
+	(*proto.ProbeDef)(nil),              // 5: cloudprober.probes.ProbeDef
+	(*proto1.SurfacerDef)(nil),          // 6: cloudprober.surfacer.SurfacerDef
+	(*proto2.ServerDef)(nil),            // 7: cloudprober.servers.ServerDef
+	(*proto3.ServerConf)(nil),           // 8: cloudprober.rds.ServerConf
+	(*proto4.TLSConfig)(nil),            // 9: cloudprober.tlsconfig.TLSConfig
+	(*proto5.GlobalTargetsOptions)(nil), // 10: cloudprober.targets.GlobalTargetsOptions
+	(*proto5.TargetsDef)(nil),           // 11: cloudprober.targets.TargetsDef
 }
 var file_github_com_cloudprober_cloudprober_config_proto_config_proto_depIdxs = []int32{
-	3, // 0: cloudprober.ProberConfig.probe:type_name -> cloudprober.probes.ProbeDef
-	4, // 1: cloudprober.ProberConfig.surfacer:type_name -> cloudprober.surfacer.SurfacerDef
-	5, // 2: cloudprober.ProberConfig.server:type_name -> cloudprober.servers.ServerDef
-	1, // 3: cloudprober.ProberConfig.shared_targets:type_name -> cloudprober.SharedTargets
-	6, // 4: cloudprober.ProberConfig.rds_server:type_name -> cloudprober.rds.ServerConf
-	7, // 5: cloudprober.ProberConfig.grpc_tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
-	8, // 6: cloudprober.ProberConfig.global_targets_options:type_name -> cloudprober.targets.GlobalTargetsOptions
-	9, // 7: cloudprober.SharedTargets.targets:type_name -> cloudprober.targets.TargetsDef
-	4, // 8: cloudprober.SurfacersConfig.surfacer:type_name -> cloudprober.surfacer.SurfacerDef
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+	5,  // 0: cloudprober.ProberConfig.probe:type_name -> cloudprober.probes.ProbeDef
+	6,  // 1: cloudprober.ProberConfig.surfacer:type_name -> cloudprober.surfacer.SurfacerDef
+	7,  // 2: cloudprober.ProberConfig.server:type_name -> cloudprober.servers.ServerDef
+	2,  // 3: cloudprober.ProberConfig.shared_targets:type_name -> cloudprober.SharedTargets
+	8,  // 4: cloudprober.ProberConfig.rds_server:type_name -> cloudprober.rds.ServerConf
+	9,  // 5: cloudprober.ProberConfig.grpc_tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	10, // 6: cloudprober.ProberConfig.global_targets_options:type_name -> cloudprober.targets.GlobalTargetsOptions
+	4,  // 7: cloudprober.ProberConfig.default_labels:type_name -> cloudprober.ProberConfig.DefaultLabelsEntry
+	1,  // 8: cloudprober.ProberConfig.watchdog:type_name -> cloudprober.Watchdog
+	11, // 9: cloudprober.SharedTargets.targets:type_name -> cloudprober.targets.TargetsDef
+	6,  // 10: cloudprober.SurfacersConfig.surfacer:type_name -> cloudprober.surfacer.SurfacerDef
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_config_proto_config_proto_init() }
@@ -532,7 +759,7 @@ func file_github_com_cloudprober_cloudprober_config_proto_config_proto_init() {
 			}
 		}
 		file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*SharedTargets); i {
+			switch v := v.(*Watchdog); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -544,6 +771,18 @@ func file_github_com_cloudprober_cloudprober_config_proto_config_proto_init() {
 			}
 		}
 		file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SharedTargets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_config_proto_config_proto_msgTypes[3].Exporter = func(v any, i int) any {
 			switch v := v.(*SurfacersConfig); i {
 			case 0:
 				return &v.state
@@ -562,7 +801,7 @@ func file_github_com_cloudprober_cloudprober_config_proto_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_config_proto_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
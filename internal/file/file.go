@@ -42,6 +42,7 @@ var global = struct {
 
 type readFunc func(ctx context.Context, path string) ([]byte, error)
 type modTimeFunc func(ctx context.Context, path string) (time.Time, error)
+type versionFunc func(ctx context.Context, path string) (string, error)
 
 var zeroTime = time.Time{}
 
@@ -59,6 +60,13 @@ var prefixToModTimeFunc = map[string]modTimeFunc{
 	"https://": httpModTime,
 }
 
+// prefixToVersionFunc maps object store prefixes to functions that return
+// an opaque version of the object, e.g. generation for GCS and ETag for S3.
+var prefixToVersionFunc = map[string]versionFunc{
+	"gs://": gcsVersion,
+	"s3://": s3Version,
+}
+
 func parseObjectURL(objectPath string) (bucket, object string, err error) {
 	parts := strings.SplitN(objectPath, "/", 2)
 	if len(parts) != 2 {
@@ -157,3 +165,16 @@ func ModTime(ctx context.Context, fname string) (time.Time, error) {
 	}
 	return statInfo.ModTime(), nil
 }
+
+// Version returns an opaque version string for the given file. It's currently
+// supported only for GCS (object generation) and S3 (object ETag). For other
+// files, it returns an empty string, and callers are expected to rely on
+// ModTime instead.
+func Version(ctx context.Context, fname string) (string, error) {
+	for prefix, f := range prefixToVersionFunc {
+		if strings.HasPrefix(fname, prefix) {
+			return f(ctx, fname[len(prefix):])
+		}
+	}
+	return "", nil
+}
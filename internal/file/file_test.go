@@ -108,3 +108,19 @@ func TestReadWithCache(t *testing.T) {
 	time.Sleep(time.Second)
 	readAndVerify(testContent+"-updated-2", 1*time.Second)
 }
+
+func TestVersion(t *testing.T) {
+	prefixToVersionFunc["test://"] = func(ctx context.Context, path string) (string, error) {
+		return "v1-" + path, nil
+	}
+	defer delete(prefixToVersionFunc, "test://")
+
+	v, err := Version(context.Background(), "test://test-file")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1-test-file", v)
+
+	// Local files don't have a version.
+	v, err = Version(context.Background(), createTempFile(t, []byte("content")))
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+}
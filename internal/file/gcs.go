@@ -69,3 +69,19 @@ func gcsModTime(ctx context.Context, objectPath string) (time.Time, error) {
 	defer res.Body.Close()
 	return httpLastModified(res)
 }
+
+// gcsVersion returns the generation of the GCS object. Generation changes
+// every time object's content is overwritten.
+func gcsVersion(ctx context.Context, objectPath string) (string, error) {
+	res, err := gcsRequest(ctx, "HEAD", objectPath)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	generation := res.Header.Get("x-goog-generation")
+	if generation == "" {
+		return "", fmt.Errorf("no generation header in GCS response for: %s", objectPath)
+	}
+	return generation, nil
+}
@@ -77,3 +77,28 @@ func s3ModTime(ctx context.Context, objectPath string) (time.Time, error) {
 
 	return *result.LastModified, nil
 }
+
+// s3Version returns the ETag of the S3 object. ETag changes every time
+// object's content changes.
+func s3Version(ctx context.Context, objectPath string) (string, error) {
+	bucket, object, err := parseObjectURL(objectPath)
+	if err != nil {
+		return "", err
+	}
+
+	sdkConfig, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load default config: %v", err)
+	}
+	s3Client := s3.NewFromConfig(sdkConfig)
+
+	result, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(object),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve file (%s): %v", objectPath, err)
+	}
+
+	return aws.ToString(result.ETag), nil
+}
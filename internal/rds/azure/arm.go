@@ -0,0 +1,213 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+)
+
+// armClients holds the Azure Resource Manager (ARM) API clients for a
+// subscription. Clients retry the failed requests as per the SDK's retry
+// policy.
+type armClients struct {
+	vms       *armcompute.VirtualMachinesClient
+	scaleSets *armcompute.VirtualMachineScaleSetsClient
+	ssVMs     *armcompute.VirtualMachineScaleSetVMsClient
+	nics      *armnetwork.InterfacesClient
+	publicIPs *armnetwork.PublicIPAddressesClient
+}
+
+func newARMClients(sub string, cred azcore.TokenCredential, opts *arm.ClientOptions) (*armClients, error) {
+	var ac armClients
+	var err error
+	if ac.vms, err = armcompute.NewVirtualMachinesClient(sub, cred, opts); err != nil {
+		return nil, err
+	}
+	if ac.scaleSets, err = armcompute.NewVirtualMachineScaleSetsClient(sub, cred, opts); err != nil {
+		return nil, err
+	}
+	if ac.ssVMs, err = armcompute.NewVirtualMachineScaleSetVMsClient(sub, cred, opts); err != nil {
+		return nil, err
+	}
+	if ac.nics, err = armnetwork.NewInterfacesClient(sub, cred, opts); err != nil {
+		return nil, err
+	}
+	if ac.publicIPs, err = armnetwork.NewPublicIPAddressesClient(sub, cred, opts); err != nil {
+		return nil, err
+	}
+	return &ac, nil
+}
+
+// listAll returns all the items from the pager, fetching all the pages.
+func listAll[R, T any](ctx context.Context, pager *runtime.Pager[R], value func(R) []*T) ([]*T, error) {
+	var items []*T
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value(page)...)
+	}
+	return items, nil
+}
+
+// scope is a subscription, or a resource group if set, to list the resources
+// in.
+type scope struct {
+	subscription, resourceGroup string
+}
+
+func (s scope) String() string {
+	if s.resourceGroup == "" {
+		return "subscription " + s.subscription
+	}
+	return "subscription " + s.subscription + ", resource group " + s.resourceGroup
+}
+
+// scopes returns the subscriptions, or the resource groups if configured, to
+// list the resources in.
+func (p *Provider) scopes() []scope {
+	var scopes []scope
+	for _, sub := range p.c.GetSubscriptionId() {
+		if len(p.c.GetResourceGroup()) == 0 {
+			scopes = append(scopes, scope{subscription: sub})
+			continue
+		}
+		for _, rg := range p.c.GetResourceGroup() {
+			scopes = append(scopes, scope{subscription: sub, resourceGroup: rg})
+		}
+	}
+	return scopes
+}
+
+// listVMs lists the VMs in the scope.
+func (ac *armClients) listVMs(ctx context.Context, s scope) ([]*armcompute.VirtualMachine, error) {
+	if s.resourceGroup == "" {
+		return listAll(ctx, ac.vms.NewListAllPager(nil), func(r armcompute.VirtualMachinesClientListAllResponse) []*armcompute.VirtualMachine { return r.Value })
+	}
+	return listAll(ctx, ac.vms.NewListPager(s.resourceGroup, nil), func(r armcompute.VirtualMachinesClientListResponse) []*armcompute.VirtualMachine { return r.Value })
+}
+
+// listScaleSets lists the scale sets in the scope.
+func (ac *armClients) listScaleSets(ctx context.Context, s scope) ([]*armcompute.VirtualMachineScaleSet, error) {
+	if s.resourceGroup == "" {
+		return listAll(ctx, ac.scaleSets.NewListAllPager(nil), func(r armcompute.VirtualMachineScaleSetsClientListAllResponse) []*armcompute.VirtualMachineScaleSet {
+			return r.Value
+		})
+	}
+	return listAll(ctx, ac.scaleSets.NewListPager(s.resourceGroup, nil), func(r armcompute.VirtualMachineScaleSetsClientListResponse) []*armcompute.VirtualMachineScaleSet {
+		return r.Value
+	})
+}
+
+// listScaleSetVMs lists the instances of a scale set.
+func (ac *armClients) listScaleSetVMs(ctx context.Context, rg, ssName string) ([]*armcompute.VirtualMachineScaleSetVM, error) {
+	return listAll(ctx, ac.ssVMs.NewListPager(rg, ssName, nil), func(r armcompute.VirtualMachineScaleSetVMsClientListResponse) []*armcompute.VirtualMachineScaleSetVM {
+		return r.Value
+	})
+}
+
+// listNetwork lists the network interfaces and the public IP addresses in the
+// scope, or of the scale set if ssName is set, and returns them as maps keyed
+// by their normalized IDs.
+func (ac *armClients) listNetwork(ctx context.Context, s scope, ssName string) (map[string]*armnetwork.Interface, map[string]string, error) {
+	var nicList []*armnetwork.Interface
+	var ipList []*armnetwork.PublicIPAddress
+	var err error
+
+	switch {
+	case ssName != "":
+		nicList, err = listAll(ctx, ac.nics.NewListVirtualMachineScaleSetNetworkInterfacesPager(s.resourceGroup, ssName, nil), func(r armnetwork.InterfacesClientListVirtualMachineScaleSetNetworkInterfacesResponse) []*armnetwork.Interface {
+			return r.Value
+		})
+		if err == nil {
+			ipList, err = listAll(ctx, ac.publicIPs.NewListVirtualMachineScaleSetPublicIPAddressesPager(s.resourceGroup, ssName, nil), func(r armnetwork.PublicIPAddressesClientListVirtualMachineScaleSetPublicIPAddressesResponse) []*armnetwork.PublicIPAddress {
+				return r.Value
+			})
+		}
+	case s.resourceGroup == "":
+		nicList, err = listAll(ctx, ac.nics.NewListAllPager(nil), func(r armnetwork.InterfacesClientListAllResponse) []*armnetwork.Interface { return r.Value })
+		if err == nil {
+			ipList, err = listAll(ctx, ac.publicIPs.NewListAllPager(nil), func(r armnetwork.PublicIPAddressesClientListAllResponse) []*armnetwork.PublicIPAddress {
+				return r.Value
+			})
+		}
+	default:
+		nicList, err = listAll(ctx, ac.nics.NewListPager(s.resourceGroup, nil), func(r armnetwork.InterfacesClientListResponse) []*armnetwork.Interface { return r.Value })
+		if err == nil {
+			ipList, err = listAll(ctx, ac.publicIPs.NewListPager(s.resourceGroup, nil), func(r armnetwork.PublicIPAddressesClientListResponse) []*armnetwork.PublicIPAddress { return r.Value })
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nics := make(map[string]*armnetwork.Interface, len(nicList))
+	for _, nic := range nicList {
+		nics[idKey(val(nic.ID))] = nic
+	}
+	publicIPs := make(map[string]string, len(ipList))
+	for _, ip := range ipList {
+		if ip.Properties != nil {
+			publicIPs[idKey(val(ip.ID))] = val(ip.Properties.IPAddress)
+		}
+	}
+	return nics, publicIPs, nil
+}
+
+// val returns the value pointed to by p, or the zero value if p is nil. ARM
+// models use pointers for all the fields.
+func val[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// tags converts ARM tags to a map of strings.
+func tags(t map[string]*string) map[string]string {
+	m := make(map[string]string, len(t))
+	for k, v := range t {
+		m[k] = val(v)
+	}
+	return m
+}
+
+// idKey normalizes a resource ID for lookups, as ARM resource IDs are case
+// insensitive and their casing is not consistent across the APIs.
+func idKey(id string) string {
+	return strings.ToLower(id)
+}
+
+// idPart returns the value for the given key in a resource ID, e.g. for
+// "resourceGroups" in "/subscriptions/s1/resourceGroups/rg1/providers/...",
+// it returns "rg1".
+func idPart(id, key string) string {
+	parts := strings.Split(id, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if strings.EqualFold(parts[i], key) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
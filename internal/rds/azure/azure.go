@@ -0,0 +1,399 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package azure implements an Azure based targets provider for cloudprober. It
+discovers VMs and scale set instances using the Azure Resource Manager API.
+*/
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v4"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/azure/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/common/cache"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "azure"
+
+/*
+SupportedFilters defines filters supported by the Azure-based resources
+type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.env"
+		 value: "prod"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+// instance is a VM or a scale set instance, with its IP addresses. Resource
+// IP is set at the time of listing, as per the requested IP config.
+type instance struct {
+	res                              *pb.Resource
+	privateIP, privateIPv6, publicIP string
+}
+
+// Provider provides an Azure-based targets provider for RDS. It implements
+// the RDS server's Provider interface.
+type Provider struct {
+	c       *configpb.ProviderConfig
+	clients map[string]*armClients // By subscription.
+	l       *logger.Logger
+
+	mu          sync.RWMutex
+	instances   []*instance
+	lastUpdated time.Time
+}
+
+// tagsMatch tells whether the given tags match the configured tag filter.
+func (p *Provider) tagsMatch(tags map[string]string) bool {
+	for k, v := range p.c.GetTag() {
+		tv, ok := tags[k]
+		if !ok || (v != "" && tv != v) {
+			return false
+		}
+	}
+	return true
+}
+
+// newInstance builds an instance from a VM or a scale set instance, using its
+// primary network interface's IP addresses.
+func newInstance(id, name, location string, nicRefs []*armcompute.NetworkInterfaceReference, ssName string, tags map[string]string, nics map[string]*armnetwork.Interface, publicIPs map[string]string) *instance {
+	labels := map[string]string{
+		"subscription":   idPart(id, "subscriptions"),
+		"resource_group": idPart(id, "resourceGroups"),
+		"location":       location,
+	}
+	if ssName != "" {
+		labels["scale_set"] = ssName
+	}
+	for k, v := range tags {
+		labels[k] = v
+	}
+
+	inst := &instance{
+		res: &pb.Resource{
+			Name:   proto.String(name),
+			Id:     proto.String(id),
+			Labels: labels,
+		},
+	}
+
+	var nic *armnetwork.Interface
+	for i, ref := range nicRefs {
+		if i == 0 || (ref.Properties != nil && val(ref.Properties.Primary)) {
+			nic = nics[idKey(val(ref.ID))]
+		}
+	}
+	if nic == nil || nic.Properties == nil {
+		return inst
+	}
+
+	for _, ipc := range nic.Properties.IPConfigurations {
+		props := ipc.Properties
+		if props == nil {
+			continue
+		}
+		if val(props.PrivateIPAddressVersion) == armnetwork.IPVersionIPv6 {
+			if inst.privateIPv6 == "" {
+				inst.privateIPv6 = val(props.PrivateIPAddress)
+			}
+			continue
+		}
+		if inst.privateIP == "" || val(props.Primary) {
+			inst.privateIP = val(props.PrivateIPAddress)
+			inst.publicIP = ""
+			if props.PublicIPAddress != nil {
+				inst.publicIP = publicIPs[idKey(val(props.PublicIPAddress.ID))]
+			}
+		}
+	}
+	return inst
+}
+
+// networkInterfaces returns the network interface references from a VM's
+// network profile.
+func networkInterfaces(np *armcompute.NetworkProfile) []*armcompute.NetworkInterfaceReference {
+	if np == nil {
+		return nil
+	}
+	return np.NetworkInterfaces
+}
+
+// listInstances lists the VMs and the scale set instances in all the scopes.
+func (p *Provider) listInstances(ctx context.Context) ([]*instance, error) {
+	var instances []*instance
+
+	for _, s := range p.scopes() {
+		ac := p.clients[s.subscription]
+
+		if p.c.GetIncludeVms() {
+			vms, err := ac.listVMs(ctx, s)
+			if err != nil {
+				return nil, fmt.Errorf("error listing VMs in %s: %v", s, err)
+			}
+			nics, publicIPs, err := ac.listNetwork(ctx, s, "")
+			if err != nil {
+				return nil, fmt.Errorf("error listing network resources in %s: %v", s, err)
+			}
+
+			for _, vm := range vms {
+				vmTags := tags(vm.Tags)
+				if !p.tagsMatch(vmTags) {
+					continue
+				}
+				var ssName string
+				var nicRefs []*armcompute.NetworkInterfaceReference
+				if props := vm.Properties; props != nil {
+					if props.VirtualMachineScaleSet != nil {
+						ssName = idPart(val(props.VirtualMachineScaleSet.ID), "virtualMachineScaleSets")
+					}
+					nicRefs = networkInterfaces(props.NetworkProfile)
+				}
+				instances = append(instances, newInstance(val(vm.ID), val(vm.Name), val(vm.Location), nicRefs, ssName, vmTags, nics, publicIPs))
+			}
+		}
+
+		if p.c.GetIncludeScaleSets() {
+			sets, err := ac.listScaleSets(ctx, s)
+			if err != nil {
+				return nil, fmt.Errorf("error listing scale sets in %s: %v", s, err)
+			}
+
+			for _, ss := range sets {
+				ssName, ssTags := val(ss.Name), tags(ss.Tags)
+				// Flexible scale set instances are regular VMs, and are
+				// listed along with them.
+				if ss.Properties != nil && val(ss.Properties.OrchestrationMode) == armcompute.OrchestrationModeFlexible {
+					continue
+				}
+				if !p.tagsMatch(ssTags) {
+					continue
+				}
+
+				ssScope := scope{subscription: s.subscription, resourceGroup: idPart(val(ss.ID), "resourceGroups")}
+				vms, err := ac.listScaleSetVMs(ctx, ssScope.resourceGroup, ssName)
+				if err != nil {
+					return nil, fmt.Errorf("error listing instances of scale set %s: %v", ssName, err)
+				}
+				nics, publicIPs, err := ac.listNetwork(ctx, ssScope, ssName)
+				if err != nil {
+					return nil, fmt.Errorf("error listing network resources of scale set %s: %v", ssName, err)
+				}
+
+				for _, vm := range vms {
+					// Instances inherit the scale set's tags.
+					instTags := make(map[string]string)
+					for k, v := range ssTags {
+						instTags[k] = v
+					}
+					for k, v := range tags(vm.Tags) {
+						instTags[k] = v
+					}
+					var nicRefs []*armcompute.NetworkInterfaceReference
+					if vm.Properties != nil {
+						nicRefs = networkInterfaces(vm.Properties.NetworkProfile)
+					}
+					instances = append(instances, newInstance(val(vm.ID), val(vm.Name), val(vm.Location), nicRefs, ssName, instTags, nics, publicIPs))
+				}
+			}
+		}
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].res.GetName() != instances[j].res.GetName() {
+			return instances[i].res.GetName() < instances[j].res.GetName()
+		}
+		return instances[i].res.GetId() < instances[j].res.GetId()
+	})
+	return instances, nil
+}
+
+// refresh refreshes the instances. On error, last known good instances are
+// retained.
+func (p *Provider) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(p.c.GetTimeoutMsec())*time.Millisecond)
+	defer cancel()
+
+	instances, err := p.listInstances(ctx)
+	if err != nil {
+		return fmt.Errorf("azure: error refreshing resources, keeping last known good: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !instancesEqual(p.instances, instances) || p.lastUpdated.IsZero() {
+		p.instances = instances
+		p.lastUpdated = time.Now()
+	}
+	return nil
+}
+
+func instancesEqual(a, b []*instance) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].privateIP != b[i].privateIP || a[i].privateIPv6 != b[i].privateIPv6 || a[i].publicIP != b[i].publicIP || !proto.Equal(a[i].res, b[i].res) {
+			return false
+		}
+	}
+	return true
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	ipConfig := req.GetIpConfig()
+	if ipConfig.GetIpType() == pb.IPConfig_ALIAS {
+		return nil, errors.New("azure: ip_type ALIAS is not supported")
+	}
+	if ipConfig.GetNicIndex() != 0 {
+		return nil, errors.New("azure: only the primary network interface (nic_index: 0) is supported")
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	lastModified := proto.Int64(p.lastUpdated.Unix())
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated.Unix() <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: lastModified}, nil
+	}
+
+	match, err := cache.Matcher(req.GetFilter(), SupportedFilters.RegexFilterKeys, p.l)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for _, inst := range p.instances {
+		if !match(inst.res) {
+			continue
+		}
+
+		ip := inst.privateIP
+		switch {
+		case ipConfig.GetIpType() == pb.IPConfig_PUBLIC:
+			ip = inst.publicIP
+		case ipConfig.GetIpVersion() == pb.IPConfig_IPV6:
+			ip = inst.privateIPv6
+		}
+		if ip == "" {
+			p.l.Debugf("azure: skipping %s, no IP address of the requested type", inst.res.GetName())
+			continue
+		}
+
+		res := proto.Clone(inst.res).(*pb.Resource)
+		res.Ip = proto.String(ip)
+		resources = append(resources, res)
+	}
+
+	p.l.Infof("azure.ListResources: returning %d resources out of %d", len(resources), len(p.instances))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: lastModified,
+	}, nil
+}
+
+func newProvider(c *configpb.ProviderConfig, cred azcore.TokenCredential, opts *arm.ClientOptions, l *logger.Logger) (*Provider, error) {
+	if len(c.GetSubscriptionId()) == 0 {
+		return nil, errors.New("azure: at least one subscription_id is required")
+	}
+	if !c.GetIncludeVms() && !c.GetIncludeScaleSets() {
+		return nil, errors.New("azure: at least one of include_vms and include_scale_sets should be true")
+	}
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("azure: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	u, err := url.Parse(c.GetManagementEndpoint())
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("azure: invalid management_endpoint: %s", c.GetManagementEndpoint())
+	}
+
+	if opts == nil {
+		opts = &arm.ClientOptions{}
+	}
+	endpoint := strings.TrimSuffix(c.GetManagementEndpoint(), "/")
+	opts.Cloud = cloud.Configuration{
+		Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+			cloud.ResourceManager: {Endpoint: endpoint, Audience: endpoint},
+		},
+	}
+
+	p := &Provider{
+		c:       c,
+		clients: make(map[string]*armClients),
+		l:       l,
+	}
+	for _, sub := range c.GetSubscriptionId() {
+		if p.clients[sub], err = newARMClients(sub, cred, opts); err != nil {
+			return nil, fmt.Errorf("azure: error creating ARM clients for subscription %s: %v", sub, err)
+		}
+	}
+	return p, nil
+}
+
+// New creates an Azure provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("azure: error creating credentials: %v", err)
+	}
+
+	p, err := newProvider(c, cred, nil, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initial refresh is done synchronously, but we don't fail if the Azure
+	// API is not reachable yet; resources will be populated by the refresh
+	// loop.
+	if err := p.refresh(); err != nil {
+		l.Error(err.Error())
+	}
+
+	go cache.RefreshLoop(time.Duration(c.GetReEvalSec())*time.Second, nil, 0, p.refresh, l)
+
+	return p, nil
+}
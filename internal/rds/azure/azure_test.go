@@ -0,0 +1,274 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/azure/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeCredential struct{}
+
+func (fakeCredential) GetToken(_ context.Context, _ policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	return azcore.AccessToken{Token: "test-token", ExpiresOn: time.Now().Add(time.Hour)}, nil
+}
+
+const rgPath = "/subscriptions/sub1/resourceGroups/rg1"
+
+// Responses of the fake ARM server, by path. VMs list is paginated. Paths are
+// matched case insensitively, as ARM does.
+var testResponses = map[string]string{
+	rgPath + "/providers/Microsoft.Compute/virtualMachines": `{
+		"value": [{
+			"id": "/subscriptions/sub1/resourceGroups/RG1/providers/Microsoft.Compute/virtualMachines/vm-1",
+			"name": "vm-1",
+			"location": "westus2",
+			"tags": {"env": "prod"},
+			"properties": {"networkProfile": {"networkInterfaces": [{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/networkInterfaces/vm-1-nic"}]}}
+		}],
+		"nextLink": "{{endpoint}}` + rgPath + `/providers/Microsoft.Compute/virtualMachines?page=2"
+	}`,
+	rgPath + "/providers/Microsoft.Compute/virtualMachines?page=2": `{
+		"value": [{
+			"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/vm-2",
+			"name": "vm-2",
+			"location": "westus2",
+			"tags": {"env": "dev"}
+		}, {
+			"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/flex-1",
+			"name": "flex-1",
+			"location": "westus2",
+			"tags": {"env": "prod"},
+			"properties": {"virtualMachineScaleSet": {"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/flex"}}
+		}]
+	}`,
+	rgPath + "/providers/Microsoft.Network/networkInterfaces": `{
+		"value": [{
+			"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/networkInterfaces/VM-1-NIC",
+			"properties": {"ipConfigurations": [
+				{"properties": {"primary": true, "privateIPAddress": "10.0.0.4", "privateIPAddressVersion": "IPv4", "publicIPAddress": {"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/publicIPAddresses/vm-1-ip"}}},
+				{"properties": {"privateIPAddress": "fd00::4", "privateIPAddressVersion": "IPv6"}}
+			]}
+		}]
+	}`,
+	rgPath + "/providers/Microsoft.Network/publicIPAddresses": `{
+		"value": [{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/publicIPAddresses/vm-1-ip", "properties": {"ipAddress": "20.1.1.1"}}]
+	}`,
+	rgPath + "/providers/Microsoft.Compute/virtualMachineScaleSets": `{
+		"value": [
+			{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/web", "name": "web", "tags": {"env": "prod"}, "properties": {"orchestrationMode": "Uniform"}},
+			{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/flex", "name": "flex", "tags": {"env": "prod"}, "properties": {"orchestrationMode": "Flexible"}}
+		]
+	}`,
+	rgPath + "/providers/Microsoft.Compute/virtualMachineScaleSets/web/virtualMachines": `{
+		"value": [{
+			"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/web/virtualMachines/0",
+			"name": "web_0",
+			"location": "westus2",
+			"properties": {"networkProfile": {"networkInterfaces": [{"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/web/virtualMachines/0/networkInterfaces/nic"}]}}
+		}]
+	}`,
+	rgPath + "/providers/Microsoft.Compute/virtualMachineScaleSets/web/networkInterfaces": `{
+		"value": [{
+			"id": "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachineScaleSets/web/virtualMachines/0/networkInterfaces/nic",
+			"properties": {"ipConfigurations": [{"properties": {"primary": true, "privateIPAddress": "10.0.1.4"}}]}
+		}]
+	}`,
+	rgPath + "/providers/Microsoft.Compute/virtualMachineScaleSets/web/publicIPAddresses": `{"value": []}`,
+}
+
+// testServer returns a fake ARM server. It fails the requests with status
+// 503 while failures is not 0; negative failures fails all the requests.
+func testServer(t *testing.T, failures *int) *httptest.Server {
+	t.Helper()
+	var ts *httptest.Server
+	var mu sync.Mutex
+	responses := make(map[string]string)
+	for k, v := range testResponses {
+		responses[strings.ToLower(k)] = v
+	}
+	ts = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		mu.Lock()
+		fail := *failures != 0
+		if *failures > 0 {
+			*failures--
+		}
+		mu.Unlock()
+		if fail {
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		key := r.URL.Path
+		if page := r.URL.Query().Get("page"); page != "" {
+			key += "?page=" + page
+		}
+		resp, ok := responses[strings.ToLower(key)]
+		if !ok {
+			http.Error(w, "not found: "+key, http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(strings.ReplaceAll(resp, "{{endpoint}}", ts.URL)))
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// testProvider returns a provider using the fake ARM server.
+func testProvider(t *testing.T, ts *httptest.Server) *Provider {
+	t.Helper()
+	opts := &arm.ClientOptions{
+		ClientOptions: policy.ClientOptions{
+			Transport: ts.Client(),
+			Retry:     policy.RetryOptions{MaxRetries: 2, RetryDelay: time.Millisecond, MaxRetryDelay: 10 * time.Millisecond},
+		},
+	}
+	p, err := newProvider(&configpb.ProviderConfig{
+		SubscriptionId:     []string{"sub1"},
+		ResourceGroup:      []string{"rg1"},
+		Tag:                map[string]string{"env": "prod"},
+		ManagementEndpoint: proto.String(ts.URL),
+	}, fakeCredential{}, opts, &logger.Logger{})
+	require.NoError(t, err)
+	return p
+}
+
+func TestListResources(t *testing.T) {
+	failures := 0
+	p := testProvider(t, testServer(t, &failures))
+	require.NoError(t, p.refresh())
+
+	tests := []struct {
+		name     string
+		req      *pb.ListResourcesRequest
+		wantIPs  map[string]string
+		wantErr  bool
+		wantSets map[string]string
+	}{
+		{
+			name:     "default",
+			req:      &pb.ListResourcesRequest{},
+			wantIPs:  map[string]string{"flex-1": "", "vm-1": "10.0.0.4", "web_0": "10.0.1.4"},
+			wantSets: map[string]string{"flex-1": "flex", "vm-1": "", "web_0": "web"},
+		},
+		{
+			name:    "public",
+			req:     &pb.ListResourcesRequest{IpConfig: &pb.IPConfig{IpType: pb.IPConfig_PUBLIC.Enum()}},
+			wantIPs: map[string]string{"vm-1": "20.1.1.1"},
+		},
+		{
+			name:    "ipv6",
+			req:     &pb.ListResourcesRequest{IpConfig: &pb.IPConfig{IpVersion: pb.IPConfig_IPV6.Enum()}},
+			wantIPs: map[string]string{"vm-1": "fd00::4"},
+		},
+		{
+			name: "scale_set_filter",
+			req: &pb.ListResourcesRequest{Filter: []*pb.Filter{
+				{Key: proto.String("labels.scale_set"), Value: proto.String("web")},
+			}},
+			wantIPs: map[string]string{"web_0": "10.0.1.4"},
+		},
+		{
+			name:    "alias",
+			req:     &pb.ListResourcesRequest{IpConfig: &pb.IPConfig{IpType: pb.IPConfig_ALIAS.Enum()}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := p.ListResources(test.req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			gotIPs := make(map[string]string)
+			for _, res := range resp.GetResources() {
+				gotIPs[res.GetName()] = res.GetIp()
+				assert.Equal(t, "prod", res.GetLabels()["env"])
+				assert.Equal(t, "rg1", strings.ToLower(res.GetLabels()["resource_group"]))
+				if test.wantSets != nil {
+					assert.Equal(t, test.wantSets[res.GetName()], res.GetLabels()["scale_set"], "resource: %s", res.GetName())
+				}
+			}
+			// Resources without the requested IP are skipped.
+			for name, ip := range test.wantIPs {
+				if ip == "" {
+					delete(test.wantIPs, name)
+				}
+			}
+			assert.Equal(t, test.wantIPs, gotIPs)
+		})
+	}
+
+	// Last known good resources are retained on errors.
+	lastUpdated := p.lastUpdated
+	failures = -1
+	assert.Error(t, p.refresh())
+	assert.Len(t, p.instances, 3)
+	assert.Equal(t, lastUpdated, p.lastUpdated)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastUpdated.Unix())})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+}
+
+func TestRefreshRetries(t *testing.T) {
+	// Failed requests are retried, as per the retry policy.
+	failures := 2
+	p := testProvider(t, testServer(t, &failures))
+	require.NoError(t, p.refresh())
+	assert.Len(t, p.instances, 3)
+
+	failures = 3
+	assert.Error(t, p.refresh())
+}
+
+func TestNewProviderErrors(t *testing.T) {
+	for _, c := range []*configpb.ProviderConfig{
+		{},
+		{SubscriptionId: []string{"sub1"}, IncludeVms: proto.Bool(false), IncludeScaleSets: proto.Bool(false)},
+		{SubscriptionId: []string{"sub1"}, ReEvalSec: proto.Int32(0)},
+		{SubscriptionId: []string{"sub1"}, ManagementEndpoint: proto.String("management.azure.com")},
+	} {
+		_, err := newProvider(c, fakeCredential{}, nil, &logger.Logger{})
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+func TestScopes(t *testing.T) {
+	p := &Provider{c: &configpb.ProviderConfig{SubscriptionId: []string{"s1", "s2"}}}
+	assert.Equal(t, []scope{{subscription: "s1"}, {subscription: "s2"}}, p.scopes())
+
+	p.c.ResourceGroup = []string{"rg1", "rg2"}
+	assert.Equal(t, []scope{{"s1", "rg1"}, {"s1", "rg2"}, {"s2", "rg1"}, {"s2", "rg2"}}, p.scopes())
+}
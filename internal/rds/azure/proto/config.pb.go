@@ -0,0 +1,298 @@
+// Configuration proto for Azure provider.
+//
+// Azure provider discovers virtual machines (VMs), and instances of virtual
+// machine scale sets (VMSS), using the Azure Resource Manager API. Each VM
+// or scale set instance becomes a resource, with its primary private IP as
+// the resource IP, and its Azure tags as the resource labels. Resources are
+// refreshed periodically; if a refresh fails, last known good resources are
+// retained.
+//
+// Credentials are obtained using Azure's default credential chain:
+// environment variables, workload identity, managed identity and Azure CLI,
+// in that order.
+//
+// Example provider config:
+// {
+//   subscription_id: "00000000-0000-0000-0000-000000000000"
+//   resource_group: "prod-rg"
+//   tag {
+//     key: "env"
+//     value: "prod"
+//   }
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "azure://"
+//       filter {
+//         key: "labels.scale_set"
+//         value: "web-.*"
+//       }
+//     }
+//   }
+// }
+//
+// Besides the tags, resources have the following labels: subscription,
+// resource_group, location and, for scale set instances, scale_set. Use
+// ip_config in the RDS request to select the public IP (ip_type: PUBLIC) or
+// the private IPv6 address (ip_version: IPV6). Resources without the
+// requested IP are skipped.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/rds/azure/proto/config.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProviderConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Subscriptions to discover the resources in. At least one subscription is
+	// required.
+	SubscriptionId []string `protobuf:"bytes,1,rep,name=subscription_id,json=subscriptionId" json:"subscription_id,omitempty"`
+	// Resource groups to limit the discovery to. By default, resources from all
+	// the resource groups in the subscriptions are discovered.
+	ResourceGroup []string `protobuf:"bytes,2,rep,name=resource_group,json=resourceGroup" json:"resource_group,omitempty"`
+	// Discover VMs, including the VMs in flexible orchestration scale sets.
+	IncludeVms *bool `protobuf:"varint,3,opt,name=include_vms,json=includeVms,def=1" json:"include_vms,omitempty"`
+	// Discover instances of uniform orchestration scale sets.
+	IncludeScaleSets *bool `protobuf:"varint,4,opt,name=include_scale_sets,json=includeScaleSets,def=1" json:"include_scale_sets,omitempty"`
+	// Discover only the VMs and scale sets that have all these tags. Empty
+	// value matches any value.
+	Tag map[string]string `protobuf:"bytes,5,rep,name=tag" json:"tag,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// How often resources should be refreshed.
+	ReEvalSec *int32 `protobuf:"varint,6,opt,name=re_eval_sec,json=reEvalSec,def=300" json:"re_eval_sec,omitempty"`
+	// Timeout for a refresh, including all the API requests and their retries.
+	// Failed requests are retried as per the Azure SDK's default retry policy.
+	TimeoutMsec *int32 `protobuf:"varint,7,opt,name=timeout_msec,json=timeoutMsec,def=60000" json:"timeout_msec,omitempty"`
+	// Azure Resource Manager endpoint. Change it for the sovereign clouds, e.g.
+	// "https://management.usgovcloudapi.net" for Azure Government.
+	ManagementEndpoint *string `protobuf:"bytes,8,opt,name=management_endpoint,json=managementEndpoint,def=https://management.azure.com" json:"management_endpoint,omitempty"`
+}
+
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_IncludeVms         = bool(true)
+	Default_ProviderConfig_IncludeScaleSets   = bool(true)
+	Default_ProviderConfig_ReEvalSec          = int32(300)
+	Default_ProviderConfig_TimeoutMsec        = int32(60000)
+	Default_ProviderConfig_ManagementEndpoint = string("https://management.azure.com")
+)
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderConfig) GetSubscriptionId() []string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetResourceGroup() []string {
+	if x != nil {
+		return x.ResourceGroup
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetIncludeVms() bool {
+	if x != nil && x.IncludeVms != nil {
+		return *x.IncludeVms
+	}
+	return Default_ProviderConfig_IncludeVms
+}
+
+func (x *ProviderConfig) GetIncludeScaleSets() bool {
+	if x != nil && x.IncludeScaleSets != nil {
+		return *x.IncludeScaleSets
+	}
+	return Default_ProviderConfig_IncludeScaleSets
+}
+
+func (x *ProviderConfig) GetTag() map[string]string {
+	if x != nil {
+		return x.Tag
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_ProviderConfig_ReEvalSec
+}
+
+func (x *ProviderConfig) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_TimeoutMsec
+}
+
+func (x *ProviderConfig) GetManagementEndpoint() string {
+	if x != nil && x.ManagementEndpoint != nil {
+		return *x.ManagementEndpoint
+	}
+	return Default_ProviderConfig_ManagementEndpoint
+}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x61, 0x7a, 0x75, 0x72,
+	0x65, 0x22, 0xd3, 0x03, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x25, 0x0a,
+	0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x25, 0x0a, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x76, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52,
+	0x0a, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x56, 0x6d, 0x73, 0x12, 0x32, 0x0a, 0x12, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x10, 0x69,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x73, 0x12,
+	0x40, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x61,
+	0x7a, 0x75, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x54, 0x61, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x74, 0x61,
+	0x67, 0x12, 0x23, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x28, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x05, 0x36, 0x30,
+	0x30, 0x30, 0x30, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63,
+	0x12, 0x4d, 0x0a, 0x13, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x65,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x1c, 0x68,
+	0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x2e, 0x61, 0x7a, 0x75, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x52, 0x12, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x1a,
+	0x36, 0x0a, 0x08, 0x54, 0x61, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x61, 0x7a, 0x75, 0x72, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_goTypes = []any{
+	(*ProviderConfig)(nil), // 0: cloudprober.rds.azure.ProviderConfig
+	nil,                    // 1: cloudprober.rds.azure.ProviderConfig.TagEntry
+}
+var file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_depIdxs = []int32{
+	1, // 0: cloudprober.rds.azure.ProviderConfig.tag:type_name -> cloudprober.rds.azure.ProviderConfig.TagEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_azure_proto_config_proto_depIdxs = nil
+}
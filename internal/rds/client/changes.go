@@ -0,0 +1,164 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Types of the resource changes.
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+// maxChangeLogsPerRefresh limits the number of changes logged individually
+// in one refresh, to avoid flooding the logs if a big part of the inventory
+// changes at once. All changes are counted irrespective of this limit.
+const maxChangeLogsPerRefresh = 100
+
+func newChangeCounts() *metrics.Map[int64] {
+	m := metrics.NewMap("change")
+	for _, k := range []string{changeAdded, changeRemoved, changeModified} {
+		m.IncKeyBy(k, 0)
+	}
+	return m
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// computeHash computes the hash of the record's fields that are reported as
+// changes: IP(s), port and labels.
+func (cr *cacheRecord) computeHash() uint64 {
+	h := fnv.New64a()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	write(cr.ipStr)
+	for _, ip := range cr.ips {
+		write(ip.String())
+	}
+	write(strconv.Itoa(cr.port))
+	for _, k := range sortedKeys(cr.labels) {
+		write(k)
+		write(cr.labels[k])
+	}
+	return h.Sum64()
+}
+
+func ipsString(cr *cacheRecord) string {
+	if len(cr.ips) == 0 {
+		return cr.ipStr
+	}
+	var ips []string
+	for _, ip := range cr.ips {
+		ips = append(ips, ip.String())
+	}
+	return strings.Join(ips, ",")
+}
+
+// diffRecords describes the differences between the old and the new record.
+func diffRecords(oldCR, newCR *cacheRecord) string {
+	var diffs []string
+	if oldIPs, newIPs := ipsString(oldCR), ipsString(newCR); oldIPs != newIPs {
+		diffs = append(diffs, fmt.Sprintf("ip: %s -> %s", oldIPs, newIPs))
+	}
+	if oldCR.port != newCR.port {
+		diffs = append(diffs, fmt.Sprintf("port: %d -> %d", oldCR.port, newCR.port))
+	}
+
+	var labelDiffs []string
+	for _, k := range sortedKeys(oldCR.labels) {
+		newV, ok := newCR.labels[k]
+		if !ok {
+			labelDiffs = append(labelDiffs, "-"+k)
+			continue
+		}
+		if oldV := oldCR.labels[k]; oldV != newV {
+			labelDiffs = append(labelDiffs, fmt.Sprintf("%s: %s -> %s", k, oldV, newV))
+		}
+	}
+	for _, k := range sortedKeys(newCR.labels) {
+		if _, ok := oldCR.labels[k]; !ok {
+			labelDiffs = append(labelDiffs, fmt.Sprintf("+%s=%s", k, newCR.labels[k]))
+		}
+	}
+	if len(labelDiffs) > 0 {
+		diffs = append(diffs, "labels: "+strings.Join(labelDiffs, ", "))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+// reportChanges compares the current cache with the old cache, and logs and
+// counts the changes. Records are compared using their hashes, and only the
+// modified records are diffed in detail. It should be called with client's
+// lock held.
+func (client *Client) reportChanges(oldNames []string, oldCache map[string]*cacheRecord) {
+	counts := make(map[string]int)
+	total := 0
+	report := func(change, name, details string) {
+		counts[change]++
+		client.changes.IncKey(change)
+		if total++; total > maxChangeLogsPerRefresh {
+			return
+		}
+		if details != "" {
+			client.l.Infof("rds.client: resource (%s) %s: %s", name, change, details)
+			return
+		}
+		client.l.Infof("rds.client: resource (%s) %s", name, change)
+	}
+
+	for _, name := range client.names {
+		cr := client.cache[name]
+		oldCR, ok := oldCache[name]
+		if !ok {
+			report(changeAdded, name, fmt.Sprintf("ip: %s, port: %d, labels: %v", ipsString(cr), cr.port, cr.labels))
+			continue
+		}
+		if oldCR.hash != cr.hash {
+			report(changeModified, name, diffRecords(oldCR, cr))
+		}
+	}
+	for _, name := range oldNames {
+		if _, ok := client.cache[name]; !ok {
+			report(changeRemoved, name, "")
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+	if total > maxChangeLogsPerRefresh {
+		client.l.Infof("rds.client: %d more resource changes not logged", total-maxChangeLogsPerRefresh)
+	}
+	client.l.Infof("rds.client: resource changes (resource_path: %s): added=%d, removed=%d, modified=%d", client.c.GetRequest().GetResourcePath(), counts[changeAdded], counts[changeRemoved], counts[changeModified])
+}
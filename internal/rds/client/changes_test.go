@@ -0,0 +1,84 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testResource(name, ip string, labels map[string]string) *pb.Resource {
+	return &pb.Resource{Name: proto.String(name), Ip: proto.String(ip), Port: proto.Int32(80), Labels: labels}
+}
+
+func TestReportChanges(t *testing.T) {
+	client := &Client{
+		c:       &configpb.ClientConf{ReportChanges: proto.Bool(true)},
+		cache:   make(map[string]*cacheRecord),
+		stats:   newRequestStats(),
+		changes: newChangeCounts(),
+		l:       &logger.Logger{},
+	}
+	wantChanges := func(added, removed, modified int64) {
+		t.Helper()
+		assert.Equal(t, added, client.changes.GetKey(changeAdded), "added")
+		assert.Equal(t, removed, client.changes.GetKey(changeRemoved), "removed")
+		assert.Equal(t, modified, client.changes.GetKey(changeModified), "modified")
+	}
+
+	// Initial load is not reported.
+	client.updateState(&pb.ListResourcesResponse{Resources: []*pb.Resource{
+		testResource("r1", "10.0.0.1", map[string]string{"zone": "a"}),
+		testResource("r2", "10.0.0.2", map[string]string{"zone": "b"}),
+		testResource("r3", "10.0.0.3", nil),
+	}})
+	wantChanges(0, 0, 0)
+
+	// Same resources, in a different order, with fresh label maps.
+	client.updateState(&pb.ListResourcesResponse{Resources: []*pb.Resource{
+		testResource("r3", "10.0.0.3", nil),
+		testResource("r2", "10.0.0.2", map[string]string{"zone": "b"}),
+		testResource("r1", "10.0.0.1", map[string]string{"zone": "a"}),
+	}})
+	wantChanges(0, 0, 0)
+
+	// r1's IP changes, r2's label changes, r3 is removed, and r4 is added.
+	client.updateState(&pb.ListResourcesResponse{Resources: []*pb.Resource{
+		testResource("r1", "10.0.0.11", map[string]string{"zone": "a"}),
+		testResource("r2", "10.0.0.2", map[string]string{"zone": "c"}),
+		testResource("r4", "10.0.0.4", nil),
+	}})
+	wantChanges(1, 1, 2)
+
+	em := client.Metrics()
+	assert.Equal(t, "map:change,added:1,modified:2,removed:1", em.Metric("rds_client_target_changes").String())
+}
+
+func TestDiffRecords(t *testing.T) {
+	oldCR := &cacheRecord{ipStr: "10.0.0.1", port: 80, labels: map[string]string{"zone": "a", "env": "prod", "old": "x"}}
+	newCR := &cacheRecord{ipStr: "10.0.0.2", port: 8080, labels: map[string]string{"zone": "b", "env": "prod", "new": "y"}}
+	assert.NotEqual(t, oldCR.computeHash(), newCR.computeHash())
+	assert.Equal(t, "ip: 10.0.0.1 -> 10.0.0.2; port: 80 -> 8080; labels: -old, zone: a -> b, +new=y", diffRecords(oldCR, newCR))
+
+	// Label key/value boundaries are part of the hash.
+	cr1 := &cacheRecord{labels: map[string]string{"ab": "c"}}
+	cr2 := &cacheRecord{labels: map[string]string{"a": "bc"}}
+	assert.NotEqual(t, cr1.computeHash(), cr2.computeHash())
+}
@@ -35,6 +35,7 @@ import (
 	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	"github.com/cloudprober/cloudprober/internal/tlsconfig"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	dnsRes "github.com/cloudprober/cloudprober/targets/resolver"
 	"google.golang.org/grpc"
@@ -53,9 +54,14 @@ var (
 type cacheRecord struct {
 	ip          net.IP
 	ipStr       string
+	ips         []net.IP // For resources with multiple IPs.
 	port        int
 	labels      map[string]string
+	typedLabels map[string]any
 	lastUpdated time.Time
+
+	// hash of the IP(s), port and labels, set only if changes are reported.
+	hash uint64
 }
 
 // Default RDS port
@@ -72,7 +78,14 @@ type Client struct {
 	listResources func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error)
 	lastModified  int64
 	resolver      *dnsRes.Resolver
+	stats         *requestStats
 	l             *logger.Logger
+
+	// changes counts the resource changes by type, if changes are reported.
+	// loaded is set after the first state update; changes are not reported
+	// for it.
+	changes *metrics.Map[int64]
+	loaded  bool
 }
 
 // ListResourcesFunc is a function that takes ListResourcesRequest and returns
@@ -86,15 +99,42 @@ func (client *Client) refreshState(timeout time.Duration) {
 
 	req := client.c.GetRequest()
 	req.IfModifiedSince = proto.Int64(client.lastModified)
+	if client.resampleEveryRefresh() {
+		req.IfModifiedSince = nil
+	}
 
-	response, err := client.listResources(ctx, req)
+	response, err := client.listResourcesWithRetry(ctx, req)
 	if err != nil {
 		client.l.Errorf("rds.client: error getting resources from RDS server: %v", err)
 		return
 	}
+
+	if client.resampleEveryRefresh() {
+		// Update the state with the fresh sample, irrespective of the
+		// resources' last modification.
+		response.LastModified = nil
+	}
+
+	if !client.notModified(response) {
+		resources, err := transformResources(ctx, req.GetProvider(), response.GetResources())
+		if err != nil {
+			client.l.Errorf("rds.client: %v", err)
+			return
+		}
+		response.Resources = client.sample(resources)
+	}
 	client.updateState(response)
 }
 
+// notModified returns true if response's resources have not been modified
+// since the last update.
+func (client *Client) notModified(response *pb.ListResourcesResponse) bool {
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	// If server doesn't support caching, response's last_modified will be 0.
+	return response.GetLastModified() != 0 && response.GetLastModified() <= client.lastModified
+}
+
 func parseIP(ipStr string) net.IP {
 	if strings.Contains(ipStr, "/") {
 		ip, _, err := net.ParseCIDR(ipStr)
@@ -106,6 +146,38 @@ func parseIP(ipStr string) net.IP {
 	return net.ParseIP(ipStr)
 }
 
+// parseIPs parses the resource's ips field, skipping the invalid IPs.
+func (client *Client) parseIPs(res *pb.Resource) []net.IP {
+	var ips []net.IP
+	for _, s := range res.GetIps() {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			client.l.Warningf("rds.client: resource (%s) has an invalid IP address: %s, ignoring it.", res.GetName(), s)
+			continue
+		}
+		ips = append(ips, ip)
+	}
+	return ips
+}
+
+func typedLabelsFromProto(in map[string]*pb.TypedLabelValue) map[string]any {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		switch v.GetValue().(type) {
+		case *pb.TypedLabelValue_IntValue:
+			out[k] = v.GetIntValue()
+		case *pb.TypedLabelValue_DoubleValue:
+			out[k] = v.GetDoubleValue()
+		case *pb.TypedLabelValue_BoolValue:
+			out[k] = v.GetBoolValue()
+		}
+	}
+	return out
+}
+
 func (client *Client) updateState(response *pb.ListResourcesResponse) {
 	client.mu.Lock()
 	defer client.mu.Unlock()
@@ -118,6 +190,7 @@ func (client *Client) updateState(response *pb.ListResourcesResponse) {
 		return
 	}
 
+	oldNames := client.names
 	client.names = make([]string, len(response.GetResources()))
 	oldcache := client.cache
 	client.cache = make(map[string]*cacheRecord, len(response.GetResources()))
@@ -128,25 +201,44 @@ func (client *Client) updateState(response *pb.ListResourcesResponse) {
 			client.l.Warningf("Got resource (%s) again, ignoring this instance: {%v}. Previous record: %+v.", res.GetName(), res, *oldRes)
 			continue
 		}
-		if oldcache[res.GetName()] != nil && res.GetIp() != oldcache[res.GetName()].ipStr {
-			client.l.Infof("Resource (%s) ip has changed: %s -> %s.", res.GetName(), oldcache[res.GetName()].ipStr, res.GetIp())
+		ipStr := res.GetIp()
+		ips := client.parseIPs(res)
+		if len(ips) > 0 {
+			ipStr = ips[0].String()
+		}
+		// IP changes are logged along with the other changes, if reporting
+		// changes.
+		if client.changes == nil && oldcache[res.GetName()] != nil && ipStr != oldcache[res.GetName()].ipStr {
+			client.l.Infof("Resource (%s) ip has changed: %s -> %s.", res.GetName(), oldcache[res.GetName()].ipStr, ipStr)
 		}
 
-		client.cache[res.GetName()] = &cacheRecord{
-			ip:          parseIP(res.GetIp()),
-			ipStr:       res.GetIp(),
+		cr := &cacheRecord{
+			ip:          parseIP(ipStr),
+			ipStr:       ipStr,
+			ips:         ips,
 			port:        int(res.GetPort()),
 			labels:      res.Labels,
+			typedLabels: typedLabelsFromProto(res.GetTypedLabels()),
 			lastUpdated: time.Unix(res.GetLastUpdated(), 0),
 		}
+		if client.changes != nil {
+			cr.hash = cr.computeHash()
+		}
+		client.cache[res.GetName()] = cr
 		client.names[i] = res.GetName()
 		i++
 	}
 	client.names = client.names[:i]
 	client.lastModified = response.GetLastModified()
+
+	if client.changes != nil && client.loaded {
+		client.reportChanges(oldNames, oldcache)
+	}
+	client.loaded = true
 }
 
-// ListEndpoints returns the list of resources.
+// ListEndpoints returns the list of resources. Resources with multiple IPs
+// are expanded into one endpoint per IP.
 func (client *Client) ListEndpoints() []endpoint.Endpoint {
 	// If ReEvalSec is set to 0 or less, we refresh state on demand.
 	if client.c.GetReEvalSec() <= 0 {
@@ -155,10 +247,15 @@ func (client *Client) ListEndpoints() []endpoint.Endpoint {
 
 	client.mu.RLock()
 	defer client.mu.RUnlock()
-	result := make([]endpoint.Endpoint, len(client.names))
-	for i, name := range client.names {
+	result := make([]endpoint.Endpoint, 0, len(client.names))
+	for _, name := range client.names {
 		cr := client.cache[name]
-		result[i] = endpoint.Endpoint{Name: name, IP: cr.ip, Port: cr.port, Labels: cr.labels, LastUpdated: cr.lastUpdated}
+		ep := endpoint.Endpoint{Name: name, IP: cr.ip, Port: cr.port, Labels: cr.labels, TypedLabels: cr.typedLabels, LastUpdated: cr.lastUpdated}
+		if len(cr.ips) > 0 {
+			result = append(result, ep.ExpandIPs(cr.ips)...)
+			continue
+		}
+		result = append(result, ep)
 	}
 	return result
 }
@@ -172,6 +269,14 @@ func (client *Client) Resolve(name string, ipVer int) (net.IP, error) {
 	client.mu.RLock()
 	if cr, ok := client.cache[name]; ok {
 		ip, ipStr = cr.ip, cr.ipStr
+		// For resources with multiple IPs, use the first IP of the
+		// requested version.
+		for _, resIP := range cr.ips {
+			if ipVer == 0 || iputils.IPVersion(resIP) == ipVer {
+				ip = resIP
+				break
+			}
+		}
 	}
 	client.mu.RUnlock()
 
@@ -223,6 +328,10 @@ func (client *Client) initListResourcesFunc() error {
 		client.dialOpts = append(client.dialOpts, grpc.WithInsecure())
 	}
 
+	if client.serverOpts.GetRetry() != nil {
+		client.dialOpts = append(client.dialOpts, connectParamsDialOption(client.serverOpts.GetRetry()))
+	}
+
 	// OAuth related options.
 	if client.serverOpts.GetOauthConfig() != nil {
 		oauthTS, err := oauth.TokenSourceFromConfig(client.serverOpts.GetOauthConfig(), client.l)
@@ -253,12 +362,17 @@ func New(c *configpb.ClientConf, listResources ListResourcesFunc, l *logger.Logg
 		cache:         make(map[string]*cacheRecord),
 		listResources: listResources,
 		resolver:      globalResolver,
+		stats:         newRequestStats(),
 		l:             l,
 	}
+	if c.GetReportChanges() {
+		client.changes = newChangeCounts()
+	}
 
 	if err := client.initListResourcesFunc(); err != nil {
 		return nil, fmt.Errorf("rds/client: error initializing listListResource function: %v", err)
 	}
+	registerClient(client)
 
 	if client.c.GetReEvalSec() <= 0 {
 		return client, nil
@@ -267,6 +267,45 @@ func TestListAndResolve(t *testing.T) {
 	}
 }
 
+func TestListWithMultipleIPs(t *testing.T) {
+	client := &Client{
+		c: &configpb.ClientConf{ReEvalSec: proto.Int32(10)},
+		l: &logger.Logger{},
+	}
+	client.updateState(&pb.ListResourcesResponse{
+		Resources: []*pb.Resource{
+			{
+				Name:   proto.String("rr.example.com"),
+				Ip:     proto.String("10.0.0.9"),
+				Ips:    []string{"10.0.0.1", "bad-ip", "2001:db8::1"},
+				Port:   proto.Int32(443),
+				Labels: map[string]string{"zone": "z1"},
+			},
+			{
+				Name: proto.String("single"),
+				Ip:   proto.String("10.0.0.2"),
+			},
+		},
+	})
+
+	var got []string
+	for _, ep := range client.ListEndpoints() {
+		got = append(got, fmt.Sprintf("%s:%d %s %v", ep.Name, ep.Port, ep.IP, ep.Labels))
+	}
+	assert.Equal(t, []string{
+		"rr.example.com:443 10.0.0.1 map[ip:10.0.0.1 zone:z1]",
+		"rr.example.com:443 2001:db8::1 map[ip:2001:db8::1 zone:z1]",
+		"single:0 10.0.0.2 map[]",
+	}, got)
+
+	// Resolving the name returns the first IP of the requested version.
+	for ipVer, want := range map[int]string{0: "10.0.0.1", 4: "10.0.0.1", 6: "2001:db8::1"} {
+		ip, err := client.Resolve("rr.example.com", ipVer)
+		assert.NoError(t, err)
+		assert.Equal(t, want, ip.String(), "IPv%d", ipVer)
+	}
+}
+
 func TestCacheBehaviorWithServerSupport(t *testing.T) {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
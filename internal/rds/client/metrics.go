@@ -0,0 +1,98 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"google.golang.org/grpc/status"
+)
+
+// clients keeps track of all the RDS clients created in this process, so
+// that their stats can be exported. Clients are never removed, as they keep
+// refreshing their state for the lifetime of the process anyway.
+var (
+	clientsMu sync.Mutex
+	clients   []*Client
+)
+
+func registerClient(client *Client) {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+	clients = append(clients, client)
+}
+
+// requestStats keeps the cumulative stats for the ListResources requests.
+type requestStats struct {
+	mu        sync.Mutex
+	total     int64
+	errors    *metrics.Map[int64] // By gRPC code.
+	latencyUs float64
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{errors: metrics.NewMap("code")}
+}
+
+func (rs *requestStats) record(latency time.Duration, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	rs.total++
+	rs.latencyUs += float64(latency.Microseconds())
+	if err != nil {
+		rs.errors.IncKey(status.Code(err).String())
+	}
+}
+
+// Metrics returns the client's cumulative ListResources request stats:
+// number of requests (including retries), errors by gRPC code, and the total
+// latency in microseconds. If changes are reported, it also includes the
+// resource changes by type.
+func (client *Client) Metrics() *metrics.EventMetrics {
+	rs := client.stats
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("rds_client_requests", metrics.NewInt(rs.total)).
+		AddMetric("rds_client_errors", rs.errors.Clone()).
+		AddMetric("rds_client_latency", metrics.NewFloat(rs.latencyUs)).
+		AddLabel("ptype", "rds_client").
+		AddLabel("resource_path", client.c.GetRequest().GetResourcePath())
+	if client.changes != nil {
+		em.AddMetric("rds_client_target_changes", client.changes.Clone())
+	}
+	if addr := client.serverOpts.GetServerAddress(); addr != "" {
+		em.AddLabel("server", addr)
+	}
+	return em
+}
+
+// AllMetrics returns the stats (see Client.Metrics) for all the RDS clients
+// created in this process, in the order of their creation. It's meant to be
+// called periodically by the metrics exporter.
+func AllMetrics() []*metrics.EventMetrics {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	ems := make([]*metrics.EventMetrics, 0, len(clients))
+	for _, client := range clients {
+		ems = append(ems, client.Metrics())
+	}
+	return ems
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestAllMetrics(t *testing.T) {
+	listResources := func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+		return &pb.ListResourcesResponse{}, nil
+	}
+	client, err := New(&configpb.ClientConf{
+		Request:   &pb.ListResourcesRequest{ResourcePath: proto.String("test-all-metrics")},
+		ReEvalSec: proto.Int32(0),
+	}, listResources, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client.refreshState(time.Second)
+
+	var found bool
+	for _, em := range AllMetrics() {
+		if em.Label("resource_path") == "test-all-metrics" {
+			found = true
+			assert.Equal(t, int64(1), em.Metric("rds_client_requests").(*metrics.Int).Int64())
+		}
+	}
+	assert.True(t, found, "client's metrics not found in AllMetrics()")
+}
+
+func TestAllMetricsTargetChanges(t *testing.T) {
+	resources := []*pb.Resource{testResource("r1", "10.0.0.1", nil)}
+	listResources := func(context.Context, *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+		return &pb.ListResourcesResponse{Resources: resources}, nil
+	}
+	client, err := New(&configpb.ClientConf{
+		Request:       &pb.ListResourcesRequest{ResourcePath: proto.String("test-target-changes")},
+		ReEvalSec:     proto.Int32(0),
+		ReportChanges: proto.Bool(true),
+	}, listResources, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client.refreshState(time.Second)
+
+	resources = []*pb.Resource{testResource("r1", "10.0.0.11", nil), testResource("r2", "10.0.0.2", nil)}
+	client.refreshState(time.Second)
+
+	var got string
+	for _, em := range AllMetrics() {
+		if em.Label("resource_path") == "test-target-changes" {
+			got = em.Metric("rds_client_target_changes").String()
+		}
+	}
+	assert.Equal(t, "map:change,added:1,modified:1,removed:0", got)
+}
@@ -38,14 +38,35 @@ type ClientConf struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ServerOptions *ClientConf_ServerOptions   `protobuf:"bytes,1,opt,name=server_options,json=serverOptions" json:"server_options,omitempty"`
-	Request       *proto.ListResourcesRequest `protobuf:"bytes,2,req,name=request" json:"request,omitempty"`
+	ServerOptions *ClientConf_ServerOptions `protobuf:"bytes,1,opt,name=server_options,json=serverOptions" json:"server_options,omitempty"`
+	// Resources request. Note that client sets the request's
+	// if_modified_since field to the last_modified timestamp of the last
+	// response, so servers that support caching send resources only if they
+	// have changed since then.
+	Request *proto.ListResourcesRequest `protobuf:"bytes,2,req,name=request" json:"request,omitempty"`
 	// How often targets should be evaluated. Any number less than or equal to 0
 	// will result in no target caching (targets will be reevaluated on demand).
 	// Note that individual target types may have their own caches implemented
 	// (specifically GCE instances/forwarding rules). This does not impact those
 	// caches.
 	ReEvalSec *int32 `protobuf:"varint,3,opt,name=re_eval_sec,json=reEvalSec,def=30" json:"re_eval_sec,omitempty"`
+	// If set, client compares the resources with the previous set on every
+	// refresh, and reports the resources that were added, removed, or modified
+	// (IP, port, or labels changed): each change is logged, and changes are
+	// counted by type in the "rds_client_target_changes" metric. Resources
+	// are compared using their hashes, so it's cheap even for large sets.
+	// Changes are not reported for the initial load.
+	ReportChanges *bool `protobuf:"varint,4,opt,name=report_changes,json=reportChanges" json:"report_changes,omitempty"`
+	// If set to a positive value, client uses a uniform random sample of (up
+	// to) sample_size resources, chosen after the server's filtering, e.g. for
+	// a lightweight canary probe over a large inventory. Sampling is done by
+	// the client, so it works with all the providers. A fresh sample is chosen
+	// at every refresh (and full resources are requested from the server every
+	// time), unless sample_seed is set. With sample_seed, the same resources
+	// always produce the same sample. Resources in the sample retain their
+	// relative order.
+	SampleSize *int32 `protobuf:"varint,5,opt,name=sample_size,json=sampleSize" json:"sample_size,omitempty"`
+	SampleSeed *int64 `protobuf:"varint,6,opt,name=sample_seed,json=sampleSeed" json:"sample_seed,omitempty"`
 }
 
 // Default values for ClientConf fields.
@@ -106,6 +127,27 @@ func (x *ClientConf) GetReEvalSec() int32 {
 	return Default_ClientConf_ReEvalSec
 }
 
+func (x *ClientConf) GetReportChanges() bool {
+	if x != nil && x.ReportChanges != nil {
+		return *x.ReportChanges
+	}
+	return false
+}
+
+func (x *ClientConf) GetSampleSize() int32 {
+	if x != nil && x.SampleSize != nil {
+		return *x.SampleSize
+	}
+	return 0
+}
+
+func (x *ClientConf) GetSampleSeed() int64 {
+	if x != nil && x.SampleSeed != nil {
+		return *x.SampleSeed
+	}
+	return 0
+}
+
 type ClientConf_ServerOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -125,7 +167,12 @@ type ClientConf_ServerOptions struct {
 	//     tls_cert_file: "..."
 	//     tls_key_file: "..."
 	//     }
-	TlsConfig *proto2.TLSConfig `protobuf:"bytes,3,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	//
+	// Specifying both, i.e. client cert and CA cert, enables mutual TLS (mTLS)
+	// with the RDS server. If tls_config.reload_interval_sec is set, certs
+	// are reloaded periodically.
+	TlsConfig *proto2.TLSConfig               `protobuf:"bytes,3,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	Retry     *ClientConf_ServerOptions_Retry `protobuf:"bytes,4,opt,name=retry" json:"retry,omitempty"`
 }
 
 func (x *ClientConf_ServerOptions) Reset() {
@@ -181,6 +228,90 @@ func (x *ClientConf_ServerOptions) GetTlsConfig() *proto2.TLSConfig {
 	return nil
 }
 
+func (x *ClientConf_ServerOptions) GetRetry() *ClientConf_ServerOptions_Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+// Retry and backoff settings. These apply to both, connecting to the
+// server, and ListResources requests. Failed requests are retried with
+// exponential backoff, within the refresh timeout (re_eval_sec), unless
+// the error is not retriable (e.g. INVALID_ARGUMENT or PERMISSION_DENIED).
+// If not specified, requests are not retried and gRPC's default connection
+// backoff is used.
+type ClientConf_ServerOptions_Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of attempts per refresh, including the first one.
+	MaxAttempts        *int32 `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,def=3" json:"max_attempts,omitempty"`
+	InitialBackoffMsec *int32 `protobuf:"varint,2,opt,name=initial_backoff_msec,json=initialBackoffMsec,def=500" json:"initial_backoff_msec,omitempty"`
+	MaxBackoffMsec     *int32 `protobuf:"varint,3,opt,name=max_backoff_msec,json=maxBackoffMsec,def=10000" json:"max_backoff_msec,omitempty"`
+}
+
+// Default values for ClientConf_ServerOptions_Retry fields.
+const (
+	Default_ClientConf_ServerOptions_Retry_MaxAttempts        = int32(3)
+	Default_ClientConf_ServerOptions_Retry_InitialBackoffMsec = int32(500)
+	Default_ClientConf_ServerOptions_Retry_MaxBackoffMsec     = int32(10000)
+)
+
+func (x *ClientConf_ServerOptions_Retry) Reset() {
+	*x = ClientConf_ServerOptions_Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientConf_ServerOptions_Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientConf_ServerOptions_Retry) ProtoMessage() {}
+
+func (x *ClientConf_ServerOptions_Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientConf_ServerOptions_Retry.ProtoReflect.Descriptor instead.
+func (*ClientConf_ServerOptions_Retry) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+func (x *ClientConf_ServerOptions_Retry) GetMaxAttempts() int32 {
+	if x != nil && x.MaxAttempts != nil {
+		return *x.MaxAttempts
+	}
+	return Default_ClientConf_ServerOptions_Retry_MaxAttempts
+}
+
+func (x *ClientConf_ServerOptions_Retry) GetInitialBackoffMsec() int32 {
+	if x != nil && x.InitialBackoffMsec != nil {
+		return *x.InitialBackoffMsec
+	}
+	return Default_ClientConf_ServerOptions_Retry_InitialBackoffMsec
+}
+
+func (x *ClientConf_ServerOptions_Retry) GetMaxBackoffMsec() int32 {
+	if x != nil && x.MaxBackoffMsec != nil {
+		return *x.MaxBackoffMsec
+	}
+	return Default_ClientConf_ServerOptions_Retry_MaxBackoffMsec
+}
+
 var File_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_rawDesc = []byte{
@@ -202,7 +333,7 @@ var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_pro
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
 	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfb, 0x02,
+	0x6f, 0x74, 0x6f, 0x2f, 0x72, 0x64, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc3, 0x05,
 	0x0a, 0x0a, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x50, 0x0a, 0x0e,
 	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
@@ -215,22 +346,43 @@ var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_pro
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x22, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c,
-	0x53, 0x65, 0x63, 0x1a, 0xb5, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3c, 0x0a, 0x0c,
-	0x6f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2e, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x6f,
-	0x61, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c,
-	0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
-	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x3e, 0x5a, 0x3c, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x63,
-	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x53, 0x65, 0x63, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x65, 0x65, 0x64, 0x1a, 0x94, 0x03, 0x0a,
+	0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x45, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x1a, 0x95, 0x01, 0x0a, 0x05,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x24, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x33, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x35, 0x0a, 0x14, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d,
+	0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x35, 0x30, 0x30, 0x52, 0x12,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73,
+	0x65, 0x63, 0x12, 0x2f, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66,
+	0x66, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x05, 0x31, 0x30,
+	0x30, 0x30, 0x30, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d,
+	0x73, 0x65, 0x63, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -245,24 +397,26 @@ func file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_pr
 	return file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_goTypes = []any{
-	(*ClientConf)(nil),                 // 0: cloudprober.rds.ClientConf
-	(*ClientConf_ServerOptions)(nil),   // 1: cloudprober.rds.ClientConf.ServerOptions
-	(*proto.ListResourcesRequest)(nil), // 2: cloudprober.rds.ListResourcesRequest
-	(*proto1.Config)(nil),              // 3: cloudprober.oauth.Config
-	(*proto2.TLSConfig)(nil),           // 4: cloudprober.tlsconfig.TLSConfig
+	(*ClientConf)(nil),                     // 0: cloudprober.rds.ClientConf
+	(*ClientConf_ServerOptions)(nil),       // 1: cloudprober.rds.ClientConf.ServerOptions
+	(*ClientConf_ServerOptions_Retry)(nil), // 2: cloudprober.rds.ClientConf.ServerOptions.Retry
+	(*proto.ListResourcesRequest)(nil),     // 3: cloudprober.rds.ListResourcesRequest
+	(*proto1.Config)(nil),                  // 4: cloudprober.oauth.Config
+	(*proto2.TLSConfig)(nil),               // 5: cloudprober.tlsconfig.TLSConfig
 }
 var file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_depIdxs = []int32{
 	1, // 0: cloudprober.rds.ClientConf.server_options:type_name -> cloudprober.rds.ClientConf.ServerOptions
-	2, // 1: cloudprober.rds.ClientConf.request:type_name -> cloudprober.rds.ListResourcesRequest
-	3, // 2: cloudprober.rds.ClientConf.ServerOptions.oauth_config:type_name -> cloudprober.oauth.Config
-	4, // 3: cloudprober.rds.ClientConf.ServerOptions.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	3, // 1: cloudprober.rds.ClientConf.request:type_name -> cloudprober.rds.ListResourcesRequest
+	4, // 2: cloudprober.rds.ClientConf.ServerOptions.oauth_config:type_name -> cloudprober.oauth.Config
+	5, // 3: cloudprober.rds.ClientConf.ServerOptions.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	2, // 4: cloudprober.rds.ClientConf.ServerOptions.retry:type_name -> cloudprober.rds.ClientConf.ServerOptions.Retry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_init() }
@@ -295,6 +449,18 @@ func file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_pr
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ClientConf_ServerOptions_Retry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -302,7 +468,7 @@ func file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_pr
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_client_proto_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,89 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nonRetriableCodes are the gRPC codes for which retrying the request is not
+// going to help.
+var nonRetriableCodes = map[codes.Code]bool{
+	codes.InvalidArgument:    true,
+	codes.NotFound:           true,
+	codes.PermissionDenied:   true,
+	codes.Unauthenticated:    true,
+	codes.Unimplemented:      true,
+	codes.FailedPrecondition: true,
+}
+
+// backoffDelay returns the delay before the given retry (1 for the first
+// retry).
+func backoffDelay(retry *configpb.ClientConf_ServerOptions_Retry, n int) time.Duration {
+	maxBackoff := time.Duration(retry.GetMaxBackoffMsec()) * time.Millisecond
+	d := time.Duration(retry.GetInitialBackoffMsec()) * time.Millisecond
+	for i := 1; i < n && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// connectParamsDialOption returns the dial option to use retry config's
+// backoff for connecting to the server.
+func connectParamsDialOption(retry *configpb.ClientConf_ServerOptions_Retry) grpc.DialOption {
+	bc := backoff.DefaultConfig
+	bc.BaseDelay = time.Duration(retry.GetInitialBackoffMsec()) * time.Millisecond
+	bc.MaxDelay = time.Duration(retry.GetMaxBackoffMsec()) * time.Millisecond
+	return grpc.WithConnectParams(grpc.ConnectParams{Backoff: bc})
+}
+
+// listResourcesWithRetry calls listResources, retrying on retriable errors
+// as per the retry config, until the context expires.
+func (client *Client) listResourcesWithRetry(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	retry := client.serverOpts.GetRetry()
+	maxAttempts := 1
+	if retry != nil {
+		maxAttempts = int(retry.GetMaxAttempts())
+	}
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		resp, err := client.listResources(ctx, req)
+		client.stats.record(time.Since(start), err)
+
+		if err == nil || attempt >= maxAttempts || nonRetriableCodes[status.Code(err)] {
+			return resp, err
+		}
+
+		d := backoffDelay(retry, attempt)
+		client.l.Warningf("rds.client: error getting resources from RDS server (attempt: %d), retrying in %v: %v", attempt, d, err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(d):
+		}
+	}
+}
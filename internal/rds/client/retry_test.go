@@ -0,0 +1,121 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	retry := &configpb.ClientConf_ServerOptions_Retry{
+		InitialBackoffMsec: proto.Int32(100),
+		MaxBackoffMsec:     proto.Int32(500),
+	}
+	var got []time.Duration
+	for n := 1; n <= 5; n++ {
+		got = append(got, backoffDelay(retry, n))
+	}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}
+	assert.Equal(t, want, got)
+}
+
+func TestListResourcesWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		retry        *configpb.ClientConf_ServerOptions_Retry
+		errs         []error
+		wantErr      bool
+		wantRequests int64
+		wantErrors   map[string]int64
+	}{
+		{
+			name:         "no_retry",
+			errs:         []error{status.Error(codes.Unavailable, "down")},
+			wantErr:      true,
+			wantRequests: 1,
+			wantErrors:   map[string]int64{"Unavailable": 1},
+		},
+		{
+			name:         "retry_success",
+			retry:        &configpb.ClientConf_ServerOptions_Retry{InitialBackoffMsec: proto.Int32(1)},
+			errs:         []error{status.Error(codes.Unavailable, "down"), status.Error(codes.DeadlineExceeded, "slow")},
+			wantRequests: 3,
+			wantErrors:   map[string]int64{"Unavailable": 1, "DeadlineExceeded": 1},
+		},
+		{
+			name:         "retries_exhausted",
+			retry:        &configpb.ClientConf_ServerOptions_Retry{MaxAttempts: proto.Int32(2), InitialBackoffMsec: proto.Int32(1)},
+			errs:         []error{status.Error(codes.Unavailable, "down"), status.Error(codes.Unavailable, "down"), nil},
+			wantErr:      true,
+			wantRequests: 2,
+			wantErrors:   map[string]int64{"Unavailable": 2},
+		},
+		{
+			name:         "non_retriable",
+			retry:        &configpb.ClientConf_ServerOptions_Retry{InitialBackoffMsec: proto.Int32(1)},
+			errs:         []error{status.Error(codes.PermissionDenied, "denied")},
+			wantErr:      true,
+			wantRequests: 1,
+			wantErrors:   map[string]int64{"PermissionDenied": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			listResources := func(_ context.Context, _ *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+				calls++
+				if calls <= len(test.errs) && test.errs[calls-1] != nil {
+					return nil, test.errs[calls-1]
+				}
+				return &pb.ListResourcesResponse{Resources: []*pb.Resource{{Name: proto.String("r1")}}}, nil
+			}
+
+			client, err := New(&configpb.ClientConf{
+				ServerOptions: &configpb.ClientConf_ServerOptions{Retry: test.retry},
+				Request:       &pb.ListResourcesRequest{ResourcePath: proto.String("test")},
+				ReEvalSec:     proto.Int32(0),
+			}, listResources, &logger.Logger{})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			_, err = client.listResourcesWithRetry(context.Background(), client.c.GetRequest())
+			if (err != nil) != test.wantErr {
+				t.Errorf("listResourcesWithRetry() error = %v, wantErr = %v", err, test.wantErr)
+			}
+
+			em := client.Metrics()
+			assert.Equal(t, "test", em.Label("resource_path"))
+			assert.Equal(t, test.wantRequests, em.Metric("rds_client_requests").(*metrics.Int).Int64())
+			errs := em.Metric("rds_client_errors").(*metrics.Map[int64])
+			assert.Len(t, errs.Keys(), len(test.wantErrors))
+			for code, count := range test.wantErrors {
+				assert.Equal(t, count, errs.GetKey(code), "code: %s", code)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"math/rand"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+)
+
+// sampleResources returns a uniform random sample of n resources, using
+// selection sampling (Knuth's algorithm S), which retains the relative order
+// of the resources.
+func sampleResources(resources []*pb.Resource, n int, intn func(int) int) []*pb.Resource {
+	if len(resources) <= n {
+		return resources
+	}
+	sample := make([]*pb.Resource, 0, n)
+	for i, res := range resources {
+		if intn(len(resources)-i) < n-len(sample) {
+			sample = append(sample, res)
+		}
+	}
+	return sample
+}
+
+// sample returns a random sample of the resources as per the client config,
+// or the resources as it is if sampling is not configured. With sample_seed,
+// the same resources always produce the same sample.
+func (client *Client) sample(resources []*pb.Resource) []*pb.Resource {
+	n := int(client.c.GetSampleSize())
+	if n <= 0 {
+		return resources
+	}
+	intn := rand.Intn
+	if client.c.SampleSeed != nil {
+		intn = rand.New(rand.NewSource(client.c.GetSampleSeed())).Intn
+	}
+	return sampleResources(resources, n, intn)
+}
+
+// resampleEveryRefresh tells whether a fresh sample should be chosen at every
+// refresh, in which case full resources are requested from the server every
+// time, irrespective of their last modification.
+func (client *Client) resampleEveryRefresh() bool {
+	return client.c.GetSampleSize() > 0 && client.c.SampleSeed == nil
+}
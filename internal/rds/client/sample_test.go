@@ -0,0 +1,128 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server"
+	serverpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testSampleResources(n int) []*pb.Resource {
+	var resources []*pb.Resource
+	for i := 0; i < n; i++ {
+		resources = append(resources, &pb.Resource{
+			Name: proto.String(fmt.Sprintf("r%02d", i)),
+			Ip:   proto.String(fmt.Sprintf("10.0.0.%d", i+1)),
+		})
+	}
+	return resources
+}
+
+func resourceNames(resources []*pb.Resource) []string {
+	var names []string
+	for _, res := range resources {
+		names = append(names, res.GetName())
+	}
+	return names
+}
+
+func TestSampleResources(t *testing.T) {
+	resources := testSampleResources(10)
+
+	assert.Len(t, sampleResources(resources, 20, rand.Intn), 10)
+	assert.Len(t, sampleResources(resources, 10, rand.Intn), 10)
+
+	// Sampling is uniform, and sample retains the order.
+	counts := make(map[string]int)
+	trials := 20000
+	for i := 0; i < trials; i++ {
+		sample := sampleResources(resources, 3, rand.Intn)
+		require.Len(t, sample, 3)
+		names := resourceNames(sample)
+		assert.IsIncreasing(t, names)
+		for _, name := range names {
+			counts[name]++
+		}
+	}
+	for _, res := range resources {
+		// Expected count is 3/10 of the trials, i.e. 6000.
+		assert.InDelta(t, 6000, counts[res.GetName()], 400, "resource: %s", res.GetName())
+	}
+}
+
+func TestClientSampling(t *testing.T) {
+	// Provider supports caching; sampling should work irrespective of that.
+	tp := &testProvider{
+		resources:           testSampleResources(50),
+		supportCacheControl: true,
+		lastModified:        time.Now().Unix(),
+	}
+	srv, err := server.New(context.Background(), &serverpb.ServerConf{}, map[string]server.Provider{testProviderName: tp}, &logger.Logger{})
+	require.NoError(t, err)
+
+	endpointNames := func(client *Client) string {
+		var names []string
+		for _, ep := range client.ListEndpoints() {
+			names = append(names, ep.Name)
+		}
+		return strings.Join(names, ",")
+	}
+
+	newClient := func(seed *int64) *Client {
+		t.Helper()
+		client, err := New(&configpb.ClientConf{
+			Request:    &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+			SampleSize: proto.Int32(5),
+			SampleSeed: seed,
+		}, srv.ListResources, &logger.Logger{})
+		require.NoError(t, err)
+		return client
+	}
+
+	// Without seed, a fresh sample is chosen at every refresh, even if the
+	// resources have not changed.
+	client := newClient(nil)
+	samples := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		client.refreshState(time.Second)
+		assert.Len(t, client.ListEndpoints(), 5)
+		samples[endpointNames(client)] = true
+		assert.Zero(t, tp.requestCache[len(tp.requestCache)-1].GetIfModifiedSince(), "if_modified_since")
+	}
+	assert.Greater(t, len(samples), 1)
+
+	// With seed, sample is reproducible.
+	want := endpointNames(newClient(proto.Int64(42)))
+	for i := 0; i < 5; i++ {
+		client := newClient(proto.Int64(42))
+		assert.Equal(t, want, endpointNames(client))
+		client.refreshState(time.Second)
+		assert.Equal(t, want, endpointNames(client))
+	}
+	assert.Len(t, strings.Split(want, ","), 5)
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// ResourceTransform post-processes the resources received from the RDS
+// server, e.g. to enrich them with labels from a CMDB, before they become
+// targets. It is called with the provider name and a copy of the resources,
+// and returns the resources to use. It may modify the resources in place.
+type ResourceTransform func(ctx context.Context, provider string, resources []*pb.Resource) ([]*pb.Resource, error)
+
+type namedTransform struct {
+	name string
+	f    ResourceTransform
+}
+
+var (
+	transformsMu sync.RWMutex
+	transforms   []namedTransform
+)
+
+// RegisterResourceTransform registers a resource transform. Registered
+// transforms are applied, in the registration order, to the resources after
+// each refresh, for all RDS clients. Transforms run outside the client's
+// lock, and their result is applied atomically, so targets can be listed
+// while transforms are running. Transforms are bounded by the refresh
+// timeout; if a transform fails, or doesn't return in time, client keeps the
+// resources from the last successful refresh.
+func RegisterResourceTransform(name string, f ResourceTransform) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms = append(transforms, namedTransform{name: name, f: f})
+}
+
+func registeredTransforms() []namedTransform {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	return append([]namedTransform(nil), transforms...)
+}
+
+// transformResources applies the registered transforms to the resources. It
+// returns an error if any of the transforms fails or if context is canceled
+// before the transforms return.
+func transformResources(ctx context.Context, provider string, resources []*pb.Resource) ([]*pb.Resource, error) {
+	ts := registeredTransforms()
+	if len(ts) == 0 {
+		return resources, nil
+	}
+
+	// Transforms work on a copy, as the resources may be shared with the
+	// provider, e.g. for the in-process providers.
+	out := make([]*pb.Resource, len(resources))
+	for i, res := range resources {
+		out[i] = proto.Clone(res).(*pb.Resource)
+	}
+
+	type result struct {
+		resources []*pb.Resource
+		err       error
+	}
+	for _, t := range ts {
+		resultChan := make(chan result, 1)
+		go func(in []*pb.Resource) {
+			res, err := t.f(ctx, provider, in)
+			resultChan <- result{res, err}
+		}(out)
+
+		select {
+		case r := <-resultChan:
+			if r.err != nil {
+				return nil, fmt.Errorf("resource transform (%s) failed: %w", t.name, r.err)
+			}
+			out = r.resources
+		case <-ctx.Done():
+			return nil, fmt.Errorf("resource transform (%s) didn't return in time: %w", t.name, ctx.Err())
+		}
+	}
+	return out, nil
+}
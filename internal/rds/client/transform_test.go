@@ -0,0 +1,131 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func setTestTransforms(t *testing.T, ts ...namedTransform) {
+	t.Helper()
+	transformsMu.Lock()
+	old := transforms
+	transforms = ts
+	transformsMu.Unlock()
+	t.Cleanup(func() {
+		transformsMu.Lock()
+		transforms = old
+		transformsMu.Unlock()
+	})
+}
+
+func TestTransformResources(t *testing.T) {
+	resources := []*pb.Resource{
+		{Name: proto.String("r1"), Labels: map[string]string{"zone": "a"}},
+		{Name: proto.String("r2")},
+	}
+
+	var gotProvider string
+	addOwner := func(_ context.Context, provider string, in []*pb.Resource) ([]*pb.Resource, error) {
+		gotProvider = provider
+		for _, res := range in {
+			if res.Labels == nil {
+				res.Labels = make(map[string]string)
+			}
+			res.Labels["owner"] = "team-" + res.GetName()
+		}
+		return in, nil
+	}
+	dropR2 := func(_ context.Context, _ string, in []*pb.Resource) ([]*pb.Resource, error) {
+		return in[:1], nil
+	}
+
+	setTestTransforms(t, namedTransform{"add_owner", addOwner}, namedTransform{"drop_r2", dropR2})
+	out, err := transformResources(context.Background(), "p1", resources)
+	require.NoError(t, err)
+	assert.Equal(t, "p1", gotProvider)
+	require.Len(t, out, 1)
+	assert.Equal(t, map[string]string{"zone": "a", "owner": "team-r1"}, out[0].GetLabels())
+
+	// Input resources are not modified.
+	assert.Equal(t, map[string]string{"zone": "a"}, resources[0].GetLabels())
+	assert.Nil(t, resources[1].GetLabels())
+
+	setTestTransforms(t, namedTransform{"fail", func(context.Context, string, []*pb.Resource) ([]*pb.Resource, error) {
+		return nil, errors.New("cmdb unavailable")
+	}})
+	_, err = transformResources(context.Background(), "p1", resources)
+	assert.ErrorContains(t, err, "cmdb unavailable")
+
+	// Transform that ignores the context is not waited for beyond the deadline.
+	block := make(chan struct{})
+	defer close(block)
+	setTestTransforms(t, namedTransform{"stuck", func(context.Context, string, []*pb.Resource) ([]*pb.Resource, error) {
+		<-block
+		return nil, nil
+	}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = transformResources(ctx, "p1", resources)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRefreshStateWithTransform(t *testing.T) {
+	tp := &testProvider{resources: []*pb.Resource{{Name: proto.String("r1"), Ip: proto.String("10.0.0.1")}}}
+	failing := false
+	setTestTransforms(t, namedTransform{"enrich", func(_ context.Context, _ string, in []*pb.Resource) ([]*pb.Resource, error) {
+		if failing {
+			return nil, errors.New("cmdb unavailable")
+		}
+		for _, res := range in {
+			res.Labels = map[string]string{"cmdb_id": "id-" + res.GetName()}
+		}
+		return in, nil
+	}})
+
+	c := &configpb.ClientConf{
+		Request:   &pb.ListResourcesRequest{Provider: proto.String(testProviderName)},
+		ReEvalSec: proto.Int32(0),
+	}
+	client, err := New(c, func(_ context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+		return tp.ListResources(req)
+	}, &logger.Logger{})
+	require.NoError(t, err)
+
+	eps := client.ListEndpoints()
+	require.Len(t, eps, 1)
+	assert.Equal(t, "id-r1", eps[0].Labels["cmdb_id"])
+	assert.Nil(t, tp.resources[0].GetLabels(), "provider's resources modified")
+
+	// On transform failure, last transformed resources are retained.
+	failing = true
+	tp.resources = append(tp.resources, &pb.Resource{Name: proto.String("r2")})
+	eps = client.ListEndpoints()
+	require.Len(t, eps, 1)
+	assert.Equal(t, "id-r1", eps[0].Labels["cmdb_id"])
+
+	failing = false
+	assert.Len(t, client.ListEndpoints(), 2)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package cache implements the resources cache shared by the RDS providers
+that refresh their resources periodically, or on change notifications, and
+serve ListResources requests from the last known resources.
+*/
+package cache
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// Cache holds a provider's resources, along with their last modified
+// time. It's safe for concurrent use.
+type Cache struct {
+	name            string
+	regexFilterKeys []string
+	l               *logger.Logger
+
+	mu           sync.RWMutex
+	resources    []*pb.Resource
+	lastModified int64
+}
+
+// New returns a new cache. Name is used only for logging.
+func New(name string, regexFilterKeys []string, l *logger.Logger) *Cache {
+	return &Cache{
+		name:            name,
+		regexFilterKeys: regexFilterKeys,
+		l:               l,
+	}
+}
+
+// Update replaces the cached resources and sets their last modified time,
+// if resources have changed or if this is the first update. Resources are
+// expected to be sorted in a stable order. Update reports whether the cached
+// resources were replaced.
+func (c *Cache) Update(resources []*pb.Resource, lastModified int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.lastModified != 0 && Equal(c.resources, resources) {
+		return false
+	}
+	c.resources = resources
+	c.lastModified = lastModified
+	return true
+}
+
+// LastModified returns the last modified time of the cached resources.
+func (c *Cache) LastModified() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastModified
+}
+
+// ListResources returns the cached resources matching the request's filters.
+func (c *Cache) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	lastModified := proto.Int64(c.lastModified)
+	if req.GetIfModifiedSince() != 0 && c.lastModified <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: lastModified}, nil
+	}
+
+	match, err := Matcher(req.GetFilter(), c.regexFilterKeys, c.l)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for _, res := range c.resources {
+		if match(res) {
+			resources = append(resources, res)
+		}
+	}
+
+	c.l.Infof("%s.ListResources: returning %d resources out of %d", c.name, len(resources), len(c.resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: lastModified,
+	}, nil
+}
+
+// Matcher parses the given filters and returns a function that tells whether
+// a resource matches them. Only the "name" regex filter and the labels
+// filters are supported.
+func Matcher(filters []*pb.Filter, regexFilterKeys []string, l *logger.Logger) (func(*pb.Resource) bool, error) {
+	allFilters, err := filter.ParseFilters(filters, regexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.LabelsFilter
+
+	return func(res *pb.Resource) bool {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), l) {
+			return false
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), l) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// Equal tells whether the two resource lists are equal.
+func Equal(a, b []*pb.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// RefreshLoop runs refresh every interval, and also whenever there is a
+// signal on refreshChan (which may be nil). Bursts of signals are debounced
+// by waiting for at least minInterval since the last refresh. Errors are
+// logged. RefreshLoop never returns.
+func RefreshLoop(interval time.Duration, refreshChan <-chan struct{}, minInterval time.Duration, refresh func() error, l *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastRefresh time.Time
+	for {
+		select {
+		case <-ticker.C:
+		case <-refreshChan:
+			if wait := minInterval - time.Since(lastRefresh); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		lastRefresh = time.Now()
+		if err := refresh(); err != nil {
+			l.Error(err.Error())
+		}
+	}
+}
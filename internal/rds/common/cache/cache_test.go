@@ -0,0 +1,139 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testResources(names ...string) []*pb.Resource {
+	var resources []*pb.Resource
+	for _, name := range names {
+		resources = append(resources, &pb.Resource{
+			Name:   proto.String(name),
+			Labels: map[string]string{"env": name[:1]},
+		})
+	}
+	return resources
+}
+
+func TestCacheUpdate(t *testing.T) {
+	c := New("test", []string{"name"}, nil)
+
+	// First update always goes through, even if empty.
+	assert.True(t, c.Update(nil, 10))
+	assert.Equal(t, int64(10), c.LastModified())
+
+	assert.True(t, c.Update(testResources("a1", "b1"), 20))
+	assert.Equal(t, int64(20), c.LastModified())
+
+	// Same resources don't change the last modified time.
+	assert.False(t, c.Update(testResources("a1", "b1"), 30))
+	assert.Equal(t, int64(20), c.LastModified())
+
+	assert.True(t, c.Update(testResources("a1"), 40))
+	assert.Equal(t, int64(40), c.LastModified())
+}
+
+func TestCacheListResources(t *testing.T) {
+	c := New("test", []string{"name"}, nil)
+	c.Update(testResources("a1", "a2", "b1"), 10)
+
+	tests := []struct {
+		name      string
+		req       *pb.ListResourcesRequest
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "no_filter",
+			req:       &pb.ListResourcesRequest{},
+			wantNames: []string{"a1", "a2", "b1"},
+		},
+		{
+			name: "name_filter",
+			req: &pb.ListResourcesRequest{
+				Filter: []*pb.Filter{{Key: proto.String("name"), Value: proto.String(".*1")}},
+			},
+			wantNames: []string{"a1", "b1"},
+		},
+		{
+			name: "labels_filter",
+			req: &pb.ListResourcesRequest{
+				Filter: []*pb.Filter{{Key: proto.String("labels.env"), Value: proto.String("a")}},
+			},
+			wantNames: []string{"a1", "a2"},
+		},
+		{
+			name: "unsupported_filter",
+			req: &pb.ListResourcesRequest{
+				Filter: []*pb.Filter{{Key: proto.String("zone"), Value: proto.String("a")}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "not_modified",
+			req:  &pb.ListResourcesRequest{IfModifiedSince: proto.Int64(10)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := c.ListResources(test.req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, res := range resp.GetResources() {
+				names = append(names, res.GetName())
+			}
+			assert.Equal(t, test.wantNames, names)
+			assert.Equal(t, int64(10), resp.GetLastModified())
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal(nil, nil))
+	assert.True(t, Equal(testResources("a1", "b1"), testResources("a1", "b1")))
+	assert.False(t, Equal(testResources("a1", "b1"), testResources("a1")))
+	assert.False(t, Equal(testResources("a1", "b1"), testResources("b1", "a1")))
+}
+
+func TestRefreshLoop(t *testing.T) {
+	var refreshes atomic.Int32
+	refreshChan := make(chan struct{}, 1)
+
+	go RefreshLoop(time.Hour, refreshChan, 0, func() error {
+		refreshes.Add(1)
+		return errors.New("refresh error")
+	}, nil)
+
+	for i := 1; i <= 3; i++ {
+		refreshChan <- struct{}{}
+		assert.Eventually(t, func() bool { return refreshes.Load() == int32(i) }, time.Second, time.Millisecond)
+	}
+}
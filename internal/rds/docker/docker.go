@@ -0,0 +1,372 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package docker implements a Docker-based targets provider for cloudprober. It
+discovers running containers using the Docker Engine API.
+*/
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/rds/common/cache"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/docker/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "docker"
+
+/*
+SupportedFilters defines filters supported by the Docker-based resources
+type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.com.docker.compose.service"
+		 value: "web"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+// Minimum time between two refreshes triggered by events.
+const minEventRefreshInterval = time.Second
+
+// Container events that change the set of running containers.
+var watchedEvents = []string{"start", "die", "stop", "kill", "pause", "unpause", "destroy", "rename"}
+
+// container is a container as returned by the Docker containers list API.
+type container struct {
+	ID     string `json:"Id"`
+	Names  []string
+	Labels map[string]string
+	State  string
+	Ports  []struct {
+		IP          string
+		PrivatePort int
+		PublicPort  int
+		Type        string
+	}
+	NetworkSettings struct {
+		Networks map[string]struct {
+			IPAddress         string
+			GlobalIPv6Address string
+		}
+	}
+}
+
+// name returns the container name, without the leading "/".
+func (ct *container) name() string {
+	if len(ct.Names) == 0 {
+		return ct.ID
+	}
+	return strings.TrimPrefix(ct.Names[0], "/")
+}
+
+func (ct *container) ip(network string) string {
+	if network != "" {
+		n := ct.NetworkSettings.Networks[network]
+		if n.IPAddress != "" {
+			return n.IPAddress
+		}
+		return n.GlobalIPv6Address
+	}
+
+	var names []string
+	for name := range ct.NetworkSettings.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if ip := ct.ip(name); ip != "" {
+			return ip
+		}
+	}
+	return ""
+}
+
+// Provider provides a Docker-based targets provider for RDS. It implements
+// the RDS server's Provider interface.
+type Provider struct {
+	c       *configpb.ProviderConfig
+	client  *http.Client
+	baseURL string
+	l       *logger.Logger
+
+	cache *cache.Cache
+
+	refreshChan chan struct{}
+}
+
+func (p *Provider) apiURL(path string) string {
+	if p.c.GetApiVersion() != "" {
+		return p.baseURL + "/v" + strings.TrimPrefix(p.c.GetApiVersion(), "v") + path
+	}
+	return p.baseURL + path
+}
+
+// resource converts a container to a resource. It returns nil if container
+// doesn't have a usable address.
+func (p *Provider) resource(ct *container) *pb.Resource {
+	res := &pb.Resource{
+		Name:   proto.String(ct.name()),
+		Labels: ct.Labels,
+	}
+
+	published := p.c.GetAddressType() == configpb.ProviderConfig_PUBLISHED
+
+	// Pick the port: configured container port or the lowest one.
+	containerPort, port, bindIP := 0, 0, ""
+	for _, pt := range ct.Ports {
+		if published && pt.PublicPort == 0 {
+			continue
+		}
+		if p.c.GetContainerPort() != 0 && pt.PrivatePort != int(p.c.GetContainerPort()) {
+			continue
+		}
+		if containerPort == 0 || pt.PrivatePort < containerPort {
+			containerPort, port, bindIP = pt.PrivatePort, pt.PrivatePort, pt.IP
+			if published {
+				port = pt.PublicPort
+			}
+		}
+	}
+
+	if !published {
+		if p.c.GetContainerPort() != 0 {
+			port = int(p.c.GetContainerPort())
+		}
+		if ip := ct.ip(p.c.GetNetwork()); ip != "" {
+			res.Ip = proto.String(ip)
+		}
+	} else {
+		if port == 0 {
+			p.l.Debugf("docker: skipping container %s, no published ports", ct.name())
+			return nil
+		}
+		switch bindIP {
+		case "", "0.0.0.0":
+			bindIP = "127.0.0.1"
+		case "::":
+			bindIP = "::1"
+		}
+		res.Ip = proto.String(bindIP)
+	}
+
+	if port != 0 {
+		res.Port = proto.Int32(int32(port))
+	}
+	return res
+}
+
+func (p *Provider) listContainers() ([]*container, error) {
+	resp, err := p.client.Get(p.apiURL("/containers/json"))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("containers list API returned status %d: %s", resp.StatusCode, string(b))
+	}
+
+	var containers []*container
+	if err := json.Unmarshal(b, &containers); err != nil {
+		return nil, fmt.Errorf("error parsing containers list: %v", err)
+	}
+	return containers, nil
+}
+
+func (p *Provider) refresh() error {
+	containers, err := p.listContainers()
+	if err != nil {
+		return fmt.Errorf("docker: error listing containers: %v", err)
+	}
+
+	var resources []*pb.Resource
+	for _, ct := range containers {
+		// Containers list API returns only running containers, but we check
+		// it explicitly, for paused containers for example.
+		if ct.State != "" && ct.State != "running" {
+			continue
+		}
+		if res := p.resource(ct); res != nil {
+			resources = append(resources, res)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+
+	p.cache.Update(resources, time.Now().Unix())
+	return nil
+}
+
+// streamEvents reads container events from the Docker events stream, and
+// triggers a refresh for each event. It returns when stream ends.
+func (p *Provider) streamEvents(ctx context.Context) error {
+	filters, _ := json.Marshal(map[string][]string{
+		"type":  {"container"},
+		"event": watchedEvents,
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL("/events?filters="+url.QueryEscape(string(filters))), nil)
+	if err != nil {
+		return err
+	}
+
+	// Events stream is long-lived, so we don't use the client timeout here.
+	client := &http.Client{Transport: p.client.Transport}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("events API returned status %d", resp.StatusCode)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var event struct {
+			Action string
+		}
+		if err := dec.Decode(&event); err != nil {
+			return err
+		}
+		p.l.Debugf("docker: got container event: %s", event.Action)
+		select {
+		case p.refreshChan <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// watchEvents watches the Docker events stream, reconnecting on errors after
+// the given delay.
+func (p *Provider) watchEvents(retryDelay time.Duration) {
+	for {
+		err := p.streamEvents(context.Background())
+		p.l.Warningf("docker: events stream error: %v, retrying in %v", err, retryDelay)
+		time.Sleep(retryDelay)
+	}
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return p.cache.ListResources(req)
+}
+
+// newTransport returns the HTTP transport and the base URL for the Docker
+// daemon address.
+func newTransport(c *configpb.ProviderConfig) (*http.Transport, string, error) {
+	u, err := url.Parse(c.GetDockerHost())
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid docker_host (%s): %v", c.GetDockerHost(), err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		if c.GetTlsConfig() != nil {
+			return nil, "", fmt.Errorf("tls_config is not supported with unix sockets")
+		}
+		socketPath := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socketPath)
+			},
+		}
+		// Host part is not used when connecting over unix sockets.
+		return transport, "http://docker", nil
+	case "tcp", "http", "https":
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		scheme := "http"
+		if c.GetTlsConfig() != nil || u.Scheme == "https" {
+			scheme = "https"
+			transport.TLSClientConfig = &tls.Config{}
+			if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+				return nil, "", fmt.Errorf("error parsing tls_config: %v", err)
+			}
+		}
+		return transport, scheme + "://" + u.Host, nil
+	}
+	return nil, "", fmt.Errorf("unsupported docker_host scheme: %s", u.Scheme)
+}
+
+// New creates a Docker provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("docker: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	transport, baseURL, err := newTransport(c)
+	if err != nil {
+		return nil, fmt.Errorf("docker: %v", err)
+	}
+
+	p := &Provider{
+		c: c,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(c.GetTimeoutMsec()) * time.Millisecond,
+		},
+		baseURL:     baseURL,
+		l:           l,
+		cache:       cache.New("docker", SupportedFilters.RegexFilterKeys, l),
+		refreshChan: make(chan struct{}, 1),
+	}
+
+	// Initial refresh is done synchronously, but we don't fail if Docker
+	// daemon is not reachable yet; resources will be populated by the refresh
+	// loop.
+	if err := p.refresh(); err != nil {
+		l.Error(err.Error())
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	if c.GetWatchEvents() {
+		go p.watchEvents(reEvalInterval)
+	}
+	go cache.RefreshLoop(reEvalInterval, p.refreshChan, minEventRefreshInterval, p.refresh, l)
+
+	return p, nil
+}
@@ -0,0 +1,261 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package docker
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/docker/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const testContainers = `[
+  {
+    "Id": "8dfafdbc3a40",
+    "Names": ["/web-1"],
+    "State": "running",
+    "Labels": {"com.docker.compose.service": "web"},
+    "Ports": [
+      {"IP": "0.0.0.0", "PrivatePort": 8080, "PublicPort": 18080, "Type": "tcp"},
+      {"IP": "::", "PrivatePort": 8080, "PublicPort": 18080, "Type": "tcp"},
+      {"PrivatePort": 9090, "Type": "tcp"}
+    ],
+    "NetworkSettings": {"Networks": {
+      "frontend": {"IPAddress": "172.18.0.2"},
+      "backend": {"IPAddress": "172.19.0.2"}
+    }}
+  },
+  {
+    "Id": "9cd87474be90",
+    "Names": ["/db"],
+    "State": "running",
+    "Labels": {"com.docker.compose.service": "db"},
+    "Ports": [{"PrivatePort": 5432, "Type": "tcp"}],
+    "NetworkSettings": {"Networks": {"backend": {"IPAddress": "172.19.0.3"}}}
+  },
+  {
+    "Id": "3176a2479c92",
+    "Names": ["/paused"],
+    "State": "paused",
+    "NetworkSettings": {"Networks": {"backend": {"IPAddress": "172.19.0.4"}}}
+  }
+]`
+
+// fakeDocker is a fake Docker daemon, listening on a unix socket.
+type fakeDocker struct {
+	socket string
+
+	mu         sync.Mutex
+	containers string
+	paths      []string
+	events     chan string
+}
+
+func newFakeDocker(t *testing.T) *fakeDocker {
+	t.Helper()
+
+	fd := &fakeDocker{
+		socket:     filepath.Join(t.TempDir(), "docker.sock"),
+		containers: testContainers,
+		events:     make(chan string, 10),
+	}
+
+	ln, err := net.Listen("unix", fd.socket)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fd.mu.Lock()
+		fd.paths = append(fd.paths, r.URL.Path)
+		containers := fd.containers
+		fd.mu.Unlock()
+
+		switch filepath.Base(r.URL.Path) {
+		case "json":
+			fmt.Fprint(w, containers)
+		case "events":
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case <-r.Context().Done():
+					return
+				case action := <-fd.events:
+					fmt.Fprintf(w, `{"Type":"container","Action":"%s"}`+"\n", action)
+					w.(http.Flusher).Flush()
+				}
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	t.Cleanup(func() { srv.Close() })
+
+	return fd
+}
+
+func (fd *fakeDocker) setContainers(s string) {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	fd.containers = s
+}
+
+func (fd *fakeDocker) lastPath() string {
+	fd.mu.Lock()
+	defer fd.mu.Unlock()
+	return fd.paths[len(fd.paths)-1]
+}
+
+func resourcesString(resources []*pb.Resource) []string {
+	var out []string
+	for _, res := range resources {
+		out = append(out, fmt.Sprintf("%s %s:%d %v", res.GetName(), res.GetIp(), res.GetPort(), res.GetLabels()))
+	}
+	return out
+}
+
+func TestListResources(t *testing.T) {
+	fd := newFakeDocker(t)
+
+	tests := []struct {
+		name   string
+		c      *configpb.ProviderConfig
+		filter map[string]string
+		want   []string
+	}{
+		{
+			name: "default",
+			c:    &configpb.ProviderConfig{},
+			want: []string{
+				"db 172.19.0.3:5432 map[com.docker.compose.service:db]",
+				"web-1 172.19.0.2:8080 map[com.docker.compose.service:web]",
+			},
+		},
+		{
+			name: "network-and-port",
+			c: &configpb.ProviderConfig{
+				Network:       proto.String("frontend"),
+				ContainerPort: proto.Int32(9090),
+			},
+			want: []string{
+				"db :9090 map[com.docker.compose.service:db]",
+				"web-1 172.18.0.2:9090 map[com.docker.compose.service:web]",
+			},
+		},
+		{
+			name: "published",
+			c: &configpb.ProviderConfig{
+				AddressType: configpb.ProviderConfig_PUBLISHED.Enum(),
+			},
+			want: []string{
+				"web-1 127.0.0.1:18080 map[com.docker.compose.service:web]",
+			},
+		},
+		{
+			name:   "label-filter",
+			c:      &configpb.ProviderConfig{},
+			filter: map[string]string{"labels.com.docker.compose.service": "db"},
+			want: []string{
+				"db 172.19.0.3:5432 map[com.docker.compose.service:db]",
+			},
+		},
+		{
+			name:   "name-filter",
+			c:      &configpb.ProviderConfig{},
+			filter: map[string]string{"name": "web-.*"},
+			want: []string{
+				"web-1 172.19.0.2:8080 map[com.docker.compose.service:web]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.DockerHost = proto.String("unix://" + fd.socket)
+			p, err := New(test.c, nil)
+			require.NoError(t, err)
+
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filter {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+			resp, err := p.ListResources(req)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, resourcesString(resp.GetResources()))
+		})
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	fd := newFakeDocker(t)
+
+	_, err := New(&configpb.ProviderConfig{
+		DockerHost: proto.String("unix://" + fd.socket),
+		ApiVersion: proto.String("1.43"),
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/v1.43/containers/json", fd.lastPath())
+}
+
+func TestWatchEvents(t *testing.T) {
+	fd := newFakeDocker(t)
+
+	p, err := New(&configpb.ProviderConfig{
+		DockerHost:  proto.String("unix://" + fd.socket),
+		WatchEvents: proto.Bool(true),
+		ReEvalSec:   proto.Int32(3600),
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 2)
+
+	// web-1 stops.
+	fd.setContainers(`[{"Id": "9cd87474be90", "Names": ["/db"], "State": "running"}]`)
+	fd.events <- "die"
+
+	assert.Eventually(t, func() bool {
+		resp, err := p.ListResources(&pb.ListResourcesRequest{})
+		return err == nil && len(resp.GetResources()) == 1 && resp.GetResources()[0].GetName() == "db"
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, c := range []*configpb.ProviderConfig{
+		{ReEvalSec: proto.Int32(0)},
+		{DockerHost: proto.String("ssh://docker-host")},
+		{DockerHost: proto.String("unix:///var/run/docker.sock"), TlsConfig: nil, ReEvalSec: proto.Int32(-1)},
+	} {
+		_, err := New(c, nil)
+		assert.Error(t, err, "config: %v", c)
+	}
+
+	transport, baseURL, err := newTransport(&configpb.ProviderConfig{DockerHost: proto.String("tcp://docker-host:2375")})
+	assert.NoError(t, err)
+	assert.NotNil(t, transport)
+	assert.Equal(t, "http://docker-host:2375", baseURL)
+}
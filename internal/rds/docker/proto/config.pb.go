@@ -0,0 +1,361 @@
+// Configuration proto for Docker provider.
+//
+// Docker provider discovers running containers using the Docker Engine API.
+// Each running container becomes a resource, with container labels as the
+// resource labels.
+//
+// Example provider config:
+// {
+//   docker_host: "unix:///var/run/docker.sock"
+//   watch_events: true
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "docker://"
+//       filter {
+//         key: "labels.com.docker.compose.service"
+//         value: "web"
+//       }
+//     }
+//   }
+// }
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/rds/docker/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProviderConfig_AddressType int32
+
+const (
+	// Use container's IP address and the container port. This works if
+	// cloudprober can reach the container network, e.g. on the same host.
+	ProviderConfig_CONTAINER_IP ProviderConfig_AddressType = 0
+	// Use the published host port and the IP it's bound to. Unspecified bind
+	// addresses (0.0.0.0 and ::) are replaced by the loopback address.
+	// Containers without published ports are skipped.
+	ProviderConfig_PUBLISHED ProviderConfig_AddressType = 1
+)
+
+// Enum value maps for ProviderConfig_AddressType.
+var (
+	ProviderConfig_AddressType_name = map[int32]string{
+		0: "CONTAINER_IP",
+		1: "PUBLISHED",
+	}
+	ProviderConfig_AddressType_value = map[string]int32{
+		"CONTAINER_IP": 0,
+		"PUBLISHED":    1,
+	}
+)
+
+func (x ProviderConfig_AddressType) Enum() *ProviderConfig_AddressType {
+	p := new(ProviderConfig_AddressType)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_AddressType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_AddressType) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ProviderConfig_AddressType) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_enumTypes[0]
+}
+
+func (x ProviderConfig_AddressType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_AddressType) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_AddressType(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_AddressType.Descriptor instead.
+func (ProviderConfig_AddressType) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ProviderConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Docker daemon address, either a unix socket (unix:///path/to/socket) or
+	// a TCP address (tcp://host:port).
+	DockerHost *string `protobuf:"bytes,1,opt,name=docker_host,json=dockerHost,def=unix:///var/run/docker.sock" json:"docker_host,omitempty"`
+	// TLS config for connecting to the Docker daemon over TCP.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,2,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// Docker API version to use, e.g. "1.43". If not specified, unversioned
+	// API paths are used, i.e. daemon's current API version.
+	ApiVersion  *string                     `protobuf:"bytes,3,opt,name=api_version,json=apiVersion" json:"api_version,omitempty"`
+	AddressType *ProviderConfig_AddressType `protobuf:"varint,4,opt,name=address_type,json=addressType,enum=cloudprober.rds.docker.ProviderConfig_AddressType" json:"address_type,omitempty"`
+	// Container port to use for the resources. By default, the lowest
+	// container port (exposed or published, depending on the address_type) is
+	// used.
+	ContainerPort *int32 `protobuf:"varint,5,opt,name=container_port,json=containerPort" json:"container_port,omitempty"`
+	// Network to get container's IP address from. By default, first network
+	// (in alphabetical order) is used.
+	Network *string `protobuf:"bytes,6,opt,name=network" json:"network,omitempty"`
+	// How often to refresh resources.
+	ReEvalSec *int32 `protobuf:"varint,7,opt,name=re_eval_sec,json=reEvalSec,def=30" json:"re_eval_sec,omitempty"`
+	// If enabled, provider watches the Docker events stream and refreshes
+	// resources as soon as containers start or stop. Periodic refresh still
+	// happens every re_eval_sec.
+	WatchEvents *bool `protobuf:"varint,8,opt,name=watch_events,json=watchEvents" json:"watch_events,omitempty"`
+	// Timeout for the Docker API requests (except for the events stream).
+	TimeoutMsec *int32 `protobuf:"varint,9,opt,name=timeout_msec,json=timeoutMsec,def=5000" json:"timeout_msec,omitempty"`
+}
+
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_DockerHost  = string("unix:///var/run/docker.sock")
+	Default_ProviderConfig_ReEvalSec   = int32(30)
+	Default_ProviderConfig_TimeoutMsec = int32(5000)
+)
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderConfig) GetDockerHost() string {
+	if x != nil && x.DockerHost != nil {
+		return *x.DockerHost
+	}
+	return Default_ProviderConfig_DockerHost
+}
+
+func (x *ProviderConfig) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetApiVersion() string {
+	if x != nil && x.ApiVersion != nil {
+		return *x.ApiVersion
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetAddressType() ProviderConfig_AddressType {
+	if x != nil && x.AddressType != nil {
+		return *x.AddressType
+	}
+	return ProviderConfig_CONTAINER_IP
+}
+
+func (x *ProviderConfig) GetContainerPort() int32 {
+	if x != nil && x.ContainerPort != nil {
+		return *x.ContainerPort
+	}
+	return 0
+}
+
+func (x *ProviderConfig) GetNetwork() string {
+	if x != nil && x.Network != nil {
+		return *x.Network
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_ProviderConfig_ReEvalSec
+}
+
+func (x *ProviderConfig) GetWatchEvents() bool {
+	if x != nil && x.WatchEvents != nil {
+		return *x.WatchEvents
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_TimeoutMsec
+}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x16, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x64, 0x6f, 0x63,
+	0x6b, 0x65, 0x72, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x03,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x3c, 0x0a, 0x0b, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x1b, 0x75, 0x6e, 0x69, 0x78, 0x3a, 0x2f, 0x2f, 0x2f, 0x76,
+	0x61, 0x72, 0x2f, 0x72, 0x75, 0x6e, 0x2f, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x2e, 0x73, 0x6f,
+	0x63, 0x6b, 0x52, 0x0a, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x3f,
+	0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x55, 0x0a, 0x0c, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x64, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x2e,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x22, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33,
+	0x30, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x21, 0x0a, 0x0c,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x77, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x27, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x35, 0x30, 0x30, 0x30, 0x52, 0x0b, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x22, 0x2e, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4e, 0x54, 0x41,
+	0x49, 0x4e, 0x45, 0x52, 0x5f, 0x49, 0x50, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x55, 0x42,
+	0x4c, 0x49, 0x53, 0x48, 0x45, 0x44, 0x10, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x64, 0x6f, 0x63, 0x6b,
+	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_goTypes = []any{
+	(ProviderConfig_AddressType)(0), // 0: cloudprober.rds.docker.ProviderConfig.AddressType
+	(*ProviderConfig)(nil),          // 1: cloudprober.rds.docker.ProviderConfig
+	(*proto.TLSConfig)(nil),         // 2: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_depIdxs = []int32{
+	2, // 0: cloudprober.rds.docker.ProviderConfig.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	0, // 1: cloudprober.rds.docker.ProviderConfig.address_type:type_name -> cloudprober.rds.docker.ProviderConfig.AddressType
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_enumTypes,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_docker_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+)
+
+// handleDuplicates counts the resources with duplicate names in the file,
+// and handles them as per the configured policy: it either returns an error,
+// or removes the duplicates (optionally merging their labels). Resources are
+// considered in the file order: top-level resources first, followed by the
+// sections' resources.
+func (ls *lister) handleDuplicates(resources *configpb.FileResources) error {
+	lists := [][]*targetspb.Endpoint{resources.GetResource()}
+	for _, sec := range resources.GetSection() {
+		lists = append(lists, sec.GetResource())
+	}
+
+	// Resource to keep for each name.
+	keep := make(map[string]*targetspb.Endpoint)
+	var dupNames []string
+	for _, list := range lists {
+		for _, res := range list {
+			first, ok := keep[res.GetName()]
+			if !ok {
+				keep[res.GetName()] = res
+				continue
+			}
+			dupNames = append(dupNames, res.GetName())
+
+			switch ls.onDuplicateName {
+			case configpb.ProviderConfig_KEEP_LAST:
+				keep[res.GetName()] = res
+			case configpb.ProviderConfig_MERGE_LABELS:
+				for k, v := range res.GetLabels() {
+					if first.Labels == nil {
+						first.Labels = make(map[string]string)
+					}
+					first.Labels[k] = v
+				}
+			}
+		}
+	}
+
+	if len(dupNames) == 0 {
+		return nil
+	}
+	ls.duplicates.Add(int64(len(dupNames)))
+
+	switch ls.onDuplicateName {
+	case configpb.ProviderConfig_KEEP_ALL:
+		return nil
+	case configpb.ProviderConfig_FAIL:
+		return fmt.Errorf("file_provider(%s): duplicate resource names: %s", ls.filePath, strings.Join(dupNames, ","))
+	}
+
+	ls.l.Warningf("file_provider(%s): removing duplicate resources (policy: %v): %s", ls.filePath, ls.onDuplicateName, strings.Join(dupNames, ","))
+
+	dedup := func(list []*targetspb.Endpoint) []*targetspb.Endpoint {
+		out := list[:0]
+		for _, res := range list {
+			if keep[res.GetName()] == res {
+				out = append(out, res)
+			}
+		}
+		return out
+	}
+	resources.Resource = dedup(resources.GetResource())
+	for _, sec := range resources.GetSection() {
+		sec.Resource = dedup(sec.GetResource())
+	}
+	return nil
+}
+
+// DuplicateResourcesMetrics returns the number of resources with duplicate
+// names encountered so far (across all loads), as cumulative EventMetrics,
+// one for each file.
+func (p *Provider) DuplicateResourcesMetrics() []*metrics.EventMetrics {
+	ts := time.Now()
+	var ems []*metrics.EventMetrics
+	for _, fp := range p.filePaths {
+		ems = append(ems, metrics.NewEventMetrics(ts).
+			AddMetric("duplicate_resources", metrics.NewInt(p.listers[fp].duplicates.Load())).
+			AddLabel("ptype", "rds").
+			AddLabel("provider", DefaultProviderID).
+			AddLabel("file_path", fp))
+	}
+	return ems
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDuplicateResources = `
+resource {
+  name: "web-1"
+  ip: "10.0.0.1"
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "web-2"
+  ip: "10.0.0.2"
+}
+resource {
+  name: "web-1"
+  ip: "10.0.1.1"
+  labels { key: "zone" value: "b" }
+  labels { key: "rack" value: "r1" }
+}
+section {
+  name: "db"
+  resource {
+    name: "db-1"
+    ip: "10.0.2.1"
+  }
+  resource {
+    name: "web-2"
+    ip: "10.0.2.2"
+    labels { key: "role" value: "db" }
+  }
+}
+`
+
+func TestHandleDuplicates(t *testing.T) {
+	// Resource IPs by name, and section resource names.
+	tests := []struct {
+		policy       configpb.ProviderConfig_DuplicateNamePolicy
+		wantIPs      []string
+		wantSection  []string
+		wantLabels   map[string]string // web-1's labels.
+		wantWeb2Role string
+		wantErr      bool
+	}{
+		{
+			policy:      configpb.ProviderConfig_KEEP_ALL,
+			wantIPs:     []string{"10.0.0.1", "10.0.0.2", "10.0.1.1"},
+			wantSection: []string{"db-1", "web-2"},
+			wantLabels:  map[string]string{"zone": "a"},
+		},
+		{
+			policy:  configpb.ProviderConfig_FAIL,
+			wantErr: true,
+		},
+		{
+			policy:      configpb.ProviderConfig_KEEP_FIRST,
+			wantIPs:     []string{"10.0.0.1", "10.0.0.2"},
+			wantSection: []string{"db-1"},
+			wantLabels:  map[string]string{"zone": "a"},
+		},
+		{
+			policy:      configpb.ProviderConfig_KEEP_LAST,
+			wantIPs:     []string{"10.0.1.1"},
+			wantSection: []string{"db-1", "web-2"},
+			wantLabels:  map[string]string{"zone": "b", "rack": "r1"},
+		},
+		{
+			policy:       configpb.ProviderConfig_MERGE_LABELS,
+			wantIPs:      []string{"10.0.0.1", "10.0.0.2"},
+			wantSection:  []string{"db-1"},
+			wantLabels:   map[string]string{"zone": "b", "rack": "r1"},
+			wantWeb2Role: "db",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			ls := &lister{
+				filePath:        "test.textpb",
+				format:          configpb.ProviderConfig_TEXTPB,
+				onDuplicateName: test.policy,
+			}
+			resources, err := ls.parseFileContent([]byte(testDuplicateResources))
+			assert.Equal(t, int64(2), ls.duplicates.Load())
+			if test.wantErr {
+				assert.ErrorContains(t, err, "web-1,web-2")
+				return
+			}
+			require.NoError(t, err)
+
+			var ips []string
+			for _, res := range resources.GetResource() {
+				ips = append(ips, res.GetIp())
+				switch res.GetName() {
+				case "web-1":
+					if test.policy != configpb.ProviderConfig_KEEP_ALL || res.GetIp() == "10.0.0.1" {
+						assert.Equal(t, test.wantLabels, res.GetLabels())
+					}
+				case "web-2":
+					assert.Equal(t, test.wantWeb2Role, res.GetLabels()["role"])
+				}
+			}
+			assert.Equal(t, test.wantIPs, ips)
+
+			var section []string
+			for _, res := range resources.GetSection()[0].GetResource() {
+				section = append(section, res.GetName())
+			}
+			assert.Equal(t, test.wantSection, section)
+
+			p := &Provider{filePaths: []string{ls.filePath}, listers: map[string]*lister{ls.filePath: ls}}
+			ems := p.DuplicateResourcesMetrics()
+			require.Len(t, ems, 1)
+			assert.Equal(t, "2", ems[0].Metric("duplicate_resources").String())
+		})
+	}
+}
@@ -20,17 +20,25 @@ package file
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"net"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
 
 	"github.com/cloudprober/cloudprober/internal/file"
 	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
 	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
@@ -71,8 +79,71 @@ type lister struct {
 	resources []*pb.Resource
 	l         *logger.Logger
 
+	// sections are the resources grouped by the file's section names. Note
+	// that resources above include the sections' resources as well.
+	sections map[string][]*pb.Resource
+
 	lastUpdated  time.Time
 	checkModTime bool
+
+	// lastVersion is the version of the file (e.g. GCS generation or S3 ETag)
+	// at the time of the last successful load. It's used, instead of the mod
+	// time, to decide whether to reload the file, if file backend supports
+	// versions.
+	lastVersion string
+
+	// defaultLabels are added to the resources missing those label keys.
+	defaultLabels map[string]string
+
+	// nameLabelRe, if set, is used to extract labels from resource names.
+	nameLabelRe *regexp.Regexp
+
+	// If set, resources' IPs are reverse-resolved to add a label.
+	reverseResolver *reverseResolver
+
+	// parsePortFromName, if set, splits "host:port" resource names into name
+	// and port.
+	parsePortFromName bool
+
+	// typedLabels are the labels with declared types.
+	typedLabels map[string]configpb.ProviderConfig_LabelType
+
+	// filterStats tracks filters' match rate.
+	filterStats filterStatsMap
+
+	// refreshStats tracks file refreshes.
+	refreshStats refreshStats
+
+	// validator, if configured, validates resources at load time.
+	validator *resourceValidator
+
+	// sourceLabel, if set, is the label that resources' source file path is
+	// added as. It's set in the merge mode.
+	sourceLabel string
+
+	// onDuplicateName is the policy for resources with duplicate names, and
+	// duplicates is the number of duplicates encountered so far.
+	onDuplicateName configpb.ProviderConfig_DuplicateNamePolicy
+	duplicates      atomic.Int64
+
+	// ready is closed after the first successful load.
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	// If set, label keys are lowercased and non-alphanumeric characters in
+	// them are replaced by '_'.
+	normalizeLabelKeys bool
+
+	// If tail is set, only the lines appended to the file since its last read
+	// are loaded on refresh.
+	tail      bool
+	tailState tailState
+
+	// If sentinel is set, file is reloaded only when the version in the
+	// sentinel file changes. lastSentinelVersion is the sentinel version at
+	// the time of the last successful load.
+	sentinel            *sentinel
+	lastSentinelVersion string
 }
 
 func (ls *lister) lastModified() int64 {
@@ -83,18 +154,50 @@ func (ls *lister) lastModified() int64 {
 
 // listResources returns the last successfully parsed list of resources.
 func (ls *lister) listResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return ls.listSectionResources(req, "")
+}
+
+// listSectionResources is similar to listResources, but if section is not
+// empty, only that section's resources are considered.
+func (ls *lister) listSectionResources(req *pb.ListResourcesRequest, section string) (*pb.ListResourcesResponse, error) {
 	ls.mu.RLock()
 	defer ls.mu.RUnlock()
 
+	allResources := ls.resources
+	if section != "" {
+		var ok bool
+		if allResources, ok = ls.sections[section]; !ok {
+			return nil, fmt.Errorf("section %s is not available in the file %s", section, ls.filePath)
+		}
+	}
+
 	// If there are no filters, return early.
 	if len(req.GetFilter()) == 0 {
 		return &pb.ListResourcesResponse{
-			Resources:    append([]*pb.Resource{}, ls.resources...),
+			Resources:    append([]*pb.Resource{}, allResources...),
 			LastModified: proto.Int64(ls.lastUpdated.Unix()),
 		}, nil
 	}
 
-	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	resources, err := filterResources(req.GetFilter(), allResources, ls.l)
+	if err != nil {
+		return nil, err
+	}
+
+	ls.filterStats.record(req.GetFilter(), len(allResources), len(resources))
+	if len(resources) == 0 && len(allResources) != 0 {
+		ls.l.Warningf("file.ListResources: filters (%s) didn't match any of the %d resources", filtersKey(req.GetFilter()), len(allResources))
+	}
+	ls.l.Infof("file.ListResources: returning %d resources out of %d", len(resources), len(allResources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: proto.Int64(ls.lastUpdated.Unix()),
+	}, nil
+}
+
+// filterResources returns the resources matching the given filters.
+func filterResources(filters []*pb.Filter, allResources []*pb.Resource, l *logger.Logger) ([]*pb.Resource, error) {
+	allFilters, err := filter.ParseFilters(filters, SupportedFilters.RegexFilterKeys, "")
 	if err != nil {
 		return nil, err
 	}
@@ -102,27 +205,22 @@ func (ls *lister) listResources(req *pb.ListResourcesRequest) (*pb.ListResources
 
 	// Allocate resources for response early but optimize for large number of
 	// total resources.
-	allocSize := len(ls.resources)
+	allocSize := len(allResources)
 	if allocSize > 100 {
 		allocSize = 100
 	}
 	resources := make([]*pb.Resource, 0, allocSize)
 
-	for _, res := range ls.resources {
-		if nameFilter != nil && !nameFilter.Match(res.GetName(), ls.l) {
+	for _, res := range allResources {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), l) {
 			continue
 		}
-		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), ls.l) {
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), l) {
 			continue
 		}
 		resources = append(resources, res)
 	}
-
-	ls.l.Infof("file.ListResources: returning %d resources out of %d", len(resources), len(ls.resources))
-	return &pb.ListResourcesResponse{
-		Resources:    resources,
-		LastModified: proto.Int64(ls.lastUpdated.Unix()),
-	}, nil
+	return resources, nil
 }
 
 func (ls *lister) parseFileContent(b []byte) (*configpb.FileResources, error) {
@@ -134,13 +232,11 @@ func (ls *lister) parseFileContent(b []byte) (*configpb.FileResources, error) {
 		if err != nil {
 			return nil, fmt.Errorf("file_provider(%s): error unmarshaling as text proto: %v", ls.filePath, err)
 		}
-		return resources, nil
 	case configpb.ProviderConfig_JSON:
 		err := protojson.Unmarshal(b, resources)
 		if err != nil {
 			return nil, fmt.Errorf("file_provider(%s): error unmarshaling as JSON: %v", ls.filePath, err)
 		}
-		return resources, nil
 	case configpb.ProviderConfig_YAML:
 		jsonCfg, err := yaml.YAMLToJSON(b)
 		if err != nil {
@@ -149,32 +245,237 @@ func (ls *lister) parseFileContent(b []byte) (*configpb.FileResources, error) {
 		if err := protojson.Unmarshal(jsonCfg, resources); err != nil {
 			return nil, fmt.Errorf("error unmarshaling intermediate JSON to proto: %v", err)
 		}
-		return resources, nil
+	case configpb.ProviderConfig_PROMETHEUS_SD:
+		var err error
+		if resources, err = parsePrometheusSD(b, ls.filePath); err != nil {
+			return nil, fmt.Errorf("file_provider(%s): error parsing Prometheus file_sd: %v", ls.filePath, err)
+		}
+	case configpb.ProviderConfig_JSONL:
+		var err error
+		if resources, err = parseJSONLines(b, 0); err != nil {
+			return nil, fmt.Errorf("file_provider(%s): %v", ls.filePath, err)
+		}
+	default:
+		return nil, fmt.Errorf("file_provider(%s): unknown format - %v", ls.filePath, ls.format)
 	}
 
-	return nil, fmt.Errorf("file_provider(%s): unknown format - %v", ls.filePath, ls.format)
+	if err := ls.processResources(resources); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, sec := range resources.GetSection() {
+		if sec.GetName() == "" {
+			return nil, fmt.Errorf("file_provider(%s): section name cannot be empty", ls.filePath)
+		}
+		if seen[sec.GetName()] {
+			return nil, fmt.Errorf("file_provider(%s): duplicate section name: %s", ls.filePath, sec.GetName())
+		}
+		seen[sec.GetName()] = true
+
+		secResources := &configpb.FileResources{Resource: sec.GetResource()}
+		if err := ls.processResources(secResources); err != nil {
+			return nil, err
+		}
+		sec.Resource = secResources.GetResource()
+	}
+
+	if err := ls.handleDuplicates(resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// processResources normalizes label keys, parses ports from names, applies
+// name, reverse DNS and default labels to the resources, and validates them.
+func (ls *lister) processResources(resources *configpb.FileResources) error {
+	ls.applyLabelKeyNormalization(resources)
+	ls.applyNamePorts(resources)
+	ls.applyNameLabels(resources)
+	if ls.reverseResolver != nil {
+		ls.reverseResolver.apply(resources.GetResource())
+	}
+	ls.applyDefaultLabels(resources)
+	return ls.validateResources(resources)
+}
+
+// normalizeLabelKey lowercases the label key and replaces non-alphanumeric
+// characters in it by '_'.
+func normalizeLabelKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		r = unicode.ToLower(r)
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, k)
 }
 
-func (ls *lister) shouldReloadFile() bool {
+// applyLabelKeyNormalization normalizes the label keys of the resources.
+// Keys are processed in the sorted order, so that the outcome of collisions
+// is deterministic: an already normalized key wins, otherwise the first key.
+func (ls *lister) applyLabelKeyNormalization(resources *configpb.FileResources) {
+	if !ls.normalizeLabelKeys {
+		return
+	}
+
+	var collisions []string
+	for _, res := range resources.GetResource() {
+		if len(res.GetLabels()) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(res.GetLabels()))
+		for k := range res.GetLabels() {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+
+		labels := make(map[string]string, len(keys))
+		for _, k := range keys {
+			nk := normalizeLabelKey(k)
+			if _, ok := labels[nk]; ok {
+				collisions = append(collisions, res.GetName()+":"+k)
+				if k != nk {
+					continue
+				}
+			}
+			labels[nk] = res.GetLabels()[k]
+		}
+		res.Labels = labels
+	}
+
+	if len(collisions) != 0 {
+		ls.l.Warningf("file_provider(%s): label keys colliding after normalization (resource:key): %s", ls.filePath, strings.Join(collisions, ","))
+	}
+}
+
+// applyNamePorts splits "host:port" names of the resources that don't have
+// a port into name and port.
+func (ls *lister) applyNamePorts(resources *configpb.FileResources) {
+	if !ls.parsePortFromName {
+		return
+	}
+
+	for _, res := range resources.GetResource() {
+		if res.GetPort() != 0 {
+			continue
+		}
+		host, portStr, err := net.SplitHostPort(res.GetName())
+		if err != nil || host == "" {
+			continue
+		}
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil || port == 0 {
+			ls.l.Warningf("file_provider(%s): invalid port in resource name: %s", ls.filePath, res.GetName())
+			continue
+		}
+		res.Name = proto.String(host)
+		res.Port = proto.Int32(int32(port))
+	}
+}
+
+// applyNameLabels adds labels extracted from resource names using the named
+// capture groups of the name label regex. Explicitly set labels take
+// precedence.
+func (ls *lister) applyNameLabels(resources *configpb.FileResources) {
+	if ls.nameLabelRe == nil {
+		return
+	}
+
+	for _, res := range resources.GetResource() {
+		matches := ls.nameLabelRe.FindStringSubmatch(res.GetName())
+		if matches == nil {
+			continue
+		}
+		for i, key := range ls.nameLabelRe.SubexpNames() {
+			if key == "" {
+				continue
+			}
+			if res.Labels == nil {
+				res.Labels = make(map[string]string)
+			}
+			if _, ok := res.Labels[key]; !ok {
+				res.Labels[key] = matches[i]
+			}
+		}
+	}
+}
+
+// applyDefaultLabels adds default labels to the resources that don't have
+// those label keys set already. Explicitly set labels always take precedence.
+func (ls *lister) applyDefaultLabels(resources *configpb.FileResources) {
+	if len(ls.defaultLabels) == 0 {
+		return
+	}
+
+	for _, res := range resources.GetResource() {
+		if res.Labels == nil {
+			res.Labels = make(map[string]string, len(ls.defaultLabels))
+		}
+		for k, v := range ls.defaultLabels {
+			if _, ok := res.Labels[k]; !ok {
+				res.Labels[k] = v
+			}
+		}
+	}
+}
+
+// shouldReloadFile returns whether the file should be reloaded, along with
+// the file's current version (if available). If file's backend supports
+// versions (GCS, S3), version is used to determine whether file has changed,
+// otherwise we rely on the file's modified time.
+func (ls *lister) shouldReloadFile() (bool, string) {
 	if !ls.checkModTime {
-		return true
+		return true, ""
+	}
+
+	version, err := file.Version(context.Background(), ls.filePath)
+	if err != nil {
+		ls.l.Warningf("file(%s): Error getting file version: %v; Ignoring modified time check.", ls.filePath, err)
+		return true, ""
+	}
+	if version != "" {
+		ls.mu.RLock()
+		defer ls.mu.RUnlock()
+		return version != ls.lastVersion, version
 	}
 
 	modTime, err := file.ModTime(context.Background(), ls.filePath)
 	if err != nil {
 		ls.l.Warningf("file(%s): Error getting modified time: %v; Ignoring modified time check.", ls.filePath, err)
-		return true
+		return true, ""
 	}
 
 	ls.mu.RLock()
 	defer ls.mu.RUnlock()
-	return modTime.After(ls.lastUpdated)
+	return modTime.After(ls.lastUpdated), ""
 }
 
+// refresh reloads the file if required. Note that if there is an error in
+// reading or parsing the file, we keep serving the last successfully loaded
+// resources.
 func (ls *lister) refresh() error {
-	if !ls.shouldReloadFile() {
-		ls.l.Infof("file(%s): Skipping reloading file as it has not changed since its last refresh at %v", ls.filePath, ls.lastUpdated)
-		return nil
+	if ls.tail {
+		return ls.refreshTail()
+	}
+
+	var sentinelVersion, version string
+	if ls.sentinel != nil {
+		var changed bool
+		if sentinelVersion, changed = ls.checkSentinel(); sentinelVersion != "" && !changed {
+			ls.l.Infof("file(%s): Skipping reloading file as sentinel version (%s) has not changed since its last refresh at %v", ls.filePath, sentinelVersion, ls.lastUpdated)
+			return nil
+		}
+	}
+
+	// Fall back to the regular checks if there is no sentinel version.
+	if sentinelVersion == "" {
+		var reload bool
+		if reload, version = ls.shouldReloadFile(); !reload {
+			ls.l.Infof("file(%s): Skipping reloading file as it has not changed since its last refresh at %v", ls.filePath, ls.lastUpdated)
+			return nil
+		}
 	}
 
 	b, err := file.ReadFile(context.Background(), ls.filePath)
@@ -187,20 +488,73 @@ func (ls *lister) refresh() error {
 		return err
 	}
 
+	resources, err := ls.toRDSResources(fileResources.GetResource())
+	if err != nil {
+		return err
+	}
+
+	var sections map[string][]*pb.Resource
+	for _, sec := range fileResources.GetSection() {
+		secResources, err := ls.toRDSResources(sec.GetResource())
+		if err != nil {
+			return err
+		}
+		if sections == nil {
+			sections = make(map[string][]*pb.Resource)
+		}
+		sections[sec.GetName()] = secResources
+		resources = append(resources, secResources...)
+	}
+
+	ls.l.Infof("file_provider(%s): Read %d endpoints in %d sections", ls.filePath, len(resources), len(sections))
+
 	ls.mu.Lock()
 	defer ls.mu.Unlock()
 
+	ls.resources = resources
+	ls.sections = sections
 	ls.lastUpdated = time.Now()
+	ls.lastVersion = version
+	ls.lastSentinelVersion = sentinelVersion
+	ls.refreshStats.recordReload()
 
-	endpoints, err := endpoint.FromProtoMessage(fileResources.GetResource())
-	if err != nil {
-		return fmt.Errorf("file_provider(%s): error parsing endpoints: %v", ls.filePath, err)
+	ls.readyOnce.Do(func() { close(ls.ready) })
+
+	return nil
+}
+
+// toRDSResources converts file's endpoints to RDS resources.
+func (ls *lister) toRDSResources(eps []*targetspb.Endpoint) ([]*pb.Resource, error) {
+	// Multiple IPs are passed on to the RDS clients as is, to be expanded into
+	// one target per IP there, so we keep them out of the endpoints parsing,
+	// which would expand them.
+	var ips [][]string
+	for i, ep := range eps {
+		if len(ep.GetIps()) == 0 {
+			continue
+		}
+		if ips == nil {
+			ips = make([][]string, len(eps))
+			eps = append([]*targetspb.Endpoint{}, eps...)
+		}
+		ips[i] = ep.GetIps()
+		eps[i] = proto.Clone(ep).(*targetspb.Endpoint)
+		eps[i].Ips = nil
 	}
 
-	ls.l.Infof("file_provider(%s): Read %d endpoints", ls.filePath, len(endpoints))
+	endpoints, err := endpoint.FromProtoMessage(eps)
+	if err != nil {
+		return nil, fmt.Errorf("file_provider(%s): error parsing endpoints: %v", ls.filePath, err)
+	}
 
-	ls.resources = make([]*pb.Resource, 0, len(endpoints))
-	for _, e := range endpoints {
+	resources := make([]*pb.Resource, 0, len(endpoints))
+	for i, e := range endpoints {
+		if ls.sourceLabel != "" {
+			if e.Labels == nil {
+				e.Labels = make(map[string]string)
+			}
+			e.Labels[ls.sourceLabel] = ls.filePath
+		}
 		epRes := &pb.Resource{
 			Name:   proto.String(e.Name),
 			Labels: e.Labels,
@@ -208,13 +562,68 @@ func (ls *lister) refresh() error {
 		if e.IP != nil {
 			epRes.Ip = proto.String(e.IP.String())
 		}
+		if ips != nil && len(ips[i]) > 0 {
+			for _, ip := range ips[i] {
+				if net.ParseIP(ip) == nil {
+					return nil, fmt.Errorf("file_provider(%s): resource %s: invalid IP address in ips: %s", ls.filePath, e.Name, ip)
+				}
+			}
+			epRes.Ips = ips[i]
+		}
 		if e.Port != 0 {
 			epRes.Port = proto.Int32(int32(e.Port))
 		}
-		ls.resources = append(ls.resources, epRes)
+		if epRes.TypedLabels, err = ls.parseTypedLabels(e.Labels); err != nil {
+			return nil, fmt.Errorf("file_provider(%s): resource %s: %v", ls.filePath, e.Name, err)
+		}
+		resources = append(resources, epRes)
 	}
+	return resources, nil
+}
 
-	return nil
+// parseTypedLabels parses declared typed labels, returning nil if there are no
+// typed labels for the resource.
+func (ls *lister) parseTypedLabels(labels map[string]string) (map[string]*pb.TypedLabelValue, error) {
+	var typedLabels map[string]*pb.TypedLabelValue
+
+	for k, labelType := range ls.typedLabels {
+		v, ok := labels[k]
+		if !ok {
+			continue
+		}
+
+		tv := &pb.TypedLabelValue{}
+		switch labelType {
+		case configpb.ProviderConfig_INT64:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value (%s) for INT64 label %s: %v", v, k, err)
+			}
+			tv.Value = &pb.TypedLabelValue_IntValue{IntValue: i}
+		case configpb.ProviderConfig_DOUBLE:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value (%s) for DOUBLE label %s: %v", v, k, err)
+			}
+			tv.Value = &pb.TypedLabelValue_DoubleValue{DoubleValue: f}
+		case configpb.ProviderConfig_BOOL:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value (%s) for BOOL label %s: %v", v, k, err)
+			}
+			tv.Value = &pb.TypedLabelValue_BoolValue{BoolValue: b}
+		default:
+			// String labels are available as regular labels.
+			continue
+		}
+
+		if typedLabels == nil {
+			typedLabels = make(map[string]*pb.TypedLabelValue)
+		}
+		typedLabels[k] = tv
+	}
+
+	return typedLabels, nil
 }
 
 func formatFromPath(path string) configpb.ProviderConfig_Format {
@@ -225,11 +634,29 @@ func formatFromPath(path string) configpb.ProviderConfig_Format {
 		return configpb.ProviderConfig_JSON
 	case ".yaml", ".yml":
 		return configpb.ProviderConfig_YAML
+	case ".jsonl":
+		return configpb.ProviderConfig_JSONL
 	}
 	return configpb.ProviderConfig_TEXTPB
 }
 
-// newLister creates a new file-based targets lister.
+// compileNameLabelRegex compiles the name label regex, making sure that it
+// has at least one named capture group.
+func compileNameLabelRegex(s string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name_label_regex (%s): %v", s, err)
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return re, nil
+		}
+	}
+	return nil, fmt.Errorf("name_label_regex (%s) has no named capture groups", s)
+}
+
+// newLister creates a new file-based targets lister. Lister's resources are
+// loaded by its first refresh.
 func newLister(filePath string, c *configpb.ProviderConfig, l *logger.Logger) (*lister, error) {
 	format := c.GetFormat()
 	if format == configpb.ProviderConfig_UNSPECIFIED {
@@ -238,42 +665,60 @@ func newLister(filePath string, c *configpb.ProviderConfig, l *logger.Logger) (*
 	}
 
 	ls := &lister{
-		filePath:     filePath,
-		format:       format,
-		l:            l,
-		checkModTime: !c.GetDisableModifiedTimeCheck(),
+		filePath:      filePath,
+		format:        format,
+		l:             l,
+		checkModTime:  !c.GetDisableModifiedTimeCheck(),
+		defaultLabels: c.GetDefaultLabels(),
+		typedLabels:   c.GetTypedLabels(),
+		ready:         make(chan struct{}),
+
+		parsePortFromName:  c.GetParsePortFromName(),
+		normalizeLabelKeys: c.GetNormalizeLabelKeys(),
+
+		onDuplicateName: c.GetOnDuplicateName(),
+	}
+	if c.GetMerge() != nil {
+		ls.sourceLabel = c.GetMerge().GetSourceLabel()
 	}
 
-	reEvalSec := c.GetReEvalSec()
-	if reEvalSec == 0 {
-		return ls, ls.refresh()
+	if c.GetTail() {
+		if err := validateTail(filePath, c, format); err != nil {
+			return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
+		}
+		ls.tail = true
 	}
 
-	reEvalInterval := time.Duration(reEvalSec) * time.Second
-	go func() {
-		if err := ls.refresh(); err != nil {
-			l.Error(err.Error())
-		}
-		// Introduce a random delay between 0-reEvalInterval before
-		// starting the refresh loop. If there are multiple cloudprober
-		// instances, this will make sure that each instance refreshes
-		// at a different point of time.
-		rand.Seed(time.Now().UnixNano())
-		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
-		time.Sleep(time.Duration(randomDelaySec) * time.Second)
-		for range time.Tick(reEvalInterval) {
-			if err := ls.refresh(); err != nil {
-				l.Error(err.Error())
-			}
+	var err error
+	if ls.sentinel, err = newSentinel(c.GetSentinel()); err != nil {
+		return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
+	}
+	if ls.sentinel != nil && ls.tail {
+		return nil, fmt.Errorf("file_provider(%s): sentinel can't be used along with tail", filePath)
+	}
+
+	validator, err := newResourceValidator(c.GetValidation())
+	if err != nil {
+		return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
+	}
+	ls.validator = validator
+
+	if c.GetNameLabelRegex() != "" {
+		if ls.nameLabelRe, err = compileNameLabelRegex(c.GetNameLabelRegex()); err != nil {
+			return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
 		}
-	}()
+	}
+
+	if ls.reverseResolver, err = newReverseResolver(c.GetReverseDns(), l); err != nil {
+		return nil, fmt.Errorf("file_provider(%s): %v", filePath, err)
+	}
 
 	return ls, nil
 }
 
-func responseWithCacheCheck(ls *lister, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+func responseWithCacheCheck(ls *lister, req *pb.ListResourcesRequest, section string) (*pb.ListResourcesResponse, error) {
 	if req.GetIfModifiedSince() == 0 {
-		return ls.listResources(req)
+		return ls.listSectionResources(req, section)
 	}
 
 	if lastModified := ls.lastModified(); lastModified <= req.GetIfModifiedSince() {
@@ -282,40 +727,63 @@ func responseWithCacheCheck(ls *lister, req *pb.ListResourcesRequest) (*pb.ListR
 		}, nil
 	}
 
-	return ls.listResources(req)
+	return ls.listSectionResources(req, section)
+}
+
+// listerForPath returns the lister and section for the given resource path.
+// Resource path is either a file path, or a file path followed by a section
+// name: "<file_path>#<section>". File path can be omitted if there is only
+// one file.
+func (p *Provider) listerForPath(resourcePath string) (*lister, string, error) {
+	if ls := p.listers[resourcePath]; ls != nil {
+		return ls, "", nil
+	}
+
+	fPath, section, ok := strings.Cut(resourcePath, "#")
+	if !ok || section == "" {
+		return nil, "", fmt.Errorf("file path %s is not available on this server", resourcePath)
+	}
+
+	if fPath == "" {
+		if len(p.filePaths) != 1 {
+			return nil, "", fmt.Errorf("file path is required with section (%s) when using multiple files", section)
+		}
+		fPath = p.filePaths[0]
+	}
+
+	ls := p.listers[fPath]
+	if ls == nil {
+		return nil, "", fmt.Errorf("file path %s is not available on this server", fPath)
+	}
+	return ls, section, nil
 }
 
 // ListResources returns the list of resources based on the given request.
 func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
-	fPath := req.GetResourcePath()
-	if fPath != "" {
-		ls := p.listers[fPath]
-		if ls == nil {
-			return nil, fmt.Errorf("file path %s is not available on this server", fPath)
+	if rPath := req.GetResourcePath(); rPath != "" {
+		ls, section, err := p.listerForPath(rPath)
+		if err != nil {
+			return nil, err
 		}
-		return responseWithCacheCheck(ls, req)
+		return responseWithCacheCheck(ls, req, section)
+	}
+
+	if p.merge != nil {
+		return p.listMergedResources(req)
 	}
 
 	// Avoid append and another allocation if there is only one lister, most
 	// common use case.
 	if len(p.listers) == 1 {
 		for _, ls := range p.listers {
-			return responseWithCacheCheck(ls, req)
+			return responseWithCacheCheck(ls, req, "")
 		}
 	}
 
 	// If we are working with multiple listers, it's slightly more complicated.
 	// In that case we need to return all the listers' resources even if only one
 	// of them has changed.
-	//
-	// Get the latest last-modified.
-	lastModified := int64(0)
-	for _, ls := range p.listers {
-		listerLastModified := ls.lastModified()
-		if lastModified < listerLastModified {
-			lastModified = listerLastModified
-		}
-	}
+	lastModified := p.lastModified()
 	resp := &pb.ListResourcesResponse{
 		LastModified: proto.Int64(lastModified),
 	}
@@ -337,11 +805,47 @@ func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResource
 	return resp, nil
 }
 
+// lastModified returns the latest last-modified time across all listers.
+func (p *Provider) lastModified() int64 {
+	lastModified := int64(0)
+	for _, ls := range p.listers {
+		listerLastModified := ls.lastModified()
+		if lastModified < listerLastModified {
+			lastModified = listerLastModified
+		}
+	}
+	return lastModified
+}
+
 // Provider provides a file-based targets provider for RDS. It implements the
 // RDS server's Provider interface.
 type Provider struct {
 	filePaths []string
 	listers   map[string]*lister
+	ready     chan struct{}
+	merge     *configpb.ProviderConfig_Merge
+	l         *logger.Logger
+
+	debugSnapshot *configpb.ProviderConfig_DebugSnapshot
+
+	maxConcurrentRefreshes int
+}
+
+// Ready returns a channel that is closed once all the files have been
+// loaded successfully at least once.
+func (p *Provider) Ready() <-chan struct{} {
+	return p.ready
+}
+
+// Metrics returns the provider's internal stats as EventMetrics. It
+// implements the RDS server's MetricsProvider interface.
+func (p *Provider) Metrics() []*metrics.EventMetrics {
+	var ems []*metrics.EventMetrics
+	ems = append(ems, p.FilterMatchMetrics()...)
+	ems = append(ems, p.InvalidResourcesMetrics()...)
+	ems = append(ems, p.RefreshMetrics()...)
+	ems = append(ems, p.DuplicateResourcesMetrics()...)
+	return ems
 }
 
 // New creates a File (file) provider for RDS server, based on the
@@ -351,6 +855,21 @@ func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
 	p := &Provider{
 		filePaths: filePaths,
 		listers:   make(map[string]*lister),
+		merge:     c.GetMerge(),
+		l:         l,
+
+		debugSnapshot: c.GetDebugSnapshot(),
+
+		maxConcurrentRefreshes: int(c.GetMaxConcurrentRefreshes()),
+	}
+	if p.maxConcurrentRefreshes <= 0 {
+		return nil, fmt.Errorf("file_provider: invalid max_concurrent_refreshes: %d", c.GetMaxConcurrentRefreshes())
+	}
+
+	for fp := range p.merge.GetFilePriority() {
+		if !slices.Contains(filePaths, fp) {
+			return nil, fmt.Errorf("file_provider: file_priority for unknown file: %s", fp)
+		}
 	}
 
 	for _, filePath := range filePaths {
@@ -361,5 +880,31 @@ func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
 		p.listers[filePath] = lister
 	}
 
+	if p.debugSnapshot != nil {
+		if err := p.registerSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+
+	// If files are not re-evaluated, or if they are required to exist, do
+	// the initial refresh synchronously so that we can fail early.
+	reEvalSec := c.GetReEvalSec()
+	if reEvalSec == 0 || c.GetRequireFiles() {
+		if err := p.refreshAll(); err != nil {
+			return nil, err
+		}
+	}
+	if reEvalSec != 0 {
+		go p.refreshLoop(time.Duration(reEvalSec)*time.Second, !c.GetRequireFiles())
+	}
+
+	p.ready = make(chan struct{})
+	go func() {
+		for _, ls := range p.listers {
+			<-ls.ready
+		}
+		close(p.ready)
+	}()
+
 	return p, nil
 }
@@ -24,6 +24,8 @@ import (
 	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
 	"github.com/cloudprober/cloudprober/internal/rds/file/testdata"
 	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -76,6 +78,16 @@ func TestListResources(t *testing.T) {
 					},
 					wantResources: testExpectedResources[:2],
 				},
+				{
+					desc: "with_values_filter",
+					f: []*rdspb.Filter{
+						{
+							Key:    proto.String("labels.cluster"),
+							Values: []string{"xx", "unknown"},
+						},
+					},
+					wantResources: testExpectedResources[:2],
+				},
 			} {
 				t.Run(test.desc, func(t *testing.T) {
 					got, err := p.ListResources(&rdspb.ListResourcesRequest{Filter: test.f})
@@ -165,6 +177,9 @@ func testModTimeCheckBehavior(t *testing.T, disableModTimeCheck bool) {
 	if err != nil {
 		t.Fatalf("Error creating file lister: %v", err)
 	}
+	if err := ls.refresh(); err != nil {
+		t.Fatalf("Error loading file: %v", err)
+	}
 
 	// Step 1: Very first run. File should be loaded.
 	res, err := ls.listResources(nil)
@@ -232,6 +247,126 @@ func TestModTimeCheckBehavior(t *testing.T) {
 	})
 }
 
+func TestRefreshRetainsLastGoodResources(t *testing.T) {
+	tf, err := os.CreateTemp("", "cloudprober_rds_file.*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFile := tf.Name()
+	defer os.Remove(testFile)
+
+	b, err := os.ReadFile(testResourcesFiles["json"][0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(testFile, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ls, err := newLister(testFile, &configpb.ProviderConfig{
+		DisableModifiedTimeCheck: proto.Bool(true),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error creating file lister: %v", err)
+	}
+	if err := ls.refresh(); err != nil {
+		t.Fatalf("Error loading file: %v", err)
+	}
+	lastUpdated := ls.lastUpdated
+
+	// Corrupt the file and then remove it. In both cases, refresh should fail
+	// and lister should keep serving the last good resources.
+	if err := os.WriteFile(testFile, []byte("{bad-json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ls.refresh(); err == nil {
+		t.Error("Expected error while refreshing corrupted file, got nil")
+	}
+	os.Remove(testFile)
+	if err := ls.refresh(); err == nil {
+		t.Error("Expected error while refreshing missing file, got nil")
+	}
+
+	if ls.lastUpdated != lastUpdated {
+		t.Errorf("Last updated time changed after failed refresh: %v, before: %v", ls.lastUpdated, lastUpdated)
+	}
+	res, err := ls.listResources(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	compareResourceList(t, res.GetResources(), testExpectedResources)
+}
+
+func TestRequireFiles(t *testing.T) {
+	missingFile := "testdata/does-not-exist.json"
+
+	tests := []struct {
+		name         string
+		reEvalSec    int32
+		requireFiles bool
+		filePath     string
+		wantErr      bool
+	}{
+		{name: "missing_no_reeval", filePath: missingFile, wantErr: true},
+		{name: "missing_reeval", filePath: missingFile, reEvalSec: 60, wantErr: false},
+		{name: "missing_reeval_required", filePath: missingFile, reEvalSec: 60, requireFiles: true, wantErr: true},
+		{name: "present_reeval_required", filePath: testResourcesFiles["json"][0], reEvalSec: 60, requireFiles: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &configpb.ProviderConfig{
+				FilePath:     []string{test.filePath},
+				ReEvalSec:    proto.Int32(test.reEvalSec),
+				RequireFiles: proto.Bool(test.requireFiles),
+			}
+			p, err := New(c, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("New(): err=%v, wantErr=%v", err, test.wantErr)
+			}
+			if err != nil || !test.requireFiles {
+				return
+			}
+
+			// Resources should be available right away.
+			res, err := p.ListResources(&rdspb.ListResourcesRequest{ResourcePath: proto.String(test.filePath)})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			compareResourceList(t, res.GetResources(), testExpectedResources)
+		})
+	}
+}
+
+func TestProviderReady(t *testing.T) {
+	// Both files are present, provider becomes ready after the initial load
+	// in the background.
+	p, err := New(&configpb.ProviderConfig{
+		FilePath:  testResourcesFiles["json"],
+		ReEvalSec: proto.Int32(60),
+	}, nil)
+	require.NoError(t, err)
+
+	select {
+	case <-p.Ready():
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the provider to become ready")
+	}
+
+	// One of the files is missing, provider never becomes ready.
+	p, err = New(&configpb.ProviderConfig{
+		FilePath:  []string{testResourcesFiles["json"][0], "testdata/does-not-exist.json"},
+		ReEvalSec: proto.Int32(60),
+	}, nil)
+	require.NoError(t, err)
+
+	select {
+	case <-p.Ready():
+		t.Error("provider is ready even though one of the files is missing")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestListResourcesWithCache(t *testing.T) {
 	// We test with a provider that contains two listers (created from textpb
 	// files above). We try accessing single lister (by setting resource path)
@@ -329,6 +464,7 @@ func TestListResourcesWithCache(t *testing.T) {
 
 			for i, fp := range test.filePaths {
 				ls, _ := newLister(fp, &configpb.ProviderConfig{}, nil)
+				ls.refresh()
 				ls.lastUpdated = time.Unix(test.listerLastModified[i], 0)
 				p.listers[fp] = ls
 			}
@@ -349,3 +485,348 @@ func TestListResourcesWithCache(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFileContentDefaultLabels(t *testing.T) {
+	ls := &lister{
+		filePath:      "test.textpb",
+		format:        configpb.ProviderConfig_TEXTPB,
+		defaultLabels: map[string]string{"env": "prod", "team": "infra"},
+	}
+
+	fileResources, err := ls.parseFileContent([]byte(`
+	resource {
+		name: "r1"
+	}
+	resource {
+		name: "r2"
+		labels {
+			key: "env"
+			value: "staging"
+		}
+	}
+	`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantLabels := []map[string]string{
+		{"env": "prod", "team": "infra"},
+		{"env": "staging", "team": "infra"},
+	}
+	for i, res := range fileResources.GetResource() {
+		if fmt.Sprint(res.GetLabels()) != fmt.Sprint(wantLabels[i]) {
+			t.Errorf("Resource %s labels=%v, want=%v", res.GetName(), res.GetLabels(), wantLabels[i])
+		}
+	}
+}
+
+func TestParseFileContentNormalizeLabelKeys(t *testing.T) {
+	ls := &lister{
+		filePath:           "test.json",
+		format:             configpb.ProviderConfig_JSON,
+		normalizeLabelKeys: true,
+		defaultLabels:      map[string]string{"Team": "infra"},
+	}
+
+	fileResources, err := ls.parseFileContent([]byte(`{
+		"resource": [
+			{"name": "r1", "labels": {"App.Name": "web", "Zone-ID": "us-east1-b", "env": "prod"}},
+			{"name": "r2", "labels": {"App.Name": "web", "app_name": "api", "APP-NAME": "db"}},
+			{"name": "r3"}
+		],
+		"section": [
+			{"name": "s1", "resource": [{"name": "r4", "labels": {"Tier": "1"}}]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	wantLabels := map[string]map[string]string{
+		// Default labels are not normalized.
+		"r1": {"app_name": "web", "zone_id": "us-east1-b", "env": "prod", "Team": "infra"},
+		// Already normalized key wins.
+		"r2": {"app_name": "api", "Team": "infra"},
+		"r3": {"Team": "infra"},
+	}
+	for _, res := range fileResources.GetResource() {
+		assert.Equal(t, wantLabels[res.GetName()], res.GetLabels(), "resource: %s", res.GetName())
+	}
+	assert.Equal(t, map[string]string{"tier": "1", "Team": "infra"}, fileResources.GetSection()[0].GetResource()[0].GetLabels())
+}
+
+func TestMultipleIPs(t *testing.T) {
+	contents := map[configpb.ProviderConfig_Format]string{
+		configpb.ProviderConfig_TEXTPB: `
+			resource {
+				name: "rr.example.com"
+				ips: "10.0.0.1"
+				ips: "10.0.0.2"
+			}
+			resource {
+				name: "single"
+				ip: "10.0.0.3"
+			}`,
+		configpb.ProviderConfig_JSON: `{
+			"resource": [
+				{"name": "rr.example.com", "ips": ["10.0.0.1", "10.0.0.2"]},
+				{"name": "single", "ip": "10.0.0.3"}
+			]}`,
+		configpb.ProviderConfig_YAML: `
+resource:
+  - name: rr.example.com
+    ips: [10.0.0.1, 10.0.0.2]
+  - name: single
+    ip: 10.0.0.3
+`,
+	}
+
+	for format, content := range contents {
+		t.Run(format.String(), func(t *testing.T) {
+			ls := &lister{filePath: "test", format: format}
+			fileResources, err := ls.parseFileContent([]byte(content))
+			require.NoError(t, err)
+			resources, err := ls.toRDSResources(fileResources.GetResource())
+			require.NoError(t, err)
+
+			require.Len(t, resources, 2)
+			assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, resources[0].GetIps())
+			assert.Equal(t, "10.0.0.3", resources[1].GetIp())
+			assert.Empty(t, resources[1].GetIps())
+		})
+	}
+
+	ls := &lister{filePath: "test", format: configpb.ProviderConfig_TEXTPB}
+	fileResources, err := ls.parseFileContent([]byte(`resource { name: "rr" ips: "10.0.0.1" ips: "rr2" }`))
+	require.NoError(t, err)
+	_, err = ls.toRDSResources(fileResources.GetResource())
+	assert.Error(t, err)
+}
+
+func TestParseFileContentNameLabels(t *testing.T) {
+	_, err := compileNameLabelRegex("^svc-([a-z]+)-.*$")
+	assert.Error(t, err, "no named groups")
+	_, err = compileNameLabelRegex("^svc-(?P<role>[a-z]+")
+	assert.Error(t, err, "invalid regex")
+
+	re, err := compileNameLabelRegex(`^svc-(?P<role>[a-z]+)-(?P<region>[a-z0-9]+)-\d+$`)
+	assert.NoError(t, err)
+
+	ls := &lister{
+		filePath:      "test.textpb",
+		format:        configpb.ProviderConfig_TEXTPB,
+		nameLabelRe:   re,
+		defaultLabels: map[string]string{"role": "unknown", "env": "prod"},
+	}
+
+	fileResources, err := ls.parseFileContent([]byte(`
+	resource {
+		name: "svc-web-use1-3"
+	}
+	resource {
+		name: "svc-db-euw2-1"
+		labels {
+			key: "role"
+			value: "primary-db"
+		}
+	}
+	resource {
+		name: "lb-01"
+	}
+	`))
+	assert.NoError(t, err)
+
+	wantLabels := []map[string]string{
+		{"role": "web", "region": "use1", "env": "prod"},
+		{"role": "primary-db", "region": "euw2", "env": "prod"},
+		{"role": "unknown", "env": "prod"},
+	}
+	for i, res := range fileResources.GetResource() {
+		assert.Equal(t, wantLabels[i], res.GetLabels(), "resource: %s", res.GetName())
+	}
+}
+
+func TestParseFileContentPortFromName(t *testing.T) {
+	ls := &lister{
+		filePath:          "test.textpb",
+		format:            configpb.ProviderConfig_TEXTPB,
+		parsePortFromName: true,
+	}
+
+	fileResources, err := ls.parseFileContent([]byte(`
+	resource { name: "web-01:8080" }
+	resource { name: "[2001:db8::1]:443" }
+	resource { name: "10.1.2.3:9100" ip: "10.1.2.3" }
+	resource { name: "db-01:5432" port: 3306 }
+	resource { name: "web-02" }
+	resource { name: "2001:db8::2" }
+	resource { name: "web-03:http" }
+	resource { name: "web-04:70000" }
+	`))
+	assert.NoError(t, err)
+
+	want := [][2]any{
+		{"web-01", int32(8080)},
+		{"2001:db8::1", int32(443)},
+		{"10.1.2.3", int32(9100)},
+		{"db-01:5432", int32(3306)},
+		{"web-02", int32(0)},
+		{"2001:db8::2", int32(0)},
+		{"web-03:http", int32(0)},
+		{"web-04:70000", int32(0)},
+	}
+	var got [][2]any
+	for _, res := range fileResources.GetResource() {
+		got = append(got, [2]any{res.GetName(), res.GetPort()})
+	}
+	assert.Equal(t, want, got)
+
+	// Names are left untouched if option is not set.
+	ls.parsePortFromName = false
+	fileResources, err = ls.parseFileContent([]byte(`resource { name: "web-01:8080" }`))
+	assert.NoError(t, err)
+	assert.Equal(t, "web-01:8080", fileResources.GetResource()[0].GetName())
+	assert.Equal(t, int32(0), fileResources.GetResource()[0].GetPort())
+}
+
+func TestParseTypedLabels(t *testing.T) {
+	ls := &lister{
+		typedLabels: map[string]configpb.ProviderConfig_LabelType{
+			"weight":  configpb.ProviderConfig_INT64,
+			"ratio":   configpb.ProviderConfig_DOUBLE,
+			"canary":  configpb.ProviderConfig_BOOL,
+			"zone":    configpb.ProviderConfig_STRING,
+			"missing": configpb.ProviderConfig_INT64,
+		},
+	}
+
+	got, err := ls.parseTypedLabels(map[string]string{"weight": "10", "ratio": "0.5", "canary": "true", "zone": "a"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := map[string]*rdspb.TypedLabelValue{
+		"weight": {Value: &rdspb.TypedLabelValue_IntValue{IntValue: 10}},
+		"ratio":  {Value: &rdspb.TypedLabelValue_DoubleValue{DoubleValue: 0.5}},
+		"canary": {Value: &rdspb.TypedLabelValue_BoolValue{BoolValue: true}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Got typed labels: %v, want: %v", got, want)
+	}
+	for k, v := range want {
+		if !proto.Equal(got[k], v) {
+			t.Errorf("Typed label %s: got=%v, want=%v", k, got[k], v)
+		}
+	}
+
+	if _, err := ls.parseTypedLabels(map[string]string{"weight": "heavy"}); err == nil {
+		t.Error("Expected error for invalid INT64 label value, got nil")
+	}
+}
+
+func TestListResourcesSections(t *testing.T) {
+	testFile := t.TempDir() + "/resources.textpb"
+	content := `
+resource {
+  name: "lb-01"
+}
+section {
+  name: "web"
+  resource {
+    name: "web-01"
+    labels { key: "zone" value: "a" }
+  }
+  resource {
+    name: "web-02"
+    labels { key: "zone" value: "b" }
+  }
+}
+section {
+  name: "db"
+  resource {
+    name: "db-01"
+  }
+}
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644))
+
+	p, err := New(&configpb.ProviderConfig{
+		FilePath:      []string{testFile},
+		DefaultLabels: map[string]string{"env": "prod"},
+	}, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		resourcePath string
+		filters      map[string]string
+		want         []string
+		wantErr      bool
+	}{
+		{resourcePath: "", want: []string{"lb-01", "web-01", "web-02", "db-01"}},
+		{resourcePath: testFile, want: []string{"lb-01", "web-01", "web-02", "db-01"}},
+		{resourcePath: testFile + "#web", want: []string{"web-01", "web-02"}},
+		{resourcePath: "#web", filters: map[string]string{"labels.zone": "b"}, want: []string{"web-02"}},
+		{resourcePath: "#db", want: []string{"db-01"}},
+		{resourcePath: testFile, filters: map[string]string{"labels.zone": ""}, want: []string{"web-01", "web-02"}},
+		{resourcePath: testFile, filters: map[string]string{"!labels.zone": ""}, want: []string{"lb-01", "db-01"}},
+		{resourcePath: "#cache", wantErr: true},
+		{resourcePath: "other.textpb#web", wantErr: true},
+		{resourcePath: testFile + "#", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.resourcePath, func(t *testing.T) {
+			req := &rdspb.ListResourcesRequest{ResourcePath: proto.String(test.resourcePath)}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &rdspb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+
+			res, err := p.ListResources(req)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var got []string
+			for _, r := range res.GetResources() {
+				got = append(got, r.GetName())
+				assert.Equal(t, "prod", r.GetLabels()["env"], "resource: %s", r.GetName())
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestParseFileContentSectionErrors(t *testing.T) {
+	for _, content := range []string{
+		`section { resource { name: "a" } }`,
+		`section { name: "web" } section { name: "web" }`,
+	} {
+		ls := &lister{filePath: "test.textpb", format: configpb.ProviderConfig_TEXTPB}
+		_, err := ls.parseFileContent([]byte(content))
+		assert.Error(t, err, "content: %s", content)
+	}
+}
+
+func TestProviderMetrics(t *testing.T) {
+	testFile := t.TempDir() + "/resources.textpb"
+	require.NoError(t, os.WriteFile(testFile, []byte(testValidationResources), 0644))
+
+	p, err := New(&configpb.ProviderConfig{
+		FilePath: []string{testFile},
+		Validation: &configpb.ProviderConfig_Validation{
+			RequiredField: []string{"ip", "label:zone"},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	got := make(map[string]string)
+	for _, em := range p.Metrics() {
+		assert.Equal(t, testFile, em.Label("file_path"))
+		for _, k := range em.MetricsKeys() {
+			got[k] = em.Metric(k).String()
+		}
+	}
+	assert.Equal(t, "6", got["invalid_resources"])
+	assert.Equal(t, "1", got["refreshes"])
+	assert.Equal(t, "1", got["reloads"])
+	assert.Equal(t, "0", got["duplicate_resources"])
+}
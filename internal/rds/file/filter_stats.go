@@ -0,0 +1,109 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// filterStats keeps track of how many resources a particular set of filters
+// was applied to and how many of those matched.
+type filterStats struct {
+	requests, total, matched int64
+}
+
+// filterStatsMap maps filters (formatted as a string) to their stats.
+type filterStatsMap struct {
+	mu sync.Mutex
+	m  map[string]*filterStats
+}
+
+func filtersKey(filters []*pb.Filter) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		if len(f.GetValues()) != 0 {
+			parts[i] = f.GetKey() + " in (" + strings.Join(f.GetValues(), ",") + ")"
+			continue
+		}
+		parts[i] = f.GetKey() + "=" + f.GetValue()
+	}
+	return strings.Join(parts, ",")
+}
+
+func (fsm *filterStatsMap) record(filters []*pb.Filter, total, matched int) {
+	key := filtersKey(filters)
+
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+
+	if fsm.m == nil {
+		fsm.m = make(map[string]*filterStats)
+	}
+	fs := fsm.m[key]
+	if fs == nil {
+		fs = &filterStats{}
+		fsm.m[key] = fs
+	}
+	fs.requests++
+	fs.total += int64(total)
+	fs.matched += int64(matched)
+}
+
+// eventMetrics returns cumulative filter stats as EventMetrics, one for each
+// filters combination seen so far.
+func (fsm *filterStatsMap) eventMetrics(ts time.Time, filePath string) []*metrics.EventMetrics {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+
+	keys := make([]string, 0, len(fsm.m))
+	for k := range fsm.m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ems []*metrics.EventMetrics
+	for _, k := range keys {
+		fs := fsm.m[k]
+		ems = append(ems, metrics.NewEventMetrics(ts).
+			AddMetric("requests", metrics.NewInt(fs.requests)).
+			AddMetric("total_resources", metrics.NewInt(fs.total)).
+			AddMetric("matched_resources", metrics.NewInt(fs.matched)).
+			AddLabel("ptype", "rds").
+			AddLabel("provider", DefaultProviderID).
+			AddLabel("file_path", filePath).
+			AddLabel("filter", k))
+	}
+	return ems
+}
+
+// FilterMatchMetrics returns the filter match stats, i.e. the number of
+// resources filters were applied to and the number of resources that matched,
+// as cumulative EventMetrics. There is one EventMetrics for each file and
+// filters combination. Ratio of matched_resources and total_resources gives
+// the match rate for the filters.
+func (p *Provider) FilterMatchMetrics() []*metrics.EventMetrics {
+	ts := time.Now()
+	var ems []*metrics.EventMetrics
+	for _, fp := range p.filePaths {
+		ems = append(ems, p.listers[fp].filterStats.eventMetrics(ts, fp)...)
+	}
+	return ems
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFilterMatchMetrics(t *testing.T) {
+	p, err := New(&configpb.ProviderConfig{FilePath: testResourcesFiles["json"]}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error while creating new provider: %v", err)
+	}
+
+	filters := [][]*rdspb.Filter{
+		{{Key: proto.String("labels.cluster"), Value: proto.String("xx")}},
+		{{Key: proto.String("labels.cluster"), Value: proto.String("xx")}},
+		{{Key: proto.String("name"), Value: proto.String("typo.*")}},
+		{{Key: proto.String("labels.cluster"), Values: []string{"xx", "yy"}}},
+		nil, // No filters, not tracked.
+	}
+	for _, f := range filters {
+		if _, err := p.ListResources(&rdspb.ListResourcesRequest{Filter: f}); err != nil {
+			t.Fatalf("Unexpected error while listing resources: %v", err)
+		}
+	}
+
+	total := int64(len(testExpectedResources))
+	ems := p.FilterMatchMetrics()
+	assert.Len(t, ems, 3)
+
+	wantStats := []struct {
+		filter                   string
+		requests, total, matched int64
+	}{
+		{"labels.cluster in (xx,yy)", 1, total, 2},
+		{"labels.cluster=xx", 2, 2 * total, 4},
+		{"name=typo.*", 1, total, 0},
+	}
+	for i, want := range wantStats {
+		em := ems[i]
+		assert.Equal(t, want.filter, em.Label("filter"))
+		assert.Equal(t, testResourcesFiles["json"][0], em.Label("file_path"))
+		assert.Equal(t, want.requests, em.Metric("requests").(metrics.NumValue).Int64())
+		assert.Equal(t, want.total, em.Metric("total_resources").(metrics.NumValue).Int64())
+		assert.Equal(t, want.matched, em.Metric("matched_resources").(metrics.NumValue).Int64())
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"sort"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// mergedResources returns all the files' resources as one resource set,
+// deduplicated by resource name as per the merge config.
+func (p *Provider) mergedResources() []*pb.Resource {
+	dedup := p.merge.GetDedup()
+	if dedup == configpb.ProviderConfig_Merge_MERGE_BY_NAME {
+		return p.resourcesMergedByName()
+	}
+
+	var resources []*pb.Resource
+	index := make(map[string]int)
+	duplicates := 0
+
+	for _, fp := range p.filePaths {
+		ls := p.listers[fp]
+		ls.mu.RLock()
+		for _, res := range ls.resources {
+			if dedup == configpb.ProviderConfig_Merge_KEEP_ALL {
+				resources = append(resources, res)
+				continue
+			}
+
+			i, ok := index[res.GetName()]
+			if !ok {
+				index[res.GetName()] = len(resources)
+				resources = append(resources, res)
+				continue
+			}
+			duplicates++
+			if dedup == configpb.ProviderConfig_Merge_LAST_FILE_WINS {
+				resources[i] = res
+			}
+		}
+		ls.mu.RUnlock()
+	}
+
+	if duplicates > 0 {
+		p.l.Debugf("file.ListResources: dropped %d duplicate resources (dedup: %v)", duplicates, dedup)
+	}
+	return resources
+}
+
+// resourcesMergedByName merges the resources with the same name into one
+// resource. Resources are merged in the increasing order of their files'
+// priority (and order, for the same priority), so that fields and labels
+// from the higher priority files override the lower priority ones.
+func (p *Provider) resourcesMergedByName() []*pb.Resource {
+	type occurrence struct {
+		res      *pb.Resource
+		priority int32
+		fileIdx  int
+	}
+
+	var names []string
+	groups := make(map[string][]occurrence)
+
+	for i, fp := range p.filePaths {
+		priority := p.merge.GetFilePriority()[fp]
+		ls := p.listers[fp]
+		ls.mu.RLock()
+		for _, res := range ls.resources {
+			if _, ok := groups[res.GetName()]; !ok {
+				names = append(names, res.GetName())
+			}
+			groups[res.GetName()] = append(groups[res.GetName()], occurrence{res, priority, i})
+		}
+		ls.mu.RUnlock()
+	}
+
+	resources := make([]*pb.Resource, 0, len(names))
+	merged := 0
+	for _, name := range names {
+		group := groups[name]
+		if len(group) == 1 {
+			resources = append(resources, group[0].res)
+			continue
+		}
+		merged++
+
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].priority != group[j].priority {
+				return group[i].priority < group[j].priority
+			}
+			return group[i].fileIdx < group[j].fileIdx
+		})
+		res := proto.Clone(group[0].res).(*pb.Resource)
+		for _, o := range group[1:] {
+			mergeResource(res, o.res)
+		}
+		resources = append(resources, res)
+	}
+
+	if merged > 0 {
+		p.l.Debugf("file.ListResources: merged %d resources found in multiple files", merged)
+	}
+	return resources
+}
+
+// mergeResource merges src into dst. Unlike proto.Merge, which concatenates
+// repeated fields, repeated fields set in src replace the ones in dst.
+func mergeResource(dst, src *pb.Resource) {
+	dstMsg := dst.ProtoReflect()
+	src.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.IsList() {
+			dstMsg.Clear(fd)
+		}
+		return true
+	})
+	proto.Merge(dst, src)
+}
+
+// listMergedResources lists resources in the merge mode: filters are applied
+// to the merged resource set.
+func (p *Provider) listMergedResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	resp := &pb.ListResourcesResponse{
+		LastModified: proto.Int64(p.lastModified()),
+	}
+
+	// if nothing changed since req.IfModifiedSince, return early.
+	if req.GetIfModifiedSince() != 0 && resp.GetLastModified() <= req.GetIfModifiedSince() {
+		return resp, nil
+	}
+
+	allResources := p.mergedResources()
+	if len(req.GetFilter()) == 0 {
+		resp.Resources = allResources
+		return resp, nil
+	}
+
+	resources, err := filterResources(req.GetFilter(), allResources, p.l)
+	if err != nil {
+		return nil, err
+	}
+	p.l.Infof("file.ListResources: returning %d resources out of %d merged resources", len(resources), len(allResources))
+	resp.Resources = resources
+	return resp, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListMergedResources(t *testing.T) {
+	dir := t.TempDir()
+	teamA, teamB := filepath.Join(dir, "team-a.textpb"), filepath.Join(dir, "team-b.textpb")
+	require.NoError(t, os.WriteFile(teamA, []byte(`
+resource { name: "web-01" ip: "10.0.0.1" labels { key: "tier" value: "fe" } }
+resource { name: "shared-01" ip: "10.0.0.2" }
+`), 0644))
+	require.NoError(t, os.WriteFile(teamB, []byte(`
+resource { name: "db-01" ip: "10.0.1.1" labels { key: "source_file" value: "bogus" } }
+resource { name: "shared-01" ip: "10.0.1.2" }
+`), 0644))
+
+	tests := []struct {
+		name         string
+		merge        *configpb.ProviderConfig_Merge
+		resourcePath string
+		filters      map[string]string
+		want         []string
+	}{
+		{
+			name:  "keep_all",
+			merge: &configpb.ProviderConfig_Merge{},
+			want: []string{
+				"web-01 10.0.0.1 " + teamA,
+				"shared-01 10.0.0.2 " + teamA,
+				"db-01 10.0.1.1 " + teamB,
+				"shared-01 10.0.1.2 " + teamB,
+			},
+		},
+		{
+			name:  "first_file_wins",
+			merge: &configpb.ProviderConfig_Merge{Dedup: configpb.ProviderConfig_Merge_FIRST_FILE_WINS.Enum()},
+			want: []string{
+				"web-01 10.0.0.1 " + teamA,
+				"shared-01 10.0.0.2 " + teamA,
+				"db-01 10.0.1.1 " + teamB,
+			},
+		},
+		{
+			name:  "last_file_wins",
+			merge: &configpb.ProviderConfig_Merge{Dedup: configpb.ProviderConfig_Merge_LAST_FILE_WINS.Enum()},
+			want: []string{
+				"web-01 10.0.0.1 " + teamA,
+				"shared-01 10.0.1.2 " + teamB,
+				"db-01 10.0.1.1 " + teamB,
+			},
+		},
+		{
+			name:    "filter_by_source",
+			merge:   &configpb.ProviderConfig_Merge{Dedup: configpb.ProviderConfig_Merge_LAST_FILE_WINS.Enum()},
+			filters: map[string]string{"labels.source_file": ".*team-a.*"},
+			want: []string{
+				"web-01 10.0.0.1 " + teamA,
+			},
+		},
+		{
+			name:         "resource_path",
+			merge:        &configpb.ProviderConfig_Merge{SourceLabel: proto.String("team_file")},
+			resourcePath: teamB,
+			want: []string{
+				"db-01 10.0.1.1 " + teamB,
+				"shared-01 10.0.1.2 " + teamB,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(&configpb.ProviderConfig{
+				FilePath: []string{teamA, teamB},
+				Merge:    test.merge,
+			}, nil)
+			require.NoError(t, err)
+
+			req := &rdspb.ListResourcesRequest{ResourcePath: proto.String(test.resourcePath)}
+			for k, v := range test.filters {
+				req.Filter = append(req.Filter, &rdspb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+			resp, err := p.ListResources(req)
+			require.NoError(t, err)
+
+			var got []string
+			for _, res := range resp.GetResources() {
+				got = append(got, res.GetName()+" "+res.GetIp()+" "+res.GetLabels()[test.merge.GetSourceLabel()])
+			}
+			assert.Equal(t, test.want, got)
+
+			// Nothing changed since last-modified.
+			req.IfModifiedSince = proto.Int64(resp.GetLastModified())
+			resp, err = p.ListResources(req)
+			require.NoError(t, err)
+			assert.Nil(t, resp.GetResources())
+		})
+	}
+}
+
+func TestMergeByName(t *testing.T) {
+	dir := t.TempDir()
+	base, overrides := filepath.Join(dir, "base.textpb"), filepath.Join(dir, "overrides.textpb")
+	require.NoError(t, os.WriteFile(base, []byte(`
+resource { name: "web-01" ip: "10.0.0.1" ips: "10.0.0.1" ips: "10.0.0.11" port: 80 labels { key: "tier" value: "fe" } labels { key: "zone" value: "a" } }
+resource { name: "db-01" ip: "10.0.0.2" ips: "10.0.0.2" }
+`), 0644))
+	require.NoError(t, os.WriteFile(overrides, []byte(`
+resource { name: "web-01" ip: "10.0.1.1" ips: "10.0.1.1" labels { key: "zone" value: "b" } labels { key: "owner" value: "team-b" } }
+resource { name: "db-01" labels { key: "owner" value: "team-b" } }
+`), 0644))
+
+	tests := []struct {
+		name     string
+		priority map[string]int32
+		want     map[string]*rdspb.Resource
+	}{
+		{
+			name: "file_order",
+			want: map[string]*rdspb.Resource{
+				"web-01": {Ip: proto.String("10.0.1.1"), Ips: []string{"10.0.1.1"}, Port: proto.Int32(80), Labels: map[string]string{"tier": "fe", "zone": "b", "owner": "team-b", "source": overrides}},
+				"db-01":  {Ip: proto.String("10.0.0.2"), Ips: []string{"10.0.0.2"}, Labels: map[string]string{"owner": "team-b", "source": overrides}},
+			},
+		},
+		{
+			name:     "explicit_priority",
+			priority: map[string]int32{base: 10},
+			want: map[string]*rdspb.Resource{
+				"web-01": {Ip: proto.String("10.0.0.1"), Ips: []string{"10.0.0.1", "10.0.0.11"}, Port: proto.Int32(80), Labels: map[string]string{"tier": "fe", "zone": "a", "owner": "team-b", "source": base}},
+				"db-01":  {Ip: proto.String("10.0.0.2"), Ips: []string{"10.0.0.2"}, Labels: map[string]string{"owner": "team-b", "source": base}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(&configpb.ProviderConfig{
+				FilePath: []string{base, overrides},
+				Merge: &configpb.ProviderConfig_Merge{
+					SourceLabel:  proto.String("source"),
+					Dedup:        configpb.ProviderConfig_Merge_MERGE_BY_NAME.Enum(),
+					FilePriority: test.priority,
+				},
+			}, nil)
+			require.NoError(t, err)
+
+			resp, err := p.ListResources(&rdspb.ListResourcesRequest{})
+			require.NoError(t, err)
+
+			// Merged resource keeps the position of the first occurrence.
+			require.Len(t, resp.GetResources(), 2)
+			assert.Equal(t, "web-01", resp.GetResources()[0].GetName())
+			for _, res := range resp.GetResources() {
+				want := test.want[res.GetName()]
+				assert.Equal(t, want.GetIp(), res.GetIp(), "resource: %s", res.GetName())
+				assert.Equal(t, want.GetIps(), res.GetIps(), "resource: %s", res.GetName())
+				assert.Equal(t, want.GetPort(), res.GetPort(), "resource: %s", res.GetName())
+				assert.Equal(t, want.GetLabels(), res.GetLabels(), "resource: %s", res.GetName())
+			}
+		})
+	}
+
+	// Listers' resources are not modified by merging.
+	p, err := New(&configpb.ProviderConfig{
+		FilePath: []string{base, overrides},
+		Merge:    &configpb.ProviderConfig_Merge{Dedup: configpb.ProviderConfig_Merge_MERGE_BY_NAME.Enum()},
+	}, nil)
+	require.NoError(t, err)
+	p.ListResources(&rdspb.ListResourcesRequest{})
+	resp, err := p.ListResources(&rdspb.ListResourcesRequest{ResourcePath: proto.String(base)})
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", resp.GetResources()[0].GetIp())
+	assert.NotContains(t, resp.GetResources()[0].GetLabels(), "owner")
+
+	_, err = New(&configpb.ProviderConfig{
+		FilePath: []string{base},
+		Merge:    &configpb.ProviderConfig_Merge{FilePriority: map[string]int32{overrides: 1}},
+	}, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// promTargetGroup is a target group in the Prometheus file_sd format.
+type promTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// promTargetToResource converts a Prometheus target (host or host:port) to a
+// resource.
+func promTargetToResource(target string) (*targetspb.Endpoint, error) {
+	res := &targetspb.Endpoint{}
+
+	host := target
+	if h, portStr, err := net.SplitHostPort(target); err == nil {
+		port, err := strconv.ParseUint(portStr, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in target %s: %v", target, err)
+		}
+		host = h
+		res.Port = proto.Int32(int32(port))
+	}
+	host = strings.Trim(host, "[]")
+	if host == "" {
+		return nil, fmt.Errorf("invalid target: %q", target)
+	}
+
+	res.Name = proto.String(host)
+	if net.ParseIP(host) != nil {
+		res.Ip = proto.String(host)
+	}
+	return res, nil
+}
+
+// parsePrometheusSD parses resources from the Prometheus file_sd content.
+// Content is interpreted as YAML if the file path has a YAML extension.
+func parsePrometheusSD(b []byte, filePath string) (*configpb.FileResources, error) {
+	if ext := filepath.Ext(filePath); ext == ".yaml" || ext == ".yml" {
+		var err error
+		if b, err = yaml.YAMLToJSON(b); err != nil {
+			return nil, fmt.Errorf("error converting YAML to JSON: %v", err)
+		}
+	}
+
+	var groups []promTargetGroup
+	if err := json.Unmarshal(b, &groups); err != nil {
+		return nil, err
+	}
+
+	resources := &configpb.FileResources{}
+	for _, g := range groups {
+		labels := make(map[string]string)
+		for k, v := range g.Labels {
+			if !strings.HasPrefix(k, "__") {
+				labels[k] = v
+			}
+		}
+
+		for _, target := range g.Targets {
+			res, err := promTargetToResource(target)
+			if err != nil {
+				return nil, err
+			}
+			if len(labels) > 0 {
+				res.Labels = make(map[string]string, len(labels))
+				for k, v := range labels {
+					res.Labels[k] = v
+				}
+			}
+			resources.Resource = append(resources.Resource, res)
+		}
+	}
+	return resources, nil
+}
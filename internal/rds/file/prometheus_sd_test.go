@@ -0,0 +1,97 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testPromSDJSON = `[
+  {
+    "targets": ["10.11.112.3:9100", "web-01:9100", "[::1]:8080"],
+    "labels": {"env": "prod", "__meta_zone": "us-east1"}
+  },
+  {
+    "targets": ["db-01"]
+  }
+]`
+
+const testPromSDYAML = `
+- targets:
+  - 10.11.112.3:9100
+  - web-01:9100
+  - "[::1]:8080"
+  labels:
+    env: prod
+    __meta_zone: us-east1
+- targets:
+  - db-01
+`
+
+func TestPrometheusSD(t *testing.T) {
+	prodLabels := map[string]string{"env": "prod"}
+	wantResources := []*rdspb.Resource{
+		{Name: proto.String("10.11.112.3"), Ip: proto.String("10.11.112.3"), Port: proto.Int32(9100), Labels: prodLabels},
+		{Name: proto.String("web-01"), Port: proto.Int32(9100), Labels: prodLabels},
+		{Name: proto.String("::1"), Ip: proto.String("::1"), Port: proto.Int32(8080), Labels: prodLabels},
+		{Name: proto.String("db-01")},
+	}
+
+	for _, test := range []struct {
+		file, content string
+	}{
+		{file: "targets.json", content: testPromSDJSON},
+		{file: "targets.yml", content: testPromSDYAML},
+	} {
+		t.Run(test.file, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), test.file)
+			assert.NoError(t, os.WriteFile(filePath, []byte(test.content), 0644))
+
+			p, err := New(&configpb.ProviderConfig{
+				FilePath: []string{filePath},
+				Format:   configpb.ProviderConfig_PROMETHEUS_SD.Enum(),
+			}, nil)
+			assert.NoError(t, err)
+
+			got, err := p.ListResources(&rdspb.ListResourcesRequest{})
+			assert.NoError(t, err)
+			compareResourceList(t, got.Resources, wantResources)
+
+			got, err = p.ListResources(&rdspb.ListResourcesRequest{
+				Filter: []*rdspb.Filter{{Key: proto.String("labels.env"), Value: proto.String("prod")}},
+			})
+			assert.NoError(t, err)
+			compareResourceList(t, got.Resources, wantResources[:3])
+		})
+	}
+}
+
+func TestPrometheusSDErrors(t *testing.T) {
+	for _, content := range []string{
+		`{"targets": ["web-01:9100"]}`, // Not a list.
+		`[{"targets": ["web-01:http"]}]`,
+		`[{"targets": [":9100"]}]`,
+	} {
+		_, err := parsePrometheusSD([]byte(content), "targets.json")
+		assert.Error(t, err, content)
+	}
+}
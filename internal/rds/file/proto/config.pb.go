@@ -52,6 +52,27 @@ const (
 	ProviderConfig_TEXTPB      ProviderConfig_Format = 1 // Text proto format (.textpb).
 	ProviderConfig_JSON        ProviderConfig_Format = 2 // JSON proto format (.json).
 	ProviderConfig_YAML        ProviderConfig_Format = 3 // YAML proto format (.yaml).
+	// Prometheus file-based service discovery (file_sd) format, in JSON or
+	// YAML (.yaml, .yml), e.g.:
+	// [
+	//
+	//	{
+	//	  "targets": ["10.11.112.3:9100", "web-01:9100"],
+	//	  "labels": {"env": "prod", "job": "node"}
+	//	}
+	//
+	// ]
+	// Each target becomes a resource, with name and port set from the
+	// target's host and port, and labels set from the group's labels. If
+	// target's host is an IP address, resource's IP is set as well. Labels
+	// starting with "__" are reserved by Prometheus and are ignored.
+	ProviderConfig_PROMETHEUS_SD ProviderConfig_Format = 4
+	// JSON lines format (.jsonl): one resource (endpoint) JSON object per
+	// line, e.g.:
+	// {"name": "web-01", "ip": "10.11.112.3", "port": 9100}
+	// {"name": "web-02", "ip": "10.11.112.4", "port": 9100}
+	// Empty lines are ignored. Sections are not supported in this format.
+	ProviderConfig_JSONL ProviderConfig_Format = 5
 )
 
 // Enum value maps for ProviderConfig_Format.
@@ -61,12 +82,16 @@ var (
 		1: "TEXTPB",
 		2: "JSON",
 		3: "YAML",
+		4: "PROMETHEUS_SD",
+		5: "JSONL",
 	}
 	ProviderConfig_Format_value = map[string]int32{
-		"UNSPECIFIED": 0,
-		"TEXTPB":      1,
-		"JSON":        2,
-		"YAML":        3,
+		"UNSPECIFIED":   0,
+		"TEXTPB":        1,
+		"JSON":          2,
+		"YAML":          3,
+		"PROMETHEUS_SD": 4,
+		"JSONL":         5,
 	}
 )
 
@@ -107,6 +132,260 @@ func (ProviderConfig_Format) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type ProviderConfig_LabelType int32
+
+const (
+	ProviderConfig_STRING ProviderConfig_LabelType = 0
+	ProviderConfig_INT64  ProviderConfig_LabelType = 1
+	ProviderConfig_DOUBLE ProviderConfig_LabelType = 2
+	ProviderConfig_BOOL   ProviderConfig_LabelType = 3
+)
+
+// Enum value maps for ProviderConfig_LabelType.
+var (
+	ProviderConfig_LabelType_name = map[int32]string{
+		0: "STRING",
+		1: "INT64",
+		2: "DOUBLE",
+		3: "BOOL",
+	}
+	ProviderConfig_LabelType_value = map[string]int32{
+		"STRING": 0,
+		"INT64":  1,
+		"DOUBLE": 2,
+		"BOOL":   3,
+	}
+)
+
+func (x ProviderConfig_LabelType) Enum() *ProviderConfig_LabelType {
+	p := new(ProviderConfig_LabelType)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_LabelType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_LabelType) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[1].Descriptor()
+}
+
+func (ProviderConfig_LabelType) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[1]
+}
+
+func (x ProviderConfig_LabelType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_LabelType) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_LabelType(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_LabelType.Descriptor instead.
+func (ProviderConfig_LabelType) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type ProviderConfig_DuplicateNamePolicy int32
+
+const (
+	ProviderConfig_KEEP_ALL   ProviderConfig_DuplicateNamePolicy = 0 // Keep all resources, including duplicates.
+	ProviderConfig_FAIL       ProviderConfig_DuplicateNamePolicy = 1 // Fail the file load.
+	ProviderConfig_KEEP_FIRST ProviderConfig_DuplicateNamePolicy = 2 // Keep the first resource with a name.
+	ProviderConfig_KEEP_LAST  ProviderConfig_DuplicateNamePolicy = 3 // Keep the last resource with a name.
+	// Keep the first resource with a name, and merge the labels of the later
+	// resources with the same name into it. For conflicting label values,
+	// later values win.
+	ProviderConfig_MERGE_LABELS ProviderConfig_DuplicateNamePolicy = 4
+)
+
+// Enum value maps for ProviderConfig_DuplicateNamePolicy.
+var (
+	ProviderConfig_DuplicateNamePolicy_name = map[int32]string{
+		0: "KEEP_ALL",
+		1: "FAIL",
+		2: "KEEP_FIRST",
+		3: "KEEP_LAST",
+		4: "MERGE_LABELS",
+	}
+	ProviderConfig_DuplicateNamePolicy_value = map[string]int32{
+		"KEEP_ALL":     0,
+		"FAIL":         1,
+		"KEEP_FIRST":   2,
+		"KEEP_LAST":    3,
+		"MERGE_LABELS": 4,
+	}
+)
+
+func (x ProviderConfig_DuplicateNamePolicy) Enum() *ProviderConfig_DuplicateNamePolicy {
+	p := new(ProviderConfig_DuplicateNamePolicy)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_DuplicateNamePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_DuplicateNamePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[2].Descriptor()
+}
+
+func (ProviderConfig_DuplicateNamePolicy) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[2]
+}
+
+func (x ProviderConfig_DuplicateNamePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_DuplicateNamePolicy) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_DuplicateNamePolicy(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_DuplicateNamePolicy.Descriptor instead.
+func (ProviderConfig_DuplicateNamePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 2}
+}
+
+type ProviderConfig_Validation_Action int32
+
+const (
+	ProviderConfig_Validation_SKIP ProviderConfig_Validation_Action = 0 // Skip invalid resources.
+	ProviderConfig_Validation_FAIL ProviderConfig_Validation_Action = 1 // Fail the file load.
+)
+
+// Enum value maps for ProviderConfig_Validation_Action.
+var (
+	ProviderConfig_Validation_Action_name = map[int32]string{
+		0: "SKIP",
+		1: "FAIL",
+	}
+	ProviderConfig_Validation_Action_value = map[string]int32{
+		"SKIP": 0,
+		"FAIL": 1,
+	}
+)
+
+func (x ProviderConfig_Validation_Action) Enum() *ProviderConfig_Validation_Action {
+	p := new(ProviderConfig_Validation_Action)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_Validation_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_Validation_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[3].Descriptor()
+}
+
+func (ProviderConfig_Validation_Action) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[3]
+}
+
+func (x ProviderConfig_Validation_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_Validation_Action) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_Validation_Action(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_Validation_Action.Descriptor instead.
+func (ProviderConfig_Validation_Action) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 2, 0}
+}
+
+type ProviderConfig_Merge_Dedup int32
+
+const (
+	ProviderConfig_Merge_KEEP_ALL        ProviderConfig_Merge_Dedup = 0 // Keep all resources, including duplicates.
+	ProviderConfig_Merge_FIRST_FILE_WINS ProviderConfig_Merge_Dedup = 1 // Keep the resource from the earliest file.
+	ProviderConfig_Merge_LAST_FILE_WINS  ProviderConfig_Merge_Dedup = 2 // Keep the resource from the latest file.
+	// Merge the resources with the same name into one resource: labels are
+	// unioned, and for conflicting labels and other fields (e.g. ip, ips,
+	// port), the resource from the file with the higher priority wins.
+	// Repeated fields (ips) are not concatenated, they are replaced as a
+	// whole. Merged resource keeps the position of the name's first
+	// occurrence.
+	ProviderConfig_Merge_MERGE_BY_NAME ProviderConfig_Merge_Dedup = 3
+)
+
+// Enum value maps for ProviderConfig_Merge_Dedup.
+var (
+	ProviderConfig_Merge_Dedup_name = map[int32]string{
+		0: "KEEP_ALL",
+		1: "FIRST_FILE_WINS",
+		2: "LAST_FILE_WINS",
+		3: "MERGE_BY_NAME",
+	}
+	ProviderConfig_Merge_Dedup_value = map[string]int32{
+		"KEEP_ALL":        0,
+		"FIRST_FILE_WINS": 1,
+		"LAST_FILE_WINS":  2,
+		"MERGE_BY_NAME":   3,
+	}
+)
+
+func (x ProviderConfig_Merge_Dedup) Enum() *ProviderConfig_Merge_Dedup {
+	p := new(ProviderConfig_Merge_Dedup)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_Merge_Dedup) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_Merge_Dedup) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[4].Descriptor()
+}
+
+func (ProviderConfig_Merge_Dedup) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes[4]
+}
+
+func (x ProviderConfig_Merge_Dedup) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_Merge_Dedup) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_Merge_Dedup(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_Merge_Dedup.Descriptor instead.
+func (ProviderConfig_Merge_Dedup) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 3, 0}
+}
+
 // File provider config.
 type ProviderConfig struct {
 	state         protoimpl.MessageState
@@ -143,11 +422,137 @@ type ProviderConfig struct {
 	// If specified, file will be re-read at the given interval.
 	ReEvalSec *int32 `protobuf:"varint,3,opt,name=re_eval_sec,json=reEvalSec" json:"re_eval_sec,omitempty"`
 	// Whenever possible, we reload a file only if it has been modified since the
-	// last load. If following option is set, mod time check is disabled.
-	// Note that mod-time check doesn't work for GCS.
+	// last load. For GCS and S3 files, we use object's generation and ETag
+	// respectively to determine if the file has changed. If following option is
+	// set, mod time check is disabled.
 	DisableModifiedTimeCheck *bool `protobuf:"varint,4,opt,name=disable_modified_time_check,json=disableModifiedTimeCheck" json:"disable_modified_time_check,omitempty"`
+	// Default labels to add to the resources. A default label is applied only
+	// if resource doesn't have that label already, i.e. explicitly set labels
+	// always win.
+	// Example:
+	//
+	//	default_labels {
+	//	  key: "env"
+	//	  value: "prod"
+	//	}
+	DefaultLabels map[string]string `protobuf:"bytes,5,rep,name=default_labels,json=defaultLabels" json:"default_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Declared types for labels. Labels declared here are parsed into the given
+	// type and exported in the resource's typed_labels, in addition to the
+	// regular (string) labels, which are still used for filtering. If a label's
+	// value cannot be parsed into its declared type, file load fails.
+	// Example:
+	//
+	//	typed_labels {
+	//	  key: "weight"
+	//	  value: INT64
+	//	}
+	TypedLabels map[string]ProviderConfig_LabelType `protobuf:"bytes,6,rep,name=typed_labels,json=typedLabels" json:"typed_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=cloudprober.rds.file.ProviderConfig_LabelType"`
+	// If set, provider fails to start if a file cannot be loaded at startup,
+	// e.g. because it doesn't exist. This is useful to catch deployment
+	// mistakes early. By default, if re_eval_sec is set, initial file load
+	// errors are only logged and provider starts with zero resources. Note that
+	// errors in later refreshes never fail the provider; last successfully
+	// loaded resources are retained in that case.
+	RequireFiles *bool `protobuf:"varint,7,opt,name=require_files,json=requireFiles" json:"require_files,omitempty"`
+	// Resource validation. If configured, resources are validated at load time:
+	// required fields must be set, and IP, port and URL, if set, must be
+	// valid. Invalid resources are either skipped (and counted in the
+	// "invalid_resources" metric) or they fail the file load.
+	// Example:
+	//
+	//	validation {
+	//	  required_field: "ip"
+	//	  required_field: "label:zone"
+	//	}
+	Validation *ProviderConfig_Validation `protobuf:"bytes,8,opt,name=validation" json:"validation,omitempty"`
+	// Regex with named capture groups to extract labels from the resource
+	// names. Each named group becomes a label, e.g. with the following regex,
+	// resource name "svc-web-use1-3" gets the labels role=web and region=use1:
+	//
+	//	name_label_regex: "^svc-(?P<role>[a-z]+)-(?P<region>[a-z0-9]+)-\\d+$"
+	//
+	// Resources with non-matching names get no extra labels. Explicitly set
+	// labels take precedence over the extracted labels, which in turn take
+	// precedence over the default labels.
+	NameLabelRegex *string `protobuf:"bytes,9,opt,name=name_label_regex,json=nameLabelRegex" json:"name_label_regex,omitempty"`
+	// If set, resources from all the files are merged into one resource set,
+	// and each resource gets a label with its source file path. Filters are
+	// applied after the merge (and dedup). Individual files can still be
+	// accessed by setting the file path as the resource_path.
+	// Example:
+	//
+	//	merge {
+	//	  dedup: FIRST_FILE_WINS
+	//	}
+	Merge *ProviderConfig_Merge `protobuf:"bytes,10,opt,name=merge" json:"merge,omitempty"`
+	// Maximum number of files to refresh concurrently. Files are refreshed in
+	// parallel, but only the files that have changed since the last refresh
+	// are re-read and parsed (see disable_modified_time_check).
+	MaxConcurrentRefreshes *int32                        `protobuf:"varint,11,opt,name=max_concurrent_refreshes,json=maxConcurrentRefreshes,def=16" json:"max_concurrent_refreshes,omitempty"`
+	DebugSnapshot          *ProviderConfig_DebugSnapshot `protobuf:"bytes,12,opt,name=debug_snapshot,json=debugSnapshot" json:"debug_snapshot,omitempty"`
+	// How to handle multiple resources with the same name within a file,
+	// including the resources in sections. Resources are considered in the
+	// order they appear in the file, with sections after the top-level
+	// resources. Duplicates are counted in the "duplicate_resources" metric,
+	// irrespective of this policy.
+	OnDuplicateName *ProviderConfig_DuplicateNamePolicy `protobuf:"varint,13,opt,name=on_duplicate_name,json=onDuplicateName,enum=cloudprober.rds.file.ProviderConfig_DuplicateNamePolicy,def=0" json:"on_duplicate_name,omitempty"`
+	// If set, names of the resources that don't have a port are parsed as
+	// "host:port", and split into the name and the port, e.g. resource name
+	// "web-01:8080" becomes name "web-01" with port 8080. IPv6 addresses
+	// should use the bracket notation, e.g. "[2001:db8::1]:443". Names that
+	// are not in "host:port" format, and resources that already have a port,
+	// are left untouched. Name is split before extracting the name labels
+	// (name_label_regex) and detecting the duplicate names.
+	ParsePortFromName *bool `protobuf:"varint,14,opt,name=parse_port_from_name,json=parsePortFromName" json:"parse_port_from_name,omitempty"`
+	// If set, IPs of the resources are reverse-resolved (PTR lookup) at load
+	// time, and the resolved name, without the trailing dot, is added as a
+	// label ("hostname" by default). If lookup fails, label is not added.
+	// Resources that already have the label are left untouched.
+	// Example:
+	//
+	//	reverse_dns {}
+	ReverseDns *ProviderConfig_ReverseDNS `protobuf:"bytes,15,opt,name=reverse_dns,json=reverseDns" json:"reverse_dns,omitempty"`
+	// If set, file is treated as append-only: on refresh, only the lines
+	// appended to the file since the last read are parsed, and the resulting
+	// resources are added to the already loaded resources. This is useful for
+	// large inventories that only ever grow, e.g. a service registry log. If
+	// the file is truncated or replaced (rotated), it's reloaded fully. Only
+	// complete, newline-terminated, lines are read.
+	// This option is supported only for local files in the JSONL format, and
+	// it can't be used along with on_duplicate_name, as duplicates are not
+	// tracked across the appends.
+	Tail *bool `protobuf:"varint,16,opt,name=tail" json:"tail,omitempty"`
+	// If set, label keys of the resources are normalized: they are lowercased,
+	// and all characters other than letters and digits are replaced by '_',
+	// e.g. "App.Name" becomes "app_name". This makes label keys consistent
+	// across sources, and safe to use in filters and metric labels.
+	// Normalization is applied to the labels in the file, before applying
+	// name labels and default labels. If two label keys of a resource collide
+	// after normalization, collision is logged, and the key that was already
+	// normalized wins; failing that, the lexicographically first key wins.
+	NormalizeLabelKeys *bool `protobuf:"varint,17,opt,name=normalize_label_keys,json=normalizeLabelKeys" json:"normalize_label_keys,omitempty"`
+	// Sentinel (version) file to decide whether to reload the resource files.
+	// If set, resource files are re-read and parsed only when the version in
+	// the sentinel file changes, irrespective of their modified time. This is
+	// useful for large files that are touched more often than their content
+	// changes. Sentinel is read on every refresh, so it should be small. If
+	// sentinel can't be read, or it doesn't have the version, we fall back to
+	// the modified time check (see disable_modified_time_check).
+	// This option can't be used along with tail.
+	// Example:
+	//
+	//	sentinel {
+	//	  path: "/data/inventory/VERSION"
+	//	}
+	Sentinel *ProviderConfig_Sentinel `protobuf:"bytes,18,opt,name=sentinel" json:"sentinel,omitempty"`
 }
 
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_MaxConcurrentRefreshes = int32(16)
+	Default_ProviderConfig_OnDuplicateName        = ProviderConfig_KEEP_ALL
+)
+
 func (x *ProviderConfig) Reset() {
 	*x = ProviderConfig{}
 	if protoimpl.UnsafeEnabled {
@@ -208,6 +613,104 @@ func (x *ProviderConfig) GetDisableModifiedTimeCheck() bool {
 	return false
 }
 
+func (x *ProviderConfig) GetDefaultLabels() map[string]string {
+	if x != nil {
+		return x.DefaultLabels
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetTypedLabels() map[string]ProviderConfig_LabelType {
+	if x != nil {
+		return x.TypedLabels
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetRequireFiles() bool {
+	if x != nil && x.RequireFiles != nil {
+		return *x.RequireFiles
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetValidation() *ProviderConfig_Validation {
+	if x != nil {
+		return x.Validation
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetNameLabelRegex() string {
+	if x != nil && x.NameLabelRegex != nil {
+		return *x.NameLabelRegex
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetMerge() *ProviderConfig_Merge {
+	if x != nil {
+		return x.Merge
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetMaxConcurrentRefreshes() int32 {
+	if x != nil && x.MaxConcurrentRefreshes != nil {
+		return *x.MaxConcurrentRefreshes
+	}
+	return Default_ProviderConfig_MaxConcurrentRefreshes
+}
+
+func (x *ProviderConfig) GetDebugSnapshot() *ProviderConfig_DebugSnapshot {
+	if x != nil {
+		return x.DebugSnapshot
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetOnDuplicateName() ProviderConfig_DuplicateNamePolicy {
+	if x != nil && x.OnDuplicateName != nil {
+		return *x.OnDuplicateName
+	}
+	return Default_ProviderConfig_OnDuplicateName
+}
+
+func (x *ProviderConfig) GetParsePortFromName() bool {
+	if x != nil && x.ParsePortFromName != nil {
+		return *x.ParsePortFromName
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetReverseDns() *ProviderConfig_ReverseDNS {
+	if x != nil {
+		return x.ReverseDns
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetTail() bool {
+	if x != nil && x.Tail != nil {
+		return *x.Tail
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetNormalizeLabelKeys() bool {
+	if x != nil && x.NormalizeLabelKeys != nil {
+		return *x.NormalizeLabelKeys
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetSentinel() *ProviderConfig_Sentinel {
+	if x != nil {
+		return x.Sentinel
+	}
+	return nil
+}
+
 type FileResources struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -228,7 +731,8 @@ type FileResources struct {
 	//	  ip: "10.1.2.3"
 	//	  port: 8080
 	//	}
-	Resource []*proto.Endpoint `protobuf:"bytes,1,rep,name=resource" json:"resource,omitempty"`
+	Resource []*proto.Endpoint        `protobuf:"bytes,1,rep,name=resource" json:"resource,omitempty"`
+	Section  []*FileResources_Section `protobuf:"bytes,2,rep,name=section" json:"section,omitempty"`
 }
 
 func (x *FileResources) Reset() {
@@ -270,76 +774,702 @@ func (x *FileResources) GetResource() []*proto.Endpoint {
 	return nil
 }
 
-var File_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto protoreflect.FileDescriptor
+func (x *FileResources) GetSection() []*FileResources_Section {
+	if x != nil {
+		return x.Section
+	}
+	return nil
+}
 
-var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDesc = []byte{
-	0x0a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
-	0x73, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x1a,
-	0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2f, 0x65, 0x6e, 0x64, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8c, 0x02, 0x0a, 0x0e, 0x50, 0x72,
-	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09,
-	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x43, 0x0a, 0x06, 0x66, 0x6f, 0x72,
-	0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65,
-	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1e,
-	0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x3d,
-	0x0a, 0x1b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
-	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x18, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x69,
-	0x66, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x39, 0x0a,
-	0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x54, 0x45, 0x58, 0x54,
-	0x50, 0x42, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x08,
-	0x0a, 0x04, 0x59, 0x41, 0x4d, 0x4c, 0x10, 0x03, 0x22, 0x4a, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x73, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f,
+type ProviderConfig_Validation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fields that must be set for a resource to be valid. Supported values:
+	// "ip" (satisfied by "ips" as well), "port", "url", and "label:<key>"
+	// for a label.
+	RequiredField []string `protobuf:"bytes,1,rep,name=required_field,json=requiredField" json:"required_field,omitempty"`
+	// What to do if a resource is invalid.
+	OnInvalid *ProviderConfig_Validation_Action `protobuf:"varint,2,opt,name=on_invalid,json=onInvalid,enum=cloudprober.rds.file.ProviderConfig_Validation_Action,def=0" json:"on_invalid,omitempty"`
 }
 
-var (
-	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescOnce sync.Once
-	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDesc
+// Default values for ProviderConfig_Validation fields.
+const (
+	Default_ProviderConfig_Validation_OnInvalid = ProviderConfig_Validation_SKIP
 )
 
-func file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP() []byte {
-	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescOnce.Do(func() {
-		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData)
-	})
-	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData
+func (x *ProviderConfig_Validation) Reset() {
+	*x = ProviderConfig_Validation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+func (x *ProviderConfig_Validation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig_Validation) ProtoMessage() {}
+
+func (x *ProviderConfig_Validation) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig_Validation.ProtoReflect.Descriptor instead.
+func (*ProviderConfig_Validation) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *ProviderConfig_Validation) GetRequiredField() []string {
+	if x != nil {
+		return x.RequiredField
+	}
+	return nil
+}
+
+func (x *ProviderConfig_Validation) GetOnInvalid() ProviderConfig_Validation_Action {
+	if x != nil && x.OnInvalid != nil {
+		return *x.OnInvalid
+	}
+	return Default_ProviderConfig_Validation_OnInvalid
+}
+
+type ProviderConfig_Merge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Label to add to each resource, with the path of the file it came from
+	// as the value. This label always overrides the resource's own label of
+	// the same name.
+	SourceLabel *string `protobuf:"bytes,1,opt,name=source_label,json=sourceLabel,def=source_file" json:"source_label,omitempty"`
+	// How to handle resources with the same name in multiple files. Files
+	// are ordered as in file_path.
+	Dedup *ProviderConfig_Merge_Dedup `protobuf:"varint,2,opt,name=dedup,enum=cloudprober.rds.file.ProviderConfig_Merge_Dedup,def=0" json:"dedup,omitempty"`
+	// Priority of the files for MERGE_BY_NAME, keyed by file path. Files
+	// without an explicit priority have priority 0. Between the files with
+	// the same priority, later file (in file_path order) wins.
+	// Example, to let overrides.textpb win over all the other files:
+	//
+	//	file_priority {
+	//	  key: "/etc/cloudprober/overrides.textpb"
+	//	  value: 10
+	//	}
+	FilePriority map[string]int32 `protobuf:"bytes,3,rep,name=file_priority,json=filePriority" json:"file_priority,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+// Default values for ProviderConfig_Merge fields.
+const (
+	Default_ProviderConfig_Merge_SourceLabel = string("source_file")
+	Default_ProviderConfig_Merge_Dedup       = ProviderConfig_Merge_KEEP_ALL
+)
+
+func (x *ProviderConfig_Merge) Reset() {
+	*x = ProviderConfig_Merge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig_Merge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig_Merge) ProtoMessage() {}
+
+func (x *ProviderConfig_Merge) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig_Merge.ProtoReflect.Descriptor instead.
+func (*ProviderConfig_Merge) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *ProviderConfig_Merge) GetSourceLabel() string {
+	if x != nil && x.SourceLabel != nil {
+		return *x.SourceLabel
+	}
+	return Default_ProviderConfig_Merge_SourceLabel
+}
+
+func (x *ProviderConfig_Merge) GetDedup() ProviderConfig_Merge_Dedup {
+	if x != nil && x.Dedup != nil {
+		return *x.Dedup
+	}
+	return Default_ProviderConfig_Merge_Dedup
+}
+
+func (x *ProviderConfig_Merge) GetFilePriority() map[string]int32 {
+	if x != nil {
+		return x.FilePriority
+	}
+	return nil
+}
+
+// Debug snapshot of the provider's current parsed resources, for each file,
+// in JSON. Snapshot is written to the given path on demand, by sending a
+// request to the URL /debug/rds/file/snapshot on cloudprober's default HTTP
+// server (unless HTTP debug handlers are disabled). It's useful to find out
+// why a filter matched unexpected resources. Resources are not redacted.
+type ProviderConfig_DebugSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path to write the snapshot to.
+	Path *string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// Maximum size of the resources in the snapshot. Resources beyond this
+	// size are omitted, and snapshot is marked as truncated.
+	MaxSizeBytes *int64 `protobuf:"varint,2,opt,name=max_size_bytes,json=maxSizeBytes,def=10485760" json:"max_size_bytes,omitempty"`
+}
+
+// Default values for ProviderConfig_DebugSnapshot fields.
+const (
+	Default_ProviderConfig_DebugSnapshot_MaxSizeBytes = int64(10485760)
+)
+
+func (x *ProviderConfig_DebugSnapshot) Reset() {
+	*x = ProviderConfig_DebugSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig_DebugSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig_DebugSnapshot) ProtoMessage() {}
+
+func (x *ProviderConfig_DebugSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig_DebugSnapshot.ProtoReflect.Descriptor instead.
+func (*ProviderConfig_DebugSnapshot) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *ProviderConfig_DebugSnapshot) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *ProviderConfig_DebugSnapshot) GetMaxSizeBytes() int64 {
+	if x != nil && x.MaxSizeBytes != nil {
+		return *x.MaxSizeBytes
+	}
+	return Default_ProviderConfig_DebugSnapshot_MaxSizeBytes
+}
+
+type ProviderConfig_ReverseDNS struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Label to set to the resolved name.
+	Label *string `protobuf:"bytes,1,opt,name=label,def=hostname" json:"label,omitempty"`
+	// Timeout for each lookup. Lookups that time out are not cached, and
+	// are retried in the next load.
+	TimeoutMsec *int32 `protobuf:"varint,2,opt,name=timeout_msec,json=timeoutMsec,def=1000" json:"timeout_msec,omitempty"`
+	// How long to cache the lookup results. Names that don't resolve are
+	// cached as well.
+	CacheTtlSec *int32 `protobuf:"varint,3,opt,name=cache_ttl_sec,json=cacheTtlSec,def=3600" json:"cache_ttl_sec,omitempty"`
+	// Maximum number of concurrent lookups during a load.
+	MaxConcurrentLookups *int32 `protobuf:"varint,4,opt,name=max_concurrent_lookups,json=maxConcurrentLookups,def=16" json:"max_concurrent_lookups,omitempty"`
+}
+
+// Default values for ProviderConfig_ReverseDNS fields.
+const (
+	Default_ProviderConfig_ReverseDNS_Label                = string("hostname")
+	Default_ProviderConfig_ReverseDNS_TimeoutMsec          = int32(1000)
+	Default_ProviderConfig_ReverseDNS_CacheTtlSec          = int32(3600)
+	Default_ProviderConfig_ReverseDNS_MaxConcurrentLookups = int32(16)
+)
+
+func (x *ProviderConfig_ReverseDNS) Reset() {
+	*x = ProviderConfig_ReverseDNS{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig_ReverseDNS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig_ReverseDNS) ProtoMessage() {}
+
+func (x *ProviderConfig_ReverseDNS) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig_ReverseDNS.ProtoReflect.Descriptor instead.
+func (*ProviderConfig_ReverseDNS) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *ProviderConfig_ReverseDNS) GetLabel() string {
+	if x != nil && x.Label != nil {
+		return *x.Label
+	}
+	return Default_ProviderConfig_ReverseDNS_Label
+}
+
+func (x *ProviderConfig_ReverseDNS) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_ReverseDNS_TimeoutMsec
+}
+
+func (x *ProviderConfig_ReverseDNS) GetCacheTtlSec() int32 {
+	if x != nil && x.CacheTtlSec != nil {
+		return *x.CacheTtlSec
+	}
+	return Default_ProviderConfig_ReverseDNS_CacheTtlSec
+}
+
+func (x *ProviderConfig_ReverseDNS) GetMaxConcurrentLookups() int32 {
+	if x != nil && x.MaxConcurrentLookups != nil {
+		return *x.MaxConcurrentLookups
+	}
+	return Default_ProviderConfig_ReverseDNS_MaxConcurrentLookups
+}
+
+type ProviderConfig_Sentinel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path of the sentinel file. Like file_path, it can be local, on GCS, on
+	// S3, or any HTTP(S) URL.
+	Path *string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	// Key of the version in the sentinel file. If set, sentinel file is
+	// parsed as a JSON or YAML object, and the value of this (top-level) key
+	// is used as the version, e.g. with version_key "version", sentinel file
+	// could be:
+	//
+	//	{"version": "2024-06-01.3", "generated_by": "inventory-sync"}
+	//
+	// If not set, the whole (whitespace-trimmed) content of the sentinel
+	// file is used as the version.
+	VersionKey *string `protobuf:"bytes,2,opt,name=version_key,json=versionKey" json:"version_key,omitempty"`
+}
+
+func (x *ProviderConfig_Sentinel) Reset() {
+	*x = ProviderConfig_Sentinel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig_Sentinel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig_Sentinel) ProtoMessage() {}
+
+func (x *ProviderConfig_Sentinel) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig_Sentinel.ProtoReflect.Descriptor instead.
+func (*ProviderConfig_Sentinel) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *ProviderConfig_Sentinel) GetPath() string {
+	if x != nil && x.Path != nil {
+		return *x.Path
+	}
+	return ""
+}
+
+func (x *ProviderConfig_Sentinel) GetVersionKey() string {
+	if x != nil && x.VersionKey != nil {
+		return *x.VersionKey
+	}
+	return ""
+}
+
+// Resources can also be grouped in named sections, e.g. by service. A
+// section can be addressed individually by setting the resource_path to
+// "<file_path>#<section_name>", or just "#<section_name>" if there is only
+// one file. Without a section in the resource_path, all the resources in
+// the file, including the ones in sections, are returned.
+//
+// Example in textproto format:
+//
+//	section {
+//	  name: "web"
+//	  resource {
+//	    name: "web-01"
+//	    ip: "10.1.2.3"
+//	  }
+//	}
+//
+//	section {
+//	  name: "db"
+//	  resource {
+//	    name: "db-01"
+//	    ip: "10.1.3.4"
+//	  }
+//	}
+type FileResources_Section struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     *string           `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Resource []*proto.Endpoint `protobuf:"bytes,2,rep,name=resource" json:"resource,omitempty"`
+}
+
+func (x *FileResources_Section) Reset() {
+	*x = FileResources_Section{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileResources_Section) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileResources_Section) ProtoMessage() {}
+
+func (x *FileResources_Section) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileResources_Section.ProtoReflect.Descriptor instead.
+func (*FileResources_Section) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *FileResources_Section) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *FileResources_Section) GetResource() []*proto.Endpoint {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x1a,
+	0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2f, 0x65, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb5, 0x13, 0x0a, 0x0e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x43, 0x0a, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65,
+	0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1e,
+	0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x3d,
+	0x0a, 0x1b, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69,
+	0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x18, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x69,
+	0x66, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x5e, 0x0a,
+	0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x58, 0x0a,
+	0x0c, 0x74, 0x79, 0x70, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x64, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65,
+	0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x4f, 0x0a, 0x0a,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72,
+	0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a,
+	0x10, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x40, 0x0a, 0x05, 0x6d, 0x65, 0x72, 0x67, 0x65,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4d, 0x65, 0x72,
+	0x67, 0x65, 0x52, 0x05, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x12, 0x3c, 0x0a, 0x18, 0x6d, 0x61, 0x78,
+	0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x36, 0x52,
+	0x16, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x66, 0x72, 0x65, 0x73, 0x68, 0x65, 0x73, 0x12, 0x59, 0x0a, 0x0e, 0x64, 0x65, 0x62, 0x75, 0x67,
+	0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x32, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64,
+	0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x52, 0x0d, 0x64, 0x65, 0x62, 0x75, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x6e, 0x0a, 0x11, 0x6f, 0x6e, 0x5f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x38, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e,
+	0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x3a, 0x08, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x41, 0x4c,
+	0x4c, 0x52, 0x0f, 0x6f, 0x6e, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x70, 0x61, 0x72, 0x73, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x11, 0x70, 0x61, 0x72, 0x73, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x5f, 0x64,
+	0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52,
+	0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x44, 0x4e, 0x53, 0x52, 0x0a, 0x72, 0x65, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x44, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x6e, 0x6f, 0x72,
+	0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x49, 0x0a, 0x08, 0x73,
+	0x65, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e,
+	0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x53, 0x65, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x73, 0x65,
+	0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x1a, 0x40, 0x0a, 0x12, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6e, 0x0a, 0x10, 0x54, 0x79, 0x70, 0x65,
+	0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x44,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e,
+	0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0xae, 0x01, 0x0a, 0x0a, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x5b,
+	0x0a, 0x0a, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x36, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x04, 0x53, 0x4b, 0x49, 0x50,
+	0x52, 0x09, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x22, 0x1c, 0x0a, 0x06, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4b, 0x49, 0x50, 0x10, 0x00, 0x12,
+	0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01, 0x1a, 0x80, 0x03, 0x0a, 0x05, 0x4d, 0x65,
+	0x72, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x50, 0x0a, 0x05, 0x64, 0x65, 0x64, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x30, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x2e, 0x44,
+	0x65, 0x64, 0x75, 0x70, 0x3a, 0x08, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x41, 0x4c, 0x4c, 0x52, 0x05,
+	0x64, 0x65, 0x64, 0x75, 0x70, 0x12, 0x61, 0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x66,
+	0x69, 0x6c, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x3f, 0x0a, 0x11, 0x46, 0x69, 0x6c, 0x65,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x51, 0x0a, 0x05, 0x44, 0x65, 0x64,
+	0x75, 0x70, 0x12, 0x0c, 0x0a, 0x08, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x00,
+	0x12, 0x13, 0x0a, 0x0f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x57,
+	0x49, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x46, 0x49,
+	0x4c, 0x45, 0x5f, 0x57, 0x49, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x45, 0x52,
+	0x47, 0x45, 0x5f, 0x42, 0x59, 0x5f, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x03, 0x1a, 0x53, 0x0a, 0x0d,
+	0x44, 0x65, 0x62, 0x75, 0x67, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x2e, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x3a, 0x08, 0x31, 0x30, 0x34, 0x38, 0x35,
+	0x37, 0x36, 0x30, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65,
+	0x73, 0x1a, 0xb9, 0x01, 0x0a, 0x0a, 0x52, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x44, 0x4e, 0x53,
+	0x12, 0x1e, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x3a,
+	0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x12, 0x27, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x31, 0x30, 0x30, 0x30, 0x52, 0x0b, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x28, 0x0a, 0x0d, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x3a, 0x04, 0x33, 0x36, 0x30, 0x30, 0x52, 0x0b, 0x63, 0x61, 0x63, 0x68, 0x65, 0x54, 0x74, 0x6c,
+	0x53, 0x65, 0x63, 0x12, 0x38, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x6c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x36, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x73, 0x1a, 0x3f, 0x0a,
+	0x08, 0x53, 0x65, 0x6e, 0x74, 0x69, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a,
+	0x0b, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x22, 0x57,
+	0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x54, 0x45, 0x58,
+	0x54, 0x50, 0x42, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x59, 0x41, 0x4d, 0x4c, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x4f,
+	0x4d, 0x45, 0x54, 0x48, 0x45, 0x55, 0x53, 0x5f, 0x53, 0x44, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05,
+	0x4a, 0x53, 0x4f, 0x4e, 0x4c, 0x10, 0x05, 0x22, 0x38, 0x0a, 0x09, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00,
+	0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44,
+	0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c, 0x10,
+	0x03, 0x22, 0x5e, 0x0a, 0x13, 0x44, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0c, 0x0a, 0x08, 0x4b, 0x45, 0x45, 0x50,
+	0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x41, 0x49, 0x4c, 0x10, 0x01,
+	0x12, 0x0e, 0x0a, 0x0a, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x02,
+	0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45, 0x45, 0x50, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x03, 0x12,
+	0x10, 0x0a, 0x0c, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x53, 0x10,
+	0x04, 0x22, 0xeb, 0x01, 0x0a, 0x0d, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x45,
+	0x0a, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64,
+	0x73, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x73, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x58, 0x0a, 0x07, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x42,
+	0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72,
+	0x64, 0x73, 0x2f, 0x66, 0x69, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_goTypes = []any{
-	(ProviderConfig_Format)(0), // 0: cloudprober.rds.file.ProviderConfig.Format
-	(*ProviderConfig)(nil),     // 1: cloudprober.rds.file.ProviderConfig
-	(*FileResources)(nil),      // 2: cloudprober.rds.file.FileResources
-	(*proto.Endpoint)(nil),     // 3: cloudprober.targets.Endpoint
+	(ProviderConfig_Format)(0),              // 0: cloudprober.rds.file.ProviderConfig.Format
+	(ProviderConfig_LabelType)(0),           // 1: cloudprober.rds.file.ProviderConfig.LabelType
+	(ProviderConfig_DuplicateNamePolicy)(0), // 2: cloudprober.rds.file.ProviderConfig.DuplicateNamePolicy
+	(ProviderConfig_Validation_Action)(0),   // 3: cloudprober.rds.file.ProviderConfig.Validation.Action
+	(ProviderConfig_Merge_Dedup)(0),         // 4: cloudprober.rds.file.ProviderConfig.Merge.Dedup
+	(*ProviderConfig)(nil),                  // 5: cloudprober.rds.file.ProviderConfig
+	(*FileResources)(nil),                   // 6: cloudprober.rds.file.FileResources
+	nil,                                     // 7: cloudprober.rds.file.ProviderConfig.DefaultLabelsEntry
+	nil,                                     // 8: cloudprober.rds.file.ProviderConfig.TypedLabelsEntry
+	(*ProviderConfig_Validation)(nil),       // 9: cloudprober.rds.file.ProviderConfig.Validation
+	(*ProviderConfig_Merge)(nil),            // 10: cloudprober.rds.file.ProviderConfig.Merge
+	(*ProviderConfig_DebugSnapshot)(nil),    // 11: cloudprober.rds.file.ProviderConfig.DebugSnapshot
+	(*ProviderConfig_ReverseDNS)(nil),       // 12: cloudprober.rds.file.ProviderConfig.ReverseDNS
+	(*ProviderConfig_Sentinel)(nil),         // 13: cloudprober.rds.file.ProviderConfig.Sentinel
+	nil,                                     // 14: cloudprober.rds.file.ProviderConfig.Merge.FilePriorityEntry
+	(*FileResources_Section)(nil),           // 15: cloudprober.rds.file.FileResources.Section
+	(*proto.Endpoint)(nil),                  // 16: cloudprober.targets.Endpoint
 }
 var file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_depIdxs = []int32{
-	0, // 0: cloudprober.rds.file.ProviderConfig.format:type_name -> cloudprober.rds.file.ProviderConfig.Format
-	3, // 1: cloudprober.rds.file.FileResources.resource:type_name -> cloudprober.targets.Endpoint
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: cloudprober.rds.file.ProviderConfig.format:type_name -> cloudprober.rds.file.ProviderConfig.Format
+	7,  // 1: cloudprober.rds.file.ProviderConfig.default_labels:type_name -> cloudprober.rds.file.ProviderConfig.DefaultLabelsEntry
+	8,  // 2: cloudprober.rds.file.ProviderConfig.typed_labels:type_name -> cloudprober.rds.file.ProviderConfig.TypedLabelsEntry
+	9,  // 3: cloudprober.rds.file.ProviderConfig.validation:type_name -> cloudprober.rds.file.ProviderConfig.Validation
+	10, // 4: cloudprober.rds.file.ProviderConfig.merge:type_name -> cloudprober.rds.file.ProviderConfig.Merge
+	11, // 5: cloudprober.rds.file.ProviderConfig.debug_snapshot:type_name -> cloudprober.rds.file.ProviderConfig.DebugSnapshot
+	2,  // 6: cloudprober.rds.file.ProviderConfig.on_duplicate_name:type_name -> cloudprober.rds.file.ProviderConfig.DuplicateNamePolicy
+	12, // 7: cloudprober.rds.file.ProviderConfig.reverse_dns:type_name -> cloudprober.rds.file.ProviderConfig.ReverseDNS
+	13, // 8: cloudprober.rds.file.ProviderConfig.sentinel:type_name -> cloudprober.rds.file.ProviderConfig.Sentinel
+	16, // 9: cloudprober.rds.file.FileResources.resource:type_name -> cloudprober.targets.Endpoint
+	15, // 10: cloudprober.rds.file.FileResources.section:type_name -> cloudprober.rds.file.FileResources.Section
+	1,  // 11: cloudprober.rds.file.ProviderConfig.TypedLabelsEntry.value:type_name -> cloudprober.rds.file.ProviderConfig.LabelType
+	3,  // 12: cloudprober.rds.file.ProviderConfig.Validation.on_invalid:type_name -> cloudprober.rds.file.ProviderConfig.Validation.Action
+	4,  // 13: cloudprober.rds.file.ProviderConfig.Merge.dedup:type_name -> cloudprober.rds.file.ProviderConfig.Merge.Dedup
+	14, // 14: cloudprober.rds.file.ProviderConfig.Merge.file_priority:type_name -> cloudprober.rds.file.ProviderConfig.Merge.FilePriorityEntry
+	16, // 15: cloudprober.rds.file.FileResources.Section.resource:type_name -> cloudprober.targets.Endpoint
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_init() }
@@ -372,14 +1502,86 @@ func file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_prot
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig_Validation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig_Merge); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig_DebugSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig_ReverseDNS); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig_Sentinel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*FileResources_Section); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_file_proto_config_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      5,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
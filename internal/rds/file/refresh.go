@@ -0,0 +1,125 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// refreshStats keeps track of a file's refreshes: how many refreshes were
+// done, how many of them actually reloaded the file, how many failed, and
+// the total time spent in them.
+type refreshStats struct {
+	mu                         sync.Mutex
+	refreshes, reloads, errors int64
+	latency                    time.Duration
+}
+
+func (rs *refreshStats) record(latency time.Duration, err error) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.refreshes++
+	rs.latency += latency
+	if err != nil {
+		rs.errors++
+	}
+}
+
+func (rs *refreshStats) recordReload() {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.reloads++
+}
+
+// timedRefresh refreshes the lister and records the refresh stats.
+func (ls *lister) timedRefresh() error {
+	start := time.Now()
+	err := ls.refresh()
+	ls.refreshStats.record(time.Since(start), err)
+	return err
+}
+
+// refreshAll refreshes all the files, up to maxConcurrentRefreshes at a time.
+// Files that have not changed since their last refresh are not re-read. It
+// returns all refresh errors joined together.
+func (p *Provider) refreshAll() error {
+	sem := make(chan struct{}, p.maxConcurrentRefreshes)
+	errs := make([]error, len(p.filePaths))
+
+	var wg sync.WaitGroup
+	for i, fp := range p.filePaths {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ls *lister) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = ls.timedRefresh()
+		}(i, p.listers[fp])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// refreshLoop refreshes the files at the given interval. If initialRefresh
+// is true, files are refreshed once right away.
+func (p *Provider) refreshLoop(reEvalInterval time.Duration, initialRefresh bool) {
+	if initialRefresh {
+		if err := p.refreshAll(); err != nil {
+			p.l.Error(err.Error())
+		}
+	}
+
+	// Introduce a random delay between 0-reEvalInterval before starting the
+	// refresh loop. If there are multiple cloudprober instances, this will
+	// make sure that each instance refreshes at a different point of time.
+	randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+	time.Sleep(time.Duration(randomDelaySec) * time.Second)
+	for range time.Tick(reEvalInterval) {
+		if err := p.refreshAll(); err != nil {
+			p.l.Error(err.Error())
+		}
+	}
+}
+
+// RefreshMetrics returns the file refresh stats as cumulative EventMetrics,
+// one for each file: number of refreshes ("refreshes"), number of refreshes
+// that actually reloaded the file ("reloads"), failed refreshes
+// ("refresh_errors"), and the total time spent in refreshes
+// ("refresh_latency", in milliseconds).
+func (p *Provider) RefreshMetrics() []*metrics.EventMetrics {
+	ts := time.Now()
+	var ems []*metrics.EventMetrics
+	for _, fp := range p.filePaths {
+		rs := &p.listers[fp].refreshStats
+		rs.mu.Lock()
+		em := metrics.NewEventMetrics(ts).
+			AddMetric("refreshes", metrics.NewInt(rs.refreshes)).
+			AddMetric("reloads", metrics.NewInt(rs.reloads)).
+			AddMetric("refresh_errors", metrics.NewInt(rs.errors)).
+			AddMetric("refresh_latency", metrics.NewFloat(float64(rs.latency)/float64(time.Millisecond))).
+			AddLabel("ptype", "rds").
+			AddLabel("provider", DefaultProviderID).
+			AddLabel("file_path", fp)
+		rs.mu.Unlock()
+		ems = append(ems, em)
+	}
+	return ems
+}
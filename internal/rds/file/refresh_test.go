@@ -0,0 +1,86 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRefreshAll(t *testing.T) {
+	dir := t.TempDir()
+
+	var filePaths []string
+	for i := 0; i < 20; i++ {
+		fp := filepath.Join(dir, fmt.Sprintf("team-%02d.textpb", i))
+		require.NoError(t, os.WriteFile(fp, []byte(fmt.Sprintf(`resource { name: "host-%02d" }`, i)), 0644))
+		filePaths = append(filePaths, fp)
+	}
+
+	p, err := New(&configpb.ProviderConfig{
+		FilePath:               filePaths,
+		MaxConcurrentRefreshes: proto.Int32(4),
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := p.ListResources(&rdspb.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), len(filePaths))
+
+	// Change one of the files. Only that file should be reloaded.
+	require.NoError(t, os.WriteFile(filePaths[3], []byte(`resource { name: "host-03a" } resource { name: "host-03b" }`), 0644))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filePaths[3], modTime, modTime))
+	require.NoError(t, p.refreshAll())
+
+	resp, err = p.ListResources(&rdspb.ListResourcesRequest{ResourcePath: proto.String(filePaths[3])})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 2)
+
+	ems := p.RefreshMetrics()
+	require.Len(t, ems, len(filePaths))
+	for i, em := range ems {
+		wantReloads := "1"
+		if i == 3 {
+			wantReloads = "2"
+		}
+		assert.Equal(t, filePaths[i], em.Label("file_path"))
+		assert.Equal(t, "2", em.Metric("refreshes").String(), "file: %s", filePaths[i])
+		assert.Equal(t, wantReloads, em.Metric("reloads").String(), "file: %s", filePaths[i])
+		assert.Equal(t, "0", em.Metric("refresh_errors").String(), "file: %s", filePaths[i])
+	}
+
+	// Remove a file; refresh error is returned and counted.
+	require.NoError(t, os.Remove(filePaths[5]))
+	assert.ErrorContains(t, p.refreshAll(), filePaths[5])
+	assert.Equal(t, "1", p.RefreshMetrics()[5].Metric("refresh_errors").String())
+}
+
+func TestInvalidMaxConcurrentRefreshes(t *testing.T) {
+	_, err := New(&configpb.ProviderConfig{
+		FilePath:               testResourcesFiles["json"],
+		MaxConcurrentRefreshes: proto.Int32(0),
+	}, nil)
+	assert.Error(t, err)
+}
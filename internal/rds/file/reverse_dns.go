@@ -0,0 +1,140 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+)
+
+type rdnsCacheEntry struct {
+	name   string // Empty if IP doesn't resolve.
+	expiry time.Time
+}
+
+// reverseResolver adds the reverse DNS names of the resources' IPs as a
+// label.
+type reverseResolver struct {
+	label         string
+	timeout       time.Duration
+	cacheTTL      time.Duration
+	maxConcurrent int
+	l             *logger.Logger
+
+	// lookupAddr is net.DefaultResolver.LookupAddr, overridden in tests.
+	lookupAddr func(ctx context.Context, addr string) ([]string, error)
+
+	mu    sync.Mutex
+	cache map[string]rdnsCacheEntry
+}
+
+func newReverseResolver(c *configpb.ProviderConfig_ReverseDNS, l *logger.Logger) (*reverseResolver, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.GetLabel() == "" {
+		return nil, fmt.Errorf("reverse_dns: label cannot be empty")
+	}
+	if c.GetTimeoutMsec() <= 0 || c.GetMaxConcurrentLookups() <= 0 {
+		return nil, fmt.Errorf("reverse_dns: timeout_msec and max_concurrent_lookups should be positive")
+	}
+	return &reverseResolver{
+		label:         c.GetLabel(),
+		timeout:       time.Duration(c.GetTimeoutMsec()) * time.Millisecond,
+		cacheTTL:      time.Duration(c.GetCacheTtlSec()) * time.Second,
+		maxConcurrent: int(c.GetMaxConcurrentLookups()),
+		l:             l,
+		lookupAddr:    net.DefaultResolver.LookupAddr,
+		cache:         make(map[string]rdnsCacheEntry),
+	}, nil
+}
+
+// resolve returns the reverse DNS name for the IP, using the cache if
+// possible. It returns an empty string if IP doesn't resolve.
+func (rr *reverseResolver) resolve(ip string) string {
+	rr.mu.Lock()
+	entry, ok := rr.cache[ip]
+	rr.mu.Unlock()
+	if ok && time.Now().Before(entry.expiry) {
+		return entry.name
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rr.timeout)
+	defer cancel()
+
+	names, err := rr.lookupAddr(ctx, ip)
+	if err != nil {
+		rr.l.Debugf("reverse_dns: lookup for %s failed: %v", ip, err)
+
+		// Cache only the definitive failures.
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			return ""
+		}
+	}
+
+	name := ""
+	if len(names) > 0 {
+		name = strings.TrimSuffix(names[0], ".")
+	}
+	rr.mu.Lock()
+	rr.cache[ip] = rdnsCacheEntry{name: name, expiry: time.Now().Add(rr.cacheTTL)}
+	rr.mu.Unlock()
+	return name
+}
+
+// apply adds the label to the resources with an IP, unless they already
+// have it. Lookups are done concurrently.
+func (rr *reverseResolver) apply(resources []*targetspb.Endpoint) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, rr.maxConcurrent)
+	names := make([]string, len(resources))
+
+	for i, res := range resources {
+		if res.GetIp() == "" || net.ParseIP(res.GetIp()) == nil {
+			continue
+		}
+		if _, ok := res.GetLabels()[rr.label]; ok {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, ip string) {
+			defer wg.Done()
+			names[i] = rr.resolve(ip)
+			<-sem
+		}(i, res.GetIp())
+	}
+	wg.Wait()
+
+	for i, res := range resources {
+		if names[i] == "" {
+			continue
+		}
+		if res.Labels == nil {
+			res.Labels = make(map[string]string)
+		}
+		res.Labels[rr.label] = names[i]
+	}
+}
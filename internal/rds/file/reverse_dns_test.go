@@ -0,0 +1,114 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestReverseDNSLabels(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "resources.textpb")
+	require.NoError(t, os.WriteFile(testFile, []byte(`
+resource { name: "r1" ip: "10.0.0.1" }
+resource { name: "r2" ip: "10.0.0.2" }
+resource { name: "r3" ip: "10.0.0.3" labels { key: "hostname" value: "explicit" } }
+resource { name: "r4" ip: "10.0.0.4" }
+resource { name: "r5" }
+section {
+  name: "s1"
+  resource { name: "r6" ip: "10.0.0.1" }
+}
+`), 0644))
+
+	ls, err := newLister(testFile, &configpb.ProviderConfig{
+		ReverseDns: &configpb.ProviderConfig_ReverseDNS{},
+	}, &logger.Logger{})
+	require.NoError(t, err)
+
+	var lookups atomic.Int64
+	ls.reverseResolver.lookupAddr = func(ctx context.Context, addr string) ([]string, error) {
+		lookups.Add(1)
+		switch addr {
+		case "10.0.0.1":
+			return []string{"host-1.example.com."}, nil
+		case "10.0.0.2":
+			return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+		default:
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+	ls.reverseResolver.timeout = 10 * time.Millisecond
+
+	require.NoError(t, ls.refresh())
+
+	wantLabels := map[string]string{
+		"r1": "host-1.example.com",
+		"r2": "",
+		"r3": "explicit",
+		"r4": "",
+		"r5": "",
+		"r6": "host-1.example.com",
+	}
+	for _, res := range ls.resources {
+		assert.Equal(t, wantLabels[res.GetName()], res.GetLabels()["hostname"], "resource: %s", res.GetName())
+	}
+	assert.Len(t, ls.resources, len(wantLabels))
+
+	// Lookups are cached (10.0.0.1 is looked up only once), except for the
+	// ones that timed out (10.0.0.4).
+	assert.Equal(t, int64(3), lookups.Load())
+	ls.lastUpdated = time.Time{}
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, int64(4), lookups.Load())
+}
+
+func TestNewReverseResolver(t *testing.T) {
+	rr, err := newReverseResolver(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rr)
+
+	for _, c := range []*configpb.ProviderConfig_ReverseDNS{
+		{Label: proto.String("")},
+		{TimeoutMsec: proto.Int32(0)},
+		{MaxConcurrentLookups: proto.Int32(-1)},
+	} {
+		_, err := newReverseResolver(c, nil)
+		assert.Error(t, err, "config: %v", c)
+	}
+
+	rr, err = newReverseResolver(&configpb.ProviderConfig_ReverseDNS{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hostname", rr.label)
+	assert.Equal(t, time.Second, rr.timeout)
+
+	// Errors other than "not found" are not cached.
+	rr.lookupAddr = func(context.Context, string) ([]string, error) { return nil, errors.New("server failure") }
+	assert.Equal(t, "", rr.resolve("10.0.0.1"))
+	assert.Empty(t, rr.cache)
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"sigs.k8s.io/yaml"
+)
+
+// sentinel is a small version file, used to decide whether to reload the
+// (potentially large) resource files.
+type sentinel struct {
+	path       string
+	versionKey string
+}
+
+func newSentinel(c *configpb.ProviderConfig_Sentinel) (*sentinel, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.GetPath() == "" {
+		return nil, fmt.Errorf("sentinel: path is required")
+	}
+	return &sentinel{path: c.GetPath(), versionKey: c.GetVersionKey()}, nil
+}
+
+// parseVersion returns the version from the sentinel file's content.
+func (s *sentinel) parseVersion(b []byte) (string, error) {
+	if s.versionKey == "" {
+		if v := strings.TrimSpace(string(b)); v != "" {
+			return v, nil
+		}
+		return "", fmt.Errorf("sentinel file is empty")
+	}
+
+	jsonB, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return "", fmt.Errorf("error parsing sentinel file: %v", err)
+	}
+	// Use json.Number to keep large numeric versions intact.
+	var m map[string]any
+	dec := json.NewDecoder(bytes.NewReader(jsonB))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return "", fmt.Errorf("error parsing sentinel file as an object: %v", err)
+	}
+
+	v, ok := m[s.versionKey]
+	if !ok || v == nil {
+		return "", fmt.Errorf("version key (%s) not found in the sentinel file", s.versionKey)
+	}
+	switch v.(type) {
+	case string, json.Number, bool:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("version key (%s) in the sentinel file is not a scalar: %v", s.versionKey, v)
+	}
+}
+
+// version reads the sentinel file and returns the version in it.
+func (s *sentinel) version(ctx context.Context) (string, error) {
+	b, err := file.ReadFile(ctx, s.path)
+	if err != nil {
+		return "", fmt.Errorf("error reading sentinel file (%s): %v", s.path, err)
+	}
+	return s.parseVersion(b)
+}
+
+// checkSentinel returns the sentinel version, and whether the sentinel
+// version has changed since the last load. If the sentinel can't be read,
+// it returns an empty version, and the caller should fall back to the
+// regular checks.
+func (ls *lister) checkSentinel() (string, bool) {
+	v, err := ls.sentinel.version(context.Background())
+	if err != nil {
+		ls.l.Warningf("file(%s): %v; falling back to the modified time check.", ls.filePath, err)
+		return "", false
+	}
+
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	return v, v != ls.lastSentinelVersion
+}
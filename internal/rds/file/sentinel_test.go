@@ -0,0 +1,146 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSentinelParseVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		versionKey string
+		content    string
+		want       string
+		wantErr    bool
+	}{
+		{name: "plain", content: "v42\n", want: "v42"},
+		{name: "plain_empty", content: " \n", wantErr: true},
+		{name: "json", versionKey: "version", content: `{"version": "2024-06-01.3", "by": "sync"}`, want: "2024-06-01.3"},
+		{name: "json_number", versionKey: "version", content: `{"version": 1717200000123456789}`, want: "1717200000123456789"},
+		{name: "yaml", versionKey: "version", content: "version: 7\nby: sync\n", want: "7"},
+		{name: "missing_key", versionKey: "version", content: `{"generation": 7}`, wantErr: true},
+		{name: "not_scalar", versionKey: "version", content: `{"version": {"major": 1}}`, wantErr: true},
+		{name: "not_object", versionKey: "version", content: `[1, 2]`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &sentinel{versionKey: test.versionKey}
+			got, err := s.parseVersion([]byte(test.content))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSentinelConfigErrors(t *testing.T) {
+	for _, c := range []*configpb.ProviderConfig{
+		{Sentinel: &configpb.ProviderConfig_Sentinel{}},
+		{Sentinel: &configpb.ProviderConfig_Sentinel{Path: proto.String("/tmp/VERSION")}, Tail: proto.Bool(true)},
+	} {
+		_, err := newLister("/tmp/resources.jsonl", c, nil)
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+func TestRefreshWithSentinel(t *testing.T) {
+	dir := t.TempDir()
+	resFile, sentinelFile := filepath.Join(dir, "resources.json"), filepath.Join(dir, "VERSION")
+
+	writeFile := func(path, content string, modTime time.Time) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	resources := func(names ...string) string {
+		s := `{"resource": [`
+		for i, name := range names {
+			if i > 0 {
+				s += ","
+			}
+			s += `{"name": "` + name + `"}`
+		}
+		return s + `]}`
+	}
+	listNames := func(ls *lister) []string {
+		t.Helper()
+		resp, err := ls.listResources(nil)
+		require.NoError(t, err)
+		var names []string
+		for _, res := range resp.GetResources() {
+			names = append(names, res.GetName())
+		}
+		return names
+	}
+
+	now := time.Now()
+	writeFile(resFile, resources("r1"), now.Add(-time.Hour))
+	writeFile(sentinelFile, `{"version": "v1"}`, now)
+
+	ls, err := newLister(resFile, &configpb.ProviderConfig{
+		Sentinel: &configpb.ProviderConfig_Sentinel{
+			Path:       proto.String(sentinelFile),
+			VersionKey: proto.String("version"),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, []string{"r1"}, listNames(ls))
+	assert.Equal(t, "v1", ls.lastSentinelVersion)
+
+	// File is modified, but sentinel version is the same: no reload.
+	writeFile(resFile, resources("r1", "r2"), now.Add(time.Hour))
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, []string{"r1"}, listNames(ls))
+	assert.Equal(t, int64(1), ls.refreshStats.reloads)
+
+	// Sentinel version changes: reload.
+	writeFile(sentinelFile, `{"version": "v2"}`, now)
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, []string{"r1", "r2"}, listNames(ls))
+	assert.Equal(t, "v2", ls.lastSentinelVersion)
+
+	// Sentinel goes missing: fall back to the mod time check.
+	require.NoError(t, os.Remove(sentinelFile))
+	require.NoError(t, os.Chtimes(resFile, now.Add(-time.Hour), now.Add(-time.Hour)))
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, int64(2), ls.refreshStats.reloads, "file should not be reloaded as it's not modified since the last load")
+
+	writeFile(resFile, resources("r3"), now.Add(2*time.Hour))
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, []string{"r3"}, listNames(ls))
+	assert.Equal(t, "", ls.lastSentinelVersion)
+
+	// Sentinel comes back, with the old version: reload, as we don't know
+	// what the last loaded file's sentinel version was.
+	writeFile(sentinelFile, `{"version": "v2"}`, now)
+	require.NoError(t, ls.refresh())
+	assert.Equal(t, int64(4), ls.refreshStats.reloads)
+	assert.Equal(t, "v2", ls.lastSentinelVersion)
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// SnapshotURL is the URL path, on the default HTTP server, that triggers
+// the debug snapshots.
+const SnapshotURL = "/debug/rds/file/snapshot"
+
+// snapshotProviders are the providers with debug snapshot enabled, keyed by
+// the snapshot path.
+var (
+	snapshotMu        sync.Mutex
+	snapshotProviders = make(map[string]*Provider)
+)
+
+type fileSnapshot struct {
+	FilePath    string            `json:"file_path"`
+	LastUpdated time.Time         `json:"last_updated"`
+	Resources   []json.RawMessage `json:"resources"`
+
+	// Sections' resource names.
+	Sections map[string][]string `json:"sections,omitempty"`
+
+	// Number of resources omitted because of the size limit.
+	OmittedResources int `json:"omitted_resources,omitempty"`
+}
+
+type snapshot struct {
+	Timestamp time.Time       `json:"timestamp"`
+	Files     []*fileSnapshot `json:"files"`
+	Truncated bool            `json:"truncated"`
+}
+
+// snapshot returns the snapshot of the provider's current resources. Once
+// the total size of the resources exceeds maxSize, remaining resources are
+// omitted.
+func (p *Provider) snapshot(maxSize int64) (*snapshot, error) {
+	s := &snapshot{Timestamp: time.Now()}
+
+	var size int64
+	for _, fp := range p.filePaths {
+		ls := p.listers[fp]
+		ls.mu.RLock()
+
+		fs := &fileSnapshot{
+			FilePath:    fp,
+			LastUpdated: ls.lastUpdated,
+			Resources:   []json.RawMessage{},
+		}
+		for i, res := range ls.resources {
+			b, err := protojson.Marshal(res)
+			if err != nil {
+				ls.mu.RUnlock()
+				return nil, fmt.Errorf("error marshaling resource %s: %v", res.GetName(), err)
+			}
+			if size += int64(len(b)); size > maxSize {
+				fs.OmittedResources = len(ls.resources) - i
+				s.Truncated = true
+				break
+			}
+			fs.Resources = append(fs.Resources, b)
+		}
+		for name, resources := range ls.sections {
+			if fs.Sections == nil {
+				fs.Sections = make(map[string][]string)
+			}
+			names := make([]string, len(resources))
+			for i, res := range resources {
+				names[i] = res.GetName()
+			}
+			fs.Sections[name] = names
+		}
+
+		ls.mu.RUnlock()
+		s.Files = append(s.Files, fs)
+	}
+	return s, nil
+}
+
+// writeSnapshot writes the provider's snapshot to the configured path. File
+// is replaced atomically.
+func (p *Provider) writeSnapshot(c *configpb.ProviderConfig_DebugSnapshot) (*snapshot, error) {
+	s, err := p.snapshot(c.GetMaxSizeBytes())
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(c.GetPath()), filepath.Base(c.GetPath())+".tmp*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return s, os.Rename(f.Name(), c.GetPath())
+}
+
+// SnapshotHandler writes the debug snapshots for all the providers that
+// have debug_snapshot configured. It's registered with the default HTTP
+// server at SnapshotURL.
+func SnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+
+	if len(snapshotProviders) == 0 {
+		http.Error(w, "no file provider with debug_snapshot configured", http.StatusNotFound)
+		return
+	}
+
+	paths := make([]string, 0, len(snapshotProviders))
+	for path := range snapshotProviders {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		p := snapshotProviders[path]
+		s, err := p.writeSnapshot(p.debugSnapshot)
+		if err != nil {
+			p.l.Errorf("file_provider: error writing debug snapshot to %s: %v", path, err)
+			errs = append(errs, fmt.Errorf("%s: %v", path, err))
+			continue
+		}
+		numResources := 0
+		for _, fs := range s.Files {
+			numResources += len(fs.Resources)
+		}
+		fmt.Fprintf(w, "Wrote snapshot to %s: %d files, %d resources, truncated: %v\n", path, len(s.Files), numResources, s.Truncated)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// registerSnapshot registers the provider for the debug snapshots. A
+// provider with the same snapshot path replaces the older one (e.g. after a
+// config reload).
+func (p *Provider) registerSnapshot() error {
+	if p.debugSnapshot.GetPath() == "" {
+		return errors.New("file_provider: debug_snapshot.path is required")
+	}
+	if p.debugSnapshot.GetMaxSizeBytes() <= 0 {
+		return fmt.Errorf("file_provider: invalid debug_snapshot.max_size_bytes: %d", p.debugSnapshot.GetMaxSizeBytes())
+	}
+
+	snapshotMu.Lock()
+	defer snapshotMu.Unlock()
+	snapshotProviders[p.debugSnapshot.GetPath()] = p
+	return nil
+}
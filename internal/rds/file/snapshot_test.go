@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDebugSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	resourcesCount := len(testExpectedResources)
+
+	tests := []struct {
+		name          string
+		maxSizeBytes  int64
+		wantResources int
+		wantTruncated bool
+	}{
+		{
+			name:          "full",
+			wantResources: 2 * resourcesCount,
+		},
+		{
+			name:          "truncated",
+			maxSizeBytes:  1,
+			wantResources: 0,
+			wantTruncated: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshotPath := filepath.Join(dir, test.name+".json")
+			snapshotConf := &configpb.ProviderConfig_DebugSnapshot{Path: proto.String(snapshotPath)}
+			if test.maxSizeBytes != 0 {
+				snapshotConf.MaxSizeBytes = proto.Int64(test.maxSizeBytes)
+			}
+
+			_, err := New(&configpb.ProviderConfig{
+				FilePath:      []string{testResourcesFiles["json"][0], testResourcesFiles["yaml"][0]},
+				DebugSnapshot: snapshotConf,
+			}, nil)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				snapshotMu.Lock()
+				defer snapshotMu.Unlock()
+				delete(snapshotProviders, snapshotPath)
+			})
+
+			w := httptest.NewRecorder()
+			SnapshotHandler(w, httptest.NewRequest(http.MethodGet, SnapshotURL, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Contains(t, w.Body.String(), snapshotPath)
+
+			b, err := os.ReadFile(snapshotPath)
+			require.NoError(t, err)
+			var s snapshot
+			require.NoError(t, json.Unmarshal(b, &s))
+
+			require.Len(t, s.Files, 2)
+			assert.Equal(t, testResourcesFiles["json"][0], s.Files[0].FilePath)
+			assert.Equal(t, test.wantTruncated, s.Truncated)
+
+			numResources, numOmitted := 0, 0
+			for _, fs := range s.Files {
+				numResources += len(fs.Resources)
+				numOmitted += fs.OmittedResources
+			}
+			assert.Equal(t, test.wantResources, numResources)
+			assert.Equal(t, 2*resourcesCount-test.wantResources, numOmitted)
+		})
+	}
+}
+
+func TestDebugSnapshotConfigErrors(t *testing.T) {
+	for _, c := range []*configpb.ProviderConfig_DebugSnapshot{
+		{},
+		{Path: proto.String("/tmp/snapshot.json"), MaxSizeBytes: proto.Int64(0)},
+	} {
+		_, err := New(&configpb.ProviderConfig{
+			FilePath:      testResourcesFiles["json"],
+			DebugSnapshot: c,
+		}, nil)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// tailState tracks the part of a tailed file that has already been loaded.
+type tailState struct {
+	fi     os.FileInfo // File that was last read.
+	offset int64       // Offset up to which file has been read.
+}
+
+// parseJSONLines parses the JSON lines content, one endpoint per line.
+// Offset is the offset of the content in the file, used only for the error
+// messages.
+func parseJSONLines(b []byte, offset int64) (*configpb.FileResources, error) {
+	resources := &configpb.FileResources{}
+	for len(b) > 0 {
+		line, rest, _ := bytes.Cut(b, []byte("\n"))
+		if len(bytes.TrimSpace(line)) != 0 {
+			ep := &targetspb.Endpoint{}
+			if err := protojson.Unmarshal(line, ep); err != nil {
+				return nil, fmt.Errorf("error unmarshaling line at offset %d as JSON: %v", offset, err)
+			}
+			resources.Resource = append(resources.Resource, ep)
+		}
+		offset += int64(len(b) - len(rest))
+		b = rest
+	}
+	return resources, nil
+}
+
+// validateTail verifies that the file can be tailed.
+func validateTail(filePath string, c *configpb.ProviderConfig, format configpb.ProviderConfig_Format) error {
+	if format != configpb.ProviderConfig_JSONL {
+		return fmt.Errorf("tail is supported only for the JSONL format, got: %v", format)
+	}
+	if strings.Contains(filePath, "://") {
+		return fmt.Errorf("tail is supported only for local files")
+	}
+	if c.GetOnDuplicateName() != configpb.ProviderConfig_KEEP_ALL {
+		return fmt.Errorf("tail can't be used along with on_duplicate_name")
+	}
+	return nil
+}
+
+// refreshTail loads the lines appended to the file since its last read. If
+// file has not been read yet, or if it has been truncated or rotated since
+// its last read, it's loaded fully.
+func (ls *lister) refreshTail() error {
+	f, err := os.Open(ls.filePath)
+	if err != nil {
+		return fmt.Errorf("file(%s): error while opening file: %v", ls.filePath, err)
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("file(%s): error while getting file info: %v", ls.filePath, err)
+	}
+
+	ls.mu.RLock()
+	ts := ls.tailState
+	ls.mu.RUnlock()
+
+	full := ts.fi == nil || !os.SameFile(ts.fi, fi) || fi.Size() < ts.offset
+	offset := ts.offset
+	if full {
+		if ts.fi != nil {
+			ls.l.Infof("file(%s): File was truncated or rotated, reloading it fully", ls.filePath)
+		}
+		offset = 0
+	} else if fi.Size() == offset {
+		ls.l.Debugf("file(%s): Nothing appended to the file since its last refresh", ls.filePath)
+		return nil
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("file(%s): error while seeking to offset %d: %v", ls.filePath, offset, err)
+	}
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("file(%s): error while reading file: %v", ls.filePath, err)
+	}
+	// Leave the incomplete last line, if any, for the next refresh.
+	b = b[:bytes.LastIndexByte(b, '\n')+1]
+
+	fileResources, err := parseJSONLines(b, offset)
+	if err != nil {
+		return fmt.Errorf("file_provider(%s): %v", ls.filePath, err)
+	}
+	if err := ls.processResources(fileResources); err != nil {
+		return err
+	}
+	resources, err := ls.toRDSResources(fileResources.GetResource())
+	if err != nil {
+		return err
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if full {
+		ls.l.Infof("file_provider(%s): Read %d endpoints", ls.filePath, len(resources))
+		ls.resources = resources
+		ls.refreshStats.recordReload()
+	} else {
+		ls.l.Infof("file_provider(%s): Read %d appended endpoints", ls.filePath, len(resources))
+		ls.resources = append(ls.resources, resources...)
+	}
+	ls.tailState = tailState{fi: fi, offset: offset + int64(len(b))}
+	if full || len(resources) != 0 {
+		ls.lastUpdated = time.Now()
+	}
+
+	ls.readyOnce.Do(func() { close(ls.ready) })
+
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseJSONLines(t *testing.T) {
+	resources, err := parseJSONLines([]byte("{\"name\": \"r1\", \"port\": 80}\n\n  \n{\"name\": \"r2\"}"), 0)
+	require.NoError(t, err)
+	require.Len(t, resources.GetResource(), 2)
+	assert.Equal(t, "r1", resources.GetResource()[0].GetName())
+	assert.Equal(t, int32(80), resources.GetResource()[0].GetPort())
+	assert.Equal(t, "r2", resources.GetResource()[1].GetName())
+
+	_, err = parseJSONLines([]byte("{\"name\": \"r1\"}\n{bad-json\n"), 100)
+	assert.ErrorContains(t, err, "offset 115")
+}
+
+func TestTailConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		c        *configpb.ProviderConfig
+		wantErr  bool
+	}{
+		{name: "jsonl", filePath: "/tmp/resources.jsonl"},
+		{name: "explicit_format", filePath: "/tmp/resources.log", c: &configpb.ProviderConfig{Format: configpb.ProviderConfig_JSONL.Enum()}},
+		{name: "json", filePath: "/tmp/resources.json", wantErr: true},
+		{name: "remote", filePath: "gs://bucket/resources.jsonl", wantErr: true},
+		{name: "on_duplicate_name", filePath: "/tmp/resources.jsonl", c: &configpb.ProviderConfig{OnDuplicateName: configpb.ProviderConfig_KEEP_LAST.Enum()}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := test.c
+			if c == nil {
+				c = &configpb.ProviderConfig{}
+			}
+			c.Tail = proto.Bool(true)
+			ls, err := newLister(test.filePath, c, &logger.Logger{})
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, ls.tail)
+		})
+	}
+}
+
+func TestTail(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "resources.jsonl")
+	require.NoError(t, os.WriteFile(testFile, []byte("{\"name\": \"r1\"}\n{\"name\": \"r2\"}\n"), 0644))
+
+	ls, err := newLister(testFile, &configpb.ProviderConfig{
+		Tail:          proto.Bool(true),
+		DefaultLabels: map[string]string{"env": "prod"},
+	}, &logger.Logger{})
+	require.NoError(t, err)
+
+	appendToFile := func(s string) {
+		t.Helper()
+		f, err := os.OpenFile(testFile, os.O_APPEND|os.O_WRONLY, 0644)
+		require.NoError(t, err)
+		_, err = f.WriteString(s)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	verify := func(wantNames []string) {
+		t.Helper()
+		require.NoError(t, ls.refresh())
+		resp, err := ls.listResources(nil)
+		require.NoError(t, err)
+		var names []string
+		for _, res := range resp.GetResources() {
+			names = append(names, res.GetName())
+			assert.Equal(t, "prod", res.GetLabels()["env"], "resource: %s", res.GetName())
+		}
+		assert.Equal(t, wantNames, names)
+	}
+
+	verify([]string{"r1", "r2"})
+	assert.Equal(t, int64(1), ls.refreshStats.reloads)
+
+	// Nothing appended: lastUpdated is not changed.
+	lastUpdated := ls.lastUpdated
+	verify([]string{"r1", "r2"})
+	assert.Equal(t, lastUpdated, ls.lastUpdated)
+
+	// Incomplete line is read only after it's complete.
+	appendToFile("{\"name\": \"r3\"}\n{\"name\": ")
+	verify([]string{"r1", "r2", "r3"})
+	appendToFile("\"r4\"}\n")
+	verify([]string{"r1", "r2", "r3", "r4"})
+	assert.Equal(t, int64(1), ls.refreshStats.reloads)
+
+	// Bad line: refresh fails, and it's retried on next refresh.
+	appendToFile("{bad-json\n")
+	assert.Error(t, ls.refresh())
+	offset := ls.tailState.offset
+	assert.Error(t, ls.refresh())
+	assert.Equal(t, offset, ls.tailState.offset)
+
+	// Truncation triggers a full reload.
+	require.NoError(t, os.WriteFile(testFile, []byte("{\"name\": \"r5\"}\n"), 0644))
+	verify([]string{"r5"})
+	assert.Equal(t, int64(2), ls.refreshStats.reloads)
+
+	// So does rotation, even if the new file is bigger.
+	rotated := filepath.Join(t.TempDir(), "new.jsonl")
+	require.NoError(t, os.WriteFile(rotated, []byte("{\"name\": \"r6\"}\n{\"name\": \"r7\"}\n{\"name\": \"r8\"}\n"), 0644))
+	require.NoError(t, os.Rename(rotated, testFile))
+	verify([]string{"r6", "r7", "r8"})
+	assert.Equal(t, int64(3), ls.refreshStats.reloads)
+}
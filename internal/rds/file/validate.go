@@ -0,0 +1,146 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	targetspb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+)
+
+const labelFieldPrefix = "label:"
+
+type resourceValidator struct {
+	requiredFields []string
+	failOnInvalid  bool
+
+	// Number of invalid resources skipped so far.
+	skipped atomic.Int64
+}
+
+func newResourceValidator(c *configpb.ProviderConfig_Validation) (*resourceValidator, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	for _, f := range c.GetRequiredField() {
+		switch {
+		case f == "ip", f == "port", f == "url":
+		case strings.HasPrefix(f, labelFieldPrefix) && len(f) > len(labelFieldPrefix):
+		default:
+			return nil, fmt.Errorf("invalid required_field: %s", f)
+		}
+	}
+
+	return &resourceValidator{
+		requiredFields: c.GetRequiredField(),
+		failOnInvalid:  c.GetOnInvalid() == configpb.ProviderConfig_Validation_FAIL,
+	}, nil
+}
+
+// validate returns an error if resource is not valid.
+func (rv *resourceValidator) validate(res *targetspb.Endpoint) error {
+	for _, f := range rv.requiredFields {
+		switch f {
+		case "ip":
+			if res.GetIp() == "" && len(res.GetIps()) == 0 {
+				return fmt.Errorf("required field ip is missing")
+			}
+		case "port":
+			if res.Port == nil {
+				return fmt.Errorf("required field port is missing")
+			}
+		case "url":
+			if res.GetUrl() == "" {
+				return fmt.Errorf("required field url is missing")
+			}
+		default:
+			key := strings.TrimPrefix(f, labelFieldPrefix)
+			if _, ok := res.GetLabels()[key]; !ok {
+				return fmt.Errorf("required label %s is missing", key)
+			}
+		}
+	}
+
+	if res.Ip != nil && net.ParseIP(res.GetIp()) == nil {
+		return fmt.Errorf("invalid ip: %s", res.GetIp())
+	}
+	for _, ip := range res.GetIps() {
+		if net.ParseIP(ip) == nil {
+			return fmt.Errorf("invalid ip in ips: %s", ip)
+		}
+	}
+	if res.Port != nil && (res.GetPort() <= 0 || res.GetPort() > 65535) {
+		return fmt.Errorf("invalid port: %d", res.GetPort())
+	}
+	if res.Url != nil {
+		u, err := url.Parse(res.GetUrl())
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid url: %s", res.GetUrl())
+		}
+	}
+	return nil
+}
+
+// validateResources validates the resources and, depending on the config,
+// either removes the invalid resources or returns an error.
+func (ls *lister) validateResources(resources *configpb.FileResources) error {
+	if ls.validator == nil {
+		return nil
+	}
+
+	valid := resources.GetResource()[:0]
+	for _, res := range resources.GetResource() {
+		err := ls.validator.validate(res)
+		if err == nil {
+			valid = append(valid, res)
+			continue
+		}
+		if ls.validator.failOnInvalid {
+			return fmt.Errorf("file_provider(%s): invalid resource %s: %v", ls.filePath, res.GetName(), err)
+		}
+		ls.l.Warningf("file_provider(%s): skipping invalid resource %s: %v", ls.filePath, res.GetName(), err)
+		ls.validator.skipped.Add(1)
+	}
+	resources.Resource = valid
+	return nil
+}
+
+// InvalidResourcesMetrics returns the number of invalid resources skipped so
+// far, as cumulative EventMetrics, one for each file. It returns nil if
+// resource validation is not configured.
+func (p *Provider) InvalidResourcesMetrics() []*metrics.EventMetrics {
+	ts := time.Now()
+	var ems []*metrics.EventMetrics
+	for _, fp := range p.filePaths {
+		rv := p.listers[fp].validator
+		if rv == nil {
+			continue
+		}
+		ems = append(ems, metrics.NewEventMetrics(ts).
+			AddMetric("invalid_resources", metrics.NewInt(rv.skipped.Load())).
+			AddLabel("ptype", "rds").
+			AddLabel("provider", DefaultProviderID).
+			AddLabel("file_path", fp))
+	}
+	return ems
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testValidationResources = `
+resource {
+  name: "good"
+  ip: "10.0.0.1"
+  port: 80
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "good-ips"
+  ips: "10.0.0.5"
+  ips: "10.0.0.6"
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "bad-ips"
+  ips: "10.0.0.7"
+  ips: "10.0.0.300"
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "no-ip"
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "bad-ip"
+  ip: "10.0.0.300"
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "bad-port"
+  ip: "10.0.0.2"
+  port: 70000
+  labels { key: "zone" value: "a" }
+}
+resource {
+  name: "no-zone"
+  ip: "10.0.0.3"
+}
+resource {
+  name: "bad-url"
+  ip: "10.0.0.4"
+  url: "not-a-url"
+  labels { key: "zone" value: "a" }
+}
+`
+
+func TestNewResourceValidator(t *testing.T) {
+	rv, err := newResourceValidator(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rv)
+
+	for _, f := range []string{"ip", "port", "url", "label:zone"} {
+		_, err := newResourceValidator(&configpb.ProviderConfig_Validation{RequiredField: []string{f}})
+		assert.NoError(t, err, f)
+	}
+	for _, f := range []string{"name", "label:", "labels"} {
+		_, err := newResourceValidator(&configpb.ProviderConfig_Validation{RequiredField: []string{f}})
+		assert.Error(t, err, f)
+	}
+}
+
+func TestParseFileContentValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    configpb.ProviderConfig_Validation_Action
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "skip",
+			action:    configpb.ProviderConfig_Validation_SKIP,
+			wantNames: []string{"good", "good-ips"},
+		},
+		{
+			name:    "fail",
+			action:  configpb.ProviderConfig_Validation_FAIL,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rv, err := newResourceValidator(&configpb.ProviderConfig_Validation{
+				RequiredField: []string{"ip", "label:zone"},
+				OnInvalid:     test.action.Enum(),
+			})
+			assert.NoError(t, err)
+
+			ls := &lister{
+				filePath:  "test.textpb",
+				format:    configpb.ProviderConfig_TEXTPB,
+				validator: rv,
+			}
+			resources, err := ls.parseFileContent([]byte(testValidationResources))
+			if test.wantErr {
+				assert.ErrorContains(t, err, "bad-ips")
+				return
+			}
+			assert.NoError(t, err)
+
+			var names []string
+			for _, res := range resources.GetResource() {
+				names = append(names, res.GetName())
+			}
+			assert.Equal(t, test.wantNames, names)
+			assert.Equal(t, int64(6), rv.skipped.Load())
+
+			p := &Provider{filePaths: []string{ls.filePath}, listers: map[string]*lister{ls.filePath: ls}}
+			ems := p.InvalidResourcesMetrics()
+			assert.Len(t, ems, 1)
+			assert.Equal(t, "6", ems[0].Metric("invalid_resources").String())
+		})
+	}
+}
+
+func TestValidationDefaultLabels(t *testing.T) {
+	rv, _ := newResourceValidator(&configpb.ProviderConfig_Validation{
+		RequiredField: []string{"label:zone"},
+	})
+	ls := &lister{
+		format:        configpb.ProviderConfig_TEXTPB,
+		defaultLabels: map[string]string{"zone": "default"},
+		validator:     rv,
+	}
+	resources, err := ls.parseFileContent([]byte(`resource { name: "r1" }`))
+	assert.NoError(t, err)
+	assert.Len(t, resources.GetResource(), 1)
+	assert.Equal(t, proto.String("r1"), resources.GetResource()[0].Name)
+}
@@ -0,0 +1,262 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// serviceNameLabel is the EndpointSlice label that points to the owning
+// service.
+const serviceNameLabel = "kubernetes.io/service-name"
+
+type epsLister struct {
+	c         *configpb.EndpointSlices
+	namespace string
+	kClient   *client
+
+	mu    sync.RWMutex // Mutex for keys and cache
+	keys  []resourceKey
+	cache map[resourceKey]*epSliceInfo
+	l     *logger.Logger
+}
+
+func epSlicesURL(ns string) string {
+	if ns == "" {
+		return "apis/discovery.k8s.io/v1/endpointslices"
+	}
+	return fmt.Sprintf("apis/discovery.k8s.io/v1/namespaces/%s/endpointslices", ns)
+}
+
+type epSliceEndpoint struct {
+	Addresses  []string
+	Conditions struct {
+		// As per the API spec, nil ready condition should be interpreted as
+		// ready.
+		Ready *bool
+	}
+	NodeName  string
+	Zone      string
+	TargetRef struct {
+		Kind string
+		Name string
+	}
+	Hints struct {
+		ForZones []struct {
+			Name string
+		}
+	}
+}
+
+func (ep *epSliceEndpoint) ready() bool {
+	return ep.Conditions.Ready == nil || *ep.Conditions.Ready
+}
+
+// hintedFor returns true if endpoint has no topology hints, or it's hinted
+// for the given zone.
+func (ep *epSliceEndpoint) hintedFor(zone string) bool {
+	if len(ep.Hints.ForZones) == 0 {
+		return true
+	}
+	for _, z := range ep.Hints.ForZones {
+		if z.Name == zone {
+			return true
+		}
+	}
+	return false
+}
+
+type epSliceInfo struct {
+	Metadata  kMetadata
+	Endpoints []epSliceEndpoint
+	Ports     []struct {
+		Name string
+		Port int
+	}
+}
+
+// serviceName returns the name of the service that the EndpointSlice belongs
+// to. EndpointSlices not managed for a service use their own name.
+func (epsi *epSliceInfo) serviceName() string {
+	if svc := epsi.Metadata.Labels[serviceNameLabel]; svc != "" {
+		return svc
+	}
+	return epsi.Metadata.Name
+}
+
+// resources returns RDS resources corresponding to an EndpointSlice. Similar
+// to endpoints, there is a resource for each combination of endpoint address
+// and port.
+func (epsi *epSliceInfo) resources(c *configpb.EndpointSlices, portFilter *filter.RegexFilter, l *logger.Logger) (resources []*pb.Resource) {
+	for _, port := range epsi.Ports {
+		// For unnamed ports, use port number.
+		portName := port.Name
+		if portName == "" {
+			portName = strconv.FormatInt(int64(port.Port), 10)
+		}
+
+		if portFilter != nil && !portFilter.Match(portName, l) {
+			continue
+		}
+
+		for _, ep := range epsi.Endpoints {
+			if !c.GetIncludeNotReady() && !ep.ready() {
+				continue
+			}
+			if c.GetTopologyZone() != "" && !ep.hintedFor(c.GetTopologyZone()) {
+				continue
+			}
+
+			for _, addr := range ep.Addresses {
+				labels := make(map[string]string)
+				for k, v := range epsi.Metadata.Labels {
+					labels[k] = v
+				}
+				labels["ready"] = strconv.FormatBool(ep.ready())
+				labels["zone"] = ep.Zone
+				labels["node"] = ep.NodeName
+				if ep.TargetRef.Kind == "Pod" {
+					labels["pod"] = ep.TargetRef.Name
+				}
+
+				resources = append(resources, &pb.Resource{
+					// We name the resource as <service_name>_<IP>_<port>
+					Name:   proto.String(fmt.Sprintf("%s_%s_%s", epsi.serviceName(), addr, portName)),
+					Ip:     proto.String(addr),
+					Port:   proto.Int32(int32(port.Port)),
+					Labels: labels,
+				})
+			}
+		}
+	}
+	return
+}
+
+func (lister *epsLister) listResources(req *pb.ListResourcesRequest) ([]*pb.Resource, error) {
+	var resources []*pb.Resource
+
+	var svcName string
+	tok := strings.SplitN(req.GetResourcePath(), "/", 2)
+	if len(tok) == 2 {
+		svcName = tok[1]
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+
+	nameFilter, nsFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.RegexFilters["namespace"], allFilters.LabelsFilter
+
+	lister.mu.RLock()
+	defer lister.mu.RUnlock()
+
+	for _, key := range lister.keys {
+		epsi := lister.cache[key]
+		name := epsi.serviceName()
+
+		if svcName != "" && name != svcName {
+			continue
+		}
+		if nameFilter != nil && !nameFilter.Match(name, lister.l) {
+			continue
+		}
+		if nsFilter != nil && !nsFilter.Match(epsi.Metadata.Namespace, lister.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(epsi.Metadata.Labels, lister.l) {
+			continue
+		}
+
+		resources = append(resources, epsi.resources(lister.c, allFilters.RegexFilters["port"], lister.l)...)
+	}
+
+	lister.l.Debugf("kubernetes.endpointslices.listResources: returning %d resources", len(resources))
+	return resources, nil
+}
+
+func parseEndpointSlicesJSON(resp []byte) (keys []resourceKey, slices map[resourceKey]*epSliceInfo, err error) {
+	var itemList struct {
+		Items []*epSliceInfo
+	}
+
+	if err = json.Unmarshal(resp, &itemList); err != nil {
+		return
+	}
+
+	keys = make([]resourceKey, len(itemList.Items))
+	slices = make(map[resourceKey]*epSliceInfo)
+	for i, item := range itemList.Items {
+		keys[i] = resourceKey{item.Metadata.Namespace, item.Metadata.Name}
+		slices[keys[i]] = item
+	}
+
+	return
+}
+
+func (lister *epsLister) expand() {
+	resp, err := lister.kClient.getURL(epSlicesURL(lister.namespace))
+	if err != nil {
+		lister.l.Warningf("epsLister.expand(): error while getting endpointslices list from API: %v", err)
+	}
+
+	keys, slices, err := parseEndpointSlicesJSON(resp)
+	if err != nil {
+		lister.l.Warningf("epsLister.expand(): error while parsing endpointslices API response (%s): %v", string(resp), err)
+	}
+
+	lister.l.Debugf("epsLister.expand(): got %d endpointslices", len(keys))
+
+	lister.mu.Lock()
+	defer lister.mu.Unlock()
+	lister.keys = keys
+	lister.cache = slices
+}
+
+func newEndpointSlicesLister(c *configpb.EndpointSlices, namespace string, reEvalInterval time.Duration, kc *client, l *logger.Logger) (*epsLister, error) {
+	lister := &epsLister{
+		c:         c,
+		namespace: namespace,
+		kClient:   kc,
+		l:         l,
+	}
+
+	go func() {
+		lister.expand()
+		// Introduce a random delay between 0-reEvalInterval before
+		// starting the refresh loop. If there are multiple cloudprober
+		// instances, this will make sure that each instance calls the
+		// API at a different point of time.
+		randomDelaySec := rand.Intn(int(reEvalInterval.Seconds()))
+		time.Sleep(time.Duration(randomDelaySec) * time.Second)
+		for range time.Tick(reEvalInterval) {
+			lister.expand()
+		}
+	}()
+
+	return lister, nil
+}
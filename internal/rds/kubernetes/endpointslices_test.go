@@ -0,0 +1,112 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"os"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/kubernetes/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEpsLister(t *testing.T, c *configpb.EndpointSlices) *epsLister {
+	t.Helper()
+	data, err := os.ReadFile("./testdata/endpointslices.json")
+	require.NoError(t, err)
+	keys, slices, err := parseEndpointSlicesJSON(data)
+	require.NoError(t, err)
+	return &epsLister{c: c, keys: keys, cache: slices}
+}
+
+func TestParseEndpointSlices(t *testing.T) {
+	lister := testEpsLister(t, &configpb.EndpointSlices{})
+	assert.Equal(t, []resourceKey{{"default", "cloudprober-abc12"}, {"system", "kubernetes"}}, lister.keys)
+
+	epsi := lister.cache[resourceKey{"default", "cloudprober-abc12"}]
+	assert.Equal(t, "cloudprober", epsi.serviceName())
+	require.Len(t, epsi.Endpoints, 3)
+	assert.Equal(t, []bool{true, false, true}, []bool{epsi.Endpoints[0].ready(), epsi.Endpoints[1].ready(), epsi.Endpoints[2].ready()})
+	assert.Equal(t, "us-central1-b", epsi.Endpoints[1].Zone)
+}
+
+func TestEndpointSlicesListResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		c         *configpb.EndpointSlices
+		req       *pb.ListResourcesRequest
+		wantNames []string
+	}{
+		{
+			name:      "ready-only",
+			c:         &configpb.EndpointSlices{},
+			req:       &pb.ListResourcesRequest{},
+			wantNames: []string{"cloudprober_10.28.0.3_http", "cloudprober_10.28.2.6_http", "kubernetes_10.0.0.1_https"},
+		},
+		{
+			name:      "include-not-ready",
+			c:         &configpb.EndpointSlices{IncludeNotReady: proto.Bool(true)},
+			req:       &pb.ListResourcesRequest{ResourcePath: proto.String("endpointslices/cloudprober")},
+			wantNames: []string{"cloudprober_10.28.0.3_http", "cloudprober_10.28.2.3_http", "cloudprober_10.28.2.6_http"},
+		},
+		{
+			name:      "topology-zone",
+			c:         &configpb.EndpointSlices{IncludeNotReady: proto.Bool(true), TopologyZone: proto.String("us-central1-b")},
+			req:       &pb.ListResourcesRequest{ResourcePath: proto.String("endpointslices/cloudprober")},
+			wantNames: []string{"cloudprober_10.28.2.3_http", "cloudprober_10.28.2.6_http"},
+		},
+		{
+			name: "service-and-port-filter",
+			c:    &configpb.EndpointSlices{},
+			req: &pb.ListResourcesRequest{
+				Filter: []*pb.Filter{
+					{Key: proto.String("name"), Value: proto.String("kube.*")},
+					{Key: proto.String("port"), Value: proto.String("https")},
+				},
+			},
+			wantNames: []string{"kubernetes_10.0.0.1_https"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resources, err := testEpsLister(t, test.c).listResources(test.req)
+			require.NoError(t, err)
+			var names []string
+			for _, res := range resources {
+				names = append(names, res.GetName())
+			}
+			assert.Equal(t, test.wantNames, names)
+		})
+	}
+
+	resources, err := testEpsLister(t, &configpb.EndpointSlices{IncludeNotReady: proto.Bool(true)}).listResources(&pb.ListResourcesRequest{ResourcePath: proto.String("endpointslices/cloudprober")})
+	require.NoError(t, err)
+	res := resources[1]
+	assert.Equal(t, "10.28.2.3", res.GetIp())
+	assert.Equal(t, int32(9313), res.GetPort())
+	assert.Equal(t, map[string]string{
+		"app":                                    "cloudprober",
+		"endpointslice.kubernetes.io/managed-by": "endpointslice-controller.k8s.io",
+		"kubernetes.io/service-name":             "cloudprober",
+		"ready":                                  "false",
+		"zone":                                   "us-central1-b",
+		"node":                                   "node-b",
+		"pod":                                    "cloudprober-54778d95f5-qnrvg",
+	}, res.GetLabels())
+}
@@ -46,12 +46,13 @@ const DefaultProviderID = "k8s"
 
 // ResourceTypes declares resource types supported by the Kubernetes provider.
 var ResourceTypes = struct {
-	Pods, Endpoints, Services, Ingresses string
+	Pods, Endpoints, Services, Ingresses, EndpointSlices string
 }{
 	"pods",
 	"endpoints",
 	"services",
 	"ingresses",
+	"endpointslices",
 }
 
 /*
@@ -79,7 +80,8 @@ var SupportedFilters = struct {
 	RegexFilterKeys []string
 	LabelsFilter    bool
 }{
-	// Note: the port filter applies only to endpoints and services.
+	// Note: the port filter applies only to endpoints, endpointslices and
+	// services.
 	[]string{"name", "namespace", "port"},
 	true,
 }
@@ -169,5 +171,14 @@ func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
 		p.listers[ResourceTypes.Ingresses] = lr
 	}
 
+	// Enable EndpointSlices lister if configured.
+	if c.GetEndpointslices() != nil {
+		lr, err := newEndpointSlicesLister(c.GetEndpointslices(), c.GetNamespace(), reEvalInterval, client, l)
+		if err != nil {
+			return nil, err
+		}
+		p.listers[ResourceTypes.EndpointSlices] = lr
+	}
+
 	return p, nil
 }
@@ -0,0 +1,274 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package ldap implements an LDAP-based targets provider for cloudprober.
+*/
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+
+	"github.com/cloudprober/cloudprober/internal/rds/common/cache"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/ldap/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "ldap"
+
+/*
+SupportedFilters defines filters supported by the LDAP-based resources
+type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.env"
+		 value: "prod"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+// Provider provides an LDAP-based targets provider for RDS. It implements
+// the RDS server's Provider interface.
+type Provider struct {
+	c         *configpb.ProviderConfig
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	l         *logger.Logger
+
+	// search runs the LDAP search. It's a variable for testing.
+	search func() ([]*ldapv3.Entry, error)
+
+	cache *cache.Cache
+}
+
+// attributes returns the entry attributes that we need from the server.
+func (p *Provider) attributes() []string {
+	attrs := []string{p.c.GetNameAttribute()}
+	for _, attr := range []string{p.c.GetIpAttribute(), p.c.GetPortAttribute()} {
+		if attr != "" {
+			attrs = append(attrs, attr)
+		}
+	}
+	for _, attr := range p.c.GetLabelAttribute() {
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+func (p *Provider) connect() (*ldapv3.Conn, error) {
+	opts := []ldapv3.DialOpt{ldapv3.DialWithDialer(&net.Dialer{Timeout: p.timeout})}
+	if p.tlsConfig != nil {
+		opts = append(opts, ldapv3.DialWithTLSConfig(p.tlsConfig))
+	}
+	conn, err := ldapv3.DialURL(p.c.GetUrl(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %v", p.c.GetUrl(), err)
+	}
+	conn.SetTimeout(p.timeout)
+
+	if p.c.GetStartTls() {
+		if err := conn.StartTLS(p.tlsConfig); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("StartTLS failed: %v", err)
+		}
+	}
+
+	if p.c.GetBindDn() != "" {
+		if err := conn.Bind(p.c.GetBindDn(), p.c.GetBindPassword()); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("bind as %s failed: %v", p.c.GetBindDn(), err)
+		}
+	}
+	return conn, nil
+}
+
+// searchLDAP connects to the LDAP server and runs the configured search. We
+// use a new connection for each search as searches are infrequent.
+func (p *Provider) searchLDAP() ([]*ldapv3.Entry, error) {
+	conn, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	req := ldapv3.NewSearchRequest(p.c.GetBaseDn(), int(p.c.GetScope()), ldapv3.NeverDerefAliases, 0, 0, false, p.c.GetFilter(), p.attributes(), nil)
+
+	var result *ldapv3.SearchResult
+	if p.c.GetPageSize() > 0 {
+		result, err = conn.SearchWithPaging(req, p.c.GetPageSize())
+	} else {
+		result, err = conn.Search(req)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %v", err)
+	}
+	return result.Entries, nil
+}
+
+// resourcesFromEntries builds resources from the LDAP entries. Entries
+// without the name attribute, or with an invalid port, are skipped.
+func (p *Provider) resourcesFromEntries(entries []*ldapv3.Entry) []*pb.Resource {
+	var resources []*pb.Resource
+	for _, entry := range entries {
+		name := entry.GetAttributeValue(p.c.GetNameAttribute())
+		if name == "" {
+			p.l.Warningf("ldap: skipping entry %s, no %s attribute", entry.DN, p.c.GetNameAttribute())
+			continue
+		}
+		res := &pb.Resource{Name: proto.String(name)}
+
+		if attr := p.c.GetIpAttribute(); attr != "" {
+			if ip := entry.GetAttributeValue(attr); ip != "" {
+				res.Ip = proto.String(ip)
+			}
+		}
+
+		if attr := p.c.GetPortAttribute(); attr != "" {
+			if portStr := entry.GetAttributeValue(attr); portStr != "" {
+				port, err := strconv.Atoi(portStr)
+				if err != nil || port <= 0 || port > 65535 {
+					p.l.Warningf("ldap: skipping entry %s, invalid port: %s", entry.DN, portStr)
+					continue
+				}
+				res.Port = proto.Int32(int32(port))
+			}
+		}
+
+		for key, attr := range p.c.GetLabelAttribute() {
+			if val := entry.GetAttributeValue(attr); val != "" {
+				if res.Labels == nil {
+					res.Labels = make(map[string]string)
+				}
+				res.Labels[key] = val
+			}
+		}
+
+		resources = append(resources, res)
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+	return resources
+}
+
+// refresh refreshes the resources. On error, last known good resources are
+// retained.
+func (p *Provider) refresh() error {
+	entries, err := p.search()
+	if err != nil {
+		return fmt.Errorf("ldap: error refreshing resources, keeping last known good: %v", err)
+	}
+	resources := p.resourcesFromEntries(entries)
+
+	p.cache.Update(resources, time.Now().Unix())
+	return nil
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return p.cache.ListResources(req)
+}
+
+func newProvider(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	u, err := url.Parse(c.GetUrl())
+	if err != nil {
+		return nil, fmt.Errorf("ldap: invalid url (%s): %v", c.GetUrl(), err)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return nil, fmt.Errorf("ldap: unsupported url scheme: %s, should be ldap or ldaps", u.Scheme)
+	}
+	if c.GetStartTls() && u.Scheme == "ldaps" {
+		return nil, errors.New("ldap: start_tls cannot be used with ldaps:// url")
+	}
+	if c.GetBaseDn() == "" {
+		return nil, errors.New("ldap: base_dn must be specified")
+	}
+	if _, err := ldapv3.CompileFilter(c.GetFilter()); err != nil {
+		return nil, fmt.Errorf("ldap: invalid filter (%s): %v", c.GetFilter(), err)
+	}
+	if c.GetNameAttribute() == "" {
+		return nil, errors.New("ldap: name_attribute cannot be empty")
+	}
+	if c.GetBindPassword() != "" && c.GetBindDn() == "" {
+		return nil, errors.New("ldap: bind_password specified without bind_dn")
+	}
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("ldap: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	p := &Provider{
+		c:       c,
+		timeout: time.Duration(c.GetTimeoutMsec()) * time.Millisecond,
+		l:       l,
+		cache:   cache.New("ldap", SupportedFilters.RegexFilterKeys, l),
+	}
+	p.search = p.searchLDAP
+
+	if c.GetTlsConfig() != nil || c.GetStartTls() {
+		p.tlsConfig = &tls.Config{}
+		if c.GetTlsConfig() != nil {
+			if err := tlsconfig.UpdateTLSConfig(p.tlsConfig, c.GetTlsConfig()); err != nil {
+				return nil, fmt.Errorf("ldap: error parsing tls_config: %v", err)
+			}
+		}
+		if p.tlsConfig.ServerName == "" {
+			p.tlsConfig.ServerName = u.Hostname()
+		}
+	}
+
+	return p, nil
+}
+
+// New creates an LDAP provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	p, err := newProvider(c, l)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initial refresh is done synchronously, but we don't fail if the LDAP
+	// server is not reachable yet; resources will be populated by the refresh
+	// loop.
+	if err := p.refresh(); err != nil {
+		l.Error(err.Error())
+	}
+
+	go cache.RefreshLoop(time.Duration(c.GetReEvalSec())*time.Second, nil, 0, p.refresh, l)
+
+	return p, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"errors"
+	"testing"
+
+	ldapv3 "github.com/go-ldap/ldap/v3"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/ldap/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var testEntries = []*ldapv3.Entry{
+	ldapv3.NewEntry("cn=web-2,ou=hosts,dc=example,dc=com", map[string][]string{
+		"cn":            {"web-2"},
+		"ipHostNumber":  {"10.0.0.2"},
+		"ipServicePort": {"8080"},
+		"environment":   {"prod"},
+	}),
+	ldapv3.NewEntry("cn=web-1,ou=hosts,dc=example,dc=com", map[string][]string{
+		"cn":           {"web-1"},
+		"ipHostNumber": {"10.0.0.1", "10.1.0.1"},
+		"environment":  {"dev"},
+		"l":            {"us-east"},
+	}),
+	// No name attribute.
+	ldapv3.NewEntry("ou=hosts,dc=example,dc=com", map[string][]string{
+		"ipHostNumber": {"10.0.0.3"},
+	}),
+	// Invalid port.
+	ldapv3.NewEntry("cn=web-4,ou=hosts,dc=example,dc=com", map[string][]string{
+		"cn":            {"web-4"},
+		"ipServicePort": {"http"},
+	}),
+}
+
+func testConfig() *configpb.ProviderConfig {
+	return &configpb.ProviderConfig{
+		Url:           proto.String("ldap://ldap.example.com"),
+		BaseDn:        proto.String("ou=hosts,dc=example,dc=com"),
+		IpAttribute:   proto.String("ipHostNumber"),
+		PortAttribute: proto.String("ipServicePort"),
+		LabelAttribute: map[string]string{
+			"env":      "environment",
+			"location": "l",
+		},
+	}
+}
+
+func TestListResources(t *testing.T) {
+	p, err := newProvider(testConfig(), nil)
+	require.NoError(t, err)
+
+	var searchErr error
+	p.search = func() ([]*ldapv3.Entry, error) { return testEntries, searchErr }
+	require.NoError(t, p.refresh())
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	want := []*pb.Resource{
+		{
+			Name:   proto.String("web-1"),
+			Ip:     proto.String("10.0.0.1"),
+			Labels: map[string]string{"env": "dev", "location": "us-east"},
+		},
+		{
+			Name:   proto.String("web-2"),
+			Ip:     proto.String("10.0.0.2"),
+			Port:   proto.Int32(8080),
+			Labels: map[string]string{"env": "prod"},
+		},
+	}
+	require.Len(t, resp.GetResources(), len(want))
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], resp.GetResources()[i]), "got: %v, want: %v", resp.GetResources()[i], want[i])
+	}
+	lastModified := resp.GetLastModified()
+
+	// Filters.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{
+		Filter: []*pb.Filter{{Key: proto.String("labels.env"), Value: proto.String("prod")}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResources(), 1)
+	assert.Equal(t, "web-2", resp.GetResources()[0].GetName())
+
+	// Search failure: last known good resources are retained.
+	searchErr = errors.New("connection refused")
+	assert.Error(t, p.refresh())
+	resp, err = p.ListResources(&pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), len(want))
+	assert.Equal(t, lastModified, resp.GetLastModified())
+
+	// Not modified.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(lastModified)})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+}
+
+func TestAttributes(t *testing.T) {
+	p, err := newProvider(testConfig(), nil)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cn", "ipHostNumber", "ipServicePort", "environment", "l"}, p.attributes())
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(c *configpb.ProviderConfig)
+		wantErr    bool
+		wantTLSCfg bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "ldaps",
+			modify: func(c *configpb.ProviderConfig) {
+				c.Url = proto.String("ldaps://ldap.example.com:636")
+			},
+		},
+		{
+			name: "start_tls",
+			modify: func(c *configpb.ProviderConfig) {
+				c.StartTls = proto.Bool(true)
+			},
+			wantTLSCfg: true,
+		},
+		{
+			name: "start_tls_with_ldaps",
+			modify: func(c *configpb.ProviderConfig) {
+				c.Url = proto.String("ldaps://ldap.example.com:636")
+				c.StartTls = proto.Bool(true)
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad_scheme",
+			modify: func(c *configpb.ProviderConfig) {
+				c.Url = proto.String("http://ldap.example.com")
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad_filter",
+			modify: func(c *configpb.ProviderConfig) {
+				c.Filter = proto.String("(objectClass=ipHost")
+			},
+			wantErr: true,
+		},
+		{
+			name: "password_without_dn",
+			modify: func(c *configpb.ProviderConfig) {
+				c.BindPassword = proto.String("secret")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig()
+			if tt.modify != nil {
+				tt.modify(c)
+			}
+			p, err := newProvider(c, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantTLSCfg {
+				require.NotNil(t, p.tlsConfig)
+				assert.Equal(t, "ldap.example.com", p.tlsConfig.ServerName)
+			}
+		})
+	}
+}
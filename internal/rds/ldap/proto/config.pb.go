@@ -0,0 +1,441 @@
+// Configuration proto for LDAP provider.
+//
+// LDAP provider discovers resources by searching an LDAP directory. Each
+// entry returned by the search becomes a resource. Entry attributes are
+// mapped to the resource name, IP and port, and to the resource labels.
+// Resources are refreshed periodically; if a refresh fails, last known good
+// resources are retained.
+//
+// Example provider config:
+// {
+//   url: "ldaps://ldap.example.com"
+//   bind_dn: "cn=cloudprober,ou=services,dc=example,dc=com"
+//   bind_password: "{{env "LDAP_PASSWORD"}}"
+//   base_dn: "ou=hosts,dc=example,dc=com"
+//   filter: "(&(objectClass=ipHost)(environment=prod))"
+//   name_attribute: "cn"
+//   ip_attribute: "ipHostNumber"
+//   label_attribute {
+//     key: "env"
+//     value: "environment"
+//   }
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "ldap://"
+//       filter {
+//         key: "labels.env"
+//         value: "prod"
+//       }
+//     }
+//   }
+// }
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/rds/ldap/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProviderConfig_Scope int32
+
+const (
+	ProviderConfig_BASE      ProviderConfig_Scope = 0
+	ProviderConfig_ONE_LEVEL ProviderConfig_Scope = 1
+	ProviderConfig_SUBTREE   ProviderConfig_Scope = 2
+)
+
+// Enum value maps for ProviderConfig_Scope.
+var (
+	ProviderConfig_Scope_name = map[int32]string{
+		0: "BASE",
+		1: "ONE_LEVEL",
+		2: "SUBTREE",
+	}
+	ProviderConfig_Scope_value = map[string]int32{
+		"BASE":      0,
+		"ONE_LEVEL": 1,
+		"SUBTREE":   2,
+	}
+)
+
+func (x ProviderConfig_Scope) Enum() *ProviderConfig_Scope {
+	p := new(ProviderConfig_Scope)
+	*p = x
+	return p
+}
+
+func (x ProviderConfig_Scope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProviderConfig_Scope) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ProviderConfig_Scope) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_enumTypes[0]
+}
+
+func (x ProviderConfig_Scope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProviderConfig_Scope) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProviderConfig_Scope(num)
+	return nil
+}
+
+// Deprecated: Use ProviderConfig_Scope.Descriptor instead.
+func (ProviderConfig_Scope) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ProviderConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// LDAP server URL, e.g. "ldap://ldap.example.com:389" or
+	// "ldaps://ldap.example.com:636".
+	Url *string `protobuf:"bytes,1,req,name=url" json:"url,omitempty"`
+	// Bind credentials. If bind_dn is not specified, searches are performed
+	// anonymously.
+	BindDn       *string `protobuf:"bytes,2,opt,name=bind_dn,json=bindDn" json:"bind_dn,omitempty"`
+	BindPassword *string `protobuf:"bytes,3,opt,name=bind_password,json=bindPassword" json:"bind_password,omitempty"`
+	// TLS config, used for ldaps:// URLs and for StartTLS.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,4,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// If enabled, upgrade the ldap:// connection to TLS using StartTLS.
+	StartTls *bool `protobuf:"varint,5,opt,name=start_tls,json=startTls" json:"start_tls,omitempty"`
+	// Search base DN and filter.
+	BaseDn *string               `protobuf:"bytes,6,req,name=base_dn,json=baseDn" json:"base_dn,omitempty"`
+	Filter *string               `protobuf:"bytes,7,opt,name=filter,def=(objectClass=*)" json:"filter,omitempty"`
+	Scope  *ProviderConfig_Scope `protobuf:"varint,8,opt,name=scope,enum=cloudprober.rds.ldap.ProviderConfig_Scope,def=2" json:"scope,omitempty"`
+	// Attributes to use for the resource name, IP and port. If an entry
+	// doesn't have the name attribute, it's skipped. IP and port are optional.
+	// If an attribute has multiple values, first value is used.
+	NameAttribute *string `protobuf:"bytes,9,opt,name=name_attribute,json=nameAttribute,def=cn" json:"name_attribute,omitempty"`
+	IpAttribute   *string `protobuf:"bytes,10,opt,name=ip_attribute,json=ipAttribute" json:"ip_attribute,omitempty"`
+	PortAttribute *string `protobuf:"bytes,11,opt,name=port_attribute,json=portAttribute" json:"port_attribute,omitempty"`
+	// Attributes to export as resource labels: label key to attribute name,
+	// e.g. {key: "env", value: "environment"}.
+	LabelAttribute map[string]string `protobuf:"bytes,12,rep,name=label_attribute,json=labelAttribute" json:"label_attribute,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Page size for the paged search (RFC 2696). Set it to 0 to disable paged
+	// search, e.g. if server doesn't support it.
+	PageSize *uint32 `protobuf:"varint,13,opt,name=page_size,json=pageSize,def=500" json:"page_size,omitempty"`
+	// How often to refresh resources.
+	ReEvalSec *int32 `protobuf:"varint,14,opt,name=re_eval_sec,json=reEvalSec,def=300" json:"re_eval_sec,omitempty"`
+	// Timeout for connecting to, and for each request to, the LDAP server.
+	TimeoutMsec *int32 `protobuf:"varint,15,opt,name=timeout_msec,json=timeoutMsec,def=10000" json:"timeout_msec,omitempty"`
+}
+
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_Filter        = string("(objectClass=*)")
+	Default_ProviderConfig_Scope         = ProviderConfig_SUBTREE
+	Default_ProviderConfig_NameAttribute = string("cn")
+	Default_ProviderConfig_PageSize      = uint32(500)
+	Default_ProviderConfig_ReEvalSec     = int32(300)
+	Default_ProviderConfig_TimeoutMsec   = int32(10000)
+)
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderConfig) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetBindDn() string {
+	if x != nil && x.BindDn != nil {
+		return *x.BindDn
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetBindPassword() string {
+	if x != nil && x.BindPassword != nil {
+		return *x.BindPassword
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetStartTls() bool {
+	if x != nil && x.StartTls != nil {
+		return *x.StartTls
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetBaseDn() string {
+	if x != nil && x.BaseDn != nil {
+		return *x.BaseDn
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetFilter() string {
+	if x != nil && x.Filter != nil {
+		return *x.Filter
+	}
+	return Default_ProviderConfig_Filter
+}
+
+func (x *ProviderConfig) GetScope() ProviderConfig_Scope {
+	if x != nil && x.Scope != nil {
+		return *x.Scope
+	}
+	return Default_ProviderConfig_Scope
+}
+
+func (x *ProviderConfig) GetNameAttribute() string {
+	if x != nil && x.NameAttribute != nil {
+		return *x.NameAttribute
+	}
+	return Default_ProviderConfig_NameAttribute
+}
+
+func (x *ProviderConfig) GetIpAttribute() string {
+	if x != nil && x.IpAttribute != nil {
+		return *x.IpAttribute
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetPortAttribute() string {
+	if x != nil && x.PortAttribute != nil {
+		return *x.PortAttribute
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetLabelAttribute() map[string]string {
+	if x != nil {
+		return x.LabelAttribute
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return Default_ProviderConfig_PageSize
+}
+
+func (x *ProviderConfig) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_ProviderConfig_ReEvalSec
+}
+
+func (x *ProviderConfig) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_TimeoutMsec
+}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x14, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x1a,
+	0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c, 0x73,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x06, 0x0a, 0x0e, 0x50, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x17,
+	0x0a, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x62, 0x69, 0x6e, 0x64, 0x44, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x62, 0x69, 0x6e, 0x64, 0x5f,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x62, 0x69, 0x6e, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x3f, 0x0a, 0x0a,
+	0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74,
+	0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x61,
+	0x73, 0x65, 0x5f, 0x64, 0x6e, 0x18, 0x06, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x62, 0x61, 0x73,
+	0x65, 0x44, 0x6e, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x3a, 0x0f, 0x28, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x3d, 0x2a, 0x29, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x6c, 0x64,
+	0x61, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x3a, 0x07, 0x53, 0x55, 0x42, 0x54, 0x52, 0x45, 0x45,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x29, 0x0a, 0x0e, 0x6e, 0x61, 0x6d, 0x65, 0x5f,
+	0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x3a,
+	0x02, 0x63, 0x6e, 0x52, 0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x70, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x70, 0x41, 0x74, 0x74, 0x72,
+	0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70,
+	0x6f, 0x72, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x61, 0x0a, 0x0f,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x18,
+	0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x6c, 0x64, 0x61, 0x70, 0x2e, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12,
+	0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x0d, 0x3a, 0x03, 0x35, 0x30, 0x30, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x23, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x09, 0x72, 0x65, 0x45,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x28, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x05, 0x31, 0x30,
+	0x30, 0x30, 0x30, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63,
+	0x1a, 0x41, 0x0a, 0x13, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x2d, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04,
+	0x42, 0x41, 0x53, 0x45, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x4e, 0x45, 0x5f, 0x4c, 0x45,
+	0x56, 0x45, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x42, 0x54, 0x52, 0x45, 0x45,
+	0x10, 0x02, 0x42, 0x3c, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x6c, 0x64, 0x61, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_goTypes = []any{
+	(ProviderConfig_Scope)(0), // 0: cloudprober.rds.ldap.ProviderConfig.Scope
+	(*ProviderConfig)(nil),    // 1: cloudprober.rds.ldap.ProviderConfig
+	nil,                       // 2: cloudprober.rds.ldap.ProviderConfig.LabelAttributeEntry
+	(*proto.TLSConfig)(nil),   // 3: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_depIdxs = []int32{
+	3, // 0: cloudprober.rds.ldap.ProviderConfig.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	0, // 1: cloudprober.rds.ldap.ProviderConfig.scope:type_name -> cloudprober.rds.ldap.ProviderConfig.Scope
+	2, // 2: cloudprober.rds.ldap.ProviderConfig.label_attribute:type_name -> cloudprober.rds.ldap.ProviderConfig.LabelAttributeEntry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_enumTypes,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_ldap_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,353 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package nomad implements a HashiCorp Nomad based targets provider for
+cloudprober. It discovers running allocations using the Nomad HTTP API.
+*/
+package nomad
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/rds/common/cache"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "nomad"
+
+/*
+SupportedFilters defines filters supported by the Nomad-based resources
+type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web\\..*"
+	 }
+	 filter {
+		 key: "labels.job"
+		 value: "web"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+type allocPort struct {
+	Label  string
+	Value  int
+	HostIP string
+}
+
+type allocNetwork struct {
+	IP            string
+	ReservedPorts []allocPort
+	DynamicPorts  []allocPort
+}
+
+// allocation is an allocation as returned by the Nomad allocations list API,
+// with resources=true.
+type allocation struct {
+	ID            string
+	Name          string
+	Namespace     string
+	NodeName      string
+	JobID         string
+	TaskGroup     string
+	ClientStatus  string
+	DesiredStatus string
+	TaskStates    map[string]json.RawMessage
+
+	AllocatedResources *struct {
+		Shared struct {
+			Ports    []allocPort
+			Networks []allocNetwork
+		}
+		Tasks map[string]struct {
+			Networks []allocNetwork
+		}
+	}
+}
+
+// ports returns the allocation's ports. Group level ports are preferred over
+// the (deprecated) task level network ports.
+func (a *allocation) ports() []allocPort {
+	if a.AllocatedResources == nil {
+		return nil
+	}
+	if ports := a.AllocatedResources.Shared.Ports; len(ports) != 0 {
+		return ports
+	}
+
+	var ports []allocPort
+	addNetworks := func(networks []allocNetwork) {
+		for _, n := range networks {
+			for _, pt := range append(append([]allocPort{}, n.ReservedPorts...), n.DynamicPorts...) {
+				if pt.HostIP == "" {
+					pt.HostIP = n.IP
+				}
+				ports = append(ports, pt)
+			}
+		}
+	}
+	addNetworks(a.AllocatedResources.Shared.Networks)
+
+	var tasks []string
+	for task := range a.AllocatedResources.Tasks {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+	for _, task := range tasks {
+		addNetworks(a.AllocatedResources.Tasks[task].Networks)
+	}
+	return ports
+}
+
+func (a *allocation) tasks() []string {
+	var tasks []string
+	for task := range a.TaskStates {
+		tasks = append(tasks, task)
+	}
+	sort.Strings(tasks)
+	return tasks
+}
+
+// Provider provides a Nomad-based targets provider for RDS. It implements
+// the RDS server's Provider interface.
+type Provider struct {
+	c       *configpb.ProviderConfig
+	client  *http.Client
+	baseURL string
+	l       *logger.Logger
+
+	// Resources cache. Last modified time is the Nomad index at which
+	// resources last changed.
+	cache *cache.Cache
+}
+
+// resource converts an allocation to a resource. It returns nil if
+// allocation doesn't have a usable address.
+func (p *Provider) resource(a *allocation) *pb.Resource {
+	var port *allocPort
+	for _, pt := range a.ports() {
+		if p.c.GetPortLabel() != "" && pt.Label != p.c.GetPortLabel() {
+			continue
+		}
+		if port == nil || pt.Value < port.Value {
+			pt := pt
+			port = &pt
+		}
+	}
+	if port == nil || port.HostIP == "" {
+		p.l.Debugf("nomad: skipping allocation %s (%s), no usable port", a.Name, a.ID)
+		return nil
+	}
+
+	labels := map[string]string{
+		"job":        a.JobID,
+		"task_group": a.TaskGroup,
+		"namespace":  a.Namespace,
+		"node":       a.NodeName,
+		"port_label": port.Label,
+	}
+	if tasks := a.tasks(); len(tasks) != 0 {
+		labels["tasks"] = strings.Join(tasks, ",")
+	}
+
+	return &pb.Resource{
+		Name:   proto.String(a.Name),
+		Id:     proto.String(a.ID),
+		Ip:     proto.String(port.HostIP),
+		Port:   proto.Int32(int32(port.Value)),
+		Labels: labels,
+	}
+}
+
+// listAllocations lists the allocations. If index is non-zero, it makes a
+// blocking query that returns when allocations change after the given index,
+// or wait time expires. It returns the allocations and the Nomad index.
+func (p *Provider) listAllocations(ctx context.Context, index int64) ([]*allocation, int64, error) {
+	q := url.Values{}
+	q.Set("resources", "true")
+	if p.c.GetNamespace() != "" {
+		q.Set("namespace", p.c.GetNamespace())
+	}
+
+	timeout := time.Duration(p.c.GetTimeoutMsec()) * time.Millisecond
+	if index > 0 {
+		wait := time.Duration(p.c.GetWaitSec()) * time.Second
+		q.Set("index", strconv.FormatInt(index, 10))
+		q.Set("wait", wait.String())
+		// Nomad adds a jitter of up to wait/16 to the wait time.
+		timeout += wait + wait/16
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/v1/allocations?"+q.Encode(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	if p.c.GetToken() != "" {
+		req.Header.Set("X-Nomad-Token", p.c.GetToken())
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("allocations API returned status %d: %s", resp.StatusCode, string(b))
+	}
+
+	newIndex, err := strconv.ParseInt(resp.Header.Get("X-Nomad-Index"), 10, 64)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid X-Nomad-Index header (%s): %v", resp.Header.Get("X-Nomad-Index"), err)
+	}
+
+	var allocs []*allocation
+	if err := json.Unmarshal(b, &allocs); err != nil {
+		return nil, 0, fmt.Errorf("error parsing allocations list: %v", err)
+	}
+	return allocs, newIndex, nil
+}
+
+// refresh fetches the allocations, blocking until they change after the
+// given index (if non-zero), and updates the resources. It returns the new
+// Nomad index.
+func (p *Provider) refresh(ctx context.Context, index int64) (int64, error) {
+	allocs, newIndex, err := p.listAllocations(ctx, index)
+	if err != nil {
+		return 0, fmt.Errorf("nomad: error listing allocations: %v", err)
+	}
+
+	var resources []*pb.Resource
+	for _, a := range allocs {
+		// Completed, failed and lost allocations, and the ones that are
+		// being stopped, drop out.
+		if a.ClientStatus != "running" || (a.DesiredStatus != "" && a.DesiredStatus != "run") {
+			continue
+		}
+		if res := p.resource(a); res != nil {
+			resources = append(resources, res)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].GetName() != resources[j].GetName() {
+			return resources[i].GetName() < resources[j].GetName()
+		}
+		return resources[i].GetId() < resources[j].GetId()
+	})
+
+	p.cache.Update(resources, newIndex)
+	return newIndex, nil
+}
+
+// watch keeps the resources up-to-date using blocking queries.
+func (p *Provider) watch(ctx context.Context, index int64) {
+	retryInterval := time.Duration(p.c.GetRetryIntervalSec()) * time.Second
+
+	for ctx.Err() == nil {
+		newIndex, err := p.refresh(ctx, index)
+		if err != nil {
+			p.l.Warningf("%v, retrying in %v", err, retryInterval)
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryInterval):
+			}
+			continue
+		}
+
+		// As per Nomad's documentation, index should be reset if it goes
+		// backwards, and it should be at least 1 so that queries block.
+		if newIndex < index || newIndex < 1 {
+			newIndex = 1
+		}
+		index = newIndex
+	}
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return p.cache.ListResources(req)
+}
+
+// New creates a Nomad provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetWaitSec() <= 0 {
+		return nil, fmt.Errorf("nomad: invalid wait_sec: %d", c.GetWaitSec())
+	}
+
+	u, err := url.Parse(c.GetAddress())
+	if err != nil {
+		return nil, fmt.Errorf("nomad: invalid address (%s): %v", c.GetAddress(), err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("nomad: unsupported address scheme: %s", u.Scheme)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("nomad: error parsing tls_config: %v", err)
+		}
+	}
+
+	p := &Provider{
+		c:       c,
+		client:  &http.Client{Transport: transport},
+		baseURL: strings.TrimSuffix(u.String(), "/"),
+		l:       l,
+		cache:   cache.New("nomad", SupportedFilters.RegexFilterKeys, l),
+	}
+
+	// Initial refresh is done synchronously, but we don't fail if Nomad API
+	// is not reachable yet; resources will be populated by the watch loop.
+	index, err := p.refresh(context.Background(), 0)
+	if err != nil {
+		l.Error(err.Error())
+	}
+	go p.watch(context.Background(), index)
+
+	return p, nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/internal/rds/nomad/proto"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const testAllocs = `[
+  {
+    "ID": "a1b2c3d4-0000-0000-0000-000000000001",
+    "Name": "web.web[0]",
+    "Namespace": "default",
+    "NodeName": "node-1",
+    "JobID": "web",
+    "TaskGroup": "web",
+    "ClientStatus": "running",
+    "DesiredStatus": "run",
+    "TaskStates": {"nginx": {}, "sidecar": {}},
+    "AllocatedResources": {"Shared": {"Ports": [
+      {"Label": "metrics", "Value": 29090, "HostIP": "10.0.0.1"},
+      {"Label": "http", "Value": 28080, "HostIP": "10.0.0.1"}
+    ]}}
+  },
+  {
+    "ID": "a1b2c3d4-0000-0000-0000-000000000002",
+    "Name": "api.api[0]",
+    "Namespace": "default",
+    "NodeName": "node-2",
+    "JobID": "api",
+    "TaskGroup": "api",
+    "ClientStatus": "running",
+    "DesiredStatus": "run",
+    "TaskStates": {"api": {}},
+    "AllocatedResources": {"Tasks": {"api": {"Networks": [
+      {"IP": "10.0.0.2", "DynamicPorts": [{"Label": "http", "Value": 21000}]}
+    ]}}}
+  },
+  {
+    "ID": "a1b2c3d4-0000-0000-0000-000000000003",
+    "Name": "batch.batch[0]",
+    "JobID": "batch",
+    "ClientStatus": "complete",
+    "DesiredStatus": "run",
+    "AllocatedResources": {"Shared": {"Ports": [{"Label": "http", "Value": 22000, "HostIP": "10.0.0.3"}]}}
+  },
+  {
+    "ID": "a1b2c3d4-0000-0000-0000-000000000004",
+    "Name": "web.web[1]",
+    "JobID": "web",
+    "ClientStatus": "running",
+    "DesiredStatus": "stop",
+    "AllocatedResources": {"Shared": {"Ports": [{"Label": "http", "Value": 23000, "HostIP": "10.0.0.4"}]}}
+  },
+  {
+    "ID": "a1b2c3d4-0000-0000-0000-000000000005",
+    "Name": "noport.noport[0]",
+    "JobID": "noport",
+    "ClientStatus": "running",
+    "DesiredStatus": "run"
+  }
+]`
+
+// fakeNomad is a fake Nomad API server that supports blocking queries.
+type fakeNomad struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	allocs  string
+	index   int64
+	changed chan struct{}
+	done    chan struct{}
+	queries []url.Values
+	tokens  []string
+}
+
+func newFakeNomad(t *testing.T) *fakeNomad {
+	t.Helper()
+
+	fn := &fakeNomad{
+		allocs:  testAllocs,
+		index:   10,
+		changed: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	fn.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/allocations" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+
+		fn.mu.Lock()
+		fn.queries = append(fn.queries, q)
+		fn.tokens = append(fn.tokens, r.Header.Get("X-Nomad-Token"))
+		index, changed := fn.index, fn.changed
+		fn.mu.Unlock()
+
+		if reqIndex, _ := strconv.ParseInt(q.Get("index"), 10, 64); reqIndex >= index {
+			wait, _ := time.ParseDuration(q.Get("wait"))
+			select {
+			case <-changed:
+			case <-time.After(wait):
+			case <-fn.done:
+				return
+			}
+		}
+
+		fn.mu.Lock()
+		defer fn.mu.Unlock()
+		w.Header().Set("X-Nomad-Index", strconv.FormatInt(fn.index, 10))
+		fmt.Fprint(w, fn.allocs)
+	}))
+	t.Cleanup(fn.Close)
+	// Unblock the pending blocking queries, so that server can be closed.
+	t.Cleanup(func() { close(fn.done) })
+
+	return fn
+}
+
+func (fn *fakeNomad) setAllocs(s string) {
+	fn.mu.Lock()
+	defer fn.mu.Unlock()
+	fn.allocs = s
+	fn.index++
+	close(fn.changed)
+	fn.changed = make(chan struct{})
+}
+
+func (fn *fakeNomad) firstQuery() (url.Values, string) {
+	fn.mu.Lock()
+	defer fn.mu.Unlock()
+	return fn.queries[0], fn.tokens[0]
+}
+
+func resourcesString(resources []*pb.Resource) []string {
+	var out []string
+	for _, res := range resources {
+		out = append(out, fmt.Sprintf("%s %s:%d %v", res.GetName(), res.GetIp(), res.GetPort(), res.GetLabels()))
+	}
+	return out
+}
+
+func TestListResources(t *testing.T) {
+	fn := newFakeNomad(t)
+
+	tests := []struct {
+		name   string
+		c      *configpb.ProviderConfig
+		filter map[string]string
+		want   []string
+	}{
+		{
+			name: "default",
+			c:    &configpb.ProviderConfig{},
+			want: []string{
+				"api.api[0] 10.0.0.2:21000 map[job:api namespace:default node:node-2 port_label:http task_group:api tasks:api]",
+				"web.web[0] 10.0.0.1:28080 map[job:web namespace:default node:node-1 port_label:http task_group:web tasks:nginx,sidecar]",
+			},
+		},
+		{
+			name: "port-label",
+			c:    &configpb.ProviderConfig{PortLabel: proto.String("metrics")},
+			want: []string{
+				"web.web[0] 10.0.0.1:29090 map[job:web namespace:default node:node-1 port_label:metrics task_group:web tasks:nginx,sidecar]",
+			},
+		},
+		{
+			name:   "label-filter",
+			c:      &configpb.ProviderConfig{},
+			filter: map[string]string{"labels.job": "api"},
+			want: []string{
+				"api.api[0] 10.0.0.2:21000 map[job:api namespace:default node:node-2 port_label:http task_group:api tasks:api]",
+			},
+		},
+		{
+			name:   "name-filter",
+			c:      &configpb.ProviderConfig{},
+			filter: map[string]string{"name": "web\\..*"},
+			want: []string{
+				"web.web[0] 10.0.0.1:28080 map[job:web namespace:default node:node-1 port_label:http task_group:web tasks:nginx,sidecar]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.c.Address = proto.String(fn.URL)
+			p, err := New(test.c, nil)
+			require.NoError(t, err)
+
+			req := &pb.ListResourcesRequest{}
+			for k, v := range test.filter {
+				req.Filter = append(req.Filter, &pb.Filter{Key: proto.String(k), Value: proto.String(v)})
+			}
+			resp, err := p.ListResources(req)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, resourcesString(resp.GetResources()))
+			assert.Equal(t, int64(10), resp.GetLastModified())
+		})
+	}
+}
+
+func TestQueryParams(t *testing.T) {
+	fn := newFakeNomad(t)
+
+	_, err := New(&configpb.ProviderConfig{
+		Address:   proto.String(fn.URL),
+		Token:     proto.String("secret-token"),
+		Namespace: proto.String("*"),
+	}, nil)
+	require.NoError(t, err)
+
+	q, token := fn.firstQuery()
+	assert.Equal(t, "secret-token", token)
+	assert.Equal(t, "*", q.Get("namespace"))
+	assert.Equal(t, "true", q.Get("resources"))
+	assert.Equal(t, "", q.Get("index"), "initial query should not block")
+}
+
+func TestBlockingQueries(t *testing.T) {
+	fn := newFakeNomad(t)
+
+	p, err := New(&configpb.ProviderConfig{
+		Address: proto.String(fn.URL),
+		WaitSec: proto.Int32(60),
+	}, nil)
+	require.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 2)
+
+	// Nothing changed since the last modified index.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(10)})
+	require.NoError(t, err)
+	assert.Nil(t, resp.GetResources())
+
+	// web.web[0] completes, and drops out.
+	fn.setAllocs(`[
+	  {"ID": "1", "Name": "web.web[0]", "ClientStatus": "complete", "AllocatedResources": {"Shared": {"Ports": [{"Label": "http", "Value": 28080, "HostIP": "10.0.0.1"}]}}},
+	  {"ID": "2", "Name": "api.api[0]", "ClientStatus": "running", "AllocatedResources": {"Shared": {"Ports": [{"Label": "http", "Value": 21000, "HostIP": "10.0.0.2"}]}}}
+	]`)
+
+	assert.Eventually(t, func() bool {
+		resp, err := p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(10)})
+		return err == nil && len(resp.GetResources()) == 1 && resp.GetResources()[0].GetName() == "api.api[0]" && resp.GetLastModified() == 11
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, c := range []*configpb.ProviderConfig{
+		{WaitSec: proto.Int32(0)},
+		{Address: proto.String("unix:///var/run/nomad.sock")},
+		{Address: proto.String("://nomad")},
+	} {
+		_, err := New(c, nil)
+		assert.Error(t, err, "config: %v", c)
+	}
+}
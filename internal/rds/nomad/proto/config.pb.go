@@ -0,0 +1,279 @@
+// Configuration proto for Nomad provider.
+//
+// Nomad provider discovers running allocations using the Nomad HTTP API.
+// Each running allocation becomes a resource, with allocation's IP address
+// and port, and job, task group and tasks as the resource labels. Changes
+// are detected using Nomad's blocking queries.
+//
+// Example provider config:
+// {
+//   address: "https://nomad.service.consul:4646"
+//   token: "{{env "NOMAD_TOKEN"}}"
+//   port_label: "http"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "nomad://"
+//       filter {
+//         key: "labels.job"
+//         value: "web"
+//       }
+//     }
+//   }
+// }
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/rds/nomad/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProviderConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Nomad HTTP API address.
+	Address *string `protobuf:"bytes,1,opt,name=address,def=http://127.0.0.1:4646" json:"address,omitempty"`
+	// ACL token to use for the API requests, sent in the X-Nomad-Token header.
+	Token *string `protobuf:"bytes,2,opt,name=token" json:"token,omitempty"`
+	// Namespace to list allocations from. Use "*" for all namespaces. By
+	// default, Nomad uses the "default" namespace.
+	Namespace *string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	// TLS config for connecting to the Nomad API over HTTPS.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,4,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// Label of the allocation port to use for the resources, e.g. "http". By
+	// default, the lowest allocated port is used. Allocations without a
+	// matching port are skipped.
+	PortLabel *string `protobuf:"bytes,5,opt,name=port_label,json=portLabel" json:"port_label,omitempty"`
+	// Maximum time a blocking query waits for changes before returning. Nomad
+	// caps it at 10 minutes.
+	WaitSec *int32 `protobuf:"varint,6,opt,name=wait_sec,json=waitSec,def=300" json:"wait_sec,omitempty"`
+	// How long to wait before retrying after a failed API request.
+	RetryIntervalSec *int32 `protobuf:"varint,7,opt,name=retry_interval_sec,json=retryIntervalSec,def=10" json:"retry_interval_sec,omitempty"`
+	// Timeout for the API requests, over and above the blocking query's wait
+	// time.
+	TimeoutMsec *int32 `protobuf:"varint,8,opt,name=timeout_msec,json=timeoutMsec,def=5000" json:"timeout_msec,omitempty"`
+}
+
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_Address          = string("http://127.0.0.1:4646")
+	Default_ProviderConfig_WaitSec          = int32(300)
+	Default_ProviderConfig_RetryIntervalSec = int32(10)
+	Default_ProviderConfig_TimeoutMsec      = int32(5000)
+)
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderConfig) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return Default_ProviderConfig_Address
+}
+
+func (x *ProviderConfig) GetToken() string {
+	if x != nil && x.Token != nil {
+		return *x.Token
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetNamespace() string {
+	if x != nil && x.Namespace != nil {
+		return *x.Namespace
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetPortLabel() string {
+	if x != nil && x.PortLabel != nil {
+		return *x.PortLabel
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetWaitSec() int32 {
+	if x != nil && x.WaitSec != nil {
+		return *x.WaitSec
+	}
+	return Default_ProviderConfig_WaitSec
+}
+
+func (x *ProviderConfig) GetRetryIntervalSec() int32 {
+	if x != nil && x.RetryIntervalSec != nil {
+		return *x.RetryIntervalSec
+	}
+	return Default_ProviderConfig_RetryIntervalSec
+}
+
+func (x *ProviderConfig) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_TimeoutMsec
+}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x6e, 0x6f, 0x6d, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x6e, 0x6f, 0x6d, 0x61,
+	0x64, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74,
+	0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x02, 0x0a, 0x0e,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2f,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x3a,
+	0x15, 0x68, 0x74, 0x74, 0x70, 0x3a, 0x2f, 0x2f, 0x31, 0x32, 0x37, 0x2e, 0x30, 0x2e, 0x30, 0x2e,
+	0x31, 0x3a, 0x34, 0x36, 0x34, 0x36, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x6f, 0x72, 0x74, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x12, 0x1e, 0x0a, 0x08, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x33, 0x30, 0x30, 0x52, 0x07, 0x77, 0x61, 0x69, 0x74,
+	0x53, 0x65, 0x63, 0x12, 0x30, 0x0a, 0x12, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x3a,
+	0x02, 0x31, 0x30, 0x52, 0x10, 0x72, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x27, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x35, 0x30, 0x30,
+	0x30, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x42, 0x3d,
+	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x6e, 0x6f, 0x6d, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_goTypes = []any{
+	(*ProviderConfig)(nil),  // 0: cloudprober.rds.nomad.ProviderConfig
+	(*proto.TLSConfig)(nil), // 1: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_depIdxs = []int32{
+	1, // 0: cloudprober.rds.nomad.ProviderConfig.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_nomad_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redisError is an error reply from the Redis server.
+type redisError string
+
+func (e redisError) Error() string { return string(e) }
+
+type connConfig struct {
+	address            string
+	username, password string
+	db                 int
+	tlsConfig          *tls.Config
+	timeout            time.Duration
+}
+
+// conn is a minimal Redis (RESP2) connection. It supports only what this
+// provider needs: simple commands, pipelining and pattern subscriptions.
+type conn struct {
+	c       net.Conn
+	r       *bufio.Reader
+	timeout time.Duration
+}
+
+func dial(cfg *connConfig) (*conn, error) {
+	d := &net.Dialer{Timeout: cfg.timeout}
+
+	var nc net.Conn
+	var err error
+	if cfg.tlsConfig != nil {
+		nc, err = tls.DialWithDialer(d, "tcp", cfg.address, cfg.tlsConfig)
+	} else {
+		nc, err = d.Dial("tcp", cfg.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{c: nc, r: bufio.NewReader(nc), timeout: cfg.timeout}
+
+	if cfg.password != "" {
+		args := []string{"AUTH", cfg.password}
+		if cfg.username != "" {
+			args = []string{"AUTH", cfg.username, cfg.password}
+		}
+		if _, err := c.do(args...); err != nil {
+			c.close()
+			return nil, fmt.Errorf("redis: AUTH failed: %v", err)
+		}
+	}
+	if cfg.db != 0 {
+		if _, err := c.do("SELECT", strconv.Itoa(cfg.db)); err != nil {
+			c.close()
+			return nil, fmt.Errorf("redis: SELECT %d failed: %v", cfg.db, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *conn) close() error {
+	return c.c.Close()
+}
+
+func (c *conn) writeCommand(w *bufio.Writer, args []string) {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+}
+
+// pipeline sends all the commands at once and then reads their replies.
+// Error replies are returned as redisError in the replies slice.
+func (c *conn) pipeline(cmds [][]string) ([]any, error) {
+	if c.timeout > 0 {
+		c.c.SetDeadline(time.Now().Add(c.timeout))
+	}
+
+	w := bufio.NewWriter(c.c)
+	for _, cmd := range cmds {
+		c.writeCommand(w, cmd)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	replies := make([]any, len(cmds))
+	for i := range cmds {
+		reply, err := c.readReply()
+		if err != nil {
+			return nil, err
+		}
+		replies[i] = reply
+	}
+	return replies, nil
+}
+
+// do sends a single command and returns its reply.
+func (c *conn) do(args ...string) (any, error) {
+	replies, err := c.pipeline([][]string{args})
+	if err != nil {
+		return nil, err
+	}
+	if rerr, ok := replies[0].(redisError); ok {
+		return nil, rerr
+	}
+	return replies[0], nil
+}
+
+func (c *conn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(line, "\r\n") {
+		return "", fmt.Errorf("redis: bad line ending in reply: %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply reads a reply: simple strings and bulk strings are returned as
+// string, integers as int64, arrays as []any, nil bulk strings and arrays as
+// nil, and errors as redisError.
+func (c *conn) readReply() (any, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return redisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length: %s", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length: %s", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		arr := make([]any, n)
+		for i := range arr {
+			if arr[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type: %q", line)
+}
+
+// psubscribe subscribes to the given patterns and calls fn for each
+// message received. It blocks until there is an error, e.g. the
+// connection is closed.
+func (c *conn) psubscribe(patterns []string, fn func(channel string)) error {
+	w := bufio.NewWriter(c.c)
+	c.writeCommand(w, append([]string{"PSUBSCRIBE"}, patterns...))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// No read deadline for subscriptions.
+	c.c.SetDeadline(time.Time{})
+	for {
+		reply, err := c.readReply()
+		if err != nil {
+			return err
+		}
+		if rerr, ok := reply.(redisError); ok {
+			return rerr
+		}
+		msg, ok := reply.([]any)
+		if !ok || len(msg) == 0 {
+			continue
+		}
+		if kind, _ := msg[0].(string); kind == "pmessage" && len(msg) == 4 {
+			channel, _ := msg[2].(string)
+			fn(channel)
+		}
+	}
+}
+
+// stringSlice converts an array reply to a string slice.
+func stringSlice(reply any) ([]string, error) {
+	arr, ok := reply.([]any)
+	if !ok {
+		if reply == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("redis: unexpected reply type %T, want array", reply)
+	}
+	ss := make([]string, len(arr))
+	for i, v := range arr {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("redis: unexpected array element type %T, want string", v)
+		}
+		ss[i] = s
+	}
+	return ss, nil
+}
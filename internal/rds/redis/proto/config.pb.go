@@ -0,0 +1,355 @@
+// Configuration proto for Redis provider.
+//
+// Redis provider reads resources from Redis hashes, one hash per resource,
+// for example:
+//   SADD cloudprober:instances web-1
+//   HSET cloudprober:instance:web-1 ip 10.1.1.1 port 8080 labels.app web
+//   EXPIRE cloudprober:instance:web-1 60
+//
+// Example provider config:
+// {
+//   address: "redis.internal:6379"
+//   set_key: "cloudprober:instances"
+//   hash_key_prefix: "cloudprober:instance:"
+// }
+//
+// In probe config:
+// probe {
+//   targets{
+//     rds_targets {
+//       resource_path: "redis://"
+//       filter {
+//         key: "labels.app"
+//         value: "web"
+//       }
+//     }
+//   }
+// }
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/rds/redis/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ProviderConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Redis server address, host:port.
+	Address *string `protobuf:"bytes,1,req,name=address" json:"address,omitempty"`
+	// Authentication. If only password is specified, legacy AUTH (without
+	// username) is used.
+	Username *string `protobuf:"bytes,2,opt,name=username" json:"username,omitempty"`
+	Password *string `protobuf:"bytes,3,opt,name=password" json:"password,omitempty"`
+	// Redis database number.
+	Db *int32 `protobuf:"varint,4,opt,name=db" json:"db,omitempty"`
+	// If specified, connect to Redis over TLS.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,5,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// Resources can be discovered in one of the two ways:
+	//   - set_key: members of this set are the resource names, and each
+	//     resource's hash is at the key: <hash_key_prefix><member>.
+	//   - key_pattern: resource hashes are the keys matching this pattern (using
+	//     SCAN), e.g. "cloudprober:instance:*".
+	//
+	// Resource hash fields:
+	//
+	//	name            Resource name, defaults to the set member or the key.
+	//	ip              Resource IP.
+	//	port            Resource port.
+	//	labels.<key>    Resource label.
+	//
+	// Resources whose hash doesn't exist (e.g. because it expired) are
+	// skipped. This allows using key TTLs to expire dead instances.
+	//
+	// Types that are assignable to Keys:
+	//
+	//	*ProviderConfig_SetKey
+	//	*ProviderConfig_KeyPattern
+	Keys          isProviderConfig_Keys `protobuf_oneof:"keys"`
+	HashKeyPrefix *string               `protobuf:"bytes,8,opt,name=hash_key_prefix,json=hashKeyPrefix" json:"hash_key_prefix,omitempty"`
+	// How often to refresh resources.
+	ReEvalSec *int32 `protobuf:"varint,9,opt,name=re_eval_sec,json=reEvalSec,def=30" json:"re_eval_sec,omitempty"`
+	// If enabled, provider subscribes to the keyspace notifications for the
+	// relevant keys and refreshes resources as soon as they change. Redis
+	// server should be configured to generate these notifications, e.g.:
+	//
+	//	CONFIG SET notify-keyspace-events Kgshx
+	//
+	// Periodic refresh still happens every re_eval_sec.
+	KeyspaceNotifications *bool `protobuf:"varint,10,opt,name=keyspace_notifications,json=keyspaceNotifications" json:"keyspace_notifications,omitempty"`
+	// Timeout for connecting to and reading from the Redis server.
+	TimeoutMsec *int32 `protobuf:"varint,11,opt,name=timeout_msec,json=timeoutMsec,def=5000" json:"timeout_msec,omitempty"`
+}
+
+// Default values for ProviderConfig fields.
+const (
+	Default_ProviderConfig_ReEvalSec   = int32(30)
+	Default_ProviderConfig_TimeoutMsec = int32(5000)
+)
+
+func (x *ProviderConfig) Reset() {
+	*x = ProviderConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderConfig) ProtoMessage() {}
+
+func (x *ProviderConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderConfig.ProtoReflect.Descriptor instead.
+func (*ProviderConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProviderConfig) GetAddress() string {
+	if x != nil && x.Address != nil {
+		return *x.Address
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetDb() int32 {
+	if x != nil && x.Db != nil {
+		return *x.Db
+	}
+	return 0
+}
+
+func (x *ProviderConfig) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (m *ProviderConfig) GetKeys() isProviderConfig_Keys {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+func (x *ProviderConfig) GetSetKey() string {
+	if x, ok := x.GetKeys().(*ProviderConfig_SetKey); ok {
+		return x.SetKey
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetKeyPattern() string {
+	if x, ok := x.GetKeys().(*ProviderConfig_KeyPattern); ok {
+		return x.KeyPattern
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetHashKeyPrefix() string {
+	if x != nil && x.HashKeyPrefix != nil {
+		return *x.HashKeyPrefix
+	}
+	return ""
+}
+
+func (x *ProviderConfig) GetReEvalSec() int32 {
+	if x != nil && x.ReEvalSec != nil {
+		return *x.ReEvalSec
+	}
+	return Default_ProviderConfig_ReEvalSec
+}
+
+func (x *ProviderConfig) GetKeyspaceNotifications() bool {
+	if x != nil && x.KeyspaceNotifications != nil {
+		return *x.KeyspaceNotifications
+	}
+	return false
+}
+
+func (x *ProviderConfig) GetTimeoutMsec() int32 {
+	if x != nil && x.TimeoutMsec != nil {
+		return *x.TimeoutMsec
+	}
+	return Default_ProviderConfig_TimeoutMsec
+}
+
+type isProviderConfig_Keys interface {
+	isProviderConfig_Keys()
+}
+
+type ProviderConfig_SetKey struct {
+	SetKey string `protobuf:"bytes,6,opt,name=set_key,json=setKey,oneof"`
+}
+
+type ProviderConfig_KeyPattern struct {
+	KeyPattern string `protobuf:"bytes,7,opt,name=key_pattern,json=keyPattern,oneof"`
+}
+
+func (*ProviderConfig_SetKey) isProviderConfig_Keys() {}
+
+func (*ProviderConfig_KeyPattern) isProviderConfig_Keys() {}
+
+var File_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x64,
+	0x73, 0x2f, 0x72, 0x65, 0x64, 0x69, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x64, 0x73, 0x2e, 0x72, 0x65, 0x64, 0x69,
+	0x73, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74,
+	0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x03, 0x0a, 0x0e,
+	0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x64, 0x62,
+	0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x19, 0x0a, 0x07, 0x73, 0x65, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x0b,
+	0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x0a, 0x6b, 0x65, 0x79, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
+	0x26, 0x0a, 0x0f, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x68, 0x61, 0x73, 0x68, 0x4b, 0x65,
+	0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x22, 0x0a, 0x0b, 0x72, 0x65, 0x5f, 0x65, 0x76,
+	0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33, 0x30,
+	0x52, 0x09, 0x72, 0x65, 0x45, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x35, 0x0a, 0x16, 0x6b,
+	0x65, 0x79, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x6b, 0x65, 0x79,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73,
+	0x65, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x35, 0x30, 0x30, 0x30, 0x52, 0x0b,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x42, 0x06, 0x0a, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x72, 0x64, 0x73, 0x2f, 0x72, 0x65, 0x64, 0x69, 0x73, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_goTypes = []any{
+	(*ProviderConfig)(nil),  // 0: cloudprober.rds.redis.ProviderConfig
+	(*proto.TLSConfig)(nil), // 1: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_depIdxs = []int32{
+	1, // 0: cloudprober.rds.redis.ProviderConfig.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProviderConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProviderConfig_SetKey)(nil),
+		(*ProviderConfig_KeyPattern)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_rds_redis_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package redis implements a Redis-based targets provider for cloudprober.
+*/
+package redis
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/rds/common/cache"
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/redis/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the povider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "redis"
+
+/*
+SupportedFilters defines filters supported by the Redis-based resources
+type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.app"
+		 value: "service-a"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+const labelFieldPrefix = "labels."
+
+// Minimum time between two refreshes triggered by keyspace notifications.
+const minNotificationRefreshInterval = time.Second
+
+// Provider provides a Redis-based targets provider for RDS. It implements
+// the RDS server's Provider interface.
+type Provider struct {
+	c       *configpb.ProviderConfig
+	connCfg *connConfig
+	l       *logger.Logger
+
+	connMu sync.Mutex
+	conn   *conn
+
+	cache *cache.Cache
+
+	refreshChan chan struct{}
+}
+
+// keyNames returns the resources' hash keys and the default resource names.
+func (p *Provider) keyNames(c *conn) ([]string, []string, error) {
+	if p.c.GetSetKey() != "" {
+		reply, err := c.do("SMEMBERS", p.c.GetSetKey())
+		if err != nil {
+			return nil, nil, err
+		}
+		members, err := stringSlice(reply)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys := make([]string, len(members))
+		for i, m := range members {
+			keys[i] = p.c.GetHashKeyPrefix() + m
+		}
+		return keys, members, nil
+	}
+
+	var keys []string
+	seen := make(map[string]bool) // SCAN may return a key multiple times.
+	cursor := "0"
+	for {
+		reply, err := c.do("SCAN", cursor, "MATCH", p.c.GetKeyPattern(), "COUNT", "1000")
+		if err != nil {
+			return nil, nil, err
+		}
+		arr, ok := reply.([]any)
+		if !ok || len(arr) != 2 {
+			return nil, nil, fmt.Errorf("redis: unexpected SCAN reply: %v", reply)
+		}
+		cursor, _ = arr[0].(string)
+		batch, err := stringSlice(arr[1])
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, k := range batch {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		if cursor == "0" || cursor == "" {
+			break
+		}
+	}
+	return keys, keys, nil
+}
+
+// resourceFromHash builds a resource from the fields of a hash.
+func resourceFromHash(defaultName string, fields []string) (*pb.Resource, error) {
+	if len(fields)%2 != 0 {
+		return nil, fmt.Errorf("odd number of hash fields: %d", len(fields))
+	}
+
+	res := &pb.Resource{Name: proto.String(defaultName)}
+	for i := 0; i < len(fields); i += 2 {
+		k, v := fields[i], fields[i+1]
+		switch {
+		case k == "name":
+			res.Name = proto.String(v)
+		case k == "ip":
+			res.Ip = proto.String(v)
+		case k == "port":
+			port, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port (%s): %v", v, err)
+			}
+			res.Port = proto.Int32(int32(port))
+		case strings.HasPrefix(k, labelFieldPrefix):
+			if res.Labels == nil {
+				res.Labels = make(map[string]string)
+			}
+			res.Labels[strings.TrimPrefix(k, labelFieldPrefix)] = v
+		}
+	}
+	return res, nil
+}
+
+func (p *Provider) fetchResources(c *conn) ([]*pb.Resource, error) {
+	keys, names, err := p.keyNames(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	cmds := make([][]string, len(keys))
+	for i, k := range keys {
+		cmds[i] = []string{"HGETALL", k}
+	}
+	replies, err := c.pipeline(cmds)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []*pb.Resource
+	for i, reply := range replies {
+		if rerr, ok := reply.(redisError); ok {
+			p.l.Warningf("redis: HGETALL %s failed: %v", keys[i], rerr)
+			continue
+		}
+		fields, err := stringSlice(reply)
+		if err != nil {
+			return nil, err
+		}
+		// Hash doesn't exist, most likely expired.
+		if len(fields) == 0 {
+			continue
+		}
+		res, err := resourceFromHash(names[i], fields)
+		if err != nil {
+			p.l.Warningf("redis: skipping resource with key %s: %v", keys[i], err)
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	sort.Slice(resources, func(i, j int) bool { return resources[i].GetName() < resources[j].GetName() })
+	return resources, nil
+}
+
+func (p *Provider) refresh() error {
+	p.connMu.Lock()
+	defer p.connMu.Unlock()
+
+	if p.conn == nil {
+		c, err := dial(p.connCfg)
+		if err != nil {
+			return fmt.Errorf("redis: error connecting to %s: %v", p.connCfg.address, err)
+		}
+		p.conn = c
+	}
+
+	resources, err := p.fetchResources(p.conn)
+	if err != nil {
+		// Reconnect next time.
+		p.conn.close()
+		p.conn = nil
+		return fmt.Errorf("redis: error fetching resources: %v", err)
+	}
+
+	p.cache.Update(resources, time.Now().Unix())
+	return nil
+}
+
+func (p *Provider) subscribePatterns() []string {
+	prefix := fmt.Sprintf("__keyspace@%d__:", p.c.GetDb())
+	if p.c.GetSetKey() != "" {
+		return []string{prefix + p.c.GetSetKey(), prefix + p.c.GetHashKeyPrefix() + "*"}
+	}
+	return []string{prefix + p.c.GetKeyPattern()}
+}
+
+// watchNotifications subscribes to the keyspace notifications and triggers
+// a refresh on changes. It reconnects on errors after the given delay.
+func (p *Provider) watchNotifications(retryDelay time.Duration) {
+	for {
+		c, err := dial(p.connCfg)
+		if err == nil {
+			err = c.psubscribe(p.subscribePatterns(), func(string) {
+				select {
+				case p.refreshChan <- struct{}{}:
+				default:
+				}
+			})
+			c.close()
+		}
+		p.l.Warningf("redis: keyspace notifications subscription error: %v, retrying in %v", err, retryDelay)
+		time.Sleep(retryDelay)
+	}
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	return p.cache.ListResources(req)
+}
+
+// New creates a Redis provider for RDS server, based on the provided config.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	if c.GetSetKey() == "" && c.GetKeyPattern() == "" {
+		return nil, errors.New("redis: one of set_key or key_pattern must be specified")
+	}
+	if c.GetReEvalSec() <= 0 {
+		return nil, fmt.Errorf("redis: invalid re_eval_sec: %d", c.GetReEvalSec())
+	}
+
+	connCfg := &connConfig{
+		address:  c.GetAddress(),
+		username: c.GetUsername(),
+		password: c.GetPassword(),
+		db:       int(c.GetDb()),
+		timeout:  time.Duration(c.GetTimeoutMsec()) * time.Millisecond,
+	}
+	if c.GetTlsConfig() != nil {
+		connCfg.tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(connCfg.tlsConfig, c.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("redis: error parsing tls_config: %v", err)
+		}
+		if connCfg.tlsConfig.ServerName == "" {
+			if host, _, err := net.SplitHostPort(c.GetAddress()); err == nil {
+				connCfg.tlsConfig.ServerName = host
+			}
+		}
+	}
+
+	p := &Provider{
+		c:           c,
+		connCfg:     connCfg,
+		l:           l,
+		cache:       cache.New("redis", SupportedFilters.RegexFilterKeys, l),
+		refreshChan: make(chan struct{}, 1),
+	}
+
+	// Initial refresh is done synchronously, but we don't fail if Redis is
+	// not reachable yet; resources will be populated by the refresh loop.
+	if err := p.refresh(); err != nil {
+		l.Error(err.Error())
+	}
+
+	reEvalInterval := time.Duration(c.GetReEvalSec()) * time.Second
+	if c.GetKeyspaceNotifications() {
+		go p.watchNotifications(reEvalInterval)
+	}
+	go cache.RefreshLoop(reEvalInterval, p.refreshChan, minNotificationRefreshInterval, p.refresh, l)
+
+	return p, nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/redis/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeRedis is a minimal fake Redis server for testing.
+type fakeRedis struct {
+	ln       net.Listener
+	password string
+
+	mu       sync.Mutex
+	sets     map[string][]string
+	hashes   map[string][]string
+	commands []string
+	subs     []chan string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr := &fakeRedis{
+		ln:     ln,
+		sets:   make(map[string][]string),
+		hashes: make(map[string][]string),
+	}
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go fr.serve(c)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return fr
+}
+
+func (fr *fakeRedis) notify(channel string) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	for _, ch := range fr.subs {
+		ch <- channel
+	}
+}
+
+func writeArray(w *bufio.Writer, ss []string) {
+	fmt.Fprintf(w, "*%d\r\n", len(ss))
+	for _, s := range ss {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(s), s)
+	}
+}
+
+func (fr *fakeRedis) serve(nc net.Conn) {
+	defer nc.Close()
+	c := &conn{c: nc, r: bufio.NewReader(nc)}
+	w := bufio.NewWriter(nc)
+	for {
+		reply, err := c.readReply()
+		if err != nil {
+			return
+		}
+		args, _ := stringSlice(reply)
+
+		fr.mu.Lock()
+		fr.commands = append(fr.commands, strings.Join(args, " "))
+		switch strings.ToUpper(args[0]) {
+		case "AUTH":
+			if args[len(args)-1] == fr.password {
+				w.WriteString("+OK\r\n")
+			} else {
+				w.WriteString("-WRONGPASS invalid password\r\n")
+			}
+		case "SELECT":
+			w.WriteString("+OK\r\n")
+		case "SMEMBERS":
+			writeArray(w, fr.sets[args[1]])
+		case "HGETALL":
+			writeArray(w, fr.hashes[args[1]])
+		case "SCAN":
+			var keys []string
+			prefix := strings.TrimSuffix(args[3], "*")
+			for k := range fr.hashes {
+				if strings.HasPrefix(k, prefix) {
+					keys = append(keys, k)
+				}
+			}
+			w.WriteString("*2\r\n$1\r\n0\r\n")
+			writeArray(w, keys)
+		case "PSUBSCRIBE":
+			ch := make(chan string, 10)
+			fr.subs = append(fr.subs, ch)
+			for i, pattern := range args[1:] {
+				fmt.Fprintf(w, "*3\r\n$10\r\npsubscribe\r\n$%d\r\n%s\r\n:%d\r\n", len(pattern), pattern, i+1)
+			}
+			w.Flush()
+			fr.mu.Unlock()
+			for channel := range ch {
+				writeArray(w, []string{"pmessage", args[1], channel, "hset"})
+				w.Flush()
+			}
+			return
+		default:
+			w.WriteString("-ERR unknown command\r\n")
+		}
+		fr.mu.Unlock()
+		w.Flush()
+	}
+}
+
+func testConfig(fr *fakeRedis) *configpb.ProviderConfig {
+	return &configpb.ProviderConfig{
+		Address:       proto.String(fr.ln.Addr().String()),
+		Keys:          &configpb.ProviderConfig_SetKey{SetKey: "instances"},
+		HashKeyPrefix: proto.String("instance:"),
+	}
+}
+
+func resourceNames(resources []*pb.Resource) []string {
+	var names []string
+	for _, res := range resources {
+		names = append(names, res.GetName())
+	}
+	return names
+}
+
+func TestListResources(t *testing.T) {
+	fr := newFakeRedis(t)
+	fr.sets["instances"] = []string{"web-2", "web-1", "db-1", "expired"}
+	fr.hashes["instance:web-1"] = []string{"ip", "10.1.1.1", "port", "8080", "labels.app", "web"}
+	fr.hashes["instance:web-2"] = []string{"ip", "10.1.1.2", "port", "8080", "labels.app", "web"}
+	fr.hashes["instance:db-1"] = []string{"name", "database-1", "ip", "10.1.1.3", "labels.app", "db"}
+
+	p, err := New(testConfig(fr), nil)
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"database-1", "web-1", "web-2"}, resourceNames(resp.GetResources()))
+	assert.Equal(t, "10.1.1.1", resp.GetResources()[1].GetIp())
+	assert.Equal(t, int32(8080), resp.GetResources()[1].GetPort())
+	assert.Equal(t, map[string]string{"app": "web"}, resp.GetResources()[1].GetLabels())
+
+	for _, tt := range []struct {
+		filters []*pb.Filter
+		want    []string
+	}{
+		{
+			filters: []*pb.Filter{{Key: proto.String("labels.app"), Value: proto.String("web")}},
+			want:    []string{"web-1", "web-2"},
+		},
+		{
+			filters: []*pb.Filter{{Key: proto.String("name"), Value: proto.String("data.*")}},
+			want:    []string{"database-1"},
+		},
+	} {
+		resp, err := p.ListResources(&pb.ListResourcesRequest{Filter: tt.filters})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, resourceNames(resp.GetResources()))
+	}
+
+	// If not modified since last update, no resources are returned.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(time.Now().Unix())})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+}
+
+func TestKeyPattern(t *testing.T) {
+	fr := newFakeRedis(t)
+	fr.hashes["instance:web-1"] = []string{"ip", "10.1.1.1"}
+	fr.hashes["instance:web-2"] = []string{"name", "web-2", "ip", "10.1.1.2"}
+	fr.hashes["other:x"] = []string{"ip", "10.1.1.3"}
+
+	c := testConfig(fr)
+	c.Keys = &configpb.ProviderConfig_KeyPattern{KeyPattern: "instance:*"}
+	p, err := New(c, nil)
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"instance:web-1", "web-2"}, resourceNames(resp.GetResources()))
+}
+
+func TestAuthAndDB(t *testing.T) {
+	fr := newFakeRedis(t)
+	fr.password = "secret"
+	fr.sets["instances"] = []string{"web-1"}
+	fr.hashes["instance:web-1"] = []string{"ip", "10.1.1.1"}
+
+	c := testConfig(fr)
+	c.Username = proto.String("user")
+	c.Password = proto.String("secret")
+	c.Db = proto.Int32(2)
+	p, err := New(c, nil)
+	assert.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"web-1"}, resourceNames(resp.GetResources()))
+	assert.Equal(t, []string{"AUTH user secret", "SELECT 2", "SMEMBERS instances", "HGETALL instance:web-1"}, fr.commands)
+
+	// Wrong password.
+	c.Password = proto.String("wrong")
+	p, err = New(c, nil)
+	assert.NoError(t, err)
+	assert.Error(t, p.refresh())
+}
+
+func TestKeyspaceNotifications(t *testing.T) {
+	fr := newFakeRedis(t)
+	fr.sets["instances"] = []string{"web-1"}
+	fr.hashes["instance:web-1"] = []string{"ip", "10.1.1.1"}
+
+	c := testConfig(fr)
+	c.KeyspaceNotifications = proto.Bool(true)
+	p, err := New(c, nil)
+	assert.NoError(t, err)
+
+	// Wait for the subscription.
+	assert.Eventually(t, func() bool {
+		fr.mu.Lock()
+		defer fr.mu.Unlock()
+		return len(fr.subs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Contains(t, fr.commands, "PSUBSCRIBE __keyspace@0__:instances __keyspace@0__:instance:*")
+
+	fr.mu.Lock()
+	fr.sets["instances"] = append(fr.sets["instances"], "web-2")
+	fr.hashes["instance:web-2"] = []string{"ip", "10.1.1.2"}
+	fr.mu.Unlock()
+	fr.notify("__keyspace@0__:instances")
+
+	assert.Eventually(t, func() bool {
+		resp, err := p.ListResources(&pb.ListResourcesRequest{})
+		return err == nil && len(resp.GetResources()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(&configpb.ProviderConfig{Address: proto.String("localhost:6379")}, nil)
+	assert.Error(t, err, "no keys config")
+
+	_, err = New(&configpb.ProviderConfig{
+		Address:   proto.String("localhost:6379"),
+		Keys:      &configpb.ProviderConfig_SetKey{SetKey: "instances"},
+		ReEvalSec: proto.Int32(0),
+	}, nil)
+	assert.Error(t, err, "invalid re_eval_sec")
+}
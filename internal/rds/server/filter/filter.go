@@ -19,7 +19,9 @@ service) providers.
 package filter
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/cloudprober/cloudprober/logger"
@@ -49,21 +51,57 @@ func (rf *RegexFilter) Match(name string, l *logger.Logger) bool {
 // LabelsFilter implements a filter on resource's labels.
 type LabelsFilter struct {
 	labels map[string]*regexp.Regexp
+	sets   map[string]map[string]bool
+	absent []string
 }
 
-// NewLabelsFilter builds LabelsFilter from a key:regexp map.
+// NewLabelsFilter builds LabelsFilter from a key:regexp map. An empty regexp
+// matches any value, i.e. it only requires the label key to be present. Keys
+// prefixed with "!" (value must be empty) require the label key to be absent.
 func NewLabelsFilter(labelsFilter map[string]string) (*LabelsFilter, error) {
-	labels := make(map[string]*regexp.Regexp)
+	lf := &LabelsFilter{labels: make(map[string]*regexp.Regexp)}
 
 	for key, regexStr := range labelsFilter {
+		if absentKey, ok := strings.CutPrefix(key, "!"); ok {
+			if regexStr != "" {
+				return nil, fmt.Errorf("value (%s) is not allowed for the label absence filter: %s", regexStr, key)
+			}
+			lf.absent = append(lf.absent, absentKey)
+			continue
+		}
+
+		if regexStr == "" {
+			lf.labels[key] = nil
+			continue
+		}
 		re, err := regexp.Compile(regexStr)
 		if err != nil {
 			return nil, err
 		}
-		labels[key] = re
+		lf.labels[key] = re
 	}
 
-	return &LabelsFilter{labels: labels}, nil
+	return lf, nil
+}
+
+// AddValueSet adds a filter that matches if the label's value is one of the
+// given values.
+func (lf *LabelsFilter) AddValueSet(key string, values []string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("no values for the label set filter: %s", key)
+	}
+	if _, ok := lf.labels[key]; ok {
+		return fmt.Errorf("label %s has both regex and set filters", key)
+	}
+	if lf.sets == nil {
+		lf.sets = make(map[string]map[string]bool)
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	lf.sets[key] = set
+	return nil
 }
 
 // Match returns true if provided string matches the regex of the filter.
@@ -72,7 +110,18 @@ func (lf *LabelsFilter) Match(inputLabels map[string]string, l *logger.Logger) b
 	for k, re := range lf.labels {
 		// If input labels don't have the requisite key or key's value doesn't match
 		// the given regex, return false.
-		if v, ok := inputLabels[k]; !ok || !re.MatchString(v) {
+		v, ok := inputLabels[k]
+		if !ok || (re != nil && !re.MatchString(v)) {
+			return false
+		}
+	}
+	for k, set := range lf.sets {
+		if v, ok := inputLabels[k]; !ok || !set[v] {
+			return false
+		}
+	}
+	for _, k := range lf.absent {
+		if _, ok := inputLabels[k]; ok {
 			return false
 		}
 	}
@@ -53,8 +53,38 @@ func TestLabelsFilter(t *testing.T) {
 			expectError:  false,
 			matchCount:   0,
 		},
+		{
+			// Empty value: label lC just needs to be present.
+			testLabels: []map[string]string{
+				{"lA": "vAA", "lC": ""},
+				{"lA": "vBB", "lC": "vC"},
+				{"lA": "vCC"},
+			},
+			labelFilters: map[string]string{"lC": ""},
+			matchCount:   2,
+		},
+		{
+			// Label lC must be absent.
+			testLabels: []map[string]string{
+				{"lA": "vAA", "lC": ""},
+				{"lA": "vBB", "lC": "vC"},
+				{"lA": "vCC"},
+			},
+			labelFilters: map[string]string{"!lC": "", "lA": "v.*"},
+			matchCount:   1,
+		},
+		{
+			labelFilters: map[string]string{"!lC": "vC"},
+			expectError:  true,
+		},
 	} {
 		lf, err := NewLabelsFilter(testData.labelFilters)
+		if testData.expectError {
+			if err == nil {
+				t.Errorf("Expected error for label filters: %v", testData.labelFilters)
+			}
+			continue
+		}
 		if err != nil {
 			t.Errorf("Got unexpected error while adding a label filter: %v", err)
 		}
@@ -72,3 +102,36 @@ func TestLabelsFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestLabelsFilterValueSet(t *testing.T) {
+	lf, err := NewLabelsFilter(map[string]string{"app": "web-.*"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := lf.AddValueSet("env", []string{"prod", "staging"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, test := range []struct {
+		labels map[string]string
+		want   bool
+	}{
+		{labels: map[string]string{"app": "web-1", "env": "prod"}, want: true},
+		{labels: map[string]string{"app": "web-1", "env": "staging"}, want: true},
+		{labels: map[string]string{"app": "web-1", "env": "prod-1"}, want: false},
+		{labels: map[string]string{"app": "web-1", "env": "dev"}, want: false},
+		{labels: map[string]string{"app": "web-1"}, want: false},
+		{labels: map[string]string{"app": "db-1", "env": "prod"}, want: false},
+	} {
+		if got := lf.Match(test.labels, nil); got != test.want {
+			t.Errorf("Match(%v)=%v, want=%v", test.labels, got, test.want)
+		}
+	}
+
+	if err := lf.AddValueSet("app", []string{"web-1"}); err == nil {
+		t.Errorf("Expected error for both regex and set filters on the same label")
+	}
+	if err := lf.AddValueSet("zone", nil); err == nil {
+		t.Errorf("Expected error for empty value set")
+	}
+}
@@ -19,7 +19,12 @@ type Filters struct {
 // based on the following criteria:
 //   - There can be multiple regex filters. Keys for these filters should be
 //     provided through the regexFilterKeys argument.
-//   - Labels filter keys always starts with the prefix "labels.".
+//   - Labels filter keys always starts with the prefix "labels.". An empty
+//     value matches any value, i.e. only checks for the label's presence.
+//     Label absence filter keys start with the prefix "!labels.", and their
+//     value must be empty.
+//   - Labels filters can have a set of values ("values" field) instead of a
+//     regex value. Such filters match if the label's value is in the set.
 //   - There can be only one freshness filter, key for which should be provided
 //     through the freshnessFilterKey argument.
 func ParseFilters(filters []*pb.Filter, regexFilterKeys []string, freshnessFilterKey string) (*Filters, error) {
@@ -33,8 +38,20 @@ func ParseFilters(filters []*pb.Filter, regexFilterKeys []string, freshnessFilte
 	}
 
 	labels := make(map[string]string)
+	labelSets := make(map[string][]string)
 
 	for _, f := range filters {
+		if len(f.GetValues()) != 0 {
+			if f.Value != nil {
+				return nil, fmt.Errorf("filter: only one of value and values can be set, key: %s", f.GetKey())
+			}
+			key, ok := strings.CutPrefix(f.GetKey(), "labels.")
+			if !ok {
+				return nil, fmt.Errorf("filter: values are supported only for the labels filters, key: %s", f.GetKey())
+			}
+			labelSets[key] = f.GetValues()
+			continue
+		}
 
 		// If we expect this filter to be a regex filter.
 		if _, ok := r.RegexFilters[f.GetKey()]; ok {
@@ -63,15 +80,26 @@ func ParseFilters(filters []*pb.Filter, regexFilterKeys []string, freshnessFilte
 			continue
 		}
 
+		// Label absence filter (starting with !labels.).
+		if strings.HasPrefix(f.GetKey(), "!labels.") {
+			labels["!"+strings.TrimPrefix(f.GetKey(), "!labels.")] = f.GetValue()
+			continue
+		}
+
 		// Unexpected filter key.
 		return nil, fmt.Errorf("unsupported filter key: %s", f.GetKey())
 	}
 
-	if len(labels) != 0 {
+	if len(labels) != 0 || len(labelSets) != 0 {
 		var err error
 		if r.LabelsFilter, err = NewLabelsFilter(labels); err != nil {
 			return nil, fmt.Errorf("filter: error creating labels filter from: %v, err: %v", labels, err)
 		}
+		for key, values := range labelSets {
+			if err := r.LabelsFilter.AddValueSet(key, values); err != nil {
+				return nil, fmt.Errorf("filter: error creating labels filter: %v", err)
+			}
+		}
 	}
 
 	return r, nil
@@ -40,6 +40,19 @@ func TestParseFilters(t *testing.T) {
 			wantLabelsFilter:    true,
 			wantFreshnessFilter: true,
 		},
+		{
+			desc: "Pass with label presence and absence filters",
+			reqFilters: map[string]string{
+				"labels.canary": "",
+				"!labels.owner": "",
+			},
+			wantLabelsFilter: true,
+		},
+		{
+			desc:       "Error value in label absence filter",
+			reqFilters: map[string]string{"!labels.owner": "team-a"},
+			wantErr:    true,
+		},
 	}
 
 	for _, test := range tests {
@@ -80,3 +93,47 @@ func TestParseFilters(t *testing.T) {
 	}
 
 }
+
+func TestParseFiltersValueSet(t *testing.T) {
+	tests := []struct {
+		desc    string
+		filter  *pb.Filter
+		wantErr bool
+	}{
+		{
+			desc:   "labels values",
+			filter: &pb.Filter{Key: proto.String("labels.env"), Values: []string{"prod", "staging"}},
+		},
+		{
+			desc:    "both value and values",
+			filter:  &pb.Filter{Key: proto.String("labels.env"), Value: proto.String("prod"), Values: []string{"prod"}},
+			wantErr: true,
+		},
+		{
+			desc:    "values for non-label filter",
+			filter:  &pb.Filter{Key: proto.String("name"), Values: []string{"web-1"}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			allFilters, err := ParseFilters([]*pb.Filter{test.filter}, []string{"name"}, "")
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for filter: %v", test.filter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !allFilters.LabelsFilter.Match(map[string]string{"env": "staging"}, nil) {
+				t.Errorf("Labels filter didn't match env=staging")
+			}
+			if allFilters.LabelsFilter.Match(map[string]string{"env": "dev"}, nil) {
+				t.Errorf("Labels filter matched env=dev")
+			}
+		})
+	}
+}
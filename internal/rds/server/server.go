@@ -22,14 +22,23 @@ package server
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/cloudprober/cloudprober/internal/rds/azure"
+	"github.com/cloudprober/cloudprober/internal/rds/docker"
 	"github.com/cloudprober/cloudprober/internal/rds/file"
 	"github.com/cloudprober/cloudprober/internal/rds/gcp"
 	"github.com/cloudprober/cloudprober/internal/rds/kubernetes"
+	"github.com/cloudprober/cloudprober/internal/rds/ldap"
+	"github.com/cloudprober/cloudprober/internal/rds/nomad"
 	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
 	spb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/redis"
 	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/static"
 	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
 	"google.golang.org/grpc"
 )
 
@@ -47,6 +56,76 @@ type Provider interface {
 	ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error)
 }
 
+// ReadyProvider is an optional interface that providers can implement to
+// signal that they have finished the initial load of their resources.
+type ReadyProvider interface {
+	// Ready returns a channel that is closed once provider is ready.
+	Ready() <-chan struct{}
+}
+
+// MetricsProvider is an optional interface that providers can implement to
+// export their internal stats as EventMetrics.
+type MetricsProvider interface {
+	Metrics() []*metrics.EventMetrics
+}
+
+// Metrics returns the EventMetrics exported by the providers implementing the
+// MetricsProvider interface, in the order of provider IDs.
+func (s *Server) Metrics() []*metrics.EventMetrics {
+	var ids []string
+	for id := range s.providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var ems []*metrics.EventMetrics
+	for _, id := range ids {
+		if mp, ok := s.providers[id].(MetricsProvider); ok {
+			ems = append(ems, mp.Metrics()...)
+		}
+	}
+	return ems
+}
+
+// waitForProviders waits for the providers implementing the ReadyProvider
+// interface to become ready. It returns an error listing the providers that
+// are not ready yet, if context is canceled before that.
+func (s *Server) waitForProviders(ctx context.Context) error {
+	var ids []string
+	for id := range s.providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for i, id := range ids {
+		rp, ok := s.providers[id].(ReadyProvider)
+		if !ok {
+			continue
+		}
+		select {
+		case <-rp.Ready():
+		case <-ctx.Done():
+			var notReady []string
+			for _, id := range ids[i:] {
+				if rp, ok := s.providers[id].(ReadyProvider); ok && !isReady(rp) {
+					notReady = append(notReady, id)
+				}
+			}
+			return fmt.Errorf("providers not ready: %v", notReady)
+		}
+	}
+	return nil
+}
+
+func isReady(rp ReadyProvider) bool {
+	select {
+	case <-rp.Ready():
+		return true
+	default:
+		return false
+	}
+}
+
 // ListResources implements the ListResources method of the ResourceDiscovery
 // service.
 func (s *Server) ListResources(ctx context.Context, req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
@@ -87,6 +166,54 @@ func (s *Server) initProviders(c *configpb.ServerConf) error {
 			if p, err = kubernetes.New(pc.GetKubernetesConfig(), s.l); err != nil {
 				return err
 			}
+		case *configpb.Provider_RedisConfig:
+			if id == "" {
+				id = redis.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Redis provider with id: %s", id)
+			if p, err = redis.New(pc.GetRedisConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_DockerConfig:
+			if id == "" {
+				id = docker.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Docker provider with id: %s", id)
+			if p, err = docker.New(pc.GetDockerConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_NomadConfig:
+			if id == "" {
+				id = nomad.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Nomad provider with id: %s", id)
+			if p, err = nomad.New(pc.GetNomadConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_LdapConfig:
+			if id == "" {
+				id = ldap.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding LDAP provider with id: %s", id)
+			if p, err = ldap.New(pc.GetLdapConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_AzureConfig:
+			if id == "" {
+				id = azure.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding Azure provider with id: %s", id)
+			if p, err = azure.New(pc.GetAzureConfig(), s.l); err != nil {
+				return err
+			}
+		case *configpb.Provider_StaticConfig:
+			if id == "" {
+				id = static.DefaultProviderID
+			}
+			s.l.Infof("rds.server: adding static provider with id: %s", id)
+			if p, err = static.New(pc.GetStaticConfig(), s.l); err != nil {
+				return err
+			}
 		}
 		s.providers[id] = p
 	}
@@ -114,6 +241,15 @@ func New(initCtx context.Context, c *configpb.ServerConf, providers map[string]P
 		}
 	}
 
+	if c.GetInitialLoadTimeoutSec() > 0 {
+		timeout := time.Duration(c.GetInitialLoadTimeoutSec()) * time.Second
+		ctx, cancel := context.WithTimeout(initCtx, timeout)
+		defer cancel()
+		if err := srv.waitForProviders(ctx); err != nil {
+			l.Warningf("rds.server: timed out waiting (%v) for the initial load: %v", timeout, err)
+		}
+	}
+
 	return srv, nil
 }
 
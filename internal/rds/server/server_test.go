@@ -4,8 +4,12 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -48,3 +52,66 @@ func TestListResources(t *testing.T) {
 		t.Errorf("Didn't get expected resource. Got=%v, Want=%v", res.Resources, testResources)
 	}
 }
+
+type readyTestProvider struct {
+	testProvider
+	ready chan struct{}
+}
+
+func (tp *readyTestProvider) Ready() <-chan struct{} {
+	return tp.ready
+}
+
+func TestNewWaitForProviders(t *testing.T) {
+	p1 := &readyTestProvider{ready: make(chan struct{})}
+	p2 := &readyTestProvider{ready: make(chan struct{})}
+	providers := map[string]Provider{"p0": &testProvider{}, "p1": p1, "p2": p2}
+
+	close(p1.ready)
+	c := &configpb.ServerConf{InitialLoadTimeoutSec: proto.Int32(1)}
+
+	// p2 is not ready, New returns after the timeout.
+	start := time.Now()
+	_, err := New(context.Background(), c, providers, nil)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+
+	srv := &Server{providers: providers}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorContains(t, srv.waitForProviders(ctx), "[p2]")
+
+	// p2 becomes ready while we are waiting.
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		close(p2.ready)
+	}()
+	start = time.Now()
+	_, err = New(context.Background(), c, providers, nil)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+type metricsTestProvider struct {
+	testProvider
+	name string
+}
+
+func (tp *metricsTestProvider) Metrics() []*metrics.EventMetrics {
+	return []*metrics.EventMetrics{metrics.NewEventMetrics(time.Now()).AddLabel("provider", tp.name)}
+}
+
+func TestServerMetrics(t *testing.T) {
+	srv := &Server{
+		providers: map[string]Provider{
+			"p0": &testProvider{},
+			"p2": &metricsTestProvider{name: "p2"},
+			"p1": &metricsTestProvider{name: "p1"},
+		},
+	}
+	var got []string
+	for _, em := range srv.Metrics() {
+		got = append(got, em.Label("provider"))
+	}
+	assert.Equal(t, []string{"p1", "p2"}, got)
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package static implements a targets provider that generates resources from
+the templates defined in the provider config itself.
+*/
+package static
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	"github.com/cloudprober/cloudprober/internal/rds/server/filter"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/static/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultProviderID is the provider id to use for this provider if a provider
+// id is not configured explicitly.
+const DefaultProviderID = "static"
+
+/*
+SupportedFilters defines filters supported by the static resources type.
+
+	 Example:
+	 filter {
+		 key: "name"
+		 value: "web-.*"
+	 }
+	 filter {
+		 key: "labels.role"
+		 value: "web"
+	 }
+*/
+var SupportedFilters = struct {
+	RegexFilterKeys []string
+	LabelsFilter    bool
+}{
+	[]string{"name"},
+	true,
+}
+
+// Provider provides resources generated from the config templates. It
+// implements the RDS server's Provider interface.
+type Provider struct {
+	resources   []*pb.Resource
+	lastUpdated time.Time
+	l           *logger.Logger
+}
+
+// segment is a part of a template: either a literal string (single value),
+// or an expression's values.
+type segment []string
+
+// parseExpr parses a brace expression's body, i.e. "1..10" or "a,b,c".
+func parseExpr(body string) (segment, error) {
+	if start, end, ok := strings.Cut(body, ".."); ok {
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range start: %s", start)
+		}
+		to, err := strconv.Atoi(end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range end: %s", end)
+		}
+
+		// Zero-pad if any of the bounds is zero-padded, e.g. {01..10}.
+		width := 0
+		for _, s := range []string{start, end} {
+			s = strings.TrimPrefix(s, "-")
+			if len(s) > 1 && s[0] == '0' && len(s) > width {
+				width = len(s)
+			}
+		}
+
+		step := 1
+		if to < from {
+			step = -1
+		}
+		var values segment
+		for i := from; ; i += step {
+			values = append(values, fmt.Sprintf("%0*d", width, i))
+			if i == to {
+				break
+			}
+		}
+		return values, nil
+	}
+
+	if strings.Contains(body, ",") {
+		return strings.Split(body, ","), nil
+	}
+	return nil, fmt.Errorf("expression should be either a range ({1..10}) or a list ({a,b})")
+}
+
+// rangeLen returns the number of values in a range expression, without
+// generating them, so that we can check the count before expanding. The
+// difference is computed in uint64 to not overflow for ranges spanning the
+// whole int range; such a range's length is capped at math.MaxUint64.
+func rangeLen(body string) uint64 {
+	start, end, ok := strings.Cut(body, "..")
+	if !ok {
+		return 0
+	}
+	from, err1 := strconv.Atoi(start)
+	to, err2 := strconv.Atoi(end)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	if to < from {
+		from, to = to, from
+	}
+	n := uint64(to) - uint64(from)
+	if n == math.MaxUint64 {
+		return n
+	}
+	return n + 1
+}
+
+// expand expands the brace expressions in the template. It returns an error
+// if template is malformed, or if it expands to more than maxCount values.
+func expand(tmpl string, maxCount int) ([]string, error) {
+	var segments []segment
+	count := 1
+	for s := tmpl; s != ""; {
+		i := strings.IndexAny(s, "{}")
+		if i == -1 {
+			segments = append(segments, segment{s})
+			break
+		}
+		if s[i] == '}' {
+			return nil, fmt.Errorf("template %q: unmatched '}'", tmpl)
+		}
+		if i > 0 {
+			segments = append(segments, segment{s[:i]})
+		}
+
+		j := strings.IndexAny(s[i+1:], "{}")
+		if j == -1 || s[i+1+j] == '{' {
+			return nil, fmt.Errorf("template %q: unmatched '{'", tmpl)
+		}
+		body := s[i+1 : i+1+j]
+		s = s[i+j+2:]
+
+		// Check the range length before generating the values.
+		if n := rangeLen(body); n > uint64(maxCount) {
+			return nil, fmt.Errorf("template %q expands to more than %d values", tmpl, maxCount)
+		}
+		values, err := parseExpr(body)
+		if err != nil {
+			return nil, fmt.Errorf("template %q: invalid expression {%s}: %v", tmpl, body, err)
+		}
+		if count *= len(values); count > maxCount {
+			return nil, fmt.Errorf("template %q expands to more than %d values", tmpl, maxCount)
+		}
+		segments = append(segments, values)
+	}
+
+	results := []string{""}
+	for _, seg := range segments {
+		next := make([]string, 0, len(results)*len(seg))
+		for _, prefix := range results {
+			for _, v := range seg {
+				next = append(next, prefix+v)
+			}
+		}
+		results = next
+	}
+	return results, nil
+}
+
+func expandTemplate(rt *configpb.ResourceTemplate, maxCount int) ([]*pb.Resource, error) {
+	names, err := expand(rt.GetName(), maxCount)
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []string
+	if rt.GetIp() != "" {
+		if ips, err = expand(rt.GetIp(), maxCount); err != nil {
+			return nil, err
+		}
+		if len(ips) != 1 && len(ips) != len(names) {
+			return nil, fmt.Errorf("ip template %q expands to %d values, name template %q expands to %d", rt.GetIp(), len(ips), rt.GetName(), len(names))
+		}
+	}
+
+	resources := make([]*pb.Resource, 0, len(names))
+	for i, name := range names {
+		res := &pb.Resource{
+			Name: proto.String(name),
+		}
+		if len(ips) == 1 {
+			res.Ip = proto.String(ips[0])
+		} else if len(ips) > 1 {
+			res.Ip = proto.String(ips[i])
+		}
+		if rt.Port != nil {
+			res.Port = proto.Int32(rt.GetPort())
+		}
+		if len(rt.GetLabels()) != 0 {
+			res.Labels = make(map[string]string, len(rt.GetLabels()))
+			for k, v := range rt.GetLabels() {
+				res.Labels[k] = v
+			}
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// ListResources returns the list of resources based on the given request.
+func (p *Provider) ListResources(req *pb.ListResourcesRequest) (*pb.ListResourcesResponse, error) {
+	lastModified := proto.Int64(p.lastUpdated.Unix())
+	if req.GetIfModifiedSince() != 0 && p.lastUpdated.Unix() <= req.GetIfModifiedSince() {
+		return &pb.ListResourcesResponse{LastModified: lastModified}, nil
+	}
+
+	allFilters, err := filter.ParseFilters(req.GetFilter(), SupportedFilters.RegexFilterKeys, "")
+	if err != nil {
+		return nil, err
+	}
+	nameFilter, labelsFilter := allFilters.RegexFilters["name"], allFilters.LabelsFilter
+
+	var resources []*pb.Resource
+	for _, res := range p.resources {
+		if nameFilter != nil && !nameFilter.Match(res.GetName(), p.l) {
+			continue
+		}
+		if labelsFilter != nil && !labelsFilter.Match(res.GetLabels(), p.l) {
+			continue
+		}
+		resources = append(resources, res)
+	}
+
+	p.l.Infof("static.ListResources: returning %d resources out of %d", len(resources), len(p.resources))
+	return &pb.ListResourcesResponse{
+		Resources:    resources,
+		LastModified: lastModified,
+	}, nil
+}
+
+// New creates a static provider. It expands all the templates and returns
+// an error if any of them is invalid, or if they expand to more than the
+// max_resources resources in total.
+func New(c *configpb.ProviderConfig, l *logger.Logger) (*Provider, error) {
+	maxCount := int(c.GetMaxResources())
+	if maxCount <= 0 {
+		return nil, fmt.Errorf("static: max_resources should be positive, got: %d", maxCount)
+	}
+
+	p := &Provider{
+		lastUpdated: time.Now(),
+		l:           l,
+	}
+
+	seen := make(map[string]bool)
+	for _, rt := range c.GetResource() {
+		resources, err := expandTemplate(rt, maxCount)
+		if err != nil {
+			return nil, fmt.Errorf("static: %v", err)
+		}
+		for _, res := range resources {
+			if seen[res.GetName()] {
+				return nil, fmt.Errorf("static: duplicate resource name: %s", res.GetName())
+			}
+			seen[res.GetName()] = true
+		}
+		if len(p.resources)+len(resources) > maxCount {
+			return nil, fmt.Errorf("static: resources count exceeds max_resources (%d)", maxCount)
+		}
+		p.resources = append(p.resources, resources...)
+	}
+
+	l.Infof("static: generated %d resources", len(p.resources))
+	return p, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package static
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	pb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	configpb "github.com/cloudprober/cloudprober/internal/rds/static/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		tmpl    string
+		want    []string
+		wantErr bool
+	}{
+		{tmpl: "host", want: []string{"host"}},
+		{tmpl: "host-{1..3}", want: []string{"host-1", "host-2", "host-3"}},
+		{tmpl: "host-{08..10}.example", want: []string{"host-08.example", "host-09.example", "host-10.example"}},
+		{tmpl: "{3..1}", want: []string{"3", "2", "1"}},
+		{tmpl: "{-1..1}", want: []string{"-1", "0", "1"}},
+		{tmpl: "{web,db}-{1..2}", want: []string{"web-1", "web-2", "db-1", "db-2"}},
+		{tmpl: "{1..10}{1..10}", wantErr: true}, // More than 50.
+		{tmpl: "{1..1000000000}", wantErr: true},
+		{tmpl: "{-9223372036854775808..9223372036854775807}", wantErr: true},
+		{tmpl: "{9223372036854775807..-9223372036854775808}", wantErr: true},
+		{tmpl: "{-9223372036854775808..-9223372036854775807}", want: []string{"-9223372036854775808", "-9223372036854775807"}},
+		{tmpl: "host-{1..3", wantErr: true},
+		{tmpl: "host-1..3}", wantErr: true},
+		{tmpl: "host-{1..{2}}", wantErr: true},
+		{tmpl: "host-{a..c}", wantErr: true},
+		{tmpl: "host-{a}", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tmpl, func(t *testing.T) {
+			got, err := expand(test.tmpl, 50)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRangeLen(t *testing.T) {
+	tests := []struct {
+		body string
+		want uint64
+	}{
+		{body: "1..10", want: 10},
+		{body: "10..1", want: 10},
+		{body: "-1..1", want: 3},
+		{body: "a,b", want: 0},
+		{body: "a..b", want: 0},
+		{body: "0..9223372036854775807", want: 9223372036854775808},
+		{body: "-9223372036854775808..9223372036854775807", want: math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			assert.Equal(t, test.want, rangeLen(test.body))
+		})
+	}
+}
+
+func testTemplate(name, ip string, labels map[string]string) *configpb.ResourceTemplate {
+	rt := &configpb.ResourceTemplate{Name: proto.String(name), Labels: labels}
+	if ip != "" {
+		rt.Ip = proto.String(ip)
+	}
+	return rt
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *configpb.ProviderConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "default",
+			conf: &configpb.ProviderConfig{Resource: []*configpb.ResourceTemplate{
+				testTemplate("web-{1..2}", "", nil),
+				testTemplate("db-{1..2}", "", nil),
+			}},
+			want: []string{"web-1", "web-2", "db-1", "db-2"},
+		},
+		{
+			name: "duplicate",
+			conf: &configpb.ProviderConfig{Resource: []*configpb.ResourceTemplate{
+				testTemplate("web-{1..2}", "", nil),
+				testTemplate("web-2", "", nil),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "too_many",
+			conf: &configpb.ProviderConfig{
+				Resource: []*configpb.ResourceTemplate{
+					testTemplate("web-{1..2}", "", nil),
+					testTemplate("db-{1..2}", "", nil),
+				},
+				MaxResources: proto.Int32(3),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ip_count_mismatch",
+			conf: &configpb.ProviderConfig{Resource: []*configpb.ResourceTemplate{
+				testTemplate("web-{1..3}", "10.0.0.{1..2}", nil),
+			}},
+			wantErr: true,
+		},
+		{
+			name:    "bad_max_resources",
+			conf:    &configpb.ProviderConfig{MaxResources: proto.Int32(0)},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(test.conf, &logger.Logger{})
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, res := range p.resources {
+				got = append(got, res.GetName())
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestListResources(t *testing.T) {
+	rt := testTemplate("web-{1..2}", "10.0.0.{1..2}", map[string]string{"role": "web"})
+	rt.Port = proto.Int32(8080)
+	p, err := New(&configpb.ProviderConfig{Resource: []*configpb.ResourceTemplate{
+		rt,
+		testTemplate("db-{1..2}", "10.0.1.1", map[string]string{"role": "db"}),
+	}}, &logger.Logger{})
+	require.NoError(t, err)
+
+	resp, err := p.ListResources(&pb.ListResourcesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetResources(), 4)
+
+	resp, err = p.ListResources(&pb.ListResourcesRequest{Filter: []*pb.Filter{
+		{Key: proto.String("labels.role"), Value: proto.String("web")},
+	}})
+	require.NoError(t, err)
+	want := []*pb.Resource{
+		{Name: proto.String("web-1"), Ip: proto.String("10.0.0.1"), Port: proto.Int32(8080), Labels: map[string]string{"role": "web"}},
+		{Name: proto.String("web-2"), Ip: proto.String("10.0.0.2"), Port: proto.Int32(8080), Labels: map[string]string{"role": "web"}},
+	}
+	require.Len(t, resp.GetResources(), len(want))
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], resp.GetResources()[i]), "got: %v, want: %v", resp.GetResources()[i], want[i])
+	}
+
+	resp, err = p.ListResources(&pb.ListResourcesRequest{Filter: []*pb.Filter{
+		{Key: proto.String("name"), Value: proto.String("db-2")},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResources(), 1)
+	assert.Equal(t, "10.0.1.1", resp.GetResources()[0].GetIp())
+
+	// Not modified since the last update.
+	resp, err = p.ListResources(&pb.ListResourcesRequest{IfModifiedSince: proto.Int64(time.Now().Unix())})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetResources())
+}
@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/requestid/proto/config.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request ID configuration. If configured, each probe request carries a
+// unique request ID and a W3C trace context (traceparent) header, so that
+// probe requests can be found in the backend logs and traces. Request ID is
+// a random UUID, and the trace ID in the traceparent header is the same UUID
+// in hex, without dashes.
+type RequestIDConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Header to send the request ID in. Set to an empty string to not send
+	// the request ID header.
+	RequestIdHeader *string `protobuf:"bytes,1,opt,name=request_id_header,json=requestIdHeader,def=X-Request-ID" json:"request_id_header,omitempty"`
+	// Header to send the W3C trace context in. Set to an empty string to not
+	// send the trace context.
+	TraceparentHeader *string `protobuf:"bytes,2,opt,name=traceparent_header,json=traceparentHeader,def=traceparent" json:"traceparent_header,omitempty"`
+}
+
+// Default values for RequestIDConfig fields.
+const (
+	Default_RequestIDConfig_RequestIdHeader   = string("X-Request-ID")
+	Default_RequestIDConfig_TraceparentHeader = string("traceparent")
+)
+
+func (x *RequestIDConfig) Reset() {
+	*x = RequestIDConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestIDConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestIDConfig) ProtoMessage() {}
+
+func (x *RequestIDConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestIDConfig.ProtoReflect.Descriptor instead.
+func (*RequestIDConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestIDConfig) GetRequestIdHeader() string {
+	if x != nil && x.RequestIdHeader != nil {
+		return *x.RequestIdHeader
+	}
+	return Default_RequestIDConfig_RequestIdHeader
+}
+
+func (x *RequestIDConfig) GetTraceparentHeader() string {
+	if x != nil && x.TraceparentHeader != nil {
+		return *x.TraceparentHeader
+	}
+	return Default_RequestIDConfig_TraceparentHeader
+}
+
+var File_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x64, 0x22, 0x87, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x38, 0x0a, 0x11, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x69, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x3a, 0x0c, 0x58, 0x2d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2d, 0x49, 0x44, 0x52, 0x0f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x3a, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x11, 0x74, 0x72, 0x61, 0x63, 0x65, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x42, 0x3d, 0x5a, 0x3b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_goTypes = []any{
+	(*RequestIDConfig)(nil), // 0: cloudprober.requestid.RequestIDConfig
+}
+var file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*RequestIDConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_requestid_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package requestid implements generation of the per-request IDs and W3C
+// trace context headers, for correlating probe requests with the backend
+// logs and traces.
+package requestid
+
+import (
+	"encoding/hex"
+	"strings"
+
+	configpb "github.com/cloudprober/cloudprober/internal/requestid/proto"
+	"github.com/google/uuid"
+)
+
+// Generator generates request IDs and the headers carrying them.
+type Generator struct {
+	requestIDHeader   string
+	traceparentHeader string
+}
+
+// New returns a new generator for the given config. It returns nil if config
+// is nil.
+func New(c *configpb.RequestIDConfig) *Generator {
+	if c == nil {
+		return nil
+	}
+	return &Generator{
+		requestIDHeader:   c.GetRequestIdHeader(),
+		traceparentHeader: c.GetTraceparentHeader(),
+	}
+}
+
+// traceparent returns a W3C traceparent header value for the given trace
+// ID, with a random parent (span) ID and the sampled flag set.
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+func traceparent(traceID uuid.UUID) string {
+	// Random UUIDs have version bits set, so span ID is never all zeros.
+	spanID := uuid.New()
+	return "00-" + hex.EncodeToString(traceID[:]) + "-" + hex.EncodeToString(spanID[:8]) + "-01"
+}
+
+// Headers returns a new request ID and the headers to send it in.
+func (g *Generator) Headers() (string, map[string]string) {
+	id := uuid.New()
+
+	headers := make(map[string]string, 2)
+	if g.requestIDHeader != "" {
+		headers[g.requestIDHeader] = id.String()
+	}
+	if g.traceparentHeader != "" {
+		headers[g.traceparentHeader] = traceparent(id)
+	}
+	return id.String(), headers
+}
+
+// ID returns the request ID from the headers set by Headers. get is the
+// header lookup function, e.g. http.Header.Get.
+func (g *Generator) ID(get func(string) string) string {
+	if g.requestIDHeader != "" {
+		return get(g.requestIDHeader)
+	}
+	if g.traceparentHeader != "" {
+		// Trace ID is the request ID without dashes.
+		if fields := strings.Split(get(g.traceparentHeader), "-"); len(fields) == 4 {
+			if id, err := uuid.Parse(fields[1]); err == nil {
+				return id.String()
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestid
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/requestid/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var traceparentRe = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-01$`)
+
+func TestHeaders(t *testing.T) {
+	assert.Nil(t, New(nil))
+
+	g := New(&configpb.RequestIDConfig{})
+	id, headers := g.Headers()
+	assert.Len(t, headers, 2)
+	assert.Equal(t, id, headers["X-Request-ID"])
+
+	m := traceparentRe.FindStringSubmatch(headers["traceparent"])
+	if assert.NotNil(t, m, "traceparent: %s", headers["traceparent"]) {
+		assert.Equal(t, strings.ReplaceAll(id, "-", ""), m[1])
+		assert.NotEqual(t, strings.Repeat("0", 16), m[2])
+	}
+
+	assert.Equal(t, id, g.ID(func(k string) string { return headers[k] }))
+
+	id2, _ := g.Headers()
+	assert.NotEqual(t, id, id2)
+}
+
+func TestHeadersCustomNames(t *testing.T) {
+	g := New(&configpb.RequestIDConfig{
+		RequestIdHeader:   proto.String("X-Correlation-ID"),
+		TraceparentHeader: proto.String(""),
+	})
+	id, headers := g.Headers()
+	assert.Equal(t, map[string]string{"X-Correlation-ID": id}, headers)
+	assert.Equal(t, id, g.ID(func(k string) string { return headers[k] }))
+
+	// Only traceparent.
+	g = New(&configpb.RequestIDConfig{RequestIdHeader: proto.String("")})
+	id, headers = g.Headers()
+	assert.Len(t, headers, 1)
+	assert.Equal(t, id, g.ID(func(k string) string { return headers[k] }))
+	assert.Equal(t, "", g.ID(func(k string) string { return "" }))
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sockopt implements helpers to set socket options, e.g. DSCP
+// marking, on the probe sockets.
+package sockopt
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// MaxDSCP is the maximum valid DSCP value (6 bits).
+const MaxDSCP = 63
+
+// ValidateDSCP returns an error if DSCP value is out of range or if setting
+// DSCP is not supported on this platform.
+func ValidateDSCP(dscp int) error {
+	if dscp < 0 || dscp > MaxDSCP {
+		return fmt.Errorf("invalid DSCP value: %d, should be between 0 and %d", dscp, MaxDSCP)
+	}
+	if !dscpSupported {
+		return fmt.Errorf("setting DSCP is not supported on this platform")
+	}
+	return nil
+}
+
+// tos returns the ToS (IPv4) or traffic class (IPv6) byte for the given DSCP
+// value. DSCP occupies the upper 6 bits of that byte.
+func tos(dscp int) int {
+	return dscp << 2
+}
+
+// SetDSCPFD sets DSCP marking on the given socket file descriptor. Socket
+// family is determined from the socket itself. Logger is used only for the
+// best effort operations' errors, and may be nil.
+func SetDSCPFD(fd int, dscp int, l *logger.Logger) error {
+	if err := setDSCP(fd, dscp, l); err != nil {
+		return fmt.Errorf("error setting DSCP (%d) on socket: %v", dscp, err)
+	}
+	return nil
+}
+
+func setDSCPRawConn(rc syscall.RawConn, dscp int, l *logger.Logger) error {
+	var err error
+	if cerr := rc.Control(func(fd uintptr) {
+		err = SetDSCPFD(int(fd), dscp, l)
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+// SetDSCP sets DSCP marking on the given connection, e.g. a *net.UDPConn.
+func SetDSCP(c syscall.Conn, dscp int, l *logger.Logger) error {
+	rc, err := c.SyscallConn()
+	if err != nil {
+		return err
+	}
+	return setDSCPRawConn(rc, dscp, l)
+}
+
+// DialerControl returns a function that can be used as net.Dialer's Control
+// function to set DSCP marking on the dialed connections. An error in setting
+// the socket option is returned as the dial error.
+func DialerControl(dscp int, l *logger.Logger) func(network, address string, c syscall.RawConn) error {
+	return func(network, address string, c syscall.RawConn) error {
+		return setDSCPRawConn(c, dscp, l)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sockopt
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+const dscpSupported = true
+
+// setDSCP sets the ToS byte on IPv4 sockets, and the traffic class on IPv6
+// sockets. Dual-stack IPv6 sockets (IPV6_V6ONLY disabled) may carry IPv4
+// traffic as well, for those we also set the ToS byte. That's best effort
+// though, as not all platforms support IP_TOS on IPv6 sockets; errors in
+// doing so are only logged.
+func setDSCP(fd int, dscp int, l *logger.Logger) error {
+	sa, err := syscall.Getsockname(fd)
+	if err != nil {
+		return os.NewSyscallError("getsockname", err)
+	}
+
+	if _, ok := sa.(*syscall.SockaddrInet6); ok {
+		if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_TCLASS, tos(dscp)); err != nil {
+			return os.NewSyscallError("setsockopt", err)
+		}
+		if v6only, err := syscall.GetsockoptInt(fd, syscall.IPPROTO_IPV6, syscall.IPV6_V6ONLY); err == nil && v6only == 0 {
+			if err := syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TOS, tos(dscp)); err != nil {
+				l.Debugf("sockopt: error setting IP_TOS on dual-stack IPv6 socket: %v", err)
+			}
+		}
+		return nil
+	}
+	return os.NewSyscallError("setsockopt", syscall.SetsockoptInt(fd, syscall.IPPROTO_IP, syscall.IP_TOS, tos(dscp)))
+}
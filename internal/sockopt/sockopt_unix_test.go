@@ -0,0 +1,126 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd linux netbsd openbsd solaris
+
+package sockopt
+
+import (
+	"context"
+	"net"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+func getTOS(t *testing.T, c syscall.Conn, ipVer int) int {
+	t.Helper()
+
+	rc, err := c.SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v int
+	var gerr error
+	rc.Control(func(fd uintptr) {
+		if ipVer == 6 {
+			v, gerr = syscall.GetsockoptInt(int(fd), syscall.IPPROTO_IPV6, syscall.IPV6_TCLASS)
+			return
+		}
+		v, gerr = syscall.GetsockoptInt(int(fd), syscall.IPPROTO_IP, syscall.IP_TOS)
+	})
+	if gerr != nil {
+		t.Fatal(gerr)
+	}
+	return v
+}
+
+func TestSetDSCP(t *testing.T) {
+	for _, test := range []struct {
+		network, addr string
+		ipVer         int
+	}{
+		{"udp4", "127.0.0.1:0", 4},
+		{"udp6", "[::1]:0", 6},
+	} {
+		t.Run(test.network, func(t *testing.T) {
+			addr, _ := net.ResolveUDPAddr(test.network, test.addr)
+			c, err := net.ListenUDP(test.network, addr)
+			if err != nil {
+				t.Skipf("Couldn't create %s socket: %v", test.network, err)
+			}
+			defer c.Close()
+
+			if err := SetDSCP(c, 46, nil); err != nil {
+				t.Fatalf("SetDSCP() error: %v", err)
+			}
+			if got, want := getTOS(t, c, test.ipVer), 46<<2; got != want {
+				t.Errorf("ToS=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestSetDSCPDualStack(t *testing.T) {
+	// Wildcard "udp" listener is a dual-stack IPv6 socket, where available.
+	c, err := net.ListenUDP("udp", &net.UDPAddr{})
+	if err != nil {
+		t.Fatalf("Couldn't create udp socket: %v", err)
+	}
+	defer c.Close()
+	if c.LocalAddr().(*net.UDPAddr).IP.To4() != nil {
+		t.Skip("Dual-stack sockets are not available")
+	}
+
+	if err := SetDSCP(c, 46, nil); err != nil {
+		t.Fatalf("SetDSCP() error: %v", err)
+	}
+	if got, want := getTOS(t, c, 6), 46<<2; got != want {
+		t.Errorf("Traffic class=%d, want=%d", got, want)
+	}
+	if runtime.GOOS != "linux" {
+		return
+	}
+	if got, want := getTOS(t, c, 4), 46<<2; got != want {
+		t.Errorf("ToS=%d, want=%d", got, want)
+	}
+}
+
+func TestDialerControl(t *testing.T) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	dialer := &net.Dialer{Control: DialerControl(10, nil)}
+	c, err := dialer.DialContext(context.Background(), "tcp4", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Dial error: %v", err)
+	}
+	defer c.Close()
+
+	if got, want := getTOS(t, c.(*net.TCPConn), 4), 10<<2; got != want {
+		t.Errorf("ToS=%d, want=%d", got, want)
+	}
+}
+
+func TestValidateDSCP(t *testing.T) {
+	for dscp, wantErr := range map[int]bool{0: false, 46: false, 63: false, -1: true, 64: true} {
+		if err := ValidateDSCP(dscp); (err != nil) != wantErr {
+			t.Errorf("ValidateDSCP(%d) error=%v, wantErr=%v", dscp, err, wantErr)
+		}
+	}
+}
@@ -20,6 +20,71 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TLSConfig_TLSVersion int32
+
+const (
+	TLSConfig_TLS_VERSION_UNSPECIFIED TLSConfig_TLSVersion = 0
+	TLSConfig_TLS_1_0                 TLSConfig_TLSVersion = 1
+	TLSConfig_TLS_1_1                 TLSConfig_TLSVersion = 2
+	TLSConfig_TLS_1_2                 TLSConfig_TLSVersion = 3
+	TLSConfig_TLS_1_3                 TLSConfig_TLSVersion = 4
+)
+
+// Enum value maps for TLSConfig_TLSVersion.
+var (
+	TLSConfig_TLSVersion_name = map[int32]string{
+		0: "TLS_VERSION_UNSPECIFIED",
+		1: "TLS_1_0",
+		2: "TLS_1_1",
+		3: "TLS_1_2",
+		4: "TLS_1_3",
+	}
+	TLSConfig_TLSVersion_value = map[string]int32{
+		"TLS_VERSION_UNSPECIFIED": 0,
+		"TLS_1_0":                 1,
+		"TLS_1_1":                 2,
+		"TLS_1_2":                 3,
+		"TLS_1_3":                 4,
+	}
+)
+
+func (x TLSConfig_TLSVersion) Enum() *TLSConfig_TLSVersion {
+	p := new(TLSConfig_TLSVersion)
+	*p = x
+	return p
+}
+
+func (x TLSConfig_TLSVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TLSConfig_TLSVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (TLSConfig_TLSVersion) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_enumTypes[0]
+}
+
+func (x TLSConfig_TLSVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *TLSConfig_TLSVersion) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = TLSConfig_TLSVersion(num)
+	return nil
+}
+
+// Deprecated: Use TLSConfig_TLSVersion.Descriptor instead.
+func (TLSConfig_TLSVersion) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
 type TLSConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -39,6 +104,26 @@ type TLSConfig struct {
 	// be reloaded every reload_interval_sec seconds. This is useful when
 	// certificates are generated and refreshed dynamically.
 	ReloadIntervalSec *int32 `protobuf:"varint,6,opt,name=reload_interval_sec,json=reloadIntervalSec" json:"reload_interval_sec,omitempty"`
+	// Use SPIFFE workload identity (X.509 SVID) fetched from the SPIFFE Workload
+	// API. SVID is presented as the local certificate, and peer certificates are
+	// verified against the SPIFFE trust bundles. SVIDs and bundles are rotated
+	// automatically.
+	//
+	// If the Workload API is not available at the startup, a warning is logged
+	// and the rest of the TLS config (e.g. tls_cert_file, ca_cert_file) is used
+	// until an SVID becomes available.
+	Spiffe *SPIFFEConfig `protobuf:"bytes,7,opt,name=spiffe" json:"spiffe,omitempty"`
+	// Cipher suites to offer or accept, by their IANA names, e.g.
+	// "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256". Insecure cipher suites (e.g.
+	// "TLS_RSA_WITH_RC4_128_SHA") are allowed, which is useful for verifying
+	// that servers reject them. Cipher suites apply only to TLS 1.2 and
+	// earlier; TLS 1.3 cipher suites are not configurable.
+	// If not specified, Go's default cipher suites are used.
+	CipherSuites []string `protobuf:"bytes,8,rep,name=cipher_suites,json=cipherSuites" json:"cipher_suites,omitempty"`
+	// Minimum and maximum TLS versions. Note that Go's default minimum version
+	// is TLS 1.2 for clients, and TLS 1.0 for servers.
+	MinVersion *TLSConfig_TLSVersion `protobuf:"varint,9,opt,name=min_version,json=minVersion,enum=cloudprober.tlsconfig.TLSConfig_TLSVersion" json:"min_version,omitempty"`
+	MaxVersion *TLSConfig_TLSVersion `protobuf:"varint,10,opt,name=max_version,json=maxVersion,enum=cloudprober.tlsconfig.TLSConfig_TLSVersion" json:"max_version,omitempty"`
 }
 
 func (x *TLSConfig) Reset() {
@@ -115,6 +200,118 @@ func (x *TLSConfig) GetReloadIntervalSec() int32 {
 	return 0
 }
 
+func (x *TLSConfig) GetSpiffe() *SPIFFEConfig {
+	if x != nil {
+		return x.Spiffe
+	}
+	return nil
+}
+
+func (x *TLSConfig) GetCipherSuites() []string {
+	if x != nil {
+		return x.CipherSuites
+	}
+	return nil
+}
+
+func (x *TLSConfig) GetMinVersion() TLSConfig_TLSVersion {
+	if x != nil && x.MinVersion != nil {
+		return *x.MinVersion
+	}
+	return TLSConfig_TLS_VERSION_UNSPECIFIED
+}
+
+func (x *TLSConfig) GetMaxVersion() TLSConfig_TLSVersion {
+	if x != nil && x.MaxVersion != nil {
+		return *x.MaxVersion
+	}
+	return TLSConfig_TLS_VERSION_UNSPECIFIED
+}
+
+type SPIFFEConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Workload API address, e.g. "unix:///run/spire/sockets/agent.sock". If not
+	// specified, SPIFFE_ENDPOINT_SOCKET environment variable is used.
+	WorkloadApiAddr *string `protobuf:"bytes,1,opt,name=workload_api_addr,json=workloadApiAddr" json:"workload_api_addr,omitempty"`
+	// Allowed peer SPIFFE IDs, e.g. "spiffe://example.org/frontend". If not
+	// specified, any peer from the allowed trust domain is accepted.
+	PeerId []string `protobuf:"bytes,2,rep,name=peer_id,json=peerId" json:"peer_id,omitempty"`
+	// Allowed peer trust domain, e.g. "example.org". If neither peer_id nor
+	// peer_trust_domain is specified, any peer that can be verified with the
+	// available trust bundles is accepted.
+	PeerTrustDomain *string `protobuf:"bytes,3,opt,name=peer_trust_domain,json=peerTrustDomain" json:"peer_trust_domain,omitempty"`
+	// How long to wait for the initial SVID at the startup.
+	InitialFetchTimeoutSec *int32 `protobuf:"varint,4,opt,name=initial_fetch_timeout_sec,json=initialFetchTimeoutSec,def=10" json:"initial_fetch_timeout_sec,omitempty"`
+}
+
+// Default values for SPIFFEConfig fields.
+const (
+	Default_SPIFFEConfig_InitialFetchTimeoutSec = int32(10)
+)
+
+func (x *SPIFFEConfig) Reset() {
+	*x = SPIFFEConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SPIFFEConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SPIFFEConfig) ProtoMessage() {}
+
+func (x *SPIFFEConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SPIFFEConfig.ProtoReflect.Descriptor instead.
+func (*SPIFFEConfig) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SPIFFEConfig) GetWorkloadApiAddr() string {
+	if x != nil && x.WorkloadApiAddr != nil {
+		return *x.WorkloadApiAddr
+	}
+	return ""
+}
+
+func (x *SPIFFEConfig) GetPeerId() []string {
+	if x != nil {
+		return x.PeerId
+	}
+	return nil
+}
+
+func (x *SPIFFEConfig) GetPeerTrustDomain() string {
+	if x != nil && x.PeerTrustDomain != nil {
+		return *x.PeerTrustDomain
+	}
+	return ""
+}
+
+func (x *SPIFFEConfig) GetInitialFetchTimeoutSec() int32 {
+	if x != nil && x.InitialFetchTimeoutSec != nil {
+		return *x.InitialFetchTimeoutSec
+	}
+	return Default_SPIFFEConfig_InitialFetchTimeoutSec
+}
+
 var File_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_rawDesc = []byte{
@@ -124,7 +321,7 @@ var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_prot
 	0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
 	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x6c, 0x6f, 0x75,
 	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x22, 0xfc, 0x01, 0x0a, 0x09, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x67, 0x22, 0xd9, 0x04, 0x0a, 0x09, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
 	0x20, 0x0a, 0x0c, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x43, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c,
 	0x65, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69,
@@ -140,10 +337,44 @@ var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_prot
 	0x12, 0x2e, 0x0a, 0x13, 0x72, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
 	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x72,
 	0x65, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63,
-	0x42, 0x3d, 0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x3b, 0x0a, 0x06, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74,
+	0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x73, 0x70, 0x69, 0x66, 0x66, 0x65, 0x12, 0x23, 0x0a,
+	0x0d, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74,
+	0x65, 0x73, 0x12, 0x4c, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x4c, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c,
+	0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5d,
+	0x0a, 0x0a, 0x54, 0x4c, 0x53, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17,
+	0x54, 0x4c, 0x53, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x4c, 0x53,
+	0x5f, 0x31, 0x5f, 0x30, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x4c, 0x53, 0x5f, 0x31, 0x5f,
+	0x31, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x4c, 0x53, 0x5f, 0x31, 0x5f, 0x32, 0x10, 0x03,
+	0x12, 0x0b, 0x0a, 0x07, 0x54, 0x4c, 0x53, 0x5f, 0x31, 0x5f, 0x33, 0x10, 0x04, 0x22, 0xbe, 0x01,
+	0x0a, 0x0c, 0x53, 0x50, 0x49, 0x46, 0x46, 0x45, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2a,
+	0x0a, 0x11, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x61, 0x70, 0x69, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x41, 0x70, 0x69, 0x41, 0x64, 0x64, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x75, 0x73,
+	0x74, 0x5f, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x70, 0x65, 0x65, 0x72, 0x54, 0x72, 0x75, 0x73, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12,
+	0x3d, 0x0a, 0x19, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x74, 0x63, 0x68,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x16, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x42, 0x3d,
+	0x5a, 0x3b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c,
+	0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -158,16 +389,22 @@ func file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_pro
 	return file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_goTypes = []any{
-	(*TLSConfig)(nil), // 0: cloudprober.tlsconfig.TLSConfig
+	(TLSConfig_TLSVersion)(0), // 0: cloudprober.tlsconfig.TLSConfig.TLSVersion
+	(*TLSConfig)(nil),         // 1: cloudprober.tlsconfig.TLSConfig
+	(*SPIFFEConfig)(nil),      // 2: cloudprober.tlsconfig.SPIFFEConfig
 }
 var file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: cloudprober.tlsconfig.TLSConfig.spiffe:type_name -> cloudprober.tlsconfig.SPIFFEConfig
+	0, // 1: cloudprober.tlsconfig.TLSConfig.min_version:type_name -> cloudprober.tlsconfig.TLSConfig.TLSVersion
+	0, // 2: cloudprober.tlsconfig.TLSConfig.max_version:type_name -> cloudprober.tlsconfig.TLSConfig.TLSVersion
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_init() }
@@ -188,19 +425,32 @@ func file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_pro
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SPIFFEConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_internal_tlsconfig_proto_config_proto = out.File
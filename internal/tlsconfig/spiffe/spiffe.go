@@ -0,0 +1,154 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package spiffe provides X.509 SVIDs (SPIFFE Verifiable Identity Documents) and
+trust bundles from the SPIFFE Workload API, using go-spiffe's X509Source.
+X509Source keeps a streaming connection to the Workload API and always holds
+the latest SVID and trust bundles, so rotated certificates are picked up
+automatically.
+*/
+package spiffe
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// Source wraps a go-spiffe X509Source. Unlike X509Source, it's created
+// without waiting for the Workload API, so that callers can fall back to
+// other credentials while the Workload API is unavailable.
+type Source struct {
+	addr  string
+	x509  atomic.Pointer[workloadapi.X509Source]
+	ready chan struct{}
+}
+
+var global = struct {
+	mu      sync.Mutex
+	sources map[string]*Source
+}{
+	sources: make(map[string]*Source),
+}
+
+// spiffeLogger adapts logger.Logger to the go-spiffe logger interface.
+type spiffeLogger struct {
+	l *logger.Logger
+}
+
+func (sl spiffeLogger) Debugf(format string, args ...interface{}) { sl.l.Debugf(format, args...) }
+func (sl spiffeLogger) Infof(format string, args ...interface{})  { sl.l.Infof(format, args...) }
+func (sl spiffeLogger) Warnf(format string, args ...interface{})  { sl.l.Warningf(format, args...) }
+func (sl spiffeLogger) Errorf(format string, args ...interface{}) { sl.l.Errorf(format, args...) }
+
+// NewSource returns a Source for the given Workload API address. If address
+// is empty, it's read from the SPIFFE_ENDPOINT_SOCKET environment variable.
+// Sources are shared across callers using the same address.
+func NewSource(addr string, l *logger.Logger) (*Source, error) {
+	if addr == "" {
+		addr, _ = workloadapi.GetDefaultAddress()
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("spiffe: workload API address not configured and %s is not set", workloadapi.SocketEnv)
+	}
+	if err := workloadapi.ValidateAddress(addr); err != nil {
+		return nil, fmt.Errorf("spiffe: invalid workload API address (%s): %v", addr, err)
+	}
+
+	global.mu.Lock()
+	defer global.mu.Unlock()
+
+	if s := global.sources[addr]; s != nil {
+		return s, nil
+	}
+
+	if l == nil {
+		l = logger.NewWithAttrs(slog.String("component", "spiffe"))
+	}
+	s := &Source{
+		addr:  addr,
+		ready: make(chan struct{}),
+	}
+
+	// NewX509Source blocks until the first SVID is received. Until then, the
+	// Workload API client keeps retrying with a backoff.
+	go func() {
+		x, err := workloadapi.NewX509Source(context.Background(), workloadapi.WithClientOptions(
+			workloadapi.WithAddr(addr),
+			workloadapi.WithLogger(spiffeLogger{l}),
+		))
+		if err != nil {
+			l.Errorf("spiffe: error creating X.509 source for the workload API (%s): %v", addr, err)
+			return
+		}
+		s.x509.Store(x)
+		close(s.ready)
+	}()
+
+	global.sources[addr] = s
+	return s, nil
+}
+
+// WaitUntilReady waits for the first SVID to be fetched from the Workload
+// API, for up to the given timeout.
+func (s *Source) WaitUntilReady(timeout time.Duration) error {
+	select {
+	case <-s.ready:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("spiffe: timed out waiting for SVID from the workload API (%s)", s.addr)
+	}
+}
+
+// X509Source returns the underlying X509Source, or nil if no SVID has been
+// fetched yet.
+func (s *Source) X509Source() *workloadapi.X509Source {
+	return s.x509.Load()
+}
+
+// Authorizer returns an authorizer for the peer's SPIFFE ID, based on the
+// allowed IDs and trust domain. If neither is set, all IDs are allowed.
+func Authorizer(allowedIDs []string, allowedTrustDomain string) (tlsconfig.Authorizer, error) {
+	if len(allowedIDs) > 0 {
+		var ids []spiffeid.ID
+		for _, s := range allowedIDs {
+			id, err := spiffeid.FromString(s)
+			if err != nil {
+				return nil, fmt.Errorf("spiffe: invalid peer_id (%s): %v", s, err)
+			}
+			ids = append(ids, id)
+		}
+		return tlsconfig.AuthorizeOneOf(ids...), nil
+	}
+
+	if allowedTrustDomain != "" {
+		td, err := spiffeid.TrustDomainFromString(strings.ToLower(allowedTrustDomain))
+		if err != nil {
+			return nil, fmt.Errorf("spiffe: invalid peer_trust_domain (%s): %v", allowedTrustDomain, err)
+		}
+		return tlsconfig.AuthorizeMemberOf(td), nil
+	}
+
+	return tlsconfig.AuthorizeAny(), nil
+}
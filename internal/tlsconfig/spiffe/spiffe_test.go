@@ -0,0 +1,226 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spiffe
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	spiffetls "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// svid returns an X509SVID message for the given SPIFFE ID.
+func (ca *testCA) svid(t *testing.T, id string) *workload.X509SVID {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	u, err := url.Parse(id)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		URIs:         []*url.URL{u},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return &workload.X509SVID{
+		SpiffeId:    id,
+		X509Svid:    der,
+		X509SvidKey: keyDER,
+		Bundle:      ca.cert.Raw,
+	}
+}
+
+// fakeWorkloadAPI is a fake Workload API server that sends the responses
+// from the given channel.
+type fakeWorkloadAPI struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+	respCh chan *workload.X509SVIDResponse
+}
+
+func (f *fakeWorkloadAPI) FetchX509SVID(_ *workload.X509SVIDRequest, stream workload.SpiffeWorkloadAPI_FetchX509SVIDServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	if len(md.Get("workload.spiffe.io")) == 0 {
+		return status.Error(codes.InvalidArgument, "missing security header")
+	}
+	for {
+		select {
+		case resp := <-f.respCh:
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func startWorkloadAPI(t *testing.T, respCh chan *workload.X509SVIDResponse) string {
+	t.Helper()
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+	ln, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(srv, &fakeWorkloadAPI{respCh: respCh})
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+
+	return "unix://" + sock
+}
+
+func TestNewSourceAddress(t *testing.T) {
+	t.Setenv("SPIFFE_ENDPOINT_SOCKET", "")
+	_, err := NewSource("", nil)
+	assert.ErrorContains(t, err, "not configured")
+
+	for _, addr := range []string{"unix://", "http://127.0.0.1:8081", "tcp://localhost:8081"} {
+		_, err := NewSource(addr, nil)
+		assert.ErrorContains(t, err, "invalid workload API address", addr)
+	}
+}
+
+func TestSource(t *testing.T) {
+	ca, otherCA := newTestCA(t), newTestCA(t)
+
+	respCh := make(chan *workload.X509SVIDResponse, 2)
+	addr := startWorkloadAPI(t, respCh)
+
+	src, err := NewSource(addr, nil)
+	require.NoError(t, err)
+	assert.Error(t, src.WaitUntilReady(10*time.Millisecond), "SVID before any response")
+	assert.Nil(t, src.X509Source())
+
+	svid1 := ca.svid(t, "spiffe://example.org/prober")
+	respCh <- &workload.X509SVIDResponse{
+		Svids: []*workload.X509SVID{svid1},
+		FederatedBundles: map[string][]byte{
+			"spiffe://other.org": otherCA.cert.Raw,
+		},
+	}
+	require.NoError(t, src.WaitUntilReady(5*time.Second))
+	x509Src := src.X509Source()
+	require.NotNil(t, x509Src)
+	svid, err := x509Src.GetX509SVID()
+	require.NoError(t, err)
+	assert.Equal(t, svid1.GetX509Svid(), svid.Certificates[0].Raw)
+
+	// Same address returns the same source.
+	src2, err := NewSource(addr, nil)
+	require.NoError(t, err)
+	assert.Same(t, src, src2)
+
+	// Verification of peers from the local and the federated trust domains.
+	verify := spiffetls.VerifyPeerCertificate(x509Src, spiffetls.AuthorizeAny())
+	for _, test := range []struct {
+		ca      *testCA
+		id      string
+		wantErr bool
+	}{
+		{ca: ca, id: "spiffe://example.org/web"},
+		{ca: otherCA, id: "spiffe://other.org/web"},
+		{ca: otherCA, id: "spiffe://example.org/web", wantErr: true}, // wrong CA.
+		{ca: ca, id: "spiffe://unknown.org/web", wantErr: true},      // no bundle.
+	} {
+		err := verify([][]byte{test.ca.svid(t, test.id).GetX509Svid()}, nil)
+		assert.Equal(t, test.wantErr, err != nil, "%s, error: %v", test.id, err)
+	}
+
+	// Rotation.
+	svid2 := ca.svid(t, "spiffe://example.org/prober")
+	respCh <- &workload.X509SVIDResponse{Svids: []*workload.X509SVID{svid2}}
+	assert.Eventually(t, func() bool {
+		svid, err := x509Src.GetX509SVID()
+		return err == nil && string(svid.Certificates[0].Raw) == string(svid2.GetX509Svid())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAuthorizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       []string
+		td        string
+		peer      string
+		wantError bool
+		wantInit  bool
+	}{
+		{name: "any", peer: "spiffe://example.org/web"},
+		{name: "id_match", ids: []string{"spiffe://example.org/web"}, peer: "spiffe://example.org/web"},
+		{name: "id_mismatch", ids: []string{"spiffe://example.org/web"}, peer: "spiffe://example.org/db", wantError: true},
+		{name: "td_match", td: "Example.org", peer: "spiffe://example.org/db"},
+		{name: "td_mismatch", td: "example.org", peer: "spiffe://other.org/db", wantError: true},
+		{name: "invalid_id", ids: []string{"https://example.org/web"}, wantInit: true},
+		{name: "invalid_td", td: "example org", wantInit: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authorize, err := Authorizer(test.ids, test.td)
+			if test.wantInit {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			err = authorize(spiffeid.RequireFromString(test.peer), nil)
+			assert.Equal(t, test.wantError, err != nil, "error: %v", err)
+		})
+	}
+}
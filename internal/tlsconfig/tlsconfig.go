@@ -20,11 +20,15 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/cloudprober/cloudprober/internal/file"
 	configpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig/spiffe"
+	"github.com/cloudprober/cloudprober/logger"
+	spiffetls "github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
 )
 
 type cacheEntry struct {
@@ -54,6 +58,56 @@ func loadCert(certFile, keyFile string) (*tls.Certificate, error) {
 	return &cert, err
 }
 
+var tlsVersions = map[configpb.TLSConfig_TLSVersion]uint16{
+	configpb.TLSConfig_TLS_1_0: tls.VersionTLS10,
+	configpb.TLSConfig_TLS_1_1: tls.VersionTLS11,
+	configpb.TLSConfig_TLS_1_2: tls.VersionTLS12,
+	configpb.TLSConfig_TLS_1_3: tls.VersionTLS13,
+}
+
+// cipherSuiteIDs converts cipher suite names to IDs. Both secure and insecure
+// cipher suites are accepted.
+func cipherSuiteIDs(names []string) ([]uint16, error) {
+	suites := make(map[string]*tls.CipherSuite)
+	for _, cs := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites[cs.Name] = cs
+	}
+
+	var ids []uint16
+	for _, name := range names {
+		cs := suites[name]
+		if cs == nil {
+			return nil, fmt.Errorf("unknown cipher suite: %s", name)
+		}
+		if len(cs.SupportedVersions) == 1 && cs.SupportedVersions[0] == tls.VersionTLS13 {
+			return nil, fmt.Errorf("TLS 1.3 cipher suites are not configurable: %s", name)
+		}
+		ids = append(ids, cs.ID)
+	}
+	return ids, nil
+}
+
+func updateTLSParams(tlsConfig *tls.Config, c *configpb.TLSConfig) error {
+	if len(c.GetCipherSuites()) > 0 {
+		ids, err := cipherSuiteIDs(c.GetCipherSuites())
+		if err != nil {
+			return fmt.Errorf("common/tlsconfig: %v", err)
+		}
+		tlsConfig.CipherSuites = ids
+	}
+
+	if c.MinVersion != nil {
+		tlsConfig.MinVersion = tlsVersions[c.GetMinVersion()]
+	}
+	if c.MaxVersion != nil {
+		tlsConfig.MaxVersion = tlsVersions[c.GetMaxVersion()]
+	}
+	if tlsConfig.MinVersion != 0 && tlsConfig.MaxVersion != 0 && tlsConfig.MinVersion > tlsConfig.MaxVersion {
+		return fmt.Errorf("common/tlsconfig: min_version (%s) is greater than max_version (%s)", c.GetMinVersion(), c.GetMaxVersion())
+	}
+	return nil
+}
+
 // UpdateTLSConfig parses the provided protobuf and updates the tls.Config object.
 func UpdateTLSConfig(tlsConfig *tls.Config, c *configpb.TLSConfig) error {
 	if c.GetDisableCertValidation() {
@@ -125,5 +179,97 @@ func UpdateTLSConfig(tlsConfig *tls.Config, c *configpb.TLSConfig) error {
 		tlsConfig.ServerName = c.GetServerName()
 	}
 
+	if err := updateTLSParams(tlsConfig, c); err != nil {
+		return err
+	}
+
+	if c.GetSpiffe() != nil {
+		return configureSPIFFE(tlsConfig, c.GetSpiffe())
+	}
+
+	return nil
+}
+
+// configureSPIFFE makes tlsConfig use the SVID and trust bundles from the
+// SPIFFE Workload API, falling back to the static configuration while an SVID
+// is not available. Certificates, peer verification and authorization are
+// provided by go-spiffe; we don't use its Hook*Config functions as they don't
+// allow such a fallback.
+func configureSPIFFE(tlsConfig *tls.Config, c *configpb.SPIFFEConfig) error {
+	l := logger.NewWithAttrs(slog.String("component", "tlsconfig"))
+
+	authorizer, err := spiffe.Authorizer(c.GetPeerId(), c.GetPeerTrustDomain())
+	if err != nil {
+		return err
+	}
+
+	src, err := spiffe.NewSource(c.GetWorkloadApiAddr(), l)
+	if err != nil {
+		return err
+	}
+	if err := src.WaitUntilReady(time.Duration(c.GetInitialFetchTimeoutSec()) * time.Second); err != nil {
+		l.Warningf("%v, falling back to the static TLS config until SVID is available", err)
+	}
+
+	staticCert := func() (*tls.Certificate, error) {
+		if len(tlsConfig.Certificates) > 0 {
+			return &tlsConfig.Certificates[0], nil
+		}
+		return &tls.Certificate{}, nil
+	}
+	if tlsConfig.GetClientCertificate != nil {
+		getCert := tlsConfig.GetClientCertificate
+		staticCert = func() (*tls.Certificate, error) { return getCert(nil) }
+	}
+	tlsConfig.GetClientCertificate = func(cri *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		if x509Src := src.X509Source(); x509Src != nil {
+			return spiffetls.GetClientCertificate(x509Src)(cri)
+		}
+		return staticCert()
+	}
+	tlsConfig.GetCertificate = func(chi *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		if x509Src := src.X509Source(); x509Src != nil {
+			return spiffetls.GetCertificate(x509Src)(chi)
+		}
+		return staticCert()
+	}
+
+	// SPIFFE IDs are not host names, so like go-spiffe's client configs, we
+	// skip the standard verification and verify peers in a callback.
+	// Fallback verification uses the standard checks.
+	skipVerify := tlsConfig.InsecureSkipVerify
+	tlsConfig.InsecureSkipVerify = true
+	tlsConfig.VerifyConnection = func(cs tls.ConnectionState) error {
+		if skipVerify || len(cs.PeerCertificates) == 0 {
+			return nil
+		}
+		x509Src := src.X509Source()
+		if x509Src == nil {
+			return verifyStatic(tlsConfig, cs)
+		}
+		var rawCerts [][]byte
+		for _, cert := range cs.PeerCertificates {
+			rawCerts = append(rawCerts, cert.Raw)
+		}
+		if err := spiffetls.VerifyPeerCertificate(x509Src, authorizer)(rawCerts, nil); err != nil {
+			return fmt.Errorf("spiffe: peer verification failed: %v", err)
+		}
+		return nil
+	}
 	return nil
 }
+
+// verifyStatic does the standard certificate verification that is skipped
+// when InsecureSkipVerify is set.
+func verifyStatic(tlsConfig *tls.Config, cs tls.ConnectionState) error {
+	intermediates := x509.NewCertPool()
+	for _, c := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         tlsConfig.RootCAs,
+		Intermediates: intermediates,
+		DNSName:       cs.ServerName,
+	})
+	return err
+}
@@ -17,6 +17,7 @@ package tlsconfig
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -168,3 +169,113 @@ func TestUpdateTLSConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateTLSConfigSPIFFEFallback(t *testing.T) {
+	tempDir := t.TempDir()
+	testCert, testKey := tempDir+"/cert", tempDir+"/key"
+	assert.NoError(t, os.WriteFile(testCert, []byte(cert1PEM), 0644))
+	assert.NoError(t, os.WriteFile(testKey, []byte(cert1Key), 0644))
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	for _, disableCertValidation := range []bool{true, false} {
+		t.Run(fmt.Sprintf("disable_cert_validation=%v", disableCertValidation), func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			assert.NoError(t, UpdateTLSConfig(tlsConfig, &configpb.TLSConfig{
+				TlsCertFile:           &testCert,
+				TlsKeyFile:            &testKey,
+				DisableCertValidation: proto.Bool(disableCertValidation),
+				Spiffe: &configpb.SPIFFEConfig{
+					// Workload API is not available.
+					WorkloadApiAddr:        proto.String("unix://" + tempDir + "/agent.sock"),
+					InitialFetchTimeoutSec: proto.Int32(0),
+				},
+			}))
+
+			// Static certificate is used until an SVID is available.
+			cert, err := tlsConfig.GetClientCertificate(nil)
+			assert.NoError(t, err)
+			parseAndVerifyCert(t, *cert, "cert1.cloudprober.org")
+
+			client := http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: tlsConfig,
+				},
+			}
+			res, err := client.Get(ts.URL)
+			if !disableCertValidation {
+				// Fallback verification uses the standard checks, and test
+				// server's certificate is not trusted.
+				assert.ErrorContains(t, err, "certificate signed by unknown authority")
+				return
+			}
+			assert.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+		})
+	}
+}
+
+func TestUpdateTLSConfigParams(t *testing.T) {
+	tests := []struct {
+		name             string
+		c                *configpb.TLSConfig
+		wantSuites       []uint16
+		wantMin, wantMax uint16
+		wantErr          bool
+	}{
+		{
+			name: "default",
+			c:    &configpb.TLSConfig{},
+		},
+		{
+			name: "weak-ciphers-old-versions",
+			c: &configpb.TLSConfig{
+				CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				MinVersion:   configpb.TLSConfig_TLS_1_0.Enum(),
+				MaxVersion:   configpb.TLSConfig_TLS_1_1.Enum(),
+			},
+			wantSuites: []uint16{tls.TLS_RSA_WITH_RC4_128_SHA, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+			wantMin:    tls.VersionTLS10,
+			wantMax:    tls.VersionTLS11,
+		},
+		{
+			name:    "unknown-cipher",
+			c:       &configpb.TLSConfig{CipherSuites: []string{"TLS_FOO"}},
+			wantErr: true,
+		},
+		{
+			name:    "tls13-cipher",
+			c:       &configpb.TLSConfig{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}},
+			wantErr: true,
+		},
+		{
+			name: "min-greater-than-max",
+			c: &configpb.TLSConfig{
+				MinVersion: configpb.TLSConfig_TLS_1_3.Enum(),
+				MaxVersion: configpb.TLSConfig_TLS_1_2.Enum(),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tlsConfig := &tls.Config{}
+			err := UpdateTLSConfig(tlsConfig, test.c)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.wantSuites, tlsConfig.CipherSuites)
+			assert.Equal(t, test.wantMin, tlsConfig.MinVersion)
+			assert.Equal(t, test.wantMax, tlsConfig.MaxVersion)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/unreachable/proto/config.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExpectUnreachable inverts the probe's success semantics: probe succeeds if
+// the target can NOT be reached, e.g. to verify that a firewall blocks the
+// traffic. Observed outcome of each run is exported in the
+// "unreachable_outcome" metric, by "outcome" label:
+//
+//	refused:  connection was refused (TCP RST in response to SYN).
+//	reset:    connection was reset after being established.
+//	filtered: connection timed out, or an ICMP unreachable was received.
+//	open:     target was reachable.
+//	error:    any other error, e.g. DNS resolution failure.
+//
+// Runs with "open" and "error" outcomes always fail.
+type ExpectUnreachable struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether "refused" and "reset" outcomes count as success.
+	AllowRefused *bool `protobuf:"varint,1,opt,name=allow_refused,json=allowRefused,def=1" json:"allow_refused,omitempty"`
+	// Whether "filtered" outcome counts as success.
+	AllowFiltered *bool `protobuf:"varint,2,opt,name=allow_filtered,json=allowFiltered,def=1" json:"allow_filtered,omitempty"`
+}
+
+// Default values for ExpectUnreachable fields.
+const (
+	Default_ExpectUnreachable_AllowRefused  = bool(true)
+	Default_ExpectUnreachable_AllowFiltered = bool(true)
+)
+
+func (x *ExpectUnreachable) Reset() {
+	*x = ExpectUnreachable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExpectUnreachable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpectUnreachable) ProtoMessage() {}
+
+func (x *ExpectUnreachable) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpectUnreachable.ProtoReflect.Descriptor instead.
+func (*ExpectUnreachable) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExpectUnreachable) GetAllowRefused() bool {
+	if x != nil && x.AllowRefused != nil {
+		return *x.AllowRefused
+	}
+	return Default_ExpectUnreachable_AllowRefused
+}
+
+func (x *ExpectUnreachable) GetAllowFiltered() bool {
+	if x != nil && x.AllowFiltered != nil {
+		return *x.AllowFiltered
+	}
+	return Default_ExpectUnreachable_AllowFiltered
+}
+
+var File_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x75, 0x6e,
+	0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x6b, 0x0a, 0x11, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x55,
+	0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x0d, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65,
+	0x66, 0x75, 0x73, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74,
+	0x72, 0x75, 0x65, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x42, 0x3f, 0x5a, 0x3d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_goTypes = []any{
+	(*ExpectUnreachable)(nil), // 0: cloudprober.unreachable.ExpectUnreachable
+}
+var file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ExpectUnreachable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_unreachable_proto_config_proto_depIdxs = nil
+}
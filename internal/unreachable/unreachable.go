@@ -0,0 +1,103 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package unreachable implements the "expect unreachable" mode of the
+// probes, where a target being reachable is a failure.
+package unreachable
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+
+	configpb "github.com/cloudprober/cloudprober/internal/unreachable/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/options"
+)
+
+// Outcomes of a probe run, exported as values of the outcome label.
+const (
+	OutcomeRefused  = "refused"
+	OutcomeReset    = "reset"
+	OutcomeFiltered = "filtered"
+	OutcomeOpen     = "open"
+	OutcomeError    = "error"
+)
+
+// OutcomeLabel is the label used to break down the outcome metric.
+const OutcomeLabel = "outcome"
+
+var outcomes = []string{OutcomeRefused, OutcomeReset, OutcomeFiltered, OutcomeOpen, OutcomeError}
+
+// Checker decides the outcome of the probe runs.
+type Checker struct {
+	allowed map[string]bool
+}
+
+// New returns a new Checker for the given config. It returns nil if config
+// is nil.
+func New(c *configpb.ExpectUnreachable) (*Checker, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if !c.GetAllowRefused() && !c.GetAllowFiltered() {
+		return nil, fmt.Errorf("expect_unreachable: at least one of allow_refused and allow_filtered should be set")
+	}
+	return &Checker{
+		allowed: map[string]bool{
+			OutcomeRefused:  c.GetAllowRefused(),
+			OutcomeReset:    c.GetAllowRefused(),
+			OutcomeFiltered: c.GetAllowFiltered(),
+		},
+	}, nil
+}
+
+// Classify maps the error returned while connecting to the target to an
+// outcome. A nil error means that the target was reachable.
+func Classify(err error) string {
+	if err == nil {
+		return OutcomeOpen
+	}
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return OutcomeRefused
+	case errors.Is(err, syscall.ECONNRESET):
+		return OutcomeReset
+	case errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return OutcomeFiltered
+	}
+
+	if options.ClassifyError(err) == options.FailureTimeout {
+		return OutcomeFiltered
+	}
+	return OutcomeError
+}
+
+// Check returns the outcome for the given error, and whether that outcome
+// counts as success.
+func (c *Checker) Check(err error) (string, bool) {
+	outcome := Classify(err)
+	return outcome, c.allowed[outcome]
+}
+
+// NewOutcomes returns a new map to count the runs by outcome, with all
+// outcomes initialized to zero.
+func NewOutcomes() *metrics.Map[int64] {
+	m := metrics.NewMap(OutcomeLabel)
+	for _, outcome := range outcomes {
+		m.IncKeyBy(outcome, 0)
+	}
+	return m
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unreachable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/unreachable/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func dialErr(err error) error {
+	return &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", err)}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: OutcomeOpen},
+		{err: dialErr(syscall.ECONNREFUSED), want: OutcomeRefused},
+		{err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: OutcomeReset},
+		{err: dialErr(syscall.EHOSTUNREACH), want: OutcomeFiltered},
+		{err: context.DeadlineExceeded, want: OutcomeFiltered},
+		{err: &net.DNSError{Err: "no such host", Name: "test.local"}, want: OutcomeError},
+		{err: errors.New("unknown"), want: OutcomeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want+"_"+fmt.Sprint(test.err), func(t *testing.T) {
+			assert.Equal(t, test.want, Classify(test.err))
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c, err := New(nil)
+	require.NoError(t, err)
+	assert.Nil(t, c)
+
+	_, err = New(&configpb.ExpectUnreachable{AllowRefused: proto.Bool(false), AllowFiltered: proto.Bool(false)})
+	assert.Error(t, err)
+
+	tests := []struct {
+		name         string
+		conf         *configpb.ExpectUnreachable
+		wantAllowed  []string
+		wantRejected []string
+	}{
+		{
+			name:         "default",
+			conf:         &configpb.ExpectUnreachable{},
+			wantAllowed:  []string{OutcomeRefused, OutcomeReset, OutcomeFiltered},
+			wantRejected: []string{OutcomeOpen, OutcomeError},
+		},
+		{
+			name:         "filtered_only",
+			conf:         &configpb.ExpectUnreachable{AllowRefused: proto.Bool(false)},
+			wantAllowed:  []string{OutcomeFiltered},
+			wantRejected: []string{OutcomeRefused, OutcomeReset, OutcomeOpen, OutcomeError},
+		},
+	}
+
+	errs := map[string]error{
+		OutcomeRefused:  dialErr(syscall.ECONNREFUSED),
+		OutcomeReset:    syscall.ECONNRESET,
+		OutcomeFiltered: context.DeadlineExceeded,
+		OutcomeOpen:     nil,
+		OutcomeError:    errors.New("unknown"),
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := New(test.conf)
+			require.NoError(t, err)
+			for _, outcome := range test.wantAllowed {
+				got, ok := c.Check(errs[outcome])
+				assert.Equal(t, outcome, got)
+				assert.True(t, ok, "outcome: %s", outcome)
+			}
+			for _, outcome := range test.wantRejected {
+				got, ok := c.Check(errs[outcome])
+				assert.Equal(t, outcome, got)
+				assert.False(t, ok, "outcome: %s", outcome)
+			}
+		})
+	}
+
+	assert.Equal(t, "map:outcome,error:0,filtered:0,open:0,refused:0,reset:0", NewOutcomes().String())
+}
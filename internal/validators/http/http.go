@@ -112,6 +112,19 @@ func lookupStatusCode(statusCode int, statusCodeRanges []*numRange) bool {
 	return false
 }
 
+// StatusCodeMatcher parses the given status code config (see
+// parseStatusCodeConfig for the format) and returns a function that reports
+// whether a status code matches it.
+func StatusCodeMatcher(s string) (func(statusCode int) bool, error) {
+	ranges, err := parseStatusCodeConfig(s)
+	if err != nil {
+		return nil, err
+	}
+	return func(statusCode int) bool {
+		return lookupStatusCode(statusCode, ranges)
+	}, nil
+}
+
 // lookupHTTPHeader looks up for the given header in the HTTP response. It
 // returns true on the first match. If valueRegex is omitted - check for header
 // existence only.
@@ -0,0 +1,159 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonschema provides a JSON schema validator for the Cloudprober's
+// validator framework.
+package jsonschema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	configpb "github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+// schemaURL is the URL under which the loaded schema document is registered
+// with the compiler. References are resolved relative to it.
+const schemaURL = "mem:///schema.json"
+
+// Validator implements a JSON schema validator.
+type Validator struct {
+	schema *jsonschema.Schema
+	l      *logger.Logger
+}
+
+// isOpenAPI30 returns true if the document is an OpenAPI 3.0.x document.
+// Schemas in these documents are based on JSON Schema draft 4.
+func isOpenAPI30(doc interface{}) bool {
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	v, _ := m["openapi"].(string)
+	return strings.HasPrefix(v, "3.0")
+}
+
+// convertNullable converts OpenAPI 3.0's "nullable: true" into the
+// equivalent JSON schema type, i.e. it adds "null" to the type.
+func convertNullable(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if nullable, _ := v["nullable"].(bool); nullable {
+			switch t := v["type"].(type) {
+			case string:
+				v["type"] = []interface{}{t, "null"}
+			case []interface{}:
+				v["type"] = append(t, "null")
+			}
+		}
+		for _, vv := range v {
+			convertNullable(vv)
+		}
+	case []interface{}:
+		for _, vv := range v {
+			convertNullable(vv)
+		}
+	}
+}
+
+// Init initializes the JSON schema validator. It loads and compiles the
+// schema, and returns an error if schema can't be loaded or is invalid.
+func (v *Validator) Init(config interface{}, l *logger.Logger) error {
+	cfg, ok := config.(*configpb.Validator)
+	if !ok {
+		return fmt.Errorf("%v is not a valid json schema validator config", config)
+	}
+
+	var b []byte
+	switch cfg.GetSchemaSource().(type) {
+	case *configpb.Validator_Schema:
+		b = []byte(cfg.GetSchema())
+	case *configpb.Validator_SchemaFile:
+		var err error
+		if b, err = file.ReadFile(context.Background(), cfg.GetSchemaFile()); err != nil {
+			return fmt.Errorf("error reading the schema file (%s): %v", cfg.GetSchemaFile(), err)
+		}
+	default:
+		return fmt.Errorf("one of schema or schema_file is required")
+	}
+
+	// JSON is valid YAML, so we can always convert from YAML.
+	jsonb, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return fmt.Errorf("error parsing the schema: %v", err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(jsonb, &doc); err != nil {
+		return fmt.Errorf("error parsing the schema: %v", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("only local references are supported, can't load %s", s)
+	}
+	if isOpenAPI30(doc) {
+		compiler.Draft = jsonschema.Draft4
+		convertNullable(doc)
+		if jsonb, err = json.Marshal(doc); err != nil {
+			return fmt.Errorf("error parsing the schema: %v", err)
+		}
+	}
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(jsonb)); err != nil {
+		return fmt.Errorf("invalid schema: %v", err)
+	}
+
+	ref := cfg.GetSchemaRef()
+	if ref != "" && !strings.HasPrefix(ref, "#") {
+		return fmt.Errorf("invalid schema_ref (%s), it should be a JSON pointer starting with #", ref)
+	}
+	if v.schema, err = compiler.Compile(schemaURL + ref); err != nil {
+		return fmt.Errorf("invalid schema: %v", err)
+	}
+
+	v.l = l
+
+	return nil
+}
+
+// Validate the provided responseBody. It returns true if responseBody is a
+// valid JSON that conforms to the schema. Otherwise, it logs the reason,
+// e.g. first schema violation, and returns false.
+func (v *Validator) Validate(responseBody []byte) (bool, error) {
+	dec := json.NewDecoder(bytes.NewReader(responseBody))
+	dec.UseNumber()
+	var input interface{}
+	err := dec.Decode(&input)
+	if err == nil && dec.More() {
+		err = fmt.Errorf("unexpected data after the JSON value")
+	}
+	if err != nil {
+		v.l.Warningf("JSON schema validation failure: response %s is not a valid JSON: %v", string(responseBody), err)
+		return false, nil
+	}
+
+	if err := v.schema.Validate(input); err != nil {
+		v.l.Warningf("JSON schema validation failure: %v", err)
+		return false, nil
+	}
+	return true, nil
+}
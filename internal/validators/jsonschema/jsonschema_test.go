@@ -0,0 +1,233 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOpenAPI = `
+openapi: 3.0.0
+info:
+  title: Pet store
+  version: "1.0"
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      additionalProperties: false
+      properties:
+        id:
+          type: integer
+          minimum: 1
+        name:
+          type: string
+          minLength: 1
+          maxLength: 16
+        tag:
+          type: string
+          nullable: true
+          pattern: "^[a-z]+$"
+        status:
+          enum: [available, sold]
+        weight:
+          type: number
+          minimum: 0
+          exclusiveMinimum: true
+        owner:
+          $ref: "#/components/schemas/Owner"
+    Owner:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        pets:
+          type: array
+          items:
+            $ref: "#/components/schemas/Pet"
+    Pets:
+      type: array
+      minItems: 1
+      uniqueItems: true
+      items:
+        $ref: "#/components/schemas/Pet"
+`
+
+func TestValidate(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(testOpenAPI), 0644))
+
+	tests := []struct {
+		name      string
+		ref       string
+		input     string
+		wantFalse bool
+	}{
+		{name: "valid", ref: "Pet", input: `{"id": 1, "name": "rex", "tag": "dog", "status": "sold", "weight": 10.5}`},
+		{name: "null_tag", ref: "Pet", input: `{"id": 1, "name": "rex", "tag": null}`},
+		{name: "recursive", ref: "Pet", input: `{"id": 1, "name": "rex", "owner": {"name": "bob", "pets": [{"id": 2, "name": "fido"}]}}`},
+		{name: "recursive_invalid", ref: "Pet", input: `{"id": 1, "name": "rex", "owner": {"name": "bob", "pets": [{"id": 2}]}}`, wantFalse: true},
+		{name: "not_json", ref: "Pet", input: `{"id": 1,`, wantFalse: true},
+		{name: "trailing_data", ref: "Pet", input: `{"id": 1, "name": "rex"} {}`, wantFalse: true},
+		{name: "missing_required", ref: "Pet", input: `{"id": 1}`, wantFalse: true},
+		{name: "wrong_type", ref: "Pet", input: `{"id": "1", "name": "rex"}`, wantFalse: true},
+		{name: "not_integer", ref: "Pet", input: `{"id": 1.5, "name": "rex"}`, wantFalse: true},
+		{name: "below_minimum", ref: "Pet", input: `{"id": 0, "name": "rex"}`, wantFalse: true},
+		{name: "exclusive_minimum", ref: "Pet", input: `{"id": 1, "name": "rex", "weight": 0}`, wantFalse: true},
+		{name: "too_long", ref: "Pet", input: `{"id": 1, "name": "rex-the-very-good-dog"}`, wantFalse: true},
+		{name: "pattern", ref: "Pet", input: `{"id": 1, "name": "rex", "tag": "Dog"}`, wantFalse: true},
+		{name: "enum", ref: "Pet", input: `{"id": 1, "name": "rex", "status": "lost"}`, wantFalse: true},
+		{name: "additional_property", ref: "Pet", input: `{"id": 1, "name": "rex", "color": "brown"}`, wantFalse: true},
+		{name: "array", ref: "Pets", input: `[{"id": 1, "name": "rex"}, {"id": 2, "name": "fido"}]`},
+		{name: "empty_array", ref: "Pets", input: `[]`, wantFalse: true},
+		{name: "duplicate_items", ref: "Pets", input: `[{"id": 1, "name": "rex"}, {"id": 1, "name": "rex"}]`, wantFalse: true},
+		{name: "invalid_item", ref: "Pets", input: `[{"id": 1, "name": "rex"}, {"id": 2}]`, wantFalse: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &Validator{}
+			require.NoError(t, v.Init(&configpb.Validator{
+				SchemaSource: &configpb.Validator_SchemaFile{SchemaFile: schemaFile},
+				SchemaRef:    "#/components/schemas/" + test.ref,
+			}, &logger.Logger{}))
+
+			ok, err := v.Validate([]byte(test.input))
+			assert.NoError(t, err)
+			assert.Equal(t, !test.wantFalse, ok)
+		})
+	}
+}
+
+func TestSchemaViolation(t *testing.T) {
+	tests := []struct {
+		schema  string
+		input   string
+		wantErr string
+	}{
+		{
+			schema:  `{"type": "object", "properties": {"results": {"type": "array", "items": {"type": "object", "required": ["id"]}}}}`,
+			input:   `{"results": [{"id": "a"}, {"name": "b"}]}`,
+			wantErr: `'/results/1' does not validate with mem:///schema.json#/properties/results/items/required: missing properties: 'id'`,
+		},
+		{
+			schema:  `{"properties": {"a-b": {"type": ["string", "null"]}}}`,
+			input:   `{"a-b": 1}`,
+			wantErr: `expected string or null, but got number`,
+		},
+		{
+			schema:  `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`,
+			input:   `1`,
+			wantErr: "valid against schemas at indexes 0 and 1",
+		},
+		{
+			schema:  `{"multipleOf": 0.1, "exclusiveMaximum": 1}`,
+			input:   `0.35`,
+			wantErr: "not multipleOf 0.1",
+		},
+		{
+			schema: `{"multipleOf": 0.1, "exclusiveMaximum": 1}`,
+			input:  `0.3`,
+		},
+		{
+			schema:  `{"properties": {"email": {"format": "email"}}}`,
+			input:   `{"email": "not-an-email"}`,
+			wantErr: "is not valid 'email'",
+		},
+		{
+			schema:  `{"patternProperties": {"^x-": {"type": "integer"}}}`,
+			input:   `{"x-count": "1"}`,
+			wantErr: "expected integer, but got string",
+		},
+		{
+			schema:  `{"prefixItems": [{"type": "string"}, {"type": "integer"}]}`,
+			input:   `["a", "b"]`,
+			wantErr: "expected integer, but got string",
+		},
+		{
+			schema:  `{"dependentRequired": {"card": ["billing_address"]}}`,
+			input:   `{"card": "1234"}`,
+			wantErr: "property 'billing_address' is required, if 'card' property exists",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			v := &Validator{}
+			require.NoError(t, v.Init(&configpb.Validator{
+				SchemaSource: &configpb.Validator_Schema{Schema: test.schema},
+			}, &logger.Logger{}))
+
+			dec := json.NewDecoder(strings.NewReader(test.input))
+			dec.UseNumber()
+			var input interface{}
+			require.NoError(t, dec.Decode(&input))
+			err := v.schema.Validate(input)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	schema := func(s string) *configpb.Validator {
+		return &configpb.Validator{SchemaSource: &configpb.Validator_Schema{Schema: s}}
+	}
+	tests := []struct {
+		name    string
+		c       *configpb.Validator
+		wantErr string
+	}{
+		{name: "no_schema", c: &configpb.Validator{}, wantErr: "schema_file is required"},
+		{name: "missing_file", c: &configpb.Validator{SchemaSource: &configpb.Validator_SchemaFile{SchemaFile: "/nonexistent/schema.json"}}, wantErr: "error reading"},
+		{name: "bad_yaml", c: schema("{type: [}"), wantErr: "error parsing"},
+		{name: "not_object", c: schema("1"), wantErr: "invalid schema"},
+		{name: "bad_type", c: schema(`{"type": "str"}`), wantErr: "invalid schema"},
+		{name: "bad_pattern", c: schema(`{"pattern": "(a"}`), wantErr: "invalid schema"},
+		{name: "bad_min_length", c: schema(`{"minLength": -1}`), wantErr: "invalid schema"},
+		{name: "bad_multiple_of", c: schema(`{"multipleOf": 0}`), wantErr: "invalid schema"},
+		{name: "bad_exclusive_minimum", c: schema(`{"exclusiveMinimum": true}`), wantErr: "invalid schema"},
+		{name: "empty_any_of", c: schema(`{"anyOf": []}`), wantErr: "invalid schema"},
+		{name: "unknown_draft", c: schema(`{"$schema": "http://example.com/my-draft"}`), wantErr: "invalid schema"},
+		{name: "ref_not_found", c: schema(`{"$ref": "#/definitions/a"}`), wantErr: "invalid schema"},
+		{name: "remote_ref", c: schema(`{"$ref": "other.json#/a"}`), wantErr: "only local references"},
+		{name: "self_ref", c: schema(`{"$ref": "#"}`), wantErr: "infinite loop"},
+		{name: "self_ref_defs", c: &configpb.Validator{SchemaSource: &configpb.Validator_Schema{Schema: `{"$defs": {"a": {"$ref": "#/$defs/a"}}}`}, SchemaRef: "#/$defs/a"}, wantErr: "infinite loop"},
+		{name: "schema_ref_not_found", c: &configpb.Validator{SchemaSource: &configpb.Validator_Schema{Schema: testOpenAPI}, SchemaRef: "#/components/schemas/Cat"}, wantErr: "invalid schema"},
+		{name: "schema_ref_not_pointer", c: &configpb.Validator{SchemaSource: &configpb.Validator_Schema{Schema: testOpenAPI}, SchemaRef: "components/schemas/Pet"}, wantErr: "invalid schema_ref"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v := &Validator{}
+			assert.ErrorContains(t, v.Init(test.c, &logger.Logger{}), test.wantErr)
+		})
+	}
+}
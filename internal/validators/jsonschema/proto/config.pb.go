@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/internal/validators/jsonschema/proto/config.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// JSON schema validator configuration. Validator passes if the probe output,
+// e.g. HTTP API response, is a valid JSON that conforms to the schema. On
+// failure, first schema violation is logged.
+//
+// JSON Schema drafts 4, 6, 7, 2019-09 and 2020-12 are supported. Draft is
+// determined from the "$schema" keyword, defaulting to 2020-12. Schemas in
+// OpenAPI 3.0 documents are treated as draft 4, with support for OpenAPI's
+// "nullable". Formats (e.g. "email", "date-time") are validated. Only local
+// references (e.g. "#/components/schemas/Pet") are supported. Schema is
+// validated against its draft's meta-schema; an invalid schema, or a schema
+// with reference loops, fails the validator initialization.
+//
+// Example:
+//
+//	json_schema_validator {
+//	  schema_file: "/etc/cloudprober/openapi.yaml"
+//	  schema_ref: "#/components/schemas/Pet"
+//	}
+type Validator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to SchemaSource:
+	//
+	//	*Validator_Schema
+	//	*Validator_SchemaFile
+	SchemaSource isValidator_SchemaSource `protobuf_oneof:"schema_source"`
+	// Reference (JSON pointer) to the schema within the loaded document, e.g.
+	// "#/components/schemas/Pet" for OpenAPI components. If not specified,
+	// whole document is used as the schema.
+	SchemaRef string `protobuf:"bytes,3,opt,name=schema_ref,json=schemaRef,proto3" json:"schema_ref,omitempty"`
+}
+
+func (x *Validator) Reset() {
+	*x = Validator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Validator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Validator) ProtoMessage() {}
+
+func (x *Validator) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Validator.ProtoReflect.Descriptor instead.
+func (*Validator) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Validator) GetSchemaSource() isValidator_SchemaSource {
+	if m != nil {
+		return m.SchemaSource
+	}
+	return nil
+}
+
+func (x *Validator) GetSchema() string {
+	if x, ok := x.GetSchemaSource().(*Validator_Schema); ok {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Validator) GetSchemaFile() string {
+	if x, ok := x.GetSchemaSource().(*Validator_SchemaFile); ok {
+		return x.SchemaFile
+	}
+	return ""
+}
+
+func (x *Validator) GetSchemaRef() string {
+	if x != nil {
+		return x.SchemaRef
+	}
+	return ""
+}
+
+type isValidator_SchemaSource interface {
+	isValidator_SchemaSource()
+}
+
+type Validator_Schema struct {
+	// Schema, in JSON or YAML format.
+	Schema string `protobuf:"bytes,1,opt,name=schema,proto3,oneof"`
+}
+
+type Validator_SchemaFile struct {
+	// File to load the schema from, in JSON or YAML format. It can be an
+	// OpenAPI document as well, in which case schema_ref should be used to
+	// point to the endpoint's schema. Like other files in cloudprober, it
+	// can be on GCS (gs://), S3 (s3://), or an HTTP(S) URL.
+	SchemaFile string `protobuf:"bytes,2,opt,name=schema_file,json=schemaFile,proto3,oneof"`
+}
+
+func (*Validator_Schema) isValidator_SchemaSource() {}
+
+func (*Validator_SchemaFile) isValidator_SchemaSource() {}
+
+var File_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x54, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2f, 0x6a, 0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x21, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x6a,
+	0x73, 0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x78, 0x0a, 0x09, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x21, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x72, 0x65,
+	0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52,
+	0x65, 0x66, 0x42, 0x0f, 0x0a, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2f, 0x6a, 0x73,
+	0x6f, 0x6e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_goTypes = []any{
+	(*Validator)(nil), // 0: cloudprober.validators.jsonschema.Validator
+}
+var file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() {
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_init()
+}
+func file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Validator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes[0].OneofWrappers = []any{
+		(*Validator_Schema)(nil),
+		(*Validator_SchemaFile)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_internal_validators_jsonschema_proto_config_proto_depIdxs = nil
+}
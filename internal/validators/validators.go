@@ -18,10 +18,12 @@ package validators
 
 import (
 	"fmt"
+	nethttp "net/http"
 
 	"github.com/cloudprober/cloudprober/internal/validators/http"
 	"github.com/cloudprober/cloudprober/internal/validators/integrity"
 	"github.com/cloudprober/cloudprober/internal/validators/json"
+	"github.com/cloudprober/cloudprober/internal/validators/jsonschema"
 	configpb "github.com/cloudprober/cloudprober/internal/validators/proto"
 	"github.com/cloudprober/cloudprober/internal/validators/regex"
 	"github.com/cloudprober/cloudprober/logger"
@@ -36,6 +38,21 @@ import (
 type Validator struct {
 	Name     string
 	Validate func(input *Input) (bool, error)
+
+	// If set, validator runs only for the matching response status codes.
+	statusCodeMatch func(statusCode int) bool
+}
+
+// applies reports whether the validator should run for the given input.
+func (v *Validator) applies(input *Input) bool {
+	if v.statusCodeMatch == nil {
+		return true
+	}
+	resp, ok := input.Response.(*nethttp.Response)
+	if !ok || resp == nil {
+		return false
+	}
+	return v.statusCodeMatch(resp.StatusCode)
 }
 
 // Init initializes the validators defined in the config.
@@ -67,6 +84,13 @@ func Init(validatorConfs []*configpb.Validator, l *logger.Logger) ([]*Validator,
 func initValidator(validatorConf *configpb.Validator, l *logger.Logger) (validator *Validator, err error) {
 	validator = &Validator{Name: validatorConf.Name}
 
+	if validatorConf.GetIfStatusCodes() != "" {
+		validator.statusCodeMatch, err = http.StatusCodeMatcher(validatorConf.GetIfStatusCodes())
+		if err != nil {
+			return nil, fmt.Errorf("validator %s: invalid if_status_codes (%s): %v", validatorConf.GetName(), validatorConf.GetIfStatusCodes(), err)
+		}
+	}
+
 	switch validatorConf.Type.(type) {
 	case *configpb.Validator_HttpValidator:
 		v := &http.Validator{}
@@ -98,6 +122,16 @@ func initValidator(validatorConf *configpb.Validator, l *logger.Logger) (validat
 		}
 		return
 
+	case *configpb.Validator_JsonSchemaValidator:
+		v := &jsonschema.Validator{}
+		if err := v.Init(validatorConf.GetJsonSchemaValidator(), l); err != nil {
+			return nil, err
+		}
+		validator.Validate = func(input *Input) (bool, error) {
+			return v.Validate(input.ResponseBody)
+		}
+		return
+
 	case *configpb.Validator_Regex:
 		v := &regex.Validator{}
 		if err := v.Init(validatorConf.GetRegex(), l); err != nil {
@@ -121,11 +155,15 @@ type Input struct {
 
 // RunValidators runs the list of validators on the given response and
 // responseBody, updates the given validationFailure map and returns the list
-// of failures.
-func RunValidators(vs []*Validator, input *Input, validationFailure *metrics.Map[int64], l *logger.Logger) []string {
+// of failures. Successful validations are counted in the validationSuccess
+// map, if it's not nil.
+func RunValidators(vs []*Validator, input *Input, validationFailure, validationSuccess *metrics.Map[int64], l *logger.Logger) []string {
 	var failures []string
 
 	for _, v := range vs {
+		if !v.applies(input) {
+			continue
+		}
 		success, err := v.Validate(input)
 		if err != nil {
 			l.Error("Error while running the validator ", v.Name, ": ", err.Error())
@@ -134,6 +172,10 @@ func RunValidators(vs []*Validator, input *Input, validationFailure *metrics.Map
 		if !success {
 			validationFailure.IncKey(v.Name)
 			failures = append(failures, v.Name)
+			continue
+		}
+		if validationSuccess != nil {
+			validationSuccess.IncKey(v.Name)
 		}
 	}
 
@@ -142,9 +184,18 @@ func RunValidators(vs []*Validator, input *Input, validationFailure *metrics.Map
 
 // ValidationFailureMap returns an initialized validation failures map.
 func ValidationFailureMap(vs []*Validator) *metrics.Map[int64] {
+	return validatorsMap(vs)
+}
+
+// ValidationSuccessMap returns an initialized validation successes map.
+func ValidationSuccessMap(vs []*Validator) *metrics.Map[int64] {
+	return validatorsMap(vs)
+}
+
+func validatorsMap(vs []*Validator) *metrics.Map[int64] {
 	m := metrics.NewMap("validator")
-	// Initialize validation failure map with validator keys, so that we always
-	// export the metrics.
+	// Initialize the map with validator keys, so that we always export the
+	// metrics.
 	for _, v := range vs {
 		m.IncKeyBy(v.Name, 0)
 	}
@@ -15,6 +15,7 @@
 package validators
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -36,7 +37,7 @@ var testValidators = []*Validator{
 
 func TestRunValidators(t *testing.T) {
 	vfMap := ValidationFailureMap(testValidators)
-	failures := RunValidators(testValidators, &Input{}, vfMap, nil)
+	failures := RunValidators(testValidators, &Input{}, vfMap, nil, nil)
 
 	if vfMap.GetKey("test-v1") != 0 {
 		t.Error("Got unexpected test-v1 validation failure.")
@@ -49,6 +50,75 @@ func TestRunValidators(t *testing.T) {
 	if !reflect.DeepEqual(failures, []string{"test-v2"}) {
 		t.Errorf("Didn't get expected validation failures. Expected: {\"test-v2\"}, Got: %v", failures)
 	}
+
+	// With validation success map.
+	vsMap := ValidationSuccessMap(testValidators)
+	RunValidators(testValidators, &Input{}, vfMap, vsMap, nil)
+	assert.Equal(t, "map:validator,test-v1:1,test-v2:0", vsMap.String())
+	assert.Equal(t, "map:validator,test-v1:0,test-v2:2", vfMap.String())
+}
+
+func TestRunValidatorsIfStatusCodes(t *testing.T) {
+	var validatorConfs []*configpb.Validator
+	for _, c := range []string{
+		`name: "ok_body"
+		 regex: "ok"`,
+		`name: "error_body"
+		 regex: "error"
+		 if_status_codes: "500-599"`,
+		`name: "redirect_body"
+		 regex: "moved"
+		 if_status_codes: "301,302"`,
+	} {
+		v := &configpb.Validator{}
+		assert.NoError(t, prototext.Unmarshal([]byte(c), v))
+		validatorConfs = append(validatorConfs, v)
+	}
+
+	vs, err := Init(validatorConfs, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		resp         interface{}
+		body         string
+		wantFailures []string
+	}{
+		{
+			name:         "200",
+			resp:         &http.Response{StatusCode: 200},
+			body:         "not-ok",
+			wantFailures: nil,
+		},
+		{
+			name:         "503",
+			resp:         &http.Response{StatusCode: 503},
+			body:         "ok",
+			wantFailures: []string{"error_body"},
+		},
+		{
+			name:         "302",
+			resp:         &http.Response{StatusCode: 302},
+			body:         "error",
+			wantFailures: []string{"ok_body", "redirect_body"},
+		},
+		{
+			name:         "non-http",
+			body:         "error",
+			wantFailures: []string{"ok_body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vfMap := ValidationFailureMap(vs)
+			failures := RunValidators(vs, &Input{Response: tt.resp, ResponseBody: []byte(tt.body)}, vfMap, nil, nil)
+			assert.Equal(t, tt.wantFailures, failures)
+			for _, name := range tt.wantFailures {
+				assert.Equal(t, int64(1), vfMap.GetKey(name), name)
+			}
+		})
+	}
 }
 
 func TestValidatorFailureMap(t *testing.T) {
@@ -91,8 +161,14 @@ func TestInit(t *testing.T) {
 						pattern_num_bytes: 8
 					}
 				`,
+				`
+					name: "schema"
+					json_schema_validator {
+						schema: "{type: object}"
+					}
+				`,
 			},
-			wantNames: []string{"http_status_200s", "found_string", "valid_json", "integrity"},
+			wantNames: []string{"http_status_200s", "found_string", "valid_json", "integrity", "schema"},
 		},
 		{
 			name: "missing name",
@@ -113,6 +189,29 @@ func TestInit(t *testing.T) {
 			},
 			wantErr: "HTTP",
 		},
+		{
+			name: "invalid if_status_codes",
+			validatorConfs: []string{
+				`
+				name: "found_string"
+				regex: ".*found.*"
+				if_status_codes: "5xx"
+				`,
+			},
+			wantErr: "if_status_codes",
+		},
+		{
+			name: "invalid json schema",
+			validatorConfs: []string{
+				`
+				name: "schema"
+				json_schema_validator {
+					schema: "{type: objekt}"
+				}
+				`,
+			},
+			wantErr: "invalid schema",
+		},
 	}
 
 	for _, tt := range tests {
@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	distpb "github.com/cloudprober/cloudprober/metrics/proto"
 	"google.golang.org/api/googleapi"
@@ -37,6 +38,10 @@ type Distribution struct {
 	bucketCounts []int64
 	count        int64   // count of all values
 	sum          float64 // sum of all samples.
+
+	// Percentiles to export along with the distribution. These are set at
+	// the creation time and are not modified afterwards.
+	percentiles []float64
 }
 
 // NewDistribution returns a new distribution container.
@@ -74,7 +79,21 @@ func NewExponentialDistribution(base, scaleFactor float64, numBuckets int) (*Dis
 // NewDistributionFromProto returns a new distribution based on the provided
 // protobuf.
 func NewDistributionFromProto(distProto *distpb.Dist) (*Distribution, error) {
+	for _, p := range distProto.GetPercentiles() {
+		if p <= 0 || p >= 100 {
+			return nil, fmt.Errorf("invalid percentile: %v, percentiles should be in the (0, 100) range", p)
+		}
+	}
+
+	d, err := newDistributionFromBuckets(distProto)
+	if err != nil {
+		return nil, err
+	}
+	d.percentiles = append([]float64(nil), distProto.GetPercentiles()...)
+	return d, nil
+}
 
+func newDistributionFromBuckets(distProto *distpb.Dist) (*Distribution, error) {
 	switch distProto.Buckets.(type) {
 
 	case *distpb.Dist_ExplicitBuckets:
@@ -178,6 +197,45 @@ func (d *Distribution) addOrSubtract(val Value, subtract bool) (bool, error) {
 	return false, nil
 }
 
+// Merge merges the incoming distribution into the receiver distribution. If
+// both distributions have the same buckets, merge is exact. Otherwise,
+// incoming distribution's bucket counts are re-bucketed into the receiver's
+// buckets, using the incoming buckets' midpoints (or lower bound for the last
+// bucket) as representative samples. Sum and count are always exact. Merge
+// returns true if the merge was exact.
+func (d *Distribution) Merge(in *Distribution) bool {
+	if d == in {
+		in = in.CloneDist()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	in.mu.RLock()
+	defer in.mu.RUnlock()
+
+	d.count += in.count
+	d.sum += in.sum
+
+	if reflect.DeepEqual(d.lowerBounds, in.lowerBounds) {
+		for i := range d.bucketCounts {
+			d.bucketCounts[i] += in.bucketCounts[i]
+		}
+		return true
+	}
+
+	for i, c := range in.bucketCounts {
+		if c == 0 {
+			continue
+		}
+		sample := in.lowerBounds[i]
+		if i+1 < len(in.lowerBounds) && !math.IsInf(sample, -1) {
+			sample = (sample + in.lowerBounds[i+1]) / 2
+		}
+		d.bucketCounts[d.bucketIndex(sample)] += c
+	}
+	return false
+}
+
 // String returns a string representation of the distribution:
 // "dist:sum:<sum>|count:<count>|lb:<lower bounds>|bc:<bucket counts>"
 // For example for a distribution with lower bounds 0.5, 2.0, 7.5 and
@@ -298,6 +356,49 @@ type DistributionData struct {
 	BucketCounts []int64
 	Count        int64   // count of all values
 	Sum          float64 // sum of all samples.
+
+	// Percentiles configured to be exported for this distribution.
+	Percentiles []float64
+}
+
+// Percentile returns an estimate of the p-th percentile (0 < p < 100) of the
+// distribution's samples, computed from the bucket counts. Percentile is
+// linearly interpolated within the bucket that contains it. If that bucket
+// is the first (-Inf lower bound) or the last (+Inf upper bound) bucket, its
+// finite bound is returned. It returns NaN if distribution has no samples.
+func (dd *DistributionData) Percentile(p float64) float64 {
+	if dd.Count <= 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(dd.Count)
+
+	var cum int64
+	for i, c := range dd.BucketCounts {
+		if c == 0 || float64(cum+c) < rank {
+			cum += c
+			continue
+		}
+
+		lower := dd.LowerBounds[i]
+		if i == len(dd.LowerBounds)-1 {
+			return lower
+		}
+		upper := dd.LowerBounds[i+1]
+		if math.IsInf(lower, -1) {
+			return upper
+		}
+		return lower + (upper-lower)*(rank-float64(cum))/float64(c)
+	}
+
+	// Should not be reached unless bucket counts are inconsistent with count.
+	return dd.LowerBounds[len(dd.LowerBounds)-1]
+}
+
+// FormatPercentile returns the string representation of a percentile, to be
+// used in metric names and labels, e.g. "99.9".
+func FormatPercentile(p float64) string {
+	return strconv.FormatFloat(p, 'f', -1, 64)
 }
 
 // Data returns a DistributionData object, built using Distribution's current
@@ -310,6 +411,7 @@ func (d *Distribution) Data() *DistributionData {
 		BucketCounts: d.bucketCounts,
 		Count:        d.count,
 		Sum:          d.sum,
+		Percentiles:  d.percentiles,
 	}
 }
 
@@ -339,10 +441,23 @@ func (d *Distribution) CloneDist() *Distribution {
 	newD := NewDistribution(d.lowerBounds[1:])
 	newD.sum = d.sum
 	newD.count = d.count
+	newD.percentiles = d.percentiles
 	copy(newD.bucketCounts, d.bucketCounts)
 	return newD
 }
 
+// ConvertUnit returns a copy of the receiver distribution with bucket
+// boundaries and sum converted from the unit "from" to the unit "to", e.g.
+// from microseconds to seconds. Bucket counts remain the same.
+func (d *Distribution) ConvertUnit(from, to time.Duration) *Distribution {
+	newD := d.CloneDist()
+	for i := range newD.lowerBounds[1:] {
+		newD.lowerBounds[i+1] = ConvertUnit(newD.lowerBounds[i+1], from, to)
+	}
+	newD.sum = ConvertUnit(newD.sum, from, to)
+	return newD
+}
+
 // Clone returns a copy of the receiver distribution.
 func (d *Distribution) Clone() Value {
 	return d.CloneDist()
@@ -19,6 +19,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	distpb "github.com/cloudprober/cloudprober/metrics/proto"
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,7 @@ func TestNewDistributionFromProto(t *testing.T) {
 		inputProto      string
 		wantError       bool
 		wantLowerBounds []float64
+		wantPercentiles []float64
 	}{
 		{
 			inputProto:      "explicit_buckets: \"1,2,4,8,16,32\"",
@@ -63,6 +65,22 @@ func TestNewDistributionFromProto(t *testing.T) {
 			}`,
 			wantError: true,
 		},
+		{
+			inputProto: `explicit_buckets: "1,2,4"
+			             percentiles: [50, 99.9]`,
+			wantLowerBounds: []float64{math.Inf(-1), 1, 2, 4},
+			wantPercentiles: []float64{50, 99.9},
+		},
+		{
+			inputProto: `explicit_buckets: "1,2,4"
+			             percentiles: [50, 100]`,
+			wantError: true,
+		},
+		{
+			inputProto: `explicit_buckets: "1,2,4"
+			             percentiles: [0]`,
+			wantError: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -79,10 +97,49 @@ func TestNewDistributionFromProto(t *testing.T) {
 				return
 			}
 			assert.Equal(t, test.wantLowerBounds, d.lowerBounds)
+			assert.Equal(t, test.wantPercentiles, d.Data().Percentiles)
 		})
 	}
 }
 
+func TestDistPercentile(t *testing.T) {
+	d := NewDistribution([]float64{0, 10, 20, 40})
+	// 10 samples in [10, 20), 10 samples in [20, 40).
+	for i := 0; i < 10; i++ {
+		d.AddSample(15)
+		d.AddSample(30)
+	}
+	d.percentiles = []float64{50, 99}
+
+	// Cloned distributions keep the percentiles.
+	data := d.CloneDist().Data()
+	assert.Equal(t, []float64{50, 99}, data.Percentiles)
+
+	for _, test := range []struct {
+		p    float64
+		want float64
+	}{
+		{p: 5, want: 11},
+		{p: 50, want: 20},
+		{p: 75, want: 30},
+		{p: 99, want: 39.6},
+	} {
+		assert.InDelta(t, test.want, data.Percentile(test.p), 1e-9, "percentile: %v", test.p)
+	}
+
+	// Samples in the first and the last bucket.
+	d = NewDistribution([]float64{1, 2})
+	d.AddSample(0.5)
+	d.AddSample(5)
+	assert.Equal(t, float64(1), d.Data().Percentile(25))
+	assert.Equal(t, float64(2), d.Data().Percentile(90))
+
+	// No samples.
+	assert.True(t, math.IsNaN(NewDistribution([]float64{1, 2}).Data().Percentile(50)))
+
+	assert.Equal(t, "99.9", FormatPercentile(99.9))
+}
+
 func TestNewExponentialDistribution(t *testing.T) {
 	rows := []struct {
 		name              string
@@ -164,6 +221,42 @@ func TestDistAdd(t *testing.T) {
 	verifyBucketCount(t, d, []int{0, 1, 2, 3, 4, 5}, []int64{1, 2, 0, 2, 0, 1})
 }
 
+func TestDistMerge(t *testing.T) {
+	lb := []float64{1, 5, 15, 30, 45}
+	d := NewDistribution(lb)
+	for _, s := range []float64{0.5, 4, 17} {
+		d.AddSample(s)
+	}
+
+	// Same buckets: exact merge.
+	in := NewDistribution(lb)
+	for _, s := range []float64{3.5, 21, 300} {
+		in.AddSample(s)
+	}
+	if !d.Merge(in) {
+		t.Errorf("Merge() with same buckets returned inexact merge")
+	}
+	verifyBucketCount(t, d, []int{0, 1, 2, 3, 4, 5}, []int64{1, 2, 0, 2, 0, 1})
+
+	// Different buckets: midpoints of the incoming buckets are used.
+	in = NewDistribution([]float64{2, 10, 100})
+	for _, s := range []float64{1, 3, 50, 200} {
+		in.AddSample(s)
+	}
+	if d.Merge(in) {
+		t.Errorf("Merge() with different buckets returned exact merge")
+	}
+	// 1 -> -Inf bucket, 3 -> midpoint 6 (bucket 5-15), 50 -> midpoint 55
+	// (bucket 45-), 200 -> lower bound 100 (bucket 45-).
+	verifyBucketCount(t, d, []int{0, 1, 2, 3, 4, 5}, []int64{2, 2, 1, 2, 0, 3})
+	if d.count != 10 || d.sum != 600 {
+		t.Errorf("Merge(): got count=%d, sum=%f, want count=10, sum=600", d.count, d.sum)
+	}
+	if err := d.Verify(); err != nil {
+		t.Errorf("Merged distribution is not valid: %v", err)
+	}
+}
+
 func TestDistSubtractCounter(t *testing.T) {
 	lb := []float64{1, 5, 15, 30, 45}
 	d := NewDistribution(lb)
@@ -203,6 +296,18 @@ func TestDistData(t *testing.T) {
 	}
 }
 
+func TestDistConvertUnit(t *testing.T) {
+	d := NewDistribution([]float64{1, 5, 15})
+	for _, s := range []float64{0.5, 4, 17} {
+		d.AddSample(s)
+	}
+
+	assert.Equal(t, "dist:sum:21500|count:3|lb:-Inf,1000,5000,15000|bc:1,1,0,1", d.ConvertUnit(time.Millisecond, time.Microsecond).String())
+	assert.Equal(t, "dist:sum:0.0215|count:3|lb:-Inf,0.001,0.005,0.015|bc:1,1,0,1", d.ConvertUnit(time.Millisecond, time.Second).String())
+	// Original distribution is not modified.
+	assert.Equal(t, "dist:sum:21.5|count:3|lb:-Inf,1,5,15|bc:1,1,0,1", d.String())
+}
+
 func TestDistString(t *testing.T) {
 	lb := []float64{1, 5, 15, 30, 45}
 	d := NewDistribution(lb)
@@ -51,7 +51,15 @@ type EventMetrics struct {
 	labels     map[string]string
 	labelsKeys []string
 
+	// Typed values for some of the labels, e.g. numeric labels declared by the
+	// targets provider. A typed label is always present in labels as well.
+	typedLabels map[string]any
+
 	LatencyUnit time.Duration
+
+	// MetricsPrefix, if set, is added to the metric names by the surfacers,
+	// right before surfacing. See WithMetricsPrefix.
+	MetricsPrefix string
 }
 
 // NewEventMetrics return a new EventMetrics object with internals maps initialized.
@@ -86,6 +94,38 @@ func (em *EventMetrics) AddMetric(name string, val Value) *EventMetrics {
 	return em
 }
 
+// SetMetric sets a metric (name & value) in the receiver EventMetrics. Unlike
+// AddMetric, it replaces the existing metric with the same name, if any.
+func (em *EventMetrics) SetMetric(name string, val Value) *EventMetrics {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if _, ok := em.metrics[name]; !ok {
+		em.metricsKeys = append(em.metricsKeys, name)
+	}
+	em.metrics[name] = val
+	return em
+}
+
+// RemoveMetric removes a metric from the receiver EventMetrics. It's a no-op
+// if metric doesn't exist.
+func (em *EventMetrics) RemoveMetric(name string) *EventMetrics {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if _, ok := em.metrics[name]; !ok {
+		return em
+	}
+	delete(em.metrics, name)
+	for i, k := range em.metricsKeys {
+		if k == name {
+			em.metricsKeys = append(em.metricsKeys[:i:i], em.metricsKeys[i+1:]...)
+			break
+		}
+	}
+	return em
+}
+
 // Metric returns an EventMetrics metric value by name. Metric will return nil
 // for a non-existent metric.
 func (em *EventMetrics) Metric(name string) Value {
@@ -131,6 +171,31 @@ func (em *EventMetrics) Label(name string) string {
 	return em.labels[name]
 }
 
+// SetTypedLabel sets a typed value (e.g. int64, float64 or bool) for an
+// existing label. It's a no-op if label doesn't exist. Surfacers that support
+// typed values can get them using TypedLabel.
+func (em *EventMetrics) SetTypedLabel(name string, val any) *EventMetrics {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	if _, ok := em.labels[name]; !ok {
+		return em
+	}
+	if em.typedLabels == nil {
+		em.typedLabels = make(map[string]any)
+	}
+	em.typedLabels[name] = val
+	return em
+}
+
+// TypedLabel returns the typed value of the label, if available.
+func (em *EventMetrics) TypedLabel(name string) (any, bool) {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	v, ok := em.typedLabels[name]
+	return v, ok
+}
+
 // LabelsKeys returns the list of all label keys.
 func (em *EventMetrics) LabelsKeys() []string {
 	em.mu.RLock()
@@ -143,10 +208,12 @@ func (em *EventMetrics) Clone() *EventMetrics {
 	em.mu.RLock()
 	defer em.mu.RUnlock()
 	newEM := &EventMetrics{
-		Timestamp: em.Timestamp,
-		Kind:      em.Kind,
-		metrics:   make(map[string]Value),
-		labels:    make(map[string]string),
+		Timestamp:     em.Timestamp,
+		Kind:          em.Kind,
+		LatencyUnit:   em.LatencyUnit,
+		MetricsPrefix: em.MetricsPrefix,
+		metrics:       make(map[string]Value),
+		labels:        make(map[string]string),
 	}
 	for _, lk := range em.labelsKeys {
 		newEM.labels[lk] = em.labels[lk]
@@ -156,6 +223,31 @@ func (em *EventMetrics) Clone() *EventMetrics {
 		newEM.metrics[mk] = em.metrics[mk].Clone()
 		newEM.metricsKeys = append(newEM.metricsKeys, mk)
 	}
+	if em.typedLabels != nil {
+		newEM.typedLabels = make(map[string]any, len(em.typedLabels))
+		for k, v := range em.typedLabels {
+			newEM.typedLabels[k] = v
+		}
+	}
+	return newEM
+}
+
+// WithMetricsPrefix returns a copy of the EventMetrics with MetricsPrefix
+// added to all the metric names. If MetricsPrefix is not set, the receiver
+// itself is returned.
+func (em *EventMetrics) WithMetricsPrefix() *EventMetrics {
+	if em.MetricsPrefix == "" {
+		return em
+	}
+
+	newEM := em.Clone()
+	newEM.MetricsPrefix = ""
+	oldMetrics := newEM.metrics
+	newEM.metrics = make(map[string]Value, len(oldMetrics))
+	for i, mk := range newEM.metricsKeys {
+		newEM.metricsKeys[i] = em.MetricsPrefix + mk
+		newEM.metrics[newEM.metricsKeys[i]] = oldMetrics[mk]
+	}
 	return newEM
 }
 
@@ -265,6 +357,39 @@ func (em *EventMetrics) Key() string {
 	return strings.Join(keys, ",")
 }
 
+// ConvertUnit converts a value from the unit "from" to the unit "to", e.g.
+// from microseconds to seconds. To avoid floating point errors, it divides
+// or multiplies by the integer ratio of the units, whenever possible.
+func ConvertUnit(v float64, from, to time.Duration) float64 {
+	if from == to || from == 0 || to == 0 {
+		return v
+	}
+	if to > from && to%from == 0 {
+		return v / float64(to/from)
+	}
+	if from > to && from%to == 0 {
+		return v * float64(from/to)
+	}
+	return v * float64(from) / float64(to)
+}
+
+// LatencyUnitSuffix returns the metric name suffix for the latency unit, as
+// per the Prometheus naming conventions, e.g. "_seconds". It returns an
+// empty string for the units other than ns, us, ms and s.
+func LatencyUnitSuffix(latencyUnit time.Duration) string {
+	switch latencyUnit {
+	case time.Nanosecond:
+		return "_nanoseconds"
+	case 0, time.Microsecond:
+		return "_microseconds"
+	case time.Millisecond:
+		return "_milliseconds"
+	case time.Second:
+		return "_seconds"
+	}
+	return ""
+}
+
 // LatencyUnitToString returns the string representation of the latency unit.
 func LatencyUnitToString(latencyUnit time.Duration) string {
 	if latencyUnit == 0 || latencyUnit == time.Microsecond {
@@ -221,3 +221,86 @@ func TestLatencyUnitToString(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertUnit(t *testing.T) {
+	assert.Equal(t, 0.0001, ConvertUnit(100, time.Microsecond, time.Second))
+	assert.Equal(t, 1500.0, ConvertUnit(1.5, time.Second, time.Millisecond))
+	assert.Equal(t, 90.0, ConvertUnit(1.5, time.Minute, time.Second))
+	assert.Equal(t, 7.0, ConvertUnit(7, time.Second, 0))
+}
+
+func TestLatencyUnitSuffix(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                "_microseconds",
+		time.Second:      "_seconds",
+		time.Millisecond: "_milliseconds",
+		time.Microsecond: "_microseconds",
+		time.Nanosecond:  "_nanoseconds",
+		time.Minute:      "",
+	}
+	for latencyUnit, want := range tests {
+		assert.Equal(t, want, LatencyUnitSuffix(latencyUnit), "LatencyUnitSuffix(%v)", latencyUnit)
+	}
+}
+
+func TestEventMetricsTypedLabels(t *testing.T) {
+	em := NewEventMetrics(time.Now()).AddLabel("weight", "10")
+
+	em.SetTypedLabel("weight", int64(10))
+	em.SetTypedLabel("missing", true) // No-op, label doesn't exist.
+
+	if _, ok := em.TypedLabel("missing"); ok {
+		t.Errorf("Got typed value for a non-existent label")
+	}
+
+	newEM := em.Clone()
+	for _, e := range []*EventMetrics{em, newEM} {
+		v, ok := e.TypedLabel("weight")
+		if !ok || v != int64(10) {
+			t.Errorf("TypedLabel(weight)=%v,%v; want=10,true", v, ok)
+		}
+	}
+}
+
+func TestEventMetricsCloneLatencyUnit(t *testing.T) {
+	em := NewEventMetrics(time.Now()).AddMetric("latency", NewFloat(1.5))
+	em.LatencyUnit = time.Millisecond
+	assert.Equal(t, time.Millisecond, em.Clone().LatencyUnit)
+}
+
+func TestEventMetricsWithMetricsPrefix(t *testing.T) {
+	em := NewEventMetrics(time.Now()).
+		AddMetric("total", NewInt(10)).
+		AddMetric("latency", NewDistribution([]float64{1, 2})).
+		AddLabel("ptype", "http")
+
+	if got := em.WithMetricsPrefix(); got != em {
+		t.Errorf("WithMetricsPrefix() with empty prefix returned a new object")
+	}
+
+	em.MetricsPrefix = "http_"
+	newEM := em.Clone().WithMetricsPrefix()
+
+	assert.Equal(t, []string{"http_total", "http_latency"}, newEM.MetricsKeys())
+	assert.Equal(t, "10", newEM.Metric("http_total").String())
+	assert.Equal(t, "", newEM.MetricsPrefix)
+	assert.Equal(t, "http", newEM.Label("ptype"))
+
+	// Original EventMetrics is not modified.
+	assert.Equal(t, []string{"total", "latency"}, em.MetricsKeys())
+}
+
+func TestEventMetricsSetRemoveMetric(t *testing.T) {
+	em := NewEventMetrics(time.Now()).
+		AddMetric("total", NewInt(10)).
+		AddMetric("success", NewInt(8)).
+		AddMetric("latency", NewFloat(1.5))
+
+	em.SetMetric("total", NewInt(20)).SetMetric("failure", NewInt(2))
+	assert.Equal(t, []string{"total", "success", "latency", "failure"}, em.MetricsKeys())
+	assert.Equal(t, "20", em.Metric("total").String())
+
+	em.RemoveMetric("success").RemoveMetric("unknown")
+	assert.Equal(t, []string{"total", "latency", "failure"}, em.MetricsKeys())
+	assert.Nil(t, em.Metric("success"))
+}
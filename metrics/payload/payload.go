@@ -35,6 +35,8 @@ type Parser struct {
 	aggMetrics  map[string]*metrics.EventMetrics
 	aggregate   bool
 	l           *logger.Logger
+
+	labelsLinePrefix string
 }
 
 // NewParser returns a new payload parser, based on the config provided.
@@ -44,6 +46,8 @@ func NewParser(opts *configpb.OutputMetricsOptions, ptype, probeName string, def
 		distMetrics: make(map[string]*metrics.Distribution),
 		aggMetrics:  make(map[string]*metrics.EventMetrics),
 		l:           l,
+
+		labelsLinePrefix: opts.GetLabelsLinePrefix(),
 	}
 
 	// If there are any distribution metrics, build them now itself.
@@ -251,23 +255,66 @@ func (p *Parser) payloadLineMetrics(payloadTS time.Time, line, target string) (*
 	return em.Clone(), nil
 }
 
+// labelsFromLine returns the labels from the line, if line is a labels line.
+func (p *Parser) labelsFromLine(line string) ([][2]string, bool) {
+	if p.labelsLinePrefix == "" || !strings.HasPrefix(line, p.labelsLinePrefix) {
+		return nil, false
+	}
+	return parseLabels(strings.TrimSpace(strings.TrimPrefix(line, p.labelsLinePrefix))), true
+}
+
+// Stream parses the output of a process line by line, keeping track of the
+// labels set through the labels lines.
+type Stream struct {
+	p      *Parser
+	target string
+	labels [][2]string
+}
+
+// NewStream returns a new Stream for the given target.
+func (p *Parser) NewStream(target string) *Stream {
+	return &Stream{p: p, target: target}
+}
+
+func (s *Stream) lineMetrics(ts time.Time, line string) *metrics.EventMetrics {
+	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return nil
+	}
+
+	if labels, ok := s.p.labelsFromLine(line); ok {
+		s.labels = labels
+		return nil
+	}
+
+	em, err := s.p.payloadLineMetrics(ts, line, s.target)
+	if err != nil {
+		s.p.l.Warning(err.Error())
+		return nil
+	}
+	for _, kv := range s.labels {
+		em.AddLabel(kv[0], kv[1])
+	}
+	return em
+}
+
+// LineMetrics parses the given output line and returns the corresponding
+// EventMetrics, or nil if line is not a metrics line.
+func (s *Stream) LineMetrics(line string) *metrics.EventMetrics {
+	return s.lineMetrics(time.Now(), line)
+}
+
 // PayloadMetrics parses the given payload and creates one EventMetrics per
 // line. Each metric line can have its own labels, e.g. num_rows{db=dbA}.
 func (p *Parser) PayloadMetrics(payload, target string) []*metrics.EventMetrics {
 	payloadTS := time.Now()
+	s := p.NewStream(target)
+
 	var results []*metrics.EventMetrics
 	for _, line := range strings.Split(payload, "\n") {
-		line = strings.TrimSpace(line)
-		if len(line) == 0 {
-			continue
-		}
-
-		em, err := p.payloadLineMetrics(payloadTS, line, target)
-		if err != nil {
-			p.l.Warning(err.Error())
-			continue
+		if em := s.lineMetrics(payloadTS, line); em != nil {
+			results = append(results, em)
 		}
-		results = append(results, em)
 	}
 	return results
 }
@@ -355,3 +355,54 @@ func BenchmarkMetricValueLabels(b *testing.B) {
 		}
 	}
 }
+
+func TestLabelsLine(t *testing.T) {
+	p, err := NewParser(&configpb.OutputMetricsOptions{
+		LabelsLinePrefix: proto.String("#labels"),
+	}, testPtype, testProbe, metrics.GAUGE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payload := strings.Join([]string{
+		"requests 10",
+		"#labels db=dbA,dc=xx",
+		"num_rows 56",
+		"num_rows{db=dbB} 20",
+		"#labels db=dbC",
+		"errors 2",
+	}, "\n")
+
+	wantLabels := []map[string]string{
+		{"db": "", "dc": ""},
+		{"db": "dbA", "dc": "xx"},
+		{"db": "dbB", "dc": "xx"},
+		{"db": "dbC", "dc": ""},
+	}
+
+	ems := p.PayloadMetrics(payload, testTarget)
+	if len(ems) != len(wantLabels) {
+		t.Fatalf("Got %d EventMetrics, want %d: %v", len(ems), len(wantLabels), ems)
+	}
+	for i, em := range ems {
+		for k, v := range wantLabels[i] {
+			if got := em.Label(k); got != v {
+				t.Errorf("EM %d (%s): label %s=%q, want=%q", i, em.String(), k, got, v)
+			}
+		}
+	}
+
+	// Labels persist across lines in a stream.
+	s := p.NewStream(testTarget)
+	for _, line := range []string{"#labels db=dbA", "", "num_rows 56"} {
+		if em := s.LineMetrics(line); em != nil {
+			if got := em.Label("db"); got != "dbA" {
+				t.Errorf("Stream EM (%s): label db=%q, want=dbA", em.String(), got)
+			}
+			continue
+		}
+		if line == "num_rows 56" {
+			t.Errorf("Got no EventMetrics for line: %s", line)
+		}
+	}
+}
@@ -31,6 +31,16 @@ type Dist struct {
 	//	*Dist_ExplicitBuckets
 	//	*Dist_ExponentialBuckets
 	Buckets isDist_Buckets `protobuf_oneof:"buckets"`
+	// Percentiles to export along with the distribution, e.g. [50, 99, 99.9].
+	// Percentiles are estimated from the bucket counts, using linear
+	// interpolation within the bucket that contains the percentile. Their
+	// accuracy, hence, depends on the bucket boundaries. Percentiles must be in
+	// the (0, 100) range. By default, no percentiles are exported.
+	//
+	// Percentiles are currently exported only by the Prometheus and Datadog
+	// surfacers. Note that for the cumulative metrics, percentiles are computed
+	// over all the samples seen so far.
+	Percentiles []float64 `protobuf:"fixed64,3,rep,packed,name=percentiles,proto3" json:"percentiles,omitempty"`
 }
 
 func (x *Dist) Reset() {
@@ -86,6 +96,13 @@ func (x *Dist) GetExponentialBuckets() *ExponentialBuckets {
 	return nil
 }
 
+func (x *Dist) GetPercentiles() []float64 {
+	if x != nil {
+		return x.Percentiles
+	}
+	return nil
+}
+
 type isDist_Buckets interface {
 	isDist_Buckets()
 }
@@ -105,6 +122,66 @@ func (*Dist_ExplicitBuckets) isDist_Buckets() {}
 
 func (*Dist_ExponentialBuckets) isDist_Buckets() {}
 
+// Summary defines a Summary data type.
+type Summary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Quantiles to compute, e.g. [0.5, 0.9, 0.99]. Quantiles must be in the
+	// (0, 1) range. Quantiles are computed from the samples seen in the last
+	// max_age_sec seconds, up to the last 10000 samples.
+	Quantiles []float64 `protobuf:"fixed64,1,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`
+	// Sliding time window for the quantiles, in seconds. Default is 600.
+	MaxAgeSec uint32 `protobuf:"varint,2,opt,name=max_age_sec,json=maxAgeSec,proto3" json:"max_age_sec,omitempty"`
+}
+
+func (x *Summary) Reset() {
+	*x = Summary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Summary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Summary) ProtoMessage() {}
+
+func (x *Summary) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Summary.ProtoReflect.Descriptor instead.
+func (*Summary) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Summary) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+func (x *Summary) GetMaxAgeSec() uint32 {
+	if x != nil {
+		return x.MaxAgeSec
+	}
+	return 0
+}
+
 // ExponentialBucket defines a set of num_buckets+2 buckets:
 //
 //	bucket[0] covers (−Inf, 0)
@@ -129,7 +206,7 @@ type ExponentialBuckets struct {
 func (x *ExponentialBuckets) Reset() {
 	*x = ExponentialBuckets{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[1]
+		mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -142,7 +219,7 @@ func (x *ExponentialBuckets) String() string {
 func (*ExponentialBuckets) ProtoMessage() {}
 
 func (x *ExponentialBuckets) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[1]
+	mi := &file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -155,7 +232,7 @@ func (x *ExponentialBuckets) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExponentialBuckets.ProtoReflect.Descriptor instead.
 func (*ExponentialBuckets) Descriptor() ([]byte, []int) {
-	return file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDescGZIP(), []int{1}
+	return file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ExponentialBuckets) GetScaleFactor() float32 {
@@ -187,7 +264,7 @@ var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDesc = [
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2f, 0x64, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x13, 0x63,
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x04, 0x44, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x10, 0x65,
+	0x63, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x04, 0x44, 0x69, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x10, 0x65,
 	0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69,
 	0x74, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x5a, 0x0a, 0x13, 0x65, 0x78, 0x70, 0x6f,
@@ -196,18 +273,25 @@ var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDesc = [
 	0x62, 0x65, 0x72, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x6f,
 	0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x48, 0x00,
 	0x52, 0x12, 0x65, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x75, 0x63,
-	0x6b, 0x65, 0x74, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22,
-	0x6c, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x75,
-	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x66,
-	0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x73, 0x63, 0x61,
-	0x6c, 0x65, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42, 0x32, 0x5a,
-	0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6b, 0x65, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69,
+	0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x01, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x22, 0x47, 0x0a, 0x07, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52,
+	0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x6d, 0x61,
+	0x78, 0x5f, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x53, 0x65, 0x63, 0x22, 0x6c, 0x0a, 0x12, 0x45, 0x78,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x46, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6e, 0x75,
+	0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x6d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -222,13 +306,14 @@ func file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDescGZI
 	return file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_goTypes = []any{
 	(*Dist)(nil),               // 0: cloudprober.metrics.Dist
-	(*ExponentialBuckets)(nil), // 1: cloudprober.metrics.ExponentialBuckets
+	(*Summary)(nil),            // 1: cloudprober.metrics.Summary
+	(*ExponentialBuckets)(nil), // 2: cloudprober.metrics.ExponentialBuckets
 }
 var file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_depIdxs = []int32{
-	1, // 0: cloudprober.metrics.Dist.exponential_buckets:type_name -> cloudprober.metrics.ExponentialBuckets
+	2, // 0: cloudprober.metrics.Dist.exponential_buckets:type_name -> cloudprober.metrics.ExponentialBuckets
 	1, // [1:1] is the sub-list for method output_type
 	1, // [1:1] is the sub-list for method input_type
 	1, // [1:1] is the sub-list for extension type_name
@@ -255,6 +340,18 @@ func file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_init() {
 			}
 		}
 		file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Summary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_msgTypes[2].Exporter = func(v any, i int) any {
 			switch v := v.(*ExponentialBuckets); i {
 			case 0:
 				return &v.state
@@ -277,7 +374,7 @@ func file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_metrics_proto_dist_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
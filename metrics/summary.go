@@ -0,0 +1,343 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
+)
+
+const (
+	defaultSummaryMaxAge = 10 * time.Minute
+
+	// maxSummarySamples is the maximum number of samples kept for computing
+	// the quantiles. Oldest samples are dropped first.
+	maxSummarySamples = 10000
+)
+
+type summarySample struct {
+	ts  time.Time
+	val float64
+}
+
+// Summary metrics type implements a summary of values: quantiles computed
+// over a sliding time window, along with the cumulative sum and count of all
+// the values.
+type Summary struct {
+	mu      sync.RWMutex
+	samples []summarySample // samples in the time window, oldest first.
+	count   int64           // count of all values
+	sum     float64         // sum of all samples.
+
+	// quantileValues are set only for the summaries parsed from strings,
+	// which don't have samples. These are used as is, instead of computing
+	// quantiles from the samples.
+	quantileValues []float64
+
+	// These are set at the creation time and are not modified afterwards.
+	quantiles []float64
+	maxAge    time.Duration
+
+	now func() time.Time
+}
+
+// NewSummary returns a new summary container.
+func NewSummary(quantiles []float64, maxAge time.Duration) *Summary {
+	return &Summary{
+		quantiles: append([]float64(nil), quantiles...),
+		maxAge:    maxAge,
+		now:       time.Now,
+	}
+}
+
+// NewSummaryFromProto returns a new summary based on the provided protobuf.
+func NewSummaryFromProto(summaryProto *distpb.Summary) (*Summary, error) {
+	if len(summaryProto.GetQuantiles()) == 0 {
+		return nil, errors.New("no quantiles specified for the summary")
+	}
+	for _, q := range summaryProto.GetQuantiles() {
+		if q <= 0 || q >= 1 {
+			return nil, fmt.Errorf("invalid quantile: %v, quantiles should be in the (0, 1) range", q)
+		}
+	}
+
+	maxAge := defaultSummaryMaxAge
+	if summaryProto.GetMaxAgeSec() != 0 {
+		maxAge = time.Duration(summaryProto.GetMaxAgeSec()) * time.Second
+	}
+	return NewSummary(summaryProto.GetQuantiles(), maxAge), nil
+}
+
+// expireSamples drops the samples that are older than maxAge, or that
+// exceed the maximum number of samples. It should be called with lock held.
+func (s *Summary) expireSamples() {
+	cutoff := s.now().Add(-s.maxAge)
+	i := sort.Search(len(s.samples), func(i int) bool {
+		return s.samples[i].ts.After(cutoff)
+	})
+	if n := len(s.samples) - i; n > maxSummarySamples {
+		i += n - maxSummarySamples
+	}
+	if i > 0 {
+		s.samples = append(s.samples[:0], s.samples[i:]...)
+	}
+}
+
+// AddFloat64 adds a float64 sample to the summary.
+func (s *Summary) AddFloat64(f float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, summarySample{ts: s.now(), val: f})
+	s.expireSamples()
+	s.count++
+	s.sum += f
+}
+
+// Add adds a summary to the receiver summary. Samples are merged, and sum
+// and count are added.
+func (s *Summary) Add(val Value) error {
+	delta, ok := val.(*Summary)
+	if !ok {
+		return errors.New("incompatible value to add to summary")
+	}
+	if s == delta {
+		delta = delta.CloneSummary()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delta.mu.RLock()
+	defer delta.mu.RUnlock()
+
+	s.samples = append(s.samples, delta.samples...)
+	sort.SliceStable(s.samples, func(i, j int) bool {
+		return s.samples[i].ts.Before(s.samples[j].ts)
+	})
+	s.expireSamples()
+	s.count += delta.count
+	s.sum += delta.sum
+	return nil
+}
+
+// SubtractCounter subtracts the provided "lastVal", assuming that value
+// represents a counter, i.e. if "value" is less than "lastVal", we assume
+// that counter has been reset and don't subtract. Only sum and count are
+// subtracted, quantiles are always computed over the sliding time window.
+func (s *Summary) SubtractCounter(lastVal Value) (bool, error) {
+	last, ok := lastVal.(*Summary)
+	if !ok {
+		return false, errors.New("incompatible value to subtract from summary")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	last.mu.RLock()
+	defer last.mu.RUnlock()
+
+	if s.count < last.count {
+		return true, nil
+	}
+	s.count -= last.count
+	s.sum -= last.sum
+	return false, nil
+}
+
+// SummaryData stuct, along with Data() function, provides a way to readily
+// share the Summary data with other packages.
+type SummaryData struct {
+	Count int64   // count of all values
+	Sum   float64 // sum of all samples.
+
+	// Quantiles and their values, computed over the sliding time window.
+	// Values are NaN if there are no samples in the time window.
+	Quantiles      []float64
+	QuantileValues []float64
+}
+
+// Data returns a SummaryData object, built using Summary's current state.
+func (s *Summary) Data() *SummaryData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireSamples()
+	vals := make([]float64, len(s.samples))
+	for i, sample := range s.samples {
+		vals[i] = sample.val
+	}
+	sort.Float64s(vals)
+
+	sd := &SummaryData{
+		Count:          s.count,
+		Sum:            s.sum,
+		Quantiles:      s.quantiles,
+		QuantileValues: make([]float64, len(s.quantiles)),
+	}
+	if s.quantileValues != nil {
+		copy(sd.QuantileValues, s.quantileValues)
+		return sd
+	}
+	for i, q := range s.quantiles {
+		sd.QuantileValues[i] = quantile(vals, q)
+	}
+	return sd
+}
+
+// quantile returns the q-quantile of the sorted values, using the nearest
+// rank method. It returns NaN if there are no values.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	rank := int(math.Ceil(q * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// String returns a string representation of the summary:
+// "summary:sum:<sum>|count:<count>|q:<quantiles>|qv:<quantile values>"
+// For example:
+// summary:sum:899|count:221|q:0.5,0.9|qv:3.5,7.25
+func (s *Summary) String() string {
+	sd := s.Data()
+
+	var b strings.Builder
+
+	b.WriteString("summary:sum:")
+	b.WriteString(strconv.FormatFloat(sd.Sum, 'f', -1, 64))
+	b.WriteString("|count:")
+	b.WriteString(strconv.FormatInt(sd.Count, 10))
+
+	b.WriteString("|q:")
+	for i, q := range sd.Quantiles {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(q, 'f', -1, 64))
+	}
+
+	b.WriteString("|qv:")
+	for i, v := range sd.QuantileValues {
+		if i != 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	return b.String()
+}
+
+// ParseSummaryFromString parses a summary value from its string
+// representation (see String()). As string representation doesn't include
+// the samples, parsed summary's quantile values are fixed to the ones in the
+// string.
+func ParseSummaryFromString(str string) (*Summary, error) {
+	tokens := strings.SplitN(str, ":", 2)
+	if len(tokens) != 2 || tokens[0] != "summary" {
+		return nil, fmt.Errorf("invalid summary string: %s", str)
+	}
+
+	s := NewSummary(nil, defaultSummaryMaxAge)
+
+	parseFloats := func(str string) ([]float64, error) {
+		var fs []float64
+		if str == "" {
+			return fs, nil
+		}
+		for _, vs := range strings.Split(str, ",") {
+			f, err := strconv.ParseFloat(vs, 64)
+			if err != nil {
+				return nil, err
+			}
+			fs = append(fs, f)
+		}
+		return fs, nil
+	}
+
+	var err error
+	for _, tok := range strings.Split(tokens[1], "|") {
+		kv := strings.Split(tok, ":")
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid summary string: %s", str)
+		}
+		switch kv[0] {
+		case "sum":
+			s.sum, err = strconv.ParseFloat(kv[1], 64)
+		case "count":
+			s.count, err = strconv.ParseInt(kv[1], 10, 64)
+		case "q":
+			s.quantiles, err = parseFloats(kv[1])
+		case "qv":
+			s.quantileValues, err = parseFloats(kv[1])
+		default:
+			err = errors.New("unknown token")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid token (%s:%s) in the summary string: %s. Err: %v", kv[0], kv[1], str, err)
+		}
+	}
+
+	if len(s.quantiles) != len(s.quantileValues) {
+		return nil, fmt.Errorf("quantiles and quantile values don't match in the summary string: %s", str)
+	}
+	if s.quantileValues == nil {
+		s.quantileValues = []float64{}
+	}
+	return s, nil
+}
+
+// CloneSummary returns a copy of the receiver summary.
+func (s *Summary) CloneSummary() *Summary {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &Summary{
+		samples:        append([]summarySample(nil), s.samples...),
+		count:          s.count,
+		sum:            s.sum,
+		quantileValues: append([]float64(nil), s.quantileValues...),
+		quantiles:      s.quantiles,
+		maxAge:         s.maxAge,
+		now:            s.now,
+	}
+}
+
+// ConvertUnit returns a copy of the receiver summary with all the samples,
+// and the sum, converted from the unit "from" to the unit "to", e.g. from
+// microseconds to seconds.
+func (s *Summary) ConvertUnit(from, to time.Duration) *Summary {
+	newS := s.CloneSummary()
+	for i := range newS.samples {
+		newS.samples[i].val = ConvertUnit(newS.samples[i].val, from, to)
+	}
+	for i := range newS.quantileValues {
+		newS.quantileValues[i] = ConvertUnit(newS.quantileValues[i], from, to)
+	}
+	newS.sum = ConvertUnit(newS.sum, from, to)
+	return newS
+}
+
+// Clone returns a copy of the receiver summary.
+func (s *Summary) Clone() Value {
+	return s.CloneSummary()
+}
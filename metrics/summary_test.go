@@ -0,0 +1,168 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSummaryFromProto(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         *distpb.Summary
+		wantMaxAge time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "default_max_age",
+			in:         &distpb.Summary{Quantiles: []float64{0.5, 0.99}},
+			wantMaxAge: defaultSummaryMaxAge,
+		},
+		{
+			name:       "max_age",
+			in:         &distpb.Summary{Quantiles: []float64{0.5}, MaxAgeSec: 60},
+			wantMaxAge: time.Minute,
+		},
+		{
+			name:    "no_quantiles",
+			in:      &distpb.Summary{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid_quantile",
+			in:      &distpb.Summary{Quantiles: []float64{0.5, 1}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSummaryFromProto(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.in.GetQuantiles(), s.quantiles)
+			assert.Equal(t, tt.wantMaxAge, s.maxAge)
+		})
+	}
+}
+
+func TestSummary(t *testing.T) {
+	now := time.Now()
+	s := NewSummary([]float64{0.5, 0.9}, time.Minute)
+	s.now = func() time.Time { return now }
+
+	sd := s.Data()
+	assert.True(t, math.IsNaN(sd.QuantileValues[0]), "quantile value with no samples")
+
+	for i := 1; i <= 10; i++ {
+		s.AddFloat64(float64(i))
+	}
+	assert.Equal(t, "summary:sum:55|count:10|q:0.5,0.9|qv:5,9", s.String())
+
+	// Add newer samples, older ones should be dropped from the quantiles
+	// computation, but not from the sum and count.
+	now = now.Add(45 * time.Second)
+	for i := 0; i < 10; i++ {
+		s.AddFloat64(100)
+	}
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, "summary:sum:1055|count:20|q:0.5,0.9|qv:100,100", s.String())
+
+	// All samples expired.
+	now = now.Add(time.Minute)
+	sd = s.Data()
+	assert.Equal(t, int64(20), sd.Count)
+	assert.True(t, math.IsNaN(sd.QuantileValues[1]), "quantile value with no samples")
+}
+
+func TestSummaryConvertUnit(t *testing.T) {
+	s := NewSummary([]float64{0.5, 0.9}, time.Minute)
+	for i := 1; i <= 10; i++ {
+		s.AddFloat64(float64(i * 1000))
+	}
+
+	assert.Equal(t, "summary:sum:55|count:10|q:0.5,0.9|qv:5,9", s.ConvertUnit(time.Microsecond, time.Millisecond).String())
+	assert.Equal(t, "summary:sum:55000|count:10|q:0.5,0.9|qv:5000,9000", s.String())
+}
+
+func TestSummaryAddSubtract(t *testing.T) {
+	s1 := NewSummary([]float64{0.5}, time.Minute)
+	s2 := NewSummary([]float64{0.5}, time.Minute)
+	for _, v := range []float64{1, 2, 3} {
+		s1.AddFloat64(v)
+	}
+	for _, v := range []float64{10, 20, 30, 40} {
+		s2.AddFloat64(v)
+	}
+
+	s := s1.CloneSummary()
+	require.NoError(t, s.Add(s2))
+	assert.Equal(t, "summary:sum:106|count:7|q:0.5|qv:10", s.String())
+	assert.Equal(t, "summary:sum:6|count:3|q:0.5|qv:2", s1.String(), "clone modified the original")
+
+	// Adding to itself.
+	require.NoError(t, s1.Add(s1))
+	assert.Equal(t, "summary:sum:12|count:6|q:0.5|qv:2", s1.String())
+
+	assert.Error(t, s.Add(NewInt(1)))
+
+	reset, err := s.SubtractCounter(s2)
+	require.NoError(t, err)
+	assert.False(t, reset)
+	assert.Equal(t, int64(3), s.Data().Count)
+	assert.Equal(t, float64(6), s.Data().Sum)
+
+	reset, err = s.SubtractCounter(s2)
+	require.NoError(t, err)
+	assert.True(t, reset)
+}
+
+func TestParseSummaryFromString(t *testing.T) {
+	s := NewSummary([]float64{0.5, 0.9}, time.Minute)
+	for i := 1; i <= 10; i++ {
+		s.AddFloat64(float64(i))
+	}
+
+	s1, err := ParseSummaryFromString(s.String())
+	require.NoError(t, err)
+	assert.Equal(t, s.Data(), s1.Data())
+	assert.Equal(t, s.String(), s1.String())
+	assert.Equal(t, s.String(), s1.CloneSummary().String())
+	assert.Equal(t, "summary:sum:0.055|count:10|q:0.5,0.9|qv:0.005,0.009", s1.ConvertUnit(time.Millisecond, time.Second).String())
+
+	// Summary without samples.
+	s2, err := ParseSummaryFromString(NewSummary([]float64{0.5}, time.Minute).String())
+	require.NoError(t, err)
+	assert.Equal(t, "summary:sum:0|count:0|q:0.5|qv:NaN", s2.String())
+
+	for _, str := range []string{
+		"dist:sum:55|count:10",
+		"summary:sum:55|count:a|q:0.5|qv:5",
+		"summary:sum:55|count:10|q:0.5,0.9|qv:5",
+		"summary:sum:55|count:10|q:0.5|qv:5|lb:1",
+	} {
+		_, err := ParseSummaryFromString(str)
+		assert.Error(t, err, str)
+	}
+}
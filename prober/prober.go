@@ -23,6 +23,7 @@ package prober
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -35,6 +36,7 @@ import (
 
 	configpb "github.com/cloudprober/cloudprober/config/proto"
 	"github.com/cloudprober/cloudprober/config/runconfig"
+	rdsclient "github.com/cloudprober/cloudprober/internal/rds/client"
 	rdsserver "github.com/cloudprober/cloudprober/internal/rds/server"
 	"github.com/cloudprober/cloudprober/internal/servers"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
@@ -78,6 +80,28 @@ type Prober struct {
 	// dataChan for passing metrics between probes and main goroutine.
 	dataChan chan *metrics.EventMetrics
 
+	// Channel for the requests to drain dataChan, used during shutdown.
+	drainReqChan chan chan struct{}
+
+	// Set on shutdown, to stop starting new probes. Protected by mu.
+	shuttingDown bool
+
+	// WaitGroup for the running probes.
+	probesWG sync.WaitGroup
+
+	// Labels added to all the metrics: default_labels and the vantage label.
+	defaultLabels map[string]string
+
+	// Watchdog, if configured.
+	watchdog *watchdog
+
+	// Local RDS server, if configured.
+	rdsServer *rdsserver.Server
+
+	// Result sinks registered by the embedding programs.
+	sinksMu sync.RWMutex
+	sinks   []*resultSink
+
 	// Required for all gRPC server implementations.
 	spb.UnimplementedCloudproberServer
 }
@@ -110,6 +134,14 @@ func (pr *Prober) addProbe(p *probes_configpb.ProbeDef) error {
 		return status.Errorf(codes.AlreadyExists, "probe %s is already defined", p.GetName())
 	}
 
+	if pr.c.GetVantage() != "" {
+		for _, al := range p.GetAdditionalLabel() {
+			if al.GetKey() == pr.c.GetVantageLabelKey() {
+				return status.Errorf(codes.InvalidArgument, "probe %s: additional_label %s clashes with the vantage label", p.GetName(), al.GetKey())
+			}
+		}
+	}
+
 	opts, err := options.BuildProbeOptions(p, pr.ldLister, pr.c.GetGlobalTargetsOptions(), pr.l)
 	if err != nil {
 		return status.Errorf(codes.Unknown, err.Error())
@@ -146,12 +178,27 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 			return err
 		}
 
+		pr.rdsServer = rdsServer
 		runconfig.SetLocalRDSServer(rdsServer)
 		if srv != nil {
 			rdsServer.RegisterWithGRPC(srv)
 		}
 	}
 
+	options.SetGlobalRateLimit(float64(pr.c.GetGlobalMaxRequestsPerSec()))
+
+	defaultLabels, err := buildDefaultLabels(pr.c)
+	if err != nil {
+		return err
+	}
+	pr.defaultLabels = defaultLabels
+
+	if pr.c.GetWatchdog() != nil {
+		if pr.watchdog, err = newWatchdog(pr.c.GetWatchdog(), pr.l); err != nil {
+			return err
+		}
+	}
+
 	// Initialize lameduck lister
 	globalTargetsOpts := pr.c.GetGlobalTargetsOptions()
 
@@ -169,8 +216,6 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 		}
 	}
 
-	var err error
-
 	// Initialize shared targets
 	for _, st := range pr.c.GetSharedTargets() {
 		tgts, err := targets.New(st.GetTargets(), pr.ldLister, globalTargetsOpts, pr.l, pr.l)
@@ -203,28 +248,86 @@ func (pr *Prober) Init(ctx context.Context, cfg *configpb.ProberConfig, l *logge
 	return nil
 }
 
+// buildDefaultLabels returns the labels to add to all the metrics:
+// default_labels from the config, and the vantage label if vantage is set.
+func buildDefaultLabels(c *configpb.ProberConfig) (map[string]string, error) {
+	if c.GetVantage() == "" {
+		return c.GetDefaultLabels(), nil
+	}
+
+	key := c.GetVantageLabelKey()
+	switch key {
+	case "":
+		return nil, errors.New("vantage_label_key cannot be empty")
+	case "probe", "ptype", "dst":
+		return nil, fmt.Errorf("vantage_label_key cannot be a probe label: %s", key)
+	}
+	if _, ok := c.GetDefaultLabels()[key]; ok {
+		return nil, fmt.Errorf("default_labels cannot have the vantage label key: %s", key)
+	}
+
+	labels := map[string]string{key: c.GetVantage()}
+	for k, v := range c.GetDefaultLabels() {
+		labels[k] = v
+	}
+	return labels, nil
+}
+
+// addDefaultLabels adds the given default labels to the EventMetrics, unless
+// EventMetrics already has a label with the same key.
+func addDefaultLabels(em *metrics.EventMetrics, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	existing := make(map[string]bool)
+	for _, k := range em.LabelsKeys() {
+		existing[k] = true
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if !existing[k] {
+			keys = append(keys, k)
+		}
+	}
+	// Sort for a deterministic label order.
+	sort.Strings(keys)
+	for _, k := range keys {
+		em.AddLabel(k, labels[k])
+	}
+}
+
 // Start starts a previously initialized Cloudprober.
 func (pr *Prober) Start(ctx context.Context) {
 	pr.dataChan = make(chan *metrics.EventMetrics, 100000)
+	pr.drainReqChan = make(chan chan struct{})
 
 	go func() {
-		var em *metrics.EventMetrics
 		for {
-			em = <-pr.dataChan
-
-			// Replicate the surfacer message to every surfacer we have
-			// registered. Note that s.Write() is expected to be
-			// non-blocking to avoid blocking of EventMetrics message
-			// processing.
-			for _, surfacer := range pr.Surfacers {
-				surfacer.Write(context.Background(), em)
+			select {
+			case em := <-pr.dataChan:
+				pr.processEventMetrics(em)
+			case done := <-pr.drainReqChan:
+				for len(pr.dataChan) > 0 {
+					pr.processEventMetrics(<-pr.dataChan)
+				}
+				close(done)
 			}
 		}
 	}()
 
+	if pr.watchdog != nil {
+		go pr.watchdog.run(ctx, pr.dataChan)
+	}
+
 	// Start a goroutine to export system variables
 	go sysvars.Start(ctx, pr.dataChan, time.Millisecond*time.Duration(pr.c.GetSysvarsIntervalMsec()), pr.c.GetSysvarsEnvVar())
 
+	// Export surfacers' queue stats, local RDS server's and RDS clients' stats
+	// along with the system variables.
+	go pr.exportInternalStats(ctx, time.Millisecond*time.Duration(pr.c.GetSysvarsIntervalMsec()))
+
 	// Start servers, each in its own goroutine
 	for _, s := range pr.Servers {
 		go s.Start(ctx, pr.dataChan)
@@ -253,13 +356,77 @@ func (pr *Prober) Start(ctx context.Context) {
 	}
 }
 
+// exportInternalStats exports the surfacers' queue stats, the local RDS
+// server's stats and the RDS clients' stats at the given interval, until the
+// context is canceled.
+func (pr *Prober) exportInternalStats(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ts := <-ticker.C:
+			for _, em := range surfacers.QueueStatsEventMetrics(pr.Surfacers, ts) {
+				pr.dataChan <- em
+			}
+			if pr.rdsServer != nil {
+				for _, em := range pr.rdsServer.Metrics() {
+					pr.dataChan <- em
+				}
+			}
+			for _, em := range rdsclient.AllMetrics() {
+				pr.dataChan <- em
+			}
+		}
+	}
+}
+
+// processEventMetrics adds default labels to the EventMetrics and writes it
+// to all the surfacers and result sinks.
+func (pr *Prober) processEventMetrics(em *metrics.EventMetrics) {
+	addDefaultLabels(em, pr.defaultLabels)
+
+	if pr.watchdog != nil {
+		pr.watchdog.observe(em)
+	}
+
+	// Replicate the surfacer message to every surfacer we have
+	// registered. Note that s.Write() is expected to be
+	// non-blocking to avoid blocking of EventMetrics message
+	// processing.
+	for _, surfacer := range pr.Surfacers {
+		surfacer.Write(context.Background(), em)
+	}
+	pr.writeToSinks(em)
+
+	if pr.c.GetWarnOnUnroutedMetrics() && !surfacers.Routed(pr.Surfacers, em) {
+		pr.l.Warningf("EventMetrics not selected by any surfacer: %s", em.String())
+	}
+}
+
 func (pr *Prober) startProbe(ctx context.Context, name string) {
 	pr.mu.Lock()
 	defer pr.mu.Unlock()
 
+	if pr.shuttingDown {
+		return
+	}
+
 	probeCtx, cancelFunc := context.WithCancel(ctx)
 	pr.probeCancelFunc[name] = cancelFunc
-	go pr.Probes[name].Start(probeCtx, pr.dataChan)
+	if pr.watchdog != nil {
+		pr.watchdog.probeStarted(name)
+	}
+	pr.probesWG.Add(1)
+	go func(p *probes.ProbeInfo) {
+		defer pr.probesWG.Done()
+		p.Start(probeCtx, pr.dataChan)
+	}(pr.Probes[name])
 }
 
 func randomDuration(duration, ceiling time.Duration) time.Duration {
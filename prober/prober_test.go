@@ -15,6 +15,7 @@
 package prober
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,6 +23,14 @@ import (
 	"time"
 
 	configpb "github.com/cloudprober/cloudprober/config/proto"
+	rdsclient "github.com/cloudprober/cloudprober/internal/rds/client"
+	rdsclientpb "github.com/cloudprober/cloudprober/internal/rds/client/proto"
+	rdsfile "github.com/cloudprober/cloudprober/internal/rds/file"
+	rdsfilepb "github.com/cloudprober/cloudprober/internal/rds/file/proto"
+	rdspb "github.com/cloudprober/cloudprober/internal/rds/proto"
+	rdsserver "github.com/cloudprober/cloudprober/internal/rds/server"
+	rdsserverpb "github.com/cloudprober/cloudprober/internal/rds/server/proto"
+	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/probes"
 	probespb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/stretchr/testify/assert"
@@ -146,3 +155,175 @@ probe: {
 		})
 	}
 }
+
+func TestAddDefaultLabels(t *testing.T) {
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(1)).
+		AddLabel("ptype", "http").
+		AddLabel("instance", "probe-set")
+
+	addDefaultLabels(em, map[string]string{
+		"instance":   "cp-01",
+		"datacenter": "us-east1",
+		"zone":       "b",
+	})
+	assert.Equal(t, []string{"ptype", "instance", "datacenter", "zone"}, em.LabelsKeys())
+	assert.Equal(t, "probe-set", em.Label("instance"), "probe label should override the default")
+	assert.Equal(t, "us-east1", em.Label("datacenter"))
+
+	em = metrics.NewEventMetrics(time.Now()).AddLabel("ptype", "http")
+	addDefaultLabels(em, nil)
+	assert.Equal(t, []string{"ptype"}, em.LabelsKeys())
+}
+
+func TestBuildDefaultLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "no_vantage",
+			cfg:  `default_labels { key: "env" value: "prod" }`,
+			want: map[string]string{"env": "prod"},
+		},
+		{
+			name: "vantage",
+			cfg: `
+				default_labels { key: "env" value: "prod" }
+				vantage: "us-east1"`,
+			want: map[string]string{"env": "prod", "vantage": "us-east1"},
+		},
+		{
+			name: "vantage_label_key",
+			cfg: `
+				vantage: "us-east1"
+				vantage_label_key: "src_region"`,
+			want: map[string]string{"src_region": "us-east1"},
+		},
+		{
+			name: "clash_with_default_labels",
+			cfg: `
+				default_labels { key: "vantage" value: "x" }
+				vantage: "us-east1"`,
+			wantErr: true,
+		},
+		{
+			name: "probe_label",
+			cfg: `
+				vantage: "us-east1"
+				vantage_label_key: "dst"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &configpb.ProberConfig{}
+			assert.NoError(t, prototext.Unmarshal([]byte(tt.cfg), cfg))
+
+			got, err := buildDefaultLabels(cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAddProbeVantageLabelClash(t *testing.T) {
+	pr := &Prober{
+		c: &configpb.ProberConfig{
+			Vantage: proto.String("us-east1"),
+		},
+		Probes: make(map[string]*probes.ProbeInfo),
+	}
+	err := pr.addProbe(&probespb.ProbeDef{
+		Name: proto.String("test-probe"),
+		Type: probespb.ProbeDef_USER_DEFINED.Enum(),
+		AdditionalLabel: []*probespb.AdditionalLabel{
+			{Key: proto.String("vantage"), Value: proto.String("eu-west1")},
+		},
+	})
+	assert.ErrorContains(t, err, "vantage")
+	assert.Empty(t, pr.Probes)
+}
+
+func TestExportInternalStats(t *testing.T) {
+	resourcesFile := filepath.Join(t.TempDir(), "targets.textpb")
+	if err := os.WriteFile(resourcesFile, []byte(`
+		resource { name: "web-1" ip: "10.0.0.1" }
+		resource { name: "web-2" ip: "10.0.0.2" }
+		resource { name: "db-1" ip: "10.0.0.3" }
+	`), 0644); err != nil {
+		t.Fatalf("error writing resources file: %v", err)
+	}
+
+	fp, err := rdsfile.New(&rdsfilepb.ProviderConfig{FilePath: []string{resourcesFile}}, nil)
+	if err != nil {
+		t.Fatalf("error creating file provider: %v", err)
+	}
+	srv, err := rdsserver.New(context.Background(), &rdsserverpb.ServerConf{}, map[string]rdsserver.Provider{"file": fp}, nil)
+	if err != nil {
+		t.Fatalf("error creating RDS server: %v", err)
+	}
+	if _, err := srv.ListResources(context.Background(), &rdspb.ListResourcesRequest{
+		Provider: proto.String("file"),
+		Filter:   []*rdspb.Filter{{Key: proto.String("name"), Value: proto.String("web-.*")}},
+	}); err != nil {
+		t.Fatalf("error listing resources: %v", err)
+	}
+
+	pr := &Prober{
+		rdsServer: srv,
+		dataChan:  make(chan *metrics.EventMetrics, 10),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pr.exportInternalStats(ctx, 10*time.Millisecond)
+
+	select {
+	case em := <-pr.dataChan:
+		assert.Equal(t, "rds", em.Label("ptype"))
+		assert.Equal(t, "name=web-.*", em.Label("filter"))
+		assert.Equal(t, int64(3), em.Metric("total_resources").(metrics.NumValue).Int64())
+		assert.Equal(t, int64(2), em.Metric("matched_resources").(metrics.NumValue).Int64())
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for RDS server's metrics")
+	}
+}
+
+func TestExportInternalStatsRDSClient(t *testing.T) {
+	listResources := func(context.Context, *rdspb.ListResourcesRequest) (*rdspb.ListResourcesResponse, error) {
+		return &rdspb.ListResourcesResponse{}, nil
+	}
+	if _, err := rdsclient.New(&rdsclientpb.ClientConf{
+		Request:       &rdspb.ListResourcesRequest{ResourcePath: proto.String("test-export")},
+		ReEvalSec:     proto.Int32(0),
+		ReportChanges: proto.Bool(true),
+	}, listResources, nil); err != nil {
+		t.Fatalf("error creating RDS client: %v", err)
+	}
+
+	pr := &Prober{dataChan: make(chan *metrics.EventMetrics, 10)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pr.exportInternalStats(ctx, 10*time.Millisecond)
+
+	for {
+		select {
+		case em := <-pr.dataChan:
+			if em.Label("resource_path") != "test-export" {
+				continue
+			}
+			assert.Equal(t, "rds_client", em.Label("ptype"))
+			assert.NotNil(t, em.Metric("rds_client_target_changes"), "rds_client_target_changes not exported")
+			return
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for RDS client's metrics")
+		}
+	}
+}
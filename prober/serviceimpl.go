@@ -69,6 +69,9 @@ func (pr *Prober) RemoveProbe(ctx context.Context, req *pb.RemoveProbeRequest) (
 
 	pr.probeCancelFunc[name]()
 	delete(pr.Probes, name)
+	if pr.watchdog != nil {
+		pr.watchdog.probeStopped(name)
+	}
 
 	if *probesConfigSavePath != "" {
 		pr.saveProbesConfigUnprotected(*probesConfigSavePath)
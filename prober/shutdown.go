@@ -0,0 +1,150 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/surfacers"
+)
+
+// stopProbeRuns stops new probe runs and waits for the in-flight runs to
+// complete, or for the context to be canceled.
+func (pr *Prober) stopProbeRuns(ctx context.Context) error {
+	pr.mu.Lock()
+	probeOpts := make(map[string]*options.Options, len(pr.Probes))
+	for name, p := range pr.Probes {
+		if p.Options != nil {
+			probeOpts[name] = p.Options
+		}
+	}
+	pr.mu.Unlock()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(probeOpts))
+	for name, opts := range probeOpts {
+		wg.Add(1)
+		go func(name string, opts *options.Options) {
+			defer wg.Done()
+			if err := opts.StopRuns(ctx); err != nil {
+				errs <- fmt.Errorf("probe %s: %v", name, err)
+			}
+		}(name, opts)
+	}
+	wg.Wait()
+	close(errs)
+
+	var allErrs []error
+	for err := range errs {
+		allErrs = append(allErrs, err)
+	}
+	return errors.Join(allErrs...)
+}
+
+// waitForProbes waits for the running probes to return, or for the context
+// to be canceled.
+func (pr *Prober) waitForProbes(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		pr.probesWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drainData processes the EventMetrics pending in the data channel.
+func (pr *Prober) drainData(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case pr.drainReqChan <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown shuts down the prober gracefully:
+//   - It stops the probes from starting new runs, and waits for the in-flight
+//     probe runs to complete.
+//   - It cancels the probes and waits for them to return.
+//   - It sends the EventMetrics pending in the pipeline to the surfacers.
+//   - It flushes the surfacers that buffer EventMetrics.
+//
+// Probes are given at most half of the time left before ctx's deadline, so
+// that there is time to flush the surfacers even if probes don't return
+// in time. Shutdown doesn't stop the servers and the surfacers; they stop
+// when the context passed to Init and Start is canceled.
+func (pr *Prober) Shutdown(ctx context.Context) error {
+	pr.mu.Lock()
+	pr.shuttingDown = true
+	pr.mu.Unlock()
+
+	probesCtx := ctx
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		probesCtx, cancel = context.WithTimeout(ctx, time.Until(deadline)/2)
+		defer cancel()
+	}
+	if err := pr.stopProbeRuns(probesCtx); err != nil {
+		pr.l.Warningf("In-flight probe runs didn't complete in time: %v", err)
+	}
+
+	pr.mu.Lock()
+	for _, cancelFunc := range pr.probeCancelFunc {
+		cancelFunc()
+	}
+	pr.mu.Unlock()
+
+	if err := pr.waitForProbes(probesCtx); err != nil {
+		pr.l.Warningf("Probes didn't stop in time: %v, flushing the surfacers anyway", err)
+	}
+
+	// Metrics pipeline is not running if prober was never started.
+	if pr.drainReqChan == nil {
+		return nil
+	}
+	if err := pr.drainData(ctx); err != nil {
+		return fmt.Errorf("error sending pending metrics to the surfacers: %v", err)
+	}
+
+	var errs []error
+	for _, si := range pr.Surfacers {
+		f, ok := si.Surfacer.(surfacers.Flusher)
+		if !ok {
+			continue
+		}
+		if err := f.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error flushing surfacer (type: %s, name: %s): %v", si.Type, si.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
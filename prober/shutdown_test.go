@@ -0,0 +1,216 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes"
+	"github.com/cloudprober/cloudprober/probes/options"
+	probespb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/surfacers"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// runningProbe exports an EventMetrics on every run. On cancelation, it
+// finishes its in-flight run, unless it's stuck.
+type runningProbe struct {
+	name  string
+	stuck bool
+}
+
+func (p *runningProbe) Init(string, *options.Options) error { return nil }
+
+func (p *runningProbe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	for run := int64(1); ; run++ {
+		time.Sleep(10 * time.Millisecond)
+		dataChan <- metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(run)).
+			AddLabel("probe", p.name)
+
+		if ctx.Err() != nil {
+			if p.stuck {
+				select {}
+			}
+			return
+		}
+	}
+}
+
+// testSurfacer buffers the EventMetrics until they are flushed.
+type testSurfacer struct {
+	mu       sync.Mutex
+	buffered []*metrics.EventMetrics
+	flushed  []*metrics.EventMetrics
+}
+
+func (s *testSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.buffered = append(s.buffered, em)
+}
+
+func (s *testSurfacer) Flush(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flushed = append(s.flushed, s.buffered...)
+	s.buffered = nil
+	return nil
+}
+
+// lastTotal returns the last flushed "total" for the probe.
+func (s *testSurfacer) lastTotal(probe string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var total int64
+	for _, em := range s.flushed {
+		if em.Label("probe") == probe {
+			total = em.Metric("total").(metrics.NumValue).Int64()
+		}
+	}
+	return total
+}
+
+func testProberWithProbes(ps ...*runningProbe) (*Prober, *testSurfacer) {
+	s := &testSurfacer{}
+	pr := &Prober{
+		c: &configpb.ProberConfig{
+			DisableJitter:       proto.Bool(true),
+			SysvarsIntervalMsec: proto.Int32(3600000),
+		},
+		l:               &logger.Logger{},
+		Probes:          make(map[string]*probes.ProbeInfo),
+		Surfacers:       []*surfacers.SurfacerInfo{{Surfacer: s, Type: "test"}},
+		probeCancelFunc: make(map[string]context.CancelFunc),
+	}
+	for _, p := range ps {
+		pr.Probes[p.name] = &probes.ProbeInfo{Probe: p, Name: p.name}
+	}
+	return pr, s
+}
+
+func TestShutdown(t *testing.T) {
+	pr, s := testProberWithProbes(&runningProbe{name: "p1"}, &runningProbe{name: "p2"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	assert.NoError(t, pr.Shutdown(shutdownCtx))
+
+	// Metrics from the last runs are flushed.
+	for _, name := range []string{"p1", "p2"} {
+		assert.Greater(t, s.lastTotal(name), int64(1), "probe: %s", name)
+	}
+
+	// No probes are started after shutdown.
+	pr.startProbe(ctx, "p1")
+	assert.NoError(t, pr.waitForProbes(shutdownCtx))
+}
+
+func TestShutdownTimeout(t *testing.T) {
+	pr, s := testProberWithProbes(&runningProbe{name: "p1"}, &runningProbe{name: "stuck", stuck: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+
+	// Shutdown doesn't wait for the stuck probe for more than half of the
+	// timeout, and still flushes the surfacers.
+	start := time.Now()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancelShutdown()
+	assert.NoError(t, pr.Shutdown(shutdownCtx))
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+
+	assert.Greater(t, s.lastTotal("p1"), int64(1))
+	assert.Greater(t, s.lastTotal("stuck"), int64(1))
+}
+
+// slowRunProbe has long runs, tracked using options.BeginRun. It records the
+// runs that were canceled before completing.
+type slowRunProbe struct {
+	opts     *options.Options
+	canceled atomic.Int32
+	runs     atomic.Int32
+}
+
+func (p *slowRunProbe) Init(_ string, opts *options.Options) error {
+	p.opts = opts
+	return nil
+}
+
+func (p *slowRunProbe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	for ctx.Err() == nil {
+		endRun, ok := p.opts.BeginRun()
+		if !ok {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+		select {
+		case <-time.After(200 * time.Millisecond):
+			p.runs.Add(1)
+		case <-ctx.Done():
+			p.canceled.Add(1)
+		}
+		endRun()
+	}
+}
+
+func TestShutdownInFlightRuns(t *testing.T) {
+	opts, err := options.BuildProbeOptions(&probespb.ProbeDef{
+		Name: proto.String("slow"),
+		Type: probespb.ProbeDef_USER_DEFINED.Enum(),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_DummyTargets{},
+		},
+	}, nil, nil, nil)
+	assert.NoError(t, err)
+
+	p := &slowRunProbe{}
+	assert.NoError(t, p.Init("slow", opts))
+
+	pr, _ := testProberWithProbes()
+	pr.Probes["slow"] = &probes.ProbeInfo{Probe: p, Name: "slow", Options: opts}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr.Start(ctx)
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+	assert.NoError(t, pr.Shutdown(shutdownCtx))
+
+	// In-flight run completes before the probe is canceled, and Shutdown
+	// returns as soon as it's done.
+	assert.Equal(t, int32(1), p.runs.Load())
+	assert.Equal(t, int32(0), p.canceled.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
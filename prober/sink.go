@@ -0,0 +1,105 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"sync/atomic"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// ResultSinkBufferSize is the number of EventMetrics buffered for each result
+// sink. If a sink falls behind by more than these many EventMetrics, newer
+// EventMetrics are dropped for that sink.
+const ResultSinkBufferSize = 1000
+
+// ResultSink receives the EventMetrics as they are produced by probes (and
+// by other cloudprober modules, e.g. sysvars), alongside the surfacers. It
+// provides a way for the programs that embed cloudprober to get probe
+// results programmatically. Probe results can be told apart using the
+// "probe" label.
+//
+// Write is called from a goroutine dedicated to the sink, so it may block,
+// but it should not modify the EventMetrics as they are shared with the
+// surfacers.
+type ResultSink interface {
+	Write(em *metrics.EventMetrics)
+}
+
+// ResultSinkFunc is an adapter to use a function as a ResultSink.
+type ResultSinkFunc func(em *metrics.EventMetrics)
+
+// Write calls f(em).
+func (f ResultSinkFunc) Write(em *metrics.EventMetrics) {
+	f(em)
+}
+
+type resultSink struct {
+	sink    ResultSink
+	ch      chan *metrics.EventMetrics
+	dropped atomic.Int64
+}
+
+func (rs *resultSink) run() {
+	for em := range rs.ch {
+		rs.sink.Write(em)
+	}
+}
+
+// AddResultSink registers a result sink. Sink starts receiving EventMetrics
+// right away, and until the returned remove function is called. It's safe
+// to call AddResultSink concurrently, including when prober is running.
+func (pr *Prober) AddResultSink(sink ResultSink) (remove func()) {
+	rs := &resultSink{
+		sink: sink,
+		ch:   make(chan *metrics.EventMetrics, ResultSinkBufferSize),
+	}
+	go rs.run()
+
+	pr.sinksMu.Lock()
+	defer pr.sinksMu.Unlock()
+	pr.sinks = append(pr.sinks, rs)
+
+	return func() {
+		pr.sinksMu.Lock()
+		defer pr.sinksMu.Unlock()
+		for i, s := range pr.sinks {
+			if s == rs {
+				pr.sinks = append(pr.sinks[:i:i], pr.sinks[i+1:]...)
+				close(rs.ch)
+				return
+			}
+		}
+	}
+}
+
+// writeToSinks sends the EventMetrics to all the result sinks, without
+// blocking: if a sink's buffer is full, EventMetrics is dropped for that
+// sink.
+func (pr *Prober) writeToSinks(em *metrics.EventMetrics) {
+	pr.sinksMu.RLock()
+	defer pr.sinksMu.RUnlock()
+
+	for _, rs := range pr.sinks {
+		select {
+		case rs.ch <- em:
+		default:
+			// Log the first drop, and every 1000th after that.
+			if n := rs.dropped.Add(1); n%1000 == 1 {
+				pr.l.Warningf("Result sink is not keeping up, dropped %d EventMetrics so far", n)
+			}
+		}
+	}
+}
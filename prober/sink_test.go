@@ -0,0 +1,87 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEM(i int) *metrics.EventMetrics {
+	return metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(int64(i))).
+		AddLabel("probe", "probe-"+strconv.Itoa(i))
+}
+
+func TestResultSinks(t *testing.T) {
+	pr := &Prober{}
+
+	var mu sync.Mutex
+	var got []string
+	remove := pr.AddResultSink(ResultSinkFunc(func(em *metrics.EventMetrics) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, em.Label("probe"))
+	}))
+
+	// A sink that never returns shouldn't block the writer, or other sinks.
+	block := make(chan struct{})
+	defer close(block)
+	removeBlocked := pr.AddResultSink(ResultSinkFunc(func(em *metrics.EventMetrics) {
+		<-block
+	}))
+	defer removeBlocked()
+
+	n := ResultSinkBufferSize + 10
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < n; i++ {
+			pr.writeToSinks(testEM(i))
+			// Give the sink goroutine a chance to keep up.
+			if i%100 == 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("writeToSinks blocked")
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) == n
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "probe-0", got[0])
+	assert.Greater(t, pr.sinks[1].dropped.Load(), int64(0))
+
+	// No more writes after the sink is removed.
+	remove()
+	remove()
+	assert.Len(t, pr.sinks, 1)
+	pr.writeToSinks(testEM(n))
+	time.Sleep(10 * time.Millisecond)
+	mu.Lock()
+	assert.Len(t, got, n)
+	mu.Unlock()
+}
@@ -0,0 +1,172 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+const pipelineComponent = "pipeline"
+
+// watchdog emits a heartbeat through the metrics pipeline and checks that
+// the pipeline and the probes are making progress.
+type watchdog struct {
+	interval            time.Duration
+	stallThreshold      time.Duration
+	probeStallThreshold time.Duration
+	exitOnStall         bool
+	l                   *logger.Logger
+
+	// Last time an EventMetrics was processed by the pipeline, in unix nano.
+	lastProcessed atomic.Int64
+
+	mu            sync.Mutex
+	probeLastSeen map[string]time.Time
+
+	heartbeats *metrics.Int
+	stalls     *metrics.Map[int64]
+}
+
+func newWatchdog(c *configpb.Watchdog, l *logger.Logger) (*watchdog, error) {
+	wd := &watchdog{
+		interval:            time.Duration(c.GetIntervalSec()) * time.Second,
+		stallThreshold:      time.Duration(c.GetStallThresholdSec()) * time.Second,
+		probeStallThreshold: time.Duration(c.GetProbeStallThresholdSec()) * time.Second,
+		exitOnStall:         c.GetExitOnStall(),
+		l:                   l,
+		probeLastSeen:       make(map[string]time.Time),
+		heartbeats:          metrics.NewInt(0),
+		stalls:              metrics.NewMap("component"),
+	}
+	if wd.interval <= 0 {
+		return nil, fmt.Errorf("watchdog: interval_sec (%d) should be positive", c.GetIntervalSec())
+	}
+	if wd.stallThreshold <= wd.interval {
+		return nil, fmt.Errorf("watchdog: stall_threshold_sec (%d) should be larger than interval_sec (%d)", c.GetStallThresholdSec(), c.GetIntervalSec())
+	}
+	if wd.probeStallThreshold < 0 {
+		return nil, fmt.Errorf("watchdog: probe_stall_threshold_sec (%d) cannot be negative", c.GetProbeStallThresholdSec())
+	}
+	wd.stalls.IncKeyBy(pipelineComponent, 0)
+	wd.lastProcessed.Store(time.Now().UnixNano())
+	return wd, nil
+}
+
+// observe records the progress indicated by the processed EventMetrics.
+func (wd *watchdog) observe(em *metrics.EventMetrics) {
+	wd.lastProcessed.Store(time.Now().UnixNano())
+	if wd.probeStallThreshold == 0 {
+		return
+	}
+
+	probe := em.Label("probe")
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	if _, ok := wd.probeLastSeen[probe]; ok {
+		wd.probeLastSeen[probe] = time.Now()
+	}
+}
+
+// probeStarted starts tracking the probe. Probe's grace period starts now.
+func (wd *watchdog) probeStarted(name string) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	wd.probeLastSeen[name] = time.Now()
+}
+
+// probeStopped stops tracking the probe.
+func (wd *watchdog) probeStopped(name string) {
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	delete(wd.probeLastSeen, name)
+}
+
+// check returns the stalled components, sorted by name.
+func (wd *watchdog) check(now time.Time) []string {
+	var stalled []string
+	if now.Sub(time.Unix(0, wd.lastProcessed.Load())) > wd.stallThreshold {
+		stalled = append(stalled, pipelineComponent)
+	}
+	if wd.probeStallThreshold == 0 {
+		return stalled
+	}
+
+	wd.mu.Lock()
+	defer wd.mu.Unlock()
+	var probes []string
+	for name, lastSeen := range wd.probeLastSeen {
+		if now.Sub(lastSeen) > wd.probeStallThreshold {
+			probes = append(probes, name)
+		}
+	}
+	sort.Strings(probes)
+	return append(stalled, probes...)
+}
+
+// runOnce runs the checks and emits the heartbeat.
+func (wd *watchdog) runOnce(ts time.Time, dataChan chan<- *metrics.EventMetrics) {
+	stalled := wd.check(ts)
+	for _, c := range stalled {
+		wd.stalls.IncKey(c)
+	}
+	wd.heartbeats.Inc()
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("watchdog_heartbeat", wd.heartbeats.Clone()).
+		AddMetric("watchdog_stalls", wd.stalls.Clone()).
+		AddLabel("ptype", "sysvars").
+		AddLabel("probe", "watchdog")
+
+	// Don't block on a stalled pipeline.
+	select {
+	case dataChan <- em:
+	default:
+		wd.l.Warning("Watchdog: metrics channel is full, dropping the heartbeat")
+	}
+
+	if len(stalled) == 0 {
+		return
+	}
+	if wd.exitOnStall {
+		wd.l.Criticalf("Watchdog: stall detected (components: %s), exiting", strings.Join(stalled, ","))
+	}
+	wd.l.Warningf("Watchdog: stall detected (components: %s)", strings.Join(stalled, ","))
+}
+
+// run runs the watchdog at the configured interval until the context is
+// canceled.
+func (wd *watchdog) run(ctx context.Context, dataChan chan<- *metrics.EventMetrics) {
+	ticker := time.NewTicker(wd.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ts := <-ticker.C:
+			wd.runOnce(ts, dataChan)
+		}
+	}
+}
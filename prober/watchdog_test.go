@@ -0,0 +1,121 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/config/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewWatchdog(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *configpb.Watchdog
+		wantErr bool
+	}{
+		{name: "default", c: &configpb.Watchdog{}},
+		{name: "probes", c: &configpb.Watchdog{ProbeStallThresholdSec: proto.Int32(600)}},
+		{name: "zero_interval", c: &configpb.Watchdog{IntervalSec: proto.Int32(0)}, wantErr: true},
+		{name: "small_threshold", c: &configpb.Watchdog{IntervalSec: proto.Int32(30), StallThresholdSec: proto.Int32(30)}, wantErr: true},
+		{name: "negative_probe_threshold", c: &configpb.Watchdog{ProbeStallThresholdSec: proto.Int32(-1)}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newWatchdog(test.c, &logger.Logger{})
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	wd, err := newWatchdog(&configpb.Watchdog{
+		IntervalSec:            proto.Int32(10),
+		StallThresholdSec:      proto.Int32(60),
+		ProbeStallThresholdSec: proto.Int32(120),
+	}, &logger.Logger{})
+	require.NoError(t, err)
+
+	wd.probeStarted("p1")
+	wd.probeStarted("p2")
+	wd.probeStarted("p3")
+	wd.probeStopped("p3")
+
+	now := time.Now()
+	assert.Empty(t, wd.check(now))
+
+	// p1 exports metrics, p2 doesn't.
+	later := now.Add(100 * time.Second)
+	wd.observe(metrics.NewEventMetrics(now).AddMetric("total", metrics.NewInt(1)).AddLabel("probe", "p1"))
+	wd.probeLastSeen["p2"] = now.Add(-time.Minute)
+	assert.Empty(t, wd.check(now.Add(30*time.Second)))
+	assert.Equal(t, []string{"pipeline", "p2"}, wd.check(later))
+
+	// Heartbeat is emitted, and stalls are counted.
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	wd.runOnce(now.Add(30*time.Second), dataChan)
+	wd.runOnce(later, dataChan)
+
+	em := <-dataChan
+	assert.Equal(t, "watchdog", em.Label("probe"))
+	assert.Equal(t, int64(1), em.Metric("watchdog_heartbeat").(metrics.NumValue).Int64())
+	assert.Equal(t, "map:component,pipeline:0", em.Metric("watchdog_stalls").String())
+
+	em = <-dataChan
+	assert.Equal(t, int64(2), em.Metric("watchdog_heartbeat").(metrics.NumValue).Int64())
+	assert.Equal(t, "map:component,p2:1,pipeline:1", em.Metric("watchdog_stalls").String())
+
+	// Heartbeat is dropped if the channel is full, instead of blocking.
+	wd.runOnce(now, make(chan *metrics.EventMetrics))
+}
+
+func TestWatchdogPipeline(t *testing.T) {
+	pr, s := testProberWithProbes(&runningProbe{name: "p1"})
+	wd, err := newWatchdog(&configpb.Watchdog{ProbeStallThresholdSec: proto.Int32(1)}, &logger.Logger{})
+	require.NoError(t, err)
+	wd.interval = 10 * time.Millisecond
+	wd.stallThreshold = 100 * time.Millisecond
+	pr.watchdog = wd
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pr.Start(ctx)
+	time.Sleep(200 * time.Millisecond)
+
+	// Pipeline processes the heartbeats and the probe's metrics.
+	assert.Empty(t, wd.check(time.Now()))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var heartbeats int
+	for _, em := range s.buffered {
+		if em.Label("probe") == "watchdog" {
+			heartbeats++
+		}
+	}
+	assert.Greater(t, heartbeats, 5)
+}
@@ -46,6 +46,14 @@ type ProbeResult interface {
 	Metrics(time.Time, *options.Options) *metrics.EventMetrics
 }
 
+// GaugeMetricsProbeResult is implemented by the probe results that export
+// some metrics, e.g. last measured values, in an independent GAUGE
+// EventMetrics, along with the regular (cumulative) metrics.
+type GaugeMetricsProbeResult interface {
+	// GaugeMetrics returns the GAUGE metrics, or nil if there are none yet.
+	GaugeMetrics(time.Time, *options.Options) *metrics.EventMetrics
+}
+
 type Scheduler struct {
 	ProbeName              string
 	DataChan               chan *metrics.EventMetrics
@@ -99,6 +107,7 @@ func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint
 	var runCnt int64
 
 	result := s.NewResult()
+	var throttledRuns, suppressedRuns, skippedUnchanged int64
 
 	ticker := time.NewTicker(s.Opts.Interval)
 	defer ticker.Stop()
@@ -108,10 +117,20 @@ func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint
 		if ctxDone(ctx) {
 			return
 		}
+		// Runs outside of the probe's schedule are suppressed, not failed. We
+		// keep exporting stats during that time so that suppressed (and
+		// skipped) runs are visible.
 		if !s.Opts.IsScheduled() {
-			continue
+			suppressedRuns++
+		} else if s.Opts.SkipUnchanged(target, ts) {
+			skippedUnchanged++
+		} else if endRun, ok := s.Opts.BeginRun(); ok {
+			if s.Opts.RateLimiter.Wait(ctx, 1, s.Opts.MaxRateLimitDelay()) > 0 {
+				throttledRuns++
+			}
+			s.RunProbeForTarget(ctx, target, result)
+			endRun()
 		}
-		s.RunProbeForTarget(ctx, target, result)
 
 		// Export stats if it's the time to do so.
 		runCnt++
@@ -119,8 +138,25 @@ func (s *Scheduler) startForTarget(ctx context.Context, target endpoint.Endpoint
 			em := result.Metrics(ts, s.Opts).
 				AddLabel("probe", s.ProbeName).
 				AddLabel("dst", target.Dst())
+			if s.Opts.RateLimiter != nil {
+				em.AddMetric("throttled_runs", metrics.NewInt(throttledRuns))
+			}
+			if s.Opts.Schedule != nil {
+				em.AddMetric("suppressed_runs", metrics.NewInt(suppressedRuns))
+			}
+			if s.Opts.ProbeChangedTargetsOnly() {
+				em.AddMetric(options.SkippedUnchangedMetricName, metrics.NewInt(skippedUnchanged))
+			}
 
 			s.Opts.RecordMetrics(target, em, s.DataChan)
+
+			if gr, ok := result.(GaugeMetricsProbeResult); ok {
+				if em := gr.GaugeMetrics(ts, s.Opts); em != nil {
+					em.Kind = metrics.GAUGE
+					em.AddLabel("probe", s.ProbeName).AddLabel("dst", target.Dst())
+					s.Opts.RecordMetrics(target, em, s.DataChan, options.WithNoAlert())
+				}
+			}
 		}
 	}
 }
@@ -135,6 +171,8 @@ func (s *Scheduler) Wait() {
 // concurrently by Start().
 func (s *Scheduler) refreshTargets(ctx context.Context) {
 	s.targets = s.Opts.Targets.ListEndpoints()
+	s.Opts.UpdateTargets(s.targets)
+	s.Opts.RecordEmptyTargets(time.Now(), s.ProbeName, s.DataChan)
 
 	s.Opts.Logger.Debugf("Probe(%s) got %d targets", s.ProbeName, len(s.targets))
 
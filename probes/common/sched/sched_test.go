@@ -24,8 +24,10 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/metrics/testutils"
 	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"google.golang.org/protobuf/proto"
 )
 
 type testProbeResult struct {
@@ -109,3 +111,97 @@ func TestUpdateTargetsAndStartProbes(t *testing.T) {
 	cancelF()
 	s.Wait()
 }
+
+func TestSuppressedRuns(t *testing.T) {
+	// Disable the probe all the time.
+	schedule, err := options.NewSchedule([]*configpb.Schedule{
+		{
+			Type:    configpb.Schedule_DISABLE.Enum(),
+			EndTime: proto.String("23:59"),
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("error creating schedule: %v", err)
+	}
+
+	s := &Scheduler{
+		ProbeName: "test-probe",
+		Opts: &options.Options{
+			Interval:            10 * time.Millisecond,
+			StatsExportInterval: 10 * time.Millisecond,
+			Schedule:            schedule,
+			LogMetrics:          func(_ *metrics.EventMetrics) {},
+			Logger:              &logger.Logger{},
+		},
+		DataChan:          make(chan *metrics.EventMetrics, 100),
+		NewResult:         func() ProbeResult { return &testProbeResult{} },
+		RunProbeForTarget: func(ctx context.Context, ep endpoint.Endpoint, r ProbeResult) { r.(*testProbeResult).total++ },
+	}
+	s.init()
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelF()
+	s.startForTarget(ctx, endpoint.Endpoint{Name: "test1.com"})
+
+	ems, _ := testutils.MetricsFromChannel(s.DataChan, 100, time.Millisecond)
+	if len(ems) == 0 {
+		t.Fatal("got no metrics during the suppressed period")
+	}
+	for _, em := range ems {
+		if total := em.Metric("total").(metrics.NumValue).Int64(); total != 0 {
+			t.Errorf("total=%d, want=0", total)
+		}
+	}
+	lastEM := ems[len(ems)-1]
+	if suppressed := lastEM.Metric("suppressed_runs").(metrics.NumValue).Int64(); suppressed != int64(len(ems)) {
+		t.Errorf("suppressed_runs=%d, want=%d", suppressed, len(ems))
+	}
+}
+
+type testGaugeProbeResult struct {
+	testProbeResult
+}
+
+func (tpr *testGaugeProbeResult) GaugeMetrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	if tpr.total < 2 {
+		return nil
+	}
+	return metrics.NewEventMetrics(ts).AddMetric("last", metrics.NewInt(int64(tpr.total)))
+}
+
+func TestGaugeMetrics(t *testing.T) {
+	s := &Scheduler{
+		ProbeName: "test-probe",
+		Opts: &options.Options{
+			Interval:            10 * time.Millisecond,
+			StatsExportInterval: 10 * time.Millisecond,
+			LogMetrics:          func(_ *metrics.EventMetrics) {},
+			Logger:              &logger.Logger{},
+		},
+		DataChan:          make(chan *metrics.EventMetrics, 100),
+		NewResult:         func() ProbeResult { return &testGaugeProbeResult{} },
+		RunProbeForTarget: func(ctx context.Context, ep endpoint.Endpoint, r ProbeResult) { r.(*testGaugeProbeResult).total++ },
+	}
+	s.init()
+
+	ctx, cancelF := context.WithTimeout(context.Background(), 35*time.Millisecond)
+	defer cancelF()
+	s.startForTarget(ctx, endpoint.Endpoint{Name: "test1.com"})
+
+	ems, _ := testutils.MetricsFromChannel(s.DataChan, 100, time.Millisecond)
+	var gaugeEMs []*metrics.EventMetrics
+	for _, em := range ems {
+		if em.Kind == metrics.GAUGE {
+			gaugeEMs = append(gaugeEMs, em)
+		}
+	}
+	// Gauge metrics are exported from the second run onwards.
+	if len(gaugeEMs) != len(ems)-len(gaugeEMs)-1 {
+		t.Fatalf("got %d gauge EventMetrics out of %d, want %d", len(gaugeEMs), len(ems), (len(ems)-1)/2)
+	}
+	for _, em := range gaugeEMs {
+		if em.Label("probe") != "test-probe" || em.Label("dst") != "test1.com" {
+			t.Errorf("gauge EventMetrics labels: probe=%s, dst=%s", em.Label("probe"), em.Label("dst"))
+		}
+	}
+}
@@ -33,7 +33,8 @@ type ProbeResult interface {
 	// working with metrics.EventMetrics.
 	Metrics() *metrics.EventMetrics
 
-	// Target returns the target associated with the probe result.
+	// Target returns the target associated with the probe result, identified
+	// by the target endpoint's ID().
 	Target() string
 }
 
@@ -77,7 +78,7 @@ func StatsKeeper(ctx context.Context, ptype, name string, opts *options.Options,
 			}
 		case ts := <-exportTicker.C:
 			for _, t := range targetsFunc() {
-				em := targetMetrics[t.Name]
+				em := targetMetrics[t.ID()]
 				if em != nil {
 					em.AddLabel("ptype", ptype)
 					em.AddLabel("probe", name)
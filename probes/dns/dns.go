@@ -33,6 +33,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
@@ -108,17 +109,33 @@ type probeRunResult struct {
 	latency           metrics.LatencyValue
 	timeouts          metrics.Int
 	validationFailure *metrics.Map[int64]
+	validationSuccess *metrics.Map[int64]
+	failures          *metrics.Map[int64]
+	throttledRuns     *metrics.Int
 	latencyMetricName string
+
+	// Validators for the target. Not exported as a metric.
+	validators []*validators.Validator
 }
 
 // Metrics converts probeRunResult into metrics.EventMetrics object
 func (prr probeRunResult) Metrics() *metrics.EventMetrics {
-	return metrics.NewEventMetrics(time.Now()).
+	em := metrics.NewEventMetrics(time.Now()).
 		AddMetric("total", &prr.total).
 		AddMetric("success", &prr.success).
 		AddMetric(prr.latencyMetricName, prr.latency.Clone()).
 		AddMetric("timeouts", &prr.timeouts).
 		AddMetric("validation_failure", prr.validationFailure)
+	if prr.validationSuccess != nil {
+		em.AddMetric("validation_success", prr.validationSuccess)
+	}
+	if prr.failures != nil {
+		em.AddMetric("failures", prr.failures)
+	}
+	if prr.throttledRuns != nil {
+		em.AddMetric("throttled_runs", prr.throttledRuns)
+	}
+	return em
 }
 
 // Target returns the p.target.
@@ -167,6 +184,9 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 	// may invoke methods on a net.Conn simultaneously.
 	p.client = new(clientImpl)
 	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
 		p.client.setSourceIP(p.opts.SourceIP)
 	}
 	// Use ReadTimeout because DialTimeout for UDP is not the RTT.
@@ -190,6 +210,7 @@ func isClientTimeout(err error) bool {
 func (p *Probe) validateResponse(resp *dns.Msg, target string, result *probeRunResult) bool {
 	if resp == nil || resp.Rcode != dns.RcodeSuccess {
 		p.l.Warningf("Target(%s): error in response %v", target, resp)
+		options.RecordFailure(result.failures, options.FailureStatus)
 		return false
 	}
 
@@ -199,10 +220,11 @@ func (p *Probe) validateResponse(resp *dns.Msg, target string, result *probeRunR
 	if minAnswers > 0 && uint32(len(resp.Answer)) < minAnswers {
 		p.l.Warningf("Target(%s): too few answers - got %d want %d.\n\tAnswerBlock: %v",
 			target, len(resp.Answer), minAnswers, resp.Answer)
+		options.RecordFailure(result.failures, options.FailureValidation)
 		return false
 	}
 
-	if p.opts.Validators != nil {
+	if result.validators != nil {
 		answers := []string{}
 		for _, rr := range resp.Answer {
 			if rr != nil {
@@ -211,9 +233,10 @@ func (p *Probe) validateResponse(resp *dns.Msg, target string, result *probeRunR
 		}
 		respBytes := []byte(strings.Join(answers, "\n"))
 
-		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: respBytes}, result.validationFailure, p.l)
+		failedValidations := validators.RunValidators(result.validators, &validators.Input{ResponseBody: respBytes}, result.validationFailure, result.validationSuccess, p.l)
 		if len(failedValidations) > 0 {
 			p.l.Debugf("Target(%s): validators %v failed. Resp: %v", target, failedValidations, answers)
+			options.RecordFailure(result.failures, options.FailureValidation)
 			return false
 		}
 	}
@@ -237,8 +260,10 @@ func (p *Probe) doDNSRequest(target string, result *probeRunResult, resultMu *sy
 		if isClientTimeout(err) {
 			p.l.Warningf("Target(%s): client.Exchange: Timeout error: %v", target, err)
 			result.timeouts.Inc()
+			options.RecordFailure(result.failures, options.FailureTimeout)
 		} else {
 			p.l.Warningf("Target(%s): client.Exchange: %v", target, err)
+			options.RecordFailure(result.failures, options.ClassifyError(err))
 		}
 	} else if p.validateResponse(resp, target, result) {
 		result.success.Inc()
@@ -246,7 +271,7 @@ func (p *Probe) doDNSRequest(target string, result *probeRunResult, resultMu *sy
 	}
 }
 
-func (p *Probe) runProbe(resultsChan chan<- statskeeper.ProbeResult) {
+func (p *Probe) runProbe(ctx context.Context, resultsChan chan<- statskeeper.ProbeResult) {
 	// Refresh the list of targets to probe.
 	p.targets = p.opts.Targets.ListEndpoints()
 
@@ -259,16 +284,19 @@ func (p *Probe) runProbe(resultsChan chan<- statskeeper.ProbeResult) {
 		go func(target endpoint.Endpoint, resultsChan chan<- statskeeper.ProbeResult) {
 			defer wg.Done()
 
+			vs := p.opts.ValidatorsForTarget(target)
 			result := probeRunResult{
-				target:            target.Name,
+				target:            target.ID(),
 				latencyMetricName: p.opts.LatencyMetricName,
-				validationFailure: validators.ValidationFailureMap(p.opts.Validators),
+				validationFailure: validators.ValidationFailureMap(vs),
+				validationSuccess: p.opts.NewValidationSuccess(vs),
+				failures:          p.opts.NewFailureReasons(),
+				validators:        vs,
 			}
 
-			if p.opts.LatencyDist != nil {
-				result.latency = p.opts.LatencyDist.CloneDist()
-			} else {
-				result.latency = metrics.NewFloat(0)
+			result.latency = p.opts.NewLatencyValue()
+			if p.opts.RateLimiter != nil {
+				result.throttledRuns = metrics.NewInt(0)
 			}
 
 			port := defaultPort
@@ -290,6 +318,9 @@ func (p *Probe) runProbe(resultsChan chan<- statskeeper.ProbeResult) {
 				ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
 				if err != nil {
 					p.l.Warningf("Target(%s): Resolve error: %v", target.Name, err)
+					if result.failures != nil {
+						result.failures.IncKeyBy(options.FailureDNS, int64(p.c.GetRequestsPerProbe()))
+					}
 					resultsChan <- result
 					return
 				}
@@ -301,6 +332,10 @@ func (p *Probe) runProbe(resultsChan chan<- statskeeper.ProbeResult) {
 				al.UpdateForTarget(target, ipLabel, port)
 			}
 
+			if p.opts.RateLimiter.Wait(ctx, int(p.c.GetRequestsPerProbe()), p.opts.MaxRateLimitDelay()) > 0 {
+				result.throttledRuns.Inc()
+			}
+
 			if p.c.GetRequestsPerProbe() == 1 {
 				p.doDNSRequest(fullTarget, &result, nil)
 				resultsChan <- result
@@ -359,7 +394,12 @@ func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics)
 		if !p.opts.IsScheduled() {
 			continue
 		}
+		endRun, ok := p.opts.BeginRun()
+		if !ok {
+			continue
+		}
 
-		p.runProbe(resultsChan)
+		p.runProbe(ctx, resultsChan)
+		endRun()
 	}
 }
@@ -15,6 +15,7 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"testing"
@@ -78,7 +79,7 @@ func runProbeAndVerify(t *testing.T, testName string, p *Probe, total, success i
 	p.targets = p.opts.Targets.ListEndpoints()
 
 	resultsChan := make(chan statskeeper.ProbeResult, len(p.targets))
-	p.runProbe(resultsChan)
+	p.runProbe(context.Background(), resultsChan)
 
 	// The resultsChan output iterates through p.targets in the same order.
 	for _, target := range p.targets {
@@ -0,0 +1,98 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// templateData is the data passed to the command argument templates.
+type templateData struct {
+	p  *Probe
+	ep endpoint.Endpoint
+
+	Probe  string
+	Name   string
+	Port   int
+	Labels map[string]string
+}
+
+// IP returns the target's IP address. Target is resolved only if the
+// template refers to the IP.
+func (td *templateData) IP() (string, error) {
+	if td.ep.IP != nil {
+		return td.ep.IP.String(), nil
+	}
+	addr, err := td.p.opts.Targets.Resolve(td.ep.Name, td.p.opts.IPVersion)
+	if err != nil {
+		return "", fmt.Errorf("error resolving target %s: %v", td.ep.Name, err)
+	}
+	return addr.String(), nil
+}
+
+// parseArgTemplates parses the command arguments that use the Go template
+// syntax. Returned slice has a nil entry for the arguments that don't.
+func parseArgTemplates(args []string) ([]*template.Template, error) {
+	var tmpls []*template.Template
+	found := false
+	for i, arg := range args {
+		var tmpl *template.Template
+		if strings.Contains(arg, "{{") {
+			var err error
+			tmpl, err = template.New(fmt.Sprintf("arg%d", i)).Option("missingkey=zero").Parse(arg)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing command argument (%s) as template: %v", arg, err)
+			}
+			found = true
+		}
+		tmpls = append(tmpls, tmpl)
+	}
+	if !found {
+		return nil, nil
+	}
+	return tmpls, nil
+}
+
+// executeArgTemplates replaces the templated arguments in args with their
+// values for the given target.
+func (p *Probe) executeArgTemplates(args []string, ep endpoint.Endpoint) error {
+	if p.argTemplates == nil {
+		return nil
+	}
+
+	td := &templateData{
+		p:      p,
+		ep:     ep,
+		Probe:  p.name,
+		Name:   ep.Name,
+		Port:   ep.Port,
+		Labels: ep.Labels,
+	}
+	for i, tmpl := range p.argTemplates {
+		if tmpl == nil {
+			continue
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, td); err != nil {
+			return err
+		}
+		args[i] = sb.String()
+	}
+	return nil
+}
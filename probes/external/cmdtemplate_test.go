@@ -0,0 +1,89 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package external
+
+import (
+	"net"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteArgTemplates(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		ep       endpoint.Endpoint
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name:     "no-templates",
+			args:     []string{"--host=@target@", "--verbose"},
+			ep:       endpoint.Endpoint{Name: "t1"},
+			wantArgs: []string{"--host=@target@", "--verbose"},
+		},
+		{
+			name: "all-fields",
+			args: []string{"--probe={{.Probe}}", "--host={{.Name}}:{{.Port}}", "--ip={{.IP}}", "--db={{.Labels.db}}", "--x={{.Labels.x}}"},
+			ep: endpoint.Endpoint{
+				Name:   "t1",
+				Port:   9313,
+				IP:     net.ParseIP("10.1.1.1"),
+				Labels: map[string]string{"db": "dbA"},
+			},
+			wantArgs: []string{"--probe=testProbe", "--host=t1:9313", "--ip=10.1.1.1", "--db=dbA", "--x="},
+		},
+		{
+			name:     "resolve-ip",
+			args:     []string{"{{.IP}}"},
+			ep:       endpoint.Endpoint{Name: "127.0.0.1"},
+			wantArgs: []string{"127.0.0.1"},
+		},
+		{
+			name:    "bad-template",
+			args:    []string{"{{.Name"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Probe{
+				name: "testProbe",
+				opts: &options.Options{Targets: targets.StaticTargets(tt.ep.Name)},
+			}
+
+			var err error
+			p.argTemplates, err = parseArgTemplates(tt.args)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Errorf("expected error, got none")
+			}
+
+			args := append([]string{}, tt.args...)
+			assert.NoError(t, p.executeArgTemplates(args, tt.ep))
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/cloudprober/cloudprober/common/strtemplate"
@@ -59,8 +60,11 @@ const maxScannerTokenSize = 256 * 1024
 
 type result struct {
 	total, success    int64
+	throttledRuns     int64
 	latency           metrics.LatencyValue
 	validationFailure *metrics.Map[int64]
+	validationSuccess *metrics.Map[int64]
+	failures          *metrics.Map[int64]
 }
 
 // Probe holds aggregate information about all probe runs, per-target.
@@ -74,6 +78,10 @@ type Probe struct {
 	c       *configpb.ProbeConf
 	l       *logger.Logger
 
+	// Templates for the command arguments using text/template syntax.
+	argTemplates []*template.Template
+	cmdTimeout   time.Duration
+
 	// book-keeping params
 	labelKeys    map[string]bool // Labels for substitution
 	requestID    int32
@@ -150,6 +158,19 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 	// Figure out labels we are interested in
 	p.updateLabelKeys()
 
+	if p.c.GetMode() == configpb.ProbeConf_ONCE {
+		if p.argTemplates, err = parseArgTemplates(p.cmdArgs); err != nil {
+			return err
+		}
+	}
+
+	if p.c.GetCommandTimeoutMsec() > 0 {
+		p.cmdTimeout = time.Duration(p.c.GetCommandTimeoutMsec()) * time.Millisecond
+		if p.cmdTimeout > p.opts.Timeout {
+			return fmt.Errorf("command_timeout_msec (%d) cannot be larger than the probe timeout (%s)", p.c.GetCommandTimeoutMsec(), p.opts.Timeout)
+		}
+	}
+
 	switch p.c.GetMode() {
 	case configpb.ProbeConf_ONCE:
 		p.mode = "once"
@@ -194,7 +215,7 @@ func (p *Probe) labels(ep endpoint.Endpoint) map[string]string {
 		labels["port"] = strconv.Itoa(ep.Port)
 	}
 	if p.labelKeys["address"] {
-		addr, err := p.opts.Targets.Resolve(ep.Name, p.opts.IPVersion)
+		addr, err := ep.Resolve(p.opts.IPVersion, p.opts.Targets)
 		if err != nil {
 			p.l.Warningf("Targets.Resolve(%v, %v) failed: %v ", ep.Name, p.opts.IPVersion, err)
 		} else if !addr.IsUnspecified() {
@@ -217,22 +238,33 @@ type probeStatus struct {
 	success bool
 	latency time.Duration
 	payload string
+
+	// failureReason is the reason for the failure, one of the standard
+	// failure reasons; options.FailureOther if not set.
+	failureReason string
 }
 
 func (p *Probe) processProbeResult(ps *probeStatus, result *result) {
 	if ps.success && p.opts.Validators != nil {
-		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(ps.payload)}, result.validationFailure, p.l)
+		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(ps.payload)}, result.validationFailure, result.validationSuccess, p.l)
 
 		// If any validation failed, log and set success to false.
 		if len(failedValidations) > 0 {
 			p.l.Debug("Target:", ps.target.Name, " failed validations: ", strings.Join(failedValidations, ","), ".")
 			ps.success = false
+			ps.failureReason = options.FailureValidation
 		}
 	}
 
 	if ps.success {
 		result.success++
 		result.latency.AddFloat64(ps.latency.Seconds() / p.opts.LatencyUnit.Seconds())
+	} else {
+		reason := ps.failureReason
+		if reason == "" {
+			reason = options.FailureOther
+		}
+		options.RecordFailure(result.failures, reason)
 	}
 
 	defaultEM := metrics.NewEventMetrics(time.Now()).
@@ -246,6 +278,15 @@ func (p *Probe) processProbeResult(ps *probeStatus, result *result) {
 	if p.opts.Validators != nil {
 		defaultEM.AddMetric("validation_failure", result.validationFailure)
 	}
+	if result.validationSuccess != nil {
+		defaultEM.AddMetric("validation_success", result.validationSuccess)
+	}
+	if result.failures != nil {
+		defaultEM.AddMetric("failures", result.failures.Clone())
+	}
+	if p.opts.RateLimiter != nil {
+		defaultEM.AddMetric("throttled_runs", metrics.NewInt(result.throttledRuns))
+	}
 	p.opts.RecordMetrics(ps.target, defaultEM, p.dataChan)
 
 	// If probe is configured to use the external process output (or reply payload
@@ -310,8 +351,10 @@ func (p *Probe) setupStreaming(c *exec.Cmd, target endpoint.Endpoint) error {
 	}()
 
 	go func() {
+		// Stream keeps track of the labels lines across output lines.
+		s := p.payloadParser.NewStream(target.Name)
 		for line := range stdout {
-			for _, em := range p.payloadParser.PayloadMetrics(line, target.Name) {
+			if em := s.LineMetrics(line); em != nil {
 				p.opts.RecordMetrics(target, em, p.dataChan, options.WithNoAlert())
 			}
 		}
@@ -323,11 +366,29 @@ func (p *Probe) setupStreaming(c *exec.Cmd, target endpoint.Endpoint) error {
 func (p *Probe) runOnceProbe(ctx context.Context) {
 	var wg sync.WaitGroup
 
+	// Semaphore to limit the number of concurrent commands.
+	var sem chan struct{}
+	if p.c.GetMaxConcurrency() > 0 {
+		sem = make(chan struct{}, p.c.GetMaxConcurrency())
+	}
+
 	for _, target := range p.targets {
 		wg.Add(1)
 		go func(target endpoint.Endpoint, result *result) {
 			defer wg.Done()
 
+			if sem != nil {
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					p.l.Warningf("Probe timed out before running command for target %s", target.Name)
+					result.total++
+					p.processProbeResult(&probeStatus{target: target, success: false, failureReason: options.FailureTimeout}, result)
+					return
+				}
+			}
+
 			args := append([]string{}, p.cmdArgs...)
 			if len(p.labelKeys) != 0 {
 				for i, arg := range p.cmdArgs {
@@ -339,11 +400,25 @@ func (p *Probe) runOnceProbe(ctx context.Context) {
 				}
 			}
 
-			p.l.Infof("Running external command: %s %s", p.cmdName, strings.Join(args, " "))
 			result.total++
+
+			if err := p.executeArgTemplates(args, target); err != nil {
+				p.l.Errorf("Error processing command arguments for target %s: %v", target.Name, err)
+				p.processProbeResult(&probeStatus{target: target, success: false}, result)
+				return
+			}
+
+			p.l.Infof("Running external command: %s %s", p.cmdName, strings.Join(args, " "))
 			startTime := time.Now()
 
-			c := exec.CommandContext(ctx, p.cmdName, args...)
+			cmdCtx := ctx
+			if p.cmdTimeout > 0 {
+				var cancel context.CancelFunc
+				cmdCtx, cancel = context.WithTimeout(ctx, p.cmdTimeout)
+				defer cancel()
+			}
+
+			c := exec.CommandContext(cmdCtx, p.cmdName, args...)
 			if p.envVars != nil {
 				c.Env = append(append(c.Env, os.Environ()...), p.envVars...)
 			}
@@ -359,11 +434,16 @@ func (p *Probe) runOnceProbe(ctx context.Context) {
 				c.Stdout, c.Stderr = &stdoutBuf, &stderrBuf
 			}
 
-			err := p.runCommand(ctx, c)
+			err := p.runCommand(cmdCtx, c)
 
 			success := true
+			var failureReason string
 			if err != nil {
 				success = false
+				failureReason = options.FailureOther
+				if cmdCtx.Err() != nil {
+					failureReason = options.FailureTimeout
+				}
 				stdout, stderr := stdoutBuf.String(), stderrBuf.String()
 				stderrout := ""
 				if stdout != "" || stderr != "" {
@@ -377,10 +457,11 @@ func (p *Probe) runOnceProbe(ctx context.Context) {
 			}
 
 			p.processProbeResult(&probeStatus{
-				target:  target,
-				success: success,
-				payload: stdoutBuf.String(),
-				latency: time.Since(startTime),
+				target:        target,
+				success:       success,
+				payload:       stdoutBuf.String(),
+				latency:       time.Since(startTime),
+				failureReason: failureReason,
 			}, result)
 		}(target, p.results[target.Key()])
 	}
@@ -395,16 +476,13 @@ func (p *Probe) updateTargets() {
 			continue
 		}
 
-		var latencyValue metrics.LatencyValue
-		if p.opts.LatencyDist != nil {
-			latencyValue = p.opts.LatencyDist.CloneDist()
-		} else {
-			latencyValue = metrics.NewFloat(0)
-		}
+		latencyValue := p.opts.NewLatencyValue()
 
 		p.results[target.Key()] = &result{
 			latency:           latencyValue,
 			validationFailure: validators.ValidationFailureMap(p.opts.Validators),
+			validationSuccess: p.opts.NewValidationSuccess(p.opts.Validators),
+			failures:          p.opts.NewFailureReasons(),
 		}
 
 		for _, al := range p.opts.AdditionalLabels {
@@ -414,11 +492,19 @@ func (p *Probe) updateTargets() {
 }
 
 func (p *Probe) runProbe(startCtx context.Context) {
+	p.updateTargets()
+
+	// Wait for the rate limiter before starting the probe timeout, so that
+	// throttling doesn't eat into the command's time.
+	if p.opts.RateLimiter.Wait(startCtx, len(p.targets), p.opts.MaxRateLimitDelay()) > 0 {
+		for _, target := range p.targets {
+			p.results[target.Key()].throttledRuns++
+		}
+	}
+
 	probeCtx, cancelFunc := context.WithTimeout(startCtx, p.opts.Timeout)
 	defer cancelFunc()
 
-	p.updateTargets()
-
 	if p.mode == "server" {
 		p.runServerProbe(probeCtx, startCtx)
 	} else {
@@ -443,7 +529,12 @@ func (p *Probe) Start(startCtx context.Context, dataChan chan *metrics.EventMetr
 		if !p.opts.IsScheduled() {
 			continue
 		}
+		endRun, ok := p.opts.BeginRun()
+		if !ok {
+			continue
+		}
 
 		p.runProbe(startCtx)
+		endRun()
 	}
 }
@@ -37,6 +37,7 @@ import (
 	"github.com/cloudprober/cloudprober/common/strtemplate"
 	serverpb "github.com/cloudprober/cloudprober/probes/external/proto"
 	"github.com/cloudprober/cloudprober/probes/external/serverutils"
+	"github.com/cloudprober/cloudprober/probes/options"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"google.golang.org/protobuf/proto"
 )
@@ -274,6 +275,6 @@ func (p *Probe) runServerProbe(ctx, startCtx context.Context) {
 	outstandingReqsMu.Lock()
 	defer outstandingReqsMu.Unlock()
 	for _, req := range outstandingReqs {
-		p.processProbeResult(&probeStatus{target: req.target, success: false}, p.results[req.target.Key()])
+		p.processProbeResult(&probeStatus{target: req.target, success: false, failureReason: options.FailureTimeout}, p.results[req.target.Key()])
 	}
 }
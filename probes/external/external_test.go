@@ -38,6 +38,7 @@ import (
 	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -531,6 +532,39 @@ func TestProcessProbeResult(t *testing.T) {
 	}
 }
 
+func TestProcessProbeResultFailureReasons(t *testing.T) {
+	p := &Probe{}
+	opts := options.DefaultOptions()
+	opts.ExportFailureReasons = true
+	opts.ProbeConf = &configpb.ProbeConf{Command: proto.String("./testCommand")}
+	require.NoError(t, p.Init("testprobe", opts))
+	p.dataChan = make(chan *metrics.EventMetrics, 20)
+
+	r := &result{
+		latency:  metrics.NewFloat(0),
+		failures: p.opts.NewFailureReasons(),
+	}
+	target := endpoint.Endpoint{Name: "test-target"}
+	for _, ps := range []*probeStatus{
+		{target: target, success: true},
+		{target: target, failureReason: options.FailureTimeout},
+		{target: target},
+	} {
+		p.processProbeResult(ps, r)
+	}
+
+	assert.Equal(t, int64(1), r.failures.GetKey(options.FailureTimeout))
+	assert.Equal(t, int64(1), r.failures.GetKey(options.FailureOther))
+	assert.Equal(t, int64(0), r.failures.GetKey(options.FailureValidation))
+
+	var em *metrics.EventMetrics
+	for len(p.dataChan) > 0 {
+		em = <-p.dataChan
+	}
+	require.NotNil(t, em)
+	assert.Equal(t, "map:failure_reason,connect:0,dns:0,other:1,proxy:0,status:0,timeout:1,tls:0,validation:0", em.Metric("failures").String())
+}
+
 func TestCommandParsing(t *testing.T) {
 	p := createTestProbe("./test-command --flag1 one --flag23 \"two three\"", nil)
 
@@ -745,3 +779,32 @@ func TestMain(m *testing.M) {
 
 	os.Exit(status)
 }
+
+func TestInitCommandTimeout(t *testing.T) {
+	for _, tt := range []struct {
+		cmdTimeoutMsec int32
+		wantErr        bool
+	}{
+		{cmdTimeoutMsec: 0},
+		{cmdTimeoutMsec: 500},
+		{cmdTimeoutMsec: 2000, wantErr: true},
+	} {
+		t.Run(fmt.Sprintf("%d", tt.cmdTimeoutMsec), func(t *testing.T) {
+			p := &Probe{}
+			err := p.Init("testProbe", &options.Options{
+				ProbeConf: &configpb.ProbeConf{
+					Command:            proto.String("/test/cmd --host={{.Name}}"),
+					CommandTimeoutMsec: proto.Int32(tt.cmdTimeoutMsec),
+				},
+				Timeout: time.Second,
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, time.Duration(tt.cmdTimeoutMsec)*time.Millisecond, p.cmdTimeout)
+			assert.Len(t, p.argTemplates, 1)
+		})
+	}
+}
@@ -36,6 +36,7 @@ import (
 
 	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/oauth"
+	"github.com/cloudprober/cloudprober/internal/requestid"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
 	"github.com/cloudprober/cloudprober/internal/tlsconfig"
 	"github.com/cloudprober/cloudprober/internal/validators"
@@ -44,6 +45,7 @@ import (
 	configpb "github.com/cloudprober/cloudprober/probes/grpc/proto"
 	"github.com/cloudprober/cloudprober/probes/options"
 	"github.com/cloudprober/cloudprober/probes/probeutils"
+	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"google.golang.org/protobuf/proto"
 
@@ -52,14 +54,19 @@ import (
 	"github.com/fullstorydev/grpcurl"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/alts"
 	"google.golang.org/grpc/credentials/insecure"
 	grpcoauth "google.golang.org/grpc/credentials/oauth"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
 
 	// Import grpclb module so it can be used by name for DirectPath connections.
 	_ "google.golang.org/grpc/balancer/grpclb"
@@ -67,6 +74,10 @@ import (
 
 const loadBalancingPolicy = `{"loadBalancingConfig":[{"grpclb":{"childPolicy":[{"pick_first":{}}]}}]}`
 
+// minConnectTimeout is the gRPC default minimum connection timeout. We need
+// to set it explicitly when setting the connect backoff.
+const minConnectTimeout = 20 * time.Second
+
 // TargetsUpdateInterval controls frequency of target updates.
 var (
 	TargetsUpdateInterval = 1 * time.Minute
@@ -87,6 +98,9 @@ type Probe struct {
 	creds    credentials.TransportCredentials
 	descSrc  grpcurl.DescriptorSource
 
+	// If configured, each request carries a unique request ID.
+	requestIDs *requestid.Generator
+
 	// Targets and cancellation function for each target.
 	targets     []endpoint.Endpoint
 	cancelFuncs map[string]context.CancelFunc
@@ -109,7 +123,42 @@ type probeRunResult struct {
 	success           metrics.Int
 	latency           metrics.LatencyValue
 	connectErrors     metrics.Int
+	reconnects        metrics.Int
+	throttledRuns     metrics.Int
 	validationFailure *metrics.Map[int64]
+	validationSuccess *metrics.Map[int64]
+	failures          *metrics.Map[int64]
+
+	// Set only for the SERVER_STREAMING method.
+	firstMsgLatency metrics.LatencyValue
+	streamMsgs      metrics.Int
+	respBytes       metrics.Int
+}
+
+var errNotServing = errors.New("not serving")
+
+// failureReason maps the gRPC request errors to the standard failure
+// reasons.
+func failureReason(err error) string {
+	if errors.Is(err, errNotServing) {
+		return options.FailureStatus
+	}
+	if errors.Is(err, errStream) {
+		return options.FailureStream
+	}
+	if errors.Is(err, errTooFewMessages) {
+		return options.FailureValidation
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return options.FailureTimeout
+	case codes.Unavailable:
+		return options.FailureConnect
+	case codes.Unknown:
+		// Not a gRPC status error.
+		return options.ClassifyError(err)
+	}
+	return options.FailureStatus
 }
 
 func (p *Probe) transportCredentials() (credentials.TransportCredentials, error) {
@@ -140,6 +189,52 @@ func (p *Probe) transportCredentials() (credentials.TransportCredentials, error)
 	return credentials.NewClientTLSFromCert(nil, ""), nil
 }
 
+// connectionDialOpts returns the dial options for keepalive and connect
+// backoff, if configured.
+func (p *Probe) connectionDialOpts() []grpc.DialOption {
+	var dialOpts []grpc.DialOption
+
+	if ka := p.c.GetKeepalive(); ka != nil {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                time.Duration(ka.GetTimeSec()) * time.Second,
+			Timeout:             time.Duration(ka.GetTimeoutSec()) * time.Second,
+			PermitWithoutStream: ka.GetPermitWithoutStream(),
+		}))
+	}
+
+	if b := p.c.GetConnectBackoff(); b != nil {
+		dialOpts = append(dialOpts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  time.Duration(b.GetBaseDelayMsec()) * time.Millisecond,
+				Multiplier: float64(b.GetMultiplier()),
+				Jitter:     float64(b.GetJitter()),
+				MaxDelay:   time.Duration(b.GetMaxDelayMsec()) * time.Millisecond,
+			},
+			MinConnectTimeout: minConnectTimeout,
+		}))
+	}
+
+	return dialOpts
+}
+
+// watchReconnects counts the connection's transitions back to the READY
+// state, i.e. reconnects done by gRPC after the connection broke. It returns
+// when the connection is closed or context is canceled.
+func (p *Probe) watchReconnects(ctx context.Context, conn *grpc.ClientConn, result *probeRunResult) {
+	state := conn.GetState()
+	for conn.WaitForStateChange(ctx, state) {
+		state = conn.GetState()
+		switch state {
+		case connectivity.Shutdown:
+			return
+		case connectivity.Ready:
+			result.Lock()
+			result.reconnects.Inc()
+			result.Unlock()
+		}
+	}
+}
+
 // Init initializes the probe with the given params.
 func (p *Probe) Init(name string, opts *options.Options) error {
 	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
@@ -170,6 +265,7 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 
 	// Initialize dial options.
 	p.dialOpts = append(p.dialOpts, grpc.WithDefaultServiceConfig(loadBalancingPolicy))
+	p.dialOpts = append(p.dialOpts, p.connectionDialOpts()...)
 	oauthCfg := p.c.GetOauthConfig()
 	if oauthCfg != nil {
 		oauthTS, err := oauth.TokenSourceFromConfig(oauthCfg, p.l)
@@ -181,6 +277,8 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 
 	resolver.SetDefaultScheme("dns")
 
+	p.requestIDs = requestid.New(p.c.GetRequestId())
+
 	p.numConns = int(p.c.GetNumConns())
 	if p.numConns == 0 {
 		p.numConns = 1
@@ -200,6 +298,21 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 		}
 	}
 
+	if p.c.GetMethod() == configpb.ProbeConf_SERVER_STREAMING {
+		if err := p.initDescriptorSource(); err != nil {
+			return err
+		}
+		// If descriptor source is not set, method is verified on every
+		// request, using reflection.
+		if p.descSrc != nil {
+			if _, err := streamingMethod(p.descSrc, p.c.GetRequest()); err != nil {
+				return err
+			}
+		} else if p.c.GetRequest().GetCallServiceMethod() == "" {
+			return errors.New("call_service_method is required for SERVER_STREAMING method")
+		}
+	}
+
 	return nil
 }
 
@@ -268,9 +381,17 @@ func (p *Probe) connectWithRetry(ctx context.Context, target endpoint.Endpoint,
 		}
 	}
 
-	if target.Port > 0 {
-		addr = net.JoinHostPort(addr, strconv.Itoa(target.Port))
+	// If targets use a custom DNS server, we resolve the target name using
+	// that, and connect to the IP address, with target name as the authority.
+	resolveName := addr == target.Name && p.c.GetUriScheme() == "" && targets.DNSResolver(p.opts.Targets) != nil
+
+	withPort := func(host string) string {
+		if target.Port > 0 {
+			return net.JoinHostPort(host, strconv.Itoa(target.Port))
+		}
+		return host
 	}
+	addr = withPort(addr)
 
 	connectTimeout := p.opts.Timeout
 	if p.c.GetConnectTimeoutMsec() > 0 {
@@ -283,10 +404,27 @@ func (p *Probe) connectWithRetry(ctx context.Context, target endpoint.Endpoint,
 			return nil
 		default:
 		}
+		dialAddr, dialOpts := addr, p.dialOpts
+		if resolveName {
+			ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+			if err != nil {
+				p.l.WarningAttrs("Resolve error: "+err.Error(), logAttrs...)
+				result.Lock()
+				result.total.Inc()
+				options.RecordFailure(result.failures, options.FailureDNS)
+				result.Unlock()
+				time.Sleep(p.opts.Interval)
+				continue
+			}
+			dialAddr = withPort(ip.String())
+			dialOpts = append(slices.Clip(p.dialOpts), grpc.WithAuthority(addr))
+		}
+
 		connCtx, cancelFunc := context.WithTimeout(ctx, connectTimeout)
 
 		if uriScheme := p.c.GetUriScheme(); uriScheme != "" {
 			addr = uriScheme + addr
+			dialAddr = addr
 		}
 
 		// Note we use grpcurl.BlockingDial which uses WithBlock dial option which is
@@ -296,7 +434,7 @@ func (p *Probe) connectWithRetry(ctx context.Context, target endpoint.Endpoint,
 		// fluid, and come and go, but for  aprober it's important that
 		// connection is established before we start sending RPCs. We'll get a
 		// much better error message if connection fails.
-		conn, err := grpcurl.BlockingDial(connCtx, "tcp", addr, p.creds, p.dialOpts...)
+		conn, err := grpcurl.BlockingDial(connCtx, "tcp", dialAddr, p.creds, dialOpts...)
 		cancelFunc()
 
 		if err != nil {
@@ -309,6 +447,11 @@ func (p *Probe) connectWithRetry(ctx context.Context, target endpoint.Endpoint,
 		result.Lock()
 		result.total.Inc()
 		result.connectErrors.Inc()
+		if reason := options.ClassifyError(err); reason != options.FailureOther {
+			options.RecordFailure(result.failures, reason)
+		} else {
+			options.RecordFailure(result.failures, options.FailureConnect)
+		}
 		result.Unlock()
 
 		// Sleep before retrying connection.
@@ -334,7 +477,7 @@ func (p *Probe) healthCheckProbe(ctx context.Context, conn *grpc.ClientConn, log
 	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
 		p.l.WarningAttrs("gRPC HealthCheck status: "+resp.GetStatus().String(), logAttrs...)
 		if !p.c.GetHealthCheckIgnoreStatus() {
-			return resp, fmt.Errorf("not serving (%s)", resp.GetStatus())
+			return resp, fmt.Errorf("%w (%s)", errNotServing, resp.GetStatus())
 		}
 	}
 	return resp, nil
@@ -356,7 +499,15 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 	if conn == nil {
 		return
 	}
-	defer conn.Close()
+	// conn may be replaced if max_connection_age_sec is set.
+	defer func() {
+		if conn != nil {
+			conn.Close()
+		}
+	}()
+	go p.watchReconnects(ctx, conn, result)
+	connectedAt := time.Now()
+	maxConnAge := time.Duration(p.c.GetMaxConnectionAgeSec()) * time.Second
 
 	client := spb.NewProberClient(conn)
 	timeout := p.opts.Timeout
@@ -378,11 +529,47 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 		if !p.opts.IsScheduled() {
 			continue
 		}
+		endRun, ok := p.opts.BeginRun()
+		if !ok {
+			continue
+		}
+
+		if maxConnAge > 0 && time.Since(connectedAt) >= maxConnAge {
+			p.l.InfoAttrs("Max connection age reached, reconnecting", logAttrs...)
+			conn.Close()
+			if conn = p.connectWithRetry(ctx, tgt, result, logAttrs...); conn == nil {
+				ticker.Stop()
+				endRun()
+				return
+			}
+			go p.watchReconnects(ctx, conn, result)
+			connectedAt = time.Now()
+			client = spb.NewProberClient(conn)
+
+			result.Lock()
+			result.reconnects.Inc()
+			result.Unlock()
+		}
+
+		if p.opts.RateLimiter.Wait(ctx, 1, p.opts.MaxRateLimitDelay()) > 0 {
+			result.Lock()
+			result.throttledRuns.Inc()
+			result.Unlock()
+		}
 
 		reqCtx, cancelFunc := context.WithTimeout(ctx, timeout)
 
 		reqCtx = p.ctxWithHeaders(reqCtx)
 
+		var reqID string
+		if p.requestIDs != nil {
+			var headers map[string]string
+			reqID, headers = p.requestIDs.Headers()
+			for k, v := range headers {
+				reqCtx = metadata.AppendToOutgoingContext(reqCtx, k, v)
+			}
+		}
+
 		var delta time.Duration
 		start := time.Now()
 
@@ -395,6 +582,8 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 		var success bool
 		var err error
 		var r fmt.Stringer
+		var sr *streamResponse
+		var reason string
 
 		switch method {
 		case configpb.ProbeConf_ECHO:
@@ -407,6 +596,9 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 			r, err = p.healthCheckProbe(reqCtx, conn, logAttrs...)
 		case configpb.ProbeConf_GENERIC:
 			r, err = p.genericRequest(reqCtx, conn, p.c.GetRequest())
+		case configpb.ProbeConf_SERVER_STREAMING:
+			sr, err = p.serverStreamingRequest(reqCtx, conn, p.c.GetRequest(), opts...)
+			r = sr
 		default:
 			p.l.Criticalf("Method %v not implemented", method)
 		}
@@ -420,18 +612,34 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 			if peer.Addr != nil {
 				peerAddr = peer.Addr.String()
 			}
-			p.l.WarningAttrs(fmt.Sprintf("Request failed: %v. ConnState: %v", err, conn.GetState()), append(logAttrs, slog.String("peer", peerAddr))...)
+			failureAttrs := append(logAttrs, slog.String("peer", peerAddr))
+			if reqID != "" {
+				failureAttrs = append(failureAttrs, slog.String("request_id", reqID))
+			}
+			p.l.WarningAttrs(fmt.Sprintf("Request failed: %v. ConnState: %v", err, conn.GetState()), failureAttrs...)
+			reason = failureReason(err)
 		} else {
 			success = true
 			delta = time.Since(start)
 		}
 
 		if success && p.opts.Validators != nil {
-			failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(r.String())}, result.validationFailure, p.l)
+			var failedValidations []string
+			if sr != nil {
+				// For streams, every message is validated.
+				for _, msg := range sr.msgs {
+					if failedValidations = validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(msg)}, result.validationFailure, result.validationSuccess, p.l); len(failedValidations) > 0 {
+						break
+					}
+				}
+			} else {
+				failedValidations = validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(r.String())}, result.validationFailure, result.validationSuccess, p.l)
+			}
 
 			if len(failedValidations) > 0 {
 				p.l.DebugAttrs("Some validations failed", append(logAttrs, slog.String("failed_validations", strings.Join(failedValidations, ",")))...)
 				success = false
+				reason = options.FailureValidation
 			}
 		}
 
@@ -439,27 +647,41 @@ func (p *Probe) oneTargetLoop(ctx context.Context, tgt endpoint.Endpoint, index
 		result.total.Inc()
 		if success {
 			result.success.Inc()
+		} else if reason != "" {
+			options.RecordFailure(result.failures, reason)
 		}
 		result.latency.AddFloat64(delta.Seconds() / p.opts.LatencyUnit.Seconds())
+		if sr != nil {
+			result.streamMsgs.IncBy(int64(len(sr.msgs)))
+			result.respBytes.IncBy(sr.bytes)
+			if success {
+				result.firstMsgLatency.AddFloat64(sr.firstMsgDelay.Seconds() / p.opts.LatencyUnit.Seconds())
+			}
+		}
 		result.Unlock()
+		endRun()
 	}
 }
 
 func (p *Probe) newResult(tgt string) *probeRunResult {
-	var latencyValue metrics.LatencyValue
-	if p.opts.LatencyDist != nil {
-		latencyValue = p.opts.LatencyDist.CloneDist()
-	} else {
-		latencyValue = metrics.NewFloat(0)
-	}
+	latencyValue := p.opts.NewLatencyValue()
 
 	validationFailure := validators.ValidationFailureMap(p.opts.Validators)
 
-	return &probeRunResult{
+	result := &probeRunResult{
 		target:            tgt,
 		latency:           latencyValue,
 		validationFailure: validationFailure,
+		validationSuccess: p.opts.NewValidationSuccess(p.opts.Validators),
+	}
+
+	if p.c.GetMethod() == configpb.ProbeConf_SERVER_STREAMING {
+		result.firstMsgLatency = p.opts.NewLatencyValue()
+		result.failures = p.opts.NewFailureReasons(options.FailureStream)
+	} else {
+		result.failures = p.opts.NewFailureReasons()
 	}
+	return result
 }
 
 // ctxWitHeaders attaches a list of headers to the given context
@@ -509,14 +731,29 @@ func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics)
 				AddMetric("success", result.success.Clone()).
 				AddMetric(p.opts.LatencyMetricName, result.latency.Clone()).
 				AddMetric("connecterrors", result.connectErrors.Clone()).
+				AddMetric("reconnects", result.reconnects.Clone()).
 				AddLabel("ptype", "grpc").
 				AddLabel("probe", p.name).
 				AddLabel("dst", target.Dst())
+			if result.failures != nil {
+				em.AddMetric("failures", result.failures.Clone())
+			}
+			if p.opts.RateLimiter != nil {
+				em.AddMetric("throttled_runs", result.throttledRuns.Clone())
+			}
+			if result.firstMsgLatency != nil {
+				em.AddMetric("first_message_latency", result.firstMsgLatency.Clone()).
+					AddMetric("stream_messages", result.streamMsgs.Clone()).
+					AddMetric("response_bytes", result.respBytes.Clone())
+			}
 			result.Unlock()
 
 			if result.validationFailure != nil {
 				em.AddMetric("validation_failure", result.validationFailure)
 			}
+			if result.validationSuccess != nil {
+				em.AddMetric("validation_success", result.validationSuccess)
+			}
 
 			p.opts.RecordMetrics(target, em, dataChan)
 		}
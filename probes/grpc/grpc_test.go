@@ -233,6 +233,59 @@ func TestGRPCSuccess(t *testing.T) {
 	}
 }
 
+func TestConnectionDialOpts(t *testing.T) {
+	p := &Probe{c: &configpb.ProbeConf{}}
+	assert.Empty(t, p.connectionDialOpts(), "default")
+
+	p.c = &configpb.ProbeConf{
+		Keepalive:      &configpb.ProbeConf_KeepAlive{TimeSec: proto.Int32(300)},
+		ConnectBackoff: &configpb.ProbeConf_ConnectBackoff{},
+	}
+	assert.Len(t, p.connectionDialOpts(), 2)
+}
+
+func TestMaxConnectionAge(t *testing.T) {
+	addr, err := globalGRPCServer(0)
+	if err != nil {
+		t.Fatalf("Error initializing global config: %v", err)
+	}
+
+	p := &Probe{}
+	assert.NoError(t, p.Init("grpc-max-conn-age", &options.Options{
+		Targets:             targets.StaticTargets(addr),
+		Interval:            100 * time.Millisecond,
+		Timeout:             time.Second,
+		Logger:              &logger.Logger{},
+		StatsExportInterval: 500 * time.Millisecond,
+		LogMetrics:          func(em *metrics.EventMetrics) {},
+		ProbeConf: &configpb.ProbeConf{
+			NumConns:            proto.Int32(1),
+			InsecureTransport:   proto.Bool(true),
+			MaxConnectionAgeSec: proto.Int32(1),
+			Keepalive:           &configpb.ProbeConf_KeepAlive{TimeSec: proto.Int32(10)},
+			ConnectBackoff:      &configpb.ProbeConf_ConnectBackoff{},
+		},
+	}))
+
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Start(ctx, dataChan)
+	}()
+
+	ems, err := testutils.MetricsFromChannel(dataChan, 6, 5*time.Second)
+	assert.NoError(t, err)
+	cancel()
+	wg.Wait()
+
+	lastEM := ems[len(ems)-1]
+	assert.GreaterOrEqual(t, lastEM.Metric("reconnects").(*metrics.Int).Int64(), int64(1), "em: %s", lastEM.String())
+	assert.Equal(t, lastEM.Metric("total").(*metrics.Int).Int64(), lastEM.Metric("success").(*metrics.Int).Int64(), "em: %s", lastEM.String())
+}
+
 // TestConnectFailures attempts to connect to localhost:9 (discard port) and
 // checks that stats are exported once every connect timeout.
 // 2 connections, 0.5 connect attempt/sec/conn, stats exported every 6 sec
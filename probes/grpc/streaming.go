@@ -0,0 +1,150 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/grpc/proto"
+	"github.com/fullstorydev/grpcurl"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/dynamic"
+	"github.com/jhump/protoreflect/dynamic/grpcdynamic"
+	"github.com/jhump/protoreflect/grpcreflect"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+var (
+	// errStream is returned for the errors after the stream was established.
+	errStream = errors.New("stream error")
+	// errTooFewMessages is returned if stream has fewer messages than
+	// streaming.min_messages.
+	errTooFewMessages = errors.New("too few messages")
+)
+
+// streamResponse is the result of a server-streaming RPC.
+type streamResponse struct {
+	msgs          []string // Messages, in compact JSON format.
+	bytes         int64
+	firstMsgDelay time.Duration
+}
+
+func (sr *streamResponse) String() string {
+	if sr == nil {
+		return ""
+	}
+	return strings.Join(sr.msgs, "\n")
+}
+
+// streamingMethod returns the descriptor of the server-streaming method
+// specified in the request.
+func streamingMethod(descSrc grpcurl.DescriptorSource, req *configpb.GenericRequest) (*desc.MethodDescriptor, error) {
+	method := req.GetCallServiceMethod()
+	if method == "" {
+		return nil, errors.New("call_service_method is required for SERVER_STREAMING method")
+	}
+
+	d, err := descSrc.FindSymbol(method)
+	if err != nil {
+		return nil, fmt.Errorf("error finding method (%s): %v", method, err)
+	}
+	md, ok := d.(*desc.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", method)
+	}
+	if md.IsClientStreaming() || !md.IsServerStreaming() {
+		return nil, fmt.Errorf("%s is not a server-streaming method", method)
+	}
+	return md, nil
+}
+
+// serverStreamingRequest opens a server-streaming RPC, and reads messages
+// until the server completes the stream, or max_messages are received.
+func (p *Probe) serverStreamingRequest(ctx context.Context, conn *grpc.ClientConn, req *configpb.GenericRequest, opts ...grpc.CallOption) (*streamResponse, error) {
+	descSrc := p.descSrc
+	if descSrc == nil {
+		descSrc = grpcurl.DescriptorSourceFromServer(ctx, grpcreflect.NewClientAuto(ctx, conn))
+	}
+
+	md, err := streamingMethod(descSrc, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rf, formatter, err := grpcurl.RequestParserAndFormatter(grpcurl.FormatJSON, descSrc, strings.NewReader(req.GetBody()), grpcurl.FormatOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct parser and formatter: %v", err)
+	}
+	reqMsg := dynamic.NewMessageFactoryWithDefaults().NewMessage(md.GetInputType())
+	if err := rf.Next(reqMsg); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error parsing request body: %v", err)
+	}
+
+	// Stream is canceled once we are done reading it.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	start := time.Now()
+	stream, err := grpcdynamic.NewStub(conn).InvokeRpcServerStream(ctx, md, reqMsg, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sr := &streamResponse{}
+	maxMsgs := int(p.c.GetStreaming().GetMaxMessages())
+	for maxMsgs <= 0 || len(sr.msgs) < maxMsgs {
+		msg, err := stream.RecvMsg()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// Errors before the first message (e.g. a non-OK status from the
+			// server) are errors in opening the stream.
+			if len(sr.msgs) == 0 {
+				return sr, err
+			}
+			return sr, fmt.Errorf("%w: after %d messages: %v", errStream, len(sr.msgs), err)
+		}
+
+		if len(sr.msgs) == 0 {
+			sr.firstMsgDelay = time.Since(start)
+		}
+		sr.bytes += int64(proto.Size(protoadapt.MessageV2Of(msg)))
+
+		s, err := formatter(msg)
+		if err != nil {
+			return sr, fmt.Errorf("%w: error formatting message %d: %v", errStream, len(sr.msgs)+1, err)
+		}
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(s)); err != nil {
+			return sr, fmt.Errorf("%w: error compacting message JSON (%s): %v", errStream, s, err)
+		}
+		sr.msgs = append(sr.msgs, buf.String())
+	}
+
+	if minMsgs := int(p.c.GetStreaming().GetMinMessages()); len(sr.msgs) < minMsgs {
+		return sr, fmt.Errorf("%w: got %d messages, want at least %d", errTooFewMessages, len(sr.msgs), minMsgs)
+	}
+	return sr, nil
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/validators"
+	validators_configpb "github.com/cloudprober/cloudprober/internal/validators/proto"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/metrics/testutils"
+	configpb "github.com/cloudprober/cloudprober/probes/grpc/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+)
+
+// healthWatchConn returns a connection to a test server that implements the
+// gRPC health service, whose Watch method is a server-streaming RPC.
+func healthWatchConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, health.NewServer())
+	reflection.Register(s)
+	go s.Serve(ln)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial(ln.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestServerStreamingRequest(t *testing.T) {
+	conn := healthWatchConn(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		streaming  *configpb.ProbeConf_Streaming
+		wantMsgs   []string
+		wantReason string
+	}{
+		{
+			name:      "max_messages",
+			method:    "grpc.health.v1.Health.Watch",
+			streaming: &configpb.ProbeConf_Streaming{MaxMessages: proto.Int32(1)},
+			wantMsgs:  []string{`{"status":"SERVING"}`},
+		},
+		{
+			// Health watch stream is not completed by the server, so we time
+			// out after the first message.
+			name:       "stream_error",
+			method:     "grpc.health.v1.Health.Watch",
+			wantMsgs:   []string{`{"status":"SERVING"}`},
+			wantReason: options.FailureStream,
+		},
+		{
+			name: "too_few_messages",
+			streaming: &configpb.ProbeConf_Streaming{
+				MinMessages: proto.Int32(2),
+				MaxMessages: proto.Int32(1),
+			},
+			method:     "grpc.health.v1.Health.Watch",
+			wantMsgs:   []string{`{"status":"SERVING"}`},
+			wantReason: options.FailureValidation,
+		},
+		{
+			name:       "not_streaming",
+			method:     "grpc.health.v1.Health.Check",
+			wantReason: options.FailureOther,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Probe{c: &configpb.ProbeConf{Streaming: test.streaming}}
+			req := &configpb.GenericRequest{
+				RequestType: &configpb.GenericRequest_CallServiceMethod{CallServiceMethod: test.method},
+				Body:        proto.String(`{"service": ""}`),
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			sr, err := p.serverStreamingRequest(ctx, conn, req)
+
+			if test.wantReason != "" {
+				assert.Error(t, err)
+				assert.Equal(t, test.wantReason, failureReason(err), "error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if test.wantMsgs == nil {
+				return
+			}
+			assert.Equal(t, test.wantMsgs, sr.msgs)
+			assert.Equal(t, int64(2), sr.bytes)
+			assert.Greater(t, sr.firstMsgDelay, time.Duration(0))
+		})
+	}
+}
+
+func TestInitServerStreaming(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		req     *configpb.GenericRequest
+		wantErr bool
+	}{
+		{
+			name:    "no_request",
+			wantErr: true,
+		},
+		{
+			name: "reflection",
+			req: &configpb.GenericRequest{
+				RequestType: &configpb.GenericRequest_CallServiceMethod{CallServiceMethod: "grpc.health.v1.Health.Watch"},
+			},
+		},
+		{
+			name: "protoset_not_streaming",
+			req: &configpb.GenericRequest{
+				ProtosetFile: proto.String("testdata/grpc_server.protoset"),
+				RequestType:  &configpb.GenericRequest_CallServiceMethod{CallServiceMethod: "cloudprober.servers.grpc.Prober.Echo"},
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.ProbeConf = &configpb.ProbeConf{
+				Method:  configpb.ProbeConf_SERVER_STREAMING.Enum(),
+				Request: test.req,
+			}
+			err := (&Probe{}).Init("grpc-stream", opts)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServerStreamingProbe(t *testing.T) {
+	conn := healthWatchConn(t)
+	addr := conn.Target()
+
+	regexValidator := []*validators_configpb.Validator{
+		{
+			Name: "serving",
+			Type: &validators_configpb.Validator_Regex{Regex: "SERVING"},
+		},
+	}
+	vals, err := validators.Init(regexValidator, nil)
+	require.NoError(t, err)
+
+	opts := &options.Options{
+		Targets:              targets.StaticTargets(addr),
+		Interval:             100 * time.Millisecond,
+		Timeout:              100 * time.Millisecond,
+		Logger:               &logger.Logger{},
+		StatsExportInterval:  500 * time.Millisecond,
+		LatencyUnit:          time.Microsecond,
+		LatencyMetricName:    "latency",
+		Validators:           vals,
+		ExportFailureReasons: true,
+		LogMetrics:           func(em *metrics.EventMetrics) {},
+		ProbeConf: &configpb.ProbeConf{
+			Method:            configpb.ProbeConf_SERVER_STREAMING.Enum(),
+			InsecureTransport: proto.Bool(true),
+			Request: &configpb.GenericRequest{
+				RequestType: &configpb.GenericRequest_CallServiceMethod{CallServiceMethod: "grpc.health.v1.Health.Watch"},
+				Body:        proto.String(`{"service": ""}`),
+			},
+			Streaming: &configpb.ProbeConf_Streaming{MaxMessages: proto.Int32(1)},
+		},
+	}
+
+	p := &Probe{}
+	require.NoError(t, p.Init("grpc-stream", opts))
+
+	dataChan := make(chan *metrics.EventMetrics, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Start(ctx, dataChan)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	ems, err := testutils.MetricsFromChannel(dataChan, 1, 2*time.Second)
+	require.NoError(t, err)
+	em := ems[0]
+
+	success := em.Metric("success").(*metrics.Int).Int64()
+	assert.Greater(t, success, int64(0))
+	assert.Equal(t, em.Metric("total").String(), em.Metric("success").String())
+	assert.Equal(t, success, em.Metric("stream_messages").(*metrics.Int).Int64())
+	assert.Equal(t, 2*success, em.Metric("response_bytes").(*metrics.Int).Int64())
+	assert.Greater(t, em.Metric("first_message_latency").(*metrics.Float).Float64(), float64(0))
+	assert.Equal(t, int64(0), em.Metric("failures").(*metrics.Map[int64]).GetKey(options.FailureStream))
+}
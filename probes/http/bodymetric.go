@@ -0,0 +1,98 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+type bodyMetric struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// bodyMetricExtractor extracts numeric values from the response body using
+// the configured regexes.
+type bodyMetricExtractor struct {
+	metrics []*bodyMetric
+}
+
+func newBodyMetricExtractor(confs []*configpb.ProbeConf_BodyMetric) (*bodyMetricExtractor, error) {
+	if len(confs) == 0 {
+		return nil, nil
+	}
+
+	bme := &bodyMetricExtractor{}
+	seen := make(map[string]bool)
+	for _, c := range confs {
+		if c.GetName() == "" {
+			return nil, fmt.Errorf("body_metric: name is required")
+		}
+		if seen[c.GetName()] {
+			return nil, fmt.Errorf("body_metric: duplicate name: %s", c.GetName())
+		}
+		seen[c.GetName()] = true
+
+		re, err := regexp.Compile(c.GetRegex())
+		if err != nil {
+			return nil, fmt.Errorf("body_metric (%s): invalid regex: %v", c.GetName(), err)
+		}
+		if re.NumSubexp() != 1 {
+			return nil, fmt.Errorf("body_metric (%s): regex should have exactly one capture group, got: %d", c.GetName(), re.NumSubexp())
+		}
+		bme.metrics = append(bme.metrics, &bodyMetric{name: c.GetName(), re: re})
+	}
+	return bme, nil
+}
+
+// extract extracts the values of all the metrics from the body. If any of
+// the metrics can't be extracted, it returns that metric's name along with
+// the error.
+func (bme *bodyMetricExtractor) extract(body []byte) ([]float64, string, error) {
+	values := make([]float64, len(bme.metrics))
+	for i, bm := range bme.metrics {
+		matches := bm.re.FindSubmatch(body)
+		if matches == nil {
+			return nil, bm.name, fmt.Errorf("body_metric (%s): regex %q didn't match the response body", bm.name, bm.re.String())
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(string(matches[1])), 64)
+		if err != nil {
+			return nil, bm.name, fmt.Errorf("body_metric (%s): captured value %q is not a number", bm.name, string(matches[1]))
+		}
+		values[i] = v
+	}
+	return values, "", nil
+}
+
+type bodyMetricResult struct {
+	failures *metrics.Map[int64]
+	// Last extracted values, in the order of the configured metrics. nil
+	// until first successful extraction.
+	values []float64
+}
+
+func (bme *bodyMetricExtractor) newResult() *bodyMetricResult {
+	bmr := &bodyMetricResult{failures: metrics.NewMap("metric")}
+	for _, bm := range bme.metrics {
+		bmr.failures.IncKeyBy(bm.name, 0)
+	}
+	return bmr
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testBodyMetric(name, regex string) *configpb.ProbeConf_BodyMetric {
+	return &configpb.ProbeConf_BodyMetric{Name: proto.String(name), Regex: proto.String(regex)}
+}
+
+func TestNewBodyMetricExtractor(t *testing.T) {
+	tests := []struct {
+		name    string
+		confs   []*configpb.ProbeConf_BodyMetric
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "none", wantNil: true},
+		{name: "valid", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("m1", `m1: (\d+)`), testBodyMetric("m2", `m2=([0-9.]+)`)}},
+		{name: "no_name", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("", `(\d+)`)}, wantErr: true},
+		{name: "duplicate", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("m1", `(\d+)`), testBodyMetric("m1", `(\d+)`)}, wantErr: true},
+		{name: "bad_regex", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("m1", `(\d+`)}, wantErr: true},
+		{name: "no_group", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("m1", `\d+`)}, wantErr: true},
+		{name: "two_groups", confs: []*configpb.ProbeConf_BodyMetric{testBodyMetric("m1", `(\w+): (\d+)`)}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bme, err := newBodyMetricExtractor(test.confs)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.wantNil {
+				assert.Nil(t, bme)
+				return
+			}
+			assert.Len(t, bme.metrics, len(test.confs))
+		})
+	}
+}
+
+func TestBodyMetricExtract(t *testing.T) {
+	bme, err := newBodyMetricExtractor([]*configpb.ProbeConf_BodyMetric{
+		testBodyMetric("pending_jobs", `pending_jobs: (\S+)`),
+		testBodyMetric("load", `"load":\s*([-0-9.e]+)`),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		body     string
+		want     []float64
+		wantFail string
+	}{
+		{body: "pending_jobs: 42\n{\"load\": 0.75}", want: []float64{42, 0.75}},
+		{body: "pending_jobs: -3\n{\"load\":1e3}", want: []float64{-3, 1000}},
+		{body: "{\"load\": 0.75}", wantFail: "pending_jobs"},
+		{body: "pending_jobs: many\n{\"load\": 0.75}", wantFail: "pending_jobs"},
+		{body: "pending_jobs: 42\n{\"load\": .}", wantFail: "load"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.body, func(t *testing.T) {
+			values, name, err := bme.extract([]byte(test.body))
+			if test.wantFail != "" {
+				assert.Error(t, err)
+				assert.Equal(t, test.wantFail, name)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, values)
+		})
+	}
+}
+
+func TestProbeWithBodyMetric(t *testing.T) {
+	body := "pending_jobs: 42"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	opts := &options.Options{
+		Targets:  targets.StaticTargets(u.Hostname()),
+		Interval: 2 * time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			Port:       proto.Int32(int32(port)),
+			BodyMetric: []*configpb.ProbeConf_BodyMetric{testBodyMetric("pending_jobs", `pending_jobs: (\d+)`)},
+		},
+		LogMetrics: func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	target := endpoint.Endpoint{Name: u.Hostname()}
+	result := p.newResult()
+	run := func() {
+		p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+	}
+
+	run()
+	assert.Equal(t, int64(1), result.success)
+	assert.Equal(t, []float64{42}, result.bodyMetrics.values)
+
+	// Non-matching body fails the run, and last value is retained.
+	body = "pending_jobs: unknown"
+	run()
+	assert.Equal(t, int64(2), result.total)
+	assert.Equal(t, int64(1), result.success)
+	assert.Equal(t, int64(1), result.bodyMetrics.failures.GetKey("pending_jobs"))
+
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	p.exportMetrics(time.Now(), result, target, dataChan)
+	em := <-dataChan
+	assert.Equal(t, "map:metric,pending_jobs:1", em.Metric("body_metric_failures").String())
+
+	em = <-dataChan
+	assert.Equal(t, metrics.Kind(metrics.GAUGE), em.Kind)
+	assert.Equal(t, "42.000", em.Metric("pending_jobs").String())
+	assert.Equal(t, u.Hostname(), em.Label("dst"))
+}
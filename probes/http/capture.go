@@ -0,0 +1,150 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+const captureTimeFormat = "20060102T150405.000000000"
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+
+// failureCapturer writes responses for the failed requests to files in a
+// per-probe subdirectory, keeping at most maxFiles files for a probe.
+type failureCapturer struct {
+	dir         string
+	maxBodySize int
+	maxFiles    int
+	l           *logger.Logger
+
+	mu sync.Mutex
+}
+
+func newFailureCapturer(c *configpb.ProbeConf_FailureCapture, probeName string, l *logger.Logger) (*failureCapturer, error) {
+	dir := filepath.Join(c.GetDir(), unsafeFileNameChars.ReplaceAllString(probeName, "_"))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating failure capture directory (%s): %v", dir, err)
+	}
+	return &failureCapturer{
+		dir:         dir,
+		maxBodySize: int(c.GetMaxBodyBytes()),
+		maxFiles:    int(c.GetMaxFiles()),
+		l:           l,
+	}, nil
+}
+
+func (fc *failureCapturer) fileName(targetName string, ts time.Time) string {
+	return unsafeFileNameChars.ReplaceAllString(targetName, "_") + "_" + ts.UTC().Format(captureTimeFormat) + ".txt"
+}
+
+// formatCapture formats the failed request's capture. Response is nil if
+// request failed before getting a response, e.g. on connection errors.
+func formatCapture(reqURL, reqID string, resp *http.Response, body []byte, maxBodySize int, failure string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "URL: %s\n", reqURL)
+	if reqID != "" {
+		fmt.Fprintf(&b, "Request ID: %s\n", reqID)
+	}
+	fmt.Fprintf(&b, "Failure: %s\n", failure)
+	if resp == nil {
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "%s %s\n", resp.Proto, resp.Status)
+	resp.Header.Write(&b)
+	b.WriteString("\n")
+
+	if maxBodySize >= 0 && len(body) > maxBodySize {
+		b.Write(body[:maxBodySize])
+		fmt.Fprintf(&b, "\n... truncated %d bytes", len(body)-maxBodySize)
+	} else {
+		b.Write(body)
+	}
+
+	return b.Bytes()
+}
+
+// capture writes the response to a new file, and removes the oldest capture
+// files if there are more than maxFiles of them.
+func (fc *failureCapturer) capture(targetName string, ts time.Time, reqURL, reqID string, resp *http.Response, body []byte, failure string) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	fname := filepath.Join(fc.dir, fc.fileName(targetName, ts))
+	if err := os.WriteFile(fname, formatCapture(reqURL, reqID, resp, body, fc.maxBodySize, failure), 0644); err != nil {
+		fc.l.Warningf("Error writing failure capture file (%s): %v", fname, err)
+		return
+	}
+
+	fc.cleanup()
+}
+
+func (fc *failureCapturer) cleanup() {
+	if fc.maxFiles <= 0 {
+		return
+	}
+
+	entries, err := os.ReadDir(fc.dir)
+	if err != nil {
+		fc.l.Warningf("Error reading failure capture directory (%s): %v", fc.dir, err)
+		return
+	}
+
+	type captureFile struct {
+		name    string
+		modTime time.Time
+	}
+	var files []captureFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".txt") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, captureFile{e.Name(), info.ModTime()})
+	}
+
+	if len(files) <= fc.maxFiles {
+		return
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].modTime.Equal(files[j].modTime) {
+			return files[i].name < files[j].name
+		}
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	for _, f := range files[:len(files)-fc.maxFiles] {
+		if err := os.Remove(filepath.Join(fc.dir, f.name)); err != nil {
+			fc.l.Warningf("Error removing old failure capture file (%s): %v", f.name, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFormatCapture(t *testing.T) {
+	resp := &http.Response{
+		Proto:  "HTTP/1.1",
+		Status: "503 Service Unavailable",
+		Header: http.Header{"Content-Type": []string{"text/plain"}},
+	}
+
+	tests := []struct {
+		name        string
+		reqID       string
+		body        string
+		maxBodySize int
+		wantBody    string
+	}{
+		{
+			name:        "full",
+			body:        "backend down",
+			maxBodySize: 100,
+			wantBody:    "backend down",
+		},
+		{
+			name:        "truncated",
+			body:        "backend down",
+			maxBodySize: 7,
+			wantBody:    "backend\n... truncated 5 bytes",
+		},
+		{
+			name:        "request_id",
+			reqID:       "a5c4e2b0-7d8e-4a8c-9f6b-0e1d2c3b4a59",
+			body:        "backend down",
+			maxBodySize: 100,
+			wantBody:    "backend down",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			want := "URL: http://test.com/\n"
+			if test.reqID != "" {
+				want += "Request ID: " + test.reqID + "\n"
+			}
+			want += "Failure: failed validations: status-code\n" +
+				"HTTP/1.1 503 Service Unavailable\n" +
+				"Content-Type: text/plain\r\n" +
+				"\n" + test.wantBody
+			got := formatCapture("http://test.com/", test.reqID, resp, []byte(test.body), test.maxBodySize, "failed validations: status-code")
+			assert.Equal(t, want, string(got))
+		})
+	}
+
+	// Request failed without a response.
+	got := formatCapture("http://test.com/", "", nil, nil, 100, "connection refused")
+	assert.Equal(t, "URL: http://test.com/\nFailure: connection refused\n", string(got))
+}
+
+func TestFailureCapturer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "captures")
+
+	fc, err := newFailureCapturer(&configpb.ProbeConf_FailureCapture{
+		Dir:      proto.String(dir),
+		MaxFiles: proto.Int32(2),
+	}, "http/probe", nil)
+	assert.NoError(t, err)
+
+	resp := &http.Response{Proto: "HTTP/1.1", Status: "500 Internal Server Error"}
+
+	// Another probe whose name is a prefix of this probe's name, its captures
+	// should not be touched.
+	otherFC, err := newFailureCapturer(&configpb.ProbeConf_FailureCapture{
+		Dir:      proto.String(dir),
+		MaxFiles: proto.Int32(2),
+	}, "http", nil)
+	assert.NoError(t, err)
+	otherFC.capture("probe_host", time.Unix(0, 0), "http://probe_host/", "", resp, []byte("error"), "status code")
+
+	ts := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		fc.capture("host:8080", ts.Add(time.Duration(i)*time.Second), "http://host:8080/", "", resp, []byte("error"), "failed validations: v1")
+		// Make sure modification times are distinct.
+		fname := filepath.Join(fc.dir, fc.fileName("host:8080", ts.Add(time.Duration(i)*time.Second)))
+		os.Chtimes(fname, ts.Add(time.Duration(i)*time.Second), ts.Add(time.Duration(i)*time.Second))
+	}
+
+	readNames := func(dir string) []string {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		assert.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+
+	names := readNames(filepath.Join(dir, "http_probe"))
+	assert.ElementsMatch(t, []string{
+		"host_8080_20240102T150406.000000000.txt",
+		"host_8080_20240102T150407.000000000.txt",
+	}, names)
+	assert.Equal(t, []string{"probe_host_19700101T000000.000000000.txt"}, readNames(filepath.Join(dir, "http")))
+
+	b, err := os.ReadFile(filepath.Join(dir, "http_probe", names[0]))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(b), "\nerror"), "capture content: %s", string(b))
+}
+
+func TestProbeFailureCapture(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	dir := t.TempDir()
+	runProbe := func(port int) {
+		t.Helper()
+		opts := &options.Options{
+			Targets:  targets.StaticTargets(u.Hostname()),
+			Interval: 2 * time.Second,
+			Timeout:  time.Second,
+			ProbeConf: &configpb.ProbeConf{
+				Port:           proto.Int32(int32(port)),
+				FailureCapture: &configpb.ProbeConf_FailureCapture{Dir: proto.String(dir)},
+			},
+		}
+		p := &Probe{}
+		require.NoError(t, p.Init("capture_test", opts))
+		target := endpoint.Endpoint{Name: u.Hostname()}
+		p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), p.newResult())
+	}
+
+	// Successful requests are not captured.
+	runProbe(port)
+	entries, err := os.ReadDir(filepath.Join(dir, "capture_test"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// Requests that fail without a response are captured too.
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	closedPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	runProbe(closedPort)
+	entries, err = os.ReadDir(filepath.Join(dir, "capture_test"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	b, err := os.ReadFile(filepath.Join(dir, "capture_test", entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "Failure: ")
+	assert.Contains(t, string(b), "connection refused")
+}
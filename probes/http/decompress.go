@@ -0,0 +1,95 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Decompression failure reasons.
+const (
+	decompressEncodingMismatch = "encoding_mismatch"
+	decompressCorrupt          = "corrupt"
+)
+
+var decompressors = map[string]func(io.Reader) (io.Reader, error){
+	"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+	"br":   func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+	// HTTP "deflate" is the zlib format (RFC 9110, section 8.4.1.2).
+	"deflate": func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) },
+}
+
+type decompressChecker struct {
+	encoding  string
+	newReader func(io.Reader) (io.Reader, error)
+}
+
+func newDecompressChecker(encoding string) (*decompressChecker, error) {
+	if encoding == "" {
+		return nil, nil
+	}
+	newReader := decompressors[encoding]
+	if newReader == nil {
+		return nil, fmt.Errorf("unsupported expected_content_encoding: %s, supported encodings: gzip, br, deflate", encoding)
+	}
+	return &decompressChecker{encoding: encoding, newReader: newReader}, nil
+}
+
+// decompress verifies the response's Content-Encoding and decompresses the
+// body. It returns the decompressed body, or the failure reason along with
+// the error.
+func (dc *decompressChecker) decompress(resp *http.Response, body []byte) ([]byte, string, error) {
+	if got := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); got != dc.encoding {
+		return nil, decompressEncodingMismatch, fmt.Errorf("content encoding mismatch, expected: %s, got: %q", dc.encoding, got)
+	}
+
+	r, err := dc.newReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, decompressCorrupt, fmt.Errorf("error decompressing %s body: %v", dc.encoding, err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, decompressCorrupt, fmt.Errorf("error decompressing %s body: %v", dc.encoding, err)
+	}
+	return b, "", nil
+}
+
+type decompressResult struct {
+	failures                           *metrics.Map[int64]
+	compressedBytes, decompressedBytes int64
+}
+
+func newDecompressResult() *decompressResult {
+	dr := &decompressResult{failures: metrics.NewMap("reason")}
+	for _, reason := range []string{decompressEncodingMismatch, decompressCorrupt} {
+		dr.failures.IncKeyBy(reason, 0)
+	}
+	return dr
+}
+
+func (dr *decompressResult) addMetrics(em *metrics.EventMetrics) {
+	em.AddMetric("decompression_failures", dr.failures.Clone()).
+		AddMetric("resp_compressed_bytes", metrics.NewInt(dr.compressedBytes)).
+		AddMetric("resp_decompressed_bytes", metrics.NewInt(dr.decompressedBytes))
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func compress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "br":
+		w = brotli.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	}
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestNewDecompressChecker(t *testing.T) {
+	dc, err := newDecompressChecker("")
+	assert.NoError(t, err)
+	assert.Nil(t, dc)
+
+	_, err = newDecompressChecker("zstd")
+	assert.Error(t, err)
+}
+
+func TestDecompress(t *testing.T) {
+	data := bytes.Repeat([]byte("cloudprober "), 100)
+
+	tests := []struct {
+		name       string
+		encoding   string
+		respHeader string
+		body       []byte
+		wantReason string
+	}{
+		{name: "gzip", encoding: "gzip", respHeader: "gzip", body: compress(t, "gzip", data)},
+		{name: "br", encoding: "br", respHeader: "br", body: compress(t, "br", data)},
+		{name: "deflate", encoding: "deflate", respHeader: "deflate", body: compress(t, "deflate", data)},
+		{name: "header_case", encoding: "gzip", respHeader: " GZIP", body: compress(t, "gzip", data)},
+		{name: "mismatch", encoding: "gzip", respHeader: "br", body: compress(t, "br", data), wantReason: decompressEncodingMismatch},
+		{name: "missing", encoding: "gzip", body: data, wantReason: decompressEncodingMismatch},
+		{name: "corrupt_gzip", encoding: "gzip", respHeader: "gzip", body: data, wantReason: decompressCorrupt},
+		{name: "truncated_gzip", encoding: "gzip", respHeader: "gzip", body: compress(t, "gzip", data)[:20], wantReason: decompressCorrupt},
+		{name: "corrupt_br", encoding: "br", respHeader: "br", body: data, wantReason: decompressCorrupt},
+		{name: "corrupt_deflate", encoding: "deflate", respHeader: "deflate", body: data, wantReason: decompressCorrupt},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dc, err := newDecompressChecker(test.encoding)
+			require.NoError(t, err)
+
+			resp := &http.Response{Header: http.Header{}}
+			if test.respHeader != "" {
+				resp.Header.Set("Content-Encoding", test.respHeader)
+			}
+			got, reason, err := dc.decompress(resp, test.body)
+			assert.Equal(t, test.wantReason, reason)
+			if test.wantReason != "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, data, got)
+		})
+	}
+}
+
+func TestProbeWithExpectedContentEncoding(t *testing.T) {
+	data := bytes.Repeat([]byte("cloudprober "), 100)
+	var gotAcceptEncoding string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAcceptEncoding = r.Header.Get("Accept-Encoding")
+		if r.URL.Path == "/plain" {
+			w.Write(data)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compress(t, "gzip", data))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	for _, path := range []string{"/gzip", "/plain"} {
+		t.Run(path, func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:                    proto.Int32(int32(port)),
+					RelativeUrl:             proto.String(path),
+					ExpectedContentEncoding: proto.String("gzip"),
+				},
+				LogMetrics: func(_ *metrics.EventMetrics) {},
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, "gzip", gotAcceptEncoding)
+			assert.Equal(t, int64(1), result.total)
+			if path == "/plain" {
+				assert.Equal(t, int64(0), result.success)
+				assert.Equal(t, int64(1), result.decompress.failures.GetKey(decompressEncodingMismatch))
+				return
+			}
+			assert.Equal(t, int64(1), result.success)
+			assert.Equal(t, int64(len(data)), result.decompress.decompressedBytes)
+			assert.Equal(t, int64(len(compress(t, "gzip", data))), result.decompress.compressedBytes)
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			for _, name := range []string{"decompression_failures", "resp_compressed_bytes", "resp_decompressed_bytes"} {
+				assert.NotNil(t, em.Metric(name), "metric: %s", name)
+			}
+		})
+	}
+}
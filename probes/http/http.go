@@ -33,14 +33,18 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/httpreq"
 	"github.com/cloudprober/cloudprober/internal/oauth"
+	"github.com/cloudprober/cloudprober/internal/requestid"
 	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/internal/unreachable"
 	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
 	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"golang.org/x/oauth2"
 )
@@ -85,6 +89,45 @@ type Probe struct {
 	waitGroup   sync.WaitGroup
 
 	requestBody *httpreq.RequestBody
+
+	// If configured, request body and/or headers are read from files.
+	reqFiles *requestFiles
+
+	// If configured, responses for the failed requests are captured to files.
+	failureCapturer *failureCapturer
+
+	// HTTP client for the shadow requests, if shadow mode is configured.
+	shadowClient *http.Client
+
+	// Retry policy for the requests that server asks us to retry, e.g. 429s.
+	retryPolicy *retryPolicy
+
+	// If configured, redirect chains are validated using this checker.
+	redirectChecker *redirectChecker
+
+	// If configured, requests fail over between these proxies.
+	proxyFailover *proxyFailover
+
+	// If configured, each request carries a unique request ID.
+	requestIDs *requestid.Generator
+
+	// If configured, response's content encoding is verified and its body is
+	// decompressed using this checker.
+	decompressChecker *decompressChecker
+
+	// If configured, requests ask for a byte range, and the partial content
+	// responses are validated using this checker.
+	rangeChecker *rangeChecker
+
+	// If configured, numeric values are extracted from the response body
+	// and exported as metrics.
+	bodyMetrics *bodyMetricExtractor
+
+	// If configured, metrics timestamp is extracted from the response.
+	respTimestamp *respTimestampExtractor
+
+	// If configured, probe succeeds only if the target is not reachable.
+	unreachable *unreachable.Checker
 }
 
 type latencyDetails struct {
@@ -93,13 +136,31 @@ type latencyDetails struct {
 
 type probeResult struct {
 	total, success, timeouts     int64
-	connEvent                    int64
+	connEvent, connReused        int64
 	latency                      metrics.LatencyValue
 	respCodes                    *metrics.Map[int64]
 	respBodies                   *metrics.Map[int64]
 	validationFailure            *metrics.Map[int64]
+	validationSuccess            *metrics.Map[int64]
+	validators                   []*validators.Validator
 	latencyBreakdown             *latencyDetails
 	sslEarliestExpirationSeconds int64
+	throttledRuns                int64
+	suppressedRuns               int64
+	skippedUnchanged             int64
+	shadow                       *shadowResult
+	retriedRequests              *metrics.Map[int64]
+	pages, pageFailures          int64
+	redirect                     *redirectResult
+	proxy                        *proxyResult
+	decompress                   *decompressResult
+	byteRange                    *rangeResult
+	bodyMetrics                  *bodyMetricResult
+	respTimestamp                time.Time
+	lastExportTimestamp          time.Time
+	unreachableOutcomes          *metrics.Map[int64]
+	hostHeader                   string
+	failures                     *metrics.Map[int64]
 }
 
 func (p *Probe) getTransport() (*http.Transport, error) {
@@ -113,6 +174,8 @@ func (p *Probe) getTransport() (*http.Transport, error) {
 			IP: p.opts.SourceIP,
 		}
 	}
+	// Use targets' DNS server, if configured, to resolve the target names.
+	dialer.Resolver = targets.DNSResolver(p.opts.Targets)
 	transport.DialContext = dialer.DialContext
 	transport.MaxIdleConns = int(p.c.GetMaxIdleConns())
 	transport.TLSHandshakeTimeout = p.opts.Timeout
@@ -123,7 +186,14 @@ func (p *Probe) getTransport() (*http.Transport, error) {
 			return nil, fmt.Errorf("error parsing proxy URL (%s): %v", p.c.GetProxyUrl(), err)
 		}
 		transport.Proxy = http.ProxyURL(url)
+	}
+
+	if p.proxyFailover != nil {
+		transport.Proxy = p.proxyFailover.proxy
+	}
 
+	if transport.Proxy != nil {
+		transport.OnProxyConnectResponse = onProxyConnectResponse
 		for k, v := range p.c.GetProxyConnectHeader() {
 			transport.ProxyConnectHeader.Add(k, v)
 		}
@@ -146,16 +216,35 @@ func (p *Probe) getTransport() (*http.Transport, error) {
 		}
 	}
 
+	if p.c.GetSni() != "" {
+		if p.c.GetTlsConfig().GetServerName() != "" {
+			return nil, fmt.Errorf("only one of sni and tls_config.server_name can be configured")
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.ServerName = p.c.GetSni()
+	}
+
 	// If HTTP keep-alives are not enabled (default), disable HTTP keep-alive in
 	// transport.
 	if !p.c.GetKeepAlive() {
+		if p.c.IdleConnTimeoutMsec != nil || p.c.MaxIdleConnsPerHost != nil {
+			return nil, fmt.Errorf("idle_conn_timeout_msec and max_idle_conns_per_host require keep_alive")
+		}
 		transport.DisableKeepAlives = true
 	} else {
 		// If it's been more than 2 probe intervals since connection was used, close it.
 		transport.IdleConnTimeout = 2 * p.opts.Interval
+		if p.c.IdleConnTimeoutMsec != nil {
+			transport.IdleConnTimeout = time.Duration(p.c.GetIdleConnTimeoutMsec()) * time.Millisecond
+		}
 		if p.c.GetRequestsPerProbe() > 1 {
 			transport.MaxIdleConnsPerHost = int(p.c.GetRequestsPerProbe())
 		}
+		if p.c.MaxIdleConnsPerHost != nil {
+			transport.MaxIdleConnsPerHost = int(p.c.GetMaxIdleConnsPerHost())
+		}
 	}
 
 	if p.c.GetDisableHttp2() {
@@ -201,6 +290,28 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 
 	p.requestBody = httpreq.NewRequestBody(p.c.GetBody()...)
 
+	if p.c.GetBodyFile() != "" || p.c.GetHeadersFile() != "" {
+		if p.c.GetBodyFile() != "" && len(p.c.GetBody()) != 0 {
+			return fmt.Errorf("only one of body and body_file can be configured")
+		}
+		rf, err := newRequestFiles(p.c.GetBodyFile(), p.c.GetHeadersFile(), p.opts.Interval, p.l)
+		if err != nil {
+			return err
+		}
+		p.reqFiles = rf
+		if rf.body != nil {
+			p.requestBody = rf.body
+		}
+	}
+
+	if p.c.GetFailureCapture() != nil {
+		fc, err := newFailureCapturer(p.c.GetFailureCapture(), p.name, p.l)
+		if err != nil {
+			return err
+		}
+		p.failureCapturer = fc
+	}
+
 	if p.c.GetOauthConfig() != nil {
 		oauthTS, err := oauth.TokenSourceFromConfig(p.c.GetOauthConfig(), p.l)
 		if err != nil {
@@ -209,6 +320,21 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 		p.oauthTS = oauthTS
 	}
 
+	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
+	}
+
+	if p.c.GetProxyUrl() != "" && len(p.c.GetProxyUrls()) != 0 {
+		return fmt.Errorf("only one of proxy_url and proxy_urls can be configured")
+	}
+	pf, err := newProxyFailover(p.c.GetProxyUrls())
+	if err != nil {
+		return err
+	}
+	p.proxyFailover = pf
+
 	transport, err := p.getTransport()
 	if err != nil {
 		return err
@@ -216,6 +342,39 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 
 	p.baseTransport = transport
 
+	if p.decompressChecker, err = newDecompressChecker(p.c.GetExpectedContentEncoding()); err != nil {
+		return err
+	}
+
+	if p.rangeChecker, err = newRangeChecker(p.c.GetByteRange()); err != nil {
+		return err
+	}
+	if p.rangeChecker != nil && p.c.GetPagination() != nil {
+		return fmt.Errorf("byte_range cannot be used along with pagination")
+	}
+
+	if p.bodyMetrics, err = newBodyMetricExtractor(p.c.GetBodyMetric()); err != nil {
+		return err
+	}
+
+	if p.respTimestamp, err = newRespTimestampExtractor(p.c.GetResponseTimestamp()); err != nil {
+		return err
+	}
+
+	if p.unreachable, err = unreachable.New(p.c.GetExpectUnreachable()); err != nil {
+		return err
+	}
+
+	if p.redirectChecker, err = newRedirectChecker(p.c.GetRedirectChain()); err != nil {
+		return err
+	}
+	if p.redirectChecker != nil {
+		if p.c.MaxRedirects != nil {
+			return fmt.Errorf("max_redirects cannot be used along with redirect_chain, use redirect_chain.max_hops instead")
+		}
+		p.redirectFunc = p.redirectChecker.checkRedirect
+	}
+
 	if p.c.MaxRedirects != nil {
 		p.redirectFunc = func(req *http.Request, via []*http.Request) error {
 			if len(via) >= int(p.c.GetMaxRedirects()) {
@@ -226,6 +385,13 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 		}
 	}
 
+	if p.c.GetShadow() != nil {
+		p.shadowClient = &http.Client{Transport: p.baseTransport, CheckRedirect: p.redirectFunc}
+	}
+
+	p.retryPolicy = newRetryPolicy(p.c.GetRetry())
+	p.requestIDs = requestid.New(p.c.GetRequestId())
+
 	p.statsExportFrequency = p.opts.StatsExportInterval.Nanoseconds() / p.opts.Interval.Nanoseconds()
 	if p.statsExportFrequency == 0 {
 		p.statsExportFrequency = 1
@@ -256,12 +422,33 @@ func isClientTimeout(err error) bool {
 	return false
 }
 
+// requestID returns the request ID of the request, if request IDs are
+// configured.
+func (p *Probe) requestID(req *http.Request) string {
+	if p.requestIDs == nil {
+		return ""
+	}
+	return p.requestIDs.ID(req.Header.Get)
+}
+
+func (p *Probe) logAttrs(req *http.Request, targetName string) []slog.Attr {
+	attrs := []slog.Attr{slog.String("target", targetName), slog.String("url", req.URL.String())}
+	if id := p.requestID(req); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+	return attrs
+}
+
 func (p *Probe) addLatency(latency metrics.LatencyValue, start time.Time) {
 	latency.AddFloat64(time.Since(start).Seconds() / p.opts.LatencyUnit.Seconds())
 }
 
 // httpRequest executes an HTTP request and updates the provided result struct.
-func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName string, result *probeResult, resultMu *sync.Mutex) {
+// doHTTPRequest executes the HTTP request and updates the result. It returns
+// response info for the comparison of shadow responses, or nil if request
+// failed.
+func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName string, result *probeResult, resultMu *sync.Mutex) *responseInfo {
+	origReq := req
 	req = p.prepareRequest(req)
 
 	start := time.Now()
@@ -286,9 +473,14 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 		}
 	}
 
-	var connEvent atomic.Int32
+	var connEvent, connReused atomic.Int32
 
 	if p.c.GetKeepAlive() {
+		trace.GotConn = func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				connReused.Add(1)
+			}
+		}
 		oldConnectDone := trace.ConnectDone
 		trace.ConnectDone = func(network, addr string, err error) {
 			connEvent.Add(1)
@@ -307,7 +499,41 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
 	}
 
-	resp, err := client.Do(req)
+	req, resp, proxyAttempts, err := p.doRequest(client, req, origReq)
+
+	// If configured, failed requests are captured from here, irrespective of
+	// where they fail. Failure sites set the failure description.
+	var success bool
+	var failure string
+	var respBody []byte
+	if p.failureCapturer != nil {
+		defer func() {
+			if !success {
+				p.failureCapturer.capture(targetName, start, req.URL.String(), p.requestID(req), resp, respBody, failure)
+			}
+		}()
+	}
+
+	// Retry once if the server asked us to, e.g. through 429 and Retry-After.
+	// Latency is measured only for the retried request.
+	var retriedCode string
+	if err == nil {
+		if d, ok := p.retryPolicy.delay(req.Context(), resp); ok {
+			retriedCode = strconv.Itoa(resp.StatusCode)
+			if err = waitForRetry(req.Context(), resp, d); err == nil {
+				start = time.Now()
+				req = p.prepareRequest(origReq).WithContext(req.Context())
+				resp, err = client.Do(req)
+			}
+		}
+	}
+
+	// If pagination is configured, fetch all the pages before measuring the
+	// latency.
+	var pr *pagedResponse
+	if err == nil && p.c.GetPagination() != nil {
+		pr, err = p.fetchPages(req, client, resp, targetName)
+	}
 	latency := time.Since(start)
 
 	if resultMu != nil {
@@ -318,21 +544,63 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 
 	result.total++
 	result.connEvent += int64(connEvent.Load())
+	result.connReused += int64(connReused.Load())
+	if retriedCode != "" {
+		result.retriedRequests.IncKey(retriedCode)
+	}
+	if proxyAttempts != nil {
+		result.proxy.record(p.proxyFailover, proxyAttempts)
+	}
+	if pr != nil {
+		result.pages += int64(len(pr.pages))
+		defer func() { result.pageFailures += pr.failures }()
+	}
+
+	if p.unreachable != nil {
+		if err == nil {
+			resp.Body.Close()
+		}
+		outcome, ok := p.unreachable.Check(err)
+		result.unreachableOutcomes.IncKey(outcome)
+		if !ok {
+			failure = "expected target to be unreachable, but outcome: " + outcome
+			p.l.WarningAttrs(failure, p.logAttrs(req, targetName)...)
+			reason := options.ClassifyError(err)
+			if err == nil {
+				reason = options.FailureValidation
+			}
+			options.RecordFailure(result.failures, reason)
+			return nil
+		}
+		result.success++
+		success = true
+		return nil
+	}
 
 	if err != nil {
+		failure = err.Error()
+		options.RecordFailure(result.failures, options.ClassifyError(err))
+		if p.redirectChecker != nil {
+			if reason := p.redirectChecker.failureReason(err); reason != "" {
+				result.redirect.failures.IncKey(reason)
+			}
+		}
 		if isClientTimeout(err) {
-			p.l.WarningAttrs(err.Error(), slog.String("target", targetName), slog.String("url", req.URL.String()))
+			p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
 			result.timeouts++
-			return
+			return nil
 		}
-		p.l.WarningAttrs(err.Error(), slog.String("target", targetName), slog.String("url", req.URL.String()))
-		return
+		p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
+		return nil
 	}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		p.l.WarningAttrs(err.Error(), slog.String("target", targetName), slog.String("url", req.URL.String()))
-		return
+	if pr != nil {
+		respBody = pr.pages[0].body
+	} else if respBody, err = io.ReadAll(resp.Body); err != nil {
+		failure = err.Error()
+		options.RecordFailure(result.failures, options.ClassifyError(err))
+		p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
+		return nil
 	}
 
 	p.l.Debug("Target:", targetName, ", URL:", req.URL.String(), ", response: ", string(respBody))
@@ -341,6 +609,50 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 	resp.Body.Close()
 	result.respCodes.IncKey(strconv.FormatInt(int64(resp.StatusCode), 10))
 
+	var respInfo *responseInfo
+	if result.shadow != nil {
+		respInfo = p.newResponseInfo(resp, respBody, latency)
+	}
+
+	if p.rangeChecker != nil {
+		n, reason, err := p.rangeChecker.validate(resp, respBody)
+		if err != nil {
+			failure = err.Error()
+			p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
+			result.byteRange.failures.IncKey(reason)
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return respInfo
+		}
+		p.l.Debug("Target:", targetName, ", URL:", req.URL.String(), ", Content-Range: ", resp.Header.Get("Content-Range"))
+		result.byteRange.rangeBytes += n
+	}
+
+	if p.decompressChecker != nil {
+		body, reason, err := p.decompressChecker.decompress(resp, respBody)
+		if err != nil {
+			failure = err.Error()
+			p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
+			result.decompress.failures.IncKey(reason)
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return respInfo
+		}
+		result.decompress.compressedBytes += int64(len(respBody))
+		result.decompress.decompressedBytes += int64(len(body))
+		respBody = body
+	}
+
+	if p.redirectChecker != nil {
+		chain := redirectChain(resp)
+		result.redirect.record(chain)
+		if reason := p.redirectChecker.validate(chain); reason != "" {
+			failure = "redirect chain validation failed: " + reason
+			p.l.WarningAttrs("redirect chain validation failed: "+reason, slog.String("target", targetName), slog.String("chain", strings.Join(chain, " -> ")))
+			result.redirect.failures.IncKey(reason)
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return respInfo
+		}
+	}
+
 	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
 		now := time.Now()
 		minExpirySeconds := resp.TLS.PeerCertificates[0].NotAfter.Sub(now).Seconds()
@@ -354,22 +666,49 @@ func (p *Probe) doHTTPRequest(req *http.Request, client *http.Client, targetName
 		result.sslEarliestExpirationSeconds = int64(minExpirySeconds)
 	}
 
-	if p.opts.Validators != nil {
-		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{Response: resp, ResponseBody: respBody}, result.validationFailure, p.l)
+	if p.respTimestamp != nil {
+		if result.respTimestamp, err = p.respTimestamp.extract(resp, respBody); err != nil {
+			p.l.WarningAttrs(err.Error()+"; using local time for the metrics", p.logAttrs(req, targetName)...)
+		}
+	}
+
+	if result.validators != nil {
+		var failedValidations []string
+		if pr != nil {
+			failedValidations = p.validatePages(pr, result)
+		} else {
+			failedValidations = validators.RunValidators(result.validators, &validators.Input{Response: resp, ResponseBody: respBody}, result.validationFailure, result.validationSuccess, p.l)
+		}
 
 		// If any validation failed, return now, leaving the success and latency
 		// counters unchanged.
 		if len(failedValidations) > 0 {
-			p.l.Debug("Target:", targetName, ", URL:", req.URL.String(), ", http.doHTTPRequest: failed validations: ", strings.Join(failedValidations, ","))
-			return
+			failure = "failed validations: " + strings.Join(failedValidations, ",")
+			options.RecordFailure(result.failures, options.FailureValidation)
+			p.l.Debug("Target:", targetName, ", URL:", req.URL.String(), ", http.doHTTPRequest: ", failure)
+			return respInfo
+		}
+	}
+
+	if p.bodyMetrics != nil {
+		values, name, err := p.bodyMetrics.extract(respBody)
+		if err != nil {
+			failure = err.Error()
+			p.l.WarningAttrs(err.Error(), p.logAttrs(req, targetName)...)
+			result.bodyMetrics.failures.IncKey(name)
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return respInfo
 		}
+		result.bodyMetrics.values = values
 	}
 
 	result.success++
+	success = true
 	result.latency.AddFloat64(latency.Seconds() / p.opts.LatencyUnit.Seconds())
 	if result.respBodies != nil && len(respBody) <= maxResponseSizeForMetrics {
 		result.respBodies.IncKey(string(respBody))
 	}
+	return respInfo
 }
 
 func (p *Probe) parseLatencyBreakdown(baseLatencyValue metrics.LatencyValue) *latencyDetails {
@@ -406,8 +745,11 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, clients
 	reqCtx, cancelReqCtx := context.WithTimeout(ctx, p.opts.Timeout)
 	defer cancelReqCtx()
 
+	// Host header is reported along with the SNI.
+	result.hostHeader = req.Host
+
 	if p.c.GetRequestsPerProbe() == 1 {
-		p.doHTTPRequest(req.WithContext(reqCtx), clients[0], target.Name, result, nil)
+		p.doHTTPRequestWithShadow(req.WithContext(reqCtx), clients[0], target.Name, result, nil)
 		return
 	}
 
@@ -424,38 +766,91 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, clients
 			defer wg.Done()
 
 			time.Sleep(time.Duration(numReq*int(p.c.GetRequestsIntervalMsec())) * time.Millisecond)
-			p.doHTTPRequest(req.WithContext(reqCtx), clients[numReq], targetName, result, &resultMu)
+			p.doHTTPRequestWithShadow(req.WithContext(reqCtx), clients[numReq], targetName, result, &resultMu)
 		}(req, numReq, target.Name, result)
 	}
 	wg.Wait()
 }
 
 func (p *Probe) newResult() *probeResult {
+	return p.newResultWithValidators(p.opts.Validators)
+}
+
+func (p *Probe) newResultWithValidators(vs []*validators.Validator) *probeResult {
 	result := &probeResult{
 		respCodes:                    metrics.NewMap("code"),
 		sslEarliestExpirationSeconds: -1,
+		failures:                     p.opts.NewFailureReasons(),
 	}
 
-	if p.opts.Validators != nil {
-		result.validationFailure = validators.ValidationFailureMap(p.opts.Validators)
+	if vs != nil {
+		result.validators = vs
+		result.validationFailure = validators.ValidationFailureMap(vs)
+		result.validationSuccess = p.opts.NewValidationSuccess(vs)
 	}
 
-	if p.opts.LatencyDist != nil {
-		result.latency = p.opts.LatencyDist.CloneDist()
-	} else {
-		result.latency = metrics.NewFloat(0)
-	}
+	result.latency = p.opts.NewLatencyValue()
 
 	result.latencyBreakdown = p.parseLatencyBreakdown(result.latency)
 
+	if p.c.GetShadow() != nil {
+		result.shadow = newShadowResult(result.latency)
+	}
+
 	if p.c.GetExportResponseAsMetrics() {
 		result.respBodies = metrics.NewMap("resp")
 	}
 
+	if p.retryPolicy != nil {
+		result.retriedRequests = metrics.NewMap("code")
+	}
+
+	if p.redirectChecker != nil {
+		result.redirect = newRedirectResult()
+	}
+
+	if p.proxyFailover != nil {
+		result.proxy = p.proxyFailover.newResult()
+	}
+
+	if p.decompressChecker != nil {
+		result.decompress = newDecompressResult()
+	}
+
+	if p.rangeChecker != nil {
+		result.byteRange = newRangeResult()
+	}
+
+	if p.bodyMetrics != nil {
+		result.bodyMetrics = p.bodyMetrics.newResult()
+	}
+
+	if p.unreachable != nil {
+		result.unreachableOutcomes = unreachable.NewOutcomes()
+	}
+
 	return result
 }
 
 func (p *Probe) exportMetrics(ts time.Time, result *probeResult, target endpoint.Endpoint, dataChan chan *metrics.EventMetrics) {
+	if p.respTimestamp != nil {
+		// Response timestamp is used only once, so that we don't keep
+		// exporting a stale timestamp if there are no new responses. It's
+		// used only if it moves forward; otherwise we fall back to the local
+		// time.
+		if result.respTimestamp.After(result.lastExportTimestamp) {
+			ts = result.respTimestamp
+		}
+		result.respTimestamp = time.Time{}
+
+		// Keep the timestamps monotonic for the target, as the local time may
+		// be behind the server time used last time.
+		if ts.Before(result.lastExportTimestamp) {
+			ts = result.lastExportTimestamp
+		}
+		result.lastExportTimestamp = ts
+	}
+
 	em := metrics.NewEventMetrics(ts).
 		AddMetric("total", metrics.NewInt(result.total)).
 		AddMetric("success", metrics.NewInt(result.success)).
@@ -469,11 +864,15 @@ func (p *Probe) exportMetrics(ts time.Time, result *probeResult, target endpoint
 
 	if p.c.GetKeepAlive() {
 		em.AddMetric("connect_event", metrics.NewInt(result.connEvent))
+		em.AddMetric("conn_reused", metrics.NewInt(result.connReused))
 	}
 
 	if result.validationFailure != nil {
 		em.AddMetric("validation_failure", result.validationFailure)
 	}
+	if result.validationSuccess != nil {
+		em.AddMetric("validation_success", result.validationSuccess)
+	}
 
 	if result.latencyBreakdown != nil {
 		if dl := result.latencyBreakdown.dnsLatency; dl != nil {
@@ -493,9 +892,89 @@ func (p *Probe) exportMetrics(ts time.Time, result *probeResult, target endpoint
 		}
 	}
 
+	if p.opts.RateLimiter != nil {
+		em.AddMetric("throttled_runs", metrics.NewInt(result.throttledRuns))
+	}
+
+	if p.opts.Schedule != nil {
+		em.AddMetric("suppressed_runs", metrics.NewInt(result.suppressedRuns))
+	}
+
+	if p.opts.ProbeChangedTargetsOnly() {
+		em.AddMetric(options.SkippedUnchangedMetricName, metrics.NewInt(result.skippedUnchanged))
+	}
+
+	if result.shadow != nil {
+		result.shadow.addMetrics(em, p.opts.LatencyMetricName)
+	}
+
+	if result.retriedRequests != nil {
+		em.AddMetric("retried_requests", result.retriedRequests.Clone())
+	}
+
+	if p.c.GetPagination() != nil {
+		em.AddMetric("pages", metrics.NewInt(result.pages)).
+			AddMetric("page_failures", metrics.NewInt(result.pageFailures))
+	}
+
+	if result.redirect != nil {
+		em.AddMetric("redirect_failures", result.redirect.failures.Clone())
+	}
+
+	if result.decompress != nil {
+		result.decompress.addMetrics(em)
+	}
+
+	if result.byteRange != nil {
+		result.byteRange.addMetrics(em)
+	}
+
+	if result.proxy != nil {
+		result.proxy.addMetrics(em)
+	}
+
+	if result.bodyMetrics != nil {
+		em.AddMetric("body_metric_failures", result.bodyMetrics.failures.Clone())
+	}
+
+	if result.unreachableOutcomes != nil {
+		em.AddMetric("unreachable_outcome", result.unreachableOutcomes.Clone())
+	}
+
+	if result.failures != nil {
+		em.AddMetric("failures", result.failures.Clone())
+	}
+
 	em.AddLabel("ptype", "http").AddLabel("probe", p.name).AddLabel("dst", target.Name)
+	if p.c.GetSni() != "" {
+		em.AddLabel("sni", p.c.GetSni()).AddLabel("host", result.hostHeader)
+	}
 	p.opts.RecordMetrics(target, em, dataChan)
 
+	// Last seen redirect chain is exported as labels of an independent GAUGE
+	// EM.
+	if result.redirect != nil && result.redirect.lastFinalURL != "" {
+		em := metrics.NewEventMetrics(ts).
+			AddMetric("redirect_chain", metrics.NewInt(1))
+		em.Kind = metrics.GAUGE
+		em.AddLabel("ptype", "http").AddLabel("probe", p.name).AddLabel("dst", target.Name).
+			AddLabel("redirect_hops", strconv.Itoa(result.redirect.lastHops)).
+			AddLabel("final_url", result.redirect.lastFinalURL)
+		p.opts.RecordMetrics(target, em, dataChan, options.WithNoAlert())
+	}
+
+	// Values extracted from the response body are exported in an independent
+	// GAUGE EM.
+	if result.bodyMetrics != nil && result.bodyMetrics.values != nil {
+		em := metrics.NewEventMetrics(ts)
+		for i, bm := range p.bodyMetrics.metrics {
+			em.AddMetric(bm.name, metrics.NewFloat(result.bodyMetrics.values[i]))
+		}
+		em.Kind = metrics.GAUGE
+		em.AddLabel("ptype", "http").AddLabel("probe", p.name).AddLabel("dst", target.Name)
+		p.opts.RecordMetrics(target, em, dataChan, options.WithNoAlert())
+	}
+
 	// SSL earliest cert expiry is exported in an independent EM as it's a
 	// GAUGE metrics.
 	if result.sslEarliestExpirationSeconds >= 0 {
@@ -544,48 +1023,80 @@ func (p *Probe) clientsForTarget(target endpoint.Endpoint) []*http.Client {
 	return clients
 }
 
-func (p *Probe) startForTarget(ctx context.Context, target endpoint.Endpoint, dataChan chan *metrics.EventMetrics) {
-	p.l.Debug("Starting probing for the target ", target.Name)
+// targetState holds the per-target probing state.
+type targetState struct {
+	target  endpoint.Endpoint
+	result  *probeResult
+	req     *http.Request
+	clients []*http.Client
 
 	// We use this counter to decide when to export stats.
-	var runCnt int64
+	runCnt int64
+}
+
+func (p *Probe) newTargetState(target endpoint.Endpoint) *targetState {
+	return &targetState{
+		target:  target,
+		result:  p.newResultWithValidators(p.opts.ValidatorsForTarget(target)),
+		req:     p.httpRequestForTarget(target),
+		clients: p.clientsForTarget(target),
+	}
+}
+
+// runForTarget runs a single probe cycle for the target and exports stats
+// if it's the time to do so.
+func (p *Probe) runForTarget(ctx context.Context, ts time.Time, st *targetState, dataChan chan *metrics.EventMetrics) {
+	result := st.result
+
+	// If request is nil (most likely because target resolving failed or it
+	// was an invalid target), skip this probe cycle. Note that request
+	// creation gets retried at a regular interval (stats export interval).
+	// Unchanged targets are skipped if probing only changed targets. Runs
+	// outside of the probe's schedule are suppressed, not failed; we keep
+	// exporting stats during that time so that suppressed runs are visible.
+	if !p.opts.IsScheduled() {
+		result.suppressedRuns++
+	} else if p.opts.SkipUnchanged(st.target, ts) {
+		result.skippedUnchanged++
+	} else if endRun, ok := p.opts.BeginRun(); ok {
+		if st.req != nil {
+			if p.opts.RateLimiter.Wait(ctx, int(p.c.GetRequestsPerProbe()), p.opts.MaxRateLimitDelay()) > 0 {
+				result.throttledRuns++
+			}
+			p.runProbe(ctx, st.target, st.clients, st.req, result)
+		} else {
+			result.total += int64(p.c.GetRequestsPerProbe())
+		}
+		endRun()
+	}
+
+	// Export stats if it's the time to do so.
+	st.runCnt++
+	if (st.runCnt % p.statsExportFrequency) == 0 {
+		p.exportMetrics(ts, result, st.target, dataChan)
+
+		// If we are resolving first, this is also a good time to recreate HTTP
+		// request in case target's IP has changed.
+		if p.c.GetResolveFirst() {
+			st.req = p.httpRequestForTarget(st.target)
+		}
+	}
+}
+
+func (p *Probe) startForTarget(ctx context.Context, target endpoint.Endpoint, dataChan chan *metrics.EventMetrics) {
+	p.l.Debug("Starting probing for the target ", target.Name)
 
-	result := p.newResult()
-	req := p.httpRequestForTarget(target)
+	st := p.newTargetState(target)
 	ticker := time.NewTicker(p.opts.Interval)
 	defer ticker.Stop()
 
-	clients := p.clientsForTarget(target)
 	for ts := time.Now(); true; ts = <-ticker.C {
 		// Don't run another probe if context is canceled already.
 		if ctxDone(ctx) {
 			return
 		}
 
-		if !p.opts.IsScheduled() {
-			continue
-		}
-
-		// If request is nil (most likely because target resolving failed or it
-		// was an invalid target), skip this probe cycle. Note that request
-		// creation gets retried at a regular interval (stats export interval).
-		if req != nil {
-			p.runProbe(ctx, target, clients, req, result)
-		} else {
-			result.total += int64(p.c.GetRequestsPerProbe())
-		}
-
-		// Export stats if it's the time to do so.
-		runCnt++
-		if (runCnt % p.statsExportFrequency) == 0 {
-			p.exportMetrics(ts, result, target, dataChan)
-
-			// If we are resolving first, this is also a good time to recreate HTTP
-			// request in case target's IP has changed.
-			if p.c.GetResolveFirst() {
-				req = p.httpRequestForTarget(target)
-			}
-		}
+		p.runForTarget(ctx, ts, st, dataChan)
 	}
 }
 
@@ -608,6 +1119,8 @@ func (p *Probe) gapBetweenTargets() time.Duration {
 // concurrently by Start().
 func (p *Probe) updateTargetsAndStartProbes(ctx context.Context, dataChan chan *metrics.EventMetrics) {
 	p.targets = p.opts.Targets.ListEndpoints()
+	p.opts.UpdateTargets(p.targets)
+	p.opts.RecordEmptyTargets(time.Now(), p.name, dataChan)
 
 	p.l.Debugf("Probe(%s) got %d targets", p.name, len(p.targets))
 
@@ -691,6 +1204,11 @@ func (p *Probe) wait() {
 func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
 	defer p.wait()
 
+	if n := p.c.GetMaxConcurrentProbes(); n > 0 {
+		p.newWorkerPool(dataChan).run(ctx, int(n))
+		return
+	}
+
 	p.updateTargetsAndStartProbes(ctx, dataChan)
 
 	// Do more frequent listing of targets until we get a non-zero list of
@@ -23,6 +23,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/http/httptrace"
 	"net/url"
 	"os"
@@ -33,14 +34,21 @@ import (
 	"testing"
 	"time"
 
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	unreachablepb "github.com/cloudprober/cloudprober/internal/unreachable/proto"
+	"github.com/cloudprober/cloudprober/internal/validators"
+	validatorpb "github.com/cloudprober/cloudprober/internal/validators/proto"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/metrics/testutils"
 	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
 	"github.com/cloudprober/cloudprober/probes/options"
+	probespb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 	"google.golang.org/protobuf/proto"
 )
@@ -746,6 +754,67 @@ func TestGetTransport(t *testing.T) {
 	}
 }
 
+func TestGetTransportIdleConns(t *testing.T) {
+	p := &Probe{opts: options.DefaultOptions()}
+
+	p.c = &configpb.ProbeConf{
+		KeepAlive:           proto.Bool(true),
+		IdleConnTimeoutMsec: proto.Int32(90000),
+		MaxIdleConnsPerHost: proto.Int32(4),
+		RequestsPerProbe:    proto.Int32(10),
+	}
+	transport, err := p.getTransport()
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.Equal(t, 4, transport.MaxIdleConnsPerHost)
+
+	p.c = &configpb.ProbeConf{IdleConnTimeoutMsec: proto.Int32(90000)}
+	_, err = p.getTransport()
+	assert.Error(t, err, "idle_conn_timeout_msec without keep_alive")
+}
+
+func TestProbeConnReused(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	for _, keepAlive := range []bool{true, false} {
+		t.Run(fmt.Sprintf("keep_alive=%v", keepAlive), func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:      proto.Int32(int32(port)),
+					KeepAlive: proto.Bool(keepAlive),
+				},
+				LogMetrics: func(_ *metrics.EventMetrics) {},
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			clients := p.clientsForTarget(target)
+			for i := 0; i < 3; i++ {
+				p.runProbe(context.Background(), target, clients, p.httpRequestForTarget(target), result)
+			}
+			assert.Equal(t, int64(3), result.success)
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			if !keepAlive {
+				assert.Nil(t, em.Metric("conn_reused"))
+				return
+			}
+			assert.Equal(t, int64(1), result.connEvent)
+			assert.Equal(t, "2", em.Metric("conn_reused").String())
+		})
+	}
+}
+
 func TestProbeInitRedirects(t *testing.T) {
 	p := &Probe{}
 	maxRedirects := 10
@@ -1040,3 +1109,275 @@ func TestProbeWithLatencyBreakdown(t *testing.T) {
 		})
 	}
 }
+
+func TestProbeFailureReasons(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	// Get a port with nothing listening on it.
+	ln, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	closedPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	tests := []struct {
+		name           string
+		port           int
+		relURL         string
+		validatorRegex string
+		wantReason     string
+	}{
+		{name: "success", port: port},
+		{name: "connect", port: closedPort, wantReason: options.FailureConnect},
+		{name: "timeout", port: port, relURL: "/slow", wantReason: options.FailureTimeout},
+		{name: "validation", port: port, validatorRegex: "not-ok", wantReason: options.FailureValidation},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  100 * time.Millisecond,
+				ProbeConf: &configpb.ProbeConf{
+					Port:        proto.Int32(int32(test.port)),
+					RelativeUrl: proto.String(test.relURL),
+				},
+				ExportFailureReasons: true,
+				LogMetrics:           func(_ *metrics.EventMetrics) {},
+			}
+			if test.validatorRegex != "" {
+				v, err := validators.Init([]*validatorpb.Validator{
+					{
+						Name: "regex",
+						Type: &validatorpb.Validator_Regex{Regex: test.validatorRegex},
+					},
+				}, nil)
+				assert.NoError(t, err)
+				opts.Validators = v
+			}
+
+			p := &Probe{}
+			assert.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			for _, reason := range result.failures.Keys() {
+				want := int64(0)
+				if reason == test.wantReason {
+					want = 1
+				}
+				assert.Equal(t, want, result.failures.GetKey(reason), "reason: %s", reason)
+			}
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			assert.Equal(t, result.failures.String(), (<-dataChan).Metric("failures").String())
+		})
+	}
+}
+
+func TestProbeSuppressedRuns(t *testing.T) {
+	// Disable the probe all the time.
+	schedule, err := options.NewSchedule([]*probespb.Schedule{
+		{
+			Type:    probespb.Schedule_DISABLE.Enum(),
+			EndTime: proto.String("23:59"),
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	opts := &options.Options{
+		Targets:             targets.StaticTargets("test.com"),
+		Interval:            10 * time.Millisecond,
+		StatsExportInterval: 10 * time.Millisecond,
+		Timeout:             5 * time.Millisecond,
+		Schedule:            schedule,
+		ProbeConf:           &configpb.ProbeConf{},
+		LogMetrics:          func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	target := endpoint.Endpoint{Name: "test.com"}
+	st := p.newTargetState(target)
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	for i := 0; i < 3; i++ {
+		p.runForTarget(context.Background(), time.Now(), st, dataChan)
+	}
+
+	ems, err := testutils.MetricsFromChannel(dataChan, 3, time.Second)
+	require.NoError(t, err)
+	for i, em := range ems {
+		assert.Equal(t, int64(0), em.Metric("total").(metrics.NumValue).Int64(), "total")
+		assert.Equal(t, int64(i+1), em.Metric("suppressed_runs").(metrics.NumValue).Int64(), "suppressed_runs")
+	}
+}
+
+func TestProbeExpectUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+	openPort := ts.Listener.Addr().(*net.TCPAddr).Port
+
+	// Get a port with nothing listening on it.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	tests := []struct {
+		name        string
+		port        int
+		wantOutcome string
+		wantSuccess int64
+	}{
+		{name: "refused", port: closedPort, wantOutcome: "refused", wantSuccess: 1},
+		{name: "open", port: openPort, wantOutcome: "open"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets("127.0.0.1"),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:              proto.Int32(int32(test.port)),
+					ExpectUnreachable: &unreachablepb.ExpectUnreachable{},
+				},
+				LogMetrics:           func(_ *metrics.EventMetrics) {},
+				ExportFailureReasons: true,
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: "127.0.0.1"}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			assert.Equal(t, int64(1), result.unreachableOutcomes.GetKey(test.wantOutcome))
+			if test.wantSuccess == 0 {
+				assert.Equal(t, int64(1), result.failures.GetKey(options.FailureValidation))
+			}
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			assert.Equal(t, int64(1), em.Metric("unreachable_outcome").(*metrics.Map[int64]).GetKey(test.wantOutcome))
+		})
+	}
+}
+
+func TestProbeWithSNI(t *testing.T) {
+	var gotSNI, gotHost string
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSNI, gotHost = r.TLS.ServerName, r.Host
+	}))
+	ts.StartTLS()
+	defer ts.Close()
+	port := ts.Listener.Addr().(*net.TCPAddr).Port
+
+	conf := &configpb.ProbeConf{
+		SchemeType: &configpb.ProbeConf_Scheme_{Scheme: configpb.ProbeConf_HTTPS},
+		Port:       proto.Int32(int32(port)),
+		Sni:        proto.String("edge.example.com"),
+		Headers:    []*configpb.ProbeConf_Header{{Name: proto.String("Host"), Value: proto.String("origin.example.com")}},
+		TlsConfig:  &tlsconfigpb.TLSConfig{DisableCertValidation: proto.Bool(true)},
+	}
+	opts := &options.Options{
+		Targets:    targets.StaticTargets("127.0.0.1"),
+		Interval:   2 * time.Second,
+		Timeout:    time.Second,
+		ProbeConf:  conf,
+		LogMetrics: func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	target := endpoint.Endpoint{Name: "127.0.0.1"}
+	result := p.newResult()
+	p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+	assert.Equal(t, int64(1), result.success)
+	assert.Equal(t, "edge.example.com", gotSNI)
+	assert.Equal(t, "origin.example.com", gotHost)
+
+	dataChan := make(chan *metrics.EventMetrics, 10)
+	p.exportMetrics(time.Now(), result, target, dataChan)
+	em := <-dataChan
+	assert.Equal(t, "edge.example.com", em.Label("sni"))
+	assert.Equal(t, "origin.example.com", em.Label("host"))
+
+	conf.TlsConfig.ServerName = proto.String("other.example.com")
+	assert.Error(t, (&Probe{}).Init("http_test", opts), "sni with tls_config.server_name")
+}
+
+func TestProbeWithValidatorSets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	regexValidator := func(name, regex string) *validatorpb.Validator {
+		return &validatorpb.Validator{Name: name, Type: &validatorpb.Validator_Regex{Regex: regex}}
+	}
+	opts, err := options.BuildProbeOptions(&probespb.ProbeDef{
+		Name:      proto.String("http_test"),
+		Type:      probespb.ProbeDef_HTTP.Enum(),
+		Targets:   &targetspb.TargetsDef{Type: &targetspb.TargetsDef_HostNames{HostNames: u.Hostname()}},
+		Validator: []*validatorpb.Validator{regexValidator("default", "ok")},
+		ValidatorSet: []*probespb.ProbeDef_ValidatorSet{
+			{Name: proto.String("xml"), Validator: []*validatorpb.Validator{regexValidator("xml", "^<")}},
+			{Name: proto.String("json"), Validator: []*validatorpb.Validator{regexValidator("json", "^{")}},
+		},
+	}, nil, nil, nil)
+	require.NoError(t, err)
+	opts.ProbeConf = &configpb.ProbeConf{Port: proto.Int32(int32(port))}
+	opts.LogMetrics = func(*metrics.EventMetrics) {}
+	opts.ExportValidationSuccess = true
+
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	for _, test := range []struct {
+		set          string
+		wantSuccess  int64
+		wantFailures string
+		wantPasses   string
+	}{
+		{set: "", wantSuccess: 1, wantFailures: "map:validator,default:0", wantPasses: "map:validator,default:1"},
+		{set: "json", wantSuccess: 1, wantFailures: "map:validator,json:0", wantPasses: "map:validator,json:1"},
+		{set: "xml", wantSuccess: 0, wantFailures: "map:validator,xml:1", wantPasses: "map:validator,xml:0"},
+	} {
+		t.Run(test.set, func(t *testing.T) {
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			if test.set != "" {
+				target.Labels = map[string]string{"validator_set": test.set}
+			}
+			st := p.newTargetState(target)
+			p.runProbe(context.Background(), st.target, st.clients, st.req, st.result)
+			assert.Equal(t, int64(1), st.result.total)
+			assert.Equal(t, test.wantSuccess, st.result.success)
+			assert.Equal(t, test.wantFailures, st.result.validationFailure.String())
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), st.result, st.target, dataChan)
+			em := <-dataChan
+			assert.Equal(t, test.wantPasses, em.Metric("validation_success").String())
+		})
+	}
+}
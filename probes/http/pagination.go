@@ -0,0 +1,190 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/internal/validators"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+type page struct {
+	resp *http.Response
+	body []byte
+}
+
+type pagedResponse struct {
+	pages    []*page
+	failures int64
+}
+
+// nextLink returns the URL of the "next" link from the Link header values,
+// resolved relative to the base URL.
+func nextLink(linkHeaders []string, base *url.URL) *url.URL {
+	for _, header := range linkHeaders {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range parts[1:] {
+				k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(k), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(v), `"`)) {
+					if strings.EqualFold(rel, "next") {
+						u, err := base.Parse(target[1 : len(target)-1])
+						if err != nil {
+							return nil
+						}
+						return u
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sensitiveHeaders are not forwarded to the pages on other hosts, similar to
+// what net/http does on redirects.
+var sensitiveHeaders = []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2", "Proxy-Authorization"}
+
+// isDomainOrSubdomain reports whether dest's host is the same as, or a
+// subdomain of, initial's host. Ports are not considered, same as net/http.
+func isDomainOrSubdomain(dest, initial *url.URL) bool {
+	dh, ih := strings.ToLower(dest.Hostname()), strings.ToLower(initial.Hostname())
+	if dh == ih {
+		return true
+	}
+	if net.ParseIP(ih) != nil {
+		return false
+	}
+	return strings.HasSuffix(dh, "."+ih)
+}
+
+// fetchPage fetches a single page and reads its body. Request headers are
+// copied from the original request, except the sensitive ones (credentials
+// and cookies) if the page is on a different host.
+func (p *Probe) fetchPage(req *http.Request, client *http.Client, u *url.URL) (*page, error) {
+	pageReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	pageReq.Header = req.Header.Clone()
+	if !isDomainOrSubdomain(u, req.URL) {
+		for _, h := range sensitiveHeaders {
+			pageReq.Header.Del(h)
+		}
+	}
+	if u.Host == req.URL.Host {
+		pageReq.Host = req.Host
+	}
+
+	resp, err := client.Do(pageReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("got status code: %d", resp.StatusCode)
+	}
+	return &page{resp: resp, body: body}, nil
+}
+
+// fetchPages reads the first response's body and follows the "next" links
+// to fetch the rest of the pages. It returns an error if reading the first
+// page fails, or if a subsequent page fails and fail_on_page_failure is set.
+func (p *Probe) fetchPages(req *http.Request, client *http.Client, resp *http.Response, targetName string) (*pagedResponse, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	pg := p.c.GetPagination()
+	pr := &pagedResponse{pages: []*page{{resp: resp, body: body}}}
+	visited := map[string]bool{req.URL.String(): true}
+
+	for next := nextLink(resp.Header.Values("Link"), req.URL); next != nil; {
+		if len(pr.pages) >= int(pg.GetMaxPages()) {
+			p.l.Warningf("Target:%s, URL:%s, reached max_pages (%d), ignoring the remaining pages", targetName, req.URL.String(), pg.GetMaxPages())
+			break
+		}
+		if visited[next.String()] {
+			p.l.Warningf("Target:%s, URL:%s, pagination loop detected at: %s", targetName, req.URL.String(), next.String())
+			break
+		}
+		visited[next.String()] = true
+
+		np, err := p.fetchPage(req, client, next)
+		if err != nil {
+			pr.failures++
+			if pg.GetFailOnPageFailure() {
+				return pr, fmt.Errorf("error fetching page %s: %v", next.String(), err)
+			}
+			p.l.Warningf("Target:%s, error fetching page %s: %v", targetName, next.String(), err)
+			break
+		}
+		pr.pages = append(pr.pages, np)
+		next = nextLink(np.resp.Header.Values("Link"), next)
+	}
+
+	return pr, nil
+}
+
+// validatePages runs validators on the pages as per the pagination config,
+// and returns the failed validations.
+func (p *Probe) validatePages(pr *pagedResponse, result *probeResult) []string {
+	pg := p.c.GetPagination()
+
+	if pg.GetValidateMode() == configpb.ProbeConf_Pagination_CONCATENATED {
+		var bodies [][]byte
+		for _, pp := range pr.pages {
+			bodies = append(bodies, pp.body)
+		}
+		return validators.RunValidators(result.validators, &validators.Input{Response: pr.pages[0].resp, ResponseBody: bytes.Join(bodies, nil)}, result.validationFailure, result.validationSuccess, p.l)
+	}
+
+	var failures []string
+	for i, pp := range pr.pages {
+		failed := validators.RunValidators(result.validators, &validators.Input{Response: pp.resp, ResponseBody: pp.body}, result.validationFailure, result.validationSuccess, p.l)
+		if len(failed) == 0 {
+			continue
+		}
+		if i > 0 {
+			pr.failures++
+			if !pg.GetFailOnPageFailure() {
+				continue
+			}
+		}
+		failures = append(failures, failed...)
+	}
+	return failures
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/validators"
+	validatorpb "github.com/cloudprober/cloudprober/internal/validators/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNextLink(t *testing.T) {
+	base, _ := url.Parse("http://example.com/items?page=1")
+
+	tests := []struct {
+		name    string
+		headers []string
+		want    string
+	}{
+		{
+			name: "no_header",
+		},
+		{
+			name:    "absolute",
+			headers: []string{`<http://example.com/items?page=2>; rel="next"`},
+			want:    "http://example.com/items?page=2",
+		},
+		{
+			name:    "relative_multiple_links",
+			headers: []string{`</items?page=1>; rel="prev", </items?page=3>; rel="next last"`},
+			want:    "http://example.com/items?page=3",
+		},
+		{
+			name:    "multiple_headers",
+			headers: []string{`</items?page=1>; rel=first`, `</items?page=4>; rel=next`},
+			want:    "http://example.com/items?page=4",
+		},
+		{
+			name:    "no_next",
+			headers: []string{`</items?page=1>; rel="prev"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := nextLink(test.headers, base)
+			if test.want == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, test.want, got.String())
+		})
+	}
+}
+
+// newPaginatedServer returns a server that serves numPages pages, linked
+// through the Link header. Page number failPage, if non-zero, returns 500.
+func newPaginatedServer(numPages, failPage int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if n == 0 {
+			n = 1
+		}
+		if n == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if n < numPages {
+			w.Header().Set("Link", fmt.Sprintf(`</?page=%d>; rel="next"`, n+1))
+		}
+		fmt.Fprintf(w, "page-%d;", n)
+	}))
+}
+
+func TestProbeWithPagination(t *testing.T) {
+	tests := []struct {
+		name             string
+		numPages         int
+		failPage         int
+		pagination       *configpb.ProbeConf_Pagination
+		validatorRegex   string
+		wantSuccess      int64
+		wantPages        int64
+		wantPageFailures int64
+	}{
+		{
+			name:        "all_pages",
+			numPages:    3,
+			pagination:  &configpb.ProbeConf_Pagination{},
+			wantSuccess: 1,
+			wantPages:   3,
+		},
+		{
+			name:        "max_pages",
+			numPages:    5,
+			pagination:  &configpb.ProbeConf_Pagination{MaxPages: proto.Int32(2)},
+			wantSuccess: 1,
+			wantPages:   2,
+		},
+		{
+			name:             "page_failure",
+			numPages:         3,
+			failPage:         2,
+			pagination:       &configpb.ProbeConf_Pagination{},
+			wantSuccess:      0,
+			wantPages:        1,
+			wantPageFailures: 1,
+		},
+		{
+			name:     "page_failure_ignored",
+			numPages: 3,
+			failPage: 2,
+			pagination: &configpb.ProbeConf_Pagination{
+				FailOnPageFailure: proto.Bool(false),
+			},
+			wantSuccess:      1,
+			wantPages:        1,
+			wantPageFailures: 1,
+		},
+		{
+			name:             "each_page_validation",
+			numPages:         3,
+			pagination:       &configpb.ProbeConf_Pagination{},
+			validatorRegex:   "page-1;",
+			wantSuccess:      0,
+			wantPages:        3,
+			wantPageFailures: 2,
+		},
+		{
+			name:     "concatenated_validation",
+			numPages: 3,
+			pagination: &configpb.ProbeConf_Pagination{
+				ValidateMode: configpb.ProbeConf_Pagination_CONCATENATED.Enum(),
+			},
+			validatorRegex: "page-1;page-2;page-3;",
+			wantSuccess:    1,
+			wantPages:      3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := newPaginatedServer(test.numPages, test.failPage)
+			defer ts.Close()
+
+			u, _ := url.Parse(ts.URL)
+			port, _ := strconv.Atoi(u.Port())
+
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:       proto.Int32(int32(port)),
+					Pagination: test.pagination,
+				},
+				LogMetrics: func(_ *metrics.EventMetrics) {},
+			}
+			if test.validatorRegex != "" {
+				v, err := validators.Init([]*validatorpb.Validator{
+					{
+						Name: "regex",
+						Type: &validatorpb.Validator_Regex{Regex: test.validatorRegex},
+					},
+				}, nil)
+				assert.NoError(t, err)
+				opts.Validators = v
+			}
+
+			p := &Probe{}
+			assert.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.total, "total")
+			assert.Equal(t, test.wantSuccess, result.success, "success")
+			assert.Equal(t, test.wantPages, result.pages, "pages")
+			assert.Equal(t, test.wantPageFailures, result.pageFailures, "page_failures")
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			for _, m := range []string{"pages", "page_failures"} {
+				assert.NotNil(t, em.Metric(m), m)
+			}
+		})
+	}
+}
+
+func TestFetchPageHeaders(t *testing.T) {
+	var gotHeader http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Clone()
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	req, _ := http.NewRequest(http.MethodGet, ts.URL, nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Custom", "value")
+
+	tests := []struct {
+		name     string
+		host     string
+		wantAuth bool
+	}{
+		{name: "same_host", host: u.Host, wantAuth: true},
+		{name: "cross_host", host: "localhost:" + u.Port(), wantAuth: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Probe{}
+			_, err := p.fetchPage(req, http.DefaultClient, &url.URL{Scheme: "http", Host: test.host, Path: "/next"})
+			if err != nil {
+				t.Skipf("Couldn't fetch page from %s: %v", test.host, err)
+			}
+			assert.Equal(t, "value", gotHeader.Get("X-Custom"))
+			if test.wantAuth {
+				assert.Equal(t, "Bearer secret", gotHeader.Get("Authorization"))
+				assert.Equal(t, "session=secret", gotHeader.Get("Cookie"))
+			} else {
+				assert.Empty(t, gotHeader.Get("Authorization"))
+				assert.Empty(t, gotHeader.Get("Cookie"))
+			}
+		})
+	}
+
+	assert.True(t, isDomainOrSubdomain(&url.URL{Host: "api.example.com"}, &url.URL{Host: "example.com"}))
+	assert.False(t, isDomainOrSubdomain(&url.URL{Host: "evilexample.com"}, &url.URL{Host: "example.com"}))
+	assert.False(t, isDomainOrSubdomain(&url.URL{Host: "1.127.0.0.1"}, &url.URL{Host: "127.0.0.1"}))
+}
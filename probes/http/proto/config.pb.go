@@ -8,7 +8,9 @@ package proto
 
 import (
 	proto "github.com/cloudprober/cloudprober/internal/oauth/proto"
+	proto2 "github.com/cloudprober/cloudprober/internal/requestid/proto"
 	proto1 "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	proto3 "github.com/cloudprober/cloudprober/internal/unreachable/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -220,7 +222,65 @@ func (ProbeConf_LatencyBreakdown) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 2}
 }
 
-// Next tag: 21
+type ProbeConf_Pagination_ValidateMode int32
+
+const (
+	// Run validators on each page separately.
+	ProbeConf_Pagination_EACH_PAGE ProbeConf_Pagination_ValidateMode = 0
+	// Run validators once on the concatenation of all the pages' bodies.
+	ProbeConf_Pagination_CONCATENATED ProbeConf_Pagination_ValidateMode = 1
+)
+
+// Enum value maps for ProbeConf_Pagination_ValidateMode.
+var (
+	ProbeConf_Pagination_ValidateMode_name = map[int32]string{
+		0: "EACH_PAGE",
+		1: "CONCATENATED",
+	}
+	ProbeConf_Pagination_ValidateMode_value = map[string]int32{
+		"EACH_PAGE":    0,
+		"CONCATENATED": 1,
+	}
+)
+
+func (x ProbeConf_Pagination_ValidateMode) Enum() *ProbeConf_Pagination_ValidateMode {
+	p := new(ProbeConf_Pagination_ValidateMode)
+	*p = x
+	return p
+}
+
+func (x ProbeConf_Pagination_ValidateMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProbeConf_Pagination_ValidateMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_enumTypes[3].Descriptor()
+}
+
+func (ProbeConf_Pagination_ValidateMode) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_enumTypes[3]
+}
+
+func (x ProbeConf_Pagination_ValidateMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProbeConf_Pagination_ValidateMode) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProbeConf_Pagination_ValidateMode(num)
+	return nil
+}
+
+// Deprecated: Use ProbeConf_Pagination_ValidateMode.Descriptor instead.
+func (ProbeConf_Pagination_ValidateMode) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 7, 0}
+}
+
+// Next tag: 42
 type ProbeConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -268,6 +328,20 @@ type ProbeConf struct {
 	//	}
 	Headers []*ProbeConf_Header `protobuf:"bytes,8,rep,name=headers" json:"headers,omitempty"`
 	Header  map[string]string   `protobuf:"bytes,20,rep,name=header" json:"header,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// If set, names of the headers configured through "headers" are sent as
+	// configured, without canonicalization (e.g. "x-api-key" instead of
+	// "X-Api-Key"), and all "headers" entries with the same name are sent, in
+	// the configured order, instead of the last one overriding the others.
+	// This is useful to mimic a specific client, along with user_agent.
+	//
+	// Transport limitations:
+	//   - Go's HTTP transport writes the header fields sorted by name (after
+	//     Host and User-Agent), so the order of the different header fields
+	//     can't be controlled. Only the order of values for a header name is
+	//     preserved.
+	//   - HTTP/2 always sends the header names in lowercase.
+	//   - Host and User-Agent headers are always sent with canonical names.
+	PreserveHeaderCase *bool `protobuf:"varint,40,opt,name=preserve_header_case,json=preserveHeaderCase" json:"preserve_header_case,omitempty"`
 	// Request body. This field works similar to the curl's data flag. If there
 	// are multiple "body" fields, we combine their values with a '&' in between.
 	//
@@ -285,8 +359,22 @@ type ProbeConf struct {
 	//	body: "clientId=aweseomeClient"
 	//	body: "clientSecret=noSecret"
 	Body []string `protobuf:"bytes,9,rep,name=body" json:"body,omitempty"`
+	// Read request body from this file, instead of specifying it inline using
+	// the "body" field above. Content type is guessed the same way as for the
+	// "body" field. File is re-read if it's modified, checked at most once per
+	// probe interval. If file cannot be read at the startup, probe fails to
+	// initialize. Later read errors are logged and last read content is used.
+	// File path can also be a GCS (gs://), S3 (s3://) or HTTP(S) URL.
+	BodyFile *string `protobuf:"bytes,29,opt,name=body_file,json=bodyFile" json:"body_file,omitempty"`
+	// Read additional request headers from this file. File should contain one
+	// header per line in the "Name: value" format. Empty lines and lines
+	// starting with '#' are ignored. Headers from this file override the
+	// headers configured inline. File is reloaded the same way as body_file.
+	HeadersFile *string `protobuf:"bytes,30,opt,name=headers_file,json=headersFile" json:"headers_file,omitempty"`
 	// Enable HTTP keep-alive. If set to true, underlying connection is reused
 	// for further probes. Default is to close the connection after every request.
+	// With keep-alive, new connections and reused connections are counted in
+	// the "connect_event" and "conn_reused" metrics respectively.
 	KeepAlive *bool `protobuf:"varint,10,opt,name=keep_alive,json=keepAlive" json:"keep_alive,omitempty"`
 	// OAuth Config
 	OauthConfig *proto.Config `protobuf:"bytes,11,opt,name=oauth_config,json=oauthConfig" json:"oauth_config,omitempty"`
@@ -305,16 +393,64 @@ type ProbeConf struct {
 	DisableCertValidation *bool `protobuf:"varint,14,opt,name=disable_cert_validation,json=disableCertValidation" json:"disable_cert_validation,omitempty"`
 	// TLS config
 	TlsConfig *proto1.TLSConfig `protobuf:"bytes,15,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// TLS server name (SNI) to send in the TLS handshake, independent of the
+	// Host header (configured through "header"). This is useful to test the
+	// edge routing of the CDNs, e.g. to send "Host: origin.example.com" to the
+	// edge serving "edge.example.com". Server certificate is verified against
+	// the SNI. If set, "sni" and "host" labels, with the SNI and the Host
+	// header of the last request, are added to the probe metrics. It cannot be
+	// used along with tls_config.server_name.
+	Sni *string `protobuf:"bytes,41,opt,name=sni" json:"sni,omitempty"`
+	// If configured, timestamp extracted from the last response is used as the
+	// timestamp of the exported metrics, instead of the local time, e.g. to
+	// align the metrics with the server's clock. Each extracted timestamp is
+	// used only once; if there is no new timestamp since the last export, or
+	// if it can't be extracted or parsed, local time is used, and the latter
+	// is logged as a warning. Metrics timestamps never go backwards for a
+	// target: if the extracted timestamp is not newer than the last exported
+	// timestamp, local time is used instead, and if local time is behind too,
+	// the last exported timestamp is reused.
+	// Example:
+	//
+	//	response_timestamp {
+	//	  jq_filter: ".server_time"
+	//	  format: "unix_ms"
+	//	}
+	ResponseTimestamp *ProbeConf_ResponseTimestamp `protobuf:"bytes,42,opt,name=response_timestamp,json=responseTimestamp" json:"response_timestamp,omitempty"`
 	// Proxy URL, e.g. http://myproxy:3128
 	ProxyUrl *string `protobuf:"bytes,16,opt,name=proxy_url,json=proxyUrl" json:"proxy_url,omitempty"`
+	// Ordered list of proxies to fail over between. Each request is first
+	// sent through the first proxy; if it fails at the proxy layer (e.g.
+	// connection to the proxy fails or proxy rejects the CONNECT request), the
+	// request is retried through the next proxy, and so on. Requests that go
+	// through a proxy are counted in the "proxy_requests" metric, and proxy
+	// layer failures in the "proxy_failures" metric, both by the "proxy"
+	// label (proxy host). If all proxies fail, request is counted in the
+	// "all_proxies_failed" metric, and with failure_reason "proxy" if failure
+	// reasons are being exported. This field cannot be used along with
+	// proxy_url.
+	// Example:
+	//
+	//	proxy_urls: [ "http://proxy-a:3128", "http://proxy-b:3128" ]
+	ProxyUrls []string `protobuf:"bytes,32,rep,name=proxy_urls,json=proxyUrls" json:"proxy_urls,omitempty"`
 	// HTTP proxy connect headers. These headers are passed on to the CONNECT
 	// requests to the HTTP proxies. Note that CONNECT method is used to fetch
 	// HTTPS URLs via HTTP proxies.
 	ProxyConnectHeader map[string]string `protobuf:"bytes,23,rep,name=proxy_connect_header,json=proxyConnectHeader" json:"proxy_connect_header,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	// User agent. Default user agent is Go's default user agent.
+	// User agent. Default user agent is Go's default user agent. It overrides
+	// the User-Agent header set through "headers" or "header".
 	UserAgent *string `protobuf:"bytes,19,opt,name=user_agent,json=userAgent" json:"user_agent,omitempty"`
 	// Maximum idle connections to keep alive
 	MaxIdleConns *int32 `protobuf:"varint,17,opt,name=max_idle_conns,json=maxIdleConns,def=256" json:"max_idle_conns,omitempty"`
+	// Idle connections are closed after this timeout. Default is 2 probe
+	// intervals, so that connections are reused between probe cycles. If your
+	// targets support keep-alive for longer, you can set this to a larger value
+	// to avoid re-dialing. Requires keep_alive.
+	IdleConnTimeoutMsec *int32 `protobuf:"varint,36,opt,name=idle_conn_timeout_msec,json=idleConnTimeoutMsec" json:"idle_conn_timeout_msec,omitempty"`
+	// Maximum idle connections to keep alive per host. Default is
+	// requests_per_probe, if it's more than 1, and Go's default (2) otherwise.
+	// Requires keep_alive.
+	MaxIdleConnsPerHost *int32 `protobuf:"varint,37,opt,name=max_idle_conns_per_host,json=maxIdleConnsPerHost" json:"max_idle_conns_per_host,omitempty"`
 	// The maximum amount of redirects the HTTP client will follow.
 	// To disable redirects, use max_redirects: 0.
 	MaxRedirects *int32 `protobuf:"varint,18,opt,name=max_redirects,json=maxRedirects" json:"max_redirects,omitempty"`
@@ -328,6 +464,122 @@ type ProbeConf struct {
 	//	latency_breakdown: [ ALL_STAGES ]
 	//	latency_breakdown: [ DNS_LATENCY, CONNECT_LATENCY, TLS_HANDSHAKE_LATENCY ]
 	LatencyBreakdown []ProbeConf_LatencyBreakdown `protobuf:"varint,22,rep,name=latency_breakdown,json=latencyBreakdown,enum=cloudprober.probes.http.ProbeConf_LatencyBreakdown" json:"latency_breakdown,omitempty"`
+	// If configured, failed requests are captured to files: failure reason, and
+	// response status, headers and body if a response was received. Requests
+	// are captured on all failures, e.g. connection errors, timeouts and
+	// validation failures. Nothing is written for successful requests. Files are written to <dir>/<probe>/ and their names
+	// contain target name and the timestamp, e.g.
+	// <dir>/<probe>/<target>_20240102T150405.000000000.txt.
+	// Example:
+	//
+	//	failure_capture {
+	//	  dir: "/var/log/cloudprober/failures"
+	//	  max_files: 50
+	//	}
+	FailureCapture *ProbeConf_FailureCapture `protobuf:"bytes,24,opt,name=failure_capture,json=failureCapture" json:"failure_capture,omitempty"`
+	// Shadow mode: if configured, each request is also sent to a shadow
+	// endpoint and the two responses are compared for status code, body and
+	// latency. Comparison results are exported as shadow_* metrics, e.g.
+	// shadow_divergence{reason=status|body|latency|error}. Shadow responses
+	// don't affect the primary probe's success.
+	// Example:
+	//
+	//	shadow {
+	//	  host: "new-frontend.example.com"
+	//	  latency_tolerance_msec: 100
+	//	}
+	Shadow *ProbeConf_Shadow `protobuf:"bytes,25,opt,name=shadow" json:"shadow,omitempty"`
+	// If configured, requests that fail with one of the configured status
+	// codes are retried once, after waiting for the delay requested by the
+	// server through the Retry-After header (or default_delay_msec if there is
+	// no Retry-After header). Request is retried only if the retry can finish
+	// within the probe timeout. Retried requests are counted in the
+	// "retried_requests" metric, by status code. Note that a retried request
+	// still counts as a single request in the "total" metric.
+	// Example:
+	//
+	//	retry {}
+	Retry *ProbeConf_Retry `protobuf:"bytes,26,opt,name=retry" json:"retry,omitempty"`
+	// Maximum number of targets to probe concurrently. By default, each target
+	// is probed in its own goroutine. If this option is set, targets are
+	// probed through a bounded pool of workers instead: at every probe
+	// interval, all targets are queued and workers pick them up from the
+	// queue. If a target's previous probe is still queued or running, that
+	// target is skipped for the interval. Queue depth is exported in the
+	// "queue_depth" metric at the beginning of each stats export interval;
+	// a consistently non-zero value indicates that workers are not able to
+	// go through all the targets within the probe interval.
+	// Note that interval_between_targets_msec is not used in this mode.
+	MaxConcurrentProbes *int32                `protobuf:"varint,27,opt,name=max_concurrent_probes,json=maxConcurrentProbes" json:"max_concurrent_probes,omitempty"`
+	Pagination          *ProbeConf_Pagination `protobuf:"bytes,28,opt,name=pagination" json:"pagination,omitempty"`
+	// Redirect chain validation. If configured, probe follows the redirects,
+	// captures the full redirect chain and validates it. Run fails if
+	// validation fails. Failures are counted in the "redirect_failures" metric,
+	// by reason: final_url_mismatch, hop_count_mismatch, hop_mismatch, loop and
+	// max_hops. Number of hops and the final URL of the last chain are
+	// exported as labels ("redirect_hops" and "final_url") of the
+	// "redirect_chain" metric. This field cannot be used together with
+	// max_redirects.
+	// Example, to verify that http://x redirects to https://x/:
+	//
+	//	redirect_chain {
+	//	  final_url_regex: "^https://x/$"
+	//	  hop_url_regex: "^https://x/$"
+	//	}
+	RedirectChain *ProbeConf_RedirectChain `protobuf:"bytes,31,opt,name=redirect_chain,json=redirectChain" json:"redirect_chain,omitempty"`
+	// If configured, each request carries a unique request ID and a W3C trace
+	// context, in the configured headers. Request ID is also logged for the
+	// failed requests and recorded in the failure captures.
+	// Example:
+	//
+	//	request_id {
+	//	  request_id_header: "X-Correlation-ID"
+	//	}
+	RequestId *proto2.RequestIDConfig `protobuf:"bytes,33,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// If set, response must be compressed using this encoding: "gzip", "br" or
+	// "deflate". Probe requests it using the Accept-Encoding header (unless
+	// that header is configured explicitly), and verifies that the response's
+	// Content-Encoding matches and that the body decompresses correctly. Run
+	// fails otherwise, and failure is counted in the "decompression_failures"
+	// metric, by reason: encoding_mismatch or corrupt. Validators see the
+	// decompressed body. Compressed and decompressed body sizes are exported
+	// as cumulative "resp_compressed_bytes" and "resp_decompressed_bytes"
+	// metrics.
+	ExpectedContentEncoding *string `protobuf:"bytes,34,opt,name=expected_content_encoding,json=expectedContentEncoding" json:"expected_content_encoding,omitempty"`
+	// Byte range to request using the Range header, e.g. "0-1023", "1024-"
+	// (from offset 1024 to the end), or "-500" (last 500 bytes). If set, run
+	// succeeds only if the response is a 206 (Partial Content), with a
+	// Content-Range matching the requested range, and a body of that length. A
+	// full 200 response is a failure. Failures are counted in the
+	// "range_failures" metric, by reason: not_partial, invalid_content_range,
+	// range_mismatch or length_mismatch. Total bytes in the returned ranges are
+	// exported as the cumulative "resp_range_bytes" metric.
+	//
+	// Since ranges apply to the encoded content, Accept-Encoding is set to
+	// "identity" (unless it's configured explicitly or through
+	// expected_content_encoding). byte_range cannot be used with pagination.
+	ByteRange *string `protobuf:"bytes,35,opt,name=byte_range,json=byteRange" json:"byte_range,omitempty"`
+	// Numeric values to extract from the response body and export as GAUGE
+	// metrics, one per target. For each body_metric, regex is matched against
+	// the response body (after decompression, if any) and the captured value
+	// becomes the metric's value. Extraction runs only if the response passes
+	// the validators. If the regex doesn't match, or the captured value is not
+	// a number, run fails, and failure is counted in the
+	// "body_metric_failures" metric, by metric name. Last extracted value is
+	// exported until a new value is extracted.
+	// Example:
+	//
+	//	body_metric {
+	//	  name: "pending_jobs"
+	//	  regex: "pending_jobs: (\\d+)"
+	//	}
+	BodyMetric []*ProbeConf_BodyMetric `protobuf:"bytes,38,rep,name=body_metric,json=bodyMetric" json:"body_metric,omitempty"`
+	// If set, probe succeeds only if the target is NOT reachable, e.g. to
+	// verify that a firewall blocks the requests. Any HTTP response, including
+	// error responses, means that target is "open". Observed outcome is
+	// exported in the "unreachable_outcome" metric, and the response checks,
+	// e.g. validators, don't apply.
+	ExpectUnreachable *proto3.ExpectUnreachable `protobuf:"bytes,39,opt,name=expect_unreachable,json=expectUnreachable" json:"expect_unreachable,omitempty"`
 	// Interval between targets.
 	IntervalBetweenTargetsMsec *int32 `protobuf:"varint,97,opt,name=interval_between_targets_msec,json=intervalBetweenTargetsMsec,def=10" json:"interval_between_targets_msec,omitempty"`
 	// Requests per probe.
@@ -460,6 +712,13 @@ func (x *ProbeConf) GetHeader() map[string]string {
 	return nil
 }
 
+func (x *ProbeConf) GetPreserveHeaderCase() bool {
+	if x != nil && x.PreserveHeaderCase != nil {
+		return *x.PreserveHeaderCase
+	}
+	return false
+}
+
 func (x *ProbeConf) GetBody() []string {
 	if x != nil {
 		return x.Body
@@ -467,6 +726,20 @@ func (x *ProbeConf) GetBody() []string {
 	return nil
 }
 
+func (x *ProbeConf) GetBodyFile() string {
+	if x != nil && x.BodyFile != nil {
+		return *x.BodyFile
+	}
+	return ""
+}
+
+func (x *ProbeConf) GetHeadersFile() string {
+	if x != nil && x.HeadersFile != nil {
+		return *x.HeadersFile
+	}
+	return ""
+}
+
 func (x *ProbeConf) GetKeepAlive() bool {
 	if x != nil && x.KeepAlive != nil {
 		return *x.KeepAlive
@@ -502,6 +775,20 @@ func (x *ProbeConf) GetTlsConfig() *proto1.TLSConfig {
 	return nil
 }
 
+func (x *ProbeConf) GetSni() string {
+	if x != nil && x.Sni != nil {
+		return *x.Sni
+	}
+	return ""
+}
+
+func (x *ProbeConf) GetResponseTimestamp() *ProbeConf_ResponseTimestamp {
+	if x != nil {
+		return x.ResponseTimestamp
+	}
+	return nil
+}
+
 func (x *ProbeConf) GetProxyUrl() string {
 	if x != nil && x.ProxyUrl != nil {
 		return *x.ProxyUrl
@@ -509,6 +796,13 @@ func (x *ProbeConf) GetProxyUrl() string {
 	return ""
 }
 
+func (x *ProbeConf) GetProxyUrls() []string {
+	if x != nil {
+		return x.ProxyUrls
+	}
+	return nil
+}
+
 func (x *ProbeConf) GetProxyConnectHeader() map[string]string {
 	if x != nil {
 		return x.ProxyConnectHeader
@@ -530,6 +824,20 @@ func (x *ProbeConf) GetMaxIdleConns() int32 {
 	return Default_ProbeConf_MaxIdleConns
 }
 
+func (x *ProbeConf) GetIdleConnTimeoutMsec() int32 {
+	if x != nil && x.IdleConnTimeoutMsec != nil {
+		return *x.IdleConnTimeoutMsec
+	}
+	return 0
+}
+
+func (x *ProbeConf) GetMaxIdleConnsPerHost() int32 {
+	if x != nil && x.MaxIdleConnsPerHost != nil {
+		return *x.MaxIdleConnsPerHost
+	}
+	return 0
+}
+
 func (x *ProbeConf) GetMaxRedirects() int32 {
 	if x != nil && x.MaxRedirects != nil {
 		return *x.MaxRedirects
@@ -544,6 +852,83 @@ func (x *ProbeConf) GetLatencyBreakdown() []ProbeConf_LatencyBreakdown {
 	return nil
 }
 
+func (x *ProbeConf) GetFailureCapture() *ProbeConf_FailureCapture {
+	if x != nil {
+		return x.FailureCapture
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetShadow() *ProbeConf_Shadow {
+	if x != nil {
+		return x.Shadow
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetRetry() *ProbeConf_Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetMaxConcurrentProbes() int32 {
+	if x != nil && x.MaxConcurrentProbes != nil {
+		return *x.MaxConcurrentProbes
+	}
+	return 0
+}
+
+func (x *ProbeConf) GetPagination() *ProbeConf_Pagination {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetRedirectChain() *ProbeConf_RedirectChain {
+	if x != nil {
+		return x.RedirectChain
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetRequestId() *proto2.RequestIDConfig {
+	if x != nil {
+		return x.RequestId
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetExpectedContentEncoding() string {
+	if x != nil && x.ExpectedContentEncoding != nil {
+		return *x.ExpectedContentEncoding
+	}
+	return ""
+}
+
+func (x *ProbeConf) GetByteRange() string {
+	if x != nil && x.ByteRange != nil {
+		return *x.ByteRange
+	}
+	return ""
+}
+
+func (x *ProbeConf) GetBodyMetric() []*ProbeConf_BodyMetric {
+	if x != nil {
+		return x.BodyMetric
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetExpectUnreachable() *proto3.ExpectUnreachable {
+	if x != nil {
+		return x.ExpectUnreachable
+	}
+	return nil
+}
+
 func (x *ProbeConf) GetIntervalBetweenTargetsMsec() int32 {
 	if x != nil && x.IntervalBetweenTargetsMsec != nil {
 		return *x.IntervalBetweenTargetsMsec
@@ -636,227 +1021,1028 @@ func (x *ProbeConf_Header) GetValue() string {
 	return ""
 }
 
-var File_github_com_cloudprober_cloudprober_probes_http_proto_config_proto protoreflect.FileDescriptor
+type ProbeConf_ResponseTimestamp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDesc = []byte{
-	0x0a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x17, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x44, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x95, 0x0e, 0x0a,
-	0x09, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x4d, 0x0a, 0x08, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66,
-	0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x3a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x48, 0x00, 0x52,
-	0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x49, 0x0a, 0x06, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68,
-	0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x63,
-	0x68, 0x65, 0x6d, 0x65, 0x3a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x48, 0x00, 0x52, 0x06, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65,
-	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61,
-	0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72,
-	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x46, 0x69, 0x72, 0x73, 0x74,
-	0x12, 0x42, 0x0a, 0x1a, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x5f, 0x61, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x52, 0x17, 0x65, 0x78, 0x70,
-	0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x41, 0x73, 0x4d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50,
-	0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x3a,
-	0x03, 0x47, 0x45, 0x54, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x43, 0x0a, 0x07,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e,
-	0x66, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x12, 0x46, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x14, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62,
-	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
-	0x79, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x3c, 0x0a, 0x0c,
-	0x6f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2e, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x6f,
-	0x61, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69,
-	0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x32, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x74, 0x74, 0x70, 0x32, 0x12,
-	0x36, 0x0a, 0x17, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x15, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x43, 0x65, 0x72, 0x74, 0x56, 0x61, 0x6c,
-	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74,
-	0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x78,
-	0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f,
-	0x78, 0x79, 0x55, 0x72, 0x6c, 0x12, 0x6c, 0x0a, 0x14, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x17, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72,
-	0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x12, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65,
-	0x6e, 0x74, 0x12, 0x29, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x63,
-	0x6f, 0x6e, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x32, 0x35, 0x36, 0x52,
-	0x0c, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x12, 0x23, 0x0a,
-	0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x12,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63,
-	0x74, 0x73, 0x12, 0x60, 0x0a, 0x11, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x62, 0x72,
-	0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x33, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e,
-	0x66, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f,
-	0x77, 0x6e, 0x52, 0x10, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b,
-	0x64, 0x6f, 0x77, 0x6e, 0x12, 0x45, 0x0a, 0x1d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
-	0x5f, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73,
-	0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x61, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52,
-	0x1a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e,
-	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x2f, 0x0a, 0x12, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x18, 0x62, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x31, 0x52, 0x10, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x37, 0x0a, 0x16,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x63, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x30, 0x52,
-	0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
-	0x6c, 0x4d, 0x73, 0x65, 0x63, 0x1a, 0x32, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x45, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x1d, 0x0a, 0x06, 0x53,
-	0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x00, 0x12,
-	0x09, 0x0a, 0x05, 0x48, 0x54, 0x54, 0x50, 0x53, 0x10, 0x01, 0x22, 0x52, 0x0a, 0x06, 0x4d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a,
-	0x04, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x02,
-	0x12, 0x08, 0x0a, 0x04, 0x48, 0x45, 0x41, 0x44, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45,
-	0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10,
-	0x05, 0x12, 0x0b, 0x0a, 0x07, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x06, 0x22, 0xa4,
-	0x01, 0x0a, 0x10, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64,
-	0x6f, 0x77, 0x6e, 0x12, 0x10, 0x0a, 0x0c, 0x4e, 0x4f, 0x5f, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x44,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x4c, 0x4c, 0x5f, 0x53, 0x54, 0x41,
-	0x47, 0x45, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x4e, 0x53, 0x5f, 0x4c, 0x41, 0x54,
-	0x45, 0x4e, 0x43, 0x59, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43,
-	0x54, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x54,
-	0x4c, 0x53, 0x5f, 0x48, 0x41, 0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x5f, 0x4c, 0x41, 0x54,
-	0x45, 0x4e, 0x43, 0x59, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x51, 0x5f, 0x57, 0x52,
-	0x49, 0x54, 0x45, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x05, 0x12, 0x16, 0x0a,
-	0x12, 0x46, 0x49, 0x52, 0x53, 0x54, 0x5f, 0x42, 0x59, 0x54, 0x45, 0x5f, 0x4c, 0x41, 0x54, 0x45,
-	0x4e, 0x43, 0x59, 0x10, 0x06, 0x42, 0x0d, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x42, 0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x73, 0x2f, 0x68, 0x74, 0x74, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	// Types that are assignable to Source:
+	//
+	//	*ProbeConf_ResponseTimestamp_Header
+	//	*ProbeConf_ResponseTimestamp_JqFilter
+	Source isProbeConf_ResponseTimestamp_Source `protobuf_oneof:"source"`
+	// Timestamp format: "unix", "unix_ms", "unix_us", "unix_ns", for the
+	// Unix timestamps in the corresponding units, or a Go time layout, e.g.
+	// "2006-01-02 15:04:05Z07:00" (see https://pkg.go.dev/time#Layout).
+	// By default, header values are parsed as HTTP dates, e.g.
+	// "Mon, 02 Jan 2006 15:04:05 GMT", or RFC 3339 timestamps; JSON strings
+	// are parsed as RFC 3339 timestamps and JSON numbers as Unix timestamps
+	// in seconds.
+	Format *string `protobuf:"bytes,3,opt,name=format" json:"format,omitempty"`
+}
+
+func (x *ProbeConf_ResponseTimestamp) Reset() {
+	*x = ProbeConf_ResponseTimestamp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescOnce sync.Once
-	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDesc
-)
+func (x *ProbeConf_ResponseTimestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP() []byte {
-	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescOnce.Do(func() {
-		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData)
-	})
-	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData
+func (*ProbeConf_ResponseTimestamp) ProtoMessage() {}
+
+func (x *ProbeConf_ResponseTimestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_goTypes = []any{
-	(ProbeConf_Scheme)(0),           // 0: cloudprober.probes.http.ProbeConf.Scheme
-	(ProbeConf_Method)(0),           // 1: cloudprober.probes.http.ProbeConf.Method
-	(ProbeConf_LatencyBreakdown)(0), // 2: cloudprober.probes.http.ProbeConf.LatencyBreakdown
-	(*ProbeConf)(nil),               // 3: cloudprober.probes.http.ProbeConf
-	(*ProbeConf_Header)(nil),        // 4: cloudprober.probes.http.ProbeConf.Header
-	nil,                             // 5: cloudprober.probes.http.ProbeConf.HeaderEntry
-	nil,                             // 6: cloudprober.probes.http.ProbeConf.ProxyConnectHeaderEntry
-	(*proto.Config)(nil),            // 7: cloudprober.oauth.Config
-	(*proto1.TLSConfig)(nil),        // 8: cloudprober.tlsconfig.TLSConfig
+// Deprecated: Use ProbeConf_ResponseTimestamp.ProtoReflect.Descriptor instead.
+func (*ProbeConf_ResponseTimestamp) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 2}
 }
-var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_depIdxs = []int32{
-	0, // 0: cloudprober.probes.http.ProbeConf.protocol:type_name -> cloudprober.probes.http.ProbeConf.Scheme
-	0, // 1: cloudprober.probes.http.ProbeConf.scheme:type_name -> cloudprober.probes.http.ProbeConf.Scheme
-	1, // 2: cloudprober.probes.http.ProbeConf.method:type_name -> cloudprober.probes.http.ProbeConf.Method
-	4, // 3: cloudprober.probes.http.ProbeConf.headers:type_name -> cloudprober.probes.http.ProbeConf.Header
-	5, // 4: cloudprober.probes.http.ProbeConf.header:type_name -> cloudprober.probes.http.ProbeConf.HeaderEntry
-	7, // 5: cloudprober.probes.http.ProbeConf.oauth_config:type_name -> cloudprober.oauth.Config
-	8, // 6: cloudprober.probes.http.ProbeConf.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
-	6, // 7: cloudprober.probes.http.ProbeConf.proxy_connect_header:type_name -> cloudprober.probes.http.ProbeConf.ProxyConnectHeaderEntry
-	2, // 8: cloudprober.probes.http.ProbeConf.latency_breakdown:type_name -> cloudprober.probes.http.ProbeConf.LatencyBreakdown
-	9, // [9:9] is the sub-list for method output_type
-	9, // [9:9] is the sub-list for method input_type
-	9, // [9:9] is the sub-list for extension type_name
-	9, // [9:9] is the sub-list for extension extendee
-	0, // [0:9] is the sub-list for field type_name
+
+func (m *ProbeConf_ResponseTimestamp) GetSource() isProbeConf_ResponseTimestamp_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
 }
 
-func init() { file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_init() }
-func file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_init() {
-	if File_github_com_cloudprober_cloudprober_probes_http_proto_config_proto != nil {
-		return
+func (x *ProbeConf_ResponseTimestamp) GetHeader() string {
+	if x, ok := x.GetSource().(*ProbeConf_ResponseTimestamp_Header); ok {
+		return x.Header
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*ProbeConf); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*ProbeConf_Header); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
+	return ""
+}
+
+func (x *ProbeConf_ResponseTimestamp) GetJqFilter() string {
+	if x, ok := x.GetSource().(*ProbeConf_ResponseTimestamp_JqFilter); ok {
+		return x.JqFilter
 	}
-	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[0].OneofWrappers = []any{
-		(*ProbeConf_Protocol)(nil),
-		(*ProbeConf_Scheme_)(nil),
+	return ""
+}
+
+func (x *ProbeConf_ResponseTimestamp) GetFormat() string {
+	if x != nil && x.Format != nil {
+		return *x.Format
+	}
+	return ""
+}
+
+type isProbeConf_ResponseTimestamp_Source interface {
+	isProbeConf_ResponseTimestamp_Source()
+}
+
+type ProbeConf_ResponseTimestamp_Header struct {
+	// Response header to read the timestamp from, e.g. "Date".
+	Header string `protobuf:"bytes,1,opt,name=header,oneof"`
+}
+
+type ProbeConf_ResponseTimestamp_JqFilter struct {
+	// jq filter to extract the timestamp from the JSON response body, e.g.
+	// ".meta.server_time".
+	JqFilter string `protobuf:"bytes,2,opt,name=jq_filter,json=jqFilter,oneof"`
+}
+
+func (*ProbeConf_ResponseTimestamp_Header) isProbeConf_ResponseTimestamp_Source() {}
+
+func (*ProbeConf_ResponseTimestamp_JqFilter) isProbeConf_ResponseTimestamp_Source() {}
+
+type ProbeConf_FailureCapture struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Directory to write the captured responses to. Responses are written to
+	// a per-probe subdirectory, named after the probe. Directories are
+	// created if they don't exist already.
+	Dir *string `protobuf:"bytes,1,req,name=dir" json:"dir,omitempty"`
+	// Maximum number of response body bytes to capture.
+	MaxBodyBytes *int32 `protobuf:"varint,2,opt,name=max_body_bytes,json=maxBodyBytes,def=65536" json:"max_body_bytes,omitempty"`
+	// Maximum number of capture files to keep for this probe. Oldest files
+	// are removed once this limit is reached.
+	MaxFiles *int32 `protobuf:"varint,3,opt,name=max_files,json=maxFiles,def=100" json:"max_files,omitempty"`
+}
+
+// Default values for ProbeConf_FailureCapture fields.
+const (
+	Default_ProbeConf_FailureCapture_MaxBodyBytes = int32(65536)
+	Default_ProbeConf_FailureCapture_MaxFiles     = int32(100)
+)
+
+func (x *ProbeConf_FailureCapture) Reset() {
+	*x = ProbeConf_FailureCapture{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_FailureCapture) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_FailureCapture) ProtoMessage() {}
+
+func (x *ProbeConf_FailureCapture) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_FailureCapture.ProtoReflect.Descriptor instead.
+func (*ProbeConf_FailureCapture) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *ProbeConf_FailureCapture) GetDir() string {
+	if x != nil && x.Dir != nil {
+		return *x.Dir
+	}
+	return ""
+}
+
+func (x *ProbeConf_FailureCapture) GetMaxBodyBytes() int32 {
+	if x != nil && x.MaxBodyBytes != nil {
+		return *x.MaxBodyBytes
+	}
+	return Default_ProbeConf_FailureCapture_MaxBodyBytes
+}
+
+func (x *ProbeConf_FailureCapture) GetMaxFiles() int32 {
+	if x != nil && x.MaxFiles != nil {
+		return *x.MaxFiles
+	}
+	return Default_ProbeConf_FailureCapture_MaxFiles
+}
+
+type ProbeConf_Shadow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Shadow endpoint's host, with optional port, e.g. "new-backend:8080".
+	// Shadow request is the same as the primary request (scheme, path,
+	// headers, body), with only the URL host replaced with this host.
+	Host *string `protobuf:"bytes,1,req,name=host" json:"host,omitempty"`
+	// By default, Host header of the shadow request is also set to the
+	// shadow host. Set this field to true to keep the primary request's Host
+	// header, for example when shadow endpoint is an IP address.
+	PreserveHostHeader *bool `protobuf:"varint,2,opt,name=preserve_host_header,json=preserveHostHeader" json:"preserve_host_header,omitempty"`
+	// Whether to compare response bodies (using their SHA-256 hash).
+	CompareBody *bool `protobuf:"varint,3,opt,name=compare_body,json=compareBody,def=1" json:"compare_body,omitempty"`
+	// If set to a non-zero value, responses are considered divergent if
+	// their latencies differ by more than this value.
+	LatencyToleranceMsec *int32 `protobuf:"varint,4,opt,name=latency_tolerance_msec,json=latencyToleranceMsec" json:"latency_tolerance_msec,omitempty"`
+}
+
+// Default values for ProbeConf_Shadow fields.
+const (
+	Default_ProbeConf_Shadow_CompareBody = bool(true)
+)
+
+func (x *ProbeConf_Shadow) Reset() {
+	*x = ProbeConf_Shadow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_Shadow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_Shadow) ProtoMessage() {}
+
+func (x *ProbeConf_Shadow) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_Shadow.ProtoReflect.Descriptor instead.
+func (*ProbeConf_Shadow) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *ProbeConf_Shadow) GetHost() string {
+	if x != nil && x.Host != nil {
+		return *x.Host
+	}
+	return ""
+}
+
+func (x *ProbeConf_Shadow) GetPreserveHostHeader() bool {
+	if x != nil && x.PreserveHostHeader != nil {
+		return *x.PreserveHostHeader
+	}
+	return false
+}
+
+func (x *ProbeConf_Shadow) GetCompareBody() bool {
+	if x != nil && x.CompareBody != nil {
+		return *x.CompareBody
+	}
+	return Default_ProbeConf_Shadow_CompareBody
+}
+
+func (x *ProbeConf_Shadow) GetLatencyToleranceMsec() int32 {
+	if x != nil && x.LatencyToleranceMsec != nil {
+		return *x.LatencyToleranceMsec
+	}
+	return 0
+}
+
+type ProbeConf_Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Status codes to retry on.
+	// Default: 429 (Too Many Requests) and 503 (Service Unavailable).
+	StatusCode []int32 `protobuf:"varint,1,rep,name=status_code,json=statusCode" json:"status_code,omitempty"`
+	// How long to wait before retrying, if response doesn't have a
+	// Retry-After header.
+	DefaultDelayMsec *int32 `protobuf:"varint,2,opt,name=default_delay_msec,json=defaultDelayMsec,def=100" json:"default_delay_msec,omitempty"`
+	// Maximum delay to honor from the Retry-After header. Requests with a
+	// longer Retry-After are not retried.
+	MaxDelayMsec *int32 `protobuf:"varint,3,opt,name=max_delay_msec,json=maxDelayMsec,def=5000" json:"max_delay_msec,omitempty"`
+}
+
+// Default values for ProbeConf_Retry fields.
+const (
+	Default_ProbeConf_Retry_DefaultDelayMsec = int32(100)
+	Default_ProbeConf_Retry_MaxDelayMsec     = int32(5000)
+)
+
+func (x *ProbeConf_Retry) Reset() {
+	*x = ProbeConf_Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_Retry) ProtoMessage() {}
+
+func (x *ProbeConf_Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_Retry.ProtoReflect.Descriptor instead.
+func (*ProbeConf_Retry) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *ProbeConf_Retry) GetStatusCode() []int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return nil
+}
+
+func (x *ProbeConf_Retry) GetDefaultDelayMsec() int32 {
+	if x != nil && x.DefaultDelayMsec != nil {
+		return *x.DefaultDelayMsec
+	}
+	return Default_ProbeConf_Retry_DefaultDelayMsec
+}
+
+func (x *ProbeConf_Retry) GetMaxDelayMsec() int32 {
+	if x != nil && x.MaxDelayMsec != nil {
+		return *x.MaxDelayMsec
+	}
+	return Default_ProbeConf_Retry_MaxDelayMsec
+}
+
+// Pagination configuration. If configured, probe follows the "next" links
+// in the response's Link header (RFC 8288), e.g.:
+//
+//	Link: <https://api.example.com/health?page=2>; rel="next"
+//
+// and fetches all the pages as part of a single probe run. Latency covers
+// all the pages. Number of pages fetched and failed pages are exported in
+// the "pages" and "page_failures" metrics. Pages are requested with the
+// same headers as the first request, except that credentials and cookies
+// (Authorization, Cookie, etc) are not sent to pages on other hosts.
+type ProbeConf_Pagination struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of pages to fetch, including the first page. If there
+	// are more pages, they are ignored.
+	MaxPages     *int32                             `protobuf:"varint,1,opt,name=max_pages,json=maxPages,def=10" json:"max_pages,omitempty"`
+	ValidateMode *ProbeConf_Pagination_ValidateMode `protobuf:"varint,2,opt,name=validate_mode,json=validateMode,enum=cloudprober.probes.http.ProbeConf_Pagination_ValidateMode" json:"validate_mode,omitempty"`
+	// Whether a failure on any page (request error, non-2xx status code, or
+	// validation failure in EACH_PAGE mode) fails the probe run. If false,
+	// failures on the pages after the first page are only counted in the
+	// "page_failures" metric.
+	FailOnPageFailure *bool `protobuf:"varint,3,opt,name=fail_on_page_failure,json=failOnPageFailure,def=1" json:"fail_on_page_failure,omitempty"`
+}
+
+// Default values for ProbeConf_Pagination fields.
+const (
+	Default_ProbeConf_Pagination_MaxPages          = int32(10)
+	Default_ProbeConf_Pagination_FailOnPageFailure = bool(true)
+)
+
+func (x *ProbeConf_Pagination) Reset() {
+	*x = ProbeConf_Pagination{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_Pagination) ProtoMessage() {}
+
+func (x *ProbeConf_Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_Pagination.ProtoReflect.Descriptor instead.
+func (*ProbeConf_Pagination) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 7}
+}
+
+func (x *ProbeConf_Pagination) GetMaxPages() int32 {
+	if x != nil && x.MaxPages != nil {
+		return *x.MaxPages
+	}
+	return Default_ProbeConf_Pagination_MaxPages
+}
+
+func (x *ProbeConf_Pagination) GetValidateMode() ProbeConf_Pagination_ValidateMode {
+	if x != nil && x.ValidateMode != nil {
+		return *x.ValidateMode
+	}
+	return ProbeConf_Pagination_EACH_PAGE
+}
+
+func (x *ProbeConf_Pagination) GetFailOnPageFailure() bool {
+	if x != nil && x.FailOnPageFailure != nil {
+		return *x.FailOnPageFailure
+	}
+	return Default_ProbeConf_Pagination_FailOnPageFailure
+}
+
+type ProbeConf_RedirectChain struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Regex that the final URL, i.e. the URL of the last request in the
+	// chain, must match.
+	FinalUrlRegex *string `protobuf:"bytes,1,opt,name=final_url_regex,json=finalUrlRegex" json:"final_url_regex,omitempty"`
+	// If specified, redirect chain must have exactly these many hops and
+	// each hop's URL (request URLs, after the initial one) must match the
+	// corresponding regex.
+	HopUrlRegex []string `protobuf:"bytes,2,rep,name=hop_url_regex,json=hopUrlRegex" json:"hop_url_regex,omitempty"`
+	// Maximum number of redirects to follow. Exceeding it is a failure.
+	MaxHops *int32 `protobuf:"varint,3,opt,name=max_hops,json=maxHops,def=10" json:"max_hops,omitempty"`
+}
+
+// Default values for ProbeConf_RedirectChain fields.
+const (
+	Default_ProbeConf_RedirectChain_MaxHops = int32(10)
+)
+
+func (x *ProbeConf_RedirectChain) Reset() {
+	*x = ProbeConf_RedirectChain{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_RedirectChain) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_RedirectChain) ProtoMessage() {}
+
+func (x *ProbeConf_RedirectChain) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_RedirectChain.ProtoReflect.Descriptor instead.
+func (*ProbeConf_RedirectChain) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 8}
+}
+
+func (x *ProbeConf_RedirectChain) GetFinalUrlRegex() string {
+	if x != nil && x.FinalUrlRegex != nil {
+		return *x.FinalUrlRegex
+	}
+	return ""
+}
+
+func (x *ProbeConf_RedirectChain) GetHopUrlRegex() []string {
+	if x != nil {
+		return x.HopUrlRegex
+	}
+	return nil
+}
+
+func (x *ProbeConf_RedirectChain) GetMaxHops() int32 {
+	if x != nil && x.MaxHops != nil {
+		return *x.MaxHops
+	}
+	return Default_ProbeConf_RedirectChain_MaxHops
+}
+
+type ProbeConf_BodyMetric struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Metric name, e.g. "pending_jobs".
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// Regex with exactly one capture group. Captured value is parsed as a
+	// number, e.g. "pending_jobs: (\\d+)".
+	Regex *string `protobuf:"bytes,2,opt,name=regex" json:"regex,omitempty"`
+}
+
+func (x *ProbeConf_BodyMetric) Reset() {
+	*x = ProbeConf_BodyMetric{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_BodyMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_BodyMetric) ProtoMessage() {}
+
+func (x *ProbeConf_BodyMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_BodyMetric.ProtoReflect.Descriptor instead.
+func (*ProbeConf_BodyMetric) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP(), []int{0, 9}
+}
+
+func (x *ProbeConf_BodyMetric) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ProbeConf_BodyMetric) GetRegex() string {
+	if x != nil && x.Regex != nil {
+		return *x.Regex
+	}
+	return ""
+}
+
+var File_github_com_cloudprober_cloudprober_probes_http_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x68, 0x74, 0x74, 0x70,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x17, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x1a, 0x44, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x48, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xda, 0x1e, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x12, 0x4d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x3a, 0x04, 0x48,
+	0x54, 0x54, 0x50, 0x48, 0x00, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
+	0x49, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x3a, 0x04, 0x48, 0x54, 0x54, 0x50,
+	0x48, 0x00, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x1a, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x61, 0x73, 0x5f, 0x6d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x05, 0x66, 0x61, 0x6c, 0x73,
+	0x65, 0x52, 0x17, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x41, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e,
+	0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x3a, 0x03, 0x47, 0x45, 0x54, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74,
+	0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x30, 0x0a, 0x14, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x18, 0x28, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70,
+	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x61, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x41,
+	0x6c, 0x69, 0x76, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0b, 0x6f, 0x61, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x68, 0x74,
+	0x74, 0x70, 0x32, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x48, 0x74, 0x74, 0x70, 0x32, 0x12, 0x36, 0x0a, 0x17, 0x64, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x43, 0x65, 0x72, 0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x6e, 0x69, 0x18, 0x29, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73,
+	0x6e, 0x69, 0x12, 0x63, 0x0a, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x55, 0x72, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x75, 0x72,
+	0x6c, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x55,
+	0x72, 0x6c, 0x73, 0x12, 0x6c, 0x0a, 0x14, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x17, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x3a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62,
+	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x12, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74,
+	0x12, 0x29, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x32, 0x35, 0x36, 0x52, 0x0c, 0x6d,
+	0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x69,
+	0x64, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x24, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x69, 0x64, 0x6c,
+	0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63,
+	0x12, 0x34, 0x0a, 0x17, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x63, 0x6f, 0x6e,
+	0x6e, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x73, 0x50,
+	0x65, 0x72, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x12, 0x60, 0x0a, 0x11, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e,
+	0x18, 0x16, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x10, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x5a, 0x0a,
+	0x0f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0e, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x73, 0x68, 0x61,
+	0x64, 0x6f, 0x77, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68,
+	0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x53, 0x68,
+	0x61, 0x64, 0x6f, 0x77, 0x52, 0x06, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x12, 0x3e, 0x0a, 0x05,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73,
+	0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x15,
+	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x6d, 0x61, 0x78,
+	0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73,
+	0x12, 0x4d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x57, 0x0a, 0x0e, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x69,
+	0x6e, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74,
+	0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x52, 0x65, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x0d, 0x72, 0x65, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x45, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x64, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x3a, 0x0a, 0x19, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x22, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x17, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x79, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x62, 0x79, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x62, 0x6f,
+	0x64, 0x79, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x26, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x52, 0x0a,
+	0x62, 0x6f, 0x64, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x59, 0x0a, 0x12, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65,
+	0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65,
+	0x2e, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62,
+	0x6c, 0x65, 0x52, 0x11, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63,
+	0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x45, 0x0a, 0x1d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x61, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30,
+	0x52, 0x1a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65,
+	0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x2f, 0x0a, 0x12,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x18, 0x62, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x31, 0x52, 0x10, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x37, 0x0a,
+	0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x63, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x30,
+	0x52, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x4d, 0x73, 0x65, 0x63, 0x1a, 0x32, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x6e, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x18, 0x0a, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x6a, 0x71, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x6a, 0x71, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x1a, 0x45, 0x0a, 0x17, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x71, 0x0a, 0x0e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x43, 0x61, 0x70, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x72,
+	0x12, 0x2b, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x05, 0x36, 0x35, 0x35, 0x33, 0x36, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a,
+	0x09, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x3a, 0x03, 0x31, 0x30, 0x30, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x1a,
+	0xad, 0x01, 0x0a, 0x06, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x30,
+	0x0a, 0x14, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x70, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x48, 0x6f, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x27, 0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x0b, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x34, 0x0a, 0x16, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d,
+	0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x65, 0x63, 0x1a,
+	0x87, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x31, 0x0a, 0x12, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x65, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x31, 0x30, 0x30, 0x52, 0x10, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x2a, 0x0a,
+	0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x35, 0x30, 0x30, 0x30, 0x52, 0x0c, 0x6d, 0x61, 0x78,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x65, 0x63, 0x1a, 0xf6, 0x01, 0x0a, 0x0a, 0x50, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52,
+	0x08, 0x6d, 0x61, 0x78, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x0d, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x3a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x14, 0x66, 0x61,
+	0x69, 0x6c, 0x5f, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x11,
+	0x66, 0x61, 0x69, 0x6c, 0x4f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x22, 0x2f, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x41, 0x43, 0x48, 0x5f, 0x50, 0x41, 0x47, 0x45, 0x10, 0x00,
+	0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4f, 0x4e, 0x43, 0x41, 0x54, 0x45, 0x4e, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x1a, 0x7a, 0x0a, 0x0d, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x75, 0x72, 0x6c,
+	0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x22, 0x0a, 0x0d, 0x68,
+	0x6f, 0x70, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x68, 0x6f, 0x70, 0x55, 0x72, 0x6c, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12,
+	0x1d, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x68, 0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x48, 0x6f, 0x70, 0x73, 0x1a, 0x36,
+	0x0a, 0x0a, 0x42, 0x6f, 0x64, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x65, 0x67, 0x65, 0x78, 0x22, 0x1d, 0x0a, 0x06, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65,
+	0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x54,
+	0x54, 0x50, 0x53, 0x10, 0x01, 0x22, 0x52, 0x0a, 0x06, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
+	0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x53, 0x54,
+	0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x48,
+	0x45, 0x41, 0x44, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10,
+	0x04, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07,
+	0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x06, 0x22, 0xa4, 0x01, 0x0a, 0x10, 0x4c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x10,
+	0x0a, 0x0c, 0x4e, 0x4f, 0x5f, 0x42, 0x52, 0x45, 0x41, 0x4b, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x00,
+	0x12, 0x0e, 0x0a, 0x0a, 0x41, 0x4c, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x53, 0x10, 0x01,
+	0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x4e, 0x53, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10,
+	0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x5f, 0x4c, 0x41, 0x54,
+	0x45, 0x4e, 0x43, 0x59, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x4c, 0x53, 0x5f, 0x48, 0x41,
+	0x4e, 0x44, 0x53, 0x48, 0x41, 0x4b, 0x45, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10,
+	0x04, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x45, 0x51, 0x5f, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x4c,
+	0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x49, 0x52, 0x53,
+	0x54, 0x5f, 0x42, 0x59, 0x54, 0x45, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x06,
+	0x42, 0x0d, 0x0a, 0x0b, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42,
+	0x36, 0x5a, 0x34, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x68, 0x74, 0x74,
+	0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_goTypes = []any{
+	(ProbeConf_Scheme)(0),                  // 0: cloudprober.probes.http.ProbeConf.Scheme
+	(ProbeConf_Method)(0),                  // 1: cloudprober.probes.http.ProbeConf.Method
+	(ProbeConf_LatencyBreakdown)(0),        // 2: cloudprober.probes.http.ProbeConf.LatencyBreakdown
+	(ProbeConf_Pagination_ValidateMode)(0), // 3: cloudprober.probes.http.ProbeConf.Pagination.ValidateMode
+	(*ProbeConf)(nil),                      // 4: cloudprober.probes.http.ProbeConf
+	(*ProbeConf_Header)(nil),               // 5: cloudprober.probes.http.ProbeConf.Header
+	nil,                                    // 6: cloudprober.probes.http.ProbeConf.HeaderEntry
+	(*ProbeConf_ResponseTimestamp)(nil),    // 7: cloudprober.probes.http.ProbeConf.ResponseTimestamp
+	nil,                                    // 8: cloudprober.probes.http.ProbeConf.ProxyConnectHeaderEntry
+	(*ProbeConf_FailureCapture)(nil),       // 9: cloudprober.probes.http.ProbeConf.FailureCapture
+	(*ProbeConf_Shadow)(nil),               // 10: cloudprober.probes.http.ProbeConf.Shadow
+	(*ProbeConf_Retry)(nil),                // 11: cloudprober.probes.http.ProbeConf.Retry
+	(*ProbeConf_Pagination)(nil),           // 12: cloudprober.probes.http.ProbeConf.Pagination
+	(*ProbeConf_RedirectChain)(nil),        // 13: cloudprober.probes.http.ProbeConf.RedirectChain
+	(*ProbeConf_BodyMetric)(nil),           // 14: cloudprober.probes.http.ProbeConf.BodyMetric
+	(*proto.Config)(nil),                   // 15: cloudprober.oauth.Config
+	(*proto1.TLSConfig)(nil),               // 16: cloudprober.tlsconfig.TLSConfig
+	(*proto2.RequestIDConfig)(nil),         // 17: cloudprober.requestid.RequestIDConfig
+	(*proto3.ExpectUnreachable)(nil),       // 18: cloudprober.unreachable.ExpectUnreachable
+}
+var file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_depIdxs = []int32{
+	0,  // 0: cloudprober.probes.http.ProbeConf.protocol:type_name -> cloudprober.probes.http.ProbeConf.Scheme
+	0,  // 1: cloudprober.probes.http.ProbeConf.scheme:type_name -> cloudprober.probes.http.ProbeConf.Scheme
+	1,  // 2: cloudprober.probes.http.ProbeConf.method:type_name -> cloudprober.probes.http.ProbeConf.Method
+	5,  // 3: cloudprober.probes.http.ProbeConf.headers:type_name -> cloudprober.probes.http.ProbeConf.Header
+	6,  // 4: cloudprober.probes.http.ProbeConf.header:type_name -> cloudprober.probes.http.ProbeConf.HeaderEntry
+	15, // 5: cloudprober.probes.http.ProbeConf.oauth_config:type_name -> cloudprober.oauth.Config
+	16, // 6: cloudprober.probes.http.ProbeConf.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	7,  // 7: cloudprober.probes.http.ProbeConf.response_timestamp:type_name -> cloudprober.probes.http.ProbeConf.ResponseTimestamp
+	8,  // 8: cloudprober.probes.http.ProbeConf.proxy_connect_header:type_name -> cloudprober.probes.http.ProbeConf.ProxyConnectHeaderEntry
+	2,  // 9: cloudprober.probes.http.ProbeConf.latency_breakdown:type_name -> cloudprober.probes.http.ProbeConf.LatencyBreakdown
+	9,  // 10: cloudprober.probes.http.ProbeConf.failure_capture:type_name -> cloudprober.probes.http.ProbeConf.FailureCapture
+	10, // 11: cloudprober.probes.http.ProbeConf.shadow:type_name -> cloudprober.probes.http.ProbeConf.Shadow
+	11, // 12: cloudprober.probes.http.ProbeConf.retry:type_name -> cloudprober.probes.http.ProbeConf.Retry
+	12, // 13: cloudprober.probes.http.ProbeConf.pagination:type_name -> cloudprober.probes.http.ProbeConf.Pagination
+	13, // 14: cloudprober.probes.http.ProbeConf.redirect_chain:type_name -> cloudprober.probes.http.ProbeConf.RedirectChain
+	17, // 15: cloudprober.probes.http.ProbeConf.request_id:type_name -> cloudprober.requestid.RequestIDConfig
+	14, // 16: cloudprober.probes.http.ProbeConf.body_metric:type_name -> cloudprober.probes.http.ProbeConf.BodyMetric
+	18, // 17: cloudprober.probes.http.ProbeConf.expect_unreachable:type_name -> cloudprober.unreachable.ExpectUnreachable
+	3,  // 18: cloudprober.probes.http.ProbeConf.Pagination.validate_mode:type_name -> cloudprober.probes.http.ProbeConf.Pagination.ValidateMode
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_init() }
+func file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_probes_http_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_Header); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_ResponseTimestamp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_FailureCapture); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_Shadow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_Retry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_Pagination); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_RedirectChain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_BodyMetric); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[0].OneofWrappers = []any{
+		(*ProbeConf_Protocol)(nil),
+		(*ProbeConf_Scheme_)(nil),
+	}
+	file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_msgTypes[3].OneofWrappers = []any{
+		(*ProbeConf_ResponseTimestamp_Header)(nil),
+		(*ProbeConf_ResponseTimestamp_JqFilter)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_probes_http_proto_config_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   4,
+			NumEnums:      4,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,157 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+type proxyIndexKey struct{}
+
+// proxyFailover implements failing over between an ordered list of proxies.
+// Proxy to use for a request is carried in the request's context, so that
+// the same transport (and its connection pool) can be used for all the
+// proxies.
+type proxyFailover struct {
+	proxies []*url.URL
+}
+
+func newProxyFailover(proxyURLs []string) (*proxyFailover, error) {
+	if len(proxyURLs) == 0 {
+		return nil, nil
+	}
+
+	pf := &proxyFailover{}
+	for _, s := range proxyURLs {
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing proxy URL (%s): %v", s, err)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL (%s): missing host", s)
+		}
+		pf.proxies = append(pf.proxies, u)
+	}
+	return pf, nil
+}
+
+// proxy is used as http.Transport's Proxy function.
+func (pf *proxyFailover) proxy(req *http.Request) (*url.URL, error) {
+	i, _ := req.Context().Value(proxyIndexKey{}).(int)
+	return pf.proxies[i], nil
+}
+
+// onProxyConnectResponse is used as http.Transport's OnProxyConnectResponse
+// hook. HTTP transport wraps errors in connecting to the proxy in a
+// "proxyconnect" OpError, but not the proxy rejecting the CONNECT request
+// (e.g. with 403 or 407); we wrap those the same way so that they are
+// treated as proxy errors too.
+func onProxyConnectResponse(_ context.Context, proxyURL *url.URL, _ *http.Request, resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	return &net.OpError{
+		Op:  "proxyconnect",
+		Net: "tcp",
+		Err: fmt.Errorf("proxy %s rejected CONNECT: %s", proxyURL.Host, resp.Status),
+	}
+}
+
+// isProxyError returns true if the error happened at the proxy layer, i.e.
+// in connecting to the proxy or in the proxy rejecting the CONNECT request.
+func isProxyError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "proxyconnect"
+}
+
+// proxyAttempts records which proxies were tried for a request.
+type proxyAttempts struct {
+	failed []int
+	used   int // -1 if all proxies failed.
+}
+
+// doRequest sends the request, failing over between the proxies if they
+// are configured. Proxies are tried in order, moving on to the next proxy
+// only if request fails at the proxy layer. It returns the request that was
+// sent last, so that the follow-up requests can go through the same proxy.
+func (p *Probe) doRequest(client *http.Client, req, origReq *http.Request) (*http.Request, *http.Response, *proxyAttempts, error) {
+	if p.proxyFailover == nil {
+		resp, err := client.Do(req)
+		return req, resp, nil, err
+	}
+
+	pa := &proxyAttempts{used: -1}
+	var resp *http.Response
+	var err error
+
+	ctx := req.Context()
+	for i := range p.proxyFailover.proxies {
+		if i > 0 {
+			// Request body is consumed by the previous attempt.
+			req = p.prepareRequest(origReq)
+		}
+		req = req.WithContext(context.WithValue(ctx, proxyIndexKey{}, i))
+		resp, err = client.Do(req)
+		if err != nil && isProxyError(err) {
+			pa.failed = append(pa.failed, i)
+			continue
+		}
+		pa.used = i
+		break
+	}
+	return req, resp, pa, err
+}
+
+// proxyResult holds the per-proxy metrics.
+type proxyResult struct {
+	requests, failures *metrics.Map[int64]
+	allFailed          int64
+}
+
+func (pf *proxyFailover) newResult() *proxyResult {
+	pr := &proxyResult{
+		requests: metrics.NewMap("proxy"),
+		failures: metrics.NewMap("proxy"),
+	}
+	for _, u := range pf.proxies {
+		pr.requests.IncKeyBy(u.Host, 0)
+		pr.failures.IncKeyBy(u.Host, 0)
+	}
+	return pr
+}
+
+func (pr *proxyResult) record(pf *proxyFailover, pa *proxyAttempts) {
+	for _, i := range pa.failed {
+		pr.failures.IncKey(pf.proxies[i].Host)
+	}
+	if pa.used == -1 {
+		pr.allFailed++
+		return
+	}
+	pr.requests.IncKey(pf.proxies[pa.used].Host)
+}
+
+func (pr *proxyResult) addMetrics(em *metrics.EventMetrics) {
+	em.AddMetric("proxy_requests", pr.requests.Clone()).
+		AddMetric("proxy_failures", pr.failures.Clone()).
+		AddMetric("all_proxies_failed", metrics.NewInt(pr.allFailed))
+}
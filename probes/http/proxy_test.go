@@ -0,0 +1,266 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewProxyFailover(t *testing.T) {
+	pf, err := newProxyFailover(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pf)
+
+	for _, urls := range [][]string{
+		{"http://proxy-a:3128", "://bad"},
+		{"proxy-a"},
+	} {
+		_, err := newProxyFailover(urls)
+		assert.Error(t, err, "proxy URLs: %v", urls)
+	}
+
+	pf, err = newProxyFailover([]string{"http://proxy-a:3128", "http://proxy-b:3128"})
+	require.NoError(t, err)
+	req := httptest.NewRequest("GET", "http://target/", nil)
+	u, _ := pf.proxy(req)
+	assert.Equal(t, "proxy-a:3128", u.Host)
+	u, _ = pf.proxy(req.WithContext(context.WithValue(req.Context(), proxyIndexKey{}, 1)))
+	assert.Equal(t, "proxy-b:3128", u.Host)
+}
+
+func TestProbeInitProxyURLs(t *testing.T) {
+	opts := &options.Options{
+		Targets:  targets.StaticTargets("test.com"),
+		Interval: 2 * time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			ProxyUrl:  proto.String("http://proxy-a:3128"),
+			ProxyUrls: []string{"http://proxy-b:3128"},
+		},
+	}
+	assert.Error(t, (&Probe{}).Init("http_test", opts))
+}
+
+// closedPortURL returns a proxy URL with nothing listening on it.
+func closedPortURL(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	return "http://" + ln.Addr().String()
+}
+
+func TestProbeWithProxyFailover(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	// A simple forward proxy for plain HTTP requests.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, err := http.Get(r.URL.String())
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		w.WriteHeader(resp.StatusCode)
+		io.Copy(w, resp.Body)
+	}))
+	defer proxy.Close()
+	goodProxy, _ := url.Parse(proxy.URL)
+
+	badProxy1, badProxy2 := closedPortURL(t), closedPortURL(t)
+	badProxy1Host, badProxy2Host := badProxy1[len("http://"):], badProxy2[len("http://"):]
+
+	tests := []struct {
+		name          string
+		proxies       []string
+		wantSuccess   int64
+		wantRequests  map[string]int64
+		wantFailures  map[string]int64
+		wantAllFailed int64
+	}{
+		{
+			name:         "first_proxy_works",
+			proxies:      []string{proxy.URL, badProxy1},
+			wantSuccess:  1,
+			wantRequests: map[string]int64{goodProxy.Host: 1, badProxy1Host: 0},
+			wantFailures: map[string]int64{goodProxy.Host: 0, badProxy1Host: 0},
+		},
+		{
+			name:         "failover",
+			proxies:      []string{badProxy1, proxy.URL},
+			wantSuccess:  1,
+			wantRequests: map[string]int64{badProxy1Host: 0, goodProxy.Host: 1},
+			wantFailures: map[string]int64{badProxy1Host: 1, goodProxy.Host: 0},
+		},
+		{
+			name:          "all_failed",
+			proxies:       []string{badProxy1, badProxy2},
+			wantRequests:  map[string]int64{badProxy1Host: 0, badProxy2Host: 0},
+			wantFailures:  map[string]int64{badProxy1Host: 1, badProxy2Host: 1},
+			wantAllFailed: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:      proto.Int32(int32(port)),
+					ProxyUrls: test.proxies,
+				},
+				ExportFailureReasons: true,
+				LogMetrics:           func(_ *metrics.EventMetrics) {},
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			for proxy, want := range test.wantRequests {
+				assert.Equal(t, want, result.proxy.requests.GetKey(proxy), "proxy_requests, proxy: %s", proxy)
+			}
+			for proxy, want := range test.wantFailures {
+				assert.Equal(t, want, result.proxy.failures.GetKey(proxy), "proxy_failures, proxy: %s", proxy)
+			}
+			assert.Equal(t, test.wantAllFailed, result.proxy.allFailed)
+			assert.Equal(t, test.wantAllFailed, result.failures.GetKey(options.FailureProxy))
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			for _, name := range []string{"proxy_requests", "proxy_failures", "all_proxies_failed"} {
+				assert.NotNil(t, em.Metric(name), "metric: %s", name)
+			}
+		})
+	}
+}
+
+// connectProxy returns a proxy that tunnels the CONNECT requests, or rejects
+// them with the given status code if it's not 0.
+func connectProxy(t *testing.T, rejectCode int) *httptest.Server {
+	t.Helper()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if rejectCode != 0 {
+			w.WriteHeader(rejectCode)
+			return
+		}
+		dst, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer dst.Close()
+		w.WriteHeader(http.StatusOK)
+		src, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer src.Close()
+		go io.Copy(dst, src)
+		io.Copy(src, dst)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestProbeWithRejectingProxy(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	goodProxy := connectProxy(t, 0)
+	goodProxyURL, _ := url.Parse(goodProxy.URL)
+
+	for _, code := range []int{http.StatusProxyAuthRequired, http.StatusForbidden} {
+		t.Run(strconv.Itoa(code), func(t *testing.T) {
+			badProxy := connectProxy(t, code)
+			badProxyURL, _ := url.Parse(badProxy.URL)
+
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					SchemeType:            &configpb.ProbeConf_Scheme_{Scheme: configpb.ProbeConf_HTTPS},
+					Port:                  proto.Int32(int32(port)),
+					DisableCertValidation: proto.Bool(true),
+					ProxyUrls:             []string{badProxy.URL, goodProxy.URL},
+				},
+				ExportFailureReasons: true,
+				LogMetrics:           func(_ *metrics.EventMetrics) {},
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.success)
+			assert.Equal(t, int64(1), result.proxy.failures.GetKey(badProxyURL.Host))
+			assert.Equal(t, int64(0), result.proxy.requests.GetKey(badProxyURL.Host))
+			assert.Equal(t, int64(1), result.proxy.requests.GetKey(goodProxyURL.Host))
+		})
+	}
+
+	// Without failover, CONNECT rejection is classified as a proxy failure.
+	badProxy := connectProxy(t, http.StatusProxyAuthRequired)
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	proxyURL, _ := url.Parse(badProxy.URL)
+	client := &http.Client{Transport: &http.Transport{
+		Proxy:                  http.ProxyURL(proxyURL),
+		OnProxyConnectResponse: onProxyConnectResponse,
+	}}
+	_, err := client.Do(req)
+	require.Error(t, err)
+	assert.True(t, isProxyError(err), "error: %v", err)
+	assert.Equal(t, options.FailureProxy, options.ClassifyError(err))
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Range validation failure reasons.
+const (
+	rangeNotPartial          = "not_partial"
+	rangeInvalidContentRange = "invalid_content_range"
+	rangeMismatch            = "range_mismatch"
+	rangeLengthMismatch      = "length_mismatch"
+)
+
+// rangeChecker sends the configured byte range in the requests and
+// validates the partial content responses.
+type rangeChecker struct {
+	// Requested range. start is -1 for suffix ranges ("-<suffix>"), and end
+	// is -1 for open-ended ranges ("<start>-").
+	start, end int64
+	suffix     int64
+
+	header string // Range header value.
+}
+
+func newRangeChecker(byteRange string) (*rangeChecker, error) {
+	if byteRange == "" {
+		return nil, nil
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(byteRange), "-")
+	if !ok || (first == "" && last == "") {
+		return nil, fmt.Errorf("invalid byte_range: %s, should be <start>-<end>, <start>- or -<suffix length>", byteRange)
+	}
+
+	rc := &rangeChecker{start: -1, end: -1}
+	parse := func(s string) (int64, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid byte_range: %s, bad offset: %s", byteRange, s)
+		}
+		return n, nil
+	}
+
+	var err error
+	if first == "" {
+		if rc.suffix, err = parse(last); err != nil {
+			return nil, err
+		}
+		if rc.suffix == 0 {
+			return nil, fmt.Errorf("invalid byte_range: %s, suffix length should be positive", byteRange)
+		}
+	} else {
+		if rc.start, err = parse(first); err != nil {
+			return nil, err
+		}
+		if last != "" {
+			if rc.end, err = parse(last); err != nil {
+				return nil, err
+			}
+			if rc.end < rc.start {
+				return nil, fmt.Errorf("invalid byte_range: %s, end is before start", byteRange)
+			}
+		}
+	}
+
+	rc.header = "bytes=" + first + "-" + last
+	return rc, nil
+}
+
+// parseContentRange parses the Content-Range header value:
+// "bytes <first>-<last>/<complete length or *>". Complete length is -1 if
+// it's unknown.
+func parseContentRange(s string) (first, last, total int64, err error) {
+	errInvalid := fmt.Errorf("invalid Content-Range: %q", s)
+
+	spec, ok := strings.CutPrefix(s, "bytes ")
+	if !ok {
+		return 0, 0, 0, errInvalid
+	}
+	rng, size, ok := strings.Cut(spec, "/")
+	if !ok {
+		return 0, 0, 0, errInvalid
+	}
+	firstStr, lastStr, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, 0, 0, errInvalid
+	}
+
+	if first, err = strconv.ParseInt(firstStr, 10, 64); err != nil {
+		return 0, 0, 0, errInvalid
+	}
+	if last, err = strconv.ParseInt(lastStr, 10, 64); err != nil || last < first {
+		return 0, 0, 0, errInvalid
+	}
+	total = -1
+	if size != "*" {
+		if total, err = strconv.ParseInt(size, 10, 64); err != nil || total <= last {
+			return 0, 0, 0, errInvalid
+		}
+	}
+	return first, last, total, nil
+}
+
+// expectedRange returns the range that server should return for the
+// requested range, given the complete length of the content. If complete
+// length is unknown (-1), unknown bounds are returned as -1.
+func (rc *rangeChecker) expectedRange(total int64) (first, last int64) {
+	if rc.start == -1 {
+		if total == -1 {
+			return -1, -1
+		}
+		return max(0, total-rc.suffix), total - 1
+	}
+
+	last = rc.end
+	if total != -1 && (last == -1 || last > total-1) {
+		last = total - 1
+	}
+	return rc.start, last
+}
+
+// validate validates the partial content response. It returns the number
+// of bytes in the returned range, or the failure reason along with the
+// error.
+func (rc *rangeChecker) validate(resp *http.Response, body []byte) (int64, string, error) {
+	if resp.StatusCode != http.StatusPartialContent {
+		return 0, rangeNotPartial, fmt.Errorf("expected %d (Partial Content) response for range %s, got: %d", http.StatusPartialContent, rc.header, resp.StatusCode)
+	}
+
+	contentRange := resp.Header.Get("Content-Range")
+	first, last, total, err := parseContentRange(contentRange)
+	if err != nil {
+		return 0, rangeInvalidContentRange, err
+	}
+
+	wantFirst, wantLast := rc.expectedRange(total)
+	if (wantFirst != -1 && first != wantFirst) || (wantLast != -1 && last != wantLast) {
+		return 0, rangeMismatch, fmt.Errorf("requested range: %s, got: %s", rc.header, contentRange)
+	}
+
+	if n := last - first + 1; int64(len(body)) != n {
+		return 0, rangeLengthMismatch, fmt.Errorf("content length mismatch for range %s, expected: %d, got: %d", contentRange, n, len(body))
+	}
+	return last - first + 1, "", nil
+}
+
+type rangeResult struct {
+	failures   *metrics.Map[int64]
+	rangeBytes int64
+}
+
+func newRangeResult() *rangeResult {
+	rr := &rangeResult{failures: metrics.NewMap("reason")}
+	for _, reason := range []string{rangeNotPartial, rangeInvalidContentRange, rangeMismatch, rangeLengthMismatch} {
+		rr.failures.IncKeyBy(reason, 0)
+	}
+	return rr
+}
+
+func (rr *rangeResult) addMetrics(em *metrics.EventMetrics) {
+	em.AddMetric("range_failures", rr.failures.Clone()).
+		AddMetric("resp_range_bytes", metrics.NewInt(rr.rangeBytes))
+}
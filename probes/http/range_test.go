@@ -0,0 +1,185 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewRangeChecker(t *testing.T) {
+	tests := []struct {
+		byteRange  string
+		wantHeader string
+		wantErr    bool
+	}{
+		{byteRange: "", wantHeader: ""},
+		{byteRange: "0-1023", wantHeader: "bytes=0-1023"},
+		{byteRange: " 1024-", wantHeader: "bytes=1024-"},
+		{byteRange: "-500", wantHeader: "bytes=-500"},
+		{byteRange: "-", wantErr: true},
+		{byteRange: "100", wantErr: true},
+		{byteRange: "100-10", wantErr: true},
+		{byteRange: "a-10", wantErr: true},
+		{byteRange: "-0", wantErr: true},
+		{byteRange: "0-1,5-10", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.byteRange, func(t *testing.T) {
+			rc, err := newRangeChecker(test.byteRange)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if test.wantHeader == "" {
+				assert.Nil(t, rc)
+				return
+			}
+			assert.Equal(t, test.wantHeader, rc.header)
+		})
+	}
+}
+
+func TestRangeValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		byteRange    string
+		code         int
+		contentRange string
+		bodyLen      int
+		wantReason   string
+	}{
+		{name: "ok", byteRange: "0-9", code: 206, contentRange: "bytes 0-9/100", bodyLen: 10},
+		{name: "ok_unknown_total", byteRange: "0-9", code: 206, contentRange: "bytes 0-9/*", bodyLen: 10},
+		{name: "ok_truncated", byteRange: "90-199", code: 206, contentRange: "bytes 90-99/100", bodyLen: 10},
+		{name: "ok_open_ended", byteRange: "90-", code: 206, contentRange: "bytes 90-99/100", bodyLen: 10},
+		{name: "ok_suffix", byteRange: "-10", code: 206, contentRange: "bytes 90-99/100", bodyLen: 10},
+		{name: "ok_suffix_larger", byteRange: "-500", code: 206, contentRange: "bytes 0-99/100", bodyLen: 100},
+		{name: "full_content", byteRange: "0-9", code: 200, bodyLen: 100, wantReason: rangeNotPartial},
+		{name: "missing_content_range", byteRange: "0-9", code: 206, bodyLen: 10, wantReason: rangeInvalidContentRange},
+		{name: "bad_content_range", byteRange: "0-9", code: 206, contentRange: "bytes 9-0/100", bodyLen: 10, wantReason: rangeInvalidContentRange},
+		{name: "wrong_range", byteRange: "0-9", code: 206, contentRange: "bytes 0-19/100", bodyLen: 20, wantReason: rangeMismatch},
+		{name: "wrong_suffix", byteRange: "-10", code: 206, contentRange: "bytes 80-89/100", bodyLen: 10, wantReason: rangeMismatch},
+		{name: "short_body", byteRange: "0-9", code: 206, contentRange: "bytes 0-9/100", bodyLen: 5, wantReason: rangeLengthMismatch},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rc, err := newRangeChecker(test.byteRange)
+			require.NoError(t, err)
+
+			resp := &http.Response{StatusCode: test.code, Header: http.Header{}}
+			if test.contentRange != "" {
+				resp.Header.Set("Content-Range", test.contentRange)
+			}
+			n, reason, err := rc.validate(resp, make([]byte, test.bodyLen))
+			assert.Equal(t, test.wantReason, reason)
+			if test.wantReason != "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, int64(test.bodyLen), n)
+		})
+	}
+}
+
+func TestProbeWithByteRange(t *testing.T) {
+	data := bytes.Repeat([]byte("cloudprober "), 100)
+	var gotRange, gotAcceptEncoding string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRange, gotAcceptEncoding = r.Header.Get("Range"), r.Header.Get("Accept-Encoding")
+		if r.URL.Path == "/norange" {
+			w.Write(data)
+			return
+		}
+		http.ServeContent(w, r, "data.txt", time.Time{}, bytes.NewReader(data))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	for _, path := range []string{"/range", "/norange"} {
+		t.Run(path, func(t *testing.T) {
+			opts := &options.Options{
+				Targets:  targets.StaticTargets(u.Hostname()),
+				Interval: 2 * time.Second,
+				Timeout:  time.Second,
+				ProbeConf: &configpb.ProbeConf{
+					Port:        proto.Int32(int32(port)),
+					RelativeUrl: proto.String(path),
+					ByteRange:   proto.String("-100"),
+				},
+				LogMetrics: func(_ *metrics.EventMetrics) {},
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("http_test", opts))
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, "bytes=-100", gotRange)
+			assert.Equal(t, "identity", gotAcceptEncoding)
+			assert.Equal(t, int64(1), result.total)
+			if path == "/norange" {
+				assert.Equal(t, int64(0), result.success)
+				assert.Equal(t, int64(1), result.byteRange.failures.GetKey(rangeNotPartial))
+				return
+			}
+			assert.Equal(t, int64(1), result.success)
+			assert.Equal(t, int64(100), result.byteRange.rangeBytes)
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			em := <-dataChan
+			for _, name := range []string{"range_failures", "resp_range_bytes"} {
+				assert.NotNil(t, em.Metric(name), "metric: %s", name)
+			}
+		})
+	}
+}
+
+func TestByteRangeWithPagination(t *testing.T) {
+	opts := &options.Options{
+		Targets:  targets.StaticTargets("test.com"),
+		Interval: 2 * time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			ByteRange:  proto.String("0-99"),
+			Pagination: &configpb.ProbeConf_Pagination{},
+		},
+	}
+	p := &Probe{}
+	assert.Error(t, p.Init("http_test", opts))
+}
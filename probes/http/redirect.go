@@ -0,0 +1,158 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+// Redirect chain failure reasons.
+const (
+	redirectFinalURLMismatch = "final_url_mismatch"
+	redirectHopCountMismatch = "hop_count_mismatch"
+	redirectHopMismatch      = "hop_mismatch"
+	redirectLoop             = "loop"
+	redirectMaxHops          = "max_hops"
+)
+
+var (
+	errRedirectLoop    = errors.New("redirect loop detected")
+	errRedirectMaxHops = errors.New("too many redirects")
+)
+
+type redirectChecker struct {
+	finalURLRe *regexp.Regexp
+	hopURLRe   []*regexp.Regexp
+	maxHops    int
+}
+
+func newRedirectChecker(c *configpb.ProbeConf_RedirectChain) (*redirectChecker, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rc := &redirectChecker{maxHops: int(c.GetMaxHops())}
+
+	if c.GetFinalUrlRegex() != "" {
+		re, err := regexp.Compile(c.GetFinalUrlRegex())
+		if err != nil {
+			return nil, fmt.Errorf("invalid final_url_regex (%s): %v", c.GetFinalUrlRegex(), err)
+		}
+		rc.finalURLRe = re
+	}
+
+	for _, s := range c.GetHopUrlRegex() {
+		re, err := regexp.Compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hop_url_regex (%s): %v", s, err)
+		}
+		rc.hopURLRe = append(rc.hopURLRe, re)
+	}
+
+	if len(rc.hopURLRe) > rc.maxHops {
+		return nil, fmt.Errorf("number of hop_url_regex (%d) is more than max_hops (%d)", len(rc.hopURLRe), rc.maxHops)
+	}
+
+	return rc, nil
+}
+
+// checkRedirect is used as http.Client's CheckRedirect function. It stops
+// following redirects on loops, and if the chain becomes too long.
+func (rc *redirectChecker) checkRedirect(req *http.Request, via []*http.Request) error {
+	for _, r := range via {
+		if r.URL.String() == req.URL.String() {
+			return errRedirectLoop
+		}
+	}
+	if len(via) > rc.maxHops {
+		return errRedirectMaxHops
+	}
+	return nil
+}
+
+// failureReason returns the redirect failure reason for the error returned by
+// the http.Client, if any.
+func (rc *redirectChecker) failureReason(err error) string {
+	switch {
+	case errors.Is(err, errRedirectLoop):
+		return redirectLoop
+	case errors.Is(err, errRedirectMaxHops):
+		return redirectMaxHops
+	}
+	return ""
+}
+
+// redirectChain returns URLs of the requests that led to the given response,
+// starting with the original request.
+func redirectChain(resp *http.Response) []string {
+	var chain []string
+	for req := resp.Request; req != nil; {
+		chain = append([]string{req.URL.String()}, chain...)
+		if req.Response == nil {
+			break
+		}
+		req = req.Response.Request
+	}
+	return chain
+}
+
+// validate validates the redirect chain and returns the failure reason, or
+// an empty string if chain is valid.
+func (rc *redirectChecker) validate(chain []string) string {
+	hops := chain[1:]
+
+	if rc.hopURLRe != nil {
+		if len(hops) != len(rc.hopURLRe) {
+			return redirectHopCountMismatch
+		}
+		for i, re := range rc.hopURLRe {
+			if !re.MatchString(hops[i]) {
+				return redirectHopMismatch
+			}
+		}
+	}
+
+	if rc.finalURLRe != nil && !rc.finalURLRe.MatchString(chain[len(chain)-1]) {
+		return redirectFinalURLMismatch
+	}
+
+	return ""
+}
+
+// redirectResult keeps track of redirect chain failures, and the last seen
+// redirect chain.
+type redirectResult struct {
+	failures     *metrics.Map[int64]
+	lastHops     int
+	lastFinalURL string
+}
+
+func newRedirectResult() *redirectResult {
+	rr := &redirectResult{failures: metrics.NewMap("reason")}
+	for _, reason := range []string{redirectFinalURLMismatch, redirectHopCountMismatch, redirectHopMismatch, redirectLoop, redirectMaxHops} {
+		rr.failures.IncKeyBy(reason, 0)
+	}
+	return rr
+}
+
+func (rr *redirectResult) record(chain []string) {
+	rr.lastHops, rr.lastFinalURL = len(chain)-1, chain[len(chain)-1]
+}
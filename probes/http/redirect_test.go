@@ -0,0 +1,186 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewRedirectChecker(t *testing.T) {
+	rc, err := newRedirectChecker(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rc)
+
+	for _, c := range []*configpb.ProbeConf_RedirectChain{
+		{FinalUrlRegex: proto.String("(")},
+		{HopUrlRegex: []string{"a", "("}},
+		{HopUrlRegex: []string{"a", "b"}, MaxHops: proto.Int32(1)},
+	} {
+		_, err := newRedirectChecker(c)
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+func TestRedirectCheckerValidate(t *testing.T) {
+	rc, err := newRedirectChecker(&configpb.ProbeConf_RedirectChain{
+		FinalUrlRegex: proto.String("^https://x/$"),
+		HopUrlRegex:   []string{"^https://x$", "^https://x/$"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		chain []string
+		want  string
+	}{
+		{chain: []string{"http://x", "https://x", "https://x/"}, want: ""},
+		{chain: []string{"http://x", "https://x/"}, want: redirectHopCountMismatch},
+		{chain: []string{"http://x", "https://y", "https://x/"}, want: redirectHopMismatch},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, rc.validate(test.chain), "chain: %v", test.chain)
+	}
+
+	rc, err = newRedirectChecker(&configpb.ProbeConf_RedirectChain{
+		FinalUrlRegex: proto.String("^https://x/$"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", rc.validate([]string{"https://x/"}))
+	assert.Equal(t, redirectFinalURLMismatch, rc.validate([]string{"http://x", "https://y/"}))
+}
+
+func TestProbeRedirectChain(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/start", http.RedirectHandler("/hop1", http.StatusMovedPermanently))
+	mux.Handle("/hop1", http.RedirectHandler("/final", http.StatusFound))
+	mux.HandleFunc("/final", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) })
+	mux.Handle("/loop1", http.RedirectHandler("/loop2", http.StatusFound))
+	mux.Handle("/loop2", http.RedirectHandler("/loop1", http.StatusFound))
+	for i := 0; i < 5; i++ {
+		mux.Handle("/long"+strconv.Itoa(i), http.RedirectHandler("/long"+strconv.Itoa(i+1), http.StatusFound))
+	}
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	tests := []struct {
+		name        string
+		relURL      string
+		c           *configpb.ProbeConf_RedirectChain
+		wantSuccess int64
+		wantFailure string
+		wantHops    string
+	}{
+		{
+			name:   "success",
+			relURL: "/start",
+			c: &configpb.ProbeConf_RedirectChain{
+				FinalUrlRegex: proto.String("/final$"),
+				HopUrlRegex:   []string{"/hop1$", "/final$"},
+			},
+			wantSuccess: 1,
+			wantHops:    "2",
+		},
+		{
+			name:        "final-url-mismatch",
+			relURL:      "/start",
+			c:           &configpb.ProbeConf_RedirectChain{FinalUrlRegex: proto.String("/other$")},
+			wantFailure: redirectFinalURLMismatch,
+			wantHops:    "2",
+		},
+		{
+			name:        "loop",
+			relURL:      "/loop1",
+			c:           &configpb.ProbeConf_RedirectChain{},
+			wantFailure: redirectLoop,
+		},
+		{
+			name:        "max-hops",
+			relURL:      "/long0",
+			c:           &configpb.ProbeConf_RedirectChain{MaxHops: proto.Int32(3)},
+			wantFailure: redirectMaxHops,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Probe{}
+			err := p.Init("http_test", &options.Options{
+				Targets:     targets.StaticTargets(u.Hostname()),
+				Interval:    2 * time.Second,
+				Timeout:     time.Second,
+				LatencyUnit: time.Millisecond,
+				ProbeConf: &configpb.ProbeConf{
+					Port:          proto.Int32(int32(port)),
+					RelativeUrl:   proto.String(test.relURL),
+					RedirectChain: test.c,
+				},
+				LogMetrics: func(_ *metrics.EventMetrics) {},
+			})
+			require.NoError(t, err)
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			for _, reason := range result.redirect.failures.Keys() {
+				want := int64(0)
+				if reason == test.wantFailure {
+					want = 1
+				}
+				assert.Equal(t, want, result.redirect.failures.GetKey(reason), "reason: %s", reason)
+			}
+
+			dataChan := make(chan *metrics.EventMetrics, 10)
+			p.exportMetrics(time.Now(), result, target, dataChan)
+			assert.Equal(t, result.redirect.failures.String(), (<-dataChan).Metric("redirect_failures").String())
+			if test.wantHops == "" {
+				assert.Len(t, dataChan, 0)
+				return
+			}
+			em := <-dataChan
+			assert.Equal(t, test.wantHops, em.Label("redirect_hops"))
+			assert.Equal(t, ts.URL+"/final", em.Label("final_url"))
+		})
+	}
+
+	// max_redirects and redirect_chain cannot be used together.
+	p := &Probe{}
+	err := p.Init("http_test", &options.Options{
+		Targets:   targets.StaticTargets(u.Hostname()),
+		Interval:  2 * time.Second,
+		Timeout:   time.Second,
+		ProbeConf: &configpb.ProbeConf{MaxRedirects: proto.Int32(2), RedirectChain: &configpb.ProbeConf_RedirectChain{}},
+	})
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ package http
 
 import (
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"strconv"
@@ -108,7 +109,16 @@ func (p *Probe) resolveFirst(target endpoint.Endpoint) bool {
 func (p *Probe) setHeaders(req *http.Request, host string, port int) {
 	var hostHeader string
 
+	rawSeen := make(map[string]bool)
 	for _, h := range p.c.GetHeaders() {
+		if p.c.GetPreserveHeaderCase() {
+			if http.CanonicalHeaderKey(h.GetName()) == "Host" {
+				hostHeader = h.GetValue()
+				continue
+			}
+			addRawHeader(req.Header, h.GetName(), h.GetValue(), rawSeen)
+			continue
+		}
 		if h.GetName() == "Host" {
 			hostHeader = h.GetValue()
 			continue
@@ -130,6 +140,28 @@ func (p *Probe) setHeaders(req *http.Request, host string, port int) {
 	req.Host = hostHeader
 }
 
+// addRawHeader adds a header value to hdr without canonicalizing the header
+// name. First occurrence of a name (in any case) replaces the existing values
+// under all the case variants of that name, e.g. Content-Type set based on
+// the request body. Later occurrences, even if in a different case, add to
+// them. User-Agent is kept canonical as transport looks for it only under the
+// canonical name.
+func addRawHeader(hdr http.Header, name, value string, seen map[string]bool) {
+	ck := http.CanonicalHeaderKey(name)
+	if ck == "User-Agent" {
+		name = ck
+	}
+	if !seen[ck] {
+		seen[ck] = true
+		for k := range hdr {
+			if http.CanonicalHeaderKey(k) == ck {
+				delete(hdr, k)
+			}
+		}
+	}
+	hdr[name] = append(hdr[name], value)
+}
+
 func (p *Probe) urlHostAndIPLabel(target endpoint.Endpoint, host string) (string, string, error) {
 	if !p.resolveFirst(target) {
 		return host, "", nil
@@ -178,6 +210,15 @@ func (p *Probe) httpRequestForTarget(target endpoint.Endpoint) *http.Request {
 	if p.c.GetUserAgent() != "" {
 		req.Header.Set("User-Agent", p.c.GetUserAgent())
 	}
+	if p.decompressChecker != nil && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", p.decompressChecker.encoding)
+	}
+	if p.rangeChecker != nil {
+		req.Header.Set("Range", p.rangeChecker.header)
+		if req.Header.Get("Accept-Encoding") == "" {
+			req.Header.Set("Accept-Encoding", "identity")
+		}
+	}
 
 	return req
 }
@@ -208,12 +249,32 @@ func (p *Probe) prepareRequest(req *http.Request) *http.Request {
 	//      share it across multiple requests.
 	//   -- if OAuth token is used, each request gets its own Authorization
 	//      header.
-	if p.oauthTS == nil && p.requestBody.Len() == 0 {
+	//   -- if request body or headers are read from files, they may change
+	//      between requests.
+	//   -- if request IDs are configured, each request gets its own ID.
+	body := p.requestBody
+	var fileHeaders http.Header
+	if p.reqFiles != nil {
+		var fileBody *httpreq.RequestBody
+		if fileBody, fileHeaders = p.reqFiles.get(); fileBody != nil {
+			body = fileBody
+		}
+	}
+
+	if p.oauthTS == nil && p.reqFiles == nil && p.requestIDs == nil && body.Len() == 0 {
 		return req
 	}
 
 	req = req.Clone(req.Context())
 
+	for k, v := range fileHeaders {
+		if k == "Host" {
+			req.Host = v[0]
+			continue
+		}
+		req.Header[k] = append([]string(nil), v...)
+	}
+
 	if p.oauthTS != nil {
 		tok, err := getToken(p.oauthTS, p.l)
 		// Note: We don't terminate the request if there is an error in getting
@@ -226,7 +287,20 @@ func (p *Probe) prepareRequest(req *http.Request) *http.Request {
 		req.Header.Set("Authorization", "Bearer "+tok)
 	}
 
-	req.Body = p.requestBody.Reader()
+	if p.requestIDs != nil {
+		_, headers := p.requestIDs.Headers()
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+
+	req.Body = body.Reader()
+	if body != p.requestBody {
+		req.ContentLength = body.Len()
+		req.GetBody = func() (io.ReadCloser, error) {
+			return body.Reader(), nil
+		}
+	}
 
 	return req
 }
@@ -15,18 +15,23 @@
 package http
 
 import (
+	"bufio"
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudprober/cloudprober/internal/httpreq"
+	"github.com/cloudprober/cloudprober/internal/requestid"
+	requestidpb "github.com/cloudprober/cloudprober/internal/requestid/proto"
 	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
 	"github.com/cloudprober/cloudprober/probes/options"
 	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 	"google.golang.org/protobuf/proto"
 )
@@ -414,6 +419,26 @@ func TestPrepareRequest(t *testing.T) {
 	}
 }
 
+func TestPrepareRequestWithRequestID(t *testing.T) {
+	p := &Probe{
+		requestBody: httpreq.NewRequestBody(),
+		requestIDs:  requestid.New(&requestidpb.RequestIDConfig{}),
+	}
+
+	inReq, _ := httpreq.NewRequest("GET", "http://cloudprober.org", p.requestBody)
+	req1, req2 := p.prepareRequest(inReq), p.prepareRequest(inReq)
+
+	assert.Empty(t, inReq.Header.Get("X-Request-ID"), "original request modified")
+	for _, req := range []*http.Request{req1, req2} {
+		assert.NotEmpty(t, req.Header.Get("X-Request-ID"))
+		assert.NotEmpty(t, req.Header.Get("traceparent"))
+		assert.Equal(t, req.Header.Get("X-Request-ID"), p.requestID(req))
+	}
+	assert.NotEqual(t, p.requestID(req1), p.requestID(req2))
+
+	assert.Empty(t, (&Probe{}).requestID(req1), "request IDs not configured")
+}
+
 func TestRequestHasConfiguredHeaders(t *testing.T) {
 	p := &Probe{}
 
@@ -454,6 +479,90 @@ func TestRequestHasConfiguredHeaders(t *testing.T) {
 	assert.Contains(t, val, testHeadersValue)
 }
 
+func TestPreserveHeaderCase(t *testing.T) {
+	// Server that captures the raw request header block.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	rawHeaders := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var lines []string
+		r := bufio.NewReader(conn)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil || line == "\r\n" {
+				break
+			}
+			lines = append(lines, strings.TrimRight(line, "\r\n"))
+		}
+		rawHeaders <- lines
+		conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Length: 0\r\n\r\n"))
+	}()
+
+	hdr := func(name, value string) *configpb.ProbeConf_Header {
+		return &configpb.ProbeConf_Header{Name: proto.String(name), Value: proto.String(value)}
+	}
+	p := &Probe{}
+	opts := &options.Options{
+		Targets:  targets.StaticTargets("127.0.0.1"),
+		Interval: time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			Port:   proto.Int32(int32(ln.Addr().(*net.TCPAddr).Port)),
+			Method: configpb.ProbeConf_POST.Enum(),
+			Body:   []string{`{"k": "v"}`},
+			Headers: []*configpb.ProbeConf_Header{
+				hdr("x-api-key", "k1"),
+				hdr("X-Multi", "b"),
+				hdr("X-Multi", "a"),
+				hdr("X-Mixed", "a"),
+				hdr("x-mixed", "b"),
+				hdr("content-type", "text/plain"),
+				hdr("user-agent", "my-client/1.0"),
+				hdr("host", "example.com"),
+			},
+			PreserveHeaderCase: proto.Bool(true),
+		},
+	}
+	require.NoError(t, p.Init("http_test", opts))
+
+	req := p.httpRequestForTarget(endpoint.Endpoint{Name: "127.0.0.1"})
+	assert.Equal(t, "example.com", req.Host)
+	assert.Equal(t, []string{"k1"}, req.Header["x-api-key"])
+	assert.Equal(t, []string{"b", "a"}, req.Header["X-Multi"])
+	assert.Equal(t, []string{"a"}, req.Header["X-Mixed"])
+	assert.Equal(t, []string{"b"}, req.Header["x-mixed"])
+	assert.Equal(t, []string{"text/plain"}, req.Header["content-type"])
+	assert.Empty(t, req.Header["Content-Type"])
+	assert.Equal(t, "my-client/1.0", req.Header.Get("User-Agent"))
+
+	resp, err := p.baseTransport.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	lines := <-rawHeaders
+	assert.Contains(t, lines, "Host: example.com")
+	assert.Contains(t, lines, "User-Agent: my-client/1.0")
+	assert.Contains(t, lines, "x-api-key: k1")
+	assert.Contains(t, lines, "content-type: text/plain")
+	assert.Contains(t, lines, "X-Mixed: a")
+	assert.Contains(t, lines, "x-mixed: b")
+	assert.NotContains(t, lines, "Content-Type: application/json")
+
+	var multi []string
+	for _, line := range lines {
+		if v, ok := strings.CutPrefix(line, "X-Multi: "); ok {
+			multi = append(multi, v)
+		}
+	}
+	assert.Equal(t, []string{"b", "a"}, multi)
+}
+
 func TestResolveFirst(t *testing.T) {
 	tests := []struct {
 		name   string
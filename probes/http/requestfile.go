@@ -0,0 +1,143 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/file"
+	"github.com/cloudprober/cloudprober/internal/httpreq"
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// requestFiles provides the request body and headers read from the files
+// (body_file and headers_file). Files are re-read if they are modified. If a
+// file cannot be read after the initial load, last read content is used.
+type requestFiles struct {
+	bodyFile      string
+	headersFile   string
+	checkInterval time.Duration
+	l             *logger.Logger
+
+	mu             sync.Mutex
+	lastCheck      time.Time
+	body           *httpreq.RequestBody
+	bodyModTime    time.Time
+	headers        http.Header
+	headersModTime time.Time
+}
+
+func newRequestFiles(bodyFile, headersFile string, checkInterval time.Duration, l *logger.Logger) (*requestFiles, error) {
+	rf := &requestFiles{
+		bodyFile:      bodyFile,
+		headersFile:   headersFile,
+		checkInterval: checkInterval,
+		l:             l,
+	}
+	if err := rf.reload(); err != nil {
+		return nil, err
+	}
+	rf.lastCheck = time.Now()
+	return rf, nil
+}
+
+// parseHeaders parses headers file's content: one "Name: value" header per
+// line, ignoring empty lines and comments.
+func parseHeaders(b []byte) (http.Header, error) {
+	headers := make(http.Header)
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header at line %d: %s, expected format is \"Name: value\"", i+1, line)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// readIfModified reads the given file if its modification time is different
+// from lastModTime. It returns nil data if the file has not been modified.
+func readIfModified(fname string, lastModTime *time.Time) ([]byte, error) {
+	modTime, err := file.ModTime(context.Background(), fname)
+	if err != nil {
+		return nil, err
+	}
+	if !lastModTime.IsZero() && modTime.Equal(*lastModTime) {
+		return nil, nil
+	}
+
+	b, err := file.ReadFile(context.Background(), fname)
+	if err != nil {
+		return nil, err
+	}
+	*lastModTime = modTime
+	return b, nil
+}
+
+func (rf *requestFiles) reload() error {
+	if rf.bodyFile != "" {
+		b, err := readIfModified(rf.bodyFile, &rf.bodyModTime)
+		if err != nil {
+			return fmt.Errorf("error reading body_file (%s): %v", rf.bodyFile, err)
+		}
+		if b != nil {
+			rf.body = httpreq.NewRequestBody(string(b))
+		}
+	}
+
+	if rf.headersFile != "" {
+		lastModTime := rf.headersModTime
+		b, err := readIfModified(rf.headersFile, &rf.headersModTime)
+		if err != nil {
+			return fmt.Errorf("error reading headers_file (%s): %v", rf.headersFile, err)
+		}
+		if b != nil {
+			headers, err := parseHeaders(b)
+			if err != nil {
+				// Try again on the next check.
+				rf.headersModTime = lastModTime
+				return fmt.Errorf("error parsing headers_file (%s): %v", rf.headersFile, err)
+			}
+			rf.headers = headers
+		}
+	}
+
+	return nil
+}
+
+// get returns the current request body and headers, reloading the files if
+// it's time to check them again.
+func (rf *requestFiles) get() (*httpreq.RequestBody, http.Header) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+
+	if time.Since(rf.lastCheck) >= rf.checkInterval {
+		rf.lastCheck = time.Now()
+		if err := rf.reload(); err != nil {
+			rf.l.Warningf("%v, using last read content", err)
+		}
+	}
+
+	return rf.body, rf.headers
+}
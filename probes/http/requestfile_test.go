@@ -0,0 +1,158 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    http.Header
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: "# Auth headers\nAuthorization: Bearer abc\n\nX-Test:  a:b \nX-Test: c\n",
+			want: http.Header{
+				"Authorization": {"Bearer abc"},
+				"X-Test":        {"a:b", "c"},
+			},
+		},
+		{
+			name:    "missing-colon",
+			content: "Authorization Bearer abc",
+			wantErr: true,
+		},
+		{
+			name:    "empty-name",
+			content: ": value",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseHeaders([]byte(test.content))
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, fname, content string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(fname, []byte(content), 0644))
+	require.NoError(t, os.Chtimes(fname, modTime, modTime))
+}
+
+func bodyString(t *testing.T, req *http.Request) string {
+	t.Helper()
+	if req.Body == nil {
+		return ""
+	}
+	b, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestRequestFiles(t *testing.T) {
+	dir := t.TempDir()
+	bodyFile, headersFile := filepath.Join(dir, "body.json"), filepath.Join(dir, "headers.txt")
+	ts := time.Now().Add(-time.Hour)
+
+	writeTestFile(t, bodyFile, `{"user": "a"}`, ts)
+	writeTestFile(t, headersFile, "X-Test: v1\nHost: svc.example.com", ts)
+
+	p := &Probe{}
+	opts := options.DefaultOptions()
+	opts.ProbeConf = &configpb.ProbeConf{
+		Method:      configpb.ProbeConf_POST.Enum(),
+		BodyFile:    proto.String(bodyFile),
+		HeadersFile: proto.String(headersFile),
+	}
+	require.NoError(t, p.Init("test-body-file", opts))
+
+	// Always check the files.
+	p.reqFiles.checkInterval = 0
+
+	req := p.httpRequestForTarget(endpoint.Endpoint{Name: "test.com"})
+	require.NotNil(t, req)
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	got := p.prepareRequest(req)
+	assert.Equal(t, `{"user": "a"}`, bodyString(t, got))
+	assert.Equal(t, "v1", got.Header.Get("X-Test"))
+	assert.Equal(t, "svc.example.com", got.Host)
+
+	// Update files.
+	writeTestFile(t, bodyFile, `{"user": "bob"}`, ts.Add(time.Minute))
+	writeTestFile(t, headersFile, "X-Test: v2", ts.Add(time.Minute))
+
+	got = p.prepareRequest(req)
+	assert.Equal(t, `{"user": "bob"}`, bodyString(t, got))
+	assert.Equal(t, int64(len(`{"user": "bob"}`)), got.ContentLength)
+	assert.Equal(t, "v2", got.Header.Get("X-Test"))
+
+	// Files become unreadable or invalid, last content is used.
+	require.NoError(t, os.Remove(bodyFile))
+	writeTestFile(t, headersFile, "invalid header", ts.Add(2*time.Minute))
+
+	got = p.prepareRequest(req)
+	assert.Equal(t, `{"user": "bob"}`, bodyString(t, got))
+	assert.Equal(t, "v2", got.Header.Get("X-Test"))
+
+	// Original request is not modified.
+	assert.Empty(t, req.Header.Get("X-Test"))
+}
+
+func TestRequestFilesInitErrors(t *testing.T) {
+	dir := t.TempDir()
+	bodyFile := filepath.Join(dir, "body.txt")
+	writeTestFile(t, bodyFile, "a=b", time.Now())
+
+	badHeadersFile := filepath.Join(dir, "headers.txt")
+	writeTestFile(t, badHeadersFile, "no-colon", time.Now())
+
+	for _, c := range []*configpb.ProbeConf{
+		{BodyFile: proto.String(filepath.Join(dir, "missing.txt"))},
+		{HeadersFile: proto.String(filepath.Join(dir, "missing.txt"))},
+		{HeadersFile: proto.String(badHeadersFile)},
+		{BodyFile: proto.String(bodyFile), Body: []string{"c=d"}},
+	} {
+		p := &Probe{}
+		opts := options.DefaultOptions()
+		opts.ProbeConf = c
+		assert.Error(t, p.Init("test-body-file", opts), "config: %v", c)
+	}
+}
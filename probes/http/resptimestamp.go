@@ -0,0 +1,148 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/itchyny/gojq"
+)
+
+var unixTimestampUnits = map[string]time.Duration{
+	"unix":    time.Second,
+	"unix_ms": time.Millisecond,
+	"unix_us": time.Microsecond,
+	"unix_ns": time.Nanosecond,
+}
+
+// respTimestampExtractor extracts the timestamp from the response, either
+// from a header or from the JSON body.
+type respTimestampExtractor struct {
+	header  string
+	jqQuery *gojq.Query
+	format  string
+}
+
+func newRespTimestampExtractor(c *configpb.ProbeConf_ResponseTimestamp) (*respTimestampExtractor, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rte := &respTimestampExtractor{format: c.GetFormat()}
+	switch c.GetSource().(type) {
+	case *configpb.ProbeConf_ResponseTimestamp_Header:
+		if c.GetHeader() == "" {
+			return nil, fmt.Errorf("response_timestamp: header cannot be empty")
+		}
+		rte.header = c.GetHeader()
+	case *configpb.ProbeConf_ResponseTimestamp_JqFilter:
+		q, err := gojq.Parse(c.GetJqFilter())
+		if err != nil {
+			return nil, fmt.Errorf("response_timestamp: error parsing the jq filter (%s): %v", c.GetJqFilter(), err)
+		}
+		rte.jqQuery = q
+	default:
+		return nil, fmt.Errorf("response_timestamp: one of header or jq_filter is required")
+	}
+	return rte, nil
+}
+
+// extract extracts the timestamp from the response.
+func (rte *respTimestampExtractor) extract(resp *http.Response, body []byte) (time.Time, error) {
+	if rte.header != "" {
+		v := resp.Header.Get(rte.header)
+		if v == "" {
+			return time.Time{}, fmt.Errorf("response_timestamp: header %s not found in the response", rte.header)
+		}
+		return rte.parse(v)
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(body, &input); err != nil {
+		return time.Time{}, fmt.Errorf("response_timestamp: response is not a valid JSON: %v", err)
+	}
+	v, ok := rte.jqQuery.Run(input).Next()
+	if !ok || v == nil {
+		return time.Time{}, fmt.Errorf("response_timestamp: jq filter (%s) returned no value", rte.jqQuery.String())
+	}
+	if err, ok := v.(error); ok {
+		return time.Time{}, fmt.Errorf("response_timestamp: error running the jq filter (%s): %v", rte.jqQuery.String(), err)
+	}
+	return rte.parse(v)
+}
+
+// parse parses the timestamp value: a string (header or JSON string), or a
+// number (JSON number).
+func (rte *respTimestampExtractor) parse(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case string:
+		if rte.format == "" {
+			return parseDefaultTimestamp(t)
+		}
+		if unit, ok := unixTimestampUnits[rte.format]; ok {
+			// Parse integers separately to not lose precision.
+			if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+				perSec := int64(time.Second / unit)
+				return time.Unix(i/perSec, (i%perSec)*int64(unit)), nil
+			}
+			f, err := strconv.ParseFloat(t, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("response_timestamp: invalid %s timestamp: %s", rte.format, t)
+			}
+			return unixTime(f, unit), nil
+		}
+		ts, err := time.Parse(rte.format, t)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("response_timestamp: error parsing timestamp: %v", err)
+		}
+		return ts, nil
+	case int:
+		return rte.parse(float64(t))
+	case float64:
+		unit := time.Second
+		if rte.format != "" {
+			var ok bool
+			if unit, ok = unixTimestampUnits[rte.format]; !ok {
+				return time.Time{}, fmt.Errorf("response_timestamp: numeric timestamp (%v) with non-unix format: %s", t, rte.format)
+			}
+		}
+		return unixTime(t, unit), nil
+	}
+	return time.Time{}, fmt.Errorf("response_timestamp: unexpected timestamp value: %v", v)
+}
+
+func unixTime(f float64, unit time.Duration) time.Time {
+	sec, frac := math.Modf(f * unit.Seconds())
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// parseDefaultTimestamp parses the timestamp string as an HTTP date or an RFC
+// 3339 timestamp.
+func parseDefaultTimestamp(s string) (time.Time, error) {
+	if t, err := http.ParseTime(s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("response_timestamp: %s is neither an HTTP date, nor an RFC 3339 timestamp", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewRespTimestampExtractor(t *testing.T) {
+	for _, c := range []*configpb.ProbeConf_ResponseTimestamp{
+		{},
+		{Source: &configpb.ProbeConf_ResponseTimestamp_Header{}},
+		{Source: &configpb.ProbeConf_ResponseTimestamp_JqFilter{JqFilter: ".ts |"}},
+	} {
+		_, err := newRespTimestampExtractor(c)
+		assert.Error(t, err, "config: %v", c)
+	}
+
+	rte, err := newRespTimestampExtractor(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, rte)
+}
+
+func TestRespTimestampExtract(t *testing.T) {
+	wantTime := time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		header  string
+		jqf     string
+		format  string
+		hdrVal  string
+		body    string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "http_date", header: "Date", hdrVal: "Tue, 05 Mar 2024 10:20:30 GMT", want: wantTime},
+		{name: "header_rfc3339", header: "X-Time", hdrVal: "2024-03-05T10:20:30Z", want: wantTime},
+		{name: "header_unix_ms", header: "X-Time", format: "unix_ms", hdrVal: "1709634030123", want: wantTime.Add(123 * time.Millisecond)},
+		{name: "header_unix_float", header: "X-Time", format: "unix", hdrVal: "1709634030.5", want: wantTime.Add(500 * time.Millisecond)},
+		{name: "header_layout", header: "X-Time", format: "2006-01-02 15:04:05", hdrVal: "2024-03-05 10:20:30", want: wantTime},
+		{name: "header_missing", header: "X-Time", wantErr: true},
+		{name: "header_bad", header: "X-Time", hdrVal: "yesterday", wantErr: true},
+		{name: "header_bad_unix", header: "X-Time", format: "unix", hdrVal: "yesterday", wantErr: true},
+		{name: "json_rfc3339", jqf: ".meta.time", body: `{"meta": {"time": "2024-03-05T10:20:30Z"}}`, want: wantTime},
+		{name: "json_number", jqf: ".time", body: `{"time": 1709634030}`, want: wantTime},
+		{name: "json_number_unix_us", jqf: ".time", format: "unix_us", body: `{"time": 1709634030000000}`, want: wantTime},
+		{name: "json_number_layout", jqf: ".time", format: "2006-01-02", body: `{"time": 1709634030}`, wantErr: true},
+		{name: "json_missing", jqf: ".time", body: `{}`, wantErr: true},
+		{name: "json_invalid", jqf: ".time", body: `{`, wantErr: true},
+		{name: "json_bool", jqf: ".time", body: `{"time": true}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &configpb.ProbeConf_ResponseTimestamp{}
+			if test.header != "" {
+				c.Source = &configpb.ProbeConf_ResponseTimestamp_Header{Header: test.header}
+			} else {
+				c.Source = &configpb.ProbeConf_ResponseTimestamp_JqFilter{JqFilter: test.jqf}
+			}
+			if test.format != "" {
+				c.Format = proto.String(test.format)
+			}
+			rte, err := newRespTimestampExtractor(c)
+			require.NoError(t, err)
+
+			resp := &http.Response{Header: http.Header{}}
+			if test.hdrVal != "" {
+				resp.Header.Set(test.header, test.hdrVal)
+			}
+			got, err := rte.extract(resp, []byte(test.body))
+			if test.wantErr {
+				assert.Error(t, err)
+				assert.True(t, got.IsZero())
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, test.want.Equal(got), "got: %v, want: %v", got, test.want)
+		})
+	}
+}
+
+func TestProbeWithResponseTimestamp(t *testing.T) {
+	body := `{"time": 1709634030}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	opts := &options.Options{
+		Targets:  targets.StaticTargets(u.Hostname()),
+		Interval: 2 * time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			Port: proto.Int32(int32(port)),
+			ResponseTimestamp: &configpb.ProbeConf_ResponseTimestamp{
+				Source: &configpb.ProbeConf_ResponseTimestamp_JqFilter{JqFilter: ".time"},
+			},
+		},
+		LogMetrics: func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	target := endpoint.Endpoint{Name: u.Hostname()}
+	result := p.newResult()
+	run := func() {
+		p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+	}
+	export := func(localTime time.Time) time.Time {
+		dataChan := make(chan *metrics.EventMetrics, 10)
+		p.exportMetrics(localTime, result, target, dataChan)
+		return (<-dataChan).Timestamp
+	}
+
+	localTime := time.Now()
+	run()
+	assert.Equal(t, int64(1), result.success)
+	assert.Equal(t, time.Unix(1709634030, 0), export(localTime))
+
+	// No new response: local time is used.
+	assert.Equal(t, localTime, export(localTime))
+
+	// Parse failure doesn't fail the run, and local time is used.
+	body = `{"time": "yesterday"}`
+	run()
+	assert.Equal(t, int64(2), result.success)
+	assert.Equal(t, localTime, export(localTime))
+}
+
+func TestProbeResponseTimestampMonotonic(t *testing.T) {
+	var serverTime int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf(`{"time": %d}`, serverTime)))
+	}))
+	defer ts.Close()
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	opts := &options.Options{
+		Targets:  targets.StaticTargets(u.Hostname()),
+		Interval: 2 * time.Second,
+		Timeout:  time.Second,
+		ProbeConf: &configpb.ProbeConf{
+			Port: proto.Int32(int32(port)),
+			ResponseTimestamp: &configpb.ProbeConf_ResponseTimestamp{
+				Source: &configpb.ProbeConf_ResponseTimestamp_JqFilter{JqFilter: ".time"},
+			},
+		},
+		LogMetrics: func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("http_test", opts))
+
+	target := endpoint.Endpoint{Name: u.Hostname()}
+	result := p.newResult()
+	runAndExport := func(st int64, localTime time.Time) time.Time {
+		serverTime = st
+		p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+		dataChan := make(chan *metrics.EventMetrics, 10)
+		p.exportMetrics(localTime, result, target, dataChan)
+		return (<-dataChan).Timestamp
+	}
+
+	base := time.Unix(1709634000, 0)
+
+	// Server is ahead of the local clock.
+	assert.Equal(t, base.Add(time.Hour), runAndExport(base.Add(time.Hour).Unix(), base))
+
+	// Server time goes backwards: local time is used, but it's behind too, so
+	// the last timestamp is reused.
+	assert.Equal(t, base.Add(time.Hour), runAndExport(base.Add(30*time.Minute).Unix(), base.Add(time.Minute)))
+
+	// Server time goes backwards, local time is ahead: local time is used.
+	assert.Equal(t, base.Add(2*time.Hour), runAndExport(base.Add(30*time.Minute).Unix(), base.Add(2*time.Hour)))
+
+	// Server time moves forward again.
+	assert.Equal(t, base.Add(3*time.Hour), runAndExport(base.Add(3*time.Hour).Unix(), base.Add(2*time.Hour)))
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+var defaultRetryStatusCodes = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+type retryPolicy struct {
+	statusCodes  map[int]bool
+	defaultDelay time.Duration
+	maxDelay     time.Duration
+}
+
+func newRetryPolicy(c *configpb.ProbeConf_Retry) *retryPolicy {
+	if c == nil {
+		return nil
+	}
+
+	rp := &retryPolicy{
+		statusCodes:  make(map[int]bool),
+		defaultDelay: time.Duration(c.GetDefaultDelayMsec()) * time.Millisecond,
+		maxDelay:     time.Duration(c.GetMaxDelayMsec()) * time.Millisecond,
+	}
+	for _, code := range c.GetStatusCode() {
+		rp.statusCodes[int(code)] = true
+	}
+	if len(rp.statusCodes) == 0 {
+		for _, code := range defaultRetryStatusCodes {
+			rp.statusCodes[code] = true
+		}
+	}
+	return rp
+}
+
+// parseRetryAfter parses the Retry-After header value, which can either be
+// a number of seconds or an HTTP date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
+
+// delay returns how long to wait before retrying the request that got the
+// given response. It returns false if request should not be retried.
+func (rp *retryPolicy) delay(ctx context.Context, resp *http.Response) (time.Duration, bool) {
+	if rp == nil || !rp.statusCodes[resp.StatusCode] {
+		return 0, false
+	}
+
+	now := time.Now()
+	d := rp.defaultDelay
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		var ok bool
+		if d, ok = parseRetryAfter(ra, now); !ok {
+			d = rp.defaultDelay
+		}
+	}
+	if d > rp.maxDelay {
+		return 0, false
+	}
+
+	// Retry only if there is enough time left to wait and make another
+	// request.
+	if deadline, ok := ctx.Deadline(); ok && now.Add(d).After(deadline) {
+		return 0, false
+	}
+	return d, true
+}
+
+// waitForRetry discards the response and waits for the given delay before
+// the request can be retried.
+func waitForRetry(ctx context.Context, resp *http.Response, d time.Duration) error {
+	// Drain and close the body to allow connection reuse.
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
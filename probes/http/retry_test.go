@@ -0,0 +1,154 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		v      string
+		want   time.Duration
+		wantOK bool
+	}{
+		{v: "", wantOK: false},
+		{v: "2", want: 2 * time.Second, wantOK: true},
+		{v: "-1", wantOK: false},
+		{v: "Mon, 01 Jan 2024 00:00:03 GMT", want: 3 * time.Second, wantOK: true},
+		{v: "Sun, 31 Dec 2023 00:00:00 GMT", want: 0, wantOK: true},
+		{v: "soon", wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.v, func(t *testing.T) {
+			got, ok := parseRetryAfter(test.v, now)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	rp := newRetryPolicy(&configpb.ProbeConf_Retry{
+		DefaultDelayMsec: proto.Int32(100),
+		MaxDelayMsec:     proto.Int32(3000),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name       string
+		code       int
+		retryAfter string
+		want       time.Duration
+		wantOK     bool
+	}{
+		{name: "ok", code: 200},
+		{name: "500", code: 500},
+		{name: "429_no_header", code: 429, want: 100 * time.Millisecond, wantOK: true},
+		{name: "503_header", code: 503, retryAfter: "1", want: time.Second, wantOK: true},
+		{name: "beyond_timeout", code: 429, retryAfter: "2"},
+		{name: "beyond_max", code: 429, retryAfter: "10"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: test.code, Header: http.Header{}}
+			if test.retryAfter != "" {
+				resp.Header.Set("Retry-After", test.retryAfter)
+			}
+			got, ok := rp.delay(ctx, resp)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, got)
+		})
+	}
+
+	// nil policy never retries.
+	var nilRP *retryPolicy
+	_, ok := nilRP.delay(ctx, &http.Response{StatusCode: 429})
+	assert.False(t, ok)
+}
+
+func TestProbeWithRetry(t *testing.T) {
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every odd request gets a 429.
+		if reqCount.Add(1)%2 == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+
+	for _, retry := range []bool{false, true} {
+		t.Run(strconv.FormatBool(retry), func(t *testing.T) {
+			reqCount.Store(0)
+
+			conf := &configpb.ProbeConf{Port: proto.Int32(int32(port))}
+			if retry {
+				conf.Retry = &configpb.ProbeConf_Retry{}
+			}
+
+			p := &Probe{}
+			err := p.Init("http_test", &options.Options{
+				Targets:     targets.StaticTargets(u.Hostname()),
+				Interval:    2 * time.Second,
+				Timeout:     time.Second,
+				LatencyUnit: time.Millisecond,
+				ProbeConf:   conf,
+			})
+			assert.NoError(t, err)
+
+			target := endpoint.Endpoint{Name: u.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			assert.Equal(t, int64(1), result.total)
+			if !retry {
+				assert.Equal(t, int64(1), result.respCodes.GetKey("429"))
+				assert.Nil(t, result.retriedRequests)
+				assert.Equal(t, int32(1), reqCount.Load())
+				return
+			}
+			assert.Equal(t, int64(1), result.respCodes.GetKey("200"))
+			assert.Equal(t, int64(0), result.respCodes.GetKey("429"))
+			assert.Equal(t, int64(1), result.retriedRequests.GetKey("429"))
+			assert.Equal(t, int32(2), reqCount.Load())
+		})
+	}
+}
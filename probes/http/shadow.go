@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"crypto/sha256"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+)
+
+// responseInfo captures the response attributes that are used to compare
+// primary and shadow responses.
+type responseInfo struct {
+	statusCode int
+	bodyHash   [sha256.Size]byte
+	latency    time.Duration
+}
+
+type shadowResult struct {
+	total, success, match int64
+	latency               metrics.LatencyValue
+	divergence            *metrics.Map[int64]
+}
+
+func newShadowResult(baseLatencyValue metrics.LatencyValue) *shadowResult {
+	sr := &shadowResult{
+		latency:    baseLatencyValue.Clone().(metrics.LatencyValue),
+		divergence: metrics.NewMap("reason"),
+	}
+	for _, reason := range []string{"status", "body", "latency", "error"} {
+		sr.divergence.IncKeyBy(reason, 0)
+	}
+	return sr
+}
+
+// shadowRequest returns the shadow version of the given request.
+func (p *Probe) shadowRequest(req *http.Request) *http.Request {
+	sc := p.c.GetShadow()
+
+	sreq := p.prepareRequest(req).Clone(req.Context())
+	sreq.URL.Host = sc.GetHost()
+	if !sc.GetPreserveHostHeader() {
+		sreq.Host = sc.GetHost()
+	}
+	return sreq
+}
+
+func (p *Probe) doShadowRequest(req *http.Request, targetName string) *responseInfo {
+	req = p.shadowRequest(req)
+
+	start := time.Now()
+	resp, err := p.shadowClient.Do(req)
+	if err != nil {
+		p.l.WarningAttrs("shadow request error: "+err.Error(), slog.String("target", targetName), slog.String("url", req.URL.String()))
+		return nil
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		p.l.WarningAttrs("shadow request error: "+err.Error(), slog.String("target", targetName), slog.String("url", req.URL.String()))
+		return nil
+	}
+
+	return p.newResponseInfo(resp, respBody, time.Since(start))
+}
+
+func (p *Probe) newResponseInfo(resp *http.Response, respBody []byte, latency time.Duration) *responseInfo {
+	ri := &responseInfo{
+		statusCode: resp.StatusCode,
+		latency:    latency,
+	}
+	if p.c.GetShadow().GetCompareBody() {
+		ri.bodyHash = sha256.Sum256(respBody)
+	}
+	return ri
+}
+
+// compare compares primary and shadow responses and updates the result.
+// A nil responseInfo represents a failed request.
+func (sr *shadowResult) compare(primary, shadow *responseInfo, sc *configpb.ProbeConf_Shadow, latencyUnit time.Duration) {
+	sr.total++
+	if shadow != nil {
+		sr.success++
+		sr.latency.AddFloat64(shadow.latency.Seconds() / latencyUnit.Seconds())
+	}
+
+	if primary == nil || shadow == nil {
+		if primary != shadow {
+			sr.divergence.IncKey("error")
+		} else {
+			sr.match++
+		}
+		return
+	}
+
+	diverged := false
+	if primary.statusCode != shadow.statusCode {
+		sr.divergence.IncKey("status")
+		diverged = true
+	}
+	if sc.GetCompareBody() && primary.bodyHash != shadow.bodyHash {
+		sr.divergence.IncKey("body")
+		diverged = true
+	}
+	if tolerance := time.Duration(sc.GetLatencyToleranceMsec()) * time.Millisecond; tolerance > 0 {
+		delta := primary.latency - shadow.latency
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > tolerance {
+			sr.divergence.IncKey("latency")
+			diverged = true
+		}
+	}
+	if !diverged {
+		sr.match++
+	}
+}
+
+// doHTTPRequestWithShadow runs the HTTP request and, if shadow mode is
+// configured, runs the shadow request concurrently and compares the two
+// responses.
+func (p *Probe) doHTTPRequestWithShadow(req *http.Request, client *http.Client, targetName string, result *probeResult, resultMu *sync.Mutex) {
+	if result.shadow == nil {
+		p.doHTTPRequest(req, client, targetName, result, resultMu)
+		return
+	}
+
+	shadowInfoCh := make(chan *responseInfo, 1)
+	go func() {
+		shadowInfoCh <- p.doShadowRequest(req, targetName)
+	}()
+
+	primaryInfo := p.doHTTPRequest(req, client, targetName, result, resultMu)
+	shadowInfo := <-shadowInfoCh
+
+	if resultMu != nil {
+		resultMu.Lock()
+		defer resultMu.Unlock()
+	}
+	result.shadow.compare(primaryInfo, shadowInfo, p.c.GetShadow(), p.opts.LatencyUnit)
+}
+
+func (sr *shadowResult) addMetrics(em *metrics.EventMetrics, latencyMetricName string) {
+	em.AddMetric("shadow_total", metrics.NewInt(sr.total)).
+		AddMetric("shadow_success", metrics.NewInt(sr.success)).
+		AddMetric("shadow_match", metrics.NewInt(sr.match)).
+		AddMetric("shadow_"+latencyMetricName, sr.latency.Clone()).
+		AddMetric("shadow_divergence", sr.divergence.Clone())
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestShadowResultCompare(t *testing.T) {
+	sc := &configpb.ProbeConf_Shadow{
+		LatencyToleranceMsec: proto.Int32(50),
+	}
+	ri := func(code int, body string, latencyMsec int) *responseInfo {
+		r := &responseInfo{statusCode: code, latency: time.Duration(latencyMsec) * time.Millisecond}
+		copy(r.bodyHash[:], body)
+		return r
+	}
+
+	sr := newShadowResult(metrics.NewFloat(0))
+	sr.compare(ri(200, "a", 10), ri(200, "a", 30), sc, time.Millisecond)  // match
+	sr.compare(ri(200, "a", 10), ri(500, "b", 100), sc, time.Millisecond) // status, body, latency
+	sr.compare(ri(200, "a", 10), nil, sc, time.Millisecond)               // error
+	sr.compare(nil, nil, sc, time.Millisecond)                            // match
+
+	assert.Equal(t, int64(4), sr.total)
+	assert.Equal(t, int64(2), sr.success)
+	assert.Equal(t, int64(2), sr.match)
+	assert.Equal(t, float64(130), sr.latency.(*metrics.Float).Float64())
+	for reason, want := range map[string]int64{"status": 1, "body": 1, "latency": 1, "error": 1} {
+		assert.Equal(t, want, sr.divergence.GetKey(reason), reason)
+	}
+}
+
+func TestProbeWithShadow(t *testing.T) {
+	newServer := func(code int, body string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(code)
+			w.Write([]byte(body))
+		}))
+	}
+
+	primary := newServer(200, "ok")
+	defer primary.Close()
+
+	tests := []struct {
+		name           string
+		shadowCode     int
+		shadowBody     string
+		wantMatch      int64
+		wantDivergence map[string]int64
+	}{
+		{
+			name:           "same",
+			shadowCode:     200,
+			shadowBody:     "ok",
+			wantMatch:      1,
+			wantDivergence: map[string]int64{"status": 0, "body": 0},
+		},
+		{
+			name:           "different",
+			shadowCode:     503,
+			shadowBody:     "unavailable",
+			wantMatch:      0,
+			wantDivergence: map[string]int64{"status": 1, "body": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shadow := newServer(test.shadowCode, test.shadowBody)
+			defer shadow.Close()
+
+			pu, _ := url.Parse(primary.URL)
+			su, _ := url.Parse(shadow.URL)
+			port, _ := strconv.Atoi(pu.Port())
+
+			p := &Probe{}
+			err := p.Init("http_test", &options.Options{
+				Targets:     targets.StaticTargets(pu.Hostname()),
+				Interval:    2 * time.Second,
+				Timeout:     time.Second,
+				LatencyUnit: time.Millisecond,
+				ProbeConf: &configpb.ProbeConf{
+					Port:   proto.Int32(int32(port)),
+					Shadow: &configpb.ProbeConf_Shadow{Host: proto.String(su.Host)},
+				},
+			})
+			assert.NoError(t, err)
+
+			target := endpoint.Endpoint{Name: pu.Hostname()}
+			result := p.newResult()
+			p.runProbe(context.Background(), target, p.clientsForTarget(target), p.httpRequestForTarget(target), result)
+
+			// Shadow response doesn't affect the primary probe result.
+			assert.Equal(t, int64(1), result.success)
+			assert.Equal(t, int64(1), result.shadow.total)
+			assert.Equal(t, int64(1), result.shadow.success)
+			assert.Equal(t, test.wantMatch, result.shadow.match)
+			for reason, want := range test.wantDivergence {
+				assert.Equal(t, want, result.shadow.divergence.GetKey(reason), reason)
+			}
+
+			em := metrics.NewEventMetrics(time.Now())
+			result.shadow.addMetrics(em, "latency")
+			assert.ElementsMatch(t, []string{"shadow_total", "shadow_success", "shadow_match", "shadow_latency", "shadow_divergence"}, em.MetricsKeys())
+		})
+	}
+}
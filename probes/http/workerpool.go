@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// Maximum number of targets in the work queue. If queue is full, adding
+// targets to it blocks until workers catch up.
+const workQueueSize = 10000
+
+type poolTarget struct {
+	*targetState
+
+	// Set while target is queued or being probed.
+	busy atomic.Bool
+}
+
+// workerPool probes targets using a fixed number of workers.
+type workerPool struct {
+	p        *Probe
+	queue    chan *poolTarget
+	dataChan chan *metrics.EventMetrics
+
+	// Accessed only by the dispatch loop.
+	targets map[string]*poolTarget
+}
+
+func (p *Probe) newWorkerPool(dataChan chan *metrics.EventMetrics) *workerPool {
+	return &workerPool{
+		p:        p,
+		queue:    make(chan *poolTarget, workQueueSize),
+		dataChan: dataChan,
+		targets:  make(map[string]*poolTarget),
+	}
+}
+
+// updateTargets refreshes the pool's targets. State for the existing targets
+// is preserved.
+func (wp *workerPool) updateTargets() {
+	p := wp.p
+	p.targets = p.opts.Targets.ListEndpoints()
+	p.opts.UpdateTargets(p.targets)
+	p.opts.RecordEmptyTargets(time.Now(), p.name, wp.dataChan)
+
+	activeTargets := make(map[string]endpoint.Endpoint)
+	for _, target := range p.targets {
+		activeTargets[target.Key()] = target
+	}
+
+	for key := range wp.targets {
+		if _, ok := activeTargets[key]; !ok {
+			delete(wp.targets, key)
+		}
+	}
+	for key, target := range activeTargets {
+		if wp.targets[key] == nil {
+			wp.targets[key] = &poolTarget{targetState: p.newTargetState(target)}
+		}
+	}
+}
+
+// enqueue adds all the targets that are not busy to the queue.
+func (wp *workerPool) enqueue(ctx context.Context) {
+	keys := make([]string, 0, len(wp.targets))
+	for key := range wp.targets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	wp.p.opts.ShuffleTargets(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+
+	for _, key := range keys {
+		pt := wp.targets[key]
+		if !pt.busy.CompareAndSwap(false, true) {
+			wp.p.l.Debugf("Target %s is still queued or being probed, skipping it for this cycle", pt.target.Name)
+			continue
+		}
+		select {
+		case wp.queue <- pt:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (wp *workerPool) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case pt := <-wp.queue:
+			// Targets queued before the probe started stopping, or outside
+			// of the probe's schedule, are not probed by runForTarget.
+			wp.p.runForTarget(ctx, time.Now(), pt.targetState, wp.dataChan)
+			pt.busy.Store(false)
+		}
+	}
+}
+
+func (wp *workerPool) exportQueueDepth(ts time.Time) {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("queue_depth", metrics.NewInt(int64(len(wp.queue)))).
+		AddLabel("ptype", "http").
+		AddLabel("probe", wp.p.name)
+	em.Kind = metrics.GAUGE
+	wp.p.opts.LogMetrics(em)
+	wp.dataChan <- em
+}
+
+// run starts the workers and the dispatch loop, and blocks until the
+// context is canceled.
+func (wp *workerPool) run(ctx context.Context, numWorkers int) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wp.worker(ctx)
+		}()
+	}
+
+	wp.updateTargets()
+	lastTargetsUpdate := time.Now()
+
+	ticker := time.NewTicker(wp.p.opts.Interval)
+	defer ticker.Stop()
+
+	var runCnt int64
+	for ts := time.Now(); true; ts = <-ticker.C {
+		if ctxDone(ctx) {
+			return
+		}
+
+		// Refresh targets more often until we get a non-zero list of targets.
+		if len(wp.targets) == 0 || time.Since(lastTargetsUpdate) >= wp.p.targetsUpdateInterval {
+			wp.updateTargets()
+			lastTargetsUpdate = time.Now()
+		}
+
+		if (runCnt % wp.p.statsExportFrequency) == 0 {
+			wp.exportQueueDepth(ts)
+		}
+		runCnt++
+
+		wp.enqueue(ctx)
+	}
+}
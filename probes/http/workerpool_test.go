@@ -0,0 +1,103 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/metrics/testutils"
+	configpb "github.com/cloudprober/cloudprober/probes/http/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// concurrencyTransport tracks the maximum number of in-flight requests.
+type concurrencyTransport struct {
+	inFlight, maxInFlight atomic.Int32
+	mu                    sync.Mutex
+	hosts                 map[string]int
+}
+
+func (ct *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	n := ct.inFlight.Add(1)
+	defer ct.inFlight.Add(-1)
+	for {
+		max := ct.maxInFlight.Load()
+		if n <= max || ct.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+
+	ct.mu.Lock()
+	ct.hosts[req.URL.Host]++
+	ct.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestWorkerPool(t *testing.T) {
+	opts := &options.Options{
+		Targets:             targets.StaticTargets("t1,t2,t3,t4,t5,t6"),
+		Interval:            50 * time.Millisecond,
+		Timeout:             20 * time.Millisecond,
+		StatsExportInterval: 50 * time.Millisecond,
+		ProbeConf:           &configpb.ProbeConf{MaxConcurrentProbes: proto.Int32(2)},
+		LogMetrics:          func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	assert.NoError(t, p.Init("http_test", opts))
+
+	ct := &concurrencyTransport{hosts: make(map[string]int)}
+	p.baseTransport = ct
+
+	dataChan := make(chan *metrics.EventMetrics, 1000)
+	ctx, cancelF := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.Start(ctx, dataChan)
+		close(done)
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+	cancelF()
+	<-done
+
+	assert.LessOrEqual(t, ct.maxInFlight.Load(), int32(2), "max in-flight requests")
+	for _, tgt := range []string{"t1", "t2", "t3", "t4", "t5", "t6"} {
+		assert.Greater(t, ct.hosts[tgt], 0, "requests for target %s", tgt)
+	}
+
+	ems, _ := testutils.MetricsFromChannel(dataChan, 1000, 100*time.Millisecond)
+	var queueDepthEMs, targetEMs int
+	for _, em := range ems {
+		if em.Metric("queue_depth") != nil {
+			queueDepthEMs++
+			assert.Equal(t, "", em.Label("dst"))
+			continue
+		}
+		targetEMs++
+	}
+	assert.Greater(t, queueDepthEMs, 0, "queue_depth metrics")
+	assert.Greater(t, targetEMs, 0, "target metrics")
+}
@@ -0,0 +1,254 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ntp implements an NTP probe type. It sends SNTP (RFC 4330)
+// requests to the targets, and measures the clock offset and the round-trip
+// delay.
+package ntp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/iputils"
+	"github.com/cloudprober/cloudprober/internal/sockopt"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/common/sched"
+	configpb "github.com/cloudprober/cloudprober/probes/ntp/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+const defaultPort = 123
+
+// Probe holds aggregate information about all probe runs, per-target.
+type Probe struct {
+	name string
+	opts *options.Options
+	c    *configpb.ProbeConf
+	l    *logger.Logger
+
+	network     string
+	dialContext func(context.Context, string, string) (net.Conn, error)
+}
+
+type probeResult struct {
+	total, success int64
+	latency        metrics.LatencyValue
+	failures       *metrics.Map[int64]
+
+	// Last measurement.
+	measured      bool
+	offset, delay time.Duration
+	stratum       uint8
+}
+
+func (p *Probe) newResult() sched.ProbeResult {
+	return &probeResult{
+		latency:  p.opts.NewLatencyValue(),
+		failures: p.opts.NewFailureReasons(),
+	}
+}
+
+func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(result.total)).
+		AddMetric("success", metrics.NewInt(result.success)).
+		AddMetric(opts.LatencyMetricName, result.latency.Clone()).
+		AddLabel("ptype", "ntp")
+
+	if result.failures != nil {
+		em.AddMetric("failures", result.failures.Clone())
+	}
+
+	return em
+}
+
+// GaugeMetrics returns the last measured offset and delay, with the stratum
+// as a label.
+func (result *probeResult) GaugeMetrics(ts time.Time, _ *options.Options) *metrics.EventMetrics {
+	if !result.measured {
+		return nil
+	}
+	return metrics.NewEventMetrics(ts).
+		AddMetric("offset_sec", metrics.NewFloat(result.offset.Seconds())).
+		AddMetric("delay_sec", metrics.NewFloat(result.delay.Seconds())).
+		AddLabel("ptype", "ntp").
+		AddLabel("stratum", strconv.Itoa(int(result.stratum)))
+}
+
+// Init initializes the probe with the given params.
+func (p *Probe) Init(name string, opts *options.Options) error {
+	if opts.ProbeConf == nil {
+		opts.ProbeConf = &configpb.ProbeConf{}
+	}
+
+	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
+	if !ok {
+		return fmt.Errorf("not ntp probe config")
+	}
+	p.name = name
+	p.opts = opts
+	if p.l = opts.Logger; p.l == nil {
+		p.l = &logger.Logger{}
+	}
+	p.c = c
+
+	if v := p.c.GetVersion(); v < 1 || v > 4 {
+		return fmt.Errorf("invalid NTP version: %d, should be between 1 and 4", v)
+	}
+	if p.c.GetMaxOffsetMsec() < 0 {
+		return fmt.Errorf("max_offset_msec cannot be negative: %d", p.c.GetMaxOffsetMsec())
+	}
+
+	p.network = "udp"
+	if p.opts.IPVersion != 0 {
+		p.network += strconv.Itoa(p.opts.IPVersion)
+	}
+
+	dialer := &net.Dialer{}
+	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
+		dialer.LocalAddr = &net.UDPAddr{IP: p.opts.SourceIP}
+	}
+	if p.opts.DSCP != 0 {
+		dialer.Control = sockopt.DialerControl(p.opts.DSCP, p.l)
+	}
+	// Use targets' DNS server, if configured, to resolve the target names.
+	dialer.Resolver = targets.DNSResolver(p.opts.Targets)
+	p.dialContext = dialer.DialContext
+
+	return nil
+}
+
+// query sends an NTP request over the connection, and returns the validated
+// response, along with the request's transmit time and the response's
+// receive time.
+func (p *Probe) query(conn net.Conn) (*packet, time.Time, time.Time, error) {
+	t1 := time.Now()
+	req := newRequest(uint8(p.c.GetVersion()), t1)
+	if _, err := conn.Write(req.marshal()); err != nil {
+		return nil, t1, time.Time{}, err
+	}
+
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	t4 := time.Now()
+	if err != nil {
+		return nil, t1, t4, err
+	}
+
+	resp, err := parsePacket(buf[:n])
+	if err != nil {
+		return nil, t1, t4, &invalidResponseError{err}
+	}
+	if err := resp.validate(req); err != nil {
+		return nil, t1, t4, &invalidResponseError{err}
+	}
+	return resp, t1, t4, nil
+}
+
+func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
+	ctx, cancelCtx := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancelCtx()
+
+	result := res.(*probeResult)
+	result.total++
+
+	host := target.Name
+	ipLabel := ""
+
+	resolveFirst := target.IP != nil
+	if p.c.ResolveFirst != nil {
+		resolveFirst = p.c.GetResolveFirst()
+	}
+	if resolveFirst {
+		ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", resolve error: ", err.Error())
+			options.RecordFailure(result.failures, options.FailureDNS)
+			return
+		}
+		host = ip.String()
+		ipLabel = host
+	}
+
+	port := int(p.c.GetPort())
+	if port == 0 {
+		port = target.Port
+	}
+	if port == 0 {
+		port = defaultPort
+	}
+
+	for _, al := range p.opts.AdditionalLabels {
+		al.UpdateForTarget(target, ipLabel, port)
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := p.dialContext(ctx, p.network, addr)
+	if err != nil {
+		p.l.Warning("Target:", target.Name, ", dial: ", err.Error())
+		options.RecordFailure(result.failures, options.ClassifyError(err))
+		return
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	resp, t1, t4, err := p.query(conn)
+	if err != nil {
+		p.l.Warning("Target:", target.Name, ", NTP query: ", err.Error())
+		if _, ok := err.(*invalidResponseError); ok {
+			options.RecordFailure(result.failures, options.FailureValidation)
+		} else {
+			options.RecordFailure(result.failures, options.ClassifyError(err))
+		}
+		return
+	}
+
+	offset, delay := resp.offsetAndDelay(t1, t4)
+	result.measured = true
+	result.offset, result.delay, result.stratum = offset, delay, resp.stratum
+
+	if maxOffset := time.Duration(p.c.GetMaxOffsetMsec()) * time.Millisecond; maxOffset > 0 && offset.Abs() > maxOffset {
+		p.l.Warningf("Target: %s, clock offset (%v) is more than the max offset (%v)", target.Name, offset, maxOffset)
+		options.RecordFailure(result.failures, options.FailureValidation)
+		return
+	}
+
+	result.success++
+	result.latency.AddFloat64(delay.Seconds() / p.opts.LatencyUnit.Seconds())
+}
+
+// Start starts and runs the probe indefinitely.
+func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	s := &sched.Scheduler{
+		ProbeName:              p.name,
+		DataChan:               dataChan,
+		Opts:                   p.opts,
+		NewResult:              p.newResult,
+		RunProbeForTarget:      p.runProbe,
+		IntervalBetweenTargets: time.Duration(p.c.GetIntervalBetweenTargetsMsec()) * time.Millisecond,
+	}
+	s.UpdateTargetsAndStartProbes(ctx)
+}
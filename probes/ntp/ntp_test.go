@@ -0,0 +1,173 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/ntp/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// startTestServer starts a fake NTP server, with its clock ahead by offset.
+// If modify is not nil, it's applied to the responses. If it returns false,
+// no response is sent.
+func startTestServer(t *testing.T, network, addr string, offset time.Duration, modify func(*packet) bool) *net.UDPAddr {
+	t.Helper()
+	conn, err := net.ListenPacket(network, addr)
+	if err != nil {
+		t.Skipf("Error listening on %s: %v", addr, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, raddr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req, err := parsePacket(buf[:n])
+			if err != nil {
+				continue
+			}
+			resp := &packet{
+				version:     req.version,
+				mode:        modeServer,
+				stratum:     2,
+				originTime:  req.transmitTime,
+				receiveTime: toNTPTime(time.Now().Add(offset)),
+			}
+			resp.transmitTime = toNTPTime(time.Now().Add(offset))
+			if modify != nil && !modify(resp) {
+				continue
+			}
+			conn.WriteTo(resp.marshal(), raddr)
+		}
+	}()
+
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+func testProbe(t *testing.T, conf *configpb.ProbeConf) *Probe {
+	t.Helper()
+	opts := options.DefaultOptions()
+	opts.Timeout = 200 * time.Millisecond
+	opts.ExportFailureReasons = true
+	opts.ProbeConf = conf
+	opts.Targets = targets.StaticTargets("localhost")
+
+	p := &Probe{}
+	require.NoError(t, p.Init("ntp_test", opts))
+	return p
+}
+
+func TestRunProbe(t *testing.T) {
+	tests := []struct {
+		name        string
+		network     string
+		addr        string
+		conf        *configpb.ProbeConf
+		modify      func(*packet) bool
+		wantSuccess int64
+		wantFailure string
+	}{
+		{
+			name:        "ipv4",
+			network:     "udp4",
+			addr:        "127.0.0.1:0",
+			wantSuccess: 1,
+		},
+		{
+			name:        "ipv6",
+			network:     "udp6",
+			addr:        "[::1]:0",
+			wantSuccess: 1,
+		},
+		{
+			name:        "max_offset",
+			network:     "udp4",
+			addr:        "127.0.0.1:0",
+			conf:        &configpb.ProbeConf{MaxOffsetMsec: proto.Int32(1000)},
+			wantFailure: options.FailureValidation,
+		},
+		{
+			name:        "kiss_of_death",
+			network:     "udp4",
+			addr:        "127.0.0.1:0",
+			modify:      func(p *packet) bool { p.stratum = 0; return true },
+			wantFailure: options.FailureValidation,
+		},
+		{
+			name:        "no_response",
+			network:     "udp4",
+			addr:        "127.0.0.1:0",
+			modify:      func(p *packet) bool { return false },
+			wantFailure: options.FailureTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addr := startTestServer(t, test.network, test.addr, 5*time.Second, test.modify)
+
+			conf := test.conf
+			if conf == nil {
+				conf = &configpb.ProbeConf{}
+			}
+			conf.Port = proto.Int32(int32(addr.Port))
+			p := testProbe(t, conf)
+
+			result := p.newResult().(*probeResult)
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: addr.IP.String()}, result)
+
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			if test.wantFailure != "" {
+				assert.Equal(t, int64(1), result.failures.GetKey(test.wantFailure))
+			}
+			if test.wantSuccess == 0 && test.modify != nil {
+				assert.Nil(t, result.GaugeMetrics(time.Now(), p.opts))
+				return
+			}
+
+			assert.InDelta(t, 5, result.offset.Seconds(), 0.1)
+			em := result.GaugeMetrics(time.Now(), p.opts)
+			assert.Equal(t, "2", em.Label("stratum"))
+			assert.InDelta(t, 5, em.Metric("offset_sec").(metrics.NumValue).Float64(), 0.1)
+			assert.Less(t, em.Metric("delay_sec").(metrics.NumValue).Float64(), 0.1)
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	for _, conf := range []*configpb.ProbeConf{
+		{Version: proto.Int32(5)},
+		{MaxOffsetMsec: proto.Int32(-1)},
+	} {
+		opts := options.DefaultOptions()
+		opts.ProbeConf = conf
+		assert.Error(t, (&Probe{}).Init("ntp_test", opts), "conf: %v", conf)
+	}
+}
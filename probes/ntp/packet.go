@@ -0,0 +1,148 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+const (
+	packetLen = 48
+
+	modeClient = 3
+	modeServer = 4
+
+	leapNotInSync = 3
+	maxStratum    = 15
+
+	// Seconds between the NTP epoch (1900) and the Unix epoch (1970).
+	ntpEpochOffset = 2208988800
+)
+
+// invalidResponseError is returned for responses that fail validation.
+type invalidResponseError struct {
+	err error
+}
+
+func (e *invalidResponseError) Error() string {
+	return "invalid response: " + e.err.Error()
+}
+
+// packet is an NTP packet, without the optional extension fields and
+// authenticator, which we don't use. See RFC 4330, section 4.
+type packet struct {
+	leap, version, mode uint8
+	stratum             uint8
+	refID               [4]byte
+
+	// Timestamps in the NTP 64-bit format: seconds since the NTP epoch in
+	// the upper 32 bits, and the fraction of the second in the lower 32
+	// bits.
+	originTime, receiveTime, transmitTime uint64
+}
+
+// toNTPTime converts t to the NTP timestamp format.
+func toNTPTime(t time.Time) uint64 {
+	nsec := uint64(t.Sub(time.Unix(-ntpEpochOffset, 0)))
+	sec := nsec / 1e9
+	frac := (nsec % 1e9) << 32 / 1e9
+	return sec<<32 | frac
+}
+
+// fromNTPTime converts an NTP timestamp to time.Time. As recommended by RFC
+// 4330 (section 3), timestamps with the most significant bit unset are
+// assumed to be in the next era, i.e. after 2036.
+func fromNTPTime(v uint64) time.Time {
+	sec, frac := int64(v>>32), int64(v&0xffffffff)
+	if sec&0x80000000 == 0 {
+		sec += 1 << 32
+	}
+	return time.Unix(sec-ntpEpochOffset, frac*1e9>>32)
+}
+
+func newRequest(version uint8, t time.Time) *packet {
+	return &packet{
+		version:      version,
+		mode:         modeClient,
+		transmitTime: toNTPTime(t),
+	}
+}
+
+func (pkt *packet) marshal() []byte {
+	b := make([]byte, packetLen)
+	b[0] = pkt.leap<<6 | pkt.version<<3 | pkt.mode
+	b[1] = pkt.stratum
+	copy(b[12:16], pkt.refID[:])
+	binary.BigEndian.PutUint64(b[24:], pkt.originTime)
+	binary.BigEndian.PutUint64(b[32:], pkt.receiveTime)
+	binary.BigEndian.PutUint64(b[40:], pkt.transmitTime)
+	return b
+}
+
+func parsePacket(b []byte) (*packet, error) {
+	if len(b) < packetLen {
+		return nil, fmt.Errorf("packet too short: %d bytes", len(b))
+	}
+	pkt := &packet{
+		leap:         b[0] >> 6,
+		version:      (b[0] >> 3) & 0x7,
+		mode:         b[0] & 0x7,
+		stratum:      b[1],
+		originTime:   binary.BigEndian.Uint64(b[24:]),
+		receiveTime:  binary.BigEndian.Uint64(b[32:]),
+		transmitTime: binary.BigEndian.Uint64(b[40:]),
+	}
+	copy(pkt.refID[:], b[12:16])
+	return pkt, nil
+}
+
+// validate validates the response packet for the request, as described in
+// RFC 4330, section 5.
+func (pkt *packet) validate(req *packet) error {
+	if pkt.mode != modeServer {
+		return fmt.Errorf("unexpected mode: %d", pkt.mode)
+	}
+	if pkt.originTime != req.transmitTime {
+		return fmt.Errorf("origin timestamp doesn't match the request's transmit timestamp")
+	}
+	if pkt.stratum == 0 {
+		return fmt.Errorf("kiss-o'-death packet, code: %s", string(pkt.refID[:]))
+	}
+	if pkt.stratum > maxStratum {
+		return fmt.Errorf("invalid stratum: %d", pkt.stratum)
+	}
+	if pkt.leap == leapNotInSync {
+		return fmt.Errorf("server clock is not synchronized")
+	}
+	if pkt.transmitTime == 0 {
+		return fmt.Errorf("transmit timestamp is not set")
+	}
+	return nil
+}
+
+// offsetAndDelay computes the local clock's offset and the round-trip delay,
+// given the request's transmit time (t1), and the response's receive time
+// (t4).
+func (pkt *packet) offsetAndDelay(t1, t4 time.Time) (time.Duration, time.Duration) {
+	t2, t3 := fromNTPTime(pkt.receiveTime), fromNTPTime(pkt.transmitTime)
+	offset := (t2.Sub(t1) + t3.Sub(t4)) / 2
+	delay := t4.Sub(t1) - t3.Sub(t2)
+	if delay < 0 {
+		delay = 0
+	}
+	return offset, delay
+}
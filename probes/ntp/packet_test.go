@@ -0,0 +1,102 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ntp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNTPTime(t *testing.T) {
+	for _, ts := range []time.Time{
+		time.Date(2024, 5, 1, 10, 0, 0, 123456789, time.UTC),
+		// Era 1, i.e. after 2036-02-07.
+		time.Date(2040, 1, 1, 0, 0, 0, 500000000, time.UTC),
+	} {
+		got := fromNTPTime(toNTPTime(ts))
+		assert.WithinDuration(t, ts, got, time.Microsecond, "time: %v", ts)
+	}
+
+	assert.Equal(t, uint64(ntpEpochOffset)<<32, toNTPTime(time.Unix(0, 0)))
+}
+
+func TestPacketMarshalParse(t *testing.T) {
+	pkt := &packet{
+		leap:         1,
+		version:      4,
+		mode:         modeServer,
+		stratum:      2,
+		refID:        [4]byte{'G', 'P', 'S', 0},
+		originTime:   1,
+		receiveTime:  2,
+		transmitTime: 3,
+	}
+	got, err := parsePacket(pkt.marshal())
+	require.NoError(t, err)
+	assert.Equal(t, pkt, got)
+
+	_, err = parsePacket(make([]byte, 40))
+	assert.Error(t, err)
+}
+
+func TestPacketValidate(t *testing.T) {
+	req := newRequest(4, time.Now())
+	resp := func(f func(*packet)) *packet {
+		pkt := &packet{version: 4, mode: modeServer, stratum: 1, originTime: req.transmitTime, receiveTime: 1, transmitTime: 1}
+		f(pkt)
+		return pkt
+	}
+
+	tests := []struct {
+		name    string
+		resp    *packet
+		wantErr bool
+	}{
+		{name: "valid", resp: resp(func(p *packet) {})},
+		{name: "client_mode", resp: resp(func(p *packet) { p.mode = modeClient }), wantErr: true},
+		{name: "origin_mismatch", resp: resp(func(p *packet) { p.originTime++ }), wantErr: true},
+		{name: "kiss_of_death", resp: resp(func(p *packet) { p.stratum = 0; p.refID = [4]byte{'R', 'A', 'T', 'E'} }), wantErr: true},
+		{name: "bad_stratum", resp: resp(func(p *packet) { p.stratum = 16 }), wantErr: true},
+		{name: "not_in_sync", resp: resp(func(p *packet) { p.leap = leapNotInSync }), wantErr: true},
+		{name: "no_transmit_time", resp: resp(func(p *packet) { p.transmitTime = 0 }), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.resp.validate(req)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOffsetAndDelay(t *testing.T) {
+	t1 := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	// Server clock is 2s ahead. Request takes 10ms to reach the server,
+	// server takes 1ms to respond, and response takes 20ms to come back.
+	t2 := t1.Add(2*time.Second + 10*time.Millisecond)
+	t3 := t2.Add(time.Millisecond)
+	t4 := t1.Add(31 * time.Millisecond)
+
+	pkt := &packet{receiveTime: toNTPTime(t2), transmitTime: toNTPTime(t3)}
+	offset, delay := pkt.offsetAndDelay(t1, t4)
+	assert.InDelta(t, (1995 * time.Millisecond).Seconds(), offset.Seconds(), 1e-6)
+	assert.InDelta(t, (30 * time.Millisecond).Seconds(), delay.Seconds(), 1e-6)
+}
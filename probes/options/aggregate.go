@@ -0,0 +1,228 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// AllTargetsDst is the value of the "dst" label for the metrics aggregated
+// across all targets.
+const AllTargetsDst = "__all_targets__"
+
+// Targets that haven't reported metrics in these many stats export intervals
+// are dropped from the aggregate.
+const staleTargetIntervals = 3
+
+// gaugeMetricNames are the numeric metrics that are gauges, even if they
+// are found in a cumulative EventMetrics. They are not summed across targets.
+var gaugeMetricNames = map[string]bool{
+	StableStateMetricName:  true,
+	AvailabilityMetricName: true,
+}
+
+type targetMetrics struct {
+	dists      map[string]*metrics.Distribution
+	counters   map[string]metrics.NumValue
+	lastUpdate time.Time
+}
+
+// targetsAggregator aggregates metrics across targets, and periodically
+// generates an EventMetrics with the aggregated metrics.
+//
+// Distribution metrics (e.g. latency) are merged. Since distribution bucket
+// counts are request counts, merged distribution is automatically weighted by
+// the number of requests to each target.
+//
+// If counters are enabled, numeric metrics of cumulative EventMetrics (e.g.
+// total, success) are summed up; gauges (see gaugeMetricNames) are skipped.
+//
+// To keep the aggregate monotonic when targets go away, or when a target's
+// metrics are reset (e.g. target was removed and added back), the last
+// values of such targets, counters as well as distributions, are carried
+// over to the subsequent aggregates.
+type targetsAggregator struct {
+	interval time.Duration
+	dists    bool
+	counters bool
+	l        *logger.Logger
+
+	mu         sync.Mutex
+	targets    map[string]*targetMetrics
+	carry      map[string]metrics.NumValue
+	carryDists map[string]*metrics.Distribution
+	lastExport time.Time
+	warned     map[string]bool
+}
+
+func newTargetsAggregator(interval time.Duration, dists, counters bool, l *logger.Logger) *targetsAggregator {
+	return &targetsAggregator{
+		interval:   interval,
+		dists:      dists,
+		counters:   counters,
+		l:          l,
+		targets:    make(map[string]*targetMetrics),
+		carry:      make(map[string]metrics.NumValue),
+		warned:     make(map[string]bool),
+		carryDists: make(map[string]*metrics.Distribution),
+	}
+}
+
+func addNum(m map[string]metrics.NumValue, name string, v metrics.NumValue) {
+	if m[name] == nil {
+		m[name] = v.Clone().(metrics.NumValue)
+		return
+	}
+	// Add fails only if the value types differ, e.g. int and float.
+	if err := m[name].Add(v); err != nil {
+		m[name] = metrics.NewFloat(m[name].Float64() + v.Float64())
+	}
+}
+
+// mergeDist merges the distribution into the named distribution in m.
+func (ta *targetsAggregator) mergeDist(m map[string]*metrics.Distribution, name string, d *metrics.Distribution) {
+	if m[name] == nil {
+		m[name] = d.CloneDist()
+		return
+	}
+	if !m[name].Merge(d) && !ta.warned[name] {
+		ta.warned[name] = true
+		ta.l.Warningf("Metric %s has different buckets across targets, aggregate will be approximate", name)
+	}
+}
+
+// retire carries over the target's counters and distributions to the
+// subsequent aggregates.
+func (ta *targetsAggregator) retire(tm *targetMetrics) {
+	for name, v := range tm.counters {
+		addNum(ta.carry, name, v)
+	}
+	for name, d := range tm.dists {
+		ta.mergeDist(ta.carryDists, name, d)
+	}
+}
+
+// isReset returns true if the target's metrics have been reset since the
+// last record, i.e. if any of its counters or distribution counts went back.
+func isReset(old, tm *targetMetrics) bool {
+	for name, v := range tm.counters {
+		if oldV := old.counters[name]; oldV != nil && v.Float64() < oldV.Float64() {
+			return true
+		}
+	}
+	for name, d := range tm.dists {
+		if oldD := old.dists[name]; oldD != nil && d.Data().Count < oldD.Data().Count {
+			return true
+		}
+	}
+	return false
+}
+
+// record records the target's metrics and, if it's time to export the
+// aggregate, returns an EventMetrics with the aggregated metrics. Aggregated
+// EventMetrics has the same labels as the incoming EventMetrics, except for
+// the "dst" label, which is set to AllTargetsDst.
+func (ta *targetsAggregator) record(key string, em *metrics.EventMetrics) *metrics.EventMetrics {
+	if ta == nil {
+		return nil
+	}
+
+	tm := &targetMetrics{
+		dists:      make(map[string]*metrics.Distribution),
+		counters:   make(map[string]metrics.NumValue),
+		lastUpdate: em.Timestamp,
+	}
+	for _, name := range em.MetricsKeys() {
+		switch v := em.Metric(name).(type) {
+		case *metrics.Distribution:
+			if ta.dists {
+				tm.dists[name] = v.CloneDist()
+			}
+		case metrics.NumValue:
+			if ta.counters && em.Kind == metrics.CUMULATIVE && !gaugeMetricNames[name] {
+				tm.counters[name] = v.Clone().(metrics.NumValue)
+			}
+		}
+	}
+	if len(tm.dists) == 0 && len(tm.counters) == 0 {
+		return nil
+	}
+
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	if old := ta.targets[key]; old != nil && isReset(old, tm) {
+		ta.l.Infof("Metrics reset for target %s, carrying over the last values", key)
+		ta.retire(old)
+	}
+	ta.targets[key] = tm
+
+	if em.Timestamp.Sub(ta.lastExport) < ta.interval {
+		return nil
+	}
+	ta.lastExport = em.Timestamp
+
+	var keys []string
+	for k, tm := range ta.targets {
+		if em.Timestamp.Sub(tm.lastUpdate) > staleTargetIntervals*ta.interval {
+			ta.retire(tm)
+			delete(ta.targets, k)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	merged := make(map[string]*metrics.Distribution)
+	sums := make(map[string]metrics.NumValue)
+	for name, d := range ta.carryDists {
+		ta.mergeDist(merged, name, d)
+	}
+	for name, v := range ta.carry {
+		addNum(sums, name, v)
+	}
+	for _, k := range keys {
+		for name, d := range ta.targets[k].dists {
+			ta.mergeDist(merged, name, d)
+		}
+		for name, v := range ta.targets[k].counters {
+			addNum(sums, name, v)
+		}
+	}
+
+	aggEM := metrics.NewEventMetrics(em.Timestamp)
+	aggEM.Kind = em.Kind
+	for _, name := range em.MetricsKeys() {
+		if d := merged[name]; d != nil {
+			aggEM.AddMetric(name, d)
+		}
+		if v := sums[name]; v != nil {
+			aggEM.AddMetric(name, v)
+		}
+	}
+	for _, k := range em.LabelsKeys() {
+		if k == "dst" {
+			continue
+		}
+		aggEM.AddLabel(k, em.Label(k))
+	}
+	aggEM.AddLabel("dst", AllTargetsDst)
+	return aggEM
+}
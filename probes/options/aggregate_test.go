@@ -0,0 +1,164 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDistEM(ts time.Time, dst string, samples ...float64) *metrics.EventMetrics {
+	d := metrics.NewDistribution([]float64{1, 10, 100})
+	for _, s := range samples {
+		d.AddSample(s)
+	}
+	return metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(int64(len(samples)))).
+		AddMetric("latency", d).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "p1").
+		AddLabel("dst", dst)
+}
+
+func TestDistAggregator(t *testing.T) {
+	var nilDA *targetsAggregator
+	assert.Nil(t, nilDA.record("t1", testDistEM(time.Now(), "t1", 1)))
+
+	da := newTargetsAggregator(10*time.Second, true, false, nil)
+	ts := time.Now()
+
+	// First record triggers an export.
+	aggEM := da.record("t1", testDistEM(ts, "t1", 5, 50))
+	assert.NotNil(t, aggEM)
+	assert.Equal(t, "dist:sum:55|count:2|lb:-Inf,1,10,100|bc:0,1,1,0", aggEM.Metric("latency").String())
+
+	// Not yet time for the next export.
+	assert.Nil(t, da.record("t2", testDistEM(ts.Add(time.Second), "t2", 5, 5, 500)))
+
+	// EventMetrics without distributions are ignored.
+	assert.Nil(t, da.record("t3", metrics.NewEventMetrics(ts.Add(20*time.Second)).AddMetric("total", metrics.NewInt(1))))
+
+	aggEM = da.record("t1", testDistEM(ts.Add(10*time.Second), "t1", 5, 50, 50))
+	assert.NotNil(t, aggEM)
+	assert.Equal(t, "dist:sum:615|count:6|lb:-Inf,1,10,100|bc:0,3,2,1", aggEM.Metric("latency").String())
+	assert.Nil(t, aggEM.Metric("total"), "non-distribution metric in aggregate")
+	assert.Equal(t, AllTargetsDst, aggEM.Label("dst"))
+	assert.Equal(t, "p1", aggEM.Label("probe"))
+
+	// t2 becomes stale; its last distribution is carried over, so that the
+	// aggregate doesn't go back.
+	aggEM = da.record("t1", testDistEM(ts.Add(40*time.Second), "t1", 5, 50, 50, 5))
+	assert.Equal(t, "dist:sum:620|count:7|lb:-Inf,1,10,100|bc:0,4,2,1", aggEM.Metric("latency").String())
+
+	// t1's distribution is reset; its last distribution is carried over too.
+	aggEM = da.record("t1", testDistEM(ts.Add(50*time.Second), "t1", 5))
+	assert.Equal(t, "dist:sum:625|count:8|lb:-Inf,1,10,100|bc:0,5,2,1", aggEM.Metric("latency").String())
+}
+
+func TestCounterAggregator(t *testing.T) {
+	countersEM := func(ts time.Time, dst string, total, success int64) *metrics.EventMetrics {
+		return metrics.NewEventMetrics(ts).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success)).
+			AddLabel("probe", "p1").
+			AddLabel("dst", dst)
+	}
+	checkSums := func(t *testing.T, aggEM *metrics.EventMetrics, total, success int64) {
+		t.Helper()
+		assert.NotNil(t, aggEM)
+		assert.Equal(t, total, aggEM.Metric("total").(metrics.NumValue).Int64(), "total")
+		assert.Equal(t, success, aggEM.Metric("success").(metrics.NumValue).Int64(), "success")
+	}
+
+	ta := newTargetsAggregator(10*time.Second, false, true, nil)
+	ts := time.Now()
+
+	checkSums(t, ta.record("t1", countersEM(ts, "t1", 10, 9)), 10, 9)
+	assert.Nil(t, ta.record("t2", countersEM(ts.Add(time.Second), "t2", 20, 20)))
+
+	aggEM := ta.record("t1", countersEM(ts.Add(10*time.Second), "t1", 20, 18))
+	checkSums(t, aggEM, 40, 38)
+	assert.Equal(t, AllTargetsDst, aggEM.Label("dst"))
+	assert.Equal(t, "p1", aggEM.Label("probe"))
+
+	// Gauge EventMetrics are not summed.
+	gaugeEM := countersEM(ts.Add(11*time.Second), "t3", 100, 100)
+	gaugeEM.Kind = metrics.GAUGE
+	assert.Nil(t, ta.record("t3", gaugeEM))
+
+	// Gauges in cumulative EventMetrics are not summed either.
+	em := countersEM(ts.Add(12*time.Second), "t4", 0, 0).
+		AddMetric(StableStateMetricName, metrics.NewInt(1)).
+		AddMetric(AvailabilityMetricName, metrics.NewFloat(0.5))
+	assert.Nil(t, ta.record("t4", em))
+	assert.NotContains(t, ta.targets["t4"].counters, StableStateMetricName)
+	assert.NotContains(t, ta.targets["t4"].counters, AvailabilityMetricName)
+	delete(ta.targets, "t4")
+
+	// t2 goes away; its last values are carried over.
+	checkSums(t, ta.record("t1", countersEM(ts.Add(40*time.Second), "t1", 30, 27)), 50, 47)
+
+	// t2 comes back with fresh counters.
+	checkSums(t, ta.record("t2", countersEM(ts.Add(50*time.Second), "t2", 5, 5)), 55, 52)
+
+	// t1's counters are reset.
+	checkSums(t, ta.record("t1", countersEM(ts.Add(60*time.Second), "t1", 1, 1)), 56, 53)
+}
+
+func TestRecordMetricsWithAggregate(t *testing.T) {
+	ep := endpoint.Endpoint{Name: "t1"}
+	opts := DefaultOptions()
+	opts.targetsAggregator = newTargetsAggregator(10*time.Second, true, false, nil)
+	opts.AdditionalLabels = []*AdditionalLabel{
+		{Key: "env", staticValue: "prod"},
+		{Key: "dc", valueForTarget: map[string]string{ep.Key(): "dc1"}},
+	}
+
+	dataChan := make(chan *metrics.EventMetrics, 3)
+	opts.RecordMetrics(ep, testDistEM(time.Now(), "t1", 5), dataChan)
+
+	assert.Len(t, dataChan, 2)
+	em, aggEM := <-dataChan, <-dataChan
+	assert.Equal(t, "dc1", em.Label("dc"))
+	assert.Equal(t, AllTargetsDst, aggEM.Label("dst"))
+	assert.Equal(t, "prod", aggEM.Label("env"))
+	assert.Equal(t, "", aggEM.Label("dc"))
+}
+
+func TestRecordMetricsAggregateSkipsGauges(t *testing.T) {
+	opts := DefaultOptions()
+	opts.stableState = newStableStateTracker(2, time.Minute)
+	opts.targetsAggregator = newTargetsAggregator(10*time.Second, false, true, nil)
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(4)).
+		AddMetric("success", metrics.NewInt(4)).
+		AddLabel("dst", "t1")
+
+	dataChan := make(chan *metrics.EventMetrics, 3)
+	opts.RecordMetrics(endpoint.Endpoint{Name: "t1"}, em, dataChan)
+
+	// Probe's EventMetrics, stable state gauge, and the aggregate.
+	assert.Len(t, dataChan, 3)
+	<-dataChan
+	<-dataChan
+	aggEM := <-dataChan
+	assert.Equal(t, AllTargetsDst, aggEM.Label("dst"))
+	assert.Equal(t, []string{"total", "success"}, aggEM.MetricsKeys())
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+)
+
+// AvailabilityMetricName is the name of the rolling availability metric: the
+// ratio of successful runs to total runs over the availability window.
+const AvailabilityMetricName = "availability"
+
+type availabilityBucket struct {
+	start          time.Time
+	total, success int64
+}
+
+type targetAvailability struct {
+	lastTotal, lastSuccess int64
+
+	// Buckets in the window, oldest first.
+	buckets []availabilityBucket
+
+	lastSeen, lastComputed time.Time
+	value                  float64
+	valid                  bool
+}
+
+// availabilityTracker computes the rolling availability of the targets from
+// their total and success counters.
+type availabilityTracker struct {
+	window, updateInterval time.Duration
+
+	mu      sync.Mutex
+	targets map[string]*targetAvailability
+	lastGC  time.Time
+}
+
+func newAvailabilityTracker(c *configpb.ProbeDef_Availability) (*availabilityTracker, error) {
+	if c == nil {
+		return nil, nil
+	}
+	if c.GetWindowSec() <= 0 || c.GetUpdateIntervalSec() <= 0 || c.GetUpdateIntervalSec() > c.GetWindowSec() {
+		return nil, fmt.Errorf("invalid availability config: window_sec (%d) and update_interval_sec (%d) should be positive, and update_interval_sec should not be larger than window_sec", c.GetWindowSec(), c.GetUpdateIntervalSec())
+	}
+	return &availabilityTracker{
+		window:         time.Duration(c.GetWindowSec()) * time.Second,
+		updateInterval: time.Duration(c.GetUpdateIntervalSec()) * time.Second,
+		targets:        make(map[string]*targetAvailability),
+	}, nil
+}
+
+// compute drops the buckets that have moved out of the window and recomputes
+// the availability.
+func (at *availabilityTracker) compute(ta *targetAvailability, now time.Time) {
+	windowStart := now.Add(-at.window)
+	i := 0
+	for i < len(ta.buckets) && !ta.buckets[i].start.Add(at.updateInterval).After(windowStart) {
+		i++
+	}
+	ta.buckets = ta.buckets[i:]
+
+	var total, success int64
+	for _, b := range ta.buckets {
+		total, success = total+b.total, success+b.success
+	}
+	ta.valid = total > 0
+	if ta.valid {
+		ta.value = float64(success) / float64(total)
+	}
+	ta.lastComputed = now
+}
+
+// update records the target's total and success counters at the given time,
+// and returns its availability. Second return value is false if there have
+// been no runs in the window.
+func (at *availabilityTracker) update(key string, total, success int64, now time.Time) (float64, bool) {
+	at.mu.Lock()
+	defer at.mu.Unlock()
+
+	at.gc(now)
+
+	ta := at.targets[key]
+	// Counters went back, e.g. target was removed and re-added: start afresh.
+	if ta == nil || total < ta.lastTotal || success < ta.lastSuccess {
+		ta = &targetAvailability{}
+		at.targets[key] = ta
+	}
+
+	newRuns, newSuccess := total-ta.lastTotal, success-ta.lastSuccess
+	ta.lastTotal, ta.lastSuccess = total, success
+	ta.lastSeen = now
+
+	if newRuns > 0 {
+		start := now.Truncate(at.updateInterval)
+		if n := len(ta.buckets); n > 0 && ta.buckets[n-1].start.Equal(start) {
+			ta.buckets[n-1].total += newRuns
+			ta.buckets[n-1].success += newSuccess
+		} else {
+			ta.buckets = append(ta.buckets, availabilityBucket{start: start, total: newRuns, success: newSuccess})
+		}
+	}
+
+	if !ta.valid || now.Sub(ta.lastComputed) >= at.updateInterval {
+		at.compute(ta, now)
+	}
+	return ta.value, ta.valid
+}
+
+// gc discards the state of the targets that haven't reported for a full
+// window. It runs at most once per window.
+func (at *availabilityTracker) gc(now time.Time) {
+	if now.Sub(at.lastGC) < at.window {
+		return
+	}
+	at.lastGC = now
+	for key, ta := range at.targets {
+		if now.Sub(ta.lastSeen) >= at.window {
+			delete(at.targets, key)
+		}
+	}
+}
+
+// record adds the availability metric to the gauge EventMetrics (gaugeEM),
+// if the probe's EventMetrics (em) has the total and success metrics.
+func (at *availabilityTracker) record(key string, em, gaugeEM *metrics.EventMetrics) {
+	if at == nil {
+		return
+	}
+
+	total, ok := em.Metric("total").(metrics.NumValue)
+	if !ok {
+		return
+	}
+	success, ok := em.Metric("success").(metrics.NumValue)
+	if !ok {
+		return
+	}
+
+	if v, ok := at.update(key, total.Int64(), success.Int64(), em.Timestamp); ok {
+		gaugeEM.AddMetric(AvailabilityMetricName, metrics.NewFloat(v))
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewAvailabilityTracker(t *testing.T) {
+	at, err := newAvailabilityTracker(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, at)
+
+	for _, c := range []*configpb.ProbeDef_Availability{
+		{WindowSec: proto.Int32(0)},
+		{UpdateIntervalSec: proto.Int32(-1)},
+		{WindowSec: proto.Int32(60), UpdateIntervalSec: proto.Int32(120)},
+	} {
+		_, err := newAvailabilityTracker(c)
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+func TestAvailabilityTracker(t *testing.T) {
+	at, err := newAvailabilityTracker(&configpb.ProbeDef_Availability{
+		WindowSec:         proto.Int32(300),
+		UpdateIntervalSec: proto.Int32(60),
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Each step is the time offset and the result of new runs.
+	steps := []struct {
+		offset               time.Duration
+		newTotal, newSuccess int64
+		reset                bool
+		want                 float64
+		wantOK               bool
+	}{
+		{offset: 0}, // No runs yet
+		{offset: 10 * time.Second, newTotal: 4, newSuccess: 4, want: 1, wantOK: true},               // 4/4
+		{offset: 30 * time.Second, newTotal: 4, newSuccess: 0, want: 1, wantOK: true},               // Not recomputed yet
+		{offset: 70 * time.Second, newTotal: 2, newSuccess: 2, want: 0.6, wantOK: true},             // 6/10
+		{offset: 360 * time.Second, want: 1, wantOK: true},                                          // First bucket out: 2/2
+		{offset: 430 * time.Second},                                                                 // All buckets out
+		{offset: 440 * time.Second, newTotal: 1, newSuccess: 0, want: 0, wantOK: true},              // 0/1
+		{offset: 450 * time.Second, reset: true, newTotal: 3, newSuccess: 3, want: 1, wantOK: true}, // Reset: 3/3
+	}
+
+	var total, success int64
+	for i, step := range steps {
+		if step.reset {
+			total, success = 0, 0
+		}
+		total, success = total+step.newTotal, success+step.newSuccess
+		got, ok := at.update("t1", total, success, start.Add(step.offset))
+		assert.Equal(t, step.wantOK, ok, "step %d", i)
+		if ok {
+			assert.InDelta(t, step.want, got, 1e-9, "step %d", i)
+		}
+	}
+
+	// Other target is tracked independently.
+	v, ok := at.update("t2", 2, 1, start.Add(450*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 0.5, v)
+
+	// t2 stops reporting, and is garbage collected after a window.
+	at.update("t1", total, success, start.Add(1000*time.Second))
+	assert.Len(t, at.targets, 1)
+	assert.NotNil(t, at.targets["t1"])
+}
+
+func TestAvailabilityRecord(t *testing.T) {
+	var at *availabilityTracker
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(4)).
+		AddMetric("success", metrics.NewInt(3))
+
+	gaugeEM := metrics.NewEventMetrics(time.Now())
+
+	// Nil tracker is a no-op.
+	at.record("t1", em, gaugeEM)
+	assert.Nil(t, gaugeEM.Metric(AvailabilityMetricName))
+
+	at, _ = newAvailabilityTracker(&configpb.ProbeDef_Availability{})
+	at.record("t1", em, gaugeEM)
+	assert.Equal(t, "0.750", gaugeEM.Metric(AvailabilityMetricName).String())
+	assert.Nil(t, em.Metric(AvailabilityMetricName), "availability added to the probe's EventMetrics")
+
+	// No success metric.
+	em = metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(4))
+	gaugeEM = metrics.NewEventMetrics(time.Now())
+	at.record("t2", em, gaugeEM)
+	assert.Nil(t, gaugeEM.Metric(AvailabilityMetricName))
+}
+
+func TestRecordMetricsWithAvailability(t *testing.T) {
+	opts := DefaultOptions()
+	opts.stableState = newStableStateTracker(2, time.Minute)
+	opts.availability, _ = newAvailabilityTracker(&configpb.ProbeDef_Availability{})
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(4)).
+		AddMetric("success", metrics.NewInt(4)).
+		AddLabel("dst", "t1")
+
+	dataChan := make(chan *metrics.EventMetrics, 3)
+	opts.RecordMetrics(endpoint.Endpoint{Name: "t1"}, em, dataChan)
+
+	// Both the gauges are exported in one GAUGE EventMetrics.
+	assert.Len(t, dataChan, 2)
+	em, gaugeEM := <-dataChan, <-dataChan
+	assert.Nil(t, em.Metric(AvailabilityMetricName))
+	assert.Equal(t, metrics.Kind(metrics.GAUGE), gaugeEM.Kind)
+	assert.Equal(t, []string{StableStateMetricName, AvailabilityMetricName}, gaugeEM.MetricsKeys())
+	assert.Equal(t, "1.000", gaugeEM.Metric(AvailabilityMetricName).String())
+	assert.Equal(t, "t1", gaugeEM.Label("dst"))
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// SkippedUnchangedMetricName is the name of the metric that counts runs
+// skipped because the target didn't change since it was last probed.
+const SkippedUnchangedMetricName = "skipped_unchanged"
+
+type probedState struct {
+	lastUpdated time.Time
+	lastRun     time.Time
+}
+
+// changedTargetsTracker keeps track of the targets' last_updated timestamps,
+// and when they were last probed. Labels and IP are part of the target key,
+// so a change in those shows up as a new target.
+type changedTargetsTracker struct {
+	fullSweepInterval time.Duration
+
+	mu          sync.Mutex
+	lastUpdated map[string]time.Time // Latest last_updated, keyed by target key.
+	probed      map[string]*probedState
+}
+
+func newChangedTargetsTracker(fullSweepInterval time.Duration) *changedTargetsTracker {
+	return &changedTargetsTracker{
+		fullSweepInterval: fullSweepInterval,
+		lastUpdated:       make(map[string]time.Time),
+		probed:            make(map[string]*probedState),
+	}
+}
+
+// ProbeChangedTargetsOnly returns true if probe should run only for the
+// changed targets.
+func (opts *Options) ProbeChangedTargetsOnly() bool {
+	return opts.changedTargets != nil
+}
+
+// UpdateTargets records the latest state of the targets. Probes call it
+// whenever they refresh their targets list.
+func (opts *Options) UpdateTargets(eps []endpoint.Endpoint) {
+	ct := opts.changedTargets
+	if ct == nil {
+		return
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	active := make(map[string]bool, len(eps))
+	for _, ep := range eps {
+		key := ep.Key()
+		active[key] = true
+		ct.lastUpdated[key] = ep.LastUpdated
+	}
+	for key := range ct.lastUpdated {
+		if !active[key] {
+			delete(ct.lastUpdated, key)
+			delete(ct.probed, key)
+		}
+	}
+}
+
+// SkipUnchanged returns true if the probe run at the given time should be
+// skipped because the target hasn't changed since it was last probed, and
+// full sweep interval hasn't passed yet. If it returns false, target is
+// recorded as probed at the given time.
+func (opts *Options) SkipUnchanged(ep endpoint.Endpoint, ts time.Time) bool {
+	ct := opts.changedTargets
+	if ct == nil {
+		return false
+	}
+
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	key := ep.Key()
+	lastUpdated, ok := ct.lastUpdated[key]
+	if !ok {
+		lastUpdated = ep.LastUpdated
+	}
+
+	ps := ct.probed[key]
+	if ps != nil && ps.lastUpdated.Equal(lastUpdated) && ts.Sub(ps.lastRun) < ct.fullSweepInterval {
+		return true
+	}
+	ct.probed[key] = &probedState{lastUpdated: lastUpdated, lastRun: ts}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSkipUnchanged(t *testing.T) {
+	opts := &Options{}
+	assert.False(t, opts.ProbeChangedTargetsOnly())
+	assert.False(t, opts.SkipUnchanged(endpoint.Endpoint{Name: "t1"}, time.Now()))
+
+	opts.changedTargets = newChangedTargetsTracker(time.Hour)
+	assert.True(t, opts.ProbeChangedTargetsOnly())
+
+	t0 := time.Unix(1700000000, 0)
+	ep1 := endpoint.Endpoint{Name: "t1", LastUpdated: t0}
+	ep2 := endpoint.Endpoint{Name: "t2", LastUpdated: t0}
+	opts.UpdateTargets([]endpoint.Endpoint{ep1, ep2})
+
+	ts := t0.Add(time.Minute)
+	assert.False(t, opts.SkipUnchanged(ep1, ts), "first run")
+	assert.False(t, opts.SkipUnchanged(ep2, ts), "first run")
+	assert.True(t, opts.SkipUnchanged(ep1, ts.Add(time.Minute)), "unchanged")
+
+	// Target t1 is updated. Probe loops hold on to the old endpoint, updated
+	// timestamp comes from the targets refresh.
+	ep1Updated := ep1
+	ep1Updated.LastUpdated = t0.Add(2 * time.Minute)
+	opts.UpdateTargets([]endpoint.Endpoint{ep1Updated, ep2})
+	assert.False(t, opts.SkipUnchanged(ep1, ts.Add(2*time.Minute)), "t1 changed")
+	assert.True(t, opts.SkipUnchanged(ep1, ts.Add(3*time.Minute)), "t1 unchanged since last run")
+	assert.True(t, opts.SkipUnchanged(ep2, ts.Add(3*time.Minute)), "t2 unchanged")
+
+	// Full sweep.
+	assert.False(t, opts.SkipUnchanged(ep2, ts.Add(time.Hour)), "full sweep")
+	assert.True(t, opts.SkipUnchanged(ep2, ts.Add(time.Hour+time.Minute)), "after full sweep")
+
+	// Labels change the target key, i.e. it's a new target.
+	ep2Labeled := ep2
+	ep2Labeled.Labels = map[string]string{"zone": "a"}
+	opts.UpdateTargets([]endpoint.Endpoint{ep1Updated, ep2Labeled})
+	assert.False(t, opts.SkipUnchanged(ep2Labeled, ts.Add(time.Hour+2*time.Minute)), "labels changed")
+	assert.Len(t, opts.changedTargets.probed, 2, "state for the removed target is dropped")
+}
+
+func TestChangedTargetsOnlyConfig(t *testing.T) {
+	p := &configpb.ProbeDef{
+		Name:         proto.String("test-probe"),
+		Type:         configpb.ProbeDef_HTTP.Enum(),
+		IntervalMsec: proto.Int32(10000),
+		Targets: &targetspb.TargetsDef{
+			Type: &targetspb.TargetsDef_HostNames{HostNames: "t1"},
+		},
+		ChangedTargetsOnly: &configpb.ProbeDef_ChangedTargetsOnly{},
+	}
+	opts, err := BuildProbeOptions(p, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Hour, opts.changedTargets.fullSweepInterval)
+
+	p.ChangedTargetsOnly.FullSweepIntervalSec = proto.Int32(5)
+	_, err = BuildProbeOptions(p, nil, nil, nil)
+	assert.Error(t, err, "full sweep interval smaller than probe interval")
+}
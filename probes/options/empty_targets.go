@@ -0,0 +1,45 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets"
+)
+
+// EmptyTargetsMetricName is the name of the metric that counts the number of
+// times the probe found its targets list empty.
+const EmptyTargetsMetricName = "empty_targets"
+
+// RecordEmptyTargets exports the empty_targets metric, if the probe's targets
+// are configured to treat an empty targets list as an error. Probes call it
+// after refreshing their targets list.
+func (opts *Options) RecordEmptyTargets(ts time.Time, probeName string, dataChan chan<- *metrics.EventMetrics) {
+	count, ok := targets.EmptyTargetsCount(opts.Targets)
+	if !ok {
+		return
+	}
+
+	em := metrics.NewEventMetrics(ts).
+		AddMetric(EmptyTargetsMetricName, metrics.NewInt(count)).
+		AddLabel("probe", probeName)
+	em.Kind = metrics.CUMULATIVE
+	em.MetricsPrefix = opts.MetricsPrefix
+
+	opts.LogMetrics(em)
+	dataChan <- em
+}
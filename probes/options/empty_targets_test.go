@@ -0,0 +1,60 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRecordEmptyTargets(t *testing.T) {
+	dataChan := make(chan *metrics.EventMetrics, 10)
+
+	// Not configured.
+	opts := &Options{Targets: targets.StaticTargets("a")}
+	opts.RecordEmptyTargets(time.Now(), "p1", dataChan)
+	assert.Empty(t, dataChan)
+
+	tgts, err := targets.New(&targetspb.TargetsDef{
+		Type:                &targetspb.TargetsDef_HostNames{HostNames: "a"},
+		Regex:               proto.String("b"),
+		EmptyTargetsAsError: proto.Bool(true),
+	}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	opts = &Options{
+		Targets:       tgts,
+		MetricsPrefix: "test_",
+		LogMetrics:    func(*metrics.EventMetrics) {},
+	}
+	for i := 0; i < 2; i++ {
+		tgts.ListEndpoints()
+	}
+	opts.RecordEmptyTargets(time.Now(), "p1", dataChan)
+
+	require.Len(t, dataChan, 1)
+	em := <-dataChan
+	assert.Equal(t, metrics.Kind(metrics.CUMULATIVE), em.Kind)
+	assert.Equal(t, "p1", em.Label("probe"))
+	assert.Equal(t, "test_", em.MetricsPrefix)
+	assert.Equal(t, int64(2), em.Metric(EmptyTargetsMetricName).(metrics.NumValue).Int64())
+}
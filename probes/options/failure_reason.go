@@ -0,0 +1,148 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+)
+
+// Failure reasons, exported as values of the failure_reason label. These are
+// consistent across probe types.
+const (
+	FailureDNS        = "dns"
+	FailureConnect    = "connect"
+	FailureProxy      = "proxy"
+	FailureTLS        = "tls"
+	FailureTimeout    = "timeout"
+	FailureStatus     = "status"
+	FailureValidation = "validation"
+	FailureOther      = "other"
+
+	// FailureStream is used only by the streaming probes, for the failures
+	// after the stream was established. See NewFailureReasons.
+	FailureStream = "stream"
+)
+
+// FailureReasonLabel is the label used to break down the failures metric.
+const FailureReasonLabel = "failure_reason"
+
+var failureReasons = []string{FailureDNS, FailureConnect, FailureProxy, FailureTLS, FailureTimeout, FailureStatus, FailureValidation, FailureOther}
+
+var failureReasonsSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_HTTP:      true,
+	configpb.ProbeDef_TCP:       true,
+	configpb.ProbeDef_DNS:       true,
+	configpb.ProbeDef_GRPC:      true,
+	configpb.ProbeDef_NTP:       true,
+	configpb.ProbeDef_WEBSOCKET: true,
+	configpb.ProbeDef_QUIC:      true,
+	configpb.ProbeDef_EXTERNAL:  true,
+}
+
+// NewFailureReasons returns a new map to count failures by reason, with all
+// reasons, including the probe-specific extra reasons, initialized to zero.
+// It returns nil if failure reasons are not enabled for the probe.
+func (opts *Options) NewFailureReasons(extraReasons ...string) *metrics.Map[int64] {
+	if !opts.ExportFailureReasons {
+		return nil
+	}
+	m := metrics.NewMap(FailureReasonLabel)
+	for _, reasons := range [][]string{failureReasons, extraReasons} {
+		for _, reason := range reasons {
+			m.IncKeyBy(reason, 0)
+		}
+	}
+	return m
+}
+
+// RecordFailure increments the failure count for the given reason. It's a
+// no-op if m is nil, i.e. if failure reasons are not enabled.
+func RecordFailure(m *metrics.Map[int64], reason string) {
+	if m == nil {
+		return
+	}
+	m.IncKey(reason)
+}
+
+func isTLSError(err error) bool {
+	var (
+		certVerifyErr   *tls.CertificateVerificationError
+		recordHeaderErr tls.RecordHeaderError
+		alertErr        tls.AlertError
+		unknownAuthErr  x509.UnknownAuthorityError
+		hostnameErr     x509.HostnameError
+		certInvalidErr  x509.CertificateInvalidError
+	)
+	if errors.As(err, &certVerifyErr) || errors.As(err, &recordHeaderErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &unknownAuthErr) || errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) {
+		return true
+	}
+	// Not all TLS errors are typed.
+	return strings.Contains(err.Error(), "tls: ")
+}
+
+// ClassifyError maps an error, returned while making a request to the
+// target, to one of the standard failure reasons.
+func ClassifyError(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return FailureDNS
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return FailureTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return FailureTimeout
+	}
+
+	// HTTP transport wraps errors in connecting to the proxy in a
+	// "proxyconnect" OpError. HTTP probe wraps the CONNECT request
+	// rejections the same way.
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+		return FailureProxy
+	}
+
+	if isTLSError(err) {
+		return FailureTLS
+	}
+
+	if opErr != nil && opErr.Op == "dial" {
+		return FailureConnect
+	}
+	for _, errno := range []syscall.Errno{syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.EHOSTUNREACH, syscall.ENETUNREACH} {
+		if errors.Is(err, errno) {
+			return FailureConnect
+		}
+	}
+
+	return FailureOther
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "dns", err: fmt.Errorf("dial: %w", &net.DNSError{Err: "no such host", Name: "x.test"}), want: FailureDNS},
+		{name: "deadline", err: fmt.Errorf("get: %w", context.DeadlineExceeded), want: FailureTimeout},
+		{name: "os-deadline", err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, want: FailureTimeout},
+		{name: "tls-unknown-authority", err: fmt.Errorf("get: %w", x509.UnknownAuthorityError{}), want: FailureTLS},
+		{name: "tls-untyped", err: errors.New("remote error: tls: handshake failure"), want: FailureTLS},
+		{name: "dial", err: &net.OpError{Op: "dial", Err: errors.New("some error")}, want: FailureConnect},
+		{name: "proxy", err: &net.OpError{Op: "proxyconnect", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, want: FailureProxy},
+		{name: "conn-reset", err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, want: FailureConnect},
+		{name: "other", err: errors.New("unexpected EOF"), want: FailureOther},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ClassifyError(test.err))
+		})
+	}
+}
+
+func TestNewFailureReasons(t *testing.T) {
+	opts := &Options{}
+	assert.Nil(t, opts.NewFailureReasons())
+	RecordFailure(nil, FailureDNS) // Should not panic.
+
+	opts.ExportFailureReasons = true
+	m := opts.NewFailureReasons()
+	assert.ElementsMatch(t, failureReasons, m.Keys())
+	RecordFailure(m, FailureTimeout)
+	assert.Equal(t, int64(1), m.GetKey(FailureTimeout))
+	assert.Equal(t, "map:failure_reason,connect:0,dns:0,other:0,proxy:0,status:0,timeout:1,tls:0,validation:0", m.String())
+
+	m = opts.NewFailureReasons(FailureStream)
+	assert.ElementsMatch(t, append([]string{FailureStream}, failureReasons...), m.Keys())
+}
+
+func TestFailureReasonsSupport(t *testing.T) {
+	for ptype, wantErr := range map[configpb.ProbeDef_Type]bool{
+		configpb.ProbeDef_HTTP: false,
+		configpb.ProbeDef_UDP:  true,
+	} {
+		p := &configpb.ProbeDef{
+			Name:                 proto.String("test"),
+			Type:                 ptype.Enum(),
+			Targets:              &targetspb.TargetsDef{Type: &targetspb.TargetsDef_DummyTargets{}},
+			ExportFailureReasons: proto.Bool(true),
+		}
+		opts, err := BuildProbeOptions(p, nil, nil, nil)
+		if wantErr {
+			assert.Error(t, err, "probe type: %v", ptype)
+			continue
+		}
+		assert.NoError(t, err, "probe type: %v", ptype)
+		assert.True(t, opts.ExportFailureReasons)
+	}
+}
@@ -110,6 +110,20 @@ func (al *AdditionalLabel) KeyValueForTarget(ep endpoint.Endpoint) (key, val str
 	return al.Key, al.valueForTarget[ep.Key()]
 }
 
+// TypedValueForTarget returns the typed value of the label for the given
+// target, if label's value is derived entirely from a typed target label,
+// e.g. "@target.label.weight@".
+func (al *AdditionalLabel) TypedValueForTarget(ep endpoint.Endpoint) (any, bool) {
+	if len(ep.TypedLabels) == 0 || len(al.tokens) != 1 || al.tokens[0].tokenType != label {
+		return nil, false
+	}
+	if len(al.valueParts) != 3 || al.valueParts[0] != "" || al.valueParts[2] != "" {
+		return nil, false
+	}
+	v, ok := ep.TypedLabels[al.tokens[0].labelKey]
+	return v, ok
+}
+
 // ParseAdditionalLabel parses an additional label proto message into an
 // AdditionalLabel struct.
 func ParseAdditionalLabel(alpb *configpb.AdditionalLabel) *AdditionalLabel {
@@ -176,3 +176,31 @@ func TestUpdateAdditionalLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestAdditionalLabelTypedValue(t *testing.T) {
+	ep := endpoint.Endpoint{
+		Name:        "target1",
+		Labels:      map[string]string{"weight": "10", "zone": "a"},
+		TypedLabels: map[string]any{"weight": int64(10)},
+	}
+
+	for _, test := range []struct {
+		value     string
+		wantValue any
+		wantOK    bool
+	}{
+		{value: "@target.label.weight@", wantValue: int64(10), wantOK: true},
+		{value: "w-@target.label.weight@", wantOK: false},
+		{value: "@target.label.zone@", wantOK: false},
+		{value: "static", wantOK: false},
+	} {
+		al := ParseAdditionalLabel(&configpb.AdditionalLabel{
+			Key:   proto.String("w"),
+			Value: proto.String(test.value),
+		})
+		v, ok := al.TypedValueForTarget(ep)
+		if ok != test.wantOK || !reflect.DeepEqual(v, test.wantValue) {
+			t.Errorf("TypedValueForTarget(%s)=%v,%v; want=%v,%v", test.value, v, ok, test.wantValue, test.wantOK)
+		}
+	}
+}
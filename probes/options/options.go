@@ -20,11 +20,14 @@ package options
 import (
 	"fmt"
 	"log/slog"
+	"math/rand"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/alerting"
+	"github.com/cloudprober/cloudprober/internal/sockopt"
 	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
@@ -41,6 +44,7 @@ type Options struct {
 	Logger              *logger.Logger
 	ProbeConf           interface{} // Probe-type specific config
 	LatencyDist         *metrics.Distribution
+	LatencySummary      *metrics.Summary
 	LatencyUnit         time.Duration
 	LatencyMetricName   string
 	Validators          []*validators.Validator
@@ -51,7 +55,29 @@ type Options struct {
 	AdditionalLabels    []*AdditionalLabel
 	Schedule            *Schedule
 	NegativeTest        bool
+	DSCP                int
+	RateLimiter         *RateLimiter
+	MetricsPrefix       string
+	addPortLabel        bool
+	stableState         *stableStateTracker
+	availability        *availabilityTracker
+	targetsAggregator   *targetsAggregator
+	changedTargets      *changedTargetsTracker
+	validatorSets       *validatorSets
+	runs                *runTracker
 	AlertHandlers       []*alerting.AlertHandler
+
+	// ExportFailureReasons, if set, probes export failures broken down by
+	// the failure reason. See NewFailureReasons and ClassifyError.
+	ExportFailureReasons bool
+
+	// RandomizeTargetOrder, if set, probes shuffle the order in which they
+	// probe targets in every cycle. See ShuffleTargets.
+	RandomizeTargetOrder bool
+
+	// ExportValidationSuccess, if set, probes export successful validations
+	// per validator. See NewValidationSuccess.
+	ExportValidationSuccess bool
 }
 
 const defaultStatsExtportIntv = 10 * time.Second
@@ -63,6 +89,29 @@ var negativeTestSupported = map[configpb.ProbeDef_Type]bool{
 	configpb.ProbeDef_PING: true,
 }
 
+var randomizeTargetOrderSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_PING: true,
+	configpb.ProbeDef_UDP:  true,
+	configpb.ProbeDef_HTTP: true,
+}
+
+var validationSuccessSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_HTTP:      true,
+	configpb.ProbeDef_DNS:       true,
+	configpb.ProbeDef_GRPC:      true,
+	configpb.ProbeDef_EXTERNAL:  true,
+	configpb.ProbeDef_PING:      true,
+	configpb.ProbeDef_WEBSOCKET: true,
+}
+
+var dscpSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_TCP:       true,
+	configpb.ProbeDef_UDP:       true,
+	configpb.ProbeDef_PING:      true,
+	configpb.ProbeDef_NTP:       true,
+	configpb.ProbeDef_WEBSOCKET: true,
+}
+
 func defaultStatsExportInterval(p *configpb.ProbeDef, opts *Options) time.Duration {
 	minIntv := opts.Interval
 	if opts.Timeout > opts.Interval {
@@ -159,13 +208,48 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 		return nil, fmt.Errorf("negative_test is not supported by %s probes", p.GetType().String())
 	}
 
+	if p.GetExportFailureReasons() && !failureReasonsSupported[p.GetType()] {
+		return nil, fmt.Errorf("export_failure_reasons is not supported by %s probes", p.GetType().String())
+	}
+
+	if p.GetRandomizeTargetOrder() && !randomizeTargetOrderSupported[p.GetType()] {
+		return nil, fmt.Errorf("randomize_target_order is not supported by %s probes", p.GetType().String())
+	}
+
+	if p.GetExportValidationSuccess() && !validationSuccessSupported[p.GetType()] {
+		return nil, fmt.Errorf("export_validation_success is not supported by %s probes", p.GetType().String())
+	}
+
+	// UDP_LISTENER probe only responds to the incoming packets.
+	if p.MaxRequestsPerSec != nil && p.GetType() == configpb.ProbeDef_UDP_LISTENER {
+		return nil, fmt.Errorf("max_requests_per_sec is not supported by %s probes", p.GetType().String())
+	}
+
+	if p.Dscp != nil {
+		if !dscpSupported[p.GetType()] {
+			return nil, fmt.Errorf("dscp is not supported by %s probes", p.GetType().String())
+		}
+		if err := sockopt.ValidateDSCP(int(p.GetDscp())); err != nil {
+			return nil, err
+		}
+	}
+
 	opts := &Options{
 		Interval:          intervalDuration,
 		Timeout:           timeoutDuration,
 		IPVersion:         ipv(p.IpVersion),
 		LatencyMetricName: p.GetLatencyMetricName(),
 		NegativeTest:      p.GetNegativeTest(),
+		DSCP:              int(p.GetDscp()),
+		RateLimiter:       newRateLimiter(float64(p.GetMaxRequestsPerSec())),
+		runs:              &runTracker{},
+		MetricsPrefix:     p.GetMetricsPrefix(),
 		Logger:            logger.NewWithAttrs(slog.String("probe", p.GetName())),
+
+		ExportFailureReasons: p.GetExportFailureReasons(),
+		RandomizeTargetOrder: p.GetRandomizeTargetOrder(),
+
+		ExportValidationSuccess: p.GetExportValidationSuccess(),
 	}
 
 	if p.GetTargets() == nil {
@@ -181,6 +265,8 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 	if opts.Targets, err = targets.New(p.GetTargets(), ldLister, globalTargetsOpts, l, opts.Logger); err != nil {
 		return nil, err
 	}
+	// Targets expanded from the same resource differ only in their port.
+	opts.addPortLabel = p.GetTargets().GetPortsLabel() != ""
 
 	if latencyDist := p.GetLatencyDistribution(); latencyDist != nil {
 		var d *metrics.Distribution
@@ -190,6 +276,15 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 		opts.LatencyDist = d
 	}
 
+	if latencySummary := p.GetLatencySummary(); latencySummary != nil {
+		if opts.LatencyDist != nil {
+			return nil, fmt.Errorf("latency_summary cannot be used together with latency_distribution")
+		}
+		if opts.LatencySummary, err = metrics.NewSummaryFromProto(latencySummary); err != nil {
+			return nil, fmt.Errorf("error creating summary from the specification (%v): %v", latencySummary, err)
+		}
+	}
+
 	// latency_unit is specified as a human-readable string, e.g. ns, ms, us etc.
 	if opts.LatencyUnit, err = time.ParseDuration("1" + p.GetLatencyUnit()); err != nil {
 		return nil, fmt.Errorf("failed to parse the latency unit (%s): %v", p.GetLatencyUnit(), err)
@@ -202,6 +297,10 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 		}
 	}
 
+	if opts.validatorSets, err = newValidatorSets(p, opts.Logger); err != nil {
+		return nil, err
+	}
+
 	if p.GetSourceIpConfig() != nil {
 		opts.SourceIP, err = getSourceIPFromConfig(p, l)
 		if err != nil {
@@ -224,6 +323,24 @@ func BuildProbeOptions(p *configpb.ProbeDef, ldLister endpoint.Lister, globalTar
 
 	opts.AdditionalLabels = parseAdditionalLabels(p)
 
+	opts.stableState = newStableStateTracker(int(p.GetStableStateRuns()), staleTargetIntervals*opts.StatsExportInterval)
+
+	if opts.availability, err = newAvailabilityTracker(p.GetAvailability()); err != nil {
+		return nil, err
+	}
+
+	if p.GetAggregateDistributionsAcrossTargets() || p.GetAggregateCountersAcrossTargets() {
+		opts.targetsAggregator = newTargetsAggregator(opts.StatsExportInterval, p.GetAggregateDistributionsAcrossTargets(), p.GetAggregateCountersAcrossTargets(), opts.Logger)
+	}
+
+	if ct := p.GetChangedTargetsOnly(); ct != nil {
+		fullSweepInterval := time.Duration(ct.GetFullSweepIntervalSec()) * time.Second
+		if fullSweepInterval < opts.Interval {
+			return nil, fmt.Errorf("changed_targets_only.full_sweep_interval_sec (%d) smaller than probe interval %v", ct.GetFullSweepIntervalSec(), opts.Interval)
+		}
+		opts.changedTargets = newChangedTargetsTracker(fullSweepInterval)
+	}
+
 	for _, alertConf := range p.GetAlert() {
 		ah, err := alerting.NewAlertHandler(alertConf, p.GetName(), opts.Logger)
 		if err != nil {
@@ -283,23 +400,106 @@ func WithNoAlert() RecordOptions {
 	}
 }
 
+// NewLatencyValue returns a new latency metric value, as per the probe's
+// latency configuration: a distribution, a summary or a float.
+func (opts *Options) NewLatencyValue() metrics.LatencyValue {
+	if opts.LatencyDist != nil {
+		return opts.LatencyDist.CloneDist()
+	}
+	if opts.LatencySummary != nil {
+		return opts.LatencySummary.CloneSummary()
+	}
+	return metrics.NewFloat(0)
+}
+
+// NewValidationSuccess returns a new map to count successful validations by
+// validator, for the given validators. It returns nil if validation success
+// export is not enabled, or if there are no validators.
+func (opts *Options) NewValidationSuccess(vs []*validators.Validator) *metrics.Map[int64] {
+	if !opts.ExportValidationSuccess || len(vs) == 0 {
+		return nil
+	}
+	return validators.ValidationSuccessMap(vs)
+}
+
+// ShuffleTargets shuffles the probe cycle's targets using the provided swap
+// function, if randomize_target_order is enabled. It's a no-op otherwise.
+// Shuffle order is different in every call.
+func (opts *Options) ShuffleTargets(n int, swap func(i, j int)) {
+	if !opts.RandomizeTargetOrder {
+		return
+	}
+	rand.Shuffle(n, swap)
+}
+
 func (opts *Options) IsScheduled() bool {
 	return opts.Schedule.isIn(time.Now())
 }
 
 func (opts *Options) RecordMetrics(ep endpoint.Endpoint, em *metrics.EventMetrics, dataChan chan<- *metrics.EventMetrics, ropts ...RecordOptions) {
+	ro := &recordOptions{}
+	for _, ropt := range ropts {
+		ropt(ro)
+	}
+
+	// Aggregate is computed before adding the additional labels, as they
+	// may be target specific. Gauges derived from the counters (stable state
+	// and availability) are exported in a separate GAUGE EventMetrics.
+	var aggEM, gaugeEM *metrics.EventMetrics
+	if !ro.NoAlert {
+		if opts.stableState != nil || opts.availability != nil {
+			gaugeEM = metrics.NewEventMetrics(em.Timestamp)
+			gaugeEM.Kind = metrics.GAUGE
+			opts.stableState.record(ep.Key(), em, gaugeEM)
+			opts.availability.record(ep.Key(), em, gaugeEM)
+		}
+		aggEM = opts.targetsAggregator.record(ep.Key(), em)
+	}
+
 	em.LatencyUnit = opts.LatencyUnit
+	em.MetricsPrefix = opts.MetricsPrefix
 	for _, al := range opts.AdditionalLabels {
 		em.AddLabel(al.KeyValueForTarget(ep))
+		if v, ok := al.TypedValueForTarget(ep); ok {
+			em.SetTypedLabel(al.Key, v)
+		}
+	}
+	if opts.addPortLabel && ep.Port != 0 {
+		em.AddLabel("port", strconv.Itoa(ep.Port))
+	}
+	// Endpoints expanded from a multi-IP resource share the "dst" label, IP
+	// label tells them apart.
+	if ip := ep.ExpandedIP(); ip != "" && em.Label(endpoint.IPLabel) == "" {
+		em.AddLabel(endpoint.IPLabel, ip)
 	}
 
 	opts.LogMetrics(em)
 	dataChan <- em.Clone()
 
-	ro := &recordOptions{}
-	for _, ropt := range ropts {
-		ropt(ro)
+	if gaugeEM != nil && len(gaugeEM.MetricsKeys()) > 0 {
+		gaugeEM.MetricsPrefix = em.MetricsPrefix
+		for _, k := range em.LabelsKeys() {
+			gaugeEM.AddLabel(k, em.Label(k))
+			if v, ok := em.TypedLabel(k); ok {
+				gaugeEM.SetTypedLabel(k, v)
+			}
+		}
+		opts.LogMetrics(gaugeEM)
+		dataChan <- gaugeEM
 	}
+
+	if aggEM != nil {
+		aggEM.LatencyUnit, aggEM.MetricsPrefix = em.LatencyUnit, em.MetricsPrefix
+		// Only static additional labels apply to the aggregate.
+		for _, al := range opts.AdditionalLabels {
+			if al.staticValue != "" {
+				aggEM.AddLabel(al.Key, al.staticValue)
+			}
+		}
+		opts.LogMetrics(aggEM)
+		dataChan <- aggEM
+	}
+
 	if !ro.NoAlert {
 		for _, ah := range opts.AlertHandlers {
 			ah.Record(ep, em)
@@ -17,15 +17,19 @@ package options
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"net"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/cloudprober/cloudprober/common/iputils"
 	"github.com/cloudprober/cloudprober/internal/alerting"
 	alerting_configpb "github.com/cloudprober/cloudprober/internal/alerting/proto"
+	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
 	configpb "github.com/cloudprober/cloudprober/probes/proto"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 	targetspb "github.com/cloudprober/cloudprober/targets/proto"
@@ -425,6 +429,25 @@ func TestRecordMetrics(t *testing.T) {
 	}
 }
 
+func TestRecordMetricsPortLabel(t *testing.T) {
+	for _, addPortLabel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("addPortLabel=%v", addPortLabel), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.addPortLabel = addPortLabel
+
+			dataChan := make(chan *metrics.EventMetrics, 1)
+			em := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1))
+			opts.RecordMetrics(endpoint.Endpoint{Name: "t1", Port: 443}, em, dataChan)
+
+			wantPort := ""
+			if addPortLabel {
+				wantPort = "443"
+			}
+			assert.Equal(t, wantPort, (<-dataChan).Label("port"))
+		})
+	}
+}
+
 func TestNilTargets(t *testing.T) {
 	tests := []struct {
 		cfg           *configpb.ProbeDef
@@ -485,3 +508,136 @@ func TestNilTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestDSCPConfig(t *testing.T) {
+	tests := []struct {
+		ptype    configpb.ProbeDef_Type
+		dscp     int32
+		wantDSCP int
+		wantErr  bool
+	}{
+		{ptype: configpb.ProbeDef_TCP, dscp: 46, wantDSCP: 46},
+		{ptype: configpb.ProbeDef_UDP, dscp: 10, wantDSCP: 10},
+		{ptype: configpb.ProbeDef_PING, dscp: 0, wantDSCP: 0},
+		{ptype: configpb.ProbeDef_PING, dscp: 64, wantErr: true},
+		{ptype: configpb.ProbeDef_HTTP, dscp: 46, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.ptype.String()+"_"+strconv.Itoa(int(test.dscp)), func(t *testing.T) {
+			opts, err := BuildProbeOptions(&configpb.ProbeDef{
+				Type:    test.ptype.Enum(),
+				Targets: testTargets,
+				Dscp:    proto.Int32(test.dscp),
+			}, nil, nil, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("BuildProbeOptions() error = %v, wantErr = %v", err, test.wantErr)
+			}
+			if err == nil && opts.DSCP != test.wantDSCP {
+				t.Errorf("opts.DSCP = %d, want = %d", opts.DSCP, test.wantDSCP)
+			}
+		})
+	}
+}
+
+func TestRandomizeTargetOrder(t *testing.T) {
+	for _, ptype := range []configpb.ProbeDef_Type{configpb.ProbeDef_PING, configpb.ProbeDef_UDP, configpb.ProbeDef_HTTP, configpb.ProbeDef_TCP} {
+		t.Run(ptype.String(), func(t *testing.T) {
+			opts, err := BuildProbeOptions(&configpb.ProbeDef{
+				Type:                 ptype.Enum(),
+				Targets:              testTargets,
+				RandomizeTargetOrder: proto.Bool(true),
+			}, nil, nil, nil)
+			if !randomizeTargetOrderSupported[ptype] {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, opts.RandomizeTargetOrder)
+		})
+	}
+
+	var keys []string
+	for i := 0; i < 20; i++ {
+		keys = append(keys, strconv.Itoa(i))
+	}
+	shuffle := func(opts *Options) []string {
+		s := append([]string{}, keys...)
+		opts.ShuffleTargets(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+		return s
+	}
+
+	// Order is preserved by default.
+	assert.Equal(t, keys, shuffle(&Options{}))
+
+	// Order changes across cycles, and no targets are lost.
+	opts := &Options{RandomizeTargetOrder: true}
+	s1, s2 := shuffle(opts), shuffle(opts)
+	assert.ElementsMatch(t, keys, s1)
+	assert.ElementsMatch(t, keys, s2)
+	assert.NotEqual(t, s1, s2)
+}
+
+func TestValidationSuccess(t *testing.T) {
+	for _, ptype := range []configpb.ProbeDef_Type{configpb.ProbeDef_HTTP, configpb.ProbeDef_DNS, configpb.ProbeDef_UDP} {
+		t.Run(ptype.String(), func(t *testing.T) {
+			opts, err := BuildProbeOptions(&configpb.ProbeDef{
+				Type:                    ptype.Enum(),
+				Targets:                 testTargets,
+				ExportValidationSuccess: proto.Bool(true),
+			}, nil, nil, nil)
+			if !validationSuccessSupported[ptype] {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, opts.ExportValidationSuccess)
+		})
+	}
+
+	vs := []*validators.Validator{{Name: "v1"}, {Name: "v2"}}
+	assert.Nil(t, (&Options{}).NewValidationSuccess(vs))
+	assert.Nil(t, (&Options{ExportValidationSuccess: true}).NewValidationSuccess(nil))
+	assert.Equal(t, "map:validator,v1:0,v2:0", (&Options{ExportValidationSuccess: true}).NewValidationSuccess(vs).String())
+}
+
+func TestLatencySummary(t *testing.T) {
+	summaryConf := &distpb.Summary{Quantiles: []float64{0.5, 0.99}}
+
+	opts, err := BuildProbeOptions(&configpb.ProbeDef{
+		Type:           configpb.ProbeDef_HTTP.Enum(),
+		Targets:        testTargets,
+		LatencySummary: summaryConf,
+	}, nil, nil, nil)
+	assert.NoError(t, err)
+	if assert.NotNil(t, opts.LatencySummary) {
+		assert.IsType(t, &metrics.Summary{}, opts.NewLatencyValue())
+		assert.NotSame(t, opts.LatencySummary, opts.NewLatencyValue())
+	}
+
+	_, err = BuildProbeOptions(&configpb.ProbeDef{
+		Type:                configpb.ProbeDef_HTTP.Enum(),
+		Targets:             testTargets,
+		LatencySummary:      summaryConf,
+		LatencyDistribution: &distpb.Dist{Buckets: &distpb.Dist_ExplicitBuckets{ExplicitBuckets: "1,2,4"}},
+	}, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRecordMetricsExpandedIP(t *testing.T) {
+	opts := DefaultOptions()
+	dataChan := make(chan *metrics.EventMetrics, 2)
+
+	ep := endpoint.Endpoint{Name: "web-1"}
+	for _, e := range ep.ExpandIPs([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")}) {
+		em := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1)).AddLabel("dst", e.Name)
+		opts.RecordMetrics(e, em, dataChan)
+	}
+	assert.Equal(t, "10.0.0.1", (<-dataChan).Label("ip"))
+	assert.Equal(t, "10.0.0.2", (<-dataChan).Label("ip"))
+
+	// Not added for regular endpoints.
+	em := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1))
+	opts.RecordMetrics(endpoint.Endpoint{Name: "web-2", IP: net.ParseIP("10.0.0.3")}, em, dataChan)
+	assert.Equal(t, "", (<-dataChan).Label("ip"))
+}
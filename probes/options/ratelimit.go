@@ -0,0 +1,122 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var (
+	globalLimiterMu sync.Mutex
+	globalLimiter   *rate.Limiter
+)
+
+// SetGlobalRateLimit sets the global requests-per-second limit, shared by all
+// the probes that are created after this call. A non-positive value removes
+// the global limit.
+func SetGlobalRateLimit(rps float64) {
+	globalLimiterMu.Lock()
+	defer globalLimiterMu.Unlock()
+
+	if rps <= 0 {
+		globalLimiter = nil
+		return
+	}
+	globalLimiter = rate.NewLimiter(rate.Limit(rps), 1)
+}
+
+func getGlobalLimiter() *rate.Limiter {
+	globalLimiterMu.Lock()
+	defer globalLimiterMu.Unlock()
+	return globalLimiter
+}
+
+// RateLimiter paces outbound probe requests, based on the per-probe and
+// global requests-per-second limits. It's used by all the active probe types;
+// UDP_LISTENER, being passive, ignores it.
+type RateLimiter struct {
+	limiters []*rate.Limiter
+}
+
+// newRateLimiter returns a new rate limiter for the given per-probe limit.
+// It returns nil if neither per-probe nor global limit is configured.
+func newRateLimiter(rps float64) *RateLimiter {
+	rl := &RateLimiter{}
+	if rps > 0 {
+		rl.limiters = append(rl.limiters, rate.NewLimiter(rate.Limit(rps), 1))
+	}
+	if gl := getGlobalLimiter(); gl != nil {
+		rl.limiters = append(rl.limiters, gl)
+	}
+	if len(rl.limiters) == 0 {
+		return nil
+	}
+	return rl
+}
+
+// Wait waits until n requests are allowed by the rate limiter, but never
+// longer than maxDelay, so that probe runs don't spill over the probe
+// interval. It returns the time spent waiting. Wait returns early if context
+// is canceled.
+//
+// Reservations that would need a wait longer than maxDelay are canceled, so
+// that a probe that falls behind doesn't keep accumulating debt on the
+// limiters, which would make all the later calls wait for maxDelay.
+func (rl *RateLimiter) Wait(ctx context.Context, n int, maxDelay time.Duration) time.Duration {
+	if rl == nil {
+		return 0
+	}
+
+	now := time.Now()
+	var delay time.Duration
+	for _, l := range rl.limiters {
+		for i := 0; i < n; i++ {
+			r := l.ReserveN(now, 1)
+			d := r.DelayFrom(now)
+			if d > maxDelay {
+				r.CancelAt(now)
+				d = maxDelay
+			}
+			if d > delay {
+				delay = d
+			}
+		}
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return time.Since(now)
+}
+
+// MaxRateLimitDelay returns the maximum time a probe run can be delayed by
+// the rate limiter: the part of the probe interval not needed by the probe
+// timeout.
+func (opts *Options) MaxRateLimitDelay() time.Duration {
+	if d := opts.Interval - opts.Timeout; d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	// nil rate limiter never waits.
+	var nilRL *RateLimiter
+	assert.Equal(t, time.Duration(0), nilRL.Wait(context.Background(), 1, time.Second))
+
+	rl := newRateLimiter(20) // One request every 50ms.
+
+	// First request goes through immediately.
+	assert.Equal(t, time.Duration(0), rl.Wait(context.Background(), 1, time.Second))
+
+	start := time.Now()
+	assert.Greater(t, rl.Wait(context.Background(), 1, time.Second), time.Duration(0))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	// Wait is capped at maxDelay.
+	start = time.Now()
+	rl.Wait(context.Background(), 10, 10*time.Millisecond)
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+
+	// Reservations beyond maxDelay are canceled: the capped wait above
+	// doesn't leave debt on the limiter, and the next request is paced
+	// normally, instead of waiting for ~500ms (10 requests).
+	time.Sleep(50 * time.Millisecond)
+	start = time.Now()
+	rl.Wait(context.Background(), 1, time.Second)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// Canceled context ends the wait early.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	rl.Wait(ctx, 10, time.Second)
+	assert.Less(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRateLimiterConfig(t *testing.T) {
+	defer SetGlobalRateLimit(0)
+
+	probeDef := func(rps float32) *configpb.ProbeDef {
+		p := &configpb.ProbeDef{Type: configpb.ProbeDef_HTTP.Enum(), Targets: testTargets}
+		if rps != 0 {
+			p.MaxRequestsPerSec = proto.Float32(rps)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name         string
+		globalRPS    float64
+		probeRPS     float32
+		wantLimiters int
+	}{
+		{name: "none"},
+		{name: "probe", probeRPS: 10, wantLimiters: 1},
+		{name: "global", globalRPS: 100, wantLimiters: 1},
+		{name: "both", globalRPS: 100, probeRPS: 10, wantLimiters: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			SetGlobalRateLimit(test.globalRPS)
+			opts, err := BuildProbeOptions(probeDef(test.probeRPS), nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if test.wantLimiters == 0 {
+				assert.Nil(t, opts.RateLimiter)
+				return
+			}
+			assert.Len(t, opts.RateLimiter.limiters, test.wantLimiters)
+		})
+	}
+
+	t.Run("udp_listener", func(t *testing.T) {
+		p := probeDef(10)
+		p.Type = configpb.ProbeDef_UDP_LISTENER.Enum()
+		_, err := BuildProbeOptions(p, nil, nil, nil)
+		assert.ErrorContains(t, err, "max_requests_per_sec is not supported")
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"sync"
+)
+
+// runTracker tracks the in-flight probe runs, so that probes can be stopped
+// gracefully: no new runs are started once stopping, and in-flight runs are
+// allowed to complete.
+type runTracker struct {
+	mu       sync.Mutex
+	stopping bool
+	inFlight sync.WaitGroup
+}
+
+// BeginRun should be called by the probes before starting a probe run. It
+// returns false if the probe is being stopped, in which case no new run
+// should be started. Otherwise, the returned function must be called once
+// the run is complete.
+func (opts *Options) BeginRun() (func(), bool) {
+	rt := opts.runs
+	if rt == nil {
+		return func() {}, true
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.stopping {
+		return nil, false
+	}
+	rt.inFlight.Add(1)
+	return rt.inFlight.Done, true
+}
+
+// StopRuns stops the new probe runs (see BeginRun) and waits for the
+// in-flight runs to complete, or for the context to be canceled.
+func (opts *Options) StopRuns(ctx context.Context) error {
+	rt := opts.runs
+	if rt == nil {
+		return nil
+	}
+
+	rt.mu.Lock()
+	rt.stopping = true
+	rt.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		rt.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStopRuns(t *testing.T) {
+	// Options without the run tracker, e.g. in tests, never stop runs.
+	opts := &Options{}
+	assert.NoError(t, opts.StopRuns(context.Background()))
+	_, ok := opts.BeginRun()
+	assert.True(t, ok)
+
+	opts = &Options{runs: &runTracker{}}
+	endRun, ok := opts.BeginRun()
+	assert.True(t, ok)
+
+	// In-flight run doesn't complete before the context's deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, opts.StopRuns(ctx), context.DeadlineExceeded)
+
+	// No new runs after StopRuns.
+	_, ok = opts.BeginRun()
+	assert.False(t, ok)
+
+	endRun()
+	assert.NoError(t, opts.StopRuns(context.Background()))
+}
@@ -29,7 +29,12 @@ type period struct {
 	loc                *time.Location
 	everyDay           bool
 	startTime, endTime time.Time
-	l                  *logger.Logger
+
+	// Optional date range to which the period is limited. dateEnd is the
+	// start of the day after the end date.
+	dateStart, dateEnd time.Time
+
+	l *logger.Logger
 }
 
 // normalizeTime is the most tricky part of the schedule implementation. It
@@ -56,6 +61,10 @@ func (p *period) isInPeriod(t time.Time) bool {
 	// Convert the provided time to the same timezone as the period.
 	t = t.In(p.loc)
 
+	if (!p.dateStart.IsZero() && t.Before(p.dateStart)) || (!p.dateEnd.IsZero() && !t.Before(p.dateEnd)) {
+		return false
+	}
+
 	nt := p.normalizeTime(int(t.Weekday()), t.Hour(), t.Minute())
 
 	// For times between Sunday 00:00 and the start of the schedule.
@@ -101,6 +110,22 @@ func parsePeriod(sched *configpb.Schedule, l *logger.Logger) (*period, error) {
 	}
 	p.endTime = p.normalizeTime(weekDayNum(sched.GetEndWeekday()), endTimeHour, endTimeMin)
 
+	if sched.GetStartDate() != "" {
+		if p.dateStart, err = time.ParseInLocation("2006-01-02", sched.GetStartDate(), p.loc); err != nil {
+			return nil, fmt.Errorf("error parsing start date (%s): %v", sched.GetStartDate(), err)
+		}
+	}
+	if sched.GetEndDate() != "" {
+		endDate, err := time.ParseInLocation("2006-01-02", sched.GetEndDate(), p.loc)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing end date (%s): %v", sched.GetEndDate(), err)
+		}
+		p.dateEnd = endDate.AddDate(0, 0, 1)
+		if !p.dateStart.IsZero() && !p.dateStart.Before(p.dateEnd) {
+			return nil, fmt.Errorf("invalid schedule: end date (%s) is before start date (%s)", sched.GetEndDate(), sched.GetStartDate())
+		}
+	}
+
 	if p.endTime.Before(p.startTime) {
 		if p.everyDay || sched.GetStartWeekday() == sched.GetEndWeekday() {
 			return nil, fmt.Errorf("invalid schedule: for same day start time (%s) should be after end time (%s)", sched.GetStartTime(), sched.GetEndTime())
@@ -114,10 +139,19 @@ func parsePeriod(sched *configpb.Schedule, l *logger.Logger) (*period, error) {
 }
 
 func (p *period) String() string {
+	var s string
 	if p.everyDay {
-		return fmt.Sprintf("Everyday %s - %s", p.startTime.Format("15:04 MST"), p.endTime.Format("15:04 MST"))
+		s = fmt.Sprintf("Everyday %s - %s", p.startTime.Format("15:04 MST"), p.endTime.Format("15:04 MST"))
+	} else {
+		s = fmt.Sprintf("%s - %s", p.startTime.Format("Mon 15:04 MST"), p.endTime.Format("Mon 15:04 MST"))
+	}
+	if !p.dateStart.IsZero() {
+		s += fmt.Sprintf(", from %s", p.dateStart.Format("2006-01-02"))
+	}
+	if !p.dateEnd.IsZero() {
+		s += fmt.Sprintf(", until %s", p.dateEnd.AddDate(0, 0, -1).Format("2006-01-02"))
 	}
-	return fmt.Sprintf("%s - %s", p.startTime.Format("Mon 15:04 MST"), p.endTime.Format("Mon 15:04 MST"))
+	return s
 }
 
 type Schedule struct {
@@ -88,6 +88,25 @@ func TestSchedules(t *testing.T) {
 		},
 	}
 
+	maintenanceWindow := []*configpb.Schedule{
+		{
+			Type:      configpb.Schedule_DISABLE.Enum(),
+			StartTime: proto.String("01:00"),
+			EndTime:   proto.String("03:00"),
+			StartDate: proto.String("2023-12-15"),
+			EndDate:   proto.String("2023-12-16"),
+			Timezone:  proto.String("America/New_York"),
+		},
+		{
+			Type:         configpb.Schedule_DISABLE.Enum(),
+			StartWeekday: configpb.Schedule_SATURDAY.Enum(),
+			StartTime:    proto.String("02:00"),
+			EndWeekday:   configpb.Schedule_SATURDAY.Enum(),
+			EndTime:      proto.String("04:00"),
+			Timezone:     proto.String("America/New_York"),
+		},
+	}
+
 	tests := []struct {
 		name    string
 		confs   []*configpb.Schedule
@@ -116,6 +135,27 @@ func TestSchedules(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "err-invalid-date",
+			confs: []*configpb.Schedule{
+				{
+					Type:      configpb.Schedule_DISABLE.Enum(),
+					StartDate: proto.String("2023/12/15"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "err-end-date-before-start-date",
+			confs: []*configpb.Schedule{
+				{
+					Type:      configpb.Schedule_DISABLE.Enum(),
+					StartDate: proto.String("2023-12-15"),
+					EndDate:   proto.String("2023-12-14"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name:  "weekendAndRollout",
 			confs: scheduleConfs,
@@ -178,6 +218,20 @@ func TestSchedules(t *testing.T) {
 				"2023-12-15 22:01:00 -0500": false, // Fri
 			},
 		},
+		{
+			name:  "maintenanceWindow",
+			confs: maintenanceWindow,
+			results: map[string]bool{
+				"2023-12-14 02:00:00 -0500": true,  // Thu -- before the date range
+				"2023-12-15 02:00:00 -0500": false, // Fri -- in maintenance
+				"2023-12-15 03:01:00 -0500": true,  // Fri
+				"2023-12-16 01:30:00 -0500": false, // Sat -- in maintenance
+				"2023-12-16 03:30:00 -0500": false, // Sat -- overlapping weekly window
+				"2023-12-16 04:01:00 -0500": true,  // Sat
+				"2023-12-17 02:00:00 -0500": true,  // Sun -- after the date range
+				"2023-12-23 03:00:00 -0500": false, // Sat -- weekly window
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
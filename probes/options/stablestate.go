@@ -0,0 +1,152 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// StableStateMetricName is the name of the debounced state metric: 1 if the
+// target is stably up, 0 if it's stably down.
+const StableStateMetricName = "stable_state"
+
+type targetState struct {
+	lastTotal, lastSuccess int64
+
+	stableUp    bool
+	initialized bool
+
+	// Number of consecutive runs that disagree with the stable state.
+	streak int
+
+	lastSeen time.Time
+}
+
+// stableStateTracker debounces probe results: stable state of a target
+// changes only after the new state has persisted for the configured number of
+// consecutive runs.
+//
+// State of the targets that haven't reported for staleAfter is discarded, so
+// that the tracker doesn't grow indefinitely as targets come and go.
+type stableStateTracker struct {
+	runs       int
+	staleAfter time.Duration
+
+	mu     sync.Mutex
+	states map[string]*targetState
+	lastGC time.Time
+}
+
+func newStableStateTracker(runs int, staleAfter time.Duration) *stableStateTracker {
+	if runs <= 0 {
+		return nil
+	}
+	return &stableStateTracker{
+		runs:       runs,
+		staleAfter: staleAfter,
+		states:     make(map[string]*targetState),
+	}
+}
+
+// gc discards the state of the targets that haven't reported for staleAfter.
+// It runs at most once per staleAfter.
+func (sst *stableStateTracker) gc(now time.Time) {
+	if sst.staleAfter <= 0 || now.Sub(sst.lastGC) < sst.staleAfter {
+		return
+	}
+	sst.lastGC = now
+	for key, ts := range sst.states {
+		if now.Sub(ts.lastSeen) >= sst.staleAfter {
+			delete(sst.states, key)
+		}
+	}
+}
+
+// update updates the target's state using the total and success counters,
+// and returns the current stable state. Since metrics may be exported after
+// multiple runs, we look at the change in counters since the last update:
+// if all new runs succeeded (or all failed), they count towards the streak,
+// otherwise (state flapped within the export interval) the streak is reset.
+func (sst *stableStateTracker) update(key string, total, success int64, now time.Time) bool {
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+
+	sst.gc(now)
+
+	ts := sst.states[key]
+	if ts == nil {
+		ts = &targetState{}
+		sst.states[key] = ts
+	}
+	ts.lastSeen = now
+
+	newRuns, newSuccess := total-ts.lastTotal, success-ts.lastSuccess
+	ts.lastTotal, ts.lastSuccess = total, success
+
+	// Counters went back (e.g. target was re-added), or no new runs.
+	if newRuns <= 0 || newSuccess < 0 || newSuccess > newRuns {
+		return ts.stableUp
+	}
+
+	allUp, allDown := newSuccess == newRuns, newSuccess == 0
+
+	// First result for the target determines its initial stable state.
+	if !ts.initialized && (allUp || allDown) {
+		ts.initialized = true
+		ts.stableUp = allUp
+		return ts.stableUp
+	}
+
+	switch {
+	case (allUp && ts.stableUp) || (allDown && !ts.stableUp):
+		ts.streak = 0
+	case allUp || allDown:
+		ts.streak += int(newRuns)
+		if ts.streak >= sst.runs {
+			ts.stableUp = !ts.stableUp
+			ts.streak = 0
+		}
+	default:
+		ts.streak = 0
+	}
+
+	return ts.stableUp
+}
+
+// record adds the stable state metric to the gauge EventMetrics (gaugeEM),
+// if the probe's EventMetrics (em) has the total and success metrics.
+func (sst *stableStateTracker) record(key string, em, gaugeEM *metrics.EventMetrics) {
+	if sst == nil {
+		return
+	}
+
+	total, ok := em.Metric("total").(metrics.NumValue)
+	if !ok {
+		return
+	}
+	success, ok := em.Metric("success").(metrics.NumValue)
+	if !ok {
+		return
+	}
+
+	var v int64
+	if sst.update(key, total.Int64(), success.Int64(), em.Timestamp) {
+		v = 1
+	}
+	gaugeEM.AddMetric(StableStateMetricName, metrics.NewInt(v))
+}
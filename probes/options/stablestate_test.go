@@ -0,0 +1,124 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStableStateTracker(t *testing.T) {
+	assert.Nil(t, newStableStateTracker(0, time.Minute))
+
+	sst := newStableStateTracker(3, time.Minute)
+
+	// Each step is the result of new runs: success count and total count.
+	steps := []struct {
+		newTotal, newSuccess int64
+		want                 bool
+	}{
+		{1, 1, true},  // Initial state: up
+		{1, 0, true},  // 1 failure
+		{1, 1, true},  // Back up, streak reset
+		{1, 0, true},  // 1 failure
+		{1, 0, true},  // 2 failures
+		{1, 0, false}, // 3 failures: down
+		{2, 1, false}, // Mixed results, streak reset
+		{2, 2, false}, // 2 successes
+		{0, 0, false}, // No new runs
+		{1, 1, true},  // 3 successes: up
+	}
+
+	var total, success int64
+	for i, step := range steps {
+		total, success = total+step.newTotal, success+step.newSuccess
+		assert.Equal(t, step.want, sst.update("t1", total, success, time.Time{}), "step %d", i)
+	}
+
+	// Other target is tracked independently.
+	assert.False(t, sst.update("t2", 1, 0, time.Time{}))
+}
+
+func TestStableStateGC(t *testing.T) {
+	sst := newStableStateTracker(3, time.Minute)
+	ts := time.Now()
+
+	sst.update("t1", 1, 1, ts)
+	sst.update("t2", 1, 1, ts)
+	sst.update("t1", 2, 2, ts.Add(50*time.Second))
+	assert.Len(t, sst.states, 2)
+
+	// t2 hasn't reported for a minute.
+	sst.update("t1", 3, 3, ts.Add(70*time.Second))
+	assert.Len(t, sst.states, 1)
+	assert.NotNil(t, sst.states["t1"])
+}
+
+func TestStableStateRecord(t *testing.T) {
+	var sst *stableStateTracker
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(1)).
+		AddMetric("success", metrics.NewInt(1))
+
+	gaugeEM := metrics.NewEventMetrics(time.Now())
+
+	// nil tracker is a no-op.
+	sst.record("t1", em, gaugeEM)
+	assert.Nil(t, gaugeEM.Metric(StableStateMetricName))
+
+	sst = newStableStateTracker(2, time.Minute)
+	sst.record("t1", em, gaugeEM)
+	assert.Equal(t, "1", gaugeEM.Metric(StableStateMetricName).String())
+	assert.Nil(t, em.Metric(StableStateMetricName), "stable state added to the probe's EventMetrics")
+	assert.Equal(t, "1", em.Metric("success").String(), "raw counter modified")
+
+	// EventMetrics without total and success are left alone.
+	em2 := metrics.NewEventMetrics(time.Now()).AddMetric("latency", metrics.NewFloat(1))
+	gaugeEM2 := metrics.NewEventMetrics(time.Now())
+	sst.record("t1", em2, gaugeEM2)
+	assert.Nil(t, gaugeEM2.Metric(StableStateMetricName))
+}
+
+func TestRecordMetricsWithStableState(t *testing.T) {
+	ep := endpoint.Endpoint{Name: "t1"}
+	opts := DefaultOptions()
+	opts.stableState = newStableStateTracker(2, time.Minute)
+	opts.AdditionalLabels = []*AdditionalLabel{{Key: "env", staticValue: "prod"}}
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(1)).
+		AddMetric("success", metrics.NewInt(1)).
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t1")
+
+	dataChan := make(chan *metrics.EventMetrics, 3)
+	opts.RecordMetrics(ep, em, dataChan)
+
+	assert.Len(t, dataChan, 2)
+	em, gaugeEM := <-dataChan, <-dataChan
+	assert.Equal(t, metrics.Kind(metrics.CUMULATIVE), em.Kind)
+	assert.Nil(t, em.Metric(StableStateMetricName))
+
+	assert.Equal(t, metrics.Kind(metrics.GAUGE), gaugeEM.Kind)
+	assert.Equal(t, []string{StableStateMetricName}, gaugeEM.MetricsKeys())
+	assert.Equal(t, "1", gaugeEM.Metric(StableStateMetricName).String())
+	for _, k := range []string{"probe", "dst", "env"} {
+		assert.Equal(t, em.Label(k), gaugeEM.Label(k), "label: %s", k)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+
+	"github.com/cloudprober/cloudprober/internal/validators"
+	"github.com/cloudprober/cloudprober/logger"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+var validatorSetSupported = map[configpb.ProbeDef_Type]bool{
+	configpb.ProbeDef_HTTP: true,
+	configpb.ProbeDef_DNS:  true,
+}
+
+// validatorSets holds the validator sets, indexed by name, and the target
+// label used to select them.
+type validatorSets struct {
+	label string
+	sets  map[string][]*validators.Validator
+}
+
+func newValidatorSets(p *configpb.ProbeDef, l *logger.Logger) (*validatorSets, error) {
+	if len(p.GetValidatorSet()) == 0 {
+		return nil, nil
+	}
+	if !validatorSetSupported[p.GetType()] {
+		return nil, fmt.Errorf("validator_set is not supported by %s probes", p.GetType().String())
+	}
+
+	vs := &validatorSets{
+		label: p.GetValidatorSetLabel(),
+		sets:  make(map[string][]*validators.Validator),
+	}
+	for _, set := range p.GetValidatorSet() {
+		if set.GetName() == "" {
+			return nil, fmt.Errorf("validator_set name is required")
+		}
+		if _, ok := vs.sets[set.GetName()]; ok {
+			return nil, fmt.Errorf("validator_set %s is defined twice", set.GetName())
+		}
+		if len(set.GetValidator()) == 0 {
+			return nil, fmt.Errorf("validator_set %s has no validators", set.GetName())
+		}
+		v, err := validators.Init(set.GetValidator(), l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize validator_set %s: %v", set.GetName(), err)
+		}
+		vs.sets[set.GetName()] = v
+	}
+	return vs, nil
+}
+
+// ValidatorsForTarget returns the validators to use for the target: the
+// validator set named by the target's validator set label, if there is one,
+// or the probe's validators otherwise.
+func (opts *Options) ValidatorsForTarget(ep endpoint.Endpoint) []*validators.Validator {
+	if opts.validatorSets == nil {
+		return opts.Validators
+	}
+	name, ok := ep.Labels[opts.validatorSets.label]
+	if !ok {
+		return opts.Validators
+	}
+	if v, ok := opts.validatorSets.sets[name]; ok {
+		return v
+	}
+	opts.Logger.Warningf("Target %s: validator set %s not found, using probe's validators", ep.Name, name)
+	return opts.Validators
+}
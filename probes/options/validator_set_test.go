@@ -0,0 +1,98 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+
+	validatorpb "github.com/cloudprober/cloudprober/internal/validators/proto"
+	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func testValidatorSet(name string, validatorNames ...string) *configpb.ProbeDef_ValidatorSet {
+	set := &configpb.ProbeDef_ValidatorSet{Name: proto.String(name)}
+	for _, vn := range validatorNames {
+		set.Validator = append(set.Validator, &validatorpb.Validator{
+			Name: vn,
+			Type: &validatorpb.Validator_Regex{Regex: vn},
+		})
+	}
+	return set
+}
+
+func TestValidatorSetsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		ptype   configpb.ProbeDef_Type
+		sets    []*configpb.ProbeDef_ValidatorSet
+		wantErr bool
+	}{
+		{name: "http", ptype: configpb.ProbeDef_HTTP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("xml", "v1"), testValidatorSet("json", "v2")}},
+		{name: "dns", ptype: configpb.ProbeDef_DNS, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("s1", "v1")}},
+		{name: "unsupported", ptype: configpb.ProbeDef_TCP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("s1", "v1")}, wantErr: true},
+		{name: "no_name", ptype: configpb.ProbeDef_HTTP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("", "v1")}, wantErr: true},
+		{name: "duplicate", ptype: configpb.ProbeDef_HTTP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("s1", "v1"), testValidatorSet("s1", "v2")}, wantErr: true},
+		{name: "empty", ptype: configpb.ProbeDef_HTTP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("s1")}, wantErr: true},
+		{name: "bad_validator", ptype: configpb.ProbeDef_HTTP, sets: []*configpb.ProbeDef_ValidatorSet{testValidatorSet("s1", "v1", "v1")}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts, err := BuildProbeOptions(&configpb.ProbeDef{
+				Type:         test.ptype.Enum(),
+				Targets:      testTargets,
+				ValidatorSet: test.sets,
+			}, nil, nil, nil)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, opts.validatorSets.sets, len(test.sets))
+		})
+	}
+}
+
+func TestValidatorsForTarget(t *testing.T) {
+	opts, err := BuildProbeOptions(&configpb.ProbeDef{
+		Type:              configpb.ProbeDef_HTTP.Enum(),
+		Targets:           testTargets,
+		Validator:         testValidatorSet("", "default").GetValidator(),
+		ValidatorSet:      []*configpb.ProbeDef_ValidatorSet{testValidatorSet("xml", "xml1", "xml2"), testValidatorSet("json", "json1")},
+		ValidatorSetLabel: proto.String("format"),
+	}, nil, nil, nil)
+	require.NoError(t, err)
+
+	validatorNames := func(labels map[string]string) []string {
+		var names []string
+		for _, v := range opts.ValidatorsForTarget(endpoint.Endpoint{Name: "t1", Labels: labels}) {
+			names = append(names, v.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"xml1", "xml2"}, validatorNames(map[string]string{"format": "xml"}))
+	assert.Equal(t, []string{"json1"}, validatorNames(map[string]string{"format": "json"}))
+	assert.Equal(t, []string{"default"}, validatorNames(nil))
+	assert.Equal(t, []string{"default"}, validatorNames(map[string]string{"validator_set": "xml"}))
+	assert.Equal(t, []string{"default"}, validatorNames(map[string]string{"format": "yaml"}))
+
+	// Without validator sets, probe's validators are used.
+	assert.Len(t, (&Options{}).ValidatorsForTarget(endpoint.Endpoint{Name: "t1", Labels: map[string]string{"validator_set": "xml"}}), 0)
+}
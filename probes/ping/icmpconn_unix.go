@@ -26,6 +26,8 @@ import (
 	"syscall"
 	"time"
 	"unsafe"
+
+	"github.com/cloudprober/cloudprober/internal/sockopt"
 )
 
 // NativeEndian is the machine native endian implementation of ByteOrder.
@@ -97,6 +99,13 @@ func (p *Probe) listenPacket(sourceIP net.IP) (*icmpPacketConn, error) {
 		}
 	}
 
+	if p.opts.DSCP != 0 {
+		if err := sockopt.SetDSCPFD(s, p.opts.DSCP, p.l); err != nil {
+			syscall.Close(s)
+			return nil, err
+		}
+	}
+
 	sa, err := sockaddr(sourceIP, p.ipVer)
 	if err != nil {
 		syscall.Close(s)
@@ -45,6 +45,7 @@ import (
 	"math/rand"
 	"net"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -71,8 +72,10 @@ const (
 
 type result struct {
 	sent, rcvd        int64
+	throttledRuns     int64
 	latency           metrics.LatencyValue
 	validationFailure *metrics.Map[int64]
+	validationSuccess *metrics.Map[int64]
 }
 
 // icmpConn is an interface wrapper for *icmp.PacketConn to allow testing.
@@ -226,12 +229,12 @@ func (p *Probe) updateTargets() {
 	for _, target := range p.targets {
 
 		// Update results map:
-		p.updateResultForTarget(target.Name)
+		p.updateResultForTarget(target.ID())
 
 		ip, err := target.Resolve(p.ipVer, p.opts.Targets)
 		if err != nil {
 			p.l.Warning("Bad target: ", target.Name, ". Err: ", err.Error())
-			p.target2addr[target.Name] = nil
+			p.target2addr[target.ID()] = nil
 			continue
 		}
 
@@ -245,8 +248,8 @@ func (p *Probe) updateTargets() {
 		} else {
 			a = &net.IPAddr{IP: ip}
 		}
-		p.target2addr[target.Name] = a
-		p.ip2target[ipToKey(ip)] = target.Name
+		p.target2addr[target.ID()] = a
+		p.ip2target[ipToKey(ip)] = target.ID()
 	}
 }
 
@@ -255,16 +258,12 @@ func (p *Probe) updateResultForTarget(t string) {
 		return
 	}
 
-	var latencyValue metrics.LatencyValue
-	if p.opts.LatencyDist != nil {
-		latencyValue = p.opts.LatencyDist.CloneDist()
-	} else {
-		latencyValue = metrics.NewFloat(0)
-	}
+	latencyValue := p.opts.NewLatencyValue()
 
 	p.results[t] = &result{
 		latency:           latencyValue,
 		validationFailure: validators.ValidationFailureMap(p.opts.Validators),
+		validationSuccess: p.opts.NewValidationSuccess(p.opts.Validators),
 	}
 }
 
@@ -285,17 +284,20 @@ func (p *Probe) sendPackets(runID uint16, tracker chan bool) {
 	// We re-use the same memory space for all outgoing packets.
 	pktbuf := make([]byte, icmpHeaderSize+p.c.GetPayloadSize())
 
+	targets := slices.Clone(p.targets)
+	p.opts.ShuffleTargets(len(targets), func(i, j int) { targets[i], targets[j] = targets[j], targets[i] })
+
 	for {
-		for _, target := range p.targets {
-			p.results[target.Name].sent++
+		for _, target := range targets {
+			p.results[target.ID()].sent++
 
-			if p.target2addr[target.Name] == nil {
+			if p.target2addr[target.ID()] == nil {
 				p.l.Debug("Skipping unresolved target: ", target.Name)
 				continue
 			}
 
 			p.prepareRequestPacket(pktbuf, runID, seq, time.Now().UnixNano())
-			if _, err := p.conn.write(pktbuf, p.target2addr[target.Name]); err != nil {
+			if _, err := p.conn.write(pktbuf, p.target2addr[target.ID()]); err != nil {
 				p.l.Error(err.Error())
 				continue
 			}
@@ -450,7 +452,7 @@ func (p *Probe) recvPackets(runID uint16, tracker chan bool) {
 		result := p.results[pkt.target]
 
 		if p.opts.Validators != nil {
-			failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: pkt.data}, result.validationFailure, p.l)
+			failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: pkt.data}, result.validationFailure, result.validationSuccess, p.l)
 
 			// If any validation failed, return now, leaving the success and latency
 			// counters unchanged.
@@ -529,15 +531,28 @@ func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics)
 		if !p.opts.IsScheduled() {
 			continue
 		}
+		endRun, ok := p.opts.BeginRun()
+		if !ok {
+			continue
+		}
+
+		// All the packets of a run are sent together, so we wait for the
+		// rate limiter only once per run.
+		if p.opts.RateLimiter.Wait(ctx, int(p.c.GetPacketsPerProbe())*len(p.targets), p.opts.MaxRateLimitDelay()) > 0 {
+			for _, target := range p.targets {
+				p.results[target.ID()].throttledRuns++
+			}
+		}
 
 		p.l.Debugf("Probe started, runcount %d", p.runCnt)
 		p.runProbe()
+		endRun()
 		p.l.Debugf("Probe finished, runcount %d", p.runCnt)
 		if (p.runCnt % uint64(p.statsExportFreq)) != 0 {
 			continue
 		}
 		for _, target := range p.targets {
-			result := p.results[target.Name]
+			result := p.results[target.ID()]
 			success := result.rcvd
 			if p.opts.NegativeTest {
 				success = result.sent - result.rcvd
@@ -555,6 +570,12 @@ func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics)
 			if p.opts.Validators != nil {
 				em.AddMetric("validation_failure", result.validationFailure)
 			}
+			if result.validationSuccess != nil {
+				em.AddMetric("validation_success", result.validationSuccess)
+			}
+			if p.opts.RateLimiter != nil {
+				em.AddMetric("throttled_runs", metrics.NewInt(result.throttledRuns))
+			}
 
 			p.opts.RecordMetrics(target, em, dataChan)
 		}
@@ -441,3 +441,35 @@ func TestRunProbeRealICMP(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateTargetsExpandedIPs(t *testing.T) {
+	ep := endpoint.Endpoint{Name: "svc.example"}
+	eps := ep.ExpandIPs([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")})
+
+	p := &Probe{
+		name: "ping_test",
+		opts: &options.Options{
+			ProbeConf:   &configpb.ProbeConf{UseDatagramSocket: proto.Bool(false)},
+			Targets:     targets.StaticEndpoints(eps),
+			Interval:    2 * time.Second,
+			Timeout:     time.Second,
+			LatencyUnit: time.Millisecond,
+		},
+	}
+	if err := p.initInternal(); err != nil {
+		t.Fatalf("Got error from initInternal: %v", err)
+	}
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2"} {
+		id := "svc.example/" + ip
+		if p.results[id] == nil {
+			t.Errorf("no result for %s, results: %v", id, p.results)
+		}
+		if got := p.target2addr[id].String(); got != ip {
+			t.Errorf("target2addr[%s]=%s, want=%s", id, got, ip)
+		}
+		if got := p.ip2target[ipToKey(net.ParseIP(ip))]; got != id {
+			t.Errorf("ip2target[%s]=%s, want=%s", ip, got, id)
+		}
+	}
+}
@@ -27,12 +27,15 @@ import (
 	"github.com/cloudprober/cloudprober/probes/external"
 	grpcprobe "github.com/cloudprober/cloudprober/probes/grpc"
 	httpprobe "github.com/cloudprober/cloudprober/probes/http"
+	"github.com/cloudprober/cloudprober/probes/ntp"
 	"github.com/cloudprober/cloudprober/probes/options"
 	"github.com/cloudprober/cloudprober/probes/ping"
 	configpb "github.com/cloudprober/cloudprober/probes/proto"
+	"github.com/cloudprober/cloudprober/probes/quic"
 	"github.com/cloudprober/cloudprober/probes/tcp"
 	"github.com/cloudprober/cloudprober/probes/udp"
 	"github.com/cloudprober/cloudprober/probes/udplistener"
+	"github.com/cloudprober/cloudprober/probes/websocket"
 	"github.com/cloudprober/cloudprober/web/formatutils"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -144,6 +147,15 @@ func initProbe(p *configpb.ProbeDef, opts *options.Options) (probe Probe, probeC
 	case configpb.ProbeDef_TCP:
 		probe = &tcp.Probe{}
 		probeConf = p.GetTcpProbe()
+	case configpb.ProbeDef_QUIC:
+		probe = &quic.Probe{}
+		probeConf = p.GetQuicProbe()
+	case configpb.ProbeDef_NTP:
+		probe = &ntp.Probe{}
+		probeConf = p.GetNtpProbe()
+	case configpb.ProbeDef_WEBSOCKET:
+		probe = &websocket.Probe{}
+		probeConf = p.GetWebsocketProbe()
 	case configpb.ProbeDef_UDP:
 		probe = &udp.Probe{}
 		probeConf = p.GetUdpProbe()
@@ -14,10 +14,13 @@ import (
 	proto7 "github.com/cloudprober/cloudprober/probes/external/proto"
 	proto10 "github.com/cloudprober/cloudprober/probes/grpc/proto"
 	proto5 "github.com/cloudprober/cloudprober/probes/http/proto"
+	proto13 "github.com/cloudprober/cloudprober/probes/ntp/proto"
 	proto4 "github.com/cloudprober/cloudprober/probes/ping/proto"
+	proto12 "github.com/cloudprober/cloudprober/probes/quic/proto"
 	proto11 "github.com/cloudprober/cloudprober/probes/tcp/proto"
 	proto8 "github.com/cloudprober/cloudprober/probes/udp/proto"
 	proto9 "github.com/cloudprober/cloudprober/probes/udplistener/proto"
+	proto14 "github.com/cloudprober/cloudprober/probes/websocket/proto"
 	proto "github.com/cloudprober/cloudprober/targets/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -43,6 +46,9 @@ const (
 	ProbeDef_UDP_LISTENER ProbeDef_Type = 5
 	ProbeDef_GRPC         ProbeDef_Type = 6
 	ProbeDef_TCP          ProbeDef_Type = 7
+	ProbeDef_QUIC         ProbeDef_Type = 8
+	ProbeDef_NTP          ProbeDef_Type = 9
+	ProbeDef_WEBSOCKET    ProbeDef_Type = 10
 	// One of the extension probe types. See "extensions" below for more
 	// details.
 	ProbeDef_EXTENSION ProbeDef_Type = 98
@@ -64,6 +70,9 @@ var (
 		5:  "UDP_LISTENER",
 		6:  "GRPC",
 		7:  "TCP",
+		8:  "QUIC",
+		9:  "NTP",
+		10: "WEBSOCKET",
 		98: "EXTENSION",
 		99: "USER_DEFINED",
 	}
@@ -76,6 +85,9 @@ var (
 		"UDP_LISTENER": 5,
 		"GRPC":         6,
 		"TCP":          7,
+		"QUIC":         8,
+		"NTP":          9,
+		"WEBSOCKET":    10,
 		"EXTENSION":    98,
 		"USER_DEFINED": 99,
 	}
@@ -353,6 +365,19 @@ type ProbeDef struct {
 	Targets *proto.TargetsDef `protobuf:"bytes,6,opt,name=targets" json:"targets,omitempty"`
 	// Latency distribution. If specified, latency is stored as a distribution.
 	LatencyDistribution *proto1.Dist `protobuf:"bytes,7,opt,name=latency_distribution,json=latencyDistribution" json:"latency_distribution,omitempty"`
+	// Latency summary. If specified, latency is stored as a summary: quantiles
+	// computed over a sliding time window, along with the cumulative sum and
+	// count. Summaries are exported by the Prometheus surfacer as the native
+	// Prometheus summaries. Note that, unlike histograms, summary quantiles
+	// cannot be aggregated across targets or cloudprober instances. This
+	// field cannot be used together with latency_distribution.
+	// Example:
+	//
+	//	latency_summary {
+	//	  quantiles: [0.5, 0.9, 0.99]
+	//	  max_age_sec: 300
+	//	}
+	LatencySummary *proto1.Summary `protobuf:"bytes,37,opt,name=latency_summary,json=latencySummary" json:"latency_summary,omitempty"`
 	// Latency unit. Any string that's parseable by time.ParseDuration.
 	// Valid values: "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	LatencyUnit *string `protobuf:"bytes,8,opt,name=latency_unit,json=latencyUnit,def=us" json:"latency_unit,omitempty"`
@@ -374,9 +399,24 @@ type ProbeDef struct {
 	LatencyMetricName *string `protobuf:"bytes,15,opt,name=latency_metric_name,json=latencyMetricName,def=latency" json:"latency_metric_name,omitempty"`
 	// Validators for this probe. Validators are run on the data returned by the
 	// probe. See https://cloudprober.org/docs/how-to/validators/ for more info.
-	Validator []*proto2.Validator `protobuf:"bytes,9,rep,name=validator" json:"validator,omitempty"`
+	Validator    []*proto2.Validator      `protobuf:"bytes,9,rep,name=validator" json:"validator,omitempty"`
+	ValidatorSet []*ProbeDef_ValidatorSet `protobuf:"bytes,41,rep,name=validator_set,json=validatorSet" json:"validator_set,omitempty"`
+	// Target label that names the validator set to use for the target. If
+	// label's value doesn't match any validator set, probe's validators are
+	// used.
+	ValidatorSetLabel *string `protobuf:"bytes,42,opt,name=validator_set_label,json=validatorSetLabel,def=validator_set" json:"validator_set_label,omitempty"`
+	// If set, probe exports a "validation_success" counter, broken down by the
+	// "validator" label, along with the "validation_failure" counter that's
+	// always exported. Together these show the pass/fail counts for each
+	// validator. Validators that are skipped (e.g. because of if_status_codes)
+	// are not counted.
+	// This is currently implemented only by HTTP, DNS, GRPC, EXTERNAL, PING
+	// and WEBSOCKET probes.
+	ExportValidationSuccess *bool `protobuf:"varint,43,opt,name=export_validation_success,json=exportValidationSuccess" json:"export_validation_success,omitempty"`
 	// Set the source IP to send packets from, either by providing an IP address
-	// directly, or a network interface.
+	// directly, or a network interface. This is currently used by PING, TCP,
+	// UDP, HTTP and DNS probes. Probe initialization fails if source address is
+	// not available on the host.
 	//
 	// Types that are assignable to SourceIpConfig:
 	//
@@ -414,9 +454,116 @@ type ProbeDef struct {
 	// target doesn't respond. This is useful, for example, that your firewall is
 	// working as expected.
 	//
-	// This is currently implemented only by PING and TCP probes.
+	// This is currently implemented only by PING and TCP probes. TCP and HTTP
+	// probes also support expect_unreachable, that distinguishes between the
+	// different ways a target can be unreachable.
 	// Note: This field is currently experimental, and may change in future.
 	NegativeTest *bool `protobuf:"varint,18,opt,name=negative_test,json=negativeTest" json:"negative_test,omitempty"`
+	// DSCP value (0-63) to mark the probe packets with. This is useful if your
+	// network prioritizes traffic by DSCP, and you want probe packets to get
+	// the same QoS treatment as the production traffic. For example, to mark
+	// packets with EF (expedited forwarding), use dscp: 46.
+	//
+	// This is currently implemented only by PING, TCP, UDP, NTP and WEBSOCKET
+	// probes, and only on unix-like platforms.
+	Dscp *int32 `protobuf:"varint,28,opt,name=dscp" json:"dscp,omitempty"`
+	// Maximum number of requests per second for this probe. If configured,
+	// probe runs for different targets are paced to stay within this limit.
+	// Runs are never delayed beyond the probe interval, i.e. by more than
+	// (interval - timeout). Whenever a run is delayed by the rate limiter,
+	// "throttled_runs" counter is incremented for that target.
+	//
+	// PING, UDP and EXTERNAL probes send requests for all targets together, so
+	// for them the limit is applied to the probe run as a whole. This option is
+	// not supported by the UDP_LISTENER probe. A global limit, shared by all
+	// probes, can be configured using the global_max_requests_per_sec field in
+	// the top-level config.
+	MaxRequestsPerSec *float32 `protobuf:"fixed32,29,opt,name=max_requests_per_sec,json=maxRequestsPerSec" json:"max_requests_per_sec,omitempty"`
+	// Prefix to add to the names of all the metrics exported by this probe,
+	// e.g. "http_". This is useful to namespace metrics when multiple probe
+	// types export metrics with the same name (e.g. "total", "latency").
+	// Prefix is applied consistently by all surfacers, right before surfacing,
+	// and it applies to distribution sub-metrics (_sum, _count, _bucket) as
+	// well. It's not applied to the metrics used internally by cloudprober,
+	// e.g. by probestatus and alerting.
+	MetricsPrefix *string `protobuf:"bytes,30,opt,name=metrics_prefix,json=metricsPrefix" json:"metrics_prefix,omitempty"`
+	// If set, in addition to the raw success and failure counters, probe
+	// exports a debounced "stable_state" metric (1: up, 0: down) for each
+	// target. Stable state changes only after the new state has persisted for
+	// these many consecutive runs, which helps in suppressing state change
+	// storms for flapping targets. If a target's state flips multiple times
+	// within a single stats export interval, its streak is reset.
+	// Since it's a gauge, stable_state is exported in its own EventMetrics
+	// (of kind GAUGE), with the same labels as the probe's other metrics.
+	StableStateRuns *int32 `protobuf:"varint,31,opt,name=stable_state_runs,json=stableStateRuns" json:"stable_state_runs,omitempty"`
+	// If set, probe exports an "availability" gauge for each target: the ratio
+	// of successful runs to total runs over the rolling window. Availability
+	// is not exported until a target has had at least one run in the window.
+	// If a target's counters are reset (e.g. it's removed and re-added), its
+	// window starts afresh. State for targets that haven't reported for a
+	// full window is discarded. Since it's a gauge, availability is exported in
+	// its own EventMetrics (of kind GAUGE), along with stable_state, if
+	// enabled.
+	Availability *ProbeDef_Availability `protobuf:"bytes,38,opt,name=availability" json:"availability,omitempty"`
+	// If set, probe also exports its distribution metrics (e.g. latency)
+	// merged across all targets, with the "dst" label set to
+	// "__all_targets__". Merged distributions are weighted by the number of
+	// requests to each target, and can be used to compute fleet-wide latency
+	// percentiles. Merging is exact if all targets' distributions have the same
+	// buckets, otherwise it's approximate. Aggregate is exported at most once
+	// per stats export interval. Like the counters, aggregate distributions
+	// stay monotonic as targets come and go: distributions of the targets that
+	// go away are carried over.
+	AggregateDistributionsAcrossTargets *bool `protobuf:"varint,32,opt,name=aggregate_distributions_across_targets,json=aggregateDistributionsAcrossTargets" json:"aggregate_distributions_across_targets,omitempty"`
+	// If set, probe also exports its counters (e.g. total, success) summed
+	// across all targets, with the "dst" label set to "__all_targets__". Sums
+	// stay monotonic as targets come and go: counters of the targets that go
+	// away are carried over. If aggregate_distributions_across_targets is also
+	// set, both are exported in the same EventMetrics.
+	AggregateCountersAcrossTargets *bool `protobuf:"varint,33,opt,name=aggregate_counters_across_targets,json=aggregateCountersAcrossTargets" json:"aggregate_counters_across_targets,omitempty"`
+	// If set, probe runs only for the targets that have changed since they were
+	// last probed: new targets, targets with changed labels or IP, and targets
+	// whose last_updated timestamp (as reported by the targets provider, e.g.
+	// RDS) has changed. This is useful for expensive probes over large, mostly
+	// static inventories. Skipped runs are exported as the "skipped_unchanged"
+	// metric. Currently supported only by HTTP and TCP probes.
+	ChangedTargetsOnly *ProbeDef_ChangedTargetsOnly `protobuf:"bytes,34,opt,name=changed_targets_only,json=changedTargetsOnly" json:"changed_targets_only,omitempty"`
+	// If set, probe exports a "failures" counter broken down by the
+	// "failure_reason" label, so that failures can be classified on
+	// dashboards. Failure reasons are standardized across probe types:
+	//
+	//	dns:        name resolution failed.
+	//	connect:    connection could not be established, or was reset.
+	//	proxy:      request failed at the proxy layer, e.g. all proxies
+	//	            configured through HTTP probe's proxy_urls failed.
+	//	tls:        TLS handshake or certificate verification failed.
+	//	timeout:    request didn't complete within the probe timeout.
+	//	status:     response had an error status, e.g. DNS rcode or gRPC
+	//	            status code.
+	//	validation: response failed validation, e.g. a validator failed.
+	//	stream:     streaming RPC failed after the stream was established
+	//	            (only gRPC probe's SERVER_STREAMING method, and
+	//	            WEBSOCKET probe's message exchange).
+	//	other:      any other failure.
+	//
+	// QUIC probe additionally uses "version_negotiation" and "peer_closed"
+	// reasons for the QUIC specific handshake failures. EXTERNAL probe reports
+	// command timeouts as "timeout", validation failures as "validation" and
+	// all other failures (e.g. non-zero exit status) as "other".
+	// This is currently implemented only by HTTP, TCP, DNS, GRPC, NTP,
+	// WEBSOCKET, QUIC and EXTERNAL probes. PING and UDP probes don't support
+	// it, as their failures are lost packets, i.e. always timeouts, which are
+	// already reflected in the total and success counters.
+	ExportFailureReasons *bool `protobuf:"varint,36,opt,name=export_failure_reasons,json=exportFailureReasons" json:"export_failure_reasons,omitempty"`
+	// If set, the order in which targets are probed is shuffled in every probe
+	// cycle. By default, targets are probed in the same order in each cycle,
+	// which may bias the per-target results if a shared dependency degrades
+	// over the course of a cycle, e.g. the same early targets always see the
+	// effects of the overload first.
+	// This is currently implemented only by PING, UDP and HTTP probes. For
+	// HTTP probes, it's effective only with max_concurrent_probes, as
+	// otherwise each target is probed in its own independent loop.
+	RandomizeTargetOrder *bool `protobuf:"varint,40,opt,name=randomize_target_order,json=randomizeTargetOrder" json:"randomize_target_order,omitempty"`
 	// Alerts configuration. If specified, cloudprober will generate alerts on
 	// probe failures. You can specify multiple alerts.
 	// Example:
@@ -443,6 +590,9 @@ type ProbeDef struct {
 	//	*ProbeDef_UdpListenerProbe
 	//	*ProbeDef_GrpcProbe
 	//	*ProbeDef_TcpProbe
+	//	*ProbeDef_QuicProbe
+	//	*ProbeDef_NtpProbe
+	//	*ProbeDef_WebsocketProbe
 	//	*ProbeDef_UserDefinedProbe
 	Probe isProbeDef_Probe `protobuf_oneof:"probe"`
 	// Which machines this probe should run on. If defined, cloudprober will run
@@ -456,7 +606,9 @@ type ProbeDef struct {
 	//
 	// You can specify multiple schedules. Probe will not run if any of the
 	// "DISABLE" schedules are active. If both "ENABLE" and "DISABLE" schedules
-	// overlap, "DISABLE" takes precedence.
+	// overlap, "DISABLE" takes precedence. Runs skipped because of the schedule
+	// don't show up as failures. HTTP, TCP, NTP, WEBSOCKET and QUIC probes also
+	// report them through the "suppressed_runs" metric.
 	//
 	// For example, to disable a probe during weekends and on Tuesday between 7pm
 	// and 8pm, e.g. for rollouts:
@@ -486,6 +638,7 @@ type ProbeDef struct {
 const (
 	Default_ProbeDef_LatencyUnit       = string("us")
 	Default_ProbeDef_LatencyMetricName = string("latency")
+	Default_ProbeDef_ValidatorSetLabel = string("validator_set")
 )
 
 func (x *ProbeDef) Reset() {
@@ -576,6 +729,13 @@ func (x *ProbeDef) GetLatencyDistribution() *proto1.Dist {
 	return nil
 }
 
+func (x *ProbeDef) GetLatencySummary() *proto1.Summary {
+	if x != nil {
+		return x.LatencySummary
+	}
+	return nil
+}
+
 func (x *ProbeDef) GetLatencyUnit() string {
 	if x != nil && x.LatencyUnit != nil {
 		return *x.LatencyUnit
@@ -597,6 +757,27 @@ func (x *ProbeDef) GetValidator() []*proto2.Validator {
 	return nil
 }
 
+func (x *ProbeDef) GetValidatorSet() []*ProbeDef_ValidatorSet {
+	if x != nil {
+		return x.ValidatorSet
+	}
+	return nil
+}
+
+func (x *ProbeDef) GetValidatorSetLabel() string {
+	if x != nil && x.ValidatorSetLabel != nil {
+		return *x.ValidatorSetLabel
+	}
+	return Default_ProbeDef_ValidatorSetLabel
+}
+
+func (x *ProbeDef) GetExportValidationSuccess() bool {
+	if x != nil && x.ExportValidationSuccess != nil {
+		return *x.ExportValidationSuccess
+	}
+	return false
+}
+
 func (m *ProbeDef) GetSourceIpConfig() isProbeDef_SourceIpConfig {
 	if m != nil {
 		return m.SourceIpConfig
@@ -646,6 +827,76 @@ func (x *ProbeDef) GetNegativeTest() bool {
 	return false
 }
 
+func (x *ProbeDef) GetDscp() int32 {
+	if x != nil && x.Dscp != nil {
+		return *x.Dscp
+	}
+	return 0
+}
+
+func (x *ProbeDef) GetMaxRequestsPerSec() float32 {
+	if x != nil && x.MaxRequestsPerSec != nil {
+		return *x.MaxRequestsPerSec
+	}
+	return 0
+}
+
+func (x *ProbeDef) GetMetricsPrefix() string {
+	if x != nil && x.MetricsPrefix != nil {
+		return *x.MetricsPrefix
+	}
+	return ""
+}
+
+func (x *ProbeDef) GetStableStateRuns() int32 {
+	if x != nil && x.StableStateRuns != nil {
+		return *x.StableStateRuns
+	}
+	return 0
+}
+
+func (x *ProbeDef) GetAvailability() *ProbeDef_Availability {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *ProbeDef) GetAggregateDistributionsAcrossTargets() bool {
+	if x != nil && x.AggregateDistributionsAcrossTargets != nil {
+		return *x.AggregateDistributionsAcrossTargets
+	}
+	return false
+}
+
+func (x *ProbeDef) GetAggregateCountersAcrossTargets() bool {
+	if x != nil && x.AggregateCountersAcrossTargets != nil {
+		return *x.AggregateCountersAcrossTargets
+	}
+	return false
+}
+
+func (x *ProbeDef) GetChangedTargetsOnly() *ProbeDef_ChangedTargetsOnly {
+	if x != nil {
+		return x.ChangedTargetsOnly
+	}
+	return nil
+}
+
+func (x *ProbeDef) GetExportFailureReasons() bool {
+	if x != nil && x.ExportFailureReasons != nil {
+		return *x.ExportFailureReasons
+	}
+	return false
+}
+
+func (x *ProbeDef) GetRandomizeTargetOrder() bool {
+	if x != nil && x.RandomizeTargetOrder != nil {
+		return *x.RandomizeTargetOrder
+	}
+	return false
+}
+
 func (x *ProbeDef) GetAlert() []*proto3.AlertConf {
 	if x != nil {
 		return x.Alert
@@ -716,6 +967,27 @@ func (x *ProbeDef) GetTcpProbe() *proto11.ProbeConf {
 	return nil
 }
 
+func (x *ProbeDef) GetQuicProbe() *proto12.ProbeConf {
+	if x, ok := x.GetProbe().(*ProbeDef_QuicProbe); ok {
+		return x.QuicProbe
+	}
+	return nil
+}
+
+func (x *ProbeDef) GetNtpProbe() *proto13.ProbeConf {
+	if x, ok := x.GetProbe().(*ProbeDef_NtpProbe); ok {
+		return x.NtpProbe
+	}
+	return nil
+}
+
+func (x *ProbeDef) GetWebsocketProbe() *proto14.ProbeConf {
+	if x, ok := x.GetProbe().(*ProbeDef_WebsocketProbe); ok {
+		return x.WebsocketProbe
+	}
+	return nil
+}
+
 func (x *ProbeDef) GetUserDefinedProbe() string {
 	if x, ok := x.GetProbe().(*ProbeDef_UserDefinedProbe); ok {
 		return x.UserDefinedProbe
@@ -796,6 +1068,18 @@ type ProbeDef_TcpProbe struct {
 	TcpProbe *proto11.ProbeConf `protobuf:"bytes,27,opt,name=tcp_probe,json=tcpProbe,oneof"`
 }
 
+type ProbeDef_QuicProbe struct {
+	QuicProbe *proto12.ProbeConf `protobuf:"bytes,35,opt,name=quic_probe,json=quicProbe,oneof"`
+}
+
+type ProbeDef_NtpProbe struct {
+	NtpProbe *proto13.ProbeConf `protobuf:"bytes,39,opt,name=ntp_probe,json=ntpProbe,oneof"`
+}
+
+type ProbeDef_WebsocketProbe struct {
+	WebsocketProbe *proto14.ProbeConf `protobuf:"bytes,44,opt,name=websocket_probe,json=websocketProbe,oneof"`
+}
+
 type ProbeDef_UserDefinedProbe struct {
 	// This field's contents are passed on to the user defined probe,
 	// registered for this probe's name through probes.RegisterUserDefined().
@@ -818,6 +1102,12 @@ func (*ProbeDef_GrpcProbe) isProbeDef_Probe() {}
 
 func (*ProbeDef_TcpProbe) isProbeDef_Probe() {}
 
+func (*ProbeDef_QuicProbe) isProbeDef_Probe() {}
+
+func (*ProbeDef_NtpProbe) isProbeDef_Probe() {}
+
+func (*ProbeDef_WebsocketProbe) isProbeDef_Probe() {}
+
 func (*ProbeDef_UserDefinedProbe) isProbeDef_Probe() {}
 
 type AdditionalLabel struct {
@@ -895,6 +1185,20 @@ type Schedule struct {
 	// Timezone in which the probe should run. If not specified, it defaults to
 	// UTC. Example: "America/New_York"
 	Timezone *string `protobuf:"bytes,6,opt,name=timezone,def=UTC" json:"timezone,omitempty"`
+	// Optional date range, in YYYY-MM-DD format, to which this schedule is
+	// limited. Both dates are inclusive and are interpreted in the schedule's
+	// timezone. This is useful for one-off maintenance windows, e.g.:
+	//
+	//	schedule {
+	//	  type: DISABLE
+	//	  start_time: "01:00"
+	//	  end_time: "03:00"
+	//	  start_date: "2024-06-15"
+	//	  end_date: "2024-06-16"
+	//	  timezone: "Europe/London"
+	//	}
+	StartDate *string `protobuf:"bytes,7,opt,name=start_date,json=startDate" json:"start_date,omitempty"`
+	EndDate   *string `protobuf:"bytes,8,opt,name=end_date,json=endDate" json:"end_date,omitempty"`
 }
 
 // Default values for Schedule fields.
@@ -980,6 +1284,20 @@ func (x *Schedule) GetTimezone() string {
 	return Default_Schedule_Timezone
 }
 
+func (x *Schedule) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *Schedule) GetEndDate() string {
+	if x != nil && x.EndDate != nil {
+		return *x.EndDate
+	}
+	return ""
+}
+
 type DebugOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1028,6 +1346,194 @@ func (x *DebugOptions) GetLogMetrics() bool {
 	return false
 }
 
+// Validator sets, selected per target through the target label specified
+// by validator_set_label. This is useful if targets of the same probe
+// need different validations, e.g. some endpoints return XML and others
+// return JSON. Targets without the label use the probe's validators
+// (see above). For example:
+//
+//	validator_set {
+//	  name: "json"
+//	  validator {
+//	    name: "valid-json"
+//	    json_validator {}
+//	  }
+//	}
+//
+// This is currently implemented only by HTTP and DNS probes.
+type ProbeDef_ValidatorSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      *string             `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Validator []*proto2.Validator `protobuf:"bytes,2,rep,name=validator" json:"validator,omitempty"`
+}
+
+func (x *ProbeDef_ValidatorSet) Reset() {
+	*x = ProbeDef_ValidatorSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeDef_ValidatorSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeDef_ValidatorSet) ProtoMessage() {}
+
+func (x *ProbeDef_ValidatorSet) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeDef_ValidatorSet.ProtoReflect.Descriptor instead.
+func (*ProbeDef_ValidatorSet) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ProbeDef_ValidatorSet) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ProbeDef_ValidatorSet) GetValidator() []*proto2.Validator {
+	if x != nil {
+		return x.Validator
+	}
+	return nil
+}
+
+type ProbeDef_Availability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rolling window over which availability is computed.
+	WindowSec *int32 `protobuf:"varint,1,opt,name=window_sec,json=windowSec,def=3600" json:"window_sec,omitempty"`
+	// How often availability is recomputed. Runs are also bucketed at this
+	// granularity, i.e. the window slides in these steps.
+	UpdateIntervalSec *int32 `protobuf:"varint,2,opt,name=update_interval_sec,json=updateIntervalSec,def=60" json:"update_interval_sec,omitempty"`
+}
+
+// Default values for ProbeDef_Availability fields.
+const (
+	Default_ProbeDef_Availability_WindowSec         = int32(3600)
+	Default_ProbeDef_Availability_UpdateIntervalSec = int32(60)
+)
+
+func (x *ProbeDef_Availability) Reset() {
+	*x = ProbeDef_Availability{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeDef_Availability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeDef_Availability) ProtoMessage() {}
+
+func (x *ProbeDef_Availability) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeDef_Availability.ProtoReflect.Descriptor instead.
+func (*ProbeDef_Availability) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ProbeDef_Availability) GetWindowSec() int32 {
+	if x != nil && x.WindowSec != nil {
+		return *x.WindowSec
+	}
+	return Default_ProbeDef_Availability_WindowSec
+}
+
+func (x *ProbeDef_Availability) GetUpdateIntervalSec() int32 {
+	if x != nil && x.UpdateIntervalSec != nil {
+		return *x.UpdateIntervalSec
+	}
+	return Default_ProbeDef_Availability_UpdateIntervalSec
+}
+
+type ProbeDef_ChangedTargetsOnly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// All targets are probed at least once in this interval, even if they
+	// haven't changed.
+	FullSweepIntervalSec *int32 `protobuf:"varint,1,opt,name=full_sweep_interval_sec,json=fullSweepIntervalSec,def=3600" json:"full_sweep_interval_sec,omitempty"`
+}
+
+// Default values for ProbeDef_ChangedTargetsOnly fields.
+const (
+	Default_ProbeDef_ChangedTargetsOnly_FullSweepIntervalSec = int32(3600)
+)
+
+func (x *ProbeDef_ChangedTargetsOnly) Reset() {
+	*x = ProbeDef_ChangedTargetsOnly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeDef_ChangedTargetsOnly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeDef_ChangedTargetsOnly) ProtoMessage() {}
+
+func (x *ProbeDef_ChangedTargetsOnly) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeDef_ChangedTargetsOnly.ProtoReflect.Descriptor instead.
+func (*ProbeDef_ChangedTargetsOnly) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *ProbeDef_ChangedTargetsOnly) GetFullSweepIntervalSec() int32 {
+	if x != nil && x.FullSweepIntervalSec != nil {
+		return *x.FullSweepIntervalSec
+	}
+	return Default_ProbeDef_ChangedTargetsOnly_FullSweepIntervalSec
+}
+
 var File_github_com_cloudprober_cloudprober_probes_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDesc = []byte{
@@ -1061,124 +1567,205 @@ var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDesc =
 	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f,
 	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f,
 	0x68, 0x74, 0x74, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
 	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
 	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x40, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x74, 0x63, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x40, 0x67, 0x69,
+	0x65, 0x73, 0x2f, 0x6e, 0x74, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2f, 0x70, 0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x41, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75, 0x64, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x48,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75, 0x64, 0x70, 0x6c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x71, 0x75, 0x69, 0x63, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x74, 0x63, 0x70, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75, 0x64, 0x70,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xea, 0x0e, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x02,
-	0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x75,
+	0x64, 0x70, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x46, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x3e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xf0, 0x19, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0e, 0x32,
+	0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x2e, 0x54, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x73, 0x44, 0x65, 0x66, 0x52, 0x07, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x12, 0x4c, 0x0a, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x64, 0x69, 0x73,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x52, 0x13, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x45, 0x0a, 0x0f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x0e, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x53,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x25, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x02, 0x75, 0x73,
+	0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x37, 0x0a,
+	0x13, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x07, 0x6c, 0x61, 0x74, 0x65,
+	0x6e, 0x63, 0x79, 0x52, 0x11, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4e, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x29, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66, 0x2e, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0c, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x3d, 0x0a, 0x13, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x2a,
+	0x20, 0x01, 0x28, 0x09, 0x3a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f,
+	0x73, 0x65, 0x74, 0x52, 0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65,
+	0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x3a, 0x0a, 0x19, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x65, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x1d, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x70, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49,
+	0x70, 0x12, 0x2b, 0x0a, 0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12, 0x45,
+	0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
 	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66,
-	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73, 0x65, 0x63,
-	0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x10, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x65, 0x63, 0x12,
-	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x39, 0x0a, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73,
-	0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x44, 0x65, 0x66, 0x52, 0x07, 0x74, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x12, 0x4c, 0x0a, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
-	0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
-	0x2e, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2e, 0x44, 0x69, 0x73, 0x74, 0x52, 0x13, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0c, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x75, 0x6e,
-	0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x02, 0x75, 0x73, 0x52, 0x0b, 0x6c, 0x61,
-	0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x13, 0x6c, 0x61, 0x74,
-	0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x52,
-	0x11, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4e, 0x61,
-	0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18,
-	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x56,
-	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x70,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x49, 0x70, 0x12, 0x2b, 0x0a, 0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x12,
-	0x45, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65,
-	0x66, 0x2e, 0x49, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x69, 0x70, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f,
-	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f,
-	0x6d, 0x73, 0x65, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d,
-	0x73, 0x65, 0x63, 0x12, 0x4e, 0x0a, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61,
-	0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f,
-	0x74, 0x65, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x76, 0x65, 0x54, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x61, 0x6c, 0x65, 0x72,
-	0x74, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x41,
-	0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x12,
-	0x43, 0x0a, 0x0a, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x14, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x70, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x72,
-	0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x09, 0x70, 0x69, 0x6e, 0x67, 0x50,
-	0x72, 0x6f, 0x62, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x68, 0x74,
-	0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x09,
-	0x68, 0x74, 0x74, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x64, 0x6e, 0x73,
-	0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63,
+	0x2e, 0x49, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x69, 0x70, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x1a, 0x73, 0x74, 0x61, 0x74, 0x73, 0x5f, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x6d,
+	0x73, 0x65, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x4d, 0x73,
+	0x65, 0x63, 0x12, 0x4e, 0x0a, 0x10, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63,
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x73, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48,
-	0x01, 0x52, 0x08, 0x64, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x65,
-	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x17, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x0d, 0x65,
-	0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x40, 0x0a, 0x09,
-	0x75, 0x64, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x18, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x75, 0x64, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f,
-	0x6e, 0x66, 0x48, 0x01, 0x52, 0x08, 0x75, 0x64, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x59,
-	0x0a, 0x12, 0x75, 0x64, 0x70, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x5f, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e,
-	0x75, 0x64, 0x70, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x62,
-	0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x10, 0x75, 0x64, 0x70, 0x4c, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x67, 0x72, 0x70,
-	0x63, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x73, 0x2e, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x65, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x76, 0x65, 0x54, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x73, 0x63, 0x70, 0x18,
+	0x1c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x73, 0x63, 0x70, 0x12, 0x2f, 0x0a, 0x14, 0x6d,
+	0x61, 0x78, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f,
+	0x73, 0x65, 0x63, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x12, 0x25, 0x0a, 0x0e,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x1e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f,
+	0x73, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x75, 0x6e, 0x73, 0x12,
+	0x4d, 0x0a, 0x0c, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x44, 0x65, 0x66, 0x2e, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x0c, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x53,
+	0x0a, 0x26, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x61, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x08, 0x52, 0x23,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x21, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x63, 0x72, 0x6f, 0x73, 0x73,
+	0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1e,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x41, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x12, 0x61,
+	0x0a, 0x14, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x73, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x44, 0x65, 0x66, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x52, 0x12, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4f, 0x6e, 0x6c,
+	0x79, 0x12, 0x34, 0x0a, 0x16, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x24, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x14, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x61, 0x6e, 0x64, 0x6f,
+	0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x18, 0x28, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69,
+	0x7a, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x35, 0x0a,
+	0x05, 0x61, 0x6c, 0x65, 0x72, 0x74, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x61, 0x6c, 0x65, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x41, 0x6c, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x52, 0x05, 0x61,
+	0x6c, 0x65, 0x72, 0x74, 0x12, 0x43, 0x0a, 0x0a, 0x70, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x70, 0x69,
+	0x6e, 0x67, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x09,
+	0x70, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x68, 0x74, 0x74,
+	0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
 	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x73, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e,
-	0x66, 0x48, 0x01, 0x52, 0x09, 0x67, 0x72, 0x70, 0x63, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x40,
-	0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28,
+	0x65, 0x73, 0x2e, 0x68, 0x74, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x48, 0x01, 0x52, 0x09, 0x68, 0x74, 0x74, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x40,
+	0x0a, 0x09, 0x64, 0x6e, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65,
-	0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x08, 0x74, 0x63, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x64, 0x6e, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x08, 0x64, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x62, 0x65,
+	0x12, 0x4f, 0x0a, 0x0e, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x65, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x48, 0x01, 0x52, 0x0d, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x62,
+	0x65, 0x12, 0x40, 0x0a, 0x09, 0x75, 0x64, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x75, 0x64, 0x70, 0x2e, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x08, 0x75, 0x64, 0x70, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x12, 0x59, 0x0a, 0x12, 0x75, 0x64, 0x70, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x75, 0x64, 0x70, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x10, 0x75, 0x64,
+	0x70, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x43,
+	0x0a, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x1a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x72, 0x6f,
+	0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x09, 0x67, 0x72, 0x70, 0x63, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70, 0x2e,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52, 0x08, 0x74, 0x63, 0x70,
+	0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x71, 0x75, 0x69, 0x63, 0x5f, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x71,
+	0x75, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01, 0x52,
+	0x09, 0x71, 0x75, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x6e, 0x74,
+	0x70, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x27, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x73, 0x2e, 0x6e, 0x74, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66,
+	0x48, 0x01, 0x52, 0x08, 0x6e, 0x74, 0x70, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x52, 0x0a, 0x0f,
+	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18,
+	0x2c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x77, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x01,
+	0x52, 0x0e, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x62, 0x65,
 	0x12, 0x2e, 0x0a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64,
 	0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x63, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x10,
 	0x75, 0x73, 0x65, 0x72, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x62, 0x65,
@@ -1191,65 +1778,89 @@ var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDesc =
 	0x6e, 0x73, 0x18, 0x64, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
 	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x44, 0x65,
 	0x62, 0x75, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0c, 0x64, 0x65, 0x62, 0x75,
-	0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48,
-	0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x10, 0x02, 0x12, 0x0c,
-	0x0a, 0x08, 0x45, 0x58, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03,
-	0x55, 0x44, 0x50, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x44, 0x50, 0x5f, 0x4c, 0x49, 0x53,
-	0x54, 0x45, 0x4e, 0x45, 0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43, 0x10,
-	0x06, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x07, 0x12, 0x0d, 0x0a, 0x09, 0x45, 0x58,
-	0x54, 0x45, 0x4e, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x62, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x53, 0x45,
-	0x52, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x63, 0x22, 0x3b, 0x0a, 0x09, 0x49,
-	0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x16, 0x49, 0x50, 0x5f, 0x56,
-	0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x34, 0x10, 0x01, 0x12, 0x08,
-	0x0a, 0x04, 0x49, 0x50, 0x56, 0x36, 0x10, 0x02, 0x2a, 0x09, 0x08, 0xc8, 0x01, 0x10, 0x80, 0x80,
-	0x80, 0x80, 0x02, 0x42, 0x12, 0x0a, 0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x70,
-	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x07, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x22, 0x39, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x94, 0x04, 0x0a, 0x08,
-	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65,
-	0x64, 0x75, 0x6c, 0x65, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x53, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24,
-	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x57, 0x65, 0x65,
-	0x6b, 0x64, 0x61, 0x79, 0x3a, 0x08, 0x45, 0x56, 0x45, 0x52, 0x59, 0x44, 0x41, 0x59, 0x52, 0x0c,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x24, 0x0a, 0x0a,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x3a, 0x05, 0x30, 0x30, 0x3a, 0x30, 0x30, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x4f, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68,
-	0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x3a, 0x08, 0x45,
-	0x56, 0x45, 0x52, 0x59, 0x44, 0x41, 0x59, 0x52, 0x0a, 0x65, 0x6e, 0x64, 0x57, 0x65, 0x65, 0x6b,
-	0x64, 0x61, 0x79, 0x12, 0x20, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x05, 0x32, 0x33, 0x3a, 0x35, 0x39, 0x52, 0x07, 0x65, 0x6e,
-	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x03, 0x55, 0x54, 0x43, 0x52, 0x08, 0x74, 0x69,
-	0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x22, 0x73, 0x0a, 0x07, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61,
-	0x79, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x45, 0x52, 0x59, 0x44, 0x41, 0x59, 0x10, 0x00, 0x12,
-	0x0a, 0x0a, 0x06, 0x53, 0x55, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d,
-	0x4f, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x55, 0x45, 0x53, 0x44,
-	0x41, 0x59, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x45, 0x44, 0x4e, 0x45, 0x53, 0x44, 0x41,
-	0x59, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x48, 0x55, 0x52, 0x53, 0x44, 0x41, 0x59, 0x10,
-	0x05, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x52, 0x49, 0x44, 0x41, 0x59, 0x10, 0x06, 0x12, 0x0c, 0x0a,
-	0x08, 0x53, 0x41, 0x54, 0x55, 0x52, 0x44, 0x41, 0x59, 0x10, 0x07, 0x22, 0x45, 0x0a, 0x0c, 0x53,
-	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x53,
-	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x4e, 0x41,
-	0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45,
-	0x10, 0x02, 0x22, 0x2f, 0x0a, 0x0c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x63, 0x0a, 0x0c, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3f, 0x0a, 0x09,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x67, 0x0a,
+	0x0c, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x23, 0x0a,
+	0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x3a, 0x04, 0x33, 0x36, 0x30, 0x30, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53,
+	0x65, 0x63, 0x12, 0x32, 0x0a, 0x13, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a,
+	0x02, 0x36, 0x30, 0x52, 0x11, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x1a, 0x51, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x3b, 0x0a, 0x17,
+	0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x33,
+	0x36, 0x30, 0x30, 0x52, 0x14, 0x66, 0x75, 0x6c, 0x6c, 0x53, 0x77, 0x65, 0x65, 0x70, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x22, 0xa2, 0x01, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4e, 0x53, 0x10, 0x02, 0x12,
+	0x0c, 0x0a, 0x08, 0x45, 0x58, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a,
+	0x03, 0x55, 0x44, 0x50, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x44, 0x50, 0x5f, 0x4c, 0x49,
+	0x53, 0x54, 0x45, 0x4e, 0x45, 0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52, 0x50, 0x43,
+	0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x43, 0x50, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x51,
+	0x55, 0x49, 0x43, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x54, 0x50, 0x10, 0x09, 0x12, 0x0d,
+	0x0a, 0x09, 0x57, 0x45, 0x42, 0x53, 0x4f, 0x43, 0x4b, 0x45, 0x54, 0x10, 0x0a, 0x12, 0x0d, 0x0a,
+	0x09, 0x45, 0x58, 0x54, 0x45, 0x4e, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x62, 0x12, 0x10, 0x0a, 0x0c,
+	0x55, 0x53, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x63, 0x22, 0x3b,
+	0x0a, 0x09, 0x49, 0x50, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x16, 0x49,
+	0x50, 0x5f, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x34, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x56, 0x36, 0x10, 0x02, 0x2a, 0x09, 0x08, 0xc8, 0x01,
+	0x10, 0x80, 0x80, 0x80, 0x80, 0x02, 0x42, 0x12, 0x0a, 0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x69, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x07, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x22, 0x39, 0x0a, 0x0f, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x02, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xce,
+	0x04, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x53,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x53, 0x0a, 0x0d, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x77, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e,
+	0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x3a, 0x08, 0x45, 0x56, 0x45, 0x52, 0x59, 0x44, 0x41,
+	0x59, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x72, 0x74, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12,
+	0x24, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x3a, 0x05, 0x30, 0x30, 0x3a, 0x30, 0x30, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x4f, 0x0a, 0x0b, 0x65, 0x6e, 0x64, 0x5f, 0x77, 0x65, 0x65,
+	0x6b, 0x64, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x2e, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79,
+	0x3a, 0x08, 0x45, 0x56, 0x45, 0x52, 0x59, 0x44, 0x41, 0x59, 0x52, 0x0a, 0x65, 0x6e, 0x64, 0x57,
+	0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x20, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x05, 0x32, 0x33, 0x3a, 0x35, 0x39, 0x52,
+	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x03, 0x55, 0x54, 0x43, 0x52,
+	0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x22, 0x73, 0x0a, 0x07, 0x57, 0x65, 0x65, 0x6b, 0x64, 0x61, 0x79, 0x12, 0x0c,
+	0x0a, 0x08, 0x45, 0x56, 0x45, 0x52, 0x59, 0x44, 0x41, 0x59, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x55, 0x4e, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x4e, 0x44,
+	0x41, 0x59, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x54, 0x55, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10,
+	0x03, 0x12, 0x0d, 0x0a, 0x09, 0x57, 0x45, 0x44, 0x4e, 0x45, 0x53, 0x44, 0x41, 0x59, 0x10, 0x04,
+	0x12, 0x0c, 0x0a, 0x08, 0x54, 0x48, 0x55, 0x52, 0x53, 0x44, 0x41, 0x59, 0x10, 0x05, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x52, 0x49, 0x44, 0x41, 0x59, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x41,
+	0x54, 0x55, 0x52, 0x44, 0x41, 0x59, 0x10, 0x07, 0x22, 0x45, 0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x45, 0x4e, 0x41, 0x42, 0x4c, 0x45,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x22,
+	0x2f, 0x0a, 0x0c, 0x44, 0x65, 0x62, 0x75, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -1265,55 +1876,70 @@ func file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDescGZ
 }
 
 var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_goTypes = []any{
-	(ProbeDef_Type)(0),         // 0: cloudprober.probes.ProbeDef.Type
-	(ProbeDef_IPVersion)(0),    // 1: cloudprober.probes.ProbeDef.IPVersion
-	(Schedule_Weekday)(0),      // 2: cloudprober.probes.Schedule.Weekday
-	(Schedule_ScheduleType)(0), // 3: cloudprober.probes.Schedule.ScheduleType
-	(*ProbeDef)(nil),           // 4: cloudprober.probes.ProbeDef
-	(*AdditionalLabel)(nil),    // 5: cloudprober.probes.AdditionalLabel
-	(*Schedule)(nil),           // 6: cloudprober.probes.Schedule
-	(*DebugOptions)(nil),       // 7: cloudprober.probes.DebugOptions
-	(*proto.TargetsDef)(nil),   // 8: cloudprober.targets.TargetsDef
-	(*proto1.Dist)(nil),        // 9: cloudprober.metrics.Dist
-	(*proto2.Validator)(nil),   // 10: cloudprober.validators.Validator
-	(*proto3.AlertConf)(nil),   // 11: cloudprober.alerting.AlertConf
-	(*proto4.ProbeConf)(nil),   // 12: cloudprober.probes.ping.ProbeConf
-	(*proto5.ProbeConf)(nil),   // 13: cloudprober.probes.http.ProbeConf
-	(*proto6.ProbeConf)(nil),   // 14: cloudprober.probes.dns.ProbeConf
-	(*proto7.ProbeConf)(nil),   // 15: cloudprober.probes.external.ProbeConf
-	(*proto8.ProbeConf)(nil),   // 16: cloudprober.probes.udp.ProbeConf
-	(*proto9.ProbeConf)(nil),   // 17: cloudprober.probes.udplistener.ProbeConf
-	(*proto10.ProbeConf)(nil),  // 18: cloudprober.probes.grpc.ProbeConf
-	(*proto11.ProbeConf)(nil),  // 19: cloudprober.probes.tcp.ProbeConf
+	(ProbeDef_Type)(0),                  // 0: cloudprober.probes.ProbeDef.Type
+	(ProbeDef_IPVersion)(0),             // 1: cloudprober.probes.ProbeDef.IPVersion
+	(Schedule_Weekday)(0),               // 2: cloudprober.probes.Schedule.Weekday
+	(Schedule_ScheduleType)(0),          // 3: cloudprober.probes.Schedule.ScheduleType
+	(*ProbeDef)(nil),                    // 4: cloudprober.probes.ProbeDef
+	(*AdditionalLabel)(nil),             // 5: cloudprober.probes.AdditionalLabel
+	(*Schedule)(nil),                    // 6: cloudprober.probes.Schedule
+	(*DebugOptions)(nil),                // 7: cloudprober.probes.DebugOptions
+	(*ProbeDef_ValidatorSet)(nil),       // 8: cloudprober.probes.ProbeDef.ValidatorSet
+	(*ProbeDef_Availability)(nil),       // 9: cloudprober.probes.ProbeDef.Availability
+	(*ProbeDef_ChangedTargetsOnly)(nil), // 10: cloudprober.probes.ProbeDef.ChangedTargetsOnly
+	(*proto.TargetsDef)(nil),            // 11: cloudprober.targets.TargetsDef
+	(*proto1.Dist)(nil),                 // 12: cloudprober.metrics.Dist
+	(*proto1.Summary)(nil),              // 13: cloudprober.metrics.Summary
+	(*proto2.Validator)(nil),            // 14: cloudprober.validators.Validator
+	(*proto3.AlertConf)(nil),            // 15: cloudprober.alerting.AlertConf
+	(*proto4.ProbeConf)(nil),            // 16: cloudprober.probes.ping.ProbeConf
+	(*proto5.ProbeConf)(nil),            // 17: cloudprober.probes.http.ProbeConf
+	(*proto6.ProbeConf)(nil),            // 18: cloudprober.probes.dns.ProbeConf
+	(*proto7.ProbeConf)(nil),            // 19: cloudprober.probes.external.ProbeConf
+	(*proto8.ProbeConf)(nil),            // 20: cloudprober.probes.udp.ProbeConf
+	(*proto9.ProbeConf)(nil),            // 21: cloudprober.probes.udplistener.ProbeConf
+	(*proto10.ProbeConf)(nil),           // 22: cloudprober.probes.grpc.ProbeConf
+	(*proto11.ProbeConf)(nil),           // 23: cloudprober.probes.tcp.ProbeConf
+	(*proto12.ProbeConf)(nil),           // 24: cloudprober.probes.quic.ProbeConf
+	(*proto13.ProbeConf)(nil),           // 25: cloudprober.probes.ntp.ProbeConf
+	(*proto14.ProbeConf)(nil),           // 26: cloudprober.probes.websocket.ProbeConf
 }
 var file_github_com_cloudprober_cloudprober_probes_proto_config_proto_depIdxs = []int32{
 	0,  // 0: cloudprober.probes.ProbeDef.type:type_name -> cloudprober.probes.ProbeDef.Type
-	8,  // 1: cloudprober.probes.ProbeDef.targets:type_name -> cloudprober.targets.TargetsDef
-	9,  // 2: cloudprober.probes.ProbeDef.latency_distribution:type_name -> cloudprober.metrics.Dist
-	10, // 3: cloudprober.probes.ProbeDef.validator:type_name -> cloudprober.validators.Validator
-	1,  // 4: cloudprober.probes.ProbeDef.ip_version:type_name -> cloudprober.probes.ProbeDef.IPVersion
-	5,  // 5: cloudprober.probes.ProbeDef.additional_label:type_name -> cloudprober.probes.AdditionalLabel
-	11, // 6: cloudprober.probes.ProbeDef.alert:type_name -> cloudprober.alerting.AlertConf
-	12, // 7: cloudprober.probes.ProbeDef.ping_probe:type_name -> cloudprober.probes.ping.ProbeConf
-	13, // 8: cloudprober.probes.ProbeDef.http_probe:type_name -> cloudprober.probes.http.ProbeConf
-	14, // 9: cloudprober.probes.ProbeDef.dns_probe:type_name -> cloudprober.probes.dns.ProbeConf
-	15, // 10: cloudprober.probes.ProbeDef.external_probe:type_name -> cloudprober.probes.external.ProbeConf
-	16, // 11: cloudprober.probes.ProbeDef.udp_probe:type_name -> cloudprober.probes.udp.ProbeConf
-	17, // 12: cloudprober.probes.ProbeDef.udp_listener_probe:type_name -> cloudprober.probes.udplistener.ProbeConf
-	18, // 13: cloudprober.probes.ProbeDef.grpc_probe:type_name -> cloudprober.probes.grpc.ProbeConf
-	19, // 14: cloudprober.probes.ProbeDef.tcp_probe:type_name -> cloudprober.probes.tcp.ProbeConf
-	6,  // 15: cloudprober.probes.ProbeDef.schedule:type_name -> cloudprober.probes.Schedule
-	7,  // 16: cloudprober.probes.ProbeDef.debug_options:type_name -> cloudprober.probes.DebugOptions
-	3,  // 17: cloudprober.probes.Schedule.type:type_name -> cloudprober.probes.Schedule.ScheduleType
-	2,  // 18: cloudprober.probes.Schedule.start_weekday:type_name -> cloudprober.probes.Schedule.Weekday
-	2,  // 19: cloudprober.probes.Schedule.end_weekday:type_name -> cloudprober.probes.Schedule.Weekday
-	20, // [20:20] is the sub-list for method output_type
-	20, // [20:20] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	11, // 1: cloudprober.probes.ProbeDef.targets:type_name -> cloudprober.targets.TargetsDef
+	12, // 2: cloudprober.probes.ProbeDef.latency_distribution:type_name -> cloudprober.metrics.Dist
+	13, // 3: cloudprober.probes.ProbeDef.latency_summary:type_name -> cloudprober.metrics.Summary
+	14, // 4: cloudprober.probes.ProbeDef.validator:type_name -> cloudprober.validators.Validator
+	8,  // 5: cloudprober.probes.ProbeDef.validator_set:type_name -> cloudprober.probes.ProbeDef.ValidatorSet
+	1,  // 6: cloudprober.probes.ProbeDef.ip_version:type_name -> cloudprober.probes.ProbeDef.IPVersion
+	5,  // 7: cloudprober.probes.ProbeDef.additional_label:type_name -> cloudprober.probes.AdditionalLabel
+	9,  // 8: cloudprober.probes.ProbeDef.availability:type_name -> cloudprober.probes.ProbeDef.Availability
+	10, // 9: cloudprober.probes.ProbeDef.changed_targets_only:type_name -> cloudprober.probes.ProbeDef.ChangedTargetsOnly
+	15, // 10: cloudprober.probes.ProbeDef.alert:type_name -> cloudprober.alerting.AlertConf
+	16, // 11: cloudprober.probes.ProbeDef.ping_probe:type_name -> cloudprober.probes.ping.ProbeConf
+	17, // 12: cloudprober.probes.ProbeDef.http_probe:type_name -> cloudprober.probes.http.ProbeConf
+	18, // 13: cloudprober.probes.ProbeDef.dns_probe:type_name -> cloudprober.probes.dns.ProbeConf
+	19, // 14: cloudprober.probes.ProbeDef.external_probe:type_name -> cloudprober.probes.external.ProbeConf
+	20, // 15: cloudprober.probes.ProbeDef.udp_probe:type_name -> cloudprober.probes.udp.ProbeConf
+	21, // 16: cloudprober.probes.ProbeDef.udp_listener_probe:type_name -> cloudprober.probes.udplistener.ProbeConf
+	22, // 17: cloudprober.probes.ProbeDef.grpc_probe:type_name -> cloudprober.probes.grpc.ProbeConf
+	23, // 18: cloudprober.probes.ProbeDef.tcp_probe:type_name -> cloudprober.probes.tcp.ProbeConf
+	24, // 19: cloudprober.probes.ProbeDef.quic_probe:type_name -> cloudprober.probes.quic.ProbeConf
+	25, // 20: cloudprober.probes.ProbeDef.ntp_probe:type_name -> cloudprober.probes.ntp.ProbeConf
+	26, // 21: cloudprober.probes.ProbeDef.websocket_probe:type_name -> cloudprober.probes.websocket.ProbeConf
+	6,  // 22: cloudprober.probes.ProbeDef.schedule:type_name -> cloudprober.probes.Schedule
+	7,  // 23: cloudprober.probes.ProbeDef.debug_options:type_name -> cloudprober.probes.DebugOptions
+	3,  // 24: cloudprober.probes.Schedule.type:type_name -> cloudprober.probes.Schedule.ScheduleType
+	2,  // 25: cloudprober.probes.Schedule.start_weekday:type_name -> cloudprober.probes.Schedule.Weekday
+	2,  // 26: cloudprober.probes.Schedule.end_weekday:type_name -> cloudprober.probes.Schedule.Weekday
+	14, // 27: cloudprober.probes.ProbeDef.ValidatorSet.validator:type_name -> cloudprober.validators.Validator
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_probes_proto_config_proto_init() }
@@ -1372,6 +1998,42 @@ func file_github_com_cloudprober_cloudprober_probes_proto_config_proto_init() {
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeDef_ValidatorSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeDef_Availability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeDef_ChangedTargetsOnly); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_github_com_cloudprober_cloudprober_probes_proto_config_proto_msgTypes[0].OneofWrappers = []any{
 		(*ProbeDef_SourceIp)(nil),
@@ -1384,6 +2046,9 @@ func file_github_com_cloudprober_cloudprober_probes_proto_config_proto_init() {
 		(*ProbeDef_UdpListenerProbe)(nil),
 		(*ProbeDef_GrpcProbe)(nil),
 		(*ProbeDef_TcpProbe)(nil),
+		(*ProbeDef_QuicProbe)(nil),
+		(*ProbeDef_NtpProbe)(nil),
+		(*ProbeDef_WebsocketProbe)(nil),
 		(*ProbeDef_UserDefinedProbe)(nil),
 	}
 	type x struct{}
@@ -1392,7 +2057,7 @@ func file_github_com_cloudprober_cloudprober_probes_proto_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_probes_proto_config_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,266 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quic implements a QUIC probe type. It measures the time it takes
+// to establish a QUIC connection, i.e., to complete the QUIC handshake.
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/iputils"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/common/sched"
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/quic/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"golang.org/x/net/quic"
+)
+
+// QUIC specific failure reasons, exported along with the standard failure
+// reasons if export_failure_reasons is set. See options.NewFailureReasons.
+const (
+	failureVersionNegotiation = "version_negotiation"
+	failurePeerClosed         = "peer_closed"
+)
+
+var defaultALPN = []string{"h3"}
+
+// Probe holds aggregate information about all probe runs, per-target.
+type Probe struct {
+	name string
+	opts *options.Options
+	c    *configpb.ProbeConf
+	l    *logger.Logger
+
+	// book-keeping params
+	network   string
+	tlsConfig *tls.Config
+	endpoint  *quic.Endpoint
+}
+
+type probeResult struct {
+	total, success int64
+	latency        metrics.LatencyValue
+	failures       *metrics.Map[int64]
+}
+
+func (p *Probe) newResult() sched.ProbeResult {
+	result := &probeResult{
+		failures: p.opts.NewFailureReasons(failureVersionNegotiation, failurePeerClosed),
+	}
+
+	result.latency = p.opts.NewLatencyValue()
+
+	return result
+}
+
+func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(result.total)).
+		AddMetric("success", metrics.NewInt(result.success)).
+		AddMetric(opts.LatencyMetricName, result.latency.Clone()).
+		AddLabel("ptype", "quic")
+	if result.failures != nil {
+		em.AddMetric("failures", result.failures.Clone())
+	}
+	return em
+}
+
+// Init initializes the probe with the given params.
+func (p *Probe) Init(name string, opts *options.Options) error {
+	if opts.ProbeConf == nil {
+		opts.ProbeConf = &configpb.ProbeConf{}
+	}
+
+	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
+	if !ok {
+		return fmt.Errorf("not quic probe config")
+	}
+	p.name = name
+	p.opts = opts
+	if p.l = opts.Logger; p.l == nil {
+		p.l = &logger.Logger{}
+	}
+	p.c = c
+
+	p.network = "udp"
+	if p.opts.IPVersion != 0 {
+		p.network += strconv.Itoa(p.opts.IPVersion)
+	}
+
+	p.tlsConfig = &tls.Config{}
+	if p.c.GetTlsConfig() != nil {
+		if err := tlsconfig.UpdateTLSConfig(p.tlsConfig, p.c.GetTlsConfig()); err != nil {
+			return err
+		}
+	}
+	// QUIC requires TLS 1.3.
+	p.tlsConfig.MinVersion = tls.VersionTLS13
+	p.tlsConfig.MaxVersion = 0
+	p.tlsConfig.NextProtos = p.c.GetAlpn()
+	if len(p.tlsConfig.NextProtos) == 0 {
+		p.tlsConfig.NextProtos = defaultALPN
+	}
+
+	localAddr := ":0"
+	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
+		localAddr = net.JoinHostPort(p.opts.SourceIP.String(), "0")
+	}
+
+	// We use the same endpoint (UDP socket) for all connections. Endpoint
+	// doesn't accept incoming connections as we don't provide a listen
+	// config.
+	ep, err := quic.Listen(p.network, localAddr, nil)
+	if err != nil {
+		return fmt.Errorf("error creating QUIC endpoint: %v", err)
+	}
+	p.endpoint = ep
+
+	return nil
+}
+
+// failureReason categorizes the connection errors.
+func failureReason(err error) string {
+	var dnsErr *net.DNSError
+	var addrErr *net.AddrError
+	switch {
+	case errors.As(err, &dnsErr), errors.As(err, &addrErr):
+		return options.FailureDNS
+	case errors.Is(err, context.DeadlineExceeded):
+		return options.FailureTimeout
+	}
+
+	// QUIC package doesn't export its error types, we use error strings to
+	// categorize them.
+	errStr := err.Error()
+	switch {
+	case strings.Contains(errStr, "handshake timeout"):
+		return options.FailureTimeout
+	case strings.Contains(errStr, "CRYPTO_ERROR"), strings.Contains(errStr, "tls:"), strings.Contains(errStr, "x509:"):
+		return options.FailureTLS
+	case strings.Contains(errStr, "does not support QUIC version"):
+		return failureVersionNegotiation
+	case strings.HasPrefix(errStr, "peer closed connection"):
+		return failurePeerClosed
+	}
+	return options.FailureOther
+}
+
+func (p *Probe) connect(ctx context.Context, addr, serverName string) error {
+	tlsConfig := p.tlsConfig
+	if tlsConfig.ServerName == "" {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.ServerName = serverName
+	}
+
+	conn, err := p.endpoint.Dial(ctx, p.network, addr, &quic.Config{
+		TLSConfig:        tlsConfig,
+		HandshakeTimeout: p.opts.Timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Close the connection in the background, without waiting for the peer
+	// to acknowledge it.
+	conn.Abort(nil)
+	return nil
+}
+
+func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
+	ctx, cancelCtx := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancelCtx()
+
+	// Convert interface to struct type
+	result := res.(*probeResult)
+
+	result.total++
+
+	host := target.Name
+	ipLabel := ""
+
+	resolveFirst := false
+	if p.c.ResolveFirst != nil {
+		resolveFirst = p.c.GetResolveFirst()
+	} else {
+		resolveFirst = target.IP != nil
+	}
+	if resolveFirst {
+		ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", resolve error: ", err.Error())
+			options.RecordFailure(result.failures, options.FailureDNS)
+			return
+		}
+		host = ip.String()
+		ipLabel = host
+	}
+
+	port := int(p.c.GetPort())
+	if p.c.Port == nil && target.Port != 0 {
+		port = target.Port
+	}
+
+	for _, al := range p.opts.AdditionalLabels {
+		al.UpdateForTarget(target, ipLabel, port)
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+
+	start := time.Now()
+	err := p.connect(ctx, addr, target.Name)
+	latency := time.Since(start)
+
+	if err != nil {
+		reason := failureReason(err)
+		p.l.Warning("Target:", target.Name, ", QUIC connect (", reason, "): ", err.Error())
+		options.RecordFailure(result.failures, reason)
+		return
+	}
+
+	result.success++
+	result.latency.AddFloat64(latency.Seconds() / p.opts.LatencyUnit.Seconds())
+}
+
+// Start starts and runs the probe indefinitely.
+func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+		defer cancel()
+		p.endpoint.Close(closeCtx)
+	}()
+
+	s := &sched.Scheduler{
+		ProbeName:              p.name,
+		DataChan:               dataChan,
+		Opts:                   p.opts,
+		NewResult:              p.newResult,
+		RunProbeForTarget:      p.runProbe,
+		IntervalBetweenTargets: time.Duration(p.c.GetIntervalBetweenTargetsMsec()) * time.Millisecond,
+	}
+	s.UpdateTargetsAndStartProbes(ctx)
+}
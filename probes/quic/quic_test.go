@@ -0,0 +1,235 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quic
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/quic/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/quic"
+	"google.golang.org/protobuf/proto"
+)
+
+const testServerName = "quic.cloudprober.test"
+
+// testCert generates a self-signed certificate for the test server name, and
+// writes it to a file, to be used as the CA bundle.
+func testCert(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: testServerName},
+		DNSNames:              []string{testServerName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, caFile
+}
+
+// testServer starts a QUIC server that accepts connections and closes them
+// right away.
+func testServer(t *testing.T, cert tls.Certificate) int {
+	t.Helper()
+
+	ep, err := quic.Listen("udp", "127.0.0.1:0", &quic.Config{
+		TLSConfig: &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS13,
+			NextProtos:   []string{"h3"},
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		closeCtx, closeCancel := context.WithTimeout(context.Background(), time.Second)
+		defer closeCancel()
+		ep.Close(closeCtx)
+	})
+
+	go func() {
+		for {
+			conn, err := ep.Accept(ctx)
+			if err != nil {
+				return
+			}
+			conn.Abort(nil)
+		}
+	}()
+
+	return int(ep.LocalAddr().Port())
+}
+
+func TestFailureReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: &net.DNSError{Err: "no such host", Name: "unknown.invalid"}, want: "dns"},
+		{err: &net.AddrError{Err: "missing port in address"}, want: "dns"},
+		{err: fmt.Errorf("dial: %w", context.DeadlineExceeded), want: "timeout"},
+		{err: errors.New("closed connection: CONNECTION_REFUSED: \"handshake timeout\""), want: "timeout"},
+		{err: errors.New("tls: failed to verify certificate: x509: certificate signed by unknown authority"), want: "tls"},
+		{err: errors.New("peer closed connection: CRYPTO_ERROR(120): \"\""), want: "tls"},
+		{err: errors.New("server does not support QUIC version 1"), want: "version_negotiation"},
+		{err: errors.New("peer closed connection: INTERNAL_ERROR: \"\""), want: "peer_closed"},
+		{err: errors.New("something else"), want: "other"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			assert.Equal(t, test.want, failureReason(test.err))
+		})
+	}
+}
+
+func TestNewResultFailures(t *testing.T) {
+	p := &Probe{opts: options.DefaultOptions()}
+	result := p.newResult().(*probeResult)
+	assert.Nil(t, result.failures)
+	assert.Nil(t, result.Metrics(time.Now(), p.opts).Metric("failures"))
+
+	p.opts.ExportFailureReasons = true
+	result = p.newResult().(*probeResult)
+	for _, reason := range []string{options.FailureDNS, options.FailureTLS, options.FailureTimeout, failureVersionNegotiation, failurePeerClosed} {
+		assert.Contains(t, result.failures.Keys(), reason)
+	}
+	assert.NotNil(t, result.Metrics(time.Now(), p.opts).Metric("failures"))
+}
+
+func TestRunProbe(t *testing.T) {
+	cert, caFile := testCert(t)
+	port := testServer(t, cert)
+
+	// A UDP socket that never responds.
+	blackhole, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer blackhole.Close()
+
+	tests := []struct {
+		name        string
+		tlsConfig   *tlsconfigpb.TLSConfig
+		alpn        []string
+		port        int
+		wantSuccess int64
+		wantFailure string
+	}{
+		{
+			name: "success",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CaCertFile: proto.String(caFile),
+				ServerName: proto.String(testServerName),
+			},
+			port:        port,
+			wantSuccess: 1,
+		},
+		{
+			name: "unknown-ca",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				ServerName: proto.String(testServerName),
+			},
+			port:        port,
+			wantFailure: "tls",
+		},
+		{
+			name: "wrong-sni",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CaCertFile: proto.String(caFile),
+			},
+			port:        port,
+			wantFailure: "tls",
+		},
+		{
+			name: "alpn-mismatch",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CaCertFile: proto.String(caFile),
+				ServerName: proto.String(testServerName),
+			},
+			alpn:        []string{"hq-interop"},
+			port:        port,
+			wantFailure: "tls",
+		},
+		{
+			name:        "no-response",
+			tlsConfig:   &tlsconfigpb.TLSConfig{DisableCertValidation: proto.Bool(true)},
+			port:        blackhole.LocalAddr().(*net.UDPAddr).Port,
+			wantFailure: "timeout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &Probe{}
+			opts := options.DefaultOptions()
+			opts.Timeout = time.Second
+			opts.ExportFailureReasons = true
+			opts.ProbeConf = &configpb.ProbeConf{
+				Port:      proto.Int32(int32(test.port)),
+				TlsConfig: test.tlsConfig,
+				Alpn:      test.alpn,
+			}
+			require.NoError(t, p.Init("test-quic", opts))
+			defer p.endpoint.Close(context.Background())
+
+			result := p.newResult().(*probeResult)
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: "127.0.0.1"}, result)
+
+			assert.Equal(t, int64(1), result.total, "total")
+			assert.Equal(t, test.wantSuccess, result.success, "success")
+			for _, reason := range result.failures.Keys() {
+				want := int64(0)
+				if reason == test.wantFailure {
+					want = 1
+				}
+				assert.Equal(t, want, result.failures.GetKey(reason), "failures: %s", result.failures.String())
+			}
+		})
+	}
+}
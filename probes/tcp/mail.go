@@ -0,0 +1,295 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+// errUnexpectedResponse is returned if the mail server responds with an
+// error, or with an unexpected response.
+var errUnexpectedResponse = errors.New("unexpected response")
+
+// mailClient implements the minimal parts of the SMTP, IMAP and POP3
+// protocols that we need to check the mail servers: reading the greeting
+// banner, listing capabilities and issuing STARTTLS.
+type mailClient struct {
+	protocol configpb.ProbeConf_MailProtocol_Type
+	conn     net.Conn
+	tp       *textproto.Conn
+}
+
+func newMailClient(protocol configpb.ProbeConf_MailProtocol_Type, conn net.Conn) *mailClient {
+	return &mailClient{
+		protocol: protocol,
+		conn:     conn,
+		tp:       textproto.NewConn(conn),
+	}
+}
+
+// setConn switches the client to the new connection, e.g. after the TLS
+// handshake.
+func (mc *mailClient) setConn(conn net.Conn) {
+	mc.conn = conn
+	mc.tp = textproto.NewConn(conn)
+}
+
+func unexpected(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUnexpectedResponse, fmt.Sprintf(format, args...))
+}
+
+// readIMAPTagged reads IMAP response lines until the tagged response, and
+// returns the untagged lines. It returns an error if the tagged response is
+// not OK.
+func (mc *mailClient) readIMAPTagged(tag string) ([]string, error) {
+	var untagged []string
+	for {
+		line, err := mc.tp.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, tag+" ") {
+			untagged = append(untagged, line)
+			continue
+		}
+		if !strings.HasPrefix(line, tag+" OK") {
+			return nil, unexpected("%s", line)
+		}
+		return untagged, nil
+	}
+}
+
+// readPOP3OK reads a POP3 response line and returns an error if it's not
+// "+OK".
+func (mc *mailClient) readPOP3OK() (string, error) {
+	line, err := mc.tp.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return "", unexpected("%s", line)
+	}
+	return line, nil
+}
+
+// readBanner reads and returns the server's greeting banner.
+func (mc *mailClient) readBanner() (string, error) {
+	switch mc.protocol {
+	case configpb.ProbeConf_MailProtocol_SMTP:
+		_, msg, err := mc.tp.ReadResponse(220)
+		if err != nil {
+			var tpErr *textproto.Error
+			if errors.As(err, &tpErr) {
+				return "", unexpected("%v", tpErr)
+			}
+			return "", err
+		}
+		return msg, nil
+
+	case configpb.ProbeConf_MailProtocol_IMAP:
+		line, err := mc.tp.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		if !strings.HasPrefix(line, "* OK") && !strings.HasPrefix(line, "* PREAUTH") {
+			return "", unexpected("%s", line)
+		}
+		return line, nil
+
+	default:
+		return mc.readPOP3OK()
+	}
+}
+
+// capabilities returns the capabilities advertised by the server, keyed by
+// the capability's first word in uppercase.
+func (mc *mailClient) capabilities(ehloHostname string) (map[string]bool, error) {
+	var lines []string
+
+	switch mc.protocol {
+	case configpb.ProbeConf_MailProtocol_SMTP:
+		if err := mc.tp.PrintfLine("EHLO %s", ehloHostname); err != nil {
+			return nil, err
+		}
+		_, msg, err := mc.tp.ReadResponse(250)
+		if err != nil {
+			var tpErr *textproto.Error
+			if errors.As(err, &tpErr) {
+				return nil, unexpected("%v", tpErr)
+			}
+			return nil, err
+		}
+		// First line is the server's greeting.
+		lines = strings.Split(msg, "\n")[1:]
+
+	case configpb.ProbeConf_MailProtocol_IMAP:
+		if err := mc.tp.PrintfLine("a1 CAPABILITY"); err != nil {
+			return nil, err
+		}
+		untagged, err := mc.readIMAPTagged("a1")
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range untagged {
+			if caps, ok := strings.CutPrefix(line, "* CAPABILITY "); ok {
+				lines = append(lines, strings.Fields(caps)...)
+			}
+		}
+
+	default:
+		if err := mc.tp.PrintfLine("CAPA"); err != nil {
+			return nil, err
+		}
+		if _, err := mc.readPOP3OK(); err != nil {
+			return nil, err
+		}
+		var err error
+		if lines, err = mc.tp.ReadDotLines(); err != nil {
+			return nil, err
+		}
+	}
+
+	caps := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			caps[strings.ToUpper(fields[0])] = true
+		}
+	}
+	return caps, nil
+}
+
+// startTLS issues the STARTTLS command. Caller is responsible for the TLS
+// handshake after that.
+func (mc *mailClient) startTLS() error {
+	switch mc.protocol {
+	case configpb.ProbeConf_MailProtocol_SMTP:
+		if err := mc.tp.PrintfLine("STARTTLS"); err != nil {
+			return err
+		}
+		if _, _, err := mc.tp.ReadResponse(220); err != nil {
+			return err
+		}
+		return nil
+
+	case configpb.ProbeConf_MailProtocol_IMAP:
+		if err := mc.tp.PrintfLine("a2 STARTTLS"); err != nil {
+			return err
+		}
+		_, err := mc.readIMAPTagged("a2")
+		return err
+
+	default:
+		if err := mc.tp.PrintfLine("STLS"); err != nil {
+			return err
+		}
+		_, err := mc.readPOP3OK()
+		return err
+	}
+}
+
+// quit ends the session, without waiting for the server's response.
+func (mc *mailClient) quit() {
+	if mc.protocol == configpb.ProbeConf_MailProtocol_IMAP {
+		mc.tp.PrintfLine("a3 LOGOUT")
+		return
+	}
+	mc.tp.PrintfLine("QUIT")
+}
+
+// mailFailureReason returns the failure reason for the mail client errors.
+func mailFailureReason(err error) string {
+	if errors.Is(err, errUnexpectedResponse) {
+		return options.FailureValidation
+	}
+	return options.ClassifyError(err)
+}
+
+// runMailChecks runs the mail protocol checks over the connection. It returns
+// the failure reason if checks fail, and an empty string otherwise.
+func (p *Probe) runMailChecks(ctx context.Context, conn net.Conn, target endpoint.Endpoint, result *probeResult) string {
+	mp := p.c.GetMailProtocol()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	// Implicit TLS: handshake before the greeting.
+	if p.tlsConfig != nil && !mp.GetStarttls() {
+		tlsConn, err := p.tlsHandshake(ctx, conn, target.Name)
+		if err != nil {
+			result.tlsHandshakeFailures.Inc()
+			p.l.Warning("Target:", target.Name, ", TLS handshake: ", err.Error())
+			return tlsFailureReason(err)
+		}
+		conn = tlsConn
+	}
+
+	mc := newMailClient(mp.GetType(), conn)
+	defer mc.quit()
+
+	banner, err := mc.readBanner()
+	if err != nil {
+		p.l.Warning("Target:", target.Name, ", error reading banner: ", err.Error())
+		return mailFailureReason(err)
+	}
+	if p.bannerRe != nil {
+		if !p.bannerRe.MatchString(banner) {
+			p.l.Warningf("Target: %s, banner %q didn't match the regex %q", target.Name, banner, p.bannerRe.String())
+			return options.FailureValidation
+		}
+		result.bannerMatch.Inc()
+	}
+
+	// SMTP requires EHLO before STARTTLS.
+	if len(mp.GetRequiredCapability()) > 0 || (mp.GetStarttls() && mp.GetType() == configpb.ProbeConf_MailProtocol_SMTP) {
+		caps, err := mc.capabilities(mp.GetEhloHostname())
+		if err != nil {
+			p.l.Warning("Target:", target.Name, ", error getting capabilities: ", err.Error())
+			return mailFailureReason(err)
+		}
+		for _, c := range mp.GetRequiredCapability() {
+			if !caps[strings.ToUpper(c)] {
+				p.l.Warningf("Target: %s, required capability %s is not advertised", target.Name, c)
+				return options.FailureValidation
+			}
+		}
+	}
+
+	if mp.GetStarttls() {
+		if err := mc.startTLS(); err != nil {
+			result.tlsHandshakeFailures.Inc()
+			p.l.Warning("Target:", target.Name, ", STARTTLS: ", err.Error())
+			return tlsFailureReason(err)
+		}
+		tlsConn, err := p.tlsHandshake(ctx, conn, target.Name)
+		if err != nil {
+			result.tlsHandshakeFailures.Inc()
+			p.l.Warning("Target:", target.Name, ", TLS handshake after STARTTLS: ", err.Error())
+			return tlsFailureReason(err)
+		}
+		mc.setConn(tlsConn)
+		result.starttlsSuccess.Inc()
+	}
+
+	return ""
+}
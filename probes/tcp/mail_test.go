@@ -0,0 +1,283 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tcp
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// testMailServer is a scripted mail server: it sends the greeting, and then
+// responds to the known commands. After the STARTTLS command, if response is
+// positive, it performs the TLS handshake.
+type testMailServer struct {
+	greeting    string
+	responses   map[string]string
+	startTLSCmd string
+	tlsConfig   *tls.Config
+}
+
+func (s *testMailServer) serve(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("%s", s.greeting)
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		resp, ok := s.responses[line]
+		if !ok {
+			return
+		}
+		tp.PrintfLine("%s", resp)
+		if line == s.startTLSCmd && !strings.HasPrefix(resp, "-ERR") {
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn, tp = tlsConn, textproto.NewConn(tlsConn)
+		}
+	}
+}
+
+func startTestMailServer(t *testing.T, s *testMailServer) int {
+	t.Helper()
+
+	// Borrow httptest's TLS certificate.
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	s.tlsConfig = ts.TLS.Clone()
+	ts.Close()
+
+	ln, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+var testMailServers = map[configpb.ProbeConf_MailProtocol_Type]*testMailServer{
+	configpb.ProbeConf_MailProtocol_SMTP: {
+		greeting: "220-mail.example.com ESMTP Postfix\r\n220 ready",
+		responses: map[string]string{
+			"EHLO cloudprober": "250-mail.example.com\r\n250-PIPELINING\r\n250-8BITMIME\r\n250 STARTTLS",
+			"STARTTLS":         "220 2.0.0 Ready to start TLS",
+			"QUIT":             "221 Bye",
+		},
+		startTLSCmd: "STARTTLS",
+	},
+	configpb.ProbeConf_MailProtocol_IMAP: {
+		greeting: "* OK [CAPABILITY IMAP4rev1] Dovecot ready.",
+		responses: map[string]string{
+			"a1 CAPABILITY": "* CAPABILITY IMAP4rev1 STARTTLS IDLE\r\na1 OK Capability completed.",
+			"a2 STARTTLS":   "a2 OK Begin TLS negotiation now.",
+			"a3 LOGOUT":     "* BYE\r\na3 OK Logout completed.",
+		},
+		startTLSCmd: "a2 STARTTLS",
+	},
+	configpb.ProbeConf_MailProtocol_POP3: {
+		greeting: "+OK Dovecot ready.",
+		responses: map[string]string{
+			"CAPA": "+OK\r\nTOP\r\nUIDL\r\n.",
+			"STLS": "-ERR TLS not available",
+			"QUIT": "+OK Logging out",
+		},
+		startTLSCmd: "STLS",
+	},
+}
+
+func TestRunProbeMail(t *testing.T) {
+	ports := make(map[configpb.ProbeConf_MailProtocol_Type]int)
+	for protocol, s := range testMailServers {
+		ports[protocol] = startTestMailServer(t, s)
+	}
+
+	smtp, imap, pop3 := configpb.ProbeConf_MailProtocol_SMTP, configpb.ProbeConf_MailProtocol_IMAP, configpb.ProbeConf_MailProtocol_POP3
+	tests := []struct {
+		name             string
+		mp               *configpb.ProbeConf_MailProtocol
+		wantReason       string
+		wantBannerMatch  int64
+		wantStarttls     int64
+		wantTLSFailures  int64
+		wantNoTLSMetrics bool
+	}{
+		{
+			name:            "smtp_starttls",
+			mp:              &configpb.ProbeConf_MailProtocol{Type: smtp.Enum(), BannerRegex: proto.String("ESMTP Postfix"), Starttls: proto.Bool(true), RequiredCapability: []string{"pipelining"}},
+			wantBannerMatch: 1,
+			wantStarttls:    1,
+		},
+		{
+			name:             "smtp_banner_only",
+			mp:               &configpb.ProbeConf_MailProtocol{Type: smtp.Enum(), BannerRegex: proto.String("^mail.example.com")},
+			wantBannerMatch:  1,
+			wantNoTLSMetrics: true,
+		},
+		{
+			name:             "smtp_banner_mismatch",
+			mp:               &configpb.ProbeConf_MailProtocol{Type: smtp.Enum(), BannerRegex: proto.String("Exim")},
+			wantReason:       options.FailureValidation,
+			wantNoTLSMetrics: true,
+		},
+		{
+			name:             "smtp_missing_capability",
+			mp:               &configpb.ProbeConf_MailProtocol{Type: smtp.Enum(), RequiredCapability: []string{"SMTPUTF8"}},
+			wantReason:       options.FailureValidation,
+			wantNoTLSMetrics: true,
+		},
+		{
+			name:         "imap_starttls",
+			mp:           &configpb.ProbeConf_MailProtocol{Type: imap.Enum(), Starttls: proto.Bool(true), RequiredCapability: []string{"IDLE"}},
+			wantStarttls: 1,
+		},
+		{
+			name:             "pop3_capabilities",
+			mp:               &configpb.ProbeConf_MailProtocol{Type: pop3.Enum(), BannerRegex: proto.String("Dovecot"), RequiredCapability: []string{"UIDL"}},
+			wantBannerMatch:  1,
+			wantNoTLSMetrics: true,
+		},
+		{
+			name:            "pop3_starttls_rejected",
+			mp:              &configpb.ProbeConf_MailProtocol{Type: pop3.Enum(), Starttls: proto.Bool(true)},
+			wantReason:      options.FailureTLS,
+			wantTLSFailures: 1,
+		},
+		{
+			name:             "protocol_mismatch",
+			mp:               &configpb.ProbeConf_MailProtocol{Type: pop3.Enum()},
+			wantReason:       options.FailureValidation,
+			wantNoTLSMetrics: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port := ports[test.mp.GetType()]
+			if test.name == "protocol_mismatch" {
+				port = ports[smtp]
+			}
+
+			opts := options.DefaultOptions()
+			opts.Timeout = 5 * time.Second
+			opts.ExportFailureReasons = true
+			opts.ProbeConf = &configpb.ProbeConf{
+				Port:         proto.Int32(int32(port)),
+				TlsConfig:    &tlsconfigpb.TLSConfig{DisableCertValidation: proto.Bool(true)},
+				MailProtocol: test.mp,
+			}
+			if !test.mp.GetStarttls() {
+				opts.ProbeConf.(*configpb.ProbeConf).TlsConfig = nil
+			}
+			p := &Probe{}
+			require.NoError(t, p.Init("test-probe", opts))
+
+			res := p.newResult()
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: "localhost"}, res)
+			result := res.(*probeResult)
+
+			if test.wantReason != "" {
+				assert.Equal(t, int64(0), result.success)
+				assert.Equal(t, int64(1), result.failures.GetKey(test.wantReason))
+			} else {
+				assert.Equal(t, int64(1), result.success)
+			}
+			if test.mp.GetBannerRegex() != "" {
+				assert.Equal(t, test.wantBannerMatch, result.bannerMatch.Int64())
+			}
+			if test.wantNoTLSMetrics {
+				assert.Nil(t, result.starttlsSuccess)
+				assert.Nil(t, result.tlsHandshakeFailures)
+				return
+			}
+			assert.Equal(t, test.wantStarttls, result.starttlsSuccess.Int64())
+			assert.Equal(t, test.wantTLSFailures, result.tlsHandshakeFailures.Int64())
+		})
+	}
+}
+
+func TestRunProbeMailImplicitTLS(t *testing.T) {
+	s := &testMailServer{greeting: "+OK ready", responses: map[string]string{"QUIT": "+OK"}}
+	startTestMailServer(t, s)
+
+	// Implicit TLS server.
+	ln, err := tls.Listen("tcp", "localhost:0", s.tlsConfig)
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	opts := options.DefaultOptions()
+	opts.Timeout = 5 * time.Second
+	opts.ProbeConf = &configpb.ProbeConf{
+		Port:         proto.Int32(int32(ln.Addr().(*net.TCPAddr).Port)),
+		TlsConfig:    &tlsconfigpb.TLSConfig{DisableCertValidation: proto.Bool(true)},
+		MailProtocol: &configpb.ProbeConf_MailProtocol{Type: configpb.ProbeConf_MailProtocol_POP3.Enum(), BannerRegex: proto.String("ready")},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("test-probe", opts))
+
+	res := p.newResult()
+	p.runProbe(context.Background(), endpoint.Endpoint{Name: "localhost"}, res)
+	result := res.(*probeResult)
+	assert.Equal(t, int64(1), result.success)
+	assert.Equal(t, int64(1), result.bannerMatch.Int64())
+	assert.Equal(t, int64(0), result.tlsHandshakeFailures.Int64())
+	assert.Nil(t, result.starttlsSuccess)
+}
+
+func TestInitMailProtocolErrors(t *testing.T) {
+	opts := options.DefaultOptions()
+	opts.ProbeConf = &configpb.ProbeConf{MailProtocol: &configpb.ProbeConf_MailProtocol{BannerRegex: proto.String("(")}}
+	assert.Error(t, (&Probe{}).Init("test-probe", opts), "invalid banner_regex")
+
+	opts = options.DefaultOptions()
+	opts.ProbeConf = &configpb.ProbeConf{
+		TlsConfig:                 &tlsconfigpb.TLSConfig{},
+		ExpectTlsHandshakeFailure: proto.Bool(true),
+		MailProtocol:              &configpb.ProbeConf_MailProtocol{},
+	}
+	assert.Error(t, (&Probe{}).Init("test-probe", opts), "expect_tls_handshake_failure with mail_protocol")
+}
@@ -7,6 +7,8 @@
 package proto
 
 import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	proto1 "github.com/cloudprober/cloudprober/internal/unreachable/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -20,7 +22,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Next tag: 4
+type ProbeConf_MailProtocol_Type int32
+
+const (
+	ProbeConf_MailProtocol_SMTP ProbeConf_MailProtocol_Type = 0
+	ProbeConf_MailProtocol_IMAP ProbeConf_MailProtocol_Type = 1
+	ProbeConf_MailProtocol_POP3 ProbeConf_MailProtocol_Type = 2
+)
+
+// Enum value maps for ProbeConf_MailProtocol_Type.
+var (
+	ProbeConf_MailProtocol_Type_name = map[int32]string{
+		0: "SMTP",
+		1: "IMAP",
+		2: "POP3",
+	}
+	ProbeConf_MailProtocol_Type_value = map[string]int32{
+		"SMTP": 0,
+		"IMAP": 1,
+		"POP3": 2,
+	}
+)
+
+func (x ProbeConf_MailProtocol_Type) Enum() *ProbeConf_MailProtocol_Type {
+	p := new(ProbeConf_MailProtocol_Type)
+	*p = x
+	return p
+}
+
+func (x ProbeConf_MailProtocol_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProbeConf_MailProtocol_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ProbeConf_MailProtocol_Type) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_enumTypes[0]
+}
+
+func (x ProbeConf_MailProtocol_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ProbeConf_MailProtocol_Type) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ProbeConf_MailProtocol_Type(num)
+	return nil
+}
+
+// Deprecated: Use ProbeConf_MailProtocol_Type.Descriptor instead.
+func (ProbeConf_MailProtocol_Type) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDescGZIP(), []int{0, 0, 0}
+}
+
+// Next tag: 8
 type ProbeConf struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -37,6 +98,31 @@ type ProbeConf struct {
 	ResolveFirst *bool `protobuf:"varint,2,opt,name=resolve_first,json=resolveFirst" json:"resolve_first,omitempty"`
 	// Interval between targets.
 	IntervalBetweenTargetsMsec *int32 `protobuf:"varint,3,opt,name=interval_between_targets_msec,json=intervalBetweenTargetsMsec,def=10" json:"interval_between_targets_msec,omitempty"`
+	// If specified, probe performs a TLS handshake after connecting, using this
+	// TLS config, and reports TLS handshake failures separately from the
+	// connection failures (as "tls_handshake_failures"). Use cipher_suites and
+	// min/max_version to probe with specific TLS parameters.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,4,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// If set, TLS handshake is expected to fail, e.g. because the server
+	// rejects weak cipher suites or old TLS versions. Probe succeeds if the
+	// connection succeeds but the handshake fails, and fails if the handshake
+	// unexpectedly succeeds. Note that certificate verification failures are
+	// handshake failures too, so you may want to set disable_cert_validation
+	// in tls_config. Requires tls_config.
+	ExpectTlsHandshakeFailure *bool                   `protobuf:"varint,5,opt,name=expect_tls_handshake_failure,json=expectTlsHandshakeFailure" json:"expect_tls_handshake_failure,omitempty"`
+	MailProtocol              *ProbeConf_MailProtocol `protobuf:"bytes,6,opt,name=mail_protocol,json=mailProtocol" json:"mail_protocol,omitempty"`
+	// If set, probe succeeds only if the target is NOT reachable, e.g. to
+	// verify that a firewall blocks the connections. Unlike negative_test,
+	// it distinguishes between refused, filtered (timed out) and open
+	// connections, and exports the observed outcome in the
+	// "unreachable_outcome" metric. Cannot be used with tls_config,
+	// mail_protocol or negative_test.
+	// Example:
+	//
+	//	expect_unreachable {
+	//	  allow_refused: false  # Only silently dropped connections are OK.
+	//	}
+	ExpectUnreachable *proto1.ExpectUnreachable `protobuf:"bytes,7,opt,name=expect_unreachable,json=expectUnreachable" json:"expect_unreachable,omitempty"`
 }
 
 // Default values for ProbeConf fields.
@@ -97,6 +183,146 @@ func (x *ProbeConf) GetIntervalBetweenTargetsMsec() int32 {
 	return Default_ProbeConf_IntervalBetweenTargetsMsec
 }
 
+func (x *ProbeConf) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetExpectTlsHandshakeFailure() bool {
+	if x != nil && x.ExpectTlsHandshakeFailure != nil {
+		return *x.ExpectTlsHandshakeFailure
+	}
+	return false
+}
+
+func (x *ProbeConf) GetMailProtocol() *ProbeConf_MailProtocol {
+	if x != nil {
+		return x.MailProtocol
+	}
+	return nil
+}
+
+func (x *ProbeConf) GetExpectUnreachable() *proto1.ExpectUnreachable {
+	if x != nil {
+		return x.ExpectUnreachable
+	}
+	return nil
+}
+
+// Mail protocol checks. If configured, after connecting, probe reads the
+// server's greeting banner, optionally checks the advertised capabilities
+// and upgrades the connection to TLS using STARTTLS. Latency includes all
+// these steps.
+//
+// If tls_config is set without starttls, TLS handshake is done right after
+// connecting (implicit TLS, e.g. SMTPS, IMAPS), and the banner is read
+// over TLS. With starttls, tls_config, if set, is used for the TLS
+// handshake after the STARTTLS command.
+//
+// Example:
+//
+//	mail_protocol {
+//	  type: SMTP
+//	  banner_regex: "ESMTP Postfix"
+//	  starttls: true
+//	}
+type ProbeConf_MailProtocol struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type *ProbeConf_MailProtocol_Type `protobuf:"varint,1,opt,name=type,enum=cloudprober.probes.tcp.ProbeConf_MailProtocol_Type" json:"type,omitempty"`
+	// Regex that the greeting banner should match. For SMTP, multi-line
+	// greetings are joined with newlines. Probe fails, with failure reason
+	// "validation", if banner doesn't match.
+	BannerRegex *string `protobuf:"bytes,2,opt,name=banner_regex,json=bannerRegex" json:"banner_regex,omitempty"`
+	// If set, probe issues STARTTLS (STLS for POP3) and performs the TLS
+	// handshake. STARTTLS failures are counted in "tls_handshake_failures".
+	Starttls *bool `protobuf:"varint,3,opt,name=starttls" json:"starttls,omitempty"`
+	// Capabilities that server must advertise, e.g. "STARTTLS", "PIPELINING"
+	// for SMTP, "IDLE" for IMAP, "UIDL" for POP3. Capabilities are retrieved
+	// using EHLO (SMTP), CAPABILITY (IMAP) or CAPA (POP3) commands, and are
+	// matched case-insensitively by their first word. Probe fails, with
+	// failure reason "validation", if a capability is missing.
+	RequiredCapability []string `protobuf:"bytes,4,rep,name=required_capability,json=requiredCapability" json:"required_capability,omitempty"`
+	// Hostname to use in the SMTP EHLO command.
+	EhloHostname *string `protobuf:"bytes,5,opt,name=ehlo_hostname,json=ehloHostname,def=cloudprober" json:"ehlo_hostname,omitempty"`
+}
+
+// Default values for ProbeConf_MailProtocol fields.
+const (
+	Default_ProbeConf_MailProtocol_EhloHostname = string("cloudprober")
+)
+
+func (x *ProbeConf_MailProtocol) Reset() {
+	*x = ProbeConf_MailProtocol{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProbeConf_MailProtocol) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProbeConf_MailProtocol) ProtoMessage() {}
+
+func (x *ProbeConf_MailProtocol) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProbeConf_MailProtocol.ProtoReflect.Descriptor instead.
+func (*ProbeConf_MailProtocol) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ProbeConf_MailProtocol) GetType() ProbeConf_MailProtocol_Type {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return ProbeConf_MailProtocol_SMTP
+}
+
+func (x *ProbeConf_MailProtocol) GetBannerRegex() string {
+	if x != nil && x.BannerRegex != nil {
+		return *x.BannerRegex
+	}
+	return ""
+}
+
+func (x *ProbeConf_MailProtocol) GetStarttls() bool {
+	if x != nil && x.Starttls != nil {
+		return *x.Starttls
+	}
+	return false
+}
+
+func (x *ProbeConf_MailProtocol) GetRequiredCapability() []string {
+	if x != nil {
+		return x.RequiredCapability
+	}
+	return nil
+}
+
+func (x *ProbeConf_MailProtocol) GetEhloHostname() string {
+	if x != nil && x.EhloHostname != nil {
+		return *x.EhloHostname
+	}
+	return Default_ProbeConf_MailProtocol_EhloHostname
+}
+
 var File_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDesc = []byte{
@@ -105,19 +331,66 @@ var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDes
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x74, 0x63, 0x70, 0x2f,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x16, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x50,
-	0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d,
-	0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x46, 0x69, 0x72, 0x73,
-	0x74, 0x12, 0x45, 0x0a, 0x1d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x62, 0x65,
-	0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x5f, 0x6d, 0x73,
-	0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x1a, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x54, 0x61, 0x72,
-	0x67, 0x65, 0x74, 0x73, 0x4d, 0x73, 0x65, 0x63, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68,
 	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f, 0x74, 0x63, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x4a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x2f, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xdf, 0x05, 0x0a, 0x09, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x5f, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12, 0x45, 0x0a, 0x1d, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x73, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02,
+	0x31, 0x30, 0x52, 0x1a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x77,
+	0x65, 0x65, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x73, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x3f,
+	0x0a, 0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2e, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x3f, 0x0a, 0x1c, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x6c, 0x73, 0x5f, 0x68, 0x61,
+	0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x54, 0x6c, 0x73,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x12, 0x53, 0x0a, 0x0d, 0x6d, 0x61, 0x69, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x0c, 0x6d, 0x61, 0x69, 0x6c, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x59, 0x0a, 0x12, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x5f,
+	0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x11, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x55, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65,
+	0x1a, 0x9f, 0x02, 0x0a, 0x0c, 0x4d, 0x61, 0x69, 0x6c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x12, 0x47, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x33, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x73, 0x2e, 0x74, 0x63, 0x70, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x2e, 0x4d, 0x61, 0x69, 0x6c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x2e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61,
+	0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x74, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x74, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x30, 0x0a, 0x0d, 0x65, 0x68,
+	0x6c, 0x6f, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x3a, 0x0b, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x52, 0x0c,
+	0x65, 0x68, 0x6c, 0x6f, 0x48, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x24, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4d, 0x54, 0x50, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x49, 0x4d, 0x41, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x50, 0x33,
+	0x10, 0x02, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x2f,
+	0x74, 0x63, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -132,16 +405,25 @@ func file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDe
 	return file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_goTypes = []any{
-	(*ProbeConf)(nil), // 0: cloudprober.probes.tcp.ProbeConf
+	(ProbeConf_MailProtocol_Type)(0), // 0: cloudprober.probes.tcp.ProbeConf.MailProtocol.Type
+	(*ProbeConf)(nil),                // 1: cloudprober.probes.tcp.ProbeConf
+	(*ProbeConf_MailProtocol)(nil),   // 2: cloudprober.probes.tcp.ProbeConf.MailProtocol
+	(*proto.TLSConfig)(nil),          // 3: cloudprober.tlsconfig.TLSConfig
+	(*proto1.ExpectUnreachable)(nil), // 4: cloudprober.unreachable.ExpectUnreachable
 }
 var file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: cloudprober.probes.tcp.ProbeConf.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	2, // 1: cloudprober.probes.tcp.ProbeConf.mail_protocol:type_name -> cloudprober.probes.tcp.ProbeConf.MailProtocol
+	4, // 2: cloudprober.probes.tcp.ProbeConf.expect_unreachable:type_name -> cloudprober.unreachable.ExpectUnreachable
+	0, // 3: cloudprober.probes.tcp.ProbeConf.MailProtocol.type:type_name -> cloudprober.probes.tcp.ProbeConf.MailProtocol.Type
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_init() }
@@ -162,19 +444,32 @@ func file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_init(
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ProbeConf_MailProtocol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_goTypes,
 		DependencyIndexes: file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_enumTypes,
 		MessageInfos:      file_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto_msgTypes,
 	}.Build()
 	File_github_com_cloudprober_cloudprober_probes_tcp_proto_config_proto = out.File
@@ -17,17 +17,24 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"regexp"
 	"strconv"
 	"time"
 
+	"github.com/cloudprober/cloudprober/common/iputils"
+	"github.com/cloudprober/cloudprober/internal/sockopt"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/internal/unreachable"
 	"github.com/cloudprober/cloudprober/internal/validators"
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/probes/common/sched"
 	"github.com/cloudprober/cloudprober/probes/options"
 	configpb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
 )
 
@@ -41,27 +48,49 @@ type Probe struct {
 	// book-keeping params
 	network     string
 	dialContext func(context.Context, string, string) (net.Conn, error) // Keeps some dialing related config
+	tlsConfig   *tls.Config
+	bannerRe    *regexp.Regexp
+	unreachable *unreachable.Checker
 }
 
 type probeResult struct {
-	total, success    int64
-	latency           metrics.LatencyValue
-	validationFailure *metrics.Map[int64]
+	total, success       int64
+	latency              metrics.LatencyValue
+	validationFailure    *metrics.Map[int64]
+	tlsHandshakeFailures *metrics.Int
+	bannerMatch          *metrics.Int
+	starttlsSuccess      *metrics.Int
+	unreachableOutcomes  *metrics.Map[int64]
+	failures             *metrics.Map[int64]
 }
 
 func (p *Probe) newResult() sched.ProbeResult {
-	result := &probeResult{}
+	result := &probeResult{
+		failures: p.opts.NewFailureReasons(),
+	}
 
 	if p.opts.Validators != nil {
 		result.validationFailure = validators.ValidationFailureMap(p.opts.Validators)
 	}
 
-	if p.opts.LatencyDist != nil {
-		result.latency = p.opts.LatencyDist.CloneDist()
-	} else {
-		result.latency = metrics.NewFloat(0)
+	if p.tlsConfig != nil {
+		result.tlsHandshakeFailures = metrics.NewInt(0)
+	}
+
+	if p.bannerRe != nil {
+		result.bannerMatch = metrics.NewInt(0)
+	}
+
+	if p.c.GetMailProtocol().GetStarttls() {
+		result.starttlsSuccess = metrics.NewInt(0)
+	}
+
+	if p.unreachable != nil {
+		result.unreachableOutcomes = unreachable.NewOutcomes()
 	}
 
+	result.latency = p.opts.NewLatencyValue()
+
 	return result
 }
 
@@ -76,6 +105,26 @@ func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics
 		em.AddMetric("validation_failure", result.validationFailure)
 	}
 
+	if result.tlsHandshakeFailures != nil {
+		em.AddMetric("tls_handshake_failures", result.tlsHandshakeFailures.Clone())
+	}
+
+	if result.bannerMatch != nil {
+		em.AddMetric("banner_match", result.bannerMatch.Clone())
+	}
+
+	if result.starttlsSuccess != nil {
+		em.AddMetric("starttls_success", result.starttlsSuccess.Clone())
+	}
+
+	if result.unreachableOutcomes != nil {
+		em.AddMetric("unreachable_outcome", result.unreachableOutcomes.Clone())
+	}
+
+	if result.failures != nil {
+		em.AddMetric("failures", result.failures.Clone())
+	}
+
 	return em
 }
 
@@ -105,6 +154,12 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 		p.network += strconv.Itoa(p.opts.IPVersion)
 	}
 
+	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
+	}
+
 	// Create a dialer for our use.
 	dialer := &net.Dialer{
 		Timeout:   p.opts.Timeout,
@@ -115,11 +170,75 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 			IP: p.opts.SourceIP,
 		}
 	}
+	if p.opts.DSCP != 0 {
+		dialer.Control = sockopt.DialerControl(p.opts.DSCP, p.l)
+	}
+	// Use targets' DNS server, if configured, to resolve the target names.
+	dialer.Resolver = targets.DNSResolver(p.opts.Targets)
 	p.dialContext = dialer.DialContext
 
+	if p.c.GetTlsConfig() != nil {
+		if p.opts.NegativeTest {
+			return fmt.Errorf("negative_test is not supported with tls_config, use expect_tls_handshake_failure instead")
+		}
+		p.tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(p.tlsConfig, p.c.GetTlsConfig()); err != nil {
+			return err
+		}
+	} else if p.c.GetExpectTlsHandshakeFailure() {
+		return fmt.Errorf("expect_tls_handshake_failure requires tls_config")
+	}
+
+	if mp := p.c.GetMailProtocol(); mp != nil {
+		if p.c.GetExpectTlsHandshakeFailure() {
+			return fmt.Errorf("expect_tls_handshake_failure is not supported with mail_protocol")
+		}
+		if mp.GetBannerRegex() != "" {
+			re, err := regexp.Compile(mp.GetBannerRegex())
+			if err != nil {
+				return fmt.Errorf("invalid banner_regex: %v", err)
+			}
+			p.bannerRe = re
+		}
+		// STARTTLS uses the default TLS config if tls_config is not set.
+		if mp.GetStarttls() && p.tlsConfig == nil {
+			p.tlsConfig = &tls.Config{}
+		}
+	}
+
+	if p.c.GetExpectUnreachable() != nil {
+		if p.opts.NegativeTest || p.c.GetTlsConfig() != nil || p.c.GetMailProtocol() != nil {
+			return fmt.Errorf("expect_unreachable cannot be used along with negative_test, tls_config or mail_protocol")
+		}
+		u, err := unreachable.New(p.c.GetExpectUnreachable())
+		if err != nil {
+			return err
+		}
+		p.unreachable = u
+	}
+
 	return nil
 }
 
+// tlsHandshake performs a TLS handshake over the connection.
+func (p *Probe) tlsHandshake(ctx context.Context, conn net.Conn, serverName string) (*tls.Conn, error) {
+	cfg := p.tlsConfig
+	if cfg.ServerName == "" {
+		cfg = cfg.Clone()
+		cfg.ServerName = serverName
+	}
+	tlsConn := tls.Client(conn, cfg)
+	return tlsConn, tlsConn.HandshakeContext(ctx)
+}
+
+// tlsFailureReason returns the failure reason for the TLS handshake errors.
+func tlsFailureReason(err error) string {
+	if reason := options.ClassifyError(err); reason == options.FailureTimeout {
+		return reason
+	}
+	return options.FailureTLS
+}
+
 func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
 	ctx, cancelCtx := context.WithTimeout(ctx, p.opts.Timeout)
 	defer cancelCtx()
@@ -142,6 +261,7 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sche
 		ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
 		if err != nil {
 			p.l.Error("target: ", target.Name, ", resolve error: ", err.Error())
+			options.RecordFailure(result.failures, options.FailureDNS)
 			return
 		}
 		host = ip.String()
@@ -168,6 +288,23 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sche
 	if p.opts.NegativeTest {
 		if err == nil {
 			p.l.Warning("Negative test, but connection was successful to: ", addr)
+			options.RecordFailure(result.failures, options.FailureOther)
+			return
+		}
+		result.success++
+		return
+	}
+
+	if p.unreachable != nil {
+		outcome, ok := p.unreachable.Check(err)
+		result.unreachableOutcomes.IncKey(outcome)
+		if !ok {
+			p.l.Warning("Target:", target.Name, ", expected to be unreachable, but outcome: ", outcome)
+			reason := options.ClassifyError(err)
+			if err == nil {
+				reason = options.FailureValidation
+			}
+			options.RecordFailure(result.failures, reason)
 			return
 		}
 		result.success++
@@ -176,8 +313,35 @@ func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sche
 
 	if err != nil {
 		p.l.Warning("Target:", target.Name, ", doTCP: ", err.Error())
+		options.RecordFailure(result.failures, options.ClassifyError(err))
 		return
 	}
+
+	if p.c.GetMailProtocol() != nil {
+		if reason := p.runMailChecks(ctx, conn, target, result); reason != "" {
+			options.RecordFailure(result.failures, reason)
+			return
+		}
+		latency = time.Since(start)
+	} else if p.tlsConfig != nil {
+		// If TLS is configured, latency includes the TLS handshake.
+		tlsConn, err := p.tlsHandshake(ctx, conn, target.Name)
+		latency = time.Since(start)
+		if err != nil {
+			result.tlsHandshakeFailures.Inc()
+			if !p.c.GetExpectTlsHandshakeFailure() {
+				p.l.Warning("Target:", target.Name, ", TLS handshake: ", err.Error())
+				options.RecordFailure(result.failures, tlsFailureReason(err))
+				return
+			}
+		} else if p.c.GetExpectTlsHandshakeFailure() {
+			cs := tlsConn.ConnectionState()
+			p.l.Warningf("Target: %s, TLS handshake expected to fail, but succeeded with version: %s, cipher suite: %s", target.Name, tls.VersionName(cs.Version), tls.CipherSuiteName(cs.CipherSuite))
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return
+		}
+	}
+
 	result.success++
 	result.latency.AddFloat64(latency.Seconds() / p.opts.LatencyUnit.Seconds())
 }
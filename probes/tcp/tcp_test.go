@@ -16,14 +16,26 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	unreachablepb "github.com/cloudprober/cloudprober/internal/unreachable/proto"
+	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/tcp/proto"
+	"github.com/cloudprober/cloudprober/targets"
 	"github.com/cloudprober/cloudprober/targets/endpoint"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 type dialState struct {
@@ -114,3 +126,291 @@ func TestRunProbe(t *testing.T) {
 	}
 
 }
+
+func TestInitSourceIP(t *testing.T) {
+	for _, test := range []struct {
+		sourceIP string
+		wantErr  bool
+	}{
+		{sourceIP: "127.0.0.1"},
+		{sourceIP: "192.0.2.123", wantErr: true},
+	} {
+		t.Run(test.sourceIP, func(t *testing.T) {
+			p := &Probe{}
+			opts := options.DefaultOptions()
+			opts.SourceIP = net.ParseIP(test.sourceIP)
+			err := p.Init("tcp_test", opts)
+			if (err != nil) != test.wantErr {
+				t.Errorf("Init() error=%v, wantErr=%v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunProbeTLS(t *testing.T) {
+	// Server accepts only TLS 1.2 with a single cipher suite.
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	host, portStr, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	tests := []struct {
+		name                string
+		tlsConfig           *tlsconfigpb.TLSConfig
+		expectFailure       bool
+		wantSuccess         int64
+		wantHandshakeFailed int64
+	}{
+		{
+			name:        "handshake-success",
+			tlsConfig:   &tlsconfigpb.TLSConfig{},
+			wantSuccess: 1,
+		},
+		{
+			name: "weak-cipher-rejected",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CipherSuites: []string{"TLS_RSA_WITH_AES_128_CBC_SHA"},
+				MaxVersion:   tlsconfigpb.TLSConfig_TLS_1_2.Enum(),
+			},
+			wantHandshakeFailed: 1,
+		},
+		{
+			name: "weak-cipher-rejected-expected",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CipherSuites: []string{"TLS_RSA_WITH_AES_128_CBC_SHA"},
+				MaxVersion:   tlsconfigpb.TLSConfig_TLS_1_2.Enum(),
+			},
+			expectFailure:       true,
+			wantSuccess:         1,
+			wantHandshakeFailed: 1,
+		},
+		{
+			name: "old-version-rejected-expected",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				MinVersion: tlsconfigpb.TLSConfig_TLS_1_0.Enum(),
+				MaxVersion: tlsconfigpb.TLSConfig_TLS_1_1.Enum(),
+			},
+			expectFailure:       true,
+			wantSuccess:         1,
+			wantHandshakeFailed: 1,
+		},
+		{
+			name: "unexpected-success",
+			tlsConfig: &tlsconfigpb.TLSConfig{
+				CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			},
+			expectFailure: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.tlsConfig.DisableCertValidation = proto.Bool(true)
+
+			opts := options.DefaultOptions()
+			opts.Timeout = 5 * time.Second
+			opts.ProbeConf = &configpb.ProbeConf{
+				Port:                      proto.Int32(int32(port)),
+				TlsConfig:                 test.tlsConfig,
+				ExpectTlsHandshakeFailure: proto.Bool(test.expectFailure),
+			}
+			p := &Probe{}
+			assert.NoError(t, p.Init("test-probe", opts))
+
+			res := p.newResult()
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: host}, res)
+
+			result := res.(*probeResult)
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			assert.Equal(t, test.wantHandshakeFailed, result.tlsHandshakeFailures.Int64())
+		})
+	}
+}
+
+func TestInitTLSConfigErrors(t *testing.T) {
+	opts := options.DefaultOptions()
+	opts.ProbeConf = &configpb.ProbeConf{ExpectTlsHandshakeFailure: proto.Bool(true)}
+	assert.Error(t, (&Probe{}).Init("test-probe", opts), "expect_tls_handshake_failure without tls_config")
+
+	opts = options.DefaultOptions()
+	opts.NegativeTest = true
+	opts.ProbeConf = &configpb.ProbeConf{TlsConfig: &tlsconfigpb.TLSConfig{}}
+	assert.Error(t, (&Probe{}).Init("test-probe", opts), "negative_test with tls_config")
+}
+
+func TestRunProbeFailureReasons(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+	host, portStr, _ := net.SplitHostPort(ts.Listener.Addr().String())
+	port, _ := strconv.Atoi(portStr)
+
+	// Get a port with nothing listening on it.
+	ln, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	closedPort := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	tests := []struct {
+		name       string
+		port       int
+		tlsConfig  *tlsconfigpb.TLSConfig
+		dnsServer  string
+		wantReason string
+	}{
+		{
+			name: "success",
+			port: port,
+		},
+		{
+			name:       "connect",
+			port:       closedPort,
+			wantReason: options.FailureConnect,
+		},
+		{
+			name:       "tls",
+			port:       port,
+			tlsConfig:  &tlsconfigpb.TLSConfig{},
+			wantReason: options.FailureTLS,
+		},
+		{
+			// Custom DNS server is not reachable.
+			name:       "dns",
+			port:       port,
+			dnsServer:  "tcp://127.0.0.1:" + strconv.Itoa(closedPort),
+			wantReason: options.FailureDNS,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.Timeout = 5 * time.Second
+			opts.ExportFailureReasons = true
+			opts.ProbeConf = &configpb.ProbeConf{
+				Port:      proto.Int32(int32(test.port)),
+				TlsConfig: test.tlsConfig,
+			}
+			target := endpoint.Endpoint{Name: host}
+			if test.dnsServer != "" {
+				target.Name = "cloudprober.example.com"
+				opts.Targets, err = targets.New(&targetspb.TargetsDef{
+					DnsServer: proto.String(test.dnsServer),
+					Type:      &targetspb.TargetsDef_HostNames{HostNames: target.Name},
+				}, nil, nil, nil, nil)
+				assert.NoError(t, err)
+			}
+			p := &Probe{}
+			assert.NoError(t, p.Init("test-probe", opts))
+
+			res := p.newResult()
+			p.runProbe(context.Background(), target, res)
+
+			result := res.(*probeResult)
+			for _, reason := range result.failures.Keys() {
+				want := int64(0)
+				if reason == test.wantReason {
+					want = 1
+				}
+				assert.Equal(t, want, result.failures.GetKey(reason), "reason: %s", reason)
+			}
+			assert.NotNil(t, result.Metrics(time.Now(), opts).Metric("failures"))
+		})
+	}
+}
+
+func TestRunProbeExpectUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	defer ln.Close()
+	openPort := ln.Addr().(*net.TCPAddr).Port
+
+	// Get a port with nothing listening on it.
+	ln2, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	closedPort := ln2.Addr().(*net.TCPAddr).Port
+	ln2.Close()
+
+	tests := []struct {
+		name        string
+		port        int
+		dialErr     error
+		conf        *unreachablepb.ExpectUnreachable
+		wantOutcome string
+		wantSuccess int64
+		wantReason  string
+	}{
+		{
+			name:        "refused",
+			port:        closedPort,
+			conf:        &unreachablepb.ExpectUnreachable{},
+			wantOutcome: "refused",
+			wantSuccess: 1,
+		},
+		{
+			name:        "filtered",
+			dialErr:     context.DeadlineExceeded,
+			conf:        &unreachablepb.ExpectUnreachable{},
+			wantOutcome: "filtered",
+			wantSuccess: 1,
+		},
+		{
+			name:        "refused_not_allowed",
+			port:        closedPort,
+			conf:        &unreachablepb.ExpectUnreachable{AllowRefused: proto.Bool(false)},
+			wantOutcome: "refused",
+			wantReason:  options.FailureConnect,
+		},
+		{
+			name:        "open",
+			port:        openPort,
+			conf:        &unreachablepb.ExpectUnreachable{},
+			wantOutcome: "open",
+			wantReason:  options.FailureValidation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := options.DefaultOptions()
+			opts.ExportFailureReasons = true
+			opts.ProbeConf = &configpb.ProbeConf{
+				Port:              proto.Int32(int32(test.port)),
+				ExpectUnreachable: test.conf,
+			}
+			p := &Probe{}
+			assert.NoError(t, p.Init("test-probe", opts))
+			if test.dialErr != nil {
+				p.dialContext = func(context.Context, string, string) (net.Conn, error) {
+					return nil, test.dialErr
+				}
+			}
+
+			res := p.newResult()
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: "localhost"}, res)
+
+			result := res.(*probeResult)
+			assert.Equal(t, test.wantSuccess, result.success)
+			assert.Equal(t, int64(1), result.unreachableOutcomes.GetKey(test.wantOutcome))
+			if test.wantReason != "" {
+				assert.Equal(t, int64(1), result.failures.GetKey(test.wantReason))
+			}
+			em := result.Metrics(time.Now(), opts)
+			assert.Equal(t, int64(1), em.Metric("unreachable_outcome").(*metrics.Map[int64]).GetKey(test.wantOutcome))
+		})
+	}
+
+	opts := options.DefaultOptions()
+	opts.ProbeConf = &configpb.ProbeConf{
+		ExpectUnreachable: &unreachablepb.ExpectUnreachable{},
+		TlsConfig:         &tlsconfigpb.TLSConfig{},
+	}
+	assert.Error(t, (&Probe{}).Init("test-probe", opts), "expect_unreachable with tls_config")
+}
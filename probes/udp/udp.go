@@ -27,10 +27,12 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"slices"
 	"sync"
 	"time"
 
 	udpsrv "github.com/cloudprober/cloudprober/internal/servers/udp"
+	"github.com/cloudprober/cloudprober/internal/sockopt"
 	"github.com/cloudprober/cloudprober/internal/sysvars"
 	"github.com/cloudprober/cloudprober/internal/udpmessage"
 	"github.com/cloudprober/cloudprober/logger"
@@ -86,6 +88,7 @@ type Probe struct {
 // That's the reason we use metrics.Int types instead of metrics.AtomicInt.
 type probeResult struct {
 	total, success, delayed int64
+	throttledRuns           int64
 	latency                 metrics.LatencyValue
 	target                  endpoint.Endpoint
 }
@@ -103,23 +106,22 @@ func (prr probeResult) eventMetrics(probeName string, opts *options.Options, f f
 		AddMetric("delayed"+suffix, metrics.NewInt(prr.delayed)).
 		AddLabel("ptype", "udp").
 		AddLabel("probe", probeName).
-		AddLabel("dst", f.target)
+		AddLabel("dst", prr.target.Name)
 
 	if c.GetExportMetricsByPort() {
 		m.AddLabel("src_port", f.srcPort).
 			AddLabel("dst_port", fmt.Sprintf("%d", c.GetPort()))
 	}
 
+	if opts.RateLimiter != nil {
+		m.AddMetric("throttled_runs", metrics.NewInt(prr.throttledRuns))
+	}
+
 	return m
 }
 
 func (p *Probe) newProbeResult(target endpoint.Endpoint) *probeResult {
-	var latVal metrics.LatencyValue
-	if p.opts.LatencyDist != nil {
-		latVal = p.opts.LatencyDist.CloneDist()
-	} else {
-		latVal = metrics.NewFloat(0)
-	}
+	latVal := p.opts.NewLatencyValue()
 	return &probeResult{
 		latency: latVal,
 		target:  target,
@@ -184,6 +186,15 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 			p.l.Warningf("Opening UDP socket failed: %v", err)
 			continue
 		}
+		if p.opts.DSCP != 0 {
+			if err := sockopt.SetDSCP(udpConn, p.opts.DSCP, p.l); err != nil {
+				udpConn.Close()
+				for _, c := range p.connList[:p.numConn] {
+					c.Close()
+				}
+				return err
+			}
+		}
 		p.l.Infof("UDP socket id %d, addr %v", p.numConn, udpConn.LocalAddr())
 		p.connList[p.numConn] = udpConn
 		_, p.srcPortList[p.numConn], err = net.SplitHostPort(udpConn.LocalAddr().String())
@@ -205,7 +216,7 @@ func (p *Probe) Init(name string, opts *options.Options) error {
 func (p *Probe) initProbeRunResults() error {
 	for _, target := range p.targets {
 		if !p.c.GetExportMetricsByPort() {
-			f := flow{"", target.Name}
+			f := flow{"", target.ID()}
 			if p.res[f] == nil {
 				p.res[f] = p.newProbeResult(target)
 			}
@@ -213,7 +224,7 @@ func (p *Probe) initProbeRunResults() error {
 		}
 
 		for _, srcPort := range p.srcPortList {
-			f := flow{srcPort, target.Name}
+			f := flow{srcPort, target.ID()}
 			if p.res[f] == nil {
 				p.res[f] = p.newProbeResult(target)
 			}
@@ -389,10 +400,15 @@ func (p *Probe) runSingleProbe(f flow, conn *net.UDPConn, maxLen int, raddr *net
 // "timeout" duration before exiting. "recvLoop" function is expected to
 // capture the responses before "timeout" and the main loop will flush the
 // results.
-func (p *Probe) runProbe() {
+func (p *Probe) runProbe(ctx context.Context) {
 	if !p.opts.IsScheduled() || len(p.targets) == 0 {
 		return
 	}
+	endRun, ok := p.opts.BeginRun()
+	if !ok {
+		return
+	}
+	defer endRun()
 	maxLen := int(p.c.GetMaxLength())
 
 	var packetsPerTarget, initialConn int
@@ -404,14 +420,23 @@ func (p *Probe) runProbe() {
 		initialConn = int(p.runID % uint64(len(p.connList)))
 	}
 
+	if p.opts.RateLimiter.Wait(ctx, len(p.targets)*packetsPerTarget, p.opts.MaxRateLimitDelay()) > 0 {
+		for _, res := range p.res {
+			res.throttledRuns++
+		}
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(len(p.targets) * packetsPerTarget)
 
 	for _, conn := range p.connList {
 		conn.SetWriteDeadline(time.Now().Add(p.opts.Interval / 2))
 	}
-	for _, target := range p.targets {
-		ip, err := p.opts.Targets.Resolve(target.Name, p.ipVer)
+	targets := slices.Clone(p.targets)
+	p.opts.ShuffleTargets(len(targets), func(i, j int) { targets[i], targets[j] = targets[j], targets[i] })
+
+	for _, target := range targets {
+		ip, err := target.Resolve(p.ipVer, p.opts.Targets)
 		if err != nil {
 			p.l.Errorf("unable to resolve %s: %v", target.Name, err)
 			continue
@@ -434,7 +459,7 @@ func (p *Probe) runProbe() {
 				if err := p.runSingleProbe(f, conn, maxLen, &net.UDPAddr{IP: ip, Port: dstPort}); err != nil {
 					p.l.Errorf("Probing %+v failed: %v", f, err)
 				}
-			}(conn, flow{p.srcPortList[connID], target.Name})
+			}(conn, flow{p.srcPortList[connID], target.ID()})
 		}
 	}
 	wg.Wait()
@@ -481,7 +506,7 @@ func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics)
 			}
 			return
 		case <-probeTicker.C:
-			p.runProbe()
+			p.runProbe(ctx)
 		case <-flushTicker.C:
 			p.processPackets()
 		case <-statsExportTicker.C:
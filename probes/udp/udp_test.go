@@ -177,7 +177,7 @@ func runProbe(t *testing.T, interval, timeout time.Duration, probesToSend int, s
 
 	time.Sleep(interval)
 	for i := 0; i < probesToSend; i++ {
-		p.runProbe()
+		p.runProbe(context.Background())
 		time.Sleep(interval)
 	}
 
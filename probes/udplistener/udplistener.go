@@ -150,7 +150,26 @@ func (prr probeRunResult) Metrics() *metrics.EventMetrics {
 }
 
 func (p *Probe) updateTargets() {
-	p.targets = p.opts.Targets.ListEndpoints()
+	// Senders are identified by name. Endpoints expanded from a multi-IP
+	// resource are collapsed back into one endpoint per name.
+	p.targets = nil
+	seen := make(map[string]bool)
+	for _, ep := range p.opts.Targets.ListEndpoints() {
+		if ep.ExpandedIP() != "" {
+			if seen[ep.Name] {
+				continue
+			}
+			labels := make(map[string]string, len(ep.Labels))
+			for k, v := range ep.Labels {
+				if k != endpoint.IPLabel {
+					labels[k] = v
+				}
+			}
+			ep.IP, ep.Labels = nil, labels
+		}
+		seen[ep.Name] = true
+		p.targets = append(p.targets, ep)
+	}
 
 	for _, target := range p.targets {
 		for _, al := range p.opts.AdditionalLabels {
@@ -0,0 +1,72 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This program implements a stand-alone WebSocket prober binary using the
+// cloudprober/websocket package. It's intended to help prototype the websocket
+// package quickly and doesn't provide the facilities that cloudprober provides.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/probes/websocket"
+	configpb "github.com/cloudprober/cloudprober/probes/websocket/proto"
+	"github.com/cloudprober/cloudprober/targets"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	intervalF = flag.Duration("interval", time.Second*10, "Interval between probes")
+	timeoutF  = flag.Duration("timeout", time.Second*2, "Per-probe timeout")
+	targetsF  = flag.String("targets", "localhost", "Static host targets.")
+	portF     = flag.Int("port", 8080, "WebSocket port")
+	pathF     = flag.String("path", "/", "WebSocket request path")
+	messageF  = flag.String("message", "", "Message to send, expecting an echo")
+)
+
+func main() {
+	flag.Parse()
+
+	c := &configpb.ProbeConf{
+		Port: proto.Int32(int32(*portF)),
+		Path: proto.String(*pathF),
+	}
+	if *messageF != "" {
+		c.Message = proto.String(*messageF)
+		c.ExpectEcho = proto.Bool(true)
+	}
+
+	opts := options.DefaultOptions()
+	opts.Interval, opts.Timeout = *intervalF, *timeoutF
+	opts.Targets = targets.StaticTargets(*targetsF)
+	opts.ProbeConf = c
+
+	wp := &websocket.Probe{}
+	if err := wp.Init("websocket_test", opts); err != nil {
+		log.Fatalf("Error in initializing probe %s from the config. Err: %v", "websocket_test", err)
+	}
+	dataChan := make(chan *metrics.EventMetrics, 1000)
+	go wp.Start(context.Background(), dataChan)
+
+	for {
+		em := <-dataChan
+		fmt.Println(em.String())
+	}
+}
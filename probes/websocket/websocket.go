@@ -0,0 +1,351 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package websocket implements a WebSocket probe type. It performs the
+// WebSocket opening handshake with the targets and, optionally, measures the
+// round-trip time of a message.
+package websocket
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/iputils"
+	"github.com/cloudprober/cloudprober/internal/sockopt"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/internal/validators"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/common/sched"
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/websocket/proto"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"golang.org/x/net/websocket"
+)
+
+// Probe holds aggregate information about all probe runs, per-target.
+type Probe struct {
+	name string
+	opts *options.Options
+	c    *configpb.ProbeConf
+	l    *logger.Logger
+
+	scheme      string
+	header      http.Header
+	tlsConfig   *tls.Config
+	dialContext func(context.Context, string, string) (net.Conn, error)
+}
+
+type probeResult struct {
+	total, success                     int64
+	handshakeFailures, messageFailures int64
+	latency                            metrics.LatencyValue
+	handshakeLatency                   metrics.LatencyValue
+	validationFailure                  *metrics.Map[int64]
+	validationSuccess                  *metrics.Map[int64]
+	failures                           *metrics.Map[int64]
+}
+
+func (p *Probe) newResult() sched.ProbeResult {
+	result := &probeResult{
+		latency:  p.opts.NewLatencyValue(),
+		failures: p.opts.NewFailureReasons(options.FailureStream),
+	}
+	if p.c.Message != nil {
+		result.handshakeLatency = p.opts.NewLatencyValue()
+	}
+	if p.opts.Validators != nil {
+		result.validationFailure = validators.ValidationFailureMap(p.opts.Validators)
+		result.validationSuccess = p.opts.NewValidationSuccess(p.opts.Validators)
+	}
+	return result
+}
+
+func (result *probeResult) Metrics(ts time.Time, opts *options.Options) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(ts).
+		AddMetric("total", metrics.NewInt(result.total)).
+		AddMetric("success", metrics.NewInt(result.success)).
+		AddMetric(opts.LatencyMetricName, result.latency.Clone()).
+		AddMetric("handshake_failures", metrics.NewInt(result.handshakeFailures)).
+		AddMetric("message_failures", metrics.NewInt(result.messageFailures)).
+		AddLabel("ptype", "websocket")
+
+	if result.handshakeLatency != nil {
+		em.AddMetric("handshake_latency", result.handshakeLatency.Clone())
+	}
+	if result.validationFailure != nil {
+		em.AddMetric("validation_failure", result.validationFailure.Clone())
+	}
+	if result.validationSuccess != nil {
+		em.AddMetric("validation_success", result.validationSuccess.Clone())
+	}
+	if result.failures != nil {
+		em.AddMetric("failures", result.failures.Clone())
+	}
+
+	return em
+}
+
+// Init initializes the probe with the given params.
+func (p *Probe) Init(name string, opts *options.Options) error {
+	if opts.ProbeConf == nil {
+		opts.ProbeConf = &configpb.ProbeConf{}
+	}
+
+	c, ok := opts.ProbeConf.(*configpb.ProbeConf)
+	if !ok {
+		return fmt.Errorf("not websocket probe config")
+	}
+	p.name = name
+	p.opts = opts
+	if p.l = opts.Logger; p.l == nil {
+		p.l = &logger.Logger{}
+	}
+	p.c = c
+
+	if p.c.GetExpectEcho() && p.c.Message == nil {
+		return fmt.Errorf("expect_echo requires message")
+	}
+	if p.opts.Validators != nil && p.c.Message == nil {
+		return fmt.Errorf("validators require message, as they are run on the response message")
+	}
+
+	p.scheme = "ws"
+	if p.c.GetScheme() == configpb.ProbeConf_WSS {
+		p.scheme = "wss"
+		p.tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(p.tlsConfig, p.c.GetTlsConfig()); err != nil {
+			return err
+		}
+	} else if p.c.GetTlsConfig() != nil {
+		return fmt.Errorf("tls_config is supported only with the WSS scheme")
+	}
+
+	p.header = make(http.Header)
+	for _, h := range p.c.GetHeader() {
+		p.header.Add(h.GetName(), h.GetValue())
+	}
+
+	dialer := &net.Dialer{}
+	if p.opts.SourceIP != nil {
+		if err := iputils.CheckLocalIP(p.opts.SourceIP); err != nil {
+			return err
+		}
+		dialer.LocalAddr = &net.TCPAddr{IP: p.opts.SourceIP}
+	}
+	if p.opts.DSCP != 0 {
+		dialer.Control = sockopt.DialerControl(p.opts.DSCP, p.l)
+	}
+	// Use targets' DNS server, if configured, to resolve the target names.
+	dialer.Resolver = targets.DNSResolver(p.opts.Targets)
+	p.dialContext = dialer.DialContext
+
+	return nil
+}
+
+func (p *Probe) port(target endpoint.Endpoint) int {
+	if port := int(p.c.GetPort()); port != 0 {
+		return port
+	}
+	if target.Port != 0 {
+		return target.Port
+	}
+	if p.scheme == "wss" {
+		return 443
+	}
+	return 80
+}
+
+// wsConfig returns the WebSocket config for the given target address, i.e.
+// "host:port".
+func (p *Probe) wsConfig(targetAddr string) (*websocket.Config, error) {
+	origin := p.c.GetOrigin()
+	if origin == "" {
+		origin = "http://" + targetAddr
+		if p.scheme == "wss" {
+			origin = "https://" + targetAddr
+		}
+	}
+	cfg, err := websocket.NewConfig(p.scheme+"://"+targetAddr+p.c.GetPath(), origin)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Header = p.header
+	cfg.Protocol = p.c.GetSubprotocol()
+	return cfg, nil
+}
+
+// connect connects to the target, and performs the TLS handshake (for WSS)
+// and the WebSocket opening handshake.
+func (p *Probe) connect(ctx context.Context, target endpoint.Endpoint, dialAddr string, cfg *websocket.Config) (*websocket.Conn, error) {
+	conn, err := p.dialContext(ctx, "tcp", dialAddr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if p.tlsConfig != nil {
+		tlsConfig := p.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = target.Name
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// handshakeFailureReason returns the failure reason for the handshake
+// errors. Opening handshake errors are reported as status failures.
+func handshakeFailureReason(err error) string {
+	if _, ok := err.(*websocket.ProtocolError); ok {
+		return options.FailureStatus
+	}
+	return options.ClassifyError(err)
+}
+
+// exchange sends the message, and returns the response message.
+func (p *Probe) exchange(ws *websocket.Conn) (string, error) {
+	if err := websocket.Message.Send(ws, p.c.GetMessage()); err != nil {
+		return "", err
+	}
+	var resp string
+	if err := websocket.Message.Receive(ws, &resp); err != nil {
+		return "", err
+	}
+	return resp, nil
+}
+
+func (p *Probe) runProbe(ctx context.Context, target endpoint.Endpoint, res sched.ProbeResult) {
+	ctx, cancelCtx := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancelCtx()
+
+	result := res.(*probeResult)
+	result.total++
+
+	port := p.port(target)
+	targetAddr := net.JoinHostPort(target.Name, strconv.Itoa(port))
+	dialAddr, ipLabel := targetAddr, ""
+
+	resolveFirst := target.IP != nil
+	if p.c.ResolveFirst != nil {
+		resolveFirst = p.c.GetResolveFirst()
+	}
+	if resolveFirst {
+		ip, err := target.Resolve(p.opts.IPVersion, p.opts.Targets)
+		if err != nil {
+			p.l.Error("target: ", target.Name, ", resolve error: ", err.Error())
+			result.handshakeFailures++
+			options.RecordFailure(result.failures, options.FailureDNS)
+			return
+		}
+		ipLabel = ip.String()
+		dialAddr = net.JoinHostPort(ipLabel, strconv.Itoa(port))
+	}
+
+	for _, al := range p.opts.AdditionalLabels {
+		al.UpdateForTarget(target, ipLabel, port)
+	}
+
+	cfg, err := p.wsConfig(targetAddr)
+	if err != nil {
+		p.l.Error("target: ", target.Name, ", invalid WebSocket config: ", err.Error())
+		result.handshakeFailures++
+		options.RecordFailure(result.failures, options.FailureOther)
+		return
+	}
+
+	start := time.Now()
+	ws, err := p.connect(ctx, target, dialAddr, cfg)
+	if err != nil {
+		p.l.Warning("Target:", target.Name, ", handshake: ", err.Error())
+		result.handshakeFailures++
+		options.RecordFailure(result.failures, handshakeFailureReason(err))
+		return
+	}
+	defer ws.Close()
+	handshakeTime := time.Since(start)
+
+	if p.c.Message == nil {
+		result.success++
+		result.latency.AddFloat64(handshakeTime.Seconds() / p.opts.LatencyUnit.Seconds())
+		return
+	}
+	result.handshakeLatency.AddFloat64(handshakeTime.Seconds() / p.opts.LatencyUnit.Seconds())
+
+	start = time.Now()
+	resp, err := p.exchange(ws)
+	if err != nil {
+		p.l.Warning("Target:", target.Name, ", message: ", err.Error())
+		result.messageFailures++
+		reason := options.ClassifyError(err)
+		if reason == options.FailureOther {
+			reason = options.FailureStream
+		}
+		options.RecordFailure(result.failures, reason)
+		return
+	}
+	rtt := time.Since(start)
+
+	if p.c.GetExpectEcho() && resp != p.c.GetMessage() {
+		p.l.Warningf("Target: %s, response message (%q) is not an echo of the sent message (%q)", target.Name, resp, p.c.GetMessage())
+		result.messageFailures++
+		options.RecordFailure(result.failures, options.FailureValidation)
+		return
+	}
+
+	if p.opts.Validators != nil {
+		failedValidations := validators.RunValidators(p.opts.Validators, &validators.Input{ResponseBody: []byte(resp)}, result.validationFailure, result.validationSuccess, p.l)
+		if len(failedValidations) > 0 {
+			p.l.Debugf("Target: %s, failed validations: %v", target.Name, failedValidations)
+			result.messageFailures++
+			options.RecordFailure(result.failures, options.FailureValidation)
+			return
+		}
+	}
+
+	result.success++
+	result.latency.AddFloat64(rtt.Seconds() / p.opts.LatencyUnit.Seconds())
+}
+
+// Start starts and runs the probe indefinitely.
+func (p *Probe) Start(ctx context.Context, dataChan chan *metrics.EventMetrics) {
+	s := &sched.Scheduler{
+		ProbeName:              p.name,
+		DataChan:               dataChan,
+		Opts:                   p.opts,
+		NewResult:              p.newResult,
+		RunProbeForTarget:      p.runProbe,
+		IntervalBetweenTargets: time.Duration(p.c.GetIntervalBetweenTargetsMsec()) * time.Millisecond,
+	}
+	s.UpdateTargetsAndStartProbes(ctx)
+}
@@ -0,0 +1,141 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	tlsconfigpb "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/probes/options"
+	configpb "github.com/cloudprober/cloudprober/probes/websocket/proto"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+	"google.golang.org/protobuf/proto"
+)
+
+// testServer serves an echo WebSocket at /echo, a WebSocket that replies
+// with the upper-cased message at /upper, and 404s everything else.
+func testServer(t *testing.T) (string, int) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle("/echo", websocket.Handler(func(ws *websocket.Conn) {
+		io.Copy(ws, ws)
+	}))
+	mux.Handle("/upper", websocket.Handler(func(ws *websocket.Conn) {
+		var msg string
+		if websocket.Message.Receive(ws, &msg) == nil {
+			websocket.Message.Send(ws, strings.ToUpper(msg))
+		}
+	}))
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+	return u.Hostname(), port
+}
+
+func testProbe(t *testing.T, host string, c *configpb.ProbeConf) *Probe {
+	t.Helper()
+	opts := &options.Options{
+		Targets:              targets.StaticTargets(host),
+		Interval:             2 * time.Second,
+		Timeout:              time.Second,
+		LatencyUnit:          time.Millisecond,
+		ProbeConf:            c,
+		ExportFailureReasons: true,
+		LogMetrics:           func(_ *metrics.EventMetrics) {},
+	}
+	p := &Probe{}
+	require.NoError(t, p.Init("websocket_test", opts))
+	return p
+}
+
+func TestRunProbe(t *testing.T) {
+	host, port := testServer(t)
+
+	tests := []struct {
+		name              string
+		path              string
+		message           string
+		expectEcho        bool
+		wantSuccess       int64
+		wantHandshakeFail int64
+		wantMessageFail   int64
+		wantFailure       string
+	}{
+		{name: "handshake_only", path: "/echo", wantSuccess: 1},
+		{name: "echo", path: "/echo", message: "hello", expectEcho: true, wantSuccess: 1},
+		{name: "no_echo_check", path: "/upper", message: "hello", wantSuccess: 1},
+		{name: "echo_mismatch", path: "/upper", message: "hello", expectEcho: true, wantMessageFail: 1, wantFailure: options.FailureValidation},
+		{name: "not_websocket", path: "/unknown", wantHandshakeFail: 1, wantFailure: options.FailureStatus},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &configpb.ProbeConf{
+				Port: proto.Int32(int32(port)),
+				Path: proto.String(test.path),
+			}
+			if test.message != "" {
+				c.Message = proto.String(test.message)
+				c.ExpectEcho = proto.Bool(test.expectEcho)
+			}
+			p := testProbe(t, host, c)
+
+			result := p.newResult().(*probeResult)
+			p.runProbe(context.Background(), endpoint.Endpoint{Name: host}, result)
+
+			assert.Equal(t, int64(1), result.total)
+			assert.Equal(t, test.wantSuccess, result.success)
+			assert.Equal(t, test.wantHandshakeFail, result.handshakeFailures)
+			assert.Equal(t, test.wantMessageFail, result.messageFailures)
+			if test.wantFailure != "" {
+				assert.Equal(t, int64(1), result.failures.GetKey(test.wantFailure))
+			}
+
+			em := result.Metrics(time.Now(), p.opts)
+			assert.Equal(t, "websocket", em.Label("ptype"))
+			assert.Equal(t, test.message != "", em.Metric("handshake_latency") != nil)
+		})
+	}
+}
+
+func TestInitErrors(t *testing.T) {
+	for _, c := range []*configpb.ProbeConf{
+		{ExpectEcho: proto.Bool(true)},
+		{TlsConfig: &tlsconfigpb.TLSConfig{}},
+	} {
+		opts := &options.Options{
+			Targets:   targets.StaticTargets("localhost"),
+			Interval:  2 * time.Second,
+			Timeout:   time.Second,
+			ProbeConf: c,
+		}
+		assert.Error(t, (&Probe{}).Init("websocket_test", opts), "config: %v", c)
+	}
+}
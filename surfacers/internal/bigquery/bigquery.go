@@ -28,7 +28,9 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 )
 
 type bqrow struct {
@@ -47,6 +49,10 @@ type Surfacer struct {
 
 	// Channel for incoming data.
 	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+
+	// Channel for the flush requests.
+	flushChan chan chan struct{}
 
 	// Cloud logger
 	l *logger.Logger
@@ -70,13 +76,16 @@ func (row *bqrow) Save() (map[string]bigquery.Value, string, error) {
 
 // Write takes the data to be written
 func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case s.writeChan <- em:
-	default:
+	if !s.queue.Enqueue(em) {
 		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
 func convertToBqType(colType, label string) (bigquery.Value, error) {
 	if label == "" {
 		return "", nil
@@ -289,12 +298,22 @@ func (s *Surfacer) writeToBQ(ctx context.Context, inserter iInserter) {
 			return
 		case <-ticker.C:
 			s.batchInsertRowsToBQ(ctx, inserter)
+		case done := <-s.flushChan:
+			s.batchInsertRowsToBQ(ctx, inserter)
+			close(done)
 		}
 	}
 }
 
+// Flush inserts the pending rows into bigquery.
+func (s *Surfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, s.flushChan)
+}
+
 func (s *Surfacer) init(ctx context.Context) error {
 	s.writeChan = make(chan *metrics.EventMetrics, s.c.GetMetricsBufferSize())
+	s.queue = queue.New(s.writeChan)
+	s.flushChan = flush.NewChan()
 
 	client, err := bigquery.NewClient(ctx, s.c.GetProjectName())
 	if err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/bigquery/proto"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 )
 
 const (
@@ -72,6 +73,7 @@ func TestWriteWhenSurfacerChannelIsNotFull(t *testing.T) {
 	for _, num := range numMetrics {
 		s := &Surfacer{}
 		s.writeChan = make(chan *metrics.EventMetrics, num)
+		s.queue = queue.New(s.writeChan)
 		oldChanLen := len(s.writeChan)
 		ctx := context.Background()
 
@@ -91,6 +93,7 @@ func TestWriteWhenSurfacerChannelIsFull(t *testing.T) {
 	for _, num := range numMetrics {
 		s := &Surfacer{}
 		s.writeChan = make(chan *metrics.EventMetrics, num)
+		s.queue = queue.New(s.writeChan)
 		ctx := context.Background()
 
 		for i := 0; i < num; i++ {
@@ -211,6 +214,7 @@ func TestParseBQColsForValidRow(t *testing.T) {
 		l:         &logger.Logger{},
 		writeChan: make(chan *metrics.EventMetrics, 10),
 	}
+	s.queue = queue.New(s.writeChan)
 
 	em := metrics.NewEventMetrics(time.Now())
 	for k, v := range colValueMap {
@@ -263,6 +267,7 @@ func TestInsertRowsToBQ(t *testing.T) {
 			l:         &logger.Logger{},
 			writeChan: make(chan *metrics.EventMetrics, tc),
 		}
+		s.queue = queue.New(s.writeChan)
 
 		em := metrics.NewEventMetrics(time.Now())
 		for k, v := range colValueMap {
@@ -300,6 +305,7 @@ func TestBatchInsertion(t *testing.T) {
 			l:         &logger.Logger{},
 			writeChan: make(chan *metrics.EventMetrics, tc),
 		}
+		s.queue = queue.New(s.writeChan)
 
 		em := metrics.NewEventMetrics(time.Now())
 		for k, v := range colValueMap {
@@ -338,6 +344,7 @@ func TestWriteToBQ(t *testing.T) {
 		l:         &logger.Logger{},
 		writeChan: make(chan *metrics.EventMetrics, 4500),
 	}
+	s.queue = queue.New(s.writeChan)
 
 	em := metrics.NewEventMetrics(time.Now())
 	for k, v := range colValueMap {
@@ -31,7 +31,9 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch/proto"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 )
 
 // The dimension named used to identify distributions
@@ -42,6 +44,8 @@ type CWSurfacer struct {
 	c         *configpb.SurfacerConf
 	opts      *options.Options
 	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+	flushChan chan chan struct{}
 	session   *cloudwatch.Client
 	l         *logger.Logger
 
@@ -65,10 +69,12 @@ func New(ctx context.Context, conf *configpb.SurfacerConf, opts *options.Options
 		c:                conf,
 		opts:             opts,
 		writeChan:        make(chan *metrics.EventMetrics, opts.Config.GetMetricsBufferSize()), // incoming internal metrics buffer
+		flushChan:        flush.NewChan(),
 		session:          cloudwatch.NewFromConfig(cfg),
 		l:                l,
 		metricDatumCache: make([]types.MetricDatum, 0, int(conf.GetMetricsBatchSize())), // batching buffer between cloudprober and cloudwatch
 	}
+	cw.queue = queue.New(cw.writeChan)
 
 	go cw.processIncomingMetrics(ctx)
 
@@ -79,13 +85,16 @@ func New(ctx context.Context, conf *configpb.SurfacerConf, opts *options.Options
 // Write is a function defined to comply with the surfacer interface, and enables the
 // cloudwatch surfacer to receive EventMetrics over the buffered channel.
 func (cw *CWSurfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case cw.writeChan <- em:
-	default:
+	if !cw.queue.Enqueue(em) {
 		cw.l.Error("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (cw *CWSurfacer) Queue() *queue.Queue {
+	return cw.queue
+}
+
 func (cw *CWSurfacer) processIncomingMetrics(ctx context.Context) {
 	publishTimer := time.NewTicker(time.Duration(cw.c.GetBatchTimerSec()) * time.Second)
 	defer publishTimer.Stop()
@@ -101,10 +110,23 @@ func (cw *CWSurfacer) processIncomingMetrics(ctx context.Context) {
 			if len(cw.metricDatumCache) != 0 {
 				cw.publishMetrics(ctx)
 			}
+		case done := <-cw.flushChan:
+			for len(cw.writeChan) > 0 {
+				cw.recordEventMetrics(ctx, publishTimer, <-cw.writeChan)
+			}
+			if len(cw.metricDatumCache) != 0 {
+				cw.publishMetrics(ctx)
+			}
+			close(done)
 		}
 	}
 }
 
+// Flush publishes the pending metrics to cloudwatch.
+func (cw *CWSurfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, cw.flushChan)
+}
+
 func recordMapValue[T int64 | float64](ctx context.Context, cw *CWSurfacer, key string, m *metrics.Map[T], d []types.Dimension, em *metrics.EventMetrics, publishTimer *time.Ticker) {
 	for _, mapKey := range m.Keys() {
 		newDimensions := append(d, types.Dimension{
@@ -138,14 +160,26 @@ func (cw *CWSurfacer) recordEventMetrics(ctx context.Context, publishTimer *time
 			recordMapValue(ctx, cw, metricKey, value, emLabelsToDimensions(em), em, publishTimer)
 
 		case *metrics.Distribution:
-			for i, distributionBound := range value.Data().LowerBounds {
+			d := value.Data()
+			for i, distributionBound := range d.LowerBounds {
 				dimensions := append(emLabelsToDimensions(em), types.Dimension{
 					Name:  aws.String(distributionDimensionName),
 					Value: aws.String(strconv.FormatFloat(distributionBound, 'f', -1, 64)),
 				})
-				metricDatum := cw.newCWMetricDatum(metricKey, float64(value.Data().BucketCounts[i]), dimensions, em.Timestamp, em.LatencyUnit)
+				metricDatum := cw.newCWMetricDatum(metricKey, float64(d.BucketCounts[i]), dimensions, em.Timestamp, em.LatencyUnit)
 				cw.addMetricAndPublish(ctx, publishTimer, metricDatum)
 			}
+
+			if cw.c.GetExportDistributionSumCount() {
+				// Sum is created under the distribution's name, so that it
+				// gets the same unit conversion as the distribution.
+				sumDatum := cw.newCWMetricDatum(metricKey, d.Sum, emLabelsToDimensions(em), em.Timestamp, em.LatencyUnit)
+				sumDatum.MetricName = aws.String(metricKey + "_sum")
+				cw.addMetricAndPublish(ctx, publishTimer, sumDatum)
+
+				countDatum := cw.newCWMetricDatum(metricKey+"_count", float64(d.Count), emLabelsToDimensions(em), em.Timestamp, em.LatencyUnit)
+				cw.addMetricAndPublish(ctx, publishTimer, countDatum)
+			}
 		}
 	}
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch/proto"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func newTestCWSurfacer() CWSurfacer {
@@ -342,3 +343,32 @@ func TestCWSurfacerRecordEventMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestCWSurfacerRecordDistributionSumCount(t *testing.T) {
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddFloat64(2.5)
+	d.AddFloat64(12.25)
+	em := metrics.NewEventMetrics(time.Now()).AddMetric("latency", d)
+	em.LatencyUnit = time.Microsecond
+
+	publishTimer := time.NewTicker(1 * time.Hour)
+	defer publishTimer.Stop()
+
+	cw := &CWSurfacer{c: &configpb.SurfacerConf{}}
+	cw.recordEventMetrics(context.TODO(), publishTimer, em)
+	assert.Len(t, cw.metricDatumCache, 3, "only bucket counts by default")
+
+	cw = &CWSurfacer{c: &configpb.SurfacerConf{ExportDistributionSumCount: proto.Bool(true)}}
+	cw.recordEventMetrics(context.TODO(), publishTimer, em)
+	assert.Len(t, cw.metricDatumCache, 5)
+
+	got := make(map[string]types.MetricDatum)
+	for _, md := range cw.metricDatumCache {
+		got[*md.MetricName] = md
+	}
+	// Sum is converted to milliseconds, like the other latency values.
+	assert.Equal(t, 0.01475, *got["latency_sum"].Value)
+	assert.Equal(t, types.StandardUnitMilliseconds, got["latency_sum"].Unit)
+	assert.Equal(t, float64(2), *got["latency_count"].Value)
+	assert.Equal(t, types.StandardUnitCount, got["latency_count"].Unit)
+}
@@ -50,6 +50,11 @@ type SurfacerConf struct {
 	// Metrics will be published when the timer expires, or the buffer is
 	// full, whichever happens first.
 	BatchTimerSec *int32 `protobuf:"varint,5,opt,name=batch_timer_sec,json=batchTimerSec,def=30" json:"batch_timer_sec,omitempty"`
+	// If set, distributions' raw sum and count of observations are also
+	// published, as "<metric>_sum" and "<metric>_count" metrics, so that
+	// averages can be computed exactly, e.g. across instances. By default only
+	// the bucket counts are published.
+	ExportDistributionSumCount *bool `protobuf:"varint,6,opt,name=export_distribution_sum_count,json=exportDistributionSumCount" json:"export_distribution_sum_count,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -127,6 +132,13 @@ func (x *SurfacerConf) GetBatchTimerSec() int32 {
 	return Default_SurfacerConf_BatchTimerSec
 }
 
+func (x *SurfacerConf) GetExportDistributionSumCount() bool {
+	if x != nil && x.ExportDistributionSumCount != nil {
+		return *x.ExportDistributionSumCount
+	}
+	return false
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_config_proto_rawDesc = []byte{
@@ -137,7 +149,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_
 	0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
 	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x22, 0xd5, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
+	0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x22, 0x98, 0x02, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61,
 	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x29, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
 	0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0b, 0x63, 0x6c, 0x6f, 0x75,
 	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
@@ -150,12 +162,16 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_cloudwatch_proto_
 	0x52, 0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69,
 	0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
 	0x72, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33, 0x30, 0x52,
-	0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x63, 0x42, 0x48,
-	0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
-	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
-	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74,
-	0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x63, 0x12, 0x41,
+	0x0a, 0x1d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x6d, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x77, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
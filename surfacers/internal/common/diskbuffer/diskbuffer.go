@@ -0,0 +1,363 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package diskbuffer implements an on-disk write-ahead buffer for surfacers.
+
+EventMetrics written to the buffer are appended to segment files in the
+buffer directory. A delivery loop reads them back in order and forwards them
+to the surfacer, retrying failed deliveries. Read position is checkpointed, so
+pending EventMetrics are replayed after a restart. If the buffer grows beyond
+its maximum size, oldest segments are dropped.
+*/
+package diskbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+)
+
+const (
+	segmentSuffix  = ".wal"
+	checkpointFile = "checkpoint"
+
+	// Buffer is divided into these many segments. Disk usage is controlled by
+	// dropping whole segments.
+	numSegments = 8
+)
+
+// DeliverFunc forwards a batch of EventMetrics to the surfacer. If it returns
+// an error, the same batch is retried after the retry interval.
+type DeliverFunc func(context.Context, []*metrics.EventMetrics) error
+
+// Buffer is an on-disk buffer of EventMetrics.
+type Buffer struct {
+	dir           string
+	maxSize       int64
+	segmentSize   int64
+	batchSize     int
+	retryInterval time.Duration
+	l             *logger.Logger
+
+	mu        sync.Mutex
+	segments  []int64 // Segment sequence numbers, in ascending order.
+	sizes     map[int64]int64
+	totalSize int64
+	w         *os.File // Active (last) segment.
+	wSize     int64
+
+	// Read position. Accessed only by the delivery loop.
+	rSeq, rOff int64
+
+	notify chan struct{}
+}
+
+func segmentName(seq int64) string {
+	return fmt.Sprintf("%020d%s", seq, segmentSuffix)
+}
+
+func (b *Buffer) segmentPath(seq int64) string {
+	return filepath.Join(b.dir, segmentName(seq))
+}
+
+// New creates a buffer based on the given config, and starts the delivery
+// loop that forwards buffered EventMetrics using the deliver function. The
+// delivery loop runs until the context is canceled.
+func New(ctx context.Context, c *surfacerpb.SurfacerDef_DiskBuffer, deliver DeliverFunc, l *logger.Logger) (*Buffer, error) {
+	if c.GetMaxSizeMb() <= 0 {
+		return nil, fmt.Errorf("diskbuffer: invalid max_size_mb: %d", c.GetMaxSizeMb())
+	}
+	b, err := newBuffer(c.GetDir(), int64(c.GetMaxSizeMb())<<20, int(c.GetBatchSize()), time.Duration(c.GetRetryIntervalSec())*time.Second, l)
+	if err != nil {
+		return nil, err
+	}
+	go b.deliveryLoop(ctx, deliver)
+	return b, nil
+}
+
+func newBuffer(dir string, maxSize int64, batchSize int, retryInterval time.Duration, l *logger.Logger) (*Buffer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("diskbuffer: dir is required")
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("diskbuffer: error creating directory %s: %v", dir, err)
+	}
+
+	b := &Buffer{
+		dir:           dir,
+		maxSize:       maxSize,
+		segmentSize:   maxSize / numSegments,
+		batchSize:     batchSize,
+		retryInterval: retryInterval,
+		l:             l,
+		sizes:         make(map[int64]int64),
+		notify:        make(chan struct{}, 1),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("diskbuffer: error reading directory %s: %v", dir, err)
+	}
+	for _, e := range entries {
+		seq, err := strconv.ParseInt(strings.TrimSuffix(e.Name(), segmentSuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(e.Name(), segmentSuffix) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("diskbuffer: error reading segment %s: %v", e.Name(), err)
+		}
+		b.segments = append(b.segments, seq)
+		b.sizes[seq] = info.Size()
+		b.totalSize += info.Size()
+	}
+	sort.Slice(b.segments, func(i, j int) bool { return b.segments[i] < b.segments[j] })
+
+	b.rSeq, b.rOff = b.readCheckpoint()
+	if len(b.segments) > 0 && b.rSeq < b.segments[0] {
+		b.rSeq, b.rOff = b.segments[0], 0
+	}
+	if len(b.segments) > 0 {
+		b.l.Infof("diskbuffer: found %d pending segments (%d bytes) in %s", len(b.segments), b.totalSize, dir)
+	}
+
+	// We always start writing to a new segment.
+	var nextSeq int64 = 1
+	if len(b.segments) > 0 {
+		nextSeq = b.segments[len(b.segments)-1] + 1
+	}
+	if err := b.openSegment(nextSeq); err != nil {
+		return nil, err
+	}
+	if b.rSeq == 0 {
+		b.rSeq = nextSeq
+	}
+	return b, nil
+}
+
+// openSegment creates a new active segment. It's called with the lock held,
+// or during initialization.
+func (b *Buffer) openSegment(seq int64) error {
+	f, err := os.OpenFile(b.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("diskbuffer: error creating segment: %v", err)
+	}
+	if b.w != nil {
+		b.w.Close()
+	}
+	b.w, b.wSize = f, 0
+	b.segments = append(b.segments, seq)
+	b.sizes[seq] = 0
+	return nil
+}
+
+func (b *Buffer) activeSeq() int64 {
+	return b.segments[len(b.segments)-1]
+}
+
+// removeSegment removes a segment. It's called with the lock held.
+func (b *Buffer) removeSegment(seq int64) {
+	for i, s := range b.segments {
+		if s == seq {
+			b.segments = append(b.segments[:i], b.segments[i+1:]...)
+			break
+		}
+	}
+	b.totalSize -= b.sizes[seq]
+	delete(b.sizes, seq)
+	if err := os.Remove(b.segmentPath(seq)); err != nil && !os.IsNotExist(err) {
+		b.l.Warningf("diskbuffer: error removing segment %d: %v", seq, err)
+	}
+}
+
+// Write appends the EventMetrics to the buffer.
+func (b *Buffer) Write(em *metrics.EventMetrics) {
+	line, err := encode(em)
+	if err != nil {
+		b.l.Warningf("diskbuffer: error encoding EventMetrics: %v", err)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.wSize > 0 && b.wSize+int64(len(line)) > b.segmentSize {
+		if err := b.openSegment(b.activeSeq() + 1); err != nil {
+			b.l.Error(err.Error())
+			return
+		}
+	}
+
+	n, err := b.w.Write(line)
+	b.wSize += int64(n)
+	b.sizes[b.activeSeq()] += int64(n)
+	b.totalSize += int64(n)
+	if err != nil {
+		b.l.Errorf("diskbuffer: error writing to segment: %v", err)
+	}
+
+	// Drop oldest segments, but never the active one.
+	for b.totalSize > b.maxSize && len(b.segments) > 1 {
+		b.l.Warningf("diskbuffer: buffer size (%d bytes) exceeds the limit (%d bytes), dropping oldest segment %d", b.totalSize, b.maxSize, b.segments[0])
+		b.removeSegment(b.segments[0])
+	}
+
+	select {
+	case b.notify <- struct{}{}:
+	default:
+	}
+}
+
+// readBatch reads up to batchSize EventMetrics from the current read
+// position. It returns the EventMetrics and the number of bytes consumed.
+func (b *Buffer) readBatch() ([]*metrics.EventMetrics, int64, error) {
+	f, err := os.Open(b.segmentPath(b.rSeq))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(b.rOff, io.SeekStart); err != nil {
+		return nil, 0, err
+	}
+
+	var ems []*metrics.EventMetrics
+	var consumed int64
+	r := bufio.NewReader(f)
+	for len(ems) < b.batchSize {
+		line, err := r.ReadBytes('\n')
+		// Incomplete line, possibly being written right now.
+		if err != nil {
+			break
+		}
+		consumed += int64(len(line))
+
+		em, err := decode(bytes.TrimSpace(line))
+		if err != nil {
+			b.l.Warningf("diskbuffer: skipping bad record in segment %d: %v", b.rSeq, err)
+			continue
+		}
+		ems = append(ems, em)
+	}
+	return ems, consumed, nil
+}
+
+// nextReadSegment moves the read position forward if the current segment is
+// done or has been dropped. It returns false if there is nothing to read
+// right now.
+func (b *Buffer) nextReadSegment(segmentDone bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.rSeq < b.segments[0] {
+		b.l.Warningf("diskbuffer: segment %d was dropped before delivery", b.rSeq)
+		b.rSeq, b.rOff = b.segments[0], 0
+		return true
+	}
+	if !segmentDone || b.rSeq == b.activeSeq() {
+		return false
+	}
+	b.removeSegment(b.rSeq)
+	for _, seq := range b.segments {
+		if seq > b.rSeq {
+			b.rSeq, b.rOff = seq, 0
+			break
+		}
+	}
+	return true
+}
+
+func (b *Buffer) deliveryLoop(ctx context.Context, deliver DeliverFunc) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Check if read segment has been dropped.
+		b.nextReadSegment(false)
+
+		ems, consumed, err := b.readBatch()
+		if err != nil && !os.IsNotExist(err) {
+			b.l.Errorf("diskbuffer: error reading segment %d: %v", b.rSeq, err)
+		}
+
+		if consumed == 0 {
+			if b.nextReadSegment(true) {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.notify:
+			}
+			continue
+		}
+
+		for len(ems) > 0 {
+			err := deliver(ctx, ems)
+			if err == nil {
+				break
+			}
+			b.l.Warningf("diskbuffer: error delivering %d EventMetrics, will retry in %v: %v", len(ems), b.retryInterval, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(b.retryInterval):
+			}
+		}
+		b.rOff += consumed
+		b.writeCheckpoint()
+	}
+}
+
+func (b *Buffer) readCheckpoint() (int64, int64) {
+	data, err := os.ReadFile(filepath.Join(b.dir, checkpointFile))
+	if err != nil {
+		return 0, 0
+	}
+	var seq, off int64
+	if _, err := fmt.Sscanf(string(data), "%d %d", &seq, &off); err != nil {
+		b.l.Warningf("diskbuffer: ignoring bad checkpoint (%s): %v", string(data), err)
+		return 0, 0
+	}
+	return seq, off
+}
+
+func (b *Buffer) writeCheckpoint() {
+	tmpFile := filepath.Join(b.dir, checkpointFile+".tmp")
+	if err := os.WriteFile(tmpFile, []byte(fmt.Sprintf("%d %d", b.rSeq, b.rOff)), 0644); err != nil {
+		b.l.Warningf("diskbuffer: error writing checkpoint: %v", err)
+		return
+	}
+	if err := os.Rename(tmpFile, filepath.Join(b.dir, checkpointFile)); err != nil {
+		b.l.Warningf("diskbuffer: error writing checkpoint: %v", err)
+	}
+}
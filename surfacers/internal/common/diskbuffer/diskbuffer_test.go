@@ -0,0 +1,190 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbuffer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEM(i int) *metrics.EventMetrics {
+	m := metrics.NewMap("code")
+	m.IncKeyBy("200", int64(i))
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(5)
+	s := metrics.NewSummary([]float64{0.5, 0.9}, time.Minute)
+	for j := 1; j <= 10; j++ {
+		s.AddFloat64(float64(j))
+	}
+
+	em := metrics.NewEventMetrics(time.Unix(1700000000, int64(i))).
+		AddMetric("total", metrics.NewInt(int64(i))).
+		AddMetric("latency", metrics.NewFloat(1.5)).
+		AddMetric("resp_code", m).
+		AddMetric("latency_dist", d).
+		AddMetric("latency_summary", s).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", "p1").
+		AddLabel("dst", "t"+strconv.Itoa(i))
+	em.Kind = metrics.GAUGE
+	em.LatencyUnit = time.Millisecond
+	return em
+}
+
+func TestRecordEncodeDecode(t *testing.T) {
+	em := testEM(7)
+	line, err := encode(em)
+	require.NoError(t, err)
+
+	got, err := decode(line)
+	require.NoError(t, err)
+	assert.Equal(t, em.String(), got.String())
+	assert.Equal(t, em.Timestamp.UnixNano(), got.Timestamp.UnixNano())
+	assert.Equal(t, em.Kind, got.Kind)
+	assert.Equal(t, em.LatencyUnit, got.LatencyUnit)
+	assert.IsType(t, &metrics.Int{}, got.Metric("total"))
+	assert.IsType(t, &metrics.Map[int64]{}, got.Metric("resp_code"))
+	assert.Equal(t, em.Metric("latency_summary").(*metrics.Summary).Data(), got.Metric("latency_summary").(*metrics.Summary).Data())
+
+	_, err = encode(metrics.NewEventMetrics(time.Now()).AddLabel("probe", "p1"))
+	assert.Error(t, err, "EventMetrics without metrics")
+}
+
+type testDeliverer struct {
+	mu       sync.Mutex
+	ems      []*metrics.EventMetrics
+	failures int
+}
+
+func (td *testDeliverer) deliver(_ context.Context, ems []*metrics.EventMetrics) error {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	if td.failures > 0 {
+		td.failures--
+		return errors.New("backend down")
+	}
+	td.ems = append(td.ems, ems...)
+	return nil
+}
+
+func (td *testDeliverer) dsts() []string {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	var dsts []string
+	for _, em := range td.ems {
+		dsts = append(dsts, em.Label("dst"))
+	}
+	return dsts
+}
+
+func wantDsts(from, to int) []string {
+	var dsts []string
+	for i := from; i < to; i++ {
+		dsts = append(dsts, "t"+strconv.Itoa(i))
+	}
+	return dsts
+}
+
+func TestBufferDeliveryWithRetries(t *testing.T) {
+	b, err := newBuffer(t.TempDir(), 1<<20, 3, 10*time.Millisecond, nil)
+	require.NoError(t, err)
+
+	td := &testDeliverer{failures: 2}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.deliveryLoop(ctx, td.deliver)
+
+	for i := 0; i < 10; i++ {
+		b.Write(testEM(i))
+	}
+	assert.Eventually(t, func() bool { return len(td.dsts()) == 10 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, wantDsts(0, 10), td.dsts())
+}
+
+func TestBufferReplayAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	// Buffer with no delivery loop, e.g. backend is down.
+	b, err := newBuffer(dir, 1<<20, 100, time.Second, nil)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		b.Write(testEM(i))
+	}
+	b.w.Close()
+
+	// Restart: pending EventMetrics are delivered.
+	b, err = newBuffer(dir, 1<<20, 2, time.Second, nil)
+	require.NoError(t, err)
+	td := &testDeliverer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	go b.deliveryLoop(ctx, td.deliver)
+
+	assert.Eventually(t, func() bool { return len(td.dsts()) == 5 }, 5*time.Second, 10*time.Millisecond)
+	b.Write(testEM(5))
+	assert.Eventually(t, func() bool { return len(td.dsts()) == 6 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	b.w.Close()
+
+	// Another restart: checkpoint prevents re-delivery.
+	b, err = newBuffer(dir, 1<<20, 2, time.Second, nil)
+	require.NoError(t, err)
+	td2 := &testDeliverer{}
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go b.deliveryLoop(ctx, td2.deliver)
+	b.Write(testEM(6))
+
+	assert.Eventually(t, func() bool { return len(td2.dsts()) > 0 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, wantDsts(0, 6), td.dsts())
+	assert.Equal(t, wantDsts(6, 7), td2.dsts())
+}
+
+func TestBufferDropOldest(t *testing.T) {
+	line, err := encode(testEM(10))
+	require.NoError(t, err)
+
+	// Room for about 16 EventMetrics, 2 per segment.
+	maxSize := int64(len(line)) * 16
+	b, err := newBuffer(t.TempDir(), maxSize, 100, time.Second, nil)
+	require.NoError(t, err)
+
+	for i := 10; i < 50; i++ {
+		b.Write(testEM(i))
+	}
+	assert.LessOrEqual(t, b.totalSize, maxSize)
+
+	td := &testDeliverer{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go b.deliveryLoop(ctx, td.deliver)
+
+	assert.Eventually(t, func() bool {
+		dsts := td.dsts()
+		return len(dsts) > 0 && dsts[len(dsts)-1] == "t49"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Only the newest EventMetrics are delivered, in order.
+	dsts := td.dsts()
+	assert.LessOrEqual(t, len(dsts), 16)
+	assert.Equal(t, wantDsts(50-len(dsts), 50), dsts)
+}
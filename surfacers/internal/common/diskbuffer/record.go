@@ -0,0 +1,151 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskbuffer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Value types in the records.
+const (
+	typeInt      = "int"
+	typeFloat    = "float"
+	typeString   = "string"
+	typeMapInt   = "map_int"
+	typeMapFloat = "map_float"
+	typeDist     = "dist"
+	typeSummary  = "summary"
+)
+
+type recordMetric struct {
+	Name  string `json:"n"`
+	Type  string `json:"t"`
+	Value string `json:"v"`
+}
+
+// record is the on-disk representation of an EventMetrics. Records are
+// stored as JSON lines.
+type record struct {
+	Timestamp   int64          `json:"ts"`
+	Kind        metrics.Kind   `json:"kind"`
+	LatencyUnit time.Duration  `json:"lu,omitempty"`
+	Labels      [][2]string    `json:"labels,omitempty"`
+	Metrics     []recordMetric `json:"metrics"`
+}
+
+func encodeValue(v metrics.Value) (string, string, error) {
+	switch v := v.(type) {
+	case *metrics.Int, *metrics.AtomicInt:
+		return typeInt, strconv.FormatInt(v.(metrics.NumValue).Int64(), 10), nil
+	case *metrics.Float:
+		return typeFloat, strconv.FormatFloat(v.Float64(), 'g', -1, 64), nil
+	case metrics.String:
+		return typeString, strings.Trim(v.String(), "\""), nil
+	case *metrics.Map[int64]:
+		return typeMapInt, v.String(), nil
+	case *metrics.Map[float64]:
+		return typeMapFloat, v.String(), nil
+	case *metrics.Distribution:
+		return typeDist, v.String(), nil
+	case *metrics.Summary:
+		return typeSummary, v.String(), nil
+	}
+	return "", "", fmt.Errorf("unsupported value type: %T", v)
+}
+
+func decodeValue(typ, val string) (metrics.Value, error) {
+	switch typ {
+	case typeInt:
+		i, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return metrics.NewInt(i), nil
+	case typeFloat:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, err
+		}
+		return metrics.NewFloat(f), nil
+	case typeString:
+		return metrics.NewString(val), nil
+	case typeMapInt:
+		return metrics.ParseMapFromString[int64](val)
+	case typeMapFloat:
+		return metrics.ParseMapFromString[float64](val)
+	case typeDist:
+		return metrics.ParseDistFromString(val)
+	case typeSummary:
+		return metrics.ParseSummaryFromString(val)
+	}
+	return nil, fmt.Errorf("unknown value type: %s", typ)
+}
+
+// encode encodes the EventMetrics as a single line. Metrics with unsupported
+// value types are skipped.
+func encode(em *metrics.EventMetrics) ([]byte, error) {
+	r := &record{
+		Timestamp:   em.Timestamp.UnixNano(),
+		Kind:        em.Kind,
+		LatencyUnit: em.LatencyUnit,
+	}
+	for _, k := range em.LabelsKeys() {
+		r.Labels = append(r.Labels, [2]string{k, em.Label(k)})
+	}
+	for _, name := range em.MetricsKeys() {
+		typ, val, err := encodeValue(em.Metric(name))
+		if err != nil {
+			continue
+		}
+		r.Metrics = append(r.Metrics, recordMetric{Name: name, Type: typ, Value: val})
+	}
+	if len(r.Metrics) == 0 {
+		return nil, fmt.Errorf("no supported metrics in EventMetrics: %s", em.String())
+	}
+
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func decode(line []byte) (*metrics.EventMetrics, error) {
+	r := &record{}
+	if err := json.Unmarshal(line, r); err != nil {
+		return nil, err
+	}
+
+	em := metrics.NewEventMetrics(time.Unix(0, r.Timestamp))
+	em.Kind = r.Kind
+	em.LatencyUnit = r.LatencyUnit
+	for _, l := range r.Labels {
+		em.AddLabel(l[0], l[1])
+	}
+	for _, m := range r.Metrics {
+		v, err := decodeValue(m.Type, m.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding metric %s: %v", m.Name, err)
+		}
+		em.AddMetric(m.Name, v)
+	}
+	return em, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flush implements flush requests for the surfacers that batch
+// EventMetrics in their write loop.
+//
+// A surfacer creates a request channel using NewChan, and its write loop,
+// on receiving a request from that channel, writes out all the pending
+// EventMetrics and closes the request to signal completion.
+package flush
+
+import (
+	"context"
+)
+
+// NewChan returns a new channel for the flush requests.
+func NewChan() chan chan struct{} {
+	return make(chan chan struct{})
+}
+
+// Request sends a flush request to the write loop and waits for the write
+// loop to complete it, or for the context to be canceled.
+func Request(ctx context.Context, reqChan chan chan struct{}) error {
+	done := make(chan struct{})
+	select {
+	case reqChan <- done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
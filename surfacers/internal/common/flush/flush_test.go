@@ -0,0 +1,44 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flush
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequest(t *testing.T) {
+	reqChan := NewChan()
+
+	// No write loop: request times out.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, Request(ctx, reqChan), context.DeadlineExceeded)
+
+	flushed := 0
+	go func() {
+		for done := range reqChan {
+			flushed++
+			close(done)
+		}
+	}()
+	assert.NoError(t, Request(context.Background(), reqChan))
+	assert.NoError(t, Request(context.Background(), reqChan))
+	assert.Equal(t, 2, flushed)
+	close(reqChan)
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/cloudprober/cloudprober/config/runconfig"
 	"github.com/cloudprober/cloudprober/logger"
@@ -36,6 +37,8 @@ type labelFilter struct {
 
 var defaultLatencyMetricRe = regexp.MustCompile("^(.*_|)latency$")
 
+var validLatencyUnits = map[string]bool{"ns": true, "us": true, "ms": true, "s": true}
+
 func (lf *labelFilter) matchEventMetrics(em *metrics.EventMetrics) bool {
 	if lf.key != "" {
 		for _, lKey := range em.LabelsKeys() {
@@ -80,15 +83,23 @@ type Options struct {
 	// Metrics filtering
 	allowLabelFilters  []*labelFilter
 	ignoreLabelFilters []*labelFilter
+	labelSelector      []*labelRequirement
 	allowMetricName    *regexp.Regexp
 	ignoreMetricName   *regexp.Regexp
 
 	// latencyMetricRe is a regular expression to match latency metrics.
 	latencyMetricRe *regexp.Regexp
 
+	// If set, latency metrics are converted to this unit, and renamed with
+	// LatencyUnitSuffix, if it's not empty. See latency_unit in config.
+	LatencyUnit       time.Duration
+	LatencyUnitSuffix string
+
 	AddFailureMetric bool
 
 	AdditionalLabels [][2]string
+
+	sampler *sampler
 }
 
 // AllowEventMetrics returns whether a certain EventMetrics should be allowed
@@ -100,6 +111,10 @@ func (opts *Options) AllowEventMetrics(em *metrics.EventMetrics) bool {
 		return true
 	}
 
+	if !matchLabelSelector(opts.labelSelector, em) {
+		return false
+	}
+
 	// If we match any ignore filter, return false immediately.
 	for _, ignoreF := range opts.ignoreLabelFilters {
 		if ignoreF.matchEventMetrics(em) {
@@ -142,6 +157,10 @@ func (opts *Options) IsLatencyMetric(metricName string) bool {
 	if opts == nil {
 		return defaultLatencyMetricRe.MatchString(metricName)
 	}
+	// Latency metrics may have been renamed with the unit suffix.
+	if opts.LatencyUnitSuffix != "" {
+		metricName = strings.TrimSuffix(metricName, opts.LatencyUnitSuffix)
+	}
 	return opts.latencyMetricRe.MatchString(metricName)
 }
 
@@ -192,6 +211,12 @@ func buildOptions(sdef *surfacerpb.SurfacerDef, ignoreInit bool, l *logger.Logge
 		return nil, err
 	}
 
+	if sdef.GetLabelSelector() != "" {
+		if opts.labelSelector, err = parseLabelSelector(sdef.GetLabelSelector()); err != nil {
+			return nil, err
+		}
+	}
+
 	if sdef.GetAllowMetricsWithName() != "" {
 		opts.allowMetricName, err = regexp.Compile(sdef.GetAllowMetricsWithName())
 		if err != nil {
@@ -221,8 +246,22 @@ func buildOptions(sdef *surfacerpb.SurfacerDef, ignoreInit bool, l *logger.Logge
 	}
 	opts.latencyMetricRe = re
 
+	if lu := opts.Config.GetLatencyUnit(); lu != "" {
+		if !validLatencyUnits[lu] {
+			return nil, fmt.Errorf("invalid latency_unit: %s, should be one of: ns, us, ms, s", lu)
+		}
+		opts.LatencyUnit, _ = time.ParseDuration("1" + lu)
+		if opts.Config.GetLatencyUnitSuffix() {
+			opts.LatencyUnitSuffix = metrics.LatencyUnitSuffix(opts.LatencyUnit)
+		}
+	}
+
 	opts.AdditionalLabels = processAdditionalLabels(opts.Config.GetAdditionalLabelsEnvVar(), l)
 
+	if opts.sampler, err = newSampler(opts.Config.GetSampling()); err != nil {
+		return nil, err
+	}
+
 	return opts, nil
 }
 
@@ -254,6 +254,32 @@ func TestBuildOptions(t *testing.T) {
 			},
 			want: &Options{AddFailureMetric: true, latencyMetricRe: regexp.MustCompile("latency_.*")},
 		},
+		{
+			name: "latency_unit",
+			sdef: &surfacerpb.SurfacerDef{
+				Type:        configpb.Type_PROMETHEUS.Enum(),
+				LatencyUnit: proto.String("s"),
+			},
+			want: &Options{AddFailureMetric: true, LatencyUnit: time.Second, LatencyUnitSuffix: "_seconds"},
+		},
+		{
+			name: "latency_unit_no_suffix",
+			sdef: &surfacerpb.SurfacerDef{
+				Type:              configpb.Type_PROMETHEUS.Enum(),
+				LatencyUnit:       proto.String("ms"),
+				LatencyUnitSuffix: proto.Bool(false),
+			},
+			want: &Options{AddFailureMetric: true, LatencyUnit: time.Millisecond},
+		},
+		{
+			name: "invalid_latency_unit",
+			sdef: &surfacerpb.SurfacerDef{
+				Type:        configpb.Type_PROMETHEUS.Enum(),
+				LatencyUnit: proto.String("m"),
+			},
+			want:    &Options{},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -275,6 +301,9 @@ func TestBuildOptions(t *testing.T) {
 				t.Errorf("buildOptions() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -298,6 +327,12 @@ func TestOptions_IsLatencyMetric(t *testing.T) {
 			metricName: []string{"latency", "dns_latency", "latency_read"},
 			want:       []bool{false, false, true},
 		},
+		{
+			name:       "unit_suffix",
+			opts:       &Options{latencyMetricRe: defaultLatencyMetricRe, LatencyUnitSuffix: "_seconds"},
+			metricName: []string{"latency", "dns_latency_seconds", "latency_read_seconds", "latency_ms"},
+			want:       []bool{true, true, false, false},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,76 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+)
+
+type sampler struct {
+	rate       float64
+	targetHash bool
+
+	// randFloat is overridden in tests.
+	randFloat func() float64
+}
+
+func newSampler(c *surfacerpb.SurfacerDef_Sampling) (*sampler, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	rate := float64(c.GetRate())
+	if rate <= 0 || rate > 1 {
+		return nil, fmt.Errorf("invalid sampling rate: %v, should be in the range (0, 1]", rate)
+	}
+	if rate == 1 {
+		return nil, nil
+	}
+
+	return &sampler{
+		rate:       rate,
+		targetHash: c.GetMode() == surfacerpb.SurfacerDef_Sampling_TARGET_HASH,
+		randFloat:  rand.Float64,
+	}, nil
+}
+
+func (s *sampler) sample(em *metrics.EventMetrics) bool {
+	if s.targetHash {
+		h := fnv.New32a()
+		h.Write([]byte(em.Label("probe") + "/" + em.Label("dst")))
+		return float64(h.Sum32()) < s.rate*math.MaxUint32
+	}
+
+	// Random sampling doesn't apply to cumulative metrics.
+	if em.Kind == metrics.CUMULATIVE {
+		return true
+	}
+	return s.randFloat() < s.rate
+}
+
+// SampleEventMetrics returns whether the EventMetrics should be forwarded to
+// the surfacer as per the surfacer's sampling config.
+func (opts *Options) SampleEventMetrics(em *metrics.EventMetrics) bool {
+	if opts == nil || opts.sampler == nil {
+		return true
+	}
+	return opts.sampler.sample(em)
+}
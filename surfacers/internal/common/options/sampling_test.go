@@ -0,0 +1,100 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM(kind metrics.Kind, dst string) *metrics.EventMetrics {
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(1)).
+		AddLabel("probe", "p1").
+		AddLabel("dst", dst)
+	em.Kind = kind
+	return em
+}
+
+func TestNewSampler(t *testing.T) {
+	for _, tt := range []struct {
+		rate    float32
+		wantNil bool
+		wantErr bool
+	}{
+		{rate: 0, wantErr: true},
+		{rate: 1.5, wantErr: true},
+		{rate: 1, wantNil: true},
+		{rate: 0.1},
+	} {
+		t.Run(fmt.Sprintf("%v", tt.rate), func(t *testing.T) {
+			s, err := newSampler(&surfacerpb.SurfacerDef_Sampling{Rate: proto.Float32(tt.rate)})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNil, s == nil)
+		})
+	}
+}
+
+func TestSampleEventMetrics(t *testing.T) {
+	t.Run("no-sampling", func(t *testing.T) {
+		opts := &Options{}
+		assert.True(t, opts.SampleEventMetrics(testEM(metrics.GAUGE, "t1")))
+	})
+
+	t.Run("random", func(t *testing.T) {
+		s, err := newSampler(&surfacerpb.SurfacerDef_Sampling{Rate: proto.Float32(0.25)})
+		assert.NoError(t, err)
+		var r float64
+		s.randFloat = func() float64 { return r }
+		opts := &Options{sampler: s}
+
+		r = 0.2
+		assert.True(t, opts.SampleEventMetrics(testEM(metrics.GAUGE, "t1")))
+		r = 0.3
+		assert.False(t, opts.SampleEventMetrics(testEM(metrics.GAUGE, "t1")))
+		assert.True(t, opts.SampleEventMetrics(testEM(metrics.CUMULATIVE, "t1")), "cumulative metrics should not be sampled")
+	})
+
+	t.Run("target-hash", func(t *testing.T) {
+		s, err := newSampler(&surfacerpb.SurfacerDef_Sampling{
+			Rate: proto.Float32(0.5),
+			Mode: surfacerpb.SurfacerDef_Sampling_TARGET_HASH.Enum(),
+		})
+		assert.NoError(t, err)
+		opts := &Options{sampler: s}
+
+		numSampled := 0
+		for i := 0; i < 1000; i++ {
+			dst := fmt.Sprintf("target-%d", i)
+			got := opts.SampleEventMetrics(testEM(metrics.CUMULATIVE, dst))
+			// Sampling decision is deterministic for a target.
+			assert.Equal(t, got, opts.SampleEventMetrics(testEM(metrics.GAUGE, dst)), dst)
+			if got {
+				numSampled++
+			}
+		}
+		assert.InDelta(t, 500, numSampled, 100)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+type selectorOp int
+
+const (
+	opEquals selectorOp = iota
+	opNotEquals
+	opExists
+	opNotExists
+)
+
+// labelRequirement is a single requirement of a label selector, e.g.
+// "env=prod".
+type labelRequirement struct {
+	key   string
+	op    selectorOp
+	value string
+}
+
+func (lr *labelRequirement) match(em *metrics.EventMetrics) bool {
+	val, ok := "", false
+	for _, k := range em.LabelsKeys() {
+		if k == lr.key {
+			val, ok = em.Label(k), true
+			break
+		}
+	}
+
+	switch lr.op {
+	case opEquals:
+		return ok && val == lr.value
+	case opNotEquals:
+		return !ok || val != lr.value
+	case opExists:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// parseLabelSelector parses a label selector: a comma-separated list of
+// requirements, where each requirement is one of "key=value", "key==value",
+// "key!=value", "key" and "!key".
+func parseLabelSelector(s string) ([]*labelRequirement, error) {
+	var reqs []*labelRequirement
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid label selector (%s): empty requirement", s)
+		}
+
+		lr := &labelRequirement{}
+		switch {
+		case strings.Contains(part, "!="):
+			lr.op = opNotEquals
+			lr.key, lr.value, _ = strings.Cut(part, "!=")
+		case strings.Contains(part, "=="):
+			lr.key, lr.value, _ = strings.Cut(part, "==")
+		case strings.Contains(part, "="):
+			lr.key, lr.value, _ = strings.Cut(part, "=")
+		case strings.HasPrefix(part, "!"):
+			lr.op, lr.key = opNotExists, part[1:]
+		default:
+			lr.op, lr.key = opExists, part
+		}
+
+		lr.key, lr.value = strings.TrimSpace(lr.key), strings.TrimSpace(lr.value)
+		if lr.key == "" || strings.ContainsAny(lr.key, "!=") || strings.ContainsAny(lr.value, "!=") {
+			return nil, fmt.Errorf("invalid label selector (%s): bad requirement: %s", s, part)
+		}
+		reqs = append(reqs, lr)
+	}
+
+	return reqs, nil
+}
+
+// matchLabelSelector returns true if EventMetrics satisfies all the
+// requirements.
+func matchLabelSelector(reqs []*labelRequirement, em *metrics.EventMetrics) bool {
+	for _, lr := range reqs {
+		if !lr.match(em) {
+			return false
+		}
+	}
+	return true
+}
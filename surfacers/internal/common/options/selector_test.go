@@ -0,0 +1,87 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []*labelRequirement
+		wantErr  bool
+	}{
+		{
+			selector: "env=prod, tier==web,team != infra,canary,!debug",
+			want: []*labelRequirement{
+				{key: "env", op: opEquals, value: "prod"},
+				{key: "tier", op: opEquals, value: "web"},
+				{key: "team", op: opNotEquals, value: "infra"},
+				{key: "canary", op: opExists},
+				{key: "debug", op: opNotExists},
+			},
+		},
+		{selector: "env=", want: []*labelRequirement{{key: "env", op: opEquals}}},
+		{selector: "", wantErr: true},
+		{selector: "env=prod,", wantErr: true},
+		{selector: "=prod", wantErr: true},
+		{selector: "env=a=b", wantErr: true},
+		{selector: "!!env", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			got, err := parseLabelSelector(test.selector)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestMatchLabelSelector(t *testing.T) {
+	prodEM := metrics.NewEventMetrics(time.Now()).AddLabel("probe", "p1").AddLabel("env", "prod")
+	devEM := metrics.NewEventMetrics(time.Now()).AddLabel("probe", "p1").AddLabel("env", "dev")
+	noEnvEM := metrics.NewEventMetrics(time.Now()).AddLabel("probe", "sysvars")
+
+	tests := []struct {
+		selector string
+		want     [3]bool // prod, dev, no-env.
+	}{
+		{selector: "env=prod", want: [3]bool{true, false, false}},
+		{selector: "env!=prod", want: [3]bool{false, true, true}},
+		{selector: "env", want: [3]bool{true, true, false}},
+		{selector: "!env", want: [3]bool{false, false, true}},
+		{selector: "probe=p1,env!=dev", want: [3]bool{true, false, false}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.selector, func(t *testing.T) {
+			reqs, err := parseLabelSelector(test.selector)
+			assert.NoError(t, err)
+			for i, em := range []*metrics.EventMetrics{prodEM, devEM, noEnvEM} {
+				assert.Equal(t, test.want[i], matchLabelSelector(reqs, em), "em: %s", em.String())
+			}
+		})
+	}
+}
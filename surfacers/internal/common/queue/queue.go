@@ -0,0 +1,87 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package queue implements the stats for the surfacers' queues, i.e. the
+// buffered channels that surfacers use to hold the incoming EventMetrics
+// until their write loop processes them.
+//
+// A surfacer wraps its channel using New, and enqueues EventMetrics through
+// Enqueue instead of writing to the channel directly. Write loop keeps
+// reading from the channel as before.
+package queue
+
+import (
+	"sync"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+// Stats is a snapshot of a queue's stats.
+type Stats struct {
+	// Number of EventMetrics currently in the queue, and queue's capacity.
+	Depth    int
+	Capacity int
+
+	// Cumulative counts.
+	Enqueued int64
+	Dequeued int64
+	Dropped  int64
+}
+
+// Queue tracks the stats of a surfacer's queue.
+type Queue struct {
+	ch chan *metrics.EventMetrics
+
+	// mu serializes enqueues with the stats snapshot, so that the dequeued
+	// count can be derived from the enqueued count and the queue depth.
+	mu       sync.Mutex
+	enqueued int64
+	dropped  int64
+}
+
+// New returns a new queue for the given buffered channel.
+func New(ch chan *metrics.EventMetrics) *Queue {
+	return &Queue{ch: ch}
+}
+
+// Enqueue adds the EventMetrics to the queue, without blocking. It returns
+// false if the queue is full and EventMetrics was dropped.
+func (q *Queue) Enqueue(em *metrics.EventMetrics) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case q.ch <- em:
+		q.enqueued++
+		return true
+	default:
+		q.dropped++
+		return false
+	}
+}
+
+// Stats returns the queue's current stats.
+func (q *Queue) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	depth := len(q.ch)
+	return Stats{
+		Depth:    depth,
+		Capacity: cap(q.ch),
+		Enqueued: q.enqueued,
+		Dequeued: q.enqueued - int64(depth),
+		Dropped:  q.dropped,
+	}
+}
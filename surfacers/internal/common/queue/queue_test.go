@@ -0,0 +1,39 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueue(t *testing.T) {
+	ch := make(chan *metrics.EventMetrics, 2)
+	q := New(ch)
+	assert.Equal(t, Stats{Capacity: 2}, q.Stats())
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i < 2, q.Enqueue(&metrics.EventMetrics{}), "enqueue: %d", i)
+	}
+	assert.Equal(t, Stats{Depth: 2, Capacity: 2, Enqueued: 2, Dropped: 1}, q.Stats())
+
+	<-ch
+	assert.True(t, q.Enqueue(&metrics.EventMetrics{}))
+	<-ch
+	<-ch
+	assert.Equal(t, Stats{Depth: 0, Capacity: 2, Enqueued: 3, Dequeued: 3, Dropped: 1}, q.Stats())
+}
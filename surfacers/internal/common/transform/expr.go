@@ -0,0 +1,181 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expr is a parsed arithmetic expression. Expressions support numbers,
+// variables, the binary operators +, -, *, /, unary minus, and parentheses,
+// with the usual precedence rules.
+type expr interface {
+	eval(vars map[string]float64) float64
+}
+
+type numExpr float64
+
+func (e numExpr) eval(map[string]float64) float64 { return float64(e) }
+
+type varExpr string
+
+func (e varExpr) eval(vars map[string]float64) float64 { return vars[string(e)] }
+
+type negExpr struct{ x expr }
+
+func (e negExpr) eval(vars map[string]float64) float64 { return -e.x.eval(vars) }
+
+type binaryExpr struct {
+	op   byte
+	l, r expr
+}
+
+func (e binaryExpr) eval(vars map[string]float64) float64 {
+	l, r := e.l.eval(vars), e.r.eval(vars)
+	switch e.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	}
+	return l / r
+}
+
+type exprParser struct {
+	s           string
+	pos         int
+	allowedVars []string
+	usedVars    map[string]bool
+}
+
+// parseExpr parses an arithmetic expression. Only the allowed variables can
+// be used in the expression. It returns the parsed expression, along with the
+// variables used in it.
+func parseExpr(s string, allowedVars []string) (expr, map[string]bool, error) {
+	p := &exprParser{s: s, allowedVars: allowedVars, usedVars: make(map[string]bool)}
+
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid expression (%s): %v", s, err)
+	}
+	if p.skipSpaces(); p.pos != len(p.s) {
+		return nil, nil, fmt.Errorf("invalid expression (%s): unexpected character '%c' at position %d", s, p.s[p.pos], p.pos)
+	}
+	return e, p.usedVars, nil
+}
+
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of input.
+func (p *exprParser) peek() byte {
+	p.skipSpaces()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// parseSum parses: product (('+' | '-') product)*
+func (p *exprParser) parseSum() (expr, error) {
+	l, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '+' || op == '-'; op = p.peek() {
+		p.pos++
+		r, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryExpr{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+// parseProduct parses: unary (('*' | '/') unary)*
+func (p *exprParser) parseProduct() (expr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peek(); op == '*' || op == '/'; op = p.peek() {
+		p.pos++
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = binaryExpr{op: op, l: l, r: r}
+	}
+	return l, nil
+}
+
+// parseUnary parses: '-' unary | '(' sum ')' | number | variable
+func (p *exprParser) parseUnary() (expr, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case c == '-':
+		p.pos++
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negExpr{x}, nil
+	case c == '(':
+		p.pos++
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		p.pos++
+		return e, nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] == '.' || (p.s[p.pos] >= '0' && p.s[p.pos] <= '9')) {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %s", p.s[start:p.pos])
+		}
+		return numExpr(f), nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.s) && (p.s[p.pos] == '_' || unicode.IsLetter(rune(p.s[p.pos])) || unicode.IsDigit(rune(p.s[p.pos]))) {
+			p.pos++
+		}
+		name := p.s[start:p.pos]
+		if !slices.Contains(p.allowedVars, name) {
+			return nil, fmt.Errorf("unknown variable: %s, supported variables: %s", name, strings.Join(p.allowedVars, ", "))
+		}
+		p.usedVars[name] = true
+		return varExpr(name), nil
+	}
+	return nil, fmt.Errorf("unexpected character '%c' at position %d", c, p.pos)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpr(t *testing.T) {
+	vars := map[string]float64{"value": 10, "delta": 30, "interval": 15}
+
+	tests := []struct {
+		s        string
+		want     float64
+		wantVars []string
+		wantErr  bool
+	}{
+		{s: "value * 8", want: 80, wantVars: []string{"value"}},
+		{s: "delta / interval", want: 2, wantVars: []string{"delta", "interval"}},
+		{s: "value + 2 * 3", want: 16, wantVars: []string{"value"}},
+		{s: "(value + 2) * 3", want: 36, wantVars: []string{"value"}},
+		{s: "value - 2 - 3", want: 5, wantVars: []string{"value"}},
+		{s: "value / 2 / 5", want: 1, wantVars: []string{"value"}},
+		{s: "-value + .5", want: -9.5, wantVars: []string{"value"}},
+		{s: "value * -(2)", want: -20, wantVars: []string{"value"}},
+		{s: " 1.5e ", wantErr: true},
+		{s: "1.2.3", wantErr: true},
+		{s: "", wantErr: true},
+		{s: "value *", wantErr: true},
+		{s: "(value * 8", wantErr: true},
+		{s: "value 8", wantErr: true},
+		{s: "value % 8", wantErr: true},
+		{s: "bytes * 8", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			e, usedVars, err := parseExpr(test.s, exprVars)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, e.eval(vars))
+			assert.Len(t, usedVars, len(test.wantVars))
+			for _, v := range test.wantVars {
+				assert.True(t, usedVars[v], "variable %s not used", v)
+			}
+		})
+	}
+}
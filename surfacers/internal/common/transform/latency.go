@@ -0,0 +1,88 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+)
+
+func convertLatency(v metrics.Value, from, to time.Duration) (metrics.Value, bool) {
+	switch v := v.(type) {
+	case *metrics.Distribution:
+		return v.ConvertUnit(from, to), true
+	case *metrics.Summary:
+		return v.ConvertUnit(from, to), true
+	case metrics.NumValue:
+		return metrics.NewFloat(metrics.ConvertUnit(v.Float64(), from, to)), true
+	}
+	return nil, false
+}
+
+// NormalizeLatency converts the latency metrics, as identified by isLatency,
+// from the EventMetrics' latency unit to the given unit. If suffix is not
+// empty, latency metrics are also renamed with it, e.g. latency becomes
+// latency_seconds. It returns the original EventMetrics if there is nothing
+// to convert.
+func NormalizeLatency(em *metrics.EventMetrics, unit time.Duration, suffix string, isLatency func(string) bool) *metrics.EventMetrics {
+	// EventMetrics without a latency unit are in microseconds, the default
+	// latency unit.
+	emUnit := em.LatencyUnit
+	if emUnit == 0 {
+		emUnit = time.Microsecond
+	}
+
+	var newEM *metrics.EventMetrics
+	keys := em.MetricsKeys()
+	for i, name := range keys {
+		if !isLatency(name) {
+			continue
+		}
+		newName := name
+		if suffix != "" && !strings.HasSuffix(name, suffix) {
+			newName = name + suffix
+		}
+		if emUnit == unit && newName == name {
+			continue
+		}
+
+		v, ok := convertLatency(em.Metric(name), emUnit, unit)
+		if !ok {
+			continue
+		}
+
+		if newEM == nil {
+			newEM = em.Clone()
+		}
+		// Re-add the following metrics as well, to preserve the order.
+		for _, k := range keys[i:] {
+			nv := newEM.Metric(k)
+			newEM.RemoveMetric(k)
+			if k == name {
+				newEM.AddMetric(newName, v)
+			} else {
+				newEM.AddMetric(k, nv)
+			}
+		}
+	}
+
+	if newEM == nil {
+		return em
+	}
+	newEM.LatencyUnit = unit
+	return newEM
+}
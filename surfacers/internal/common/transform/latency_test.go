@@ -0,0 +1,96 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLatency(t *testing.T) {
+	isLatency := regexp.MustCompile("^(.+_|)latency(_seconds|)$").MatchString
+
+	testEM := func(unit time.Duration) *metrics.EventMetrics {
+		d := metrics.NewDistribution([]float64{100, 1000})
+		d.AddSample(50)
+		d.AddSample(500)
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(2)).
+			AddMetric("latency", d).
+			AddMetric("dns_latency", metrics.NewFloat(1500)).
+			AddMetric("success", metrics.NewInt(2)).
+			AddLabel("probe", "p1")
+		em.LatencyUnit = unit
+		return em
+	}
+
+	tests := []struct {
+		name   string
+		emUnit time.Duration
+		unit   time.Duration
+		suffix string
+		want   string
+	}{
+		{
+			name:   "us_to_s",
+			emUnit: time.Microsecond,
+			unit:   time.Second,
+			suffix: "_seconds",
+			want:   "total=2 latency_seconds=dist:sum:0.00055|count:2|lb:-Inf,0.0001,0.001|bc:1,1,0 dns_latency_seconds=0.002 success=2",
+		},
+		{
+			name: "default_unit_to_ms_no_suffix",
+			unit: time.Millisecond,
+			want: "total=2 latency=dist:sum:0.55|count:2|lb:-Inf,0.1,1|bc:1,1,0 dns_latency=1.500 success=2",
+		},
+		{
+			name:   "same_unit_suffix_only",
+			emUnit: time.Millisecond,
+			unit:   time.Millisecond,
+			suffix: "_milliseconds",
+			want:   "total=2 latency_milliseconds=dist:sum:550|count:2|lb:-Inf,100,1000|bc:1,1,0 dns_latency_milliseconds=1500.000 success=2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			em := testEM(test.emUnit)
+			got := NormalizeLatency(em, test.unit, test.suffix, isLatency)
+			var metricsStr []string
+			for _, k := range got.MetricsKeys() {
+				metricsStr = append(metricsStr, k+"="+got.Metric(k).String())
+			}
+			assert.Equal(t, test.want, strings.Join(metricsStr, " "))
+			assert.Equal(t, test.unit, got.LatencyUnit)
+
+			// Original EventMetrics is not modified.
+			assert.Equal(t, "1500.000", em.Metric("dns_latency").String())
+		})
+	}
+
+	// Nothing to convert.
+	em := testEM(time.Millisecond)
+	assert.Same(t, em, NormalizeLatency(em, time.Millisecond, "", isLatency))
+
+	// Already suffixed metrics are not renamed again.
+	em = metrics.NewEventMetrics(time.Now()).AddMetric("latency_seconds", metrics.NewFloat(2))
+	em.LatencyUnit = time.Second
+	assert.Same(t, em, NormalizeLatency(em, time.Second, "_seconds", isLatency))
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+)
+
+// Variables that can be used in the value transformation expressions.
+var exprVars = []string{"value", "delta", "interval"}
+
+// lastValueTTL is how long the last value of a metric is kept around after
+// it was last seen. It keeps lastValues from growing indefinitely as targets
+// and probes come and go.
+const lastValueTTL = time.Hour
+
+type valueTransform struct {
+	nameRe  *regexp.Regexp
+	e       expr
+	newName string
+
+	// needLast is true if expression uses the previous value of the metric.
+	needLast bool
+}
+
+type lastValue struct {
+	val float64
+	ts  time.Time
+}
+
+// ValueTransformer transforms metric values using the configured
+// expressions.
+type ValueTransformer struct {
+	transforms []*valueTransform
+
+	// Last values of the metrics, keyed by EventMetrics key and metric name.
+	lastValues map[string]lastValue
+	lastGC     time.Time
+}
+
+// NewValueTransformer returns a new ValueTransformer for the given config. It
+// returns nil if there are no transformations configured.
+func NewValueTransformer(configs []*surfacerpb.SurfacerDef_MetricTransform) (*ValueTransformer, error) {
+	if len(configs) == 0 {
+		return nil, nil
+	}
+
+	vt := &ValueTransformer{
+		lastValues: make(map[string]lastValue),
+	}
+	for _, c := range configs {
+		re, err := regexp.Compile(c.GetMetricName())
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric_name regex (%s): %v", c.GetMetricName(), err)
+		}
+		e, usedVars, err := parseExpr(c.GetExpression(), exprVars)
+		if err != nil {
+			return nil, err
+		}
+		vt.transforms = append(vt.transforms, &valueTransform{
+			nameRe:   re,
+			e:        e,
+			newName:  c.GetNewName(),
+			needLast: usedVars["delta"] || usedVars["interval"],
+		})
+	}
+	return vt, nil
+}
+
+// gc removes the last values that haven't been updated for lastValueTTL. It
+// runs at most once per lastValueTTL.
+func (vt *ValueTransformer) gc(now time.Time) {
+	if now.Sub(vt.lastGC) < lastValueTTL {
+		return
+	}
+	vt.lastGC = now
+	for key, last := range vt.lastValues {
+		if now.Sub(last.ts) >= lastValueTTL {
+			delete(vt.lastValues, key)
+		}
+	}
+}
+
+func (vt *ValueTransformer) match(metricName string) *valueTransform {
+	for _, t := range vt.transforms {
+		if t.nameRe.MatchString(metricName) {
+			return t
+		}
+	}
+	return nil
+}
+
+// Transform returns a copy of the EventMetrics with transformations applied
+// to the matching numeric metrics. The original EventMetrics is not modified.
+// If no metric matches, the original EventMetrics is returned.
+func (vt *ValueTransformer) Transform(em *metrics.EventMetrics) *metrics.EventMetrics {
+	vt.gc(em.Timestamp)
+
+	var newEM *metrics.EventMetrics
+	var emKey string
+
+	for _, name := range em.MetricsKeys() {
+		t := vt.match(name)
+		if t == nil {
+			continue
+		}
+		nv, ok := em.Metric(name).(metrics.NumValue)
+		if !ok {
+			continue
+		}
+
+		if newEM == nil {
+			newEM, emKey = em.Clone(), em.Key()
+		}
+
+		vars := map[string]float64{"value": nv.Float64()}
+		skip := false
+		if t.needLast {
+			key := emKey + "," + name
+			last, ok := vt.lastValues[key]
+			vt.lastValues[key] = lastValue{val: vars["value"], ts: em.Timestamp}
+			if ok {
+				vars["delta"] = vars["value"] - last.val
+				vars["interval"] = em.Timestamp.Sub(last.ts).Seconds()
+			} else {
+				skip = true
+			}
+		}
+
+		var result float64
+		if !skip {
+			result = t.e.eval(vars)
+			skip = math.IsNaN(result) || math.IsInf(result, 0)
+		}
+
+		switch {
+		case skip && t.newName == "":
+			newEM.RemoveMetric(name)
+		case skip:
+		case t.newName != "":
+			newEM.SetMetric(t.newName, metrics.NewFloat(result))
+		default:
+			newEM.SetMetric(name, metrics.NewFloat(result))
+		}
+	}
+
+	if newEM == nil {
+		return em
+	}
+	return newEM
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValueTransformer(t *testing.T) {
+	vt, err := NewValueTransformer(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, vt)
+
+	_, err = NewValueTransformer([]*surfacerpb.SurfacerDef_MetricTransform{
+		{MetricName: proto.String("("), Expression: proto.String("value")},
+	})
+	assert.Error(t, err, "invalid regex")
+
+	vt, err = NewValueTransformer([]*surfacerpb.SurfacerDef_MetricTransform{
+		{
+			MetricName: proto.String("^total$"),
+			Expression: proto.String("delta / interval"),
+			NewName:    proto.String("total_per_sec"),
+		},
+		{
+			MetricName: proto.String("^bytes$"),
+			Expression: proto.String("value * 8"),
+		},
+		{
+			MetricName: proto.String("^success$"),
+			Expression: proto.String("delta"),
+		},
+		{
+			MetricName: proto.String("^version$"),
+			Expression: proto.String("value * 2"),
+		},
+	})
+	require.NoError(t, err)
+
+	ts := time.Unix(1700000000, 0)
+	newEM := func(ts time.Time, total, success, bytes int64) *metrics.EventMetrics {
+		return metrics.NewEventMetrics(ts).
+			AddMetric("total", metrics.NewInt(total)).
+			AddMetric("success", metrics.NewInt(success)).
+			AddMetric("bytes", metrics.NewInt(bytes)).
+			AddMetric("version", metrics.NewString("v1")).
+			AddLabel("dst", "t1")
+	}
+
+	// First EventMetrics: delta and interval are not available yet.
+	em := newEM(ts, 100, 90, 10)
+	got := vt.Transform(em)
+	assert.Equal(t, []string{"total", "bytes", "version"}, got.MetricsKeys())
+	assert.Equal(t, "80.000", got.Metric("bytes").String())
+
+	// Original EventMetrics is not modified.
+	assert.Equal(t, []string{"total", "success", "bytes", "version"}, em.MetricsKeys())
+	assert.Equal(t, "10", em.Metric("bytes").String())
+
+	got = vt.Transform(newEM(ts.Add(10*time.Second), 150, 130, 20))
+	assert.Equal(t, []string{"total", "success", "bytes", "version", "total_per_sec"}, got.MetricsKeys())
+	assert.Equal(t, "150", got.Metric("total").String())
+	assert.Equal(t, "5.000", got.Metric("total_per_sec").String())
+	assert.Equal(t, "40.000", got.Metric("success").String())
+	assert.Equal(t, "160.000", got.Metric("bytes").String())
+	assert.Equal(t, "\"v1\"", got.Metric("version").String())
+
+	// Zero interval: rate is skipped.
+	got = vt.Transform(newEM(ts.Add(10*time.Second), 160, 140, 20))
+	assert.Nil(t, got.Metric("total_per_sec"))
+
+	// No matching metrics: same EventMetrics is returned.
+	em = metrics.NewEventMetrics(ts).AddMetric("latency", metrics.NewFloat(1.5))
+	assert.Same(t, em, vt.Transform(em))
+}
+
+func TestValueTransformerGC(t *testing.T) {
+	vt, err := NewValueTransformer([]*surfacerpb.SurfacerDef_MetricTransform{
+		{MetricName: proto.String("^total$"), Expression: proto.String("delta")},
+	})
+	require.NoError(t, err)
+
+	ts := time.Unix(1700000000, 0)
+	newEM := func(ts time.Time, dst string) *metrics.EventMetrics {
+		return metrics.NewEventMetrics(ts).AddMetric("total", metrics.NewInt(10)).AddLabel("dst", dst)
+	}
+
+	vt.Transform(newEM(ts, "t1"))
+	vt.Transform(newEM(ts.Add(30*time.Minute), "t2"))
+	assert.Len(t, vt.lastValues, 2)
+
+	// t1 hasn't been seen for lastValueTTL, its last value is removed.
+	vt.Transform(newEM(ts.Add(lastValueTTL), "t2"))
+	assert.Len(t, vt.lastValues, 1)
+
+	// t1 is treated as a new metric now, i.e. no delta.
+	assert.Nil(t, vt.Transform(newEM(ts.Add(lastValueTTL+time.Minute), "t1")).Metric("total"))
+}
@@ -21,11 +21,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/datadog/proto"
 	"google.golang.org/protobuf/proto"
 )
@@ -48,6 +51,8 @@ type DDSurfacer struct {
 	c         *configpb.SurfacerConf
 	opts      *options.Options
 	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+	flushChan chan chan struct{}
 	client    *ddClient
 	l         *logger.Logger
 	prefix    string
@@ -74,11 +79,13 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	dd := &DDSurfacer{
 		c:             config,
 		writeChan:     make(chan *metrics.EventMetrics, config.GetMetricsBatchSize()),
+		flushChan:     flush.NewChan(),
 		client:        newClient(config.GetServer(), config.GetApiKey(), config.GetAppKey(), config.GetDisableCompression()),
 		l:             l,
 		prefix:        p,
 		ddSeriesCache: make([]ddSeries, 0, config.GetMetricsBatchSize()),
 	}
+	dd.queue = queue.New(dd.writeChan)
 
 	go dd.receiveMetricsFromEvent(ctx)
 
@@ -89,13 +96,16 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 // Write is a function defined to comply with the surfacer interface, and enables the
 // datadog surfacer to receive EventMetrics over the buffered channel.
 func (dd *DDSurfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case dd.writeChan <- em:
-	default:
+	if !dd.queue.Enqueue(em) {
 		dd.l.Error("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (dd *DDSurfacer) Queue() *queue.Queue {
+	return dd.queue
+}
+
 func (dd *DDSurfacer) receiveMetricsFromEvent(ctx context.Context) {
 	publishTimer := time.NewTicker(time.Duration(dd.c.GetBatchTimerSec()) * time.Second)
 	defer publishTimer.Stop()
@@ -111,10 +121,23 @@ func (dd *DDSurfacer) receiveMetricsFromEvent(ctx context.Context) {
 			if len(dd.ddSeriesCache) != 0 {
 				dd.publishMetrics(ctx)
 			}
+		case done := <-dd.flushChan:
+			for len(dd.writeChan) > 0 {
+				dd.recordEventMetrics(ctx, publishTimer, <-dd.writeChan)
+			}
+			if len(dd.ddSeriesCache) != 0 {
+				dd.publishMetrics(ctx)
+			}
+			close(done)
 		}
 	}
 }
 
+// Flush publishes the pending metrics to datadog.
+func (dd *DDSurfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, dd.flushChan)
+}
+
 func recordMapValue[T int64 | float64](dd *DDSurfacer, m *metrics.Map[T], baseTags []string, key string, em *metrics.EventMetrics) []ddSeries {
 	var series []ddSeries
 	for _, k := range m.Keys() {
@@ -210,5 +233,17 @@ func (dd *DDSurfacer) distToDDSeries(d *metrics.DistributionData, metricName str
 	}
 
 	ret = append(ret, ddSeries{Metric: dd.prefix + metricName, Points: points, Tags: &tags, Type: proto.String(datadogKind[kind])})
+
+	// Configured percentiles are exported as gauges, e.g. latency.p99_9.
+	if d.Count > 0 {
+		for _, pct := range d.Percentiles {
+			ret = append(ret, ddSeries{
+				Metric: dd.prefix + metricName + ".p" + strings.ReplaceAll(metrics.FormatPercentile(pct), ".", "_"),
+				Points: [][]float64{{float64(t.Unix()), d.Percentile(pct)}},
+				Tags:   &tags,
+				Type:   proto.String(datadogKind[metrics.GAUGE]),
+			})
+		}
+	}
 	return ret
 }
@@ -15,11 +15,22 @@
 package datadog
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/datadog/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestEmLabelsToTags(t *testing.T) {
@@ -55,3 +66,61 @@ func TestEmLabelsToTags(t *testing.T) {
 		})
 	}
 }
+
+func TestDistToDDSeriesPercentiles(t *testing.T) {
+	d, err := metrics.NewDistributionFromProto(&distpb.Dist{
+		Buckets:     &distpb.Dist_ExplicitBuckets{ExplicitBuckets: "1,4"},
+		Percentiles: []float64{50, 99.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dd := &DDSurfacer{prefix: "cloudprober."}
+	ts := time.Now()
+
+	// No percentiles for an empty distribution.
+	series := dd.distToDDSeries(d.Data(), "latency", nil, ts, metrics.CUMULATIVE)
+	assert.Len(t, series, 3)
+
+	d.AddSample(2)
+	d.AddSample(3)
+	series = dd.distToDDSeries(d.Data(), "latency", nil, ts, metrics.CUMULATIVE)
+
+	got := make(map[string]float64)
+	for _, s := range series[3:] {
+		got[s.Metric] = s.Points[0][1]
+		assert.Equal(t, "gauge", *s.Type)
+	}
+	assert.Equal(t, map[string]float64{
+		"cloudprober.latency.p50":   2.5,
+		"cloudprober.latency.p99_5": 3.985,
+	}, got)
+}
+
+func TestFlush(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dd, err := New(ctx, &configpb.SurfacerConf{
+		Server:        proto.String(strings.TrimPrefix(ts.URL, "https://")),
+		ApiKey:        proto.String("test-api-key"),
+		AppKey:        proto.String("test-app-key"),
+		BatchTimerSec: proto.Int32(3600),
+	}, nil, &logger.Logger{})
+	require.NoError(t, err)
+	dd.client.c = *ts.Client()
+
+	// Nothing to publish.
+	assert.NoError(t, dd.Flush(ctx))
+	assert.Equal(t, int32(0), requests.Load())
+
+	dd.Write(ctx, metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(10)))
+	assert.NoError(t, dd.Flush(ctx))
+	assert.Equal(t, int32(1), requests.Load())
+}
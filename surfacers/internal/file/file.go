@@ -28,6 +28,7 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/compress"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/file/proto"
 )
@@ -42,6 +43,7 @@ type Surfacer struct {
 
 	// Channel for incoming data.
 	inChan         chan *metrics.EventMetrics
+	queue          *queue.Queue
 	processInputWg sync.WaitGroup
 
 	// Output file for serializing to
@@ -92,6 +94,7 @@ func (s *Surfacer) processInput(ctx context.Context) {
 
 func (s *Surfacer) init(ctx context.Context, id int64) error {
 	s.inChan = make(chan *metrics.EventMetrics, s.opts.MetricsBufferSize)
+	s.queue = queue.New(s.inChan)
 	s.id = id
 
 	// File handle for the output file
@@ -138,13 +141,16 @@ func (s *Surfacer) close() {
 // goroutine that actually writes data to a file ((usually set as a GCE
 // instance's serial port).
 func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case s.inChan <- em:
-	default:
+	if !s.queue.Enqueue(em) {
 		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
 // New initializes a Surfacer for serializing data into a file (usually set
 // as a GCE instance's serial port). This Surfacer does not utilize the Google
 // cloud logger because it is unlikely to fail reportably after the call to
@@ -0,0 +1,300 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// MQTT v3.1.1 control packet types. We implement only the subset of the
+// protocol that is required to publish messages.
+const (
+	packetConnect  = 1
+	packetConnack  = 2
+	packetPublish  = 3
+	packetPuback   = 4
+	packetPubrec   = 5
+	packetPubrel   = 6
+	packetPubcomp  = 7
+	packetPingreq  = 12
+	packetPingresp = 13
+	packetDisconn  = 14
+)
+
+const maxRemainingLength = 268435455
+
+var connackErrors = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+type clientConfig struct {
+	broker    string
+	tlsConfig *tls.Config
+	clientID  string
+	username  string
+	password  string
+	keepAlive time.Duration
+	timeout   time.Duration
+}
+
+// client is a minimal, synchronous MQTT v3.1.1 client. It's not safe for
+// concurrent use.
+type client struct {
+	cfg      *clientConfig
+	conn     net.Conn
+	r        *bufio.Reader
+	packetID uint16
+	lastSent time.Time
+}
+
+func newClient(cfg *clientConfig) *client {
+	return &client{cfg: cfg}
+}
+
+func (c *client) connected() bool {
+	return c.conn != nil
+}
+
+func encodeString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+func encodePacket(header byte, body []byte) ([]byte, error) {
+	if len(body) > maxRemainingLength {
+		return nil, fmt.Errorf("packet too large: %d bytes", len(body))
+	}
+	pkt := []byte{header}
+	for l := len(body); ; {
+		b := byte(l % 128)
+		l /= 128
+		if l > 0 {
+			b |= 0x80
+		}
+		pkt = append(pkt, b)
+		if l == 0 {
+			break
+		}
+	}
+	return append(pkt, body...), nil
+}
+
+// readPacket reads a control packet and returns its type, flags and body.
+func readPacket(r *bufio.Reader) (byte, byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	var length, multiplier int = 0, 1
+	for i := 0; ; i++ {
+		if i == 4 {
+			return 0, 0, nil, errors.New("malformed remaining length")
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		length += int(b&0x7f) * multiplier
+		multiplier *= 128
+		if b&0x80 == 0 {
+			break
+		}
+	}
+
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, 0, nil, err
+	}
+	return header >> 4, header & 0x0f, body, nil
+}
+
+func (c *client) write(header byte, body []byte) error {
+	pkt, err := encodePacket(header, body)
+	if err != nil {
+		return err
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(c.cfg.timeout))
+	if _, err := c.conn.Write(pkt); err != nil {
+		return err
+	}
+	c.lastSent = time.Now()
+	return nil
+}
+
+// expect reads the next packet and verifies that it's of the given type and,
+// if packetID is non-zero, that it's for the given packet ID.
+func (c *client) expect(ptype byte, packetID uint16) ([]byte, error) {
+	c.conn.SetReadDeadline(time.Now().Add(c.cfg.timeout))
+	for {
+		t, _, body, err := readPacket(c.r)
+		if err != nil {
+			return nil, err
+		}
+		// PINGRESP may arrive at any time, ignore it.
+		if t == packetPingresp && ptype != packetPingresp {
+			continue
+		}
+		if t != ptype {
+			return nil, fmt.Errorf("unexpected packet type: %d, expected: %d", t, ptype)
+		}
+		if packetID != 0 {
+			if len(body) < 2 || binary.BigEndian.Uint16(body) != packetID {
+				return nil, fmt.Errorf("unexpected packet id in packet type %d", t)
+			}
+		}
+		return body, nil
+	}
+}
+
+func (c *client) connect(ctx context.Context) error {
+	c.close()
+
+	dialer := &net.Dialer{Timeout: c.cfg.timeout}
+	var conn net.Conn
+	var err error
+	if c.cfg.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: c.cfg.tlsConfig}).DialContext(ctx, "tcp", c.cfg.broker)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", c.cfg.broker)
+	}
+	if err != nil {
+		return err
+	}
+	c.conn, c.r = conn, bufio.NewReader(conn)
+
+	// Variable header: protocol name, level, flags, keep alive.
+	flags := byte(0x02) // Clean session
+	if c.cfg.username != "" {
+		flags |= 0x80
+		if c.cfg.password != "" {
+			flags |= 0x40
+		}
+	}
+	body := encodeString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(c.cfg.keepAlive.Seconds()))
+
+	// Payload
+	body = encodeString(body, c.cfg.clientID)
+	if c.cfg.username != "" {
+		body = encodeString(body, c.cfg.username)
+		if c.cfg.password != "" {
+			body = encodeString(body, c.cfg.password)
+		}
+	}
+
+	if err := c.write(packetConnect<<4, body); err != nil {
+		c.close()
+		return err
+	}
+
+	resp, err := c.expect(packetConnack, 0)
+	if err != nil {
+		c.close()
+		return fmt.Errorf("error reading CONNACK: %v", err)
+	}
+	if len(resp) != 2 {
+		c.close()
+		return fmt.Errorf("malformed CONNACK")
+	}
+	if resp[1] != 0 {
+		c.close()
+		if msg, ok := connackErrors[resp[1]]; ok {
+			return fmt.Errorf("connection refused: %s", msg)
+		}
+		return fmt.Errorf("connection refused, return code: %d", resp[1])
+	}
+	return nil
+}
+
+func (c *client) nextPacketID() uint16 {
+	c.packetID++
+	if c.packetID == 0 {
+		c.packetID = 1
+	}
+	return c.packetID
+}
+
+// publish publishes the message and, for QoS > 0, waits for the broker to
+// acknowledge it.
+func (c *client) publish(topic string, payload []byte, qos byte, retain bool) error {
+	header := byte(packetPublish<<4) | qos<<1
+	if retain {
+		header |= 0x01
+	}
+
+	body := encodeString(nil, topic)
+	var id uint16
+	if qos > 0 {
+		id = c.nextPacketID()
+		body = binary.BigEndian.AppendUint16(body, id)
+	}
+	body = append(body, payload...)
+
+	if err := c.write(header, body); err != nil {
+		return err
+	}
+
+	switch qos {
+	case 1:
+		_, err := c.expect(packetPuback, id)
+		return err
+	case 2:
+		if _, err := c.expect(packetPubrec, id); err != nil {
+			return err
+		}
+		if err := c.write(packetPubrel<<4|0x02, binary.BigEndian.AppendUint16(nil, id)); err != nil {
+			return err
+		}
+		_, err := c.expect(packetPubcomp, id)
+		return err
+	}
+	return nil
+}
+
+// ping sends a PINGREQ if nothing has been sent to the broker in the last
+// half of the keep alive interval.
+func (c *client) ping() error {
+	if time.Since(c.lastSent) < c.cfg.keepAlive/2 {
+		return nil
+	}
+	if err := c.write(packetPingreq<<4, nil); err != nil {
+		return err
+	}
+	_, err := c.expect(packetPingresp, 0)
+	return err
+}
+
+func (c *client) close() {
+	if c.conn == nil {
+		return
+	}
+	c.write(packetDisconn<<4, nil)
+	c.conn.Close()
+	c.conn, c.r = nil, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBroker struct {
+	ln       net.Listener
+	connRC   byte
+	mu       sync.Mutex
+	clientID string
+	username string
+	received []*message
+}
+
+func newTestBroker(t *testing.T, addr string) *testBroker {
+	t.Helper()
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		t.Fatalf("error starting test broker: %v", err)
+	}
+	b := &testBroker{ln: ln}
+	go b.serve()
+	t.Cleanup(func() { ln.Close() })
+	return b
+}
+
+func (b *testBroker) messages() []*message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*message{}, b.received...)
+}
+
+func (b *testBroker) serve() {
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		go b.handle(conn)
+	}
+}
+
+func readString(body []byte) (string, []byte) {
+	l := binary.BigEndian.Uint16(body)
+	return string(body[2 : 2+l]), body[2+l:]
+}
+
+func (b *testBroker) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	write := func(header byte, body []byte) {
+		pkt, _ := encodePacket(header, body)
+		conn.Write(pkt)
+	}
+
+	for {
+		ptype, flags, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		switch ptype {
+		case packetConnect:
+			// Skip protocol name (6 bytes), level, flags and keep alive.
+			payload := body[10:]
+			b.mu.Lock()
+			b.clientID, payload = readString(payload)
+			if body[7]&0x80 != 0 {
+				b.username, _ = readString(payload)
+			}
+			b.mu.Unlock()
+			write(packetConnack<<4, []byte{0, b.connRC})
+		case packetPublish:
+			qos := (flags >> 1) & 0x03
+			topic, rest := readString(body)
+			var id []byte
+			if qos > 0 {
+				id, rest = rest[:2], rest[2:]
+			}
+			b.mu.Lock()
+			b.received = append(b.received, &message{topic: topic, payload: rest})
+			b.mu.Unlock()
+			switch qos {
+			case 1:
+				write(packetPuback<<4, id)
+			case 2:
+				write(packetPubrec<<4, id)
+			}
+		case packetPubrel:
+			write(packetPubcomp<<4, body)
+		case packetPingreq:
+			write(packetPingresp<<4, nil)
+		case packetDisconn:
+			return
+		}
+	}
+}
+
+func testClientConfig(addr string) *clientConfig {
+	return &clientConfig{
+		broker:    addr,
+		clientID:  "test-client",
+		username:  "user",
+		password:  "pass",
+		keepAlive: time.Second,
+		timeout:   time.Second,
+	}
+}
+
+func TestEncodeReadPacket(t *testing.T) {
+	for _, size := range []int{0, 127, 128, 16383, 16384, 100000} {
+		body := make([]byte, size)
+		pkt, err := encodePacket(packetPublish<<4|0x02, body)
+		assert.NoError(t, err)
+
+		ptype, flags, gotBody, err := readPacket(bufio.NewReader(bytes.NewReader(pkt)))
+		assert.NoError(t, err)
+		assert.Equal(t, byte(packetPublish), ptype)
+		assert.Equal(t, byte(0x02), flags)
+		assert.Equal(t, size, len(gotBody))
+	}
+}
+
+func TestClientPublish(t *testing.T) {
+	b := newTestBroker(t, "127.0.0.1:0")
+	c := newClient(testClientConfig(b.ln.Addr().String()))
+
+	assert.NoError(t, c.connect(context.Background()))
+	defer c.close()
+
+	for qos := byte(0); qos <= 2; qos++ {
+		assert.NoError(t, c.publish("test/topic", []byte{'0' + qos}, qos, false))
+	}
+	c.lastSent = time.Time{}
+	assert.NoError(t, c.ping())
+
+	// QoS 0 messages are not acknowledged, wait for the broker to receive all
+	// messages.
+	assert.Eventually(t, func() bool { return len(b.messages()) == 3 }, time.Second, 10*time.Millisecond)
+	for i, msg := range b.messages() {
+		assert.Equal(t, "test/topic", msg.topic)
+		assert.Equal(t, string([]byte{'0' + byte(i)}), string(msg.payload))
+	}
+
+	b.mu.Lock()
+	assert.Equal(t, "test-client", b.clientID)
+	assert.Equal(t, "user", b.username)
+	b.mu.Unlock()
+}
+
+func TestClientConnectRefused(t *testing.T) {
+	b := newTestBroker(t, "127.0.0.1:0")
+	b.connRC = 4
+
+	c := newClient(testClientConfig(b.ln.Addr().String()))
+	err := c.connect(context.Background())
+	assert.ErrorContains(t, err, "bad user name or password")
+	assert.False(t, c.connected())
+}
@@ -0,0 +1,295 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package mqtt implements a surfacer that publishes EventMetrics to an MQTT
+broker, in JSON format.
+*/
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudprober/cloudprober/common/strtemplate"
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
+)
+
+type message struct {
+	topic   string
+	payload []byte
+}
+
+// Surfacer implements an MQTT surfacer.
+type Surfacer struct {
+	c    *configpb.SurfacerConf
+	opts *options.Options
+	l    *logger.Logger
+
+	inChan chan *metrics.EventMetrics
+	queue  *queue.Queue
+	client *client
+	qos    byte
+
+	// Messages waiting to be published. Messages are buffered here while
+	// broker is not reachable.
+	buf            []*message
+	lastConnect    time.Time
+	reconnectIntv  time.Duration
+	droppedCounter int64
+}
+
+type distJSON struct {
+	LowerBounds  []float64 `json:"lower_bounds"`
+	BucketCounts []int64   `json:"bucket_counts"`
+	Count        int64     `json:"count"`
+	Sum          float64   `json:"sum"`
+}
+
+type emJSON struct {
+	Timestamp int64             `json:"timestamp"`
+	Kind      string            `json:"kind"`
+	Labels    map[string]string `json:"labels"`
+	Metrics   map[string]any    `json:"metrics"`
+}
+
+func mapJSON[T int64 | float64](m *metrics.Map[T]) map[string]T {
+	out := make(map[string]T)
+	for _, k := range m.Keys() {
+		out[k] = m.GetKey(k)
+	}
+	return out
+}
+
+// toJSON converts EventMetrics to JSON. Timestamp is in milliseconds since
+// epoch.
+func (s *Surfacer) toJSON(em *metrics.EventMetrics) ([]byte, error) {
+	kind := "CUMULATIVE"
+	if em.Kind == metrics.GAUGE {
+		kind = "GAUGE"
+	}
+
+	out := &emJSON{
+		Timestamp: em.Timestamp.UnixMilli(),
+		Kind:      kind,
+		Labels:    make(map[string]string),
+		Metrics:   make(map[string]any),
+	}
+	for _, k := range em.LabelsKeys() {
+		out.Labels[k] = em.Label(k)
+	}
+
+	for _, name := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(name) {
+			continue
+		}
+		switch v := em.Metric(name).(type) {
+		case metrics.NumValue:
+			if _, ok := v.(*metrics.Float); ok {
+				out.Metrics[name] = v.Float64()
+			} else {
+				out.Metrics[name] = v.Int64()
+			}
+		case metrics.String:
+			out.Metrics[name] = strings.Trim(v.String(), "\"")
+		case *metrics.Map[int64]:
+			out.Metrics[name] = mapJSON(v)
+		case *metrics.Map[float64]:
+			out.Metrics[name] = mapJSON(v)
+		case *metrics.Distribution:
+			d := v.Data()
+			out.Metrics[name] = &distJSON{d.LowerBounds, d.BucketCounts, d.Count, d.Sum}
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+// topic returns the topic for the EventMetrics, substituting placeholders.
+func (s *Surfacer) topic(em *metrics.EventMetrics) string {
+	labels := map[string]string{
+		"probe":  em.Label("probe"),
+		"target": em.Label("dst"),
+		"ptype":  em.Label("ptype"),
+	}
+	for k, v := range labels {
+		if v == "" {
+			labels[k] = "_"
+		}
+	}
+	topic, _ := strtemplate.SubstituteLabels(s.c.GetTopic(), labels)
+	return topic
+}
+
+// enqueue adds the message to the buffer, applying the overflow policy if
+// buffer is full.
+func (s *Surfacer) enqueue(msg *message) {
+	if len(s.buf) >= int(s.c.GetBufferSize()) {
+		s.droppedCounter++
+		if s.c.GetOverflowPolicy() == configpb.SurfacerConf_DROP_NEWEST {
+			s.l.Warningf("Buffer full, dropping new message (dropped so far: %d)", s.droppedCounter)
+			return
+		}
+		s.l.Warningf("Buffer full, dropping oldest message (dropped so far: %d)", s.droppedCounter)
+		s.buf = s.buf[1:]
+	}
+	s.buf = append(s.buf, msg)
+}
+
+// flush publishes the buffered messages, (re-)connecting to the broker if
+// required.
+func (s *Surfacer) flush(ctx context.Context) {
+	if len(s.buf) == 0 {
+		return
+	}
+
+	if !s.client.connected() {
+		if time.Since(s.lastConnect) < s.reconnectIntv {
+			return
+		}
+		s.lastConnect = time.Now()
+		if err := s.client.connect(ctx); err != nil {
+			s.l.Warningf("Error connecting to MQTT broker (%s): %v, buffered messages: %d", s.c.GetBroker(), err, len(s.buf))
+			return
+		}
+		s.l.Infof("Connected to MQTT broker: %s", s.c.GetBroker())
+	}
+
+	for len(s.buf) > 0 {
+		msg := s.buf[0]
+		if err := s.client.publish(msg.topic, msg.payload, s.qos, s.c.GetRetain()); err != nil {
+			s.l.Warningf("Error publishing to MQTT broker: %v, will retry after reconnection", err)
+			s.client.close()
+			return
+		}
+		s.buf[0] = nil
+		s.buf = s.buf[1:]
+	}
+}
+
+func (s *Surfacer) processInput(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	defer s.client.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+
+		case em := <-s.inChan:
+			payload, err := s.toJSON(em)
+			if err != nil {
+				s.l.Errorf("Error converting EventMetrics to JSON: %v", err)
+				continue
+			}
+			s.enqueue(&message{topic: s.topic(em), payload: payload})
+			s.flush(ctx)
+
+		case <-ticker.C:
+			s.flush(ctx)
+			if s.client.connected() {
+				if err := s.client.ping(); err != nil {
+					s.l.Warningf("Error pinging MQTT broker: %v", err)
+					s.client.close()
+				}
+			}
+		}
+	}
+}
+
+// Write queues the incoming data into a channel. This channel is watched by a
+// goroutine that actually publishes data to the broker.
+func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	if !s.queue.Enqueue(em) {
+		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
+	}
+}
+
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
+func defaultClientID() string {
+	hostname, _ := os.Hostname()
+	if hostname == "" {
+		return "cloudprober"
+	}
+	return "cloudprober-" + hostname
+}
+
+// New initializes a new MQTT surfacer.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config.GetBroker() == "" {
+		return nil, fmt.Errorf("mqtt: broker is required")
+	}
+	if config.GetQos() < 0 || config.GetQos() > 2 {
+		return nil, fmt.Errorf("mqtt: invalid qos: %d, should be 0, 1 or 2", config.GetQos())
+	}
+	if config.GetBufferSize() <= 0 {
+		return nil, fmt.Errorf("mqtt: buffer_size should be positive, got: %d", config.GetBufferSize())
+	}
+
+	cfg := &clientConfig{
+		broker:    config.GetBroker(),
+		clientID:  config.GetClientId(),
+		username:  config.GetUsername(),
+		password:  config.GetPassword(),
+		keepAlive: time.Duration(config.GetKeepAliveSec()) * time.Second,
+		timeout:   time.Duration(config.GetTimeoutSec()) * time.Second,
+	}
+	if cfg.clientID == "" {
+		cfg.clientID = defaultClientID()
+	}
+	if config.GetTlsConfig() != nil {
+		cfg.tlsConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(cfg.tlsConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("mqtt: error parsing tls_config: %v", err)
+		}
+		if cfg.tlsConfig.ServerName == "" {
+			if host, _, err := net.SplitHostPort(cfg.broker); err == nil {
+				cfg.tlsConfig.ServerName = host
+			}
+		}
+	}
+
+	s := &Surfacer{
+		c:             config,
+		opts:          opts,
+		l:             l,
+		inChan:        make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		client:        newClient(cfg),
+		qos:           byte(config.GetQos()),
+		reconnectIntv: time.Duration(config.GetReconnectIntervalSec()) * time.Second,
+	}
+	s.queue = queue.New(s.inChan)
+
+	go s.processInput(ctx)
+
+	l.Infof("Initialized MQTT surfacer, broker: %s, topic: %s", config.GetBroker(), config.GetTopic())
+	return s, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func testEM() *metrics.EventMetrics {
+	return metrics.NewEventMetrics(time.UnixMilli(1700000000123)).
+		AddMetric("total", metrics.NewInt(20)).
+		AddMetric("latency", metrics.NewFloat(9.5)).
+		AddMetric("resp_code", metrics.NewMap("code").IncKey("200")).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("ptype", "http").
+		AddLabel("probe", "homepage").
+		AddLabel("dst", "www.example.com")
+}
+
+func testSurfacer(c *configpb.SurfacerConf) *Surfacer {
+	return &Surfacer{
+		c:    c,
+		opts: &options.Options{},
+		l:    &logger.Logger{},
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	s := testSurfacer(&configpb.SurfacerConf{})
+
+	b, err := s.toJSON(testEM())
+	assert.NoError(t, err)
+
+	want := `{"timestamp":1700000000123,"kind":"CUMULATIVE","labels":{"dst":"www.example.com","probe":"homepage","ptype":"http"},"metrics":{"latency":9.5,"resp_code":{"200":1},"total":20,"version":"v1"}}`
+	assert.Equal(t, want, string(b))
+}
+
+func TestTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		em    *metrics.EventMetrics
+		want  string
+	}{
+		{
+			em:   testEM(),
+			want: "cloudprober/homepage/www.example.com",
+		},
+		{
+			topic: "edge/@ptype@/@probe@",
+			em:    testEM(),
+			want:  "edge/http/homepage",
+		},
+		{
+			em:   metrics.NewEventMetrics(time.Now()).AddLabel("probe", "sysvars"),
+			want: "cloudprober/sysvars/_",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			c := &configpb.SurfacerConf{}
+			if test.topic != "" {
+				c.Topic = proto.String(test.topic)
+			}
+			assert.Equal(t, test.want, testSurfacer(c).topic(test.em))
+		})
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	for _, policy := range []configpb.SurfacerConf_OverflowPolicy{configpb.SurfacerConf_DROP_OLDEST, configpb.SurfacerConf_DROP_NEWEST} {
+		t.Run(policy.String(), func(t *testing.T) {
+			s := testSurfacer(&configpb.SurfacerConf{
+				BufferSize:     proto.Int32(2),
+				OverflowPolicy: policy.Enum(),
+			})
+			for _, topic := range []string{"t1", "t2", "t3"} {
+				s.enqueue(&message{topic: topic})
+			}
+
+			var got []string
+			for _, msg := range s.buf {
+				got = append(got, msg.topic)
+			}
+			want := []string{"t2", "t3"}
+			if policy == configpb.SurfacerConf_DROP_NEWEST {
+				want = []string{"t1", "t2"}
+			}
+			assert.Equal(t, want, got)
+			assert.Equal(t, int64(1), s.droppedCounter)
+		})
+	}
+}
+
+func TestSurfacerBuffering(t *testing.T) {
+	// Reserve a port for the broker, but don't start it yet.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Broker:               proto.String(addr),
+		Qos:                  proto.Int32(1),
+		TimeoutSec:           proto.Int32(1),
+		ReconnectIntervalSec: proto.Int32(0),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	if err != nil {
+		t.Fatalf("Error creating surfacer: %v", err)
+	}
+
+	// Broker is not up yet, messages should get buffered.
+	s.Write(ctx, testEM())
+	s.Write(ctx, testEM())
+
+	time.Sleep(100 * time.Millisecond)
+	b := newTestBroker(t, addr)
+
+	assert.Eventually(t, func() bool { return len(b.messages()) == 2 }, 5*time.Second, 50*time.Millisecond)
+	for _, msg := range b.messages() {
+		assert.Equal(t, "cloudprober/homepage/www.example.com", msg.topic)
+	}
+}
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SurfacerConf_OverflowPolicy int32
+
+const (
+	// Drop the oldest buffered message to make room for the new one.
+	SurfacerConf_DROP_OLDEST SurfacerConf_OverflowPolicy = 0
+	// Drop the new message.
+	SurfacerConf_DROP_NEWEST SurfacerConf_OverflowPolicy = 1
+)
+
+// Enum value maps for SurfacerConf_OverflowPolicy.
+var (
+	SurfacerConf_OverflowPolicy_name = map[int32]string{
+		0: "DROP_OLDEST",
+		1: "DROP_NEWEST",
+	}
+	SurfacerConf_OverflowPolicy_value = map[string]int32{
+		"DROP_OLDEST": 0,
+		"DROP_NEWEST": 1,
+	}
+)
+
+func (x SurfacerConf_OverflowPolicy) Enum() *SurfacerConf_OverflowPolicy {
+	p := new(SurfacerConf_OverflowPolicy)
+	*p = x
+	return p
+}
+
+func (x SurfacerConf_OverflowPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerConf_OverflowPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SurfacerConf_OverflowPolicy) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_enumTypes[0]
+}
+
+func (x SurfacerConf_OverflowPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerConf_OverflowPolicy) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerConf_OverflowPolicy(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerConf_OverflowPolicy.Descriptor instead.
+func (SurfacerConf_OverflowPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// MQTT surfacer publishes EventMetrics, in JSON format, to an MQTT broker
+// (MQTT v3.1.1). It's useful for edge deployments where pull-based scraping
+// is not feasible.
+type SurfacerConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// MQTT broker address, in host:port format, e.g. "mqtt.example.com:1883".
+	Broker *string `protobuf:"bytes,1,req,name=broker" json:"broker,omitempty"`
+	// TLS config for the connection to the broker. If specified, surfacer
+	// connects to the broker over TLS.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,2,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// MQTT client ID. Default is "cloudprober-<hostname>".
+	ClientId *string `protobuf:"bytes,3,opt,name=client_id,json=clientId" json:"client_id,omitempty"`
+	// Username and password for authentication with the broker. You can also
+	// use environment variables to set them:
+	//
+	//	username: "{{env "MQTT_USERNAME"}}"
+	//	password: "{{env "MQTT_PASSWORD"}}"
+	Username *string `protobuf:"bytes,4,opt,name=username" json:"username,omitempty"`
+	Password *string `protobuf:"bytes,5,opt,name=password" json:"password,omitempty"`
+	// Topic to publish metrics to. Following placeholders are substituted
+	// using the EventMetrics' labels:
+	//
+	//	@probe@  : probe name
+	//	@target@ : target name (value of the "dst" label)
+	//	@ptype@  : probe type
+	//
+	// Placeholders that can't be substituted (e.g. for sysvars metrics
+	// that don't have a "dst" label) are replaced by "_".
+	Topic *string `protobuf:"bytes,6,opt,name=topic,def=cloudprober/@probe@/@target@" json:"topic,omitempty"`
+	// QoS level for the published messages: 0 (at most once), 1 (at least
+	// once), or 2 (exactly once).
+	Qos *int32 `protobuf:"varint,7,opt,name=qos,def=0" json:"qos,omitempty"`
+	// Whether to set the retain flag on the published messages.
+	Retain *bool `protobuf:"varint,8,opt,name=retain" json:"retain,omitempty"`
+	// Keep alive interval, in seconds.
+	KeepAliveSec *int32 `protobuf:"varint,9,opt,name=keep_alive_sec,json=keepAliveSec,def=60" json:"keep_alive_sec,omitempty"`
+	// Timeout for connect and publish operations, in seconds.
+	TimeoutSec *int32 `protobuf:"varint,10,opt,name=timeout_sec,json=timeoutSec,def=10" json:"timeout_sec,omitempty"`
+	// Minimum time between reconnection attempts, in seconds.
+	ReconnectIntervalSec *int32 `protobuf:"varint,11,opt,name=reconnect_interval_sec,json=reconnectIntervalSec,def=5" json:"reconnect_interval_sec,omitempty"`
+	// How many messages to buffer while the broker is not reachable. Messages
+	// are published in order once the connection is re-established.
+	BufferSize *int32 `protobuf:"varint,12,opt,name=buffer_size,json=bufferSize,def=10000" json:"buffer_size,omitempty"`
+	// What to do when the buffer is full.
+	OverflowPolicy *SurfacerConf_OverflowPolicy `protobuf:"varint,13,opt,name=overflow_policy,json=overflowPolicy,enum=cloudprober.surfacer.mqtt.SurfacerConf_OverflowPolicy,def=0" json:"overflow_policy,omitempty"`
+}
+
+// Default values for SurfacerConf fields.
+const (
+	Default_SurfacerConf_Topic                = string("cloudprober/@probe@/@target@")
+	Default_SurfacerConf_Qos                  = int32(0)
+	Default_SurfacerConf_KeepAliveSec         = int32(60)
+	Default_SurfacerConf_TimeoutSec           = int32(10)
+	Default_SurfacerConf_ReconnectIntervalSec = int32(5)
+	Default_SurfacerConf_BufferSize           = int32(10000)
+	Default_SurfacerConf_OverflowPolicy       = SurfacerConf_DROP_OLDEST
+)
+
+func (x *SurfacerConf) Reset() {
+	*x = SurfacerConf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerConf) ProtoMessage() {}
+
+func (x *SurfacerConf) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerConf.ProtoReflect.Descriptor instead.
+func (*SurfacerConf) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SurfacerConf) GetBroker() string {
+	if x != nil && x.Broker != nil {
+		return *x.Broker
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetClientId() string {
+	if x != nil && x.ClientId != nil {
+		return *x.ClientId
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetTopic() string {
+	if x != nil && x.Topic != nil {
+		return *x.Topic
+	}
+	return Default_SurfacerConf_Topic
+}
+
+func (x *SurfacerConf) GetQos() int32 {
+	if x != nil && x.Qos != nil {
+		return *x.Qos
+	}
+	return Default_SurfacerConf_Qos
+}
+
+func (x *SurfacerConf) GetRetain() bool {
+	if x != nil && x.Retain != nil {
+		return *x.Retain
+	}
+	return false
+}
+
+func (x *SurfacerConf) GetKeepAliveSec() int32 {
+	if x != nil && x.KeepAliveSec != nil {
+		return *x.KeepAliveSec
+	}
+	return Default_SurfacerConf_KeepAliveSec
+}
+
+func (x *SurfacerConf) GetTimeoutSec() int32 {
+	if x != nil && x.TimeoutSec != nil {
+		return *x.TimeoutSec
+	}
+	return Default_SurfacerConf_TimeoutSec
+}
+
+func (x *SurfacerConf) GetReconnectIntervalSec() int32 {
+	if x != nil && x.ReconnectIntervalSec != nil {
+		return *x.ReconnectIntervalSec
+	}
+	return Default_SurfacerConf_ReconnectIntervalSec
+}
+
+func (x *SurfacerConf) GetBufferSize() int32 {
+	if x != nil && x.BufferSize != nil {
+		return *x.BufferSize
+	}
+	return Default_SurfacerConf_BufferSize
+}
+
+func (x *SurfacerConf) GetOverflowPolicy() SurfacerConf_OverflowPolicy {
+	if x != nil && x.OverflowPolicy != nil {
+		return *x.OverflowPolicy
+	}
+	return Default_SurfacerConf_OverflowPolicy
+}
+
+var File_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x4d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6d, 0x71, 0x74, 0x74, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x19, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6d, 0x71, 0x74, 0x74, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xef, 0x04, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x6f, 0x6b, 0x65, 0x72, 0x12, 0x3f, 0x0a,
+	0x0a, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77,
+	0x6f, 0x72, 0x64, 0x12, 0x32, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x3a, 0x1c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
+	0x40, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x40, 0x2f, 0x40, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x40,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x13, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x30, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65,
+	0x74, 0x61, 0x69, 0x6e, 0x12, 0x28, 0x0a, 0x0e, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x61, 0x6c, 0x69,
+	0x76, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x36, 0x30,
+	0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x53, 0x65, 0x63, 0x12, 0x23,
+	0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x53, 0x65, 0x63, 0x12, 0x37, 0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x01, 0x35, 0x52, 0x14, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x0b,
+	0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x05, 0x3a, 0x05, 0x31, 0x30, 0x30, 0x30, 0x30, 0x52, 0x0a, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x6c, 0x0a, 0x0f, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x2e, 0x6d, 0x71, 0x74, 0x74, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x3a, 0x0b, 0x44, 0x52, 0x4f, 0x50, 0x5f, 0x4f, 0x4c, 0x44, 0x45,
+	0x53, 0x54, 0x52, 0x0e, 0x6f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x22, 0x32, 0x0a, 0x0e, 0x4f, 0x76, 0x65, 0x72, 0x66, 0x6c, 0x6f, 0x77, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x52, 0x4f, 0x50, 0x5f, 0x4f, 0x4c, 0x44,
+	0x45, 0x53, 0x54, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x52, 0x4f, 0x50, 0x5f, 0x4e, 0x45,
+	0x57, 0x45, 0x53, 0x54, 0x10, 0x01, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x6d, 0x71, 0x74, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_goTypes = []any{
+	(SurfacerConf_OverflowPolicy)(0), // 0: cloudprober.surfacer.mqtt.SurfacerConf.OverflowPolicy
+	(*SurfacerConf)(nil),             // 1: cloudprober.surfacer.mqtt.SurfacerConf
+	(*proto.TLSConfig)(nil),          // 2: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_depIdxs = []int32{
+	2, // 0: cloudprober.surfacer.mqtt.SurfacerConf.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	0, // 1: cloudprober.surfacer.mqtt.SurfacerConf.overflow_policy:type_name -> cloudprober.surfacer.mqtt.SurfacerConf.OverflowPolicy
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() {
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_init()
+}
+func file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_depIdxs,
+		EnumInfos:         file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_enumTypes,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_surfacers_internal_mqtt_proto_config_proto_depIdxs = nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudprober/cloudprober/logger"
+)
+
+// bulkItem is a document waiting to be indexed.
+type bulkItem struct {
+	index string
+	doc   []byte
+}
+
+type bulkClient struct {
+	servers  []string
+	next     int // Index of the server to send the next request to.
+	c        *http.Client
+	username string
+	password string
+	apiKey   string
+	opType   string
+	pipeline string
+	l        *logger.Logger
+}
+
+// bulkResponse is the part of the bulk API response that we use. See:
+// https://opensearch.org/docs/latest/api-reference/document-apis/bulk/
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	} `json:"items"`
+}
+
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// body returns the bulk request body, in NDJSON format.
+func (bc *bulkClient) body(items []*bulkItem) []byte {
+	var buf bytes.Buffer
+	for _, item := range items {
+		action, _ := json.Marshal(map[string]map[string]string{bc.opType: {"_index": item.index}})
+		buf.Write(action)
+		buf.WriteByte('\n')
+		buf.Write(item.doc)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+func (bc *bulkClient) newRequest(ctx context.Context, items []*bulkItem) (*http.Request, error) {
+	u := strings.TrimSuffix(bc.servers[bc.next], "/") + "/_bulk"
+	if bc.pipeline != "" {
+		u += "?pipeline=" + url.QueryEscape(bc.pipeline)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(bc.body(items)))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if bc.apiKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+bc.apiKey)
+	} else if bc.username != "" {
+		req.SetBasicAuth(bc.username, bc.password)
+	}
+	return req, nil
+}
+
+// send sends the items to the bulk API. It returns the items that should be
+// retried, and the number of items that failed permanently. Error is
+// returned only if the bulk request itself failed; errors for the
+// individual items are logged.
+func (bc *bulkClient) send(ctx context.Context, items []*bulkItem) ([]*bulkItem, int, error) {
+	req, err := bc.newRequest(ctx, items)
+	if err != nil {
+		return nil, len(items), err
+	}
+
+	resp, err := bc.c.Do(req)
+	if err != nil {
+		bc.next = (bc.next + 1) % len(bc.servers)
+		return items, 0, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return items, 0, err
+	}
+
+	if resp.StatusCode >= 300 {
+		err := fmt.Errorf("bulk request to %s failed, HTTP status: %d, response: %s", req.URL.Host, resp.StatusCode, string(b))
+		if retryableStatus(resp.StatusCode) {
+			bc.next = (bc.next + 1) % len(bc.servers)
+			return items, 0, err
+		}
+		return nil, len(items), err
+	}
+
+	var br bulkResponse
+	if err := json.Unmarshal(b, &br); err != nil {
+		return nil, len(items), fmt.Errorf("error parsing bulk response: %v", err)
+	}
+	if !br.Errors {
+		return nil, 0, nil
+	}
+	if len(br.Items) != len(items) {
+		return nil, len(items), fmt.Errorf("bulk response has %d items, expected %d", len(br.Items), len(items))
+	}
+
+	var retry []*bulkItem
+	var failed int
+	for i, respItem := range br.Items {
+		for _, result := range respItem {
+			if result.Status < 300 {
+				continue
+			}
+			if retryableStatus(result.Status) {
+				retry = append(retry, items[i])
+				continue
+			}
+			failed++
+			if failed == 1 && result.Error != nil {
+				bc.l.Warningf("Error indexing document in index %s: %s: %s", items[i].index, result.Error.Type, result.Error.Reason)
+			}
+		}
+	}
+	if failed > 1 {
+		bc.l.Warningf("%d documents failed to index", failed)
+	}
+	return retry, failed, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func testItems(docs ...string) []*bulkItem {
+	var items []*bulkItem
+	for _, doc := range docs {
+		items = append(items, &bulkItem{index: "idx", doc: []byte(doc)})
+	}
+	return items
+}
+
+func TestBulkBody(t *testing.T) {
+	bc := &bulkClient{opType: "create"}
+	want := "{\"create\":{\"_index\":\"idx\"}}\n{\"a\":1}\n{\"create\":{\"_index\":\"idx\"}}\n{\"a\":2}\n"
+	assert.Equal(t, want, string(bc.body(testItems(`{"a":1}`, `{"a":2}`))))
+}
+
+func TestBulkSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		resp       string
+		wantRetry  []string
+		wantFailed int
+		wantErr    bool
+		wantNext   int
+	}{
+		{
+			name:   "success",
+			status: 200,
+			resp:   `{"errors":false,"items":[{"index":{"status":201}},{"index":{"status":201}},{"index":{"status":201}}]}`,
+		},
+		{
+			name:       "item_errors",
+			status:     200,
+			resp:       `{"errors":true,"items":[{"index":{"status":201}},{"index":{"status":429}},{"index":{"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`,
+			wantRetry:  []string{"d2"},
+			wantFailed: 1,
+		},
+		{
+			name:      "overloaded",
+			status:    503,
+			resp:      `unavailable`,
+			wantRetry: []string{"d1", "d2", "d3"},
+			wantErr:   true,
+			wantNext:  1,
+		},
+		{
+			name:       "unauthorized",
+			status:     401,
+			resp:       `unauthorized`,
+			wantErr:    true,
+			wantFailed: 3,
+		},
+		{
+			name:       "bad_response",
+			status:     200,
+			resp:       `{"errors":true,"items":[{"index":{"status":201}}]}`,
+			wantErr:    true,
+			wantFailed: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/_bulk", r.URL.Path)
+				assert.Equal(t, "ingest", r.URL.Query().Get("pipeline"))
+				assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+				user, pass, _ := r.BasicAuth()
+				assert.Equal(t, "user:pass", user+":"+pass)
+				io.ReadAll(r.Body)
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.resp))
+			}))
+			defer ts.Close()
+
+			bc := &bulkClient{
+				servers:  []string{ts.URL, ts.URL + "/"},
+				c:        ts.Client(),
+				username: "user",
+				password: "pass",
+				opType:   "index",
+				pipeline: "ingest",
+				l:        &logger.Logger{},
+			}
+			retry, failed, err := bc.send(context.Background(), testItems("d1", "d2", "d3"))
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.wantFailed, failed)
+			assert.Equal(t, test.wantNext, bc.next)
+
+			var gotRetry []string
+			for _, item := range retry {
+				gotRetry = append(gotRetry, string(item.doc))
+			}
+			assert.Equal(t, test.wantRetry, gotRetry)
+		})
+	}
+}
+
+func TestBulkAPIKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ApiKey key123", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"errors":false}`))
+	}))
+	defer ts.Close()
+
+	bc := &bulkClient{servers: []string{ts.URL}, c: ts.Client(), apiKey: "key123", username: "user", opType: "index"}
+	_, _, err := bc.send(context.Background(), testItems("d1"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,377 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package opensearch implements a surfacer that indexes EventMetrics into
+OpenSearch (or Elasticsearch) using the bulk API.
+*/
+package opensearch
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudprober/cloudprober/internal/tlsconfig"
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/opensearch/proto"
+)
+
+var placeholderRe = regexp.MustCompile(`@([a-zA-Z0-9_]+)@`)
+
+type stats struct {
+	indexed   atomic.Int64
+	failed    atomic.Int64
+	retried   atomic.Int64
+	dropped   atomic.Int64
+	reqErrors atomic.Int64
+}
+
+// Surfacer implements an OpenSearch surfacer.
+type Surfacer struct {
+	c    *configpb.SurfacerConf
+	opts *options.Options
+	l    *logger.Logger
+	name string
+
+	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+	flushChan chan chan struct{}
+	client    *bulkClient
+	batch     []*bulkItem
+	stats     stats
+}
+
+type bucketJSON struct {
+	// Lower bound is not set for the first bucket, if it's -Inf.
+	LowerBound *float64 `json:"lower_bound,omitempty"`
+	Count      int64    `json:"count"`
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// distFields flattens a distribution into fields that can be used in
+// aggregations directly.
+func distFields(d *metrics.DistributionData) map[string]any {
+	out := map[string]any{
+		"count": d.Count,
+		"sum":   d.Sum,
+	}
+	if d.Count == 0 {
+		return out
+	}
+
+	out["mean"] = d.Sum / float64(d.Count)
+	var buckets []bucketJSON
+	for i, lb := range d.LowerBounds {
+		if d.BucketCounts[i] == 0 {
+			continue
+		}
+		b := bucketJSON{Count: d.BucketCounts[i]}
+		if finite(lb) {
+			b.LowerBound = &d.LowerBounds[i]
+		}
+		buckets = append(buckets, b)
+	}
+	out["buckets"] = buckets
+	for _, pct := range d.Percentiles {
+		if v := d.Percentile(pct); finite(v) {
+			out["p"+strings.ReplaceAll(metrics.FormatPercentile(pct), ".", "_")] = v
+		}
+	}
+	return out
+}
+
+func mapFields[T int64 | float64](m *metrics.Map[T]) map[string]T {
+	out := make(map[string]T)
+	for _, k := range m.Keys() {
+		out[k] = m.GetKey(k)
+	}
+	return out
+}
+
+// document returns the OpenSearch document for the EventMetrics.
+func (s *Surfacer) document(em *metrics.EventMetrics) map[string]any {
+	kind := "CUMULATIVE"
+	if em.Kind == metrics.GAUGE {
+		kind = "GAUGE"
+	}
+	doc := map[string]any{
+		s.c.GetTimestampField(): em.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		"kind":                  kind,
+	}
+
+	labels, metricsFields := doc, doc
+	if s.c.GetLabelsField() != "" {
+		labels = make(map[string]any)
+		doc[s.c.GetLabelsField()] = labels
+	}
+	if s.c.GetMetricsField() != "" {
+		metricsFields = make(map[string]any)
+		doc[s.c.GetMetricsField()] = metricsFields
+	}
+
+	for _, k := range em.LabelsKeys() {
+		labels[k] = em.Label(k)
+		if v, ok := em.TypedLabel(k); ok {
+			if f, isFloat := v.(float64); !isFloat || finite(f) {
+				labels[k] = v
+			}
+		}
+	}
+
+	for _, name := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(name) {
+			continue
+		}
+		switch v := em.Metric(name).(type) {
+		case metrics.NumValue:
+			if _, ok := v.(*metrics.Float); !ok {
+				metricsFields[name] = v.Int64()
+			} else if finite(v.Float64()) {
+				metricsFields[name] = v.Float64()
+			}
+		case metrics.String:
+			metricsFields[name] = strings.Trim(v.String(), "\"")
+		case *metrics.Map[int64]:
+			metricsFields[name] = mapFields(v)
+		case *metrics.Map[float64]:
+			metricsFields[name] = mapFields(v)
+		case *metrics.Distribution:
+			metricsFields[name] = distFields(v.Data())
+		}
+	}
+
+	return doc
+}
+
+// indexName returns the index name for the EventMetrics, substituting the
+// placeholders.
+func (s *Surfacer) indexName(em *metrics.EventMetrics) string {
+	index := placeholderRe.ReplaceAllStringFunc(s.c.GetIndex(), func(m string) string {
+		key := strings.Trim(m, "@")
+		if key == "date" {
+			return em.Timestamp.UTC().Format(s.c.GetIndexDateFormat())
+		}
+		if v := em.Label(key); v != "" {
+			return v
+		}
+		return "_"
+	})
+	return strings.ToLower(index)
+}
+
+func (s *Surfacer) addToBatch(ctx context.Context, em *metrics.EventMetrics) {
+	doc, err := json.Marshal(s.document(em))
+	if err != nil {
+		s.l.Errorf("Error encoding EventMetrics (%s) as JSON: %v", em.String(), err)
+		s.stats.failed.Add(1)
+		return
+	}
+	s.batch = append(s.batch, &bulkItem{index: s.indexName(em), doc: doc})
+	if len(s.batch) >= int(s.c.GetMetricsBatchSize()) {
+		s.indexBatch(ctx)
+	}
+}
+
+// indexBatch indexes the current batch, retrying the failed documents with
+// exponential backoff. Since this blocks the write loop, incoming
+// EventMetrics pile up in the write channel during retries, and are
+// dropped if it gets full.
+func (s *Surfacer) indexBatch(ctx context.Context) {
+	if len(s.batch) == 0 {
+		return
+	}
+
+	items := s.batch
+	backoff := time.Duration(s.c.GetInitialBackoffMsec()) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		retry, failed, err := s.client.send(ctx, items)
+		s.stats.indexed.Add(int64(len(items) - len(retry) - failed))
+		s.stats.failed.Add(int64(failed))
+		if err != nil {
+			s.stats.reqErrors.Add(1)
+			s.l.Warningf("Bulk request error (attempt: %d): %v", attempt+1, err)
+		}
+		if len(retry) == 0 {
+			break
+		}
+
+		if attempt >= int(s.c.GetMaxRetries()) || ctx.Err() != nil {
+			s.l.Errorf("Dropping %d documents after %d attempts", len(retry), attempt+1)
+			s.stats.dropped.Add(int64(len(retry)))
+			break
+		}
+		s.stats.retried.Add(int64(len(retry)))
+		items = retry
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		backoff *= 2
+	}
+
+	s.batch = s.batch[:0]
+}
+
+// statsEventMetrics returns the surfacer's own stats as EventMetrics.
+func (s *Surfacer) statsEventMetrics() *metrics.EventMetrics {
+	return metrics.NewEventMetrics(time.Now()).
+		AddMetric("opensearch_docs_indexed", metrics.NewInt(s.stats.indexed.Load())).
+		AddMetric("opensearch_docs_failed", metrics.NewInt(s.stats.failed.Load())).
+		AddMetric("opensearch_docs_retried", metrics.NewInt(s.stats.retried.Load())).
+		AddMetric("opensearch_docs_dropped", metrics.NewInt(s.stats.dropped.Load())).
+		AddMetric("opensearch_bulk_request_errors", metrics.NewInt(s.stats.reqErrors.Load())).
+		AddLabel("surfacer", s.name)
+}
+
+func (s *Surfacer) writeLoop(ctx context.Context) {
+	batchTicker := time.NewTicker(time.Duration(s.c.GetBatchTimerSec()) * time.Second)
+	defer batchTicker.Stop()
+
+	// Ticker channel for stats is nil, i.e. never ready, if stats export is
+	// disabled.
+	var statsTickerC <-chan time.Time
+	if s.c.GetStatsExportIntervalSec() > 0 {
+		statsTicker := time.NewTicker(time.Duration(s.c.GetStatsExportIntervalSec()) * time.Second)
+		defer statsTicker.Stop()
+		statsTickerC = statsTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.l.Infof("Context canceled, stopping the surfacer write loop")
+			return
+		case em := <-s.writeChan:
+			s.addToBatch(ctx, em)
+		case <-batchTicker.C:
+			s.indexBatch(ctx)
+		case <-statsTickerC:
+			s.addToBatch(ctx, s.statsEventMetrics())
+		case done := <-s.flushChan:
+			for len(s.writeChan) > 0 {
+				s.addToBatch(ctx, <-s.writeChan)
+			}
+			s.indexBatch(ctx)
+			close(done)
+		}
+	}
+}
+
+// Write queues the EventMetrics for indexing. If surfacer's buffer is full,
+// EventMetrics are dropped.
+func (s *Surfacer) Write(_ context.Context, em *metrics.EventMetrics) {
+	if !s.queue.Enqueue(em) {
+		s.stats.dropped.Add(1)
+		s.l.Warningf("Surfacer's write channel is full, dropping new data (dropped so far: %d).", s.stats.dropped.Load())
+	}
+}
+
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
+// Flush indexes the pending EventMetrics.
+func (s *Surfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, s.flushChan)
+}
+
+func newHTTPClient(config *configpb.SurfacerConf) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.GetTlsConfig() != nil {
+		transport.TLSClientConfig = &tls.Config{}
+		if err := tlsconfig.UpdateTLSConfig(transport.TLSClientConfig, config.GetTlsConfig()); err != nil {
+			return nil, fmt.Errorf("opensearch: error parsing tls_config: %v", err)
+		}
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(config.GetTimeoutSec()) * time.Second,
+	}, nil
+}
+
+// New creates a new OpenSearch surfacer, and starts its write loop.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if len(config.GetServer()) == 0 {
+		return nil, fmt.Errorf("opensearch: server is required")
+	}
+	for _, server := range config.GetServer() {
+		u, err := url.Parse(server)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("opensearch: invalid server URL: %s", server)
+		}
+	}
+	if config.GetMetricsBatchSize() <= 0 {
+		return nil, fmt.Errorf("opensearch: metrics_batch_size should be positive, got: %d", config.GetMetricsBatchSize())
+	}
+	if config.GetBatchTimerSec() <= 0 {
+		return nil, fmt.Errorf("opensearch: batch_timer_sec should be positive, got: %d", config.GetBatchTimerSec())
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	opType := "index"
+	if config.GetDataStream() {
+		opType = "create"
+	}
+
+	s := &Surfacer{
+		c:         config,
+		opts:      opts,
+		l:         l,
+		name:      opts.Config.GetName(),
+		writeChan: make(chan *metrics.EventMetrics, opts.MetricsBufferSize),
+		flushChan: flush.NewChan(),
+		client: &bulkClient{
+			servers:  config.GetServer(),
+			c:        httpClient,
+			username: config.GetUsername(),
+			password: config.GetPassword(),
+			apiKey:   config.GetApiKey(),
+			opType:   opType,
+			pipeline: config.GetPipeline(),
+			l:        l,
+		},
+		batch: make([]*bulkItem, 0, config.GetMetricsBatchSize()),
+	}
+	s.queue = queue.New(s.writeChan)
+	if s.name == "" {
+		s.name = "opensearch"
+	}
+
+	go s.writeLoop(ctx)
+
+	l.Infof("Initialized OpenSearch surfacer, servers: %v, index: %s", config.GetServer(), config.GetIndex())
+	return s, nil
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opensearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/opensearch/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var testTime = time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func testEM(t *testing.T) *metrics.EventMetrics {
+	t.Helper()
+	d, err := metrics.NewDistributionFromProto(&distpb.Dist{
+		Buckets:     &distpb.Dist_ExplicitBuckets{ExplicitBuckets: "1,4"},
+		Percentiles: []float64{50},
+	})
+	require.NoError(t, err)
+	for _, v := range []float64{0.5, 2, 3} {
+		d.AddSample(v)
+	}
+
+	respCodes := metrics.NewMap("code")
+	respCodes.IncKeyBy("200", 2)
+
+	return metrics.NewEventMetrics(testTime).
+		AddMetric("total", metrics.NewInt(3)).
+		AddMetric("latency", d).
+		AddMetric("resp_code", respCodes).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", "Web").
+		AddLabel("dst", "www.example.com")
+}
+
+func TestDocument(t *testing.T) {
+	em := testEM(t)
+
+	s := &Surfacer{c: &configpb.SurfacerConf{}}
+	b, err := json.Marshal(s.document(em))
+	require.NoError(t, err)
+	want := `{"@timestamp":"2024-05-01T10:00:00.000Z","kind":"CUMULATIVE",` +
+		`"labels":{"dst":"www.example.com","probe":"Web"},` +
+		`"metrics":{"latency":{"buckets":[{"count":1},{"lower_bound":1,"count":2}],"count":3,"mean":1.8333333333333333,"p50":1.75,"sum":5.5},` +
+		`"resp_code":{"200":2},"total":3,"version":"v1"}}`
+	assert.Equal(t, want, string(b))
+
+	// Custom mapping, with labels and metrics at the top level.
+	s.c = &configpb.SurfacerConf{
+		TimestampField: proto.String("ts"),
+		LabelsField:    proto.String(""),
+		MetricsField:   proto.String(""),
+	}
+	doc := s.document(em.Clone().AddMetric("empty", metrics.NewDistribution([]float64{1})))
+	assert.Equal(t, "2024-05-01T10:00:00.000Z", doc["ts"])
+	assert.Equal(t, "www.example.com", doc["dst"])
+	assert.Equal(t, int64(3), doc["total"])
+	assert.Equal(t, map[string]any{"count": int64(0), "sum": float64(0)}, doc["empty"])
+
+	// Typed labels are indexed with their types.
+	s.c = &configpb.SurfacerConf{}
+	em = em.Clone().AddLabel("weight", "10").AddLabel("canary", "true").AddLabel("ratio", "NaN")
+	em.SetTypedLabel("weight", int64(10)).SetTypedLabel("canary", true).SetTypedLabel("ratio", math.NaN())
+	b, err = json.Marshal(s.document(em)[s.c.GetLabelsField()])
+	require.NoError(t, err)
+	assert.Equal(t, `{"canary":true,"dst":"www.example.com","probe":"Web","ratio":"NaN","weight":10}`, string(b))
+}
+
+func TestIndexName(t *testing.T) {
+	em := testEM(t)
+	tests := []struct {
+		index, dateFormat, want string
+	}{
+		{want: "cloudprober-2024.05.01"},
+		{index: "probes-@date@", dateFormat: "2006.01", want: "probes-2024.05"},
+		{index: "cloudprober-@probe@-@ptype@", want: "cloudprober-web-_"},
+	}
+	for _, test := range tests {
+		c := &configpb.SurfacerConf{}
+		if test.index != "" {
+			c.Index = proto.String(test.index)
+		}
+		if test.dateFormat != "" {
+			c.IndexDateFormat = proto.String(test.dateFormat)
+		}
+		s := &Surfacer{c: c}
+		assert.Equal(t, test.want, s.indexName(em), "index: %s", test.index)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	for _, c := range []*configpb.SurfacerConf{
+		{},
+		{Server: []string{"opensearch:9200"}},
+		{Server: []string{"http://opensearch:9200"}, MetricsBatchSize: proto.Int32(0)},
+		{Server: []string{"http://opensearch:9200"}, BatchTimerSec: proto.Int32(0)},
+	} {
+		_, err := New(context.Background(), c, &options.Options{}, &logger.Logger{})
+		assert.Error(t, err, "config: %v", c)
+	}
+}
+
+// testServer is a fake bulk API server. It fails the first attempt of each
+// document with 429, and the documents in the "bad" index with 400.
+type testServer struct {
+	mu       sync.Mutex
+	seen     map[string]bool
+	indexed  []map[string]any
+	requests int
+}
+
+func (ts *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.requests++
+
+	var items []map[string]any
+	scanner := bufio.NewScanner(r.Body)
+	for scanner.Scan() {
+		var action map[string]map[string]string
+		json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		docStr := scanner.Text()
+
+		status := 201
+		switch {
+		case action["index"]["_index"] == "bad":
+			status = 400
+		case !ts.seen[docStr]:
+			ts.seen[docStr] = true
+			status = 429
+		default:
+			var doc map[string]any
+			json.Unmarshal([]byte(docStr), &doc)
+			ts.indexed = append(ts.indexed, doc)
+		}
+		items = append(items, map[string]any{"index": map[string]any{"status": status}})
+	}
+	json.NewEncoder(w).Encode(map[string]any{"errors": true, "items": items})
+}
+
+func TestWriteAndFlush(t *testing.T) {
+	fakeServer := &testServer{seen: make(map[string]bool)}
+	ts := httptest.NewServer(fakeServer)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Server:                 []string{ts.URL},
+		Index:                  proto.String("@probe@"),
+		BatchTimerSec:          proto.Int32(3600),
+		InitialBackoffMsec:     proto.Int32(1),
+		StatsExportIntervalSec: proto.Int32(0),
+	}, &options.Options{MetricsBufferSize: 10}, &logger.Logger{})
+	require.NoError(t, err)
+
+	for _, probe := range []string{"p1", "p2", "bad"} {
+		s.Write(ctx, metrics.NewEventMetrics(testTime).AddMetric("total", metrics.NewInt(1)).AddLabel("probe", probe))
+	}
+	require.NoError(t, s.Flush(ctx))
+
+	var gotProbes []string
+	for _, doc := range fakeServer.indexed {
+		gotProbes = append(gotProbes, doc["labels"].(map[string]any)["probe"].(string))
+	}
+	assert.Equal(t, []string{"p1", "p2"}, gotProbes)
+	assert.Equal(t, 2, fakeServer.requests)
+
+	assert.Equal(t, int64(2), s.stats.indexed.Load())
+	assert.Equal(t, int64(1), s.stats.failed.Load())
+	assert.Equal(t, int64(2), s.stats.retried.Load())
+
+	// Stats are exported as EventMetrics.
+	em := s.statsEventMetrics()
+	assert.Equal(t, "opensearch", em.Label("surfacer"))
+	assert.Equal(t, "1", em.Metric("opensearch_docs_failed").String())
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "overloaded", http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := New(ctx, &configpb.SurfacerConf{
+		Server:             []string{ts.URL},
+		BatchTimerSec:      proto.Int32(3600),
+		MaxRetries:         proto.Int32(2),
+		InitialBackoffMsec: proto.Int32(1),
+	}, &options.Options{MetricsBufferSize: 1}, &logger.Logger{})
+	require.NoError(t, err)
+
+	s.Write(ctx, metrics.NewEventMetrics(testTime).AddMetric("total", metrics.NewInt(1)))
+	require.NoError(t, s.Flush(ctx))
+
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, int64(1), s.stats.dropped.Load())
+	assert.Equal(t, int64(3), s.stats.reqErrors.Load())
+	assert.Equal(t, "1", s.statsEventMetrics().Metric("opensearch_docs_dropped").String())
+}
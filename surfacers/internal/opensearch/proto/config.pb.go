@@ -0,0 +1,423 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.21.5
+// source: github.com/cloudprober/cloudprober/surfacers/internal/opensearch/proto/config.proto
+
+package proto
+
+import (
+	proto "github.com/cloudprober/cloudprober/internal/tlsconfig/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// OpenSearch surfacer indexes EventMetrics into OpenSearch (or Elasticsearch)
+// using the bulk API. Each EventMetrics becomes one document:
+//
+//	{
+//	  "@timestamp": "2024-05-01T10:00:00.000Z",
+//	  "kind": "CUMULATIVE",
+//	  "labels": {"probe": "web", "dst": "www.example.com", "ptype": "http"},
+//	  "metrics": {
+//	    "total": 20,
+//	    "success": 19,
+//	    "resp_code": {"200": 19, "500": 1},
+//	    "latency": {
+//	      "count": 19, "sum": 2830.5, "mean": 148.97,
+//	      "buckets": [{"lower_bound": 100, "count": 12}, ...],
+//	      "p99": 310.2
+//	    }
+//	  }
+//	}
+//
+// Distributions are flattened into count, sum, mean, buckets and configured
+// percentiles, so that they can be used in aggregations directly.
+type SurfacerConf struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// OpenSearch server URLs, e.g. "https://opensearch.example.com:9200". If
+	// more than one URL is specified, requests are sent to the next server if
+	// a server fails.
+	Server []string `protobuf:"bytes,1,rep,name=server" json:"server,omitempty"`
+	// Index to write documents to. Following placeholders are substituted:
+	//
+	//	@date@  : EventMetrics' timestamp (UTC) formatted using index_date_format
+	//	@probe@ : probe name, or any other EventMetrics label
+	//
+	// Labels that are missing in an EventMetrics are replaced by "_". Index
+	// name is converted to lowercase, as required by OpenSearch.
+	Index *string `protobuf:"bytes,2,opt,name=index,def=cloudprober-@date@" json:"index,omitempty"`
+	// Go time layout for the @date@ placeholder in the index name, e.g. use
+	// "2006.01" for monthly indices.
+	IndexDateFormat *string `protobuf:"bytes,3,opt,name=index_date_format,json=indexDateFormat,def=2006.01.02" json:"index_date_format,omitempty"`
+	// Index documents using the "create" operation, as required for data
+	// streams. By default, "index" operation is used.
+	DataStream *bool `protobuf:"varint,4,opt,name=data_stream,json=dataStream" json:"data_stream,omitempty"`
+	// Ingest pipeline to use for the documents.
+	Pipeline *string `protobuf:"bytes,5,opt,name=pipeline" json:"pipeline,omitempty"`
+	// Username and password for basic authentication. You can also use
+	// environment variables to set them:
+	//
+	//	username: "{{env "OPENSEARCH_USERNAME"}}"
+	//	password: "{{env "OPENSEARCH_PASSWORD"}}"
+	Username *string `protobuf:"bytes,6,opt,name=username" json:"username,omitempty"`
+	Password *string `protobuf:"bytes,7,opt,name=password" json:"password,omitempty"`
+	// API key (base64 encoded id:api_key) for authentication. If set, it's
+	// sent in the "Authorization: ApiKey <api_key>" header.
+	ApiKey *string `protobuf:"bytes,8,opt,name=api_key,json=apiKey" json:"api_key,omitempty"`
+	// TLS config for the connection to the servers.
+	TlsConfig *proto.TLSConfig `protobuf:"bytes,9,opt,name=tls_config,json=tlsConfig" json:"tls_config,omitempty"`
+	// Document mapping: names of the timestamp, labels, and metrics fields. If
+	// labels_field or metrics_field is set to empty string, labels or metrics
+	// are added at the top level of the document. Labels with typed values
+	// (e.g. from the file targets' typed_labels) are indexed with their types,
+	// i.e. as numbers or booleans; all other labels are indexed as strings.
+	TimestampField *string `protobuf:"bytes,10,opt,name=timestamp_field,json=timestampField,def=@timestamp" json:"timestamp_field,omitempty"`
+	LabelsField    *string `protobuf:"bytes,11,opt,name=labels_field,json=labelsField,def=labels" json:"labels_field,omitempty"`
+	MetricsField   *string `protobuf:"bytes,12,opt,name=metrics_field,json=metricsField,def=metrics" json:"metrics_field,omitempty"`
+	// Maximum number of documents in a bulk request. Documents are indexed
+	// when batch timer expires or batch is full, whichever happens first.
+	MetricsBatchSize *int32 `protobuf:"varint,13,opt,name=metrics_batch_size,json=metricsBatchSize,def=1000" json:"metrics_batch_size,omitempty"`
+	// Maximum amount of time to hold the documents in the batch.
+	BatchTimerSec *int32 `protobuf:"varint,14,opt,name=batch_timer_sec,json=batchTimerSec,def=10" json:"batch_timer_sec,omitempty"`
+	// Timeout for the bulk requests.
+	TimeoutSec *int32 `protobuf:"varint,15,opt,name=timeout_sec,json=timeoutSec,def=30" json:"timeout_sec,omitempty"`
+	// How many times to retry a failed bulk request, or failed documents in
+	// a bulk request. Only the documents that failed with a retryable error
+	// (429 or 5xx) are retried. Retries use exponential backoff starting at
+	// initial_backoff_msec. While retries are in progress, incoming
+	// EventMetrics are buffered in the surfacer's buffer (metrics_buffer_size)
+	// and dropped if the buffer gets full.
+	MaxRetries         *int32 `protobuf:"varint,16,opt,name=max_retries,json=maxRetries,def=3" json:"max_retries,omitempty"`
+	InitialBackoffMsec *int32 `protobuf:"varint,17,opt,name=initial_backoff_msec,json=initialBackoffMsec,def=500" json:"initial_backoff_msec,omitempty"`
+	// How often to index surfacer's own stats: indexed, failed, retried, and
+	// dropped documents, and failed bulk requests. These stats are indexed
+	// as an EventMetrics with label "surfacer" set to the surfacer name, under
+	// the metric names opensearch_docs_indexed, opensearch_docs_failed,
+	// opensearch_docs_retried, opensearch_docs_dropped, and
+	// opensearch_bulk_request_errors. Set to 0 to disable.
+	StatsExportIntervalSec *int32 `protobuf:"varint,18,opt,name=stats_export_interval_sec,json=statsExportIntervalSec,def=60" json:"stats_export_interval_sec,omitempty"`
+}
+
+// Default values for SurfacerConf fields.
+const (
+	Default_SurfacerConf_Index                  = string("cloudprober-@date@")
+	Default_SurfacerConf_IndexDateFormat        = string("2006.01.02")
+	Default_SurfacerConf_TimestampField         = string("@timestamp")
+	Default_SurfacerConf_LabelsField            = string("labels")
+	Default_SurfacerConf_MetricsField           = string("metrics")
+	Default_SurfacerConf_MetricsBatchSize       = int32(1000)
+	Default_SurfacerConf_BatchTimerSec          = int32(10)
+	Default_SurfacerConf_TimeoutSec             = int32(30)
+	Default_SurfacerConf_MaxRetries             = int32(3)
+	Default_SurfacerConf_InitialBackoffMsec     = int32(500)
+	Default_SurfacerConf_StatsExportIntervalSec = int32(60)
+)
+
+func (x *SurfacerConf) Reset() {
+	*x = SurfacerConf{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerConf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerConf) ProtoMessage() {}
+
+func (x *SurfacerConf) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerConf.ProtoReflect.Descriptor instead.
+func (*SurfacerConf) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SurfacerConf) GetServer() []string {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetIndex() string {
+	if x != nil && x.Index != nil {
+		return *x.Index
+	}
+	return Default_SurfacerConf_Index
+}
+
+func (x *SurfacerConf) GetIndexDateFormat() string {
+	if x != nil && x.IndexDateFormat != nil {
+		return *x.IndexDateFormat
+	}
+	return Default_SurfacerConf_IndexDateFormat
+}
+
+func (x *SurfacerConf) GetDataStream() bool {
+	if x != nil && x.DataStream != nil {
+		return *x.DataStream
+	}
+	return false
+}
+
+func (x *SurfacerConf) GetPipeline() string {
+	if x != nil && x.Pipeline != nil {
+		return *x.Pipeline
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetApiKey() string {
+	if x != nil && x.ApiKey != nil {
+		return *x.ApiKey
+	}
+	return ""
+}
+
+func (x *SurfacerConf) GetTlsConfig() *proto.TLSConfig {
+	if x != nil {
+		return x.TlsConfig
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetTimestampField() string {
+	if x != nil && x.TimestampField != nil {
+		return *x.TimestampField
+	}
+	return Default_SurfacerConf_TimestampField
+}
+
+func (x *SurfacerConf) GetLabelsField() string {
+	if x != nil && x.LabelsField != nil {
+		return *x.LabelsField
+	}
+	return Default_SurfacerConf_LabelsField
+}
+
+func (x *SurfacerConf) GetMetricsField() string {
+	if x != nil && x.MetricsField != nil {
+		return *x.MetricsField
+	}
+	return Default_SurfacerConf_MetricsField
+}
+
+func (x *SurfacerConf) GetMetricsBatchSize() int32 {
+	if x != nil && x.MetricsBatchSize != nil {
+		return *x.MetricsBatchSize
+	}
+	return Default_SurfacerConf_MetricsBatchSize
+}
+
+func (x *SurfacerConf) GetBatchTimerSec() int32 {
+	if x != nil && x.BatchTimerSec != nil {
+		return *x.BatchTimerSec
+	}
+	return Default_SurfacerConf_BatchTimerSec
+}
+
+func (x *SurfacerConf) GetTimeoutSec() int32 {
+	if x != nil && x.TimeoutSec != nil {
+		return *x.TimeoutSec
+	}
+	return Default_SurfacerConf_TimeoutSec
+}
+
+func (x *SurfacerConf) GetMaxRetries() int32 {
+	if x != nil && x.MaxRetries != nil {
+		return *x.MaxRetries
+	}
+	return Default_SurfacerConf_MaxRetries
+}
+
+func (x *SurfacerConf) GetInitialBackoffMsec() int32 {
+	if x != nil && x.InitialBackoffMsec != nil {
+		return *x.InitialBackoffMsec
+	}
+	return Default_SurfacerConf_InitialBackoffMsec
+}
+
+func (x *SurfacerConf) GetStatsExportIntervalSec() int32 {
+	if x != nil && x.StatsExportIntervalSec != nil {
+		return *x.StatsExportIntervalSec
+	}
+	return Default_SurfacerConf_StatsExportIntervalSec
+}
+
+var File_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto protoreflect.FileDescriptor
+
+var file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDesc = []byte{
+	0x0a, 0x53, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6f, 0x70, 0x65, 0x6e,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x1a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6c, 0x73, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x84, 0x06, 0x0a, 0x0c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e,
+	0x66, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x12, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2d, 0x40, 0x64, 0x61, 0x74, 0x65, 0x40, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x36, 0x0a, 0x11, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0a,
+	0x32, 0x30, 0x30, 0x36, 0x2e, 0x30, 0x31, 0x2e, 0x30, 0x32, 0x52, 0x0f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x44, 0x61, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x61, 0x70, 0x69, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x70, 0x69, 0x4b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x0a, 0x74, 0x6c, 0x73,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x74, 0x6c, 0x73, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52,
+	0x09, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x33, 0x0a, 0x0f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x3a, 0x0a, 0x40, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0e, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x29, 0x0a, 0x0c, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x0b, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x2c, 0x0a, 0x0d, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x3a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x0c, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x32, 0x0a, 0x12, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x05, 0x3a, 0x04, 0x31, 0x30, 0x30, 0x30, 0x52, 0x10, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2a, 0x0a, 0x0f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x54, 0x69, 0x6d, 0x65, 0x72, 0x53, 0x65, 0x63, 0x12, 0x23, 0x0a, 0x0b, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x33,
+	0x30, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x12, 0x22, 0x0a,
+	0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x3a, 0x01, 0x33, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x35, 0x0a, 0x14, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x62, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x73, 0x65, 0x63, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x3a,
+	0x03, 0x35, 0x30, 0x30, 0x52, 0x12, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x61, 0x63,
+	0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x65, 0x63, 0x12, 0x3d, 0x0a, 0x19, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x36, 0x30, 0x52,
+	0x16, 0x73, 0x74, 0x61, 0x74, 0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x42, 0x48, 0x5a, 0x46, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f,
+}
+
+var (
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescOnce sync.Once
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescData = file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDesc
+)
+
+func file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescGZIP() []byte {
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescOnce.Do(func() {
+		file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescData)
+	})
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDescData
+}
+
+var file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_goTypes = []any{
+	(*SurfacerConf)(nil),    // 0: cloudprober.surfacer.opensearch.SurfacerConf
+	(*proto.TLSConfig)(nil), // 1: cloudprober.tlsconfig.TLSConfig
+}
+var file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_depIdxs = []int32{
+	1, // 0: cloudprober.surfacer.opensearch.SurfacerConf.tls_config:type_name -> cloudprober.tlsconfig.TLSConfig
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() {
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_init()
+}
+func file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_init() {
+	if File_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerConf); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_goTypes,
+		DependencyIndexes: file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_depIdxs,
+		MessageInfos:      file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_msgTypes,
+	}.Build()
+	File_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto = out.File
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_rawDesc = nil
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_goTypes = nil
+	file_github_com_cloudprober_cloudprober_surfacers_internal_opensearch_proto_config_proto_depIdxs = nil
+}
@@ -57,6 +57,7 @@ type OtelSurfacer struct {
 	mu           sync.Mutex
 	scopeMetrics map[string]*metricdata.ScopeMetrics
 
+	reader    *metric.PeriodicReader
 	startTime time.Time
 }
 
@@ -169,6 +170,7 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	// from the producer and exports to the exporter.
 	exportInterval := time.Second * time.Duration(config.GetExportIntervalSec())
 	r := metric.NewPeriodicReader(exp, metric.WithProducer(os), metric.WithInterval(exportInterval))
+	os.reader = r
 
 	var attrKVs []attribute.KeyValue
 	for _, attr := range config.GetResourceAttribute() {
@@ -188,6 +190,12 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	return os, nil
 }
 
+// Flush exports the metrics written so far, without waiting for the next
+// export interval. It implements surfacers.Flusher.
+func (os *OtelSurfacer) Flush(ctx context.Context) error {
+	return os.reader.ForceFlush(ctx)
+}
+
 func (os *OtelSurfacer) Produce(_ context.Context) ([]metricdata.ScopeMetrics, error) {
 	os.mu.Lock()
 	defer os.mu.Unlock()
@@ -16,9 +16,12 @@ package otel
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/otel/proto"
 	"github.com/stretchr/testify/assert"
@@ -226,3 +229,32 @@ func TestGetExporterType(t *testing.T) {
 		})
 	}
 }
+
+func TestOtelSurfacerFlush(t *testing.T) {
+	reqCnt := make(chan struct{}, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCnt <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	os, err := New(context.Background(), &configpb.SurfacerConf{
+		Exporter: &configpb.SurfacerConf_OtlpHttpExporter{
+			OtlpHttpExporter: &configpb.HTTPExporter{
+				EndpointUrl: proto.String(ts.URL + "/v1/metrics"),
+			},
+		},
+		// Long export interval, metrics are exported only on flush.
+		ExportIntervalSec: proto.Int32(3600),
+	}, nil, &logger.Logger{})
+	assert.NoError(t, err)
+
+	os.Write(context.Background(), testEMs(time.Now())[0])
+	assert.NoError(t, os.Flush(context.Background()))
+
+	select {
+	case <-reqCnt:
+	default:
+		t.Errorf("no export request received after flush")
+	}
+}
@@ -37,7 +37,9 @@ import (
 
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	"github.com/jackc/pgx/v5"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/postgres/proto"
@@ -217,6 +219,8 @@ func (s *Surfacer) init(ctx context.Context) error {
 		return err
 	}
 	s.writeChan = make(chan *metrics.EventMetrics, s.c.GetMetricsBufferSize())
+	s.queue = queue.New(s.writeChan)
+	s.flushChan = flush.NewChan()
 
 	// Generate the desired columns either with 'labels' by default
 	// or select 'labels' based on the label_to_column fields
@@ -235,6 +239,13 @@ func (s *Surfacer) init(ctx context.Context) error {
 		flushTicker := time.NewTicker(flushInterval)
 		defer flushTicker.Stop()
 
+		writeBuffer := func() {
+			if err := s.writeMetrics(ctx, buffer); err != nil {
+				s.l.Warningf("Error while writing metrics: %v", err)
+			}
+			buffer = buffer[:0]
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
@@ -246,19 +257,23 @@ func (s *Surfacer) init(ctx context.Context) error {
 				}
 				buffer = append(buffer, em)
 				if int32(len(buffer)) >= metricsBatchSize {
-					if err := s.writeMetrics(ctx, buffer); err != nil {
-						s.l.Warningf("Error while writing metrics: %v", err)
-					}
-					buffer = buffer[:0]
+					writeBuffer()
 					flushTicker.Reset(flushInterval)
 				}
 			case <-flushTicker.C:
 				if len(buffer) > 0 {
-					if err := s.writeMetrics(ctx, buffer); err != nil {
-						s.l.Warningf("Error while writing metrics: %v", err)
+					writeBuffer()
+				}
+			case done := <-s.flushChan:
+				for len(s.writeChan) > 0 {
+					if em := <-s.writeChan; em.Kind == metrics.CUMULATIVE || em.Kind == metrics.GAUGE {
+						buffer = append(buffer, em)
 					}
-					buffer = buffer[:0]
 				}
+				if len(buffer) > 0 {
+					writeBuffer()
+				}
+				close(done)
 			}
 		}
 	}()
@@ -268,13 +283,37 @@ func (s *Surfacer) init(ctx context.Context) error {
 
 // Write takes the data to be written
 func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case s.writeChan <- em:
-	default:
+	if !s.queue.Enqueue(em) {
 		s.l.Errorf("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
+// Flush writes the buffered EventMetrics to the database.
+func (s *Surfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, s.flushChan)
+}
+
+// WriteBatch writes the EventMetrics synchronously and returns the error, if
+// any. It's used by the disk buffer to retry failed writes. Note that with the
+// disk buffer, Write is not called, and the batching write loop stays idle.
+func (s *Surfacer) WriteBatch(ctx context.Context, ems []*metrics.EventMetrics) error {
+	var batch []*metrics.EventMetrics
+	for _, em := range ems {
+		if em.Kind == metrics.CUMULATIVE || em.Kind == metrics.GAUGE {
+			batch = append(batch, em)
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return s.writeMetrics(ctx, batch)
+}
+
 // generateValues generates column values or places NULL
 // in the event label/value does not exist
 func generateValues(labels map[string]string, ltc []*configpb.LabelToColumn) []any {
@@ -315,6 +354,10 @@ type Surfacer struct {
 
 	// Channel for incoming data.
 	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+
+	// Channel for the flush requests.
+	flushChan chan chan struct{}
 
 	// Cloud logger
 	l *logger.Logger
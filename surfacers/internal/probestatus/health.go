@@ -0,0 +1,128 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
+)
+
+type healthGroup struct {
+	name            string
+	probes          []string
+	targetRe        *regexp.Regexp
+	minSuccessRatio float64
+	maxUnhealthy    int
+	window          time.Duration
+}
+
+type healthStatus struct {
+	Group     string   `json:"group"`
+	Healthy   bool     `json:"healthy"`
+	Unhealthy []string `json:"unhealthy"`
+}
+
+func newHealthGroups(c *configpb.SurfacerConf, resolution time.Duration) (map[string]*healthGroup, error) {
+	groups := make(map[string]*healthGroup)
+	for _, hgc := range c.GetHealthGroup() {
+		if groups[hgc.GetName()] != nil {
+			return nil, fmt.Errorf("duplicate health group: %s", hgc.GetName())
+		}
+		if len(hgc.GetProbe()) == 0 {
+			return nil, fmt.Errorf("health group (%s) has no probes", hgc.GetName())
+		}
+
+		hg := &healthGroup{
+			name:            hgc.GetName(),
+			probes:          hgc.GetProbe(),
+			minSuccessRatio: float64(hgc.GetMinSuccessRatio()),
+			maxUnhealthy:    int(hgc.GetMaxUnhealthy()),
+			window:          time.Duration(hgc.GetWindowSec()) * time.Second,
+		}
+		if hg.window < resolution {
+			return nil, fmt.Errorf("health group (%s) window_sec (%d) is less than resolution_sec (%v)", hg.name, hgc.GetWindowSec(), resolution)
+		}
+		if hgc.GetTargetRegex() != "" {
+			var err error
+			if hg.targetRe, err = regexp.Compile(hgc.GetTargetRegex()); err != nil {
+				return nil, fmt.Errorf("health group (%s) has invalid target_regex: %v", hg.name, err)
+			}
+		}
+		groups[hg.name] = hg
+	}
+	return groups, nil
+}
+
+// healthStatus computes the group's health using the probes' timeseries.
+// Group with no members is considered unhealthy.
+func (ps *Surfacer) healthStatus(hg *healthGroup) *healthStatus {
+	hs := &healthStatus{Group: hg.name, Unhealthy: []string{}}
+
+	numMembers := 0
+	for _, probeName := range hg.probes {
+		targets := ps.probeTargets[probeName]
+		if len(targets) == 0 {
+			hs.Unhealthy = append(hs.Unhealthy, probeName)
+			continue
+		}
+		for _, targetName := range targets {
+			if hg.targetRe != nil && !hg.targetRe.MatchString(targetName) {
+				continue
+			}
+			numMembers++
+
+			ts := ps.metrics[probeName][targetName]
+			if ts == nil {
+				hs.Unhealthy = append(hs.Unhealthy, probeName+"/"+targetName)
+				continue
+			}
+			total, success := ts.computeDelta(hg.window)
+			if total <= 0 || float64(success)/float64(total) < hg.minSuccessRatio {
+				hs.Unhealthy = append(hs.Unhealthy, probeName+"/"+targetName)
+			}
+		}
+	}
+
+	hs.Healthy = numMembers > 0 && len(hs.Unhealthy) <= hg.maxUnhealthy
+	return hs
+}
+
+func (ps *Surfacer) writeHealth(hw *httpWriter) {
+	name := strings.TrimPrefix(hw.r.URL.Path, ps.c.GetHealthUrl()+"/")
+	hg := ps.healthGroups[name]
+	if hg == nil {
+		http.Error(hw.w, fmt.Sprintf("unknown health group: %s", name), http.StatusNotFound)
+		return
+	}
+
+	hs := ps.healthStatus(hg)
+	b, err := json.Marshal(hs)
+	if err != nil {
+		http.Error(hw.w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hw.w.Header().Set("Content-Type", "application/json")
+	if !hs.Healthy {
+		hw.w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	hw.w.Write(b)
+}
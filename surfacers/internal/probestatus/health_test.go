@@ -0,0 +1,133 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probestatus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewHealthGroups(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		hgs     []*configpb.SurfacerConf_HealthGroup
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			hgs: []*configpb.SurfacerConf_HealthGroup{
+				{Name: proto.String("g1"), Probe: []string{"p1"}},
+				{Name: proto.String("g2"), Probe: []string{"p1"}, TargetRegex: proto.String("fe.*")},
+			},
+		},
+		{
+			name:    "no-probes",
+			hgs:     []*configpb.SurfacerConf_HealthGroup{{Name: proto.String("g1")}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate",
+			hgs: []*configpb.SurfacerConf_HealthGroup{
+				{Name: proto.String("g1"), Probe: []string{"p1"}},
+				{Name: proto.String("g1"), Probe: []string{"p2"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "small-window",
+			hgs:     []*configpb.SurfacerConf_HealthGroup{{Name: proto.String("g1"), Probe: []string{"p1"}, WindowSec: proto.Int32(10)}},
+			wantErr: true,
+		},
+		{
+			name:    "bad-regex",
+			hgs:     []*configpb.SurfacerConf_HealthGroup{{Name: proto.String("g1"), Probe: []string{"p1"}, TargetRegex: proto.String("(")}},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := newHealthGroups(&configpb.SurfacerConf{HealthGroup: tt.hgs}, time.Minute)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, groups, len(tt.hgs))
+		})
+	}
+}
+
+func TestHealthGroupHandler(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	mux := http.NewServeMux()
+	ps, err := New(ctx, &configpb.SurfacerConf{
+		HealthGroup: []*configpb.SurfacerConf_HealthGroup{
+			{Name: proto.String("all"), Probe: []string{"p1", "p2"}},
+			{Name: proto.String("tolerant"), Probe: []string{"p1", "p2"}, MaxUnhealthy: proto.Int32(1)},
+			{Name: proto.String("p1-fe"), Probe: []string{"p1"}, TargetRegex: proto.String("^fe")},
+			{Name: proto.String("missing"), Probe: []string{"p3"}, MaxUnhealthy: proto.Int32(1)},
+		},
+	}, &options.Options{HTTPServeMux: mux}, nil)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	for _, d := range []struct {
+		probe, target string
+		success       int64
+	}{
+		{"p1", "fe1", 10},
+		{"p1", "be1", 5},
+		{"p2", "fe1", 10},
+	} {
+		for i, ts := range []time.Time{now.Add(-2 * time.Minute), now} {
+			ps.record(metrics.NewEventMetrics(ts).
+				AddLabel("probe", d.probe).
+				AddLabel("dst", d.target).
+				AddMetric("total", metrics.NewInt(int64(i*10))).
+				AddMetric("success", metrics.NewInt(int64(i)*d.success)))
+		}
+	}
+
+	for _, tt := range []struct {
+		group    string
+		wantCode int
+		wantBody string
+	}{
+		{"all", http.StatusServiceUnavailable, `{"group":"all","healthy":false,"unhealthy":["p1/be1"]}`},
+		{"tolerant", http.StatusOK, `{"group":"tolerant","healthy":true,"unhealthy":["p1/be1"]}`},
+		{"p1-fe", http.StatusOK, `{"group":"p1-fe","healthy":true,"unhealthy":[]}`},
+		{"missing", http.StatusServiceUnavailable, `{"group":"missing","healthy":false,"unhealthy":["p3"]}`},
+		{"unknown", http.StatusNotFound, ""},
+	} {
+		t.Run(tt.group, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", "/status/health/"+tt.group, nil))
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
 	"github.com/cloudprober/cloudprober/web/resources"
 	"github.com/cloudprober/cloudprober/web/webutils"
@@ -60,6 +61,9 @@ type httpWriter struct {
 	w        http.ResponseWriter
 	r        *http.Request
 	doneChan chan struct{}
+
+	// Set for the health group queries.
+	health bool
 }
 
 type pageCache struct {
@@ -99,6 +103,7 @@ type Surfacer struct {
 	c         *configpb.SurfacerConf // Configuration
 	opts      *options.Options
 	emChan    chan *metrics.EventMetrics // Buffered channel to store incoming EventMetrics
+	queue     *queue.Queue               // Stats of the emChan
 	queryChan chan *httpWriter           // Query channel
 	l         *logger.Logger
 
@@ -106,6 +111,7 @@ type Surfacer struct {
 	metrics      map[string]map[string]*timeseries
 	probeNames   []string
 	probeTargets map[string][]string
+	healthGroups map[string]*healthGroup
 
 	// Dashboard page cache.
 	pageCache *pageCache
@@ -149,10 +155,16 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		resolution: res,
 		l:          l,
 	}
+	ps.queue = queue.New(ps.emChan)
 
 	ps.dashDurations, ps.dashDurationsText = dashboardDurations(ps.resolution * time.Duration(ps.c.GetTimeseriesSize()))
 	ps.pageCache = newPageCache(int(ps.c.GetCacheTimeSec()))
 
+	var err error
+	if ps.healthGroups, err = newHealthGroups(config, ps.resolution); err != nil {
+		return nil, err
+	}
+
 	// Start a goroutine to process the incoming EventMetrics as well as
 	// the incoming web queries. To avoid data access race conditions, we do
 	// one thing at a time.
@@ -165,7 +177,11 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 			case em := <-ps.emChan:
 				ps.record(em)
 			case hw := <-ps.queryChan:
-				ps.writeData(hw)
+				if hw.health {
+					ps.writeHealth(hw)
+				} else {
+					ps.writeData(hw)
+				}
 				close(hw.doneChan)
 			}
 		}
@@ -175,10 +191,18 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		// doneChan is used to track the completion of the response writing. This is
 		// required as response is written in a different goroutine.
 		doneChan := make(chan struct{}, 1)
-		ps.queryChan <- &httpWriter{w, r, doneChan}
+		ps.queryChan <- &httpWriter{w: w, r: r, doneChan: doneChan}
 		<-doneChan
 	})
 
+	if len(ps.healthGroups) != 0 {
+		opts.HTTPServeMux.HandleFunc(config.GetHealthUrl()+"/", func(w http.ResponseWriter, r *http.Request) {
+			doneChan := make(chan struct{}, 1)
+			ps.queryChan <- &httpWriter{w: w, r: r, doneChan: doneChan, health: true}
+			<-doneChan
+		})
+	}
+
 	if !webutils.IsHandled(opts.HTTPServeMux, "/probestatus") {
 		opts.HTTPServeMux.Handle("/probestatus", http.RedirectHandler(config.GetUrl(), http.StatusFound))
 	}
@@ -207,13 +231,16 @@ func (ps *Surfacer) Write(_ context.Context, em *metrics.EventMetrics) {
 		return
 	}
 
-	select {
-	case ps.emChan <- em:
-	default:
+	if !ps.queue.Enqueue(em) {
 		ps.l.Errorf("Surfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (ps *Surfacer) Queue() *queue.Queue {
+	return ps.queue
+}
+
 // record processes the incoming EventMetrics and updates the in-memory
 // database.
 func (ps *Surfacer) record(em *metrics.EventMetrics) {
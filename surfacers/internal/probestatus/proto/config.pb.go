@@ -41,7 +41,10 @@ type SurfacerConf struct {
 	CacheTimeSec *int32 `protobuf:"varint,5,opt,name=cache_time_sec,json=cacheTimeSec,def=2" json:"cache_time_sec,omitempty"`
 	// Probestatus surfacer is enabled by default. To disable it, set this
 	// option.
-	Disable *bool `protobuf:"varint,6,opt,name=disable" json:"disable,omitempty"`
+	Disable     *bool                       `protobuf:"varint,6,opt,name=disable" json:"disable,omitempty"`
+	HealthGroup []*SurfacerConf_HealthGroup `protobuf:"bytes,7,rep,name=health_group,json=healthGroup" json:"health_group,omitempty"`
+	// Base URL for the health groups.
+	HealthUrl *string `protobuf:"bytes,8,opt,name=health_url,json=healthUrl,def=/status/health" json:"health_url,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -51,6 +54,7 @@ const (
 	Default_SurfacerConf_MaxTargetsPerProbe = int32(20)
 	Default_SurfacerConf_Url                = string("/status")
 	Default_SurfacerConf_CacheTimeSec       = int32(2)
+	Default_SurfacerConf_HealthUrl          = string("/status/health")
 )
 
 func (x *SurfacerConf) Reset() {
@@ -127,6 +131,132 @@ func (x *SurfacerConf) GetDisable() bool {
 	return false
 }
 
+func (x *SurfacerConf) GetHealthGroup() []*SurfacerConf_HealthGroup {
+	if x != nil {
+		return x.HealthGroup
+	}
+	return nil
+}
+
+func (x *SurfacerConf) GetHealthUrl() string {
+	if x != nil && x.HealthUrl != nil {
+		return *x.HealthUrl
+	}
+	return Default_SurfacerConf_HealthUrl
+}
+
+// Health groups map a group of probes (and optionally targets) to a single
+// up/down result, exported at the URL: <health_url>/<group name>. The
+// URL returns 200 if the group is healthy and 503 otherwise, along with a
+// short JSON body listing the unhealthy members, e.g.:
+//
+//	{"group":"frontend","healthy":false,"unhealthy":["http_fe/fe1"]}
+//
+// A member (probe, target) is unhealthy if its success ratio over the
+// last window_sec is below min_success_ratio, or if there is no data for
+// it. Note that the surfacer tracks up to max_targets_per_probe targets
+// per probe.
+type SurfacerConf_HealthGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name *string `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	// Probes in the group.
+	Probe []string `protobuf:"bytes,2,rep,name=probe" json:"probe,omitempty"`
+	// If specified, only the targets matching this regex are included.
+	TargetRegex *string `protobuf:"bytes,3,opt,name=target_regex,json=targetRegex" json:"target_regex,omitempty"`
+	// Minimum success ratio for a member to be considered healthy.
+	MinSuccessRatio *float32 `protobuf:"fixed32,4,opt,name=min_success_ratio,json=minSuccessRatio,def=1" json:"min_success_ratio,omitempty"`
+	// Group is healthy as long as the number of unhealthy members is not
+	// more than this number.
+	MaxUnhealthy *int32 `protobuf:"varint,5,opt,name=max_unhealthy,json=maxUnhealthy,def=0" json:"max_unhealthy,omitempty"`
+	// Window to compute the success ratio over. It should not be less
+	// than resolution_sec.
+	WindowSec *int32 `protobuf:"varint,6,opt,name=window_sec,json=windowSec,def=300" json:"window_sec,omitempty"`
+}
+
+// Default values for SurfacerConf_HealthGroup fields.
+const (
+	Default_SurfacerConf_HealthGroup_MinSuccessRatio = float32(1)
+	Default_SurfacerConf_HealthGroup_MaxUnhealthy    = int32(0)
+	Default_SurfacerConf_HealthGroup_WindowSec       = int32(300)
+)
+
+func (x *SurfacerConf_HealthGroup) Reset() {
+	*x = SurfacerConf_HealthGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerConf_HealthGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerConf_HealthGroup) ProtoMessage() {}
+
+func (x *SurfacerConf_HealthGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerConf_HealthGroup.ProtoReflect.Descriptor instead.
+func (*SurfacerConf_HealthGroup) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *SurfacerConf_HealthGroup) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *SurfacerConf_HealthGroup) GetProbe() []string {
+	if x != nil {
+		return x.Probe
+	}
+	return nil
+}
+
+func (x *SurfacerConf_HealthGroup) GetTargetRegex() string {
+	if x != nil && x.TargetRegex != nil {
+		return *x.TargetRegex
+	}
+	return ""
+}
+
+func (x *SurfacerConf_HealthGroup) GetMinSuccessRatio() float32 {
+	if x != nil && x.MinSuccessRatio != nil {
+		return *x.MinSuccessRatio
+	}
+	return Default_SurfacerConf_HealthGroup_MinSuccessRatio
+}
+
+func (x *SurfacerConf_HealthGroup) GetMaxUnhealthy() int32 {
+	if x != nil && x.MaxUnhealthy != nil {
+		return *x.MaxUnhealthy
+	}
+	return Default_SurfacerConf_HealthGroup_MaxUnhealthy
+}
+
+func (x *SurfacerConf_HealthGroup) GetWindowSec() int32 {
+	if x != nil && x.WindowSec != nil {
+		return *x.WindowSec
+	}
+	return Default_SurfacerConf_HealthGroup_WindowSec
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_rawDesc = []byte{
@@ -137,7 +267,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto
 	0x74, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x20, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
 	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xfd, 0x01, 0x0a, 0x0c, 0x53, 0x75, 0x72,
+	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xe3, 0x04, 0x0a, 0x0c, 0x53, 0x75, 0x72,
 	0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x29, 0x0a, 0x0e, 0x72, 0x65, 0x73,
 	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x05, 0x3a, 0x02, 0x36, 0x30, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f,
@@ -153,12 +283,34 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto
 	0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x32,
 	0x52, 0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x12, 0x18,
 	0x0a, 0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f,
+	0x07, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x5d, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x2d, 0x0a, 0x0a, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0e, 0x2f, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x09, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x55, 0x72, 0x6c, 0x1a, 0xd5, 0x01, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65,
+	0x67, 0x65, 0x78, 0x12, 0x2d, 0x0a, 0x11, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x3a, 0x01,
+	0x31, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x61, 0x74,
+	0x69, 0x6f, 0x12, 0x26, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x30, 0x52, 0x0c, 0x6d, 0x61,
+	0x78, 0x55, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x12, 0x22, 0x0a, 0x0a, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03,
+	0x33, 0x30, 0x30, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x65, 0x63, 0x42, 0x49,
+	0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -173,16 +325,18 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_prot
 	return file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_goTypes = []any{
-	(*SurfacerConf)(nil), // 0: cloudprober.surfacer.probestatus.SurfacerConf
+	(*SurfacerConf)(nil),             // 0: cloudprober.surfacer.probestatus.SurfacerConf
+	(*SurfacerConf_HealthGroup)(nil), // 1: cloudprober.surfacer.probestatus.SurfacerConf.HealthGroup
 }
 var file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: cloudprober.surfacer.probestatus.SurfacerConf.health_group:type_name -> cloudprober.surfacer.probestatus.SurfacerConf.HealthGroup
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() {
@@ -205,6 +359,18 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_prot
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerConf_HealthGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -212,7 +378,7 @@ func file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_prot
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_internal_probestatus_proto_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -33,6 +33,7 @@ rcvd{ptype="ping",probe="vm-to-public-dns",dst="8.8.4.4"} 362600 1497330037000
 package prometheus
 
 import (
+	"compress/gzip"
 	"context"
 	"flag"
 	"fmt"
@@ -46,6 +47,7 @@ import (
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/prometheus/proto"
 )
 
@@ -62,7 +64,10 @@ const (
 	ValidLabelNameRegex  = "^[a-zA-Z_]([a-zA-Z0-9_])*$"
 )
 
-const histogram = "histogram"
+const (
+	histogram = "histogram"
+	summary   = "summary"
+)
 
 // queriesQueueSize defines how many queries can we queue before we start
 // blocking on previous queries to finish.
@@ -98,7 +103,7 @@ type dataPoint struct {
 // httpWriter is a wrapper for http.ResponseWriter that includes a channel
 // to signal the completion of the writing of the response.
 type httpWriter struct {
-	w        http.ResponseWriter
+	w        io.Writer
 	doneChan chan struct{}
 }
 
@@ -115,6 +120,7 @@ type PromSurfacer struct {
 	opts        *options.Options
 	prefix      string                     // Metrics prefix, e.g. "cloudprober_"
 	emChan      chan *metrics.EventMetrics // Buffered channel to store incoming EventMetrics
+	queue       *queue.Queue               // Stats of the emChan
 	metrics     map[string]*promMetric     // Metric name to promMetric mapping
 	metricNames []string                   // Metric names, to keep names ordered.
 	queryChan   chan *httpWriter           // Query channel
@@ -146,6 +152,7 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		labelNameRe:  regexp.MustCompile(ValidLabelNameRegex),
 		l:            l,
 	}
+	ps.queue = queue.New(ps.emChan)
 
 	if *metricsPrefix != "" && ps.c.MetricsPrefix != nil {
 		return nil, fmt.Errorf("both --prometheus_metrics_prefix and config metrics_prefix are set, you can set only one of them")
@@ -190,10 +197,24 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 	}()
 
 	opts.HTTPServeMux.HandleFunc(ps.c.GetMetricsUrl(), func(w http.ResponseWriter, r *http.Request) {
+		// We always serve the text exposition format, which is accepted by
+		// the scrapers negotiating OpenMetrics as well. Content-Type is set
+		// explicitly as it can't be sniffed from the compressed output.
+		w.Header().Set("Content-Type", textFormatContentType)
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		var out io.Writer = w
+		if acceptsGzip(r) {
+			w.Header().Set("Content-Encoding", "gzip")
+			gw := gzip.NewWriter(w)
+			defer gw.Close()
+			out = gw
+		}
+
 		// doneChan is used to track the completion of the response writing. This is
 		// required as response is written in a different goroutine.
 		doneChan := make(chan struct{}, 1)
-		ps.queryChan <- &httpWriter{w, doneChan}
+		ps.queryChan <- &httpWriter{out, doneChan}
 		<-doneChan
 	})
 
@@ -205,13 +226,16 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 // goroutine that actually processes the data and updates the in-memory
 // database.
 func (ps *PromSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
-	select {
-	case ps.emChan <- em:
-	default:
+	if !ps.queue.Enqueue(em) {
 		ps.l.Errorf("PromSurfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (ps *PromSurfacer) Queue() *queue.Queue {
+	return ps.queue
+}
+
 func promType(em *metrics.EventMetrics) string {
 	switch em.Kind {
 	case metrics.CUMULATIVE:
@@ -389,6 +413,24 @@ func (ps *PromSurfacer) record(em *metrics.EventMetrics) {
 				labelsWithBucket := append(labels, "le=\""+lb+"\"")
 				ps.recordMetric(pMetricName, dataKey(pMetricName+"_bucket", labelsWithBucket), strconv.FormatInt(val, 10), em, histogram)
 			}
+			// Configured percentiles are exported as a separate gauge metric
+			// with extra label "percentile".
+			if len(d.Percentiles) > 0 && d.Count > 0 {
+				pctMetricName := pMetricName + "_percentile"
+				for _, pct := range d.Percentiles {
+					labelsWithPct := append(labels, "percentile=\""+metrics.FormatPercentile(pct)+"\"")
+					ps.recordMetric(pctMetricName, dataKey(pctMetricName, labelsWithPct), strconv.FormatFloat(d.Percentile(pct), 'f', -1, 64), em, "gauge")
+				}
+			}
+		// Summary values get expanded into metrics with extra label "quantile".
+		case *metrics.Summary:
+			d := v.Data()
+			ps.recordMetric(pMetricName, dataKey(pMetricName+"_sum", labels), strconv.FormatFloat(d.Sum, 'f', -1, 64), em, summary)
+			ps.recordMetric(pMetricName, dataKey(pMetricName+"_count", labels), strconv.FormatInt(d.Count, 10), em, summary)
+			for i, q := range d.Quantiles {
+				labelsWithQuantile := append(labels, "quantile=\""+strconv.FormatFloat(q, 'f', -1, 64)+"\"")
+				ps.recordMetric(pMetricName, dataKey(pMetricName, labelsWithQuantile), strconv.FormatFloat(d.QuantileValues[i], 'f', -1, 64), em, summary)
+			}
 		case metrics.String:
 			newLabels := append(labels, "val="+val.String())
 			ps.recordMetric(pMetricName, dataKey(pMetricName, newLabels), "1", em, "")
@@ -400,6 +442,31 @@ func (ps *PromSurfacer) record(em *metrics.EventMetrics) {
 	}
 }
 
+// textFormatContentType is the content type of the Prometheus text
+// exposition format.
+const textFormatContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// acceptsGzip returns true if the request's Accept-Encoding header allows
+// gzip encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept-Encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			coding, params, _ := strings.Cut(enc, ";")
+			if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+				continue
+			}
+			// "gzip;q=0" means gzip is not acceptable.
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if f, err := strconv.ParseFloat(q, 64); err == nil && f == 0 {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
 // writeData writes metrics data on w io.Writer
 func (ps *PromSurfacer) writeData(w io.Writer) {
 	for _, name := range ps.metricNames {
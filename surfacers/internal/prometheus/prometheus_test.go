@@ -16,18 +16,23 @@ package prometheus
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/cloudprober/cloudprober/metrics"
+	distpb "github.com/cloudprober/cloudprober/metrics/proto"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/prometheus/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -217,6 +222,124 @@ func TestScrapeOutput(t *testing.T) {
 	}
 }
 
+func TestScrapeCompression(t *testing.T) {
+	mux := http.NewServeMux()
+	ps, err := New(context.Background(), &configpb.SurfacerConf{}, &options.Options{HTTPServeMux: mux}, nil)
+	require.NoError(t, err)
+	ps.record(metrics.NewEventMetrics(time.Now()).
+		AddMetric("sent", metrics.NewInt(32)).
+		AddLabel("ptype", "http"))
+
+	tests := []struct {
+		acceptEncoding string
+		accept         string
+		wantGzip       bool
+	}{
+		{acceptEncoding: "", wantGzip: false},
+		{acceptEncoding: "gzip", wantGzip: true},
+		{acceptEncoding: "deflate, GZIP;q=0.5", wantGzip: true},
+		{acceptEncoding: "gzip;q=0, identity", wantGzip: false},
+		{acceptEncoding: "br", wantGzip: false},
+		{
+			// Prometheus scraper's headers, negotiating OpenMetrics.
+			acceptEncoding: "gzip",
+			accept:         "application/openmetrics-text;version=1.0.0,application/openmetrics-text;version=0.0.1;q=0.75,text/plain;version=0.0.4;q=0.5,*/*;q=0.1",
+			wantGzip:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.acceptEncoding+"_"+test.accept, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			req.Header.Set("Accept-Encoding", test.acceptEncoding)
+			req.Header.Set("Accept", test.accept)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", resp.Header.Get("Content-Type"))
+			assert.Equal(t, "Accept-Encoding", resp.Header.Get("Vary"))
+
+			var body io.Reader = resp.Body
+			if test.wantGzip {
+				assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+				gr, err := gzip.NewReader(resp.Body)
+				require.NoError(t, err)
+				body = gr
+			} else {
+				assert.Empty(t, resp.Header.Get("Content-Encoding"))
+			}
+			b, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Contains(t, string(b), "# TYPE sent counter\nsent{ptype=\"http\"} 32")
+		})
+	}
+}
+
+func TestScrapeOutputPercentiles(t *testing.T) {
+	ps := testPromSurfacerNoErr(t, nil)
+	latencyVal, err := metrics.NewDistributionFromProto(&distpb.Dist{
+		Buckets:     &distpb.Dist_ExplicitBuckets{ExplicitBuckets: "1,4"},
+		Percentiles: []float64{50, 75},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	latencyVal.AddSample(2)
+	latencyVal.AddSample(3)
+	ts := time.Now()
+	promTS := fmt.Sprintf("%d", ts.UnixNano()/(1000*1000))
+	ps.record(metrics.NewEventMetrics(ts).
+		AddMetric("latency", latencyVal).
+		AddLabel("ptype", "http"))
+	var b bytes.Buffer
+	ps.writeData(&b)
+	data := b.String()
+	for _, d := range []string{
+		"# TYPE latency histogram",
+		"# TYPE latency_percentile gauge",
+		"latency_bucket{ptype=\"http\",le=\"4\"} 2 " + promTS,
+		"latency_percentile{ptype=\"http\",percentile=\"50\"} 2.5 " + promTS,
+		"latency_percentile{ptype=\"http\",percentile=\"75\"} 3.25 " + promTS,
+	} {
+		if !strings.Contains(data, d) {
+			t.Errorf("String \"%s\" not found in output data: %s", d, data)
+		}
+	}
+}
+
+func TestScrapeOutputSummary(t *testing.T) {
+	ps := testPromSurfacerNoErr(t, nil)
+	latencyVal, err := metrics.NewSummaryFromProto(&distpb.Summary{
+		Quantiles: []float64{0.5, 0.9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []float64{1, 2, 3, 4} {
+		latencyVal.AddFloat64(v)
+	}
+	ts := time.Now()
+	promTS := fmt.Sprintf("%d", ts.UnixNano()/(1000*1000))
+	ps.record(metrics.NewEventMetrics(ts).
+		AddMetric("latency", latencyVal).
+		AddLabel("ptype", "http"))
+	var b bytes.Buffer
+	ps.writeData(&b)
+	data := b.String()
+	for _, d := range []string{
+		"# TYPE latency summary",
+		"latency_sum{ptype=\"http\"} 10 " + promTS,
+		"latency_count{ptype=\"http\"} 4 " + promTS,
+		"latency{ptype=\"http\",quantile=\"0.5\"} 2 " + promTS,
+		"latency{ptype=\"http\",quantile=\"0.9\"} 4 " + promTS,
+	} {
+		if !strings.Contains(data, d) {
+			t.Errorf("String \"%s\" not found in output data: %s", d, data)
+		}
+	}
+}
+
 func TestScrapeOutputNoTimestamp(t *testing.T) {
 	ps := testPromSurfacerNoErr(t, &configpb.SurfacerConf{IncludeTimestamp: proto.Bool(false)})
 	latencyVal := metrics.NewDistribution([]float64{1, 4})
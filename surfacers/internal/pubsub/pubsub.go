@@ -30,6 +30,7 @@ import (
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/compress"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/pubsub/proto"
 )
@@ -64,6 +65,7 @@ type Surfacer struct {
 
 	// Channel for incoming data.
 	inChan            chan *metrics.EventMetrics
+	queue             *queue.Queue
 	publishResultChan chan *pubsub.PublishResult
 
 	topic      *pubsub.Topic
@@ -117,6 +119,7 @@ func (s *Surfacer) processInput(ctx context.Context) {
 
 func (s *Surfacer) init(ctx context.Context) error {
 	s.inChan = make(chan *metrics.EventMetrics, s.opts.MetricsBufferSize)
+	s.queue = queue.New(s.inChan)
 
 	// We use start timestamp in millisecond as the incarnation id.
 	s.starttime = strconv.FormatInt(time.Now().UnixNano()/(1000*1000), 10)
@@ -204,13 +207,16 @@ func (s *Surfacer) close() {
 // Write queues the incoming data into a channel. This channel is watched by a
 // goroutine that actually publishes it to a pubsub topic.
 func (s *Surfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
-	select {
-	case s.inChan <- em:
-	default:
+	if !s.queue.Enqueue(em) {
 		s.l.Errorf("Surfacer's write channel (capacity: %d) is full, dropping new data.", s.opts.MetricsBufferSize)
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (s *Surfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
 // New initializes a Surfacer for publishing data to a pubsub topic.
 func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
 	s := &Surfacer{
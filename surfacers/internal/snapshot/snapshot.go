@@ -0,0 +1,269 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package snapshot implements a surfacer that serves the current state of all
+metrics as a one-shot JSON snapshot over HTTP. It keeps the latest
+EventMetrics for each unique combination of metric names and labels in
+memory.
+
+Snapshot looks like this:
+
+	{
+	  "timestamp_ms": 1700000000000,
+	  "metrics": [
+	    {
+	      "timestamp_ms": 1699999995000,
+	      "kind": "cumulative",
+	      "latency_unit": "us",
+	      "labels": {"probe": "web", "dst": "example.com"},
+	      "metrics": {
+	        "total": 10,
+	        "resp-code": {"200": 10},
+	        "latency": {
+	          "count": 10, "sum": 2341.5,
+	          "buckets": [{"lower_bound": "-Inf", "count": 0}, ...]
+	        }
+	      }
+	    }
+	  ]
+	}
+*/
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cloudprober/cloudprober/logger"
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/snapshot/proto"
+)
+
+type bucket struct {
+	// Lower bound is a string as the first bucket's lower bound is -Inf,
+	// which can't be represented in JSON as a number.
+	LowerBound string `json:"lower_bound"`
+	Count      int64  `json:"count"`
+}
+
+type distribution struct {
+	Count   int64    `json:"count"`
+	Sum     float64  `json:"sum"`
+	Buckets []bucket `json:"buckets"`
+}
+
+type eventMetrics struct {
+	TimestampMs int64             `json:"timestamp_ms"`
+	Kind        string            `json:"kind"`
+	LatencyUnit string            `json:"latency_unit,omitempty"`
+	Labels      map[string]string `json:"labels"`
+	Metrics     map[string]any    `json:"metrics"`
+}
+
+type snapshot struct {
+	TimestampMs int64           `json:"timestamp_ms"`
+	Metrics     []*eventMetrics `json:"metrics"`
+}
+
+// Surfacer implements the JSON snapshot surfacer.
+type Surfacer struct {
+	c    *configpb.SurfacerConf
+	opts *options.Options
+	l    *logger.Logger
+
+	mu  sync.RWMutex
+	ems map[string]*metrics.EventMetrics
+	// Last update time for each EventMetrics, used for expiration.
+	lastUpdated map[string]time.Time
+}
+
+// New returns a new JSON snapshot surfacer and registers its HTTP handler.
+func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Options, l *logger.Logger) (*Surfacer, error) {
+	if config == nil {
+		config = &configpb.SurfacerConf{}
+	}
+	s := &Surfacer{
+		c:           config,
+		opts:        opts,
+		l:           l,
+		ems:         make(map[string]*metrics.EventMetrics),
+		lastUpdated: make(map[string]time.Time),
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.deleteExpired(time.Now())
+			}
+		}
+	}()
+
+	opts.HTTPServeMux.HandleFunc(s.c.GetUrl(), s.handler)
+
+	l.Infof("Initialized JSON snapshot surfacer at the URL: %s", s.c.GetUrl())
+	return s, nil
+}
+
+// Write records the EventMetrics as the latest state for its metrics and
+// labels combination.
+func (s *Surfacer) Write(_ context.Context, em *metrics.EventMetrics) {
+	key := em.Key()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ems[key] = em.Clone()
+	s.lastUpdated[key] = time.Now()
+}
+
+func (s *Surfacer) deleteExpired(now time.Time) {
+	expiration := time.Duration(s.c.GetMetricsExpirationSec()) * time.Second
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, ts := range s.lastUpdated {
+		if now.Sub(ts) > expiration {
+			delete(s.ems, key)
+			delete(s.lastUpdated, key)
+		}
+	}
+}
+
+func jsonFloat(f float64) any {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return f
+}
+
+func mapValue[T int64 | float64](m *metrics.Map[T]) map[string]any {
+	out := make(map[string]any)
+	for _, k := range m.Keys() {
+		switch v := any(m.GetKey(k)).(type) {
+		case float64:
+			out[k] = jsonFloat(v)
+		default:
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func distValue(d *metrics.Distribution) *distribution {
+	data := d.Data()
+	out := &distribution{Count: data.Count, Sum: data.Sum}
+	for i, lb := range data.LowerBounds {
+		out.Buckets = append(out.Buckets, bucket{
+			LowerBound: strconv.FormatFloat(lb, 'g', -1, 64),
+			Count:      data.BucketCounts[i],
+		})
+	}
+	return out
+}
+
+// jsonValue converts a metric value to a value that can be marshaled to
+// JSON. It returns nil for unsupported values.
+func jsonValue(v metrics.Value) any {
+	switch v := v.(type) {
+	case *metrics.Int, *metrics.AtomicInt:
+		return v.(metrics.NumValue).Int64()
+	case *metrics.Float:
+		return jsonFloat(v.Float64())
+	case metrics.String:
+		var s string
+		if err := json.Unmarshal([]byte(v.String()), &s); err == nil {
+			return s
+		}
+		return v.String()
+	case *metrics.Map[int64]:
+		return mapValue(v)
+	case *metrics.Map[float64]:
+		return mapValue(v)
+	case *metrics.Distribution:
+		return distValue(v)
+	}
+	return nil
+}
+
+func (s *Surfacer) toJSON(em *metrics.EventMetrics) *eventMetrics {
+	out := &eventMetrics{
+		TimestampMs: em.Timestamp.UnixMilli(),
+		Kind:        "cumulative",
+		Labels:      make(map[string]string),
+		Metrics:     make(map[string]any),
+	}
+	if em.Kind == metrics.GAUGE {
+		out.Kind = "gauge"
+	}
+	if em.LatencyUnit != 0 {
+		out.LatencyUnit = metrics.LatencyUnitToString(em.LatencyUnit)
+	}
+	for _, k := range em.LabelsKeys() {
+		out.Labels[k] = em.Label(k)
+	}
+	for _, name := range em.MetricsKeys() {
+		if !s.opts.AllowMetric(name) {
+			continue
+		}
+		if v := jsonValue(em.Metric(name)); v != nil {
+			out.Metrics[name] = v
+		}
+	}
+	return out
+}
+
+// snapshot returns the current snapshot. If probe is not empty, only
+// EventMetrics for that probe are included.
+func (s *Surfacer) snapshot(probe string) *snapshot {
+	s.mu.RLock()
+	keys := make([]string, 0, len(s.ems))
+	for k, em := range s.ems {
+		if probe == "" || em.Label("probe") == probe {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	out := &snapshot{
+		TimestampMs: time.Now().UnixMilli(),
+		Metrics:     make([]*eventMetrics, 0, len(keys)),
+	}
+	for _, k := range keys {
+		out.Metrics = append(out.Metrics, s.toJSON(s.ems[k]))
+	}
+	s.mu.RUnlock()
+
+	return out
+}
+
+func (s *Surfacer) handler(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(s.snapshot(r.URL.Query().Get("probe")))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
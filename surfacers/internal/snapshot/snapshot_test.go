@@ -0,0 +1,108 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	configpb "github.com/cloudprober/cloudprober/surfacers/internal/snapshot/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEM(probe, dst string, total int64) *metrics.EventMetrics {
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddSample(5)
+	d.AddSample(20)
+
+	em := metrics.NewEventMetrics(time.UnixMilli(1700000000000)).
+		AddMetric("total", metrics.NewInt(total)).
+		AddMetric("ratio", metrics.NewFloat(0.5)).
+		AddMetric("resp-code", metrics.NewMap("code").IncKeyBy("200", total)).
+		AddMetric("latency", d).
+		AddMetric("version", metrics.NewString("v1")).
+		AddLabel("probe", probe).
+		AddLabel("dst", dst)
+	em.LatencyUnit = time.Millisecond
+	return em
+}
+
+func TestSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	opts := &options.Options{HTTPServeMux: http.NewServeMux()}
+	s, err := New(ctx, &configpb.SurfacerConf{}, opts, nil)
+	require.NoError(t, err)
+
+	s.Write(ctx, testEM("p1", "t1", 5))
+	s.Write(ctx, testEM("p1", "t1", 10)) // Replaces the previous one.
+	s.Write(ctx, testEM("p2", "t2", 3))
+
+	get := func(url string) map[string]any {
+		t.Helper()
+		w := httptest.NewRecorder()
+		opts.HTTPServeMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var out map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &out))
+		return out
+	}
+
+	out := get("/metrics.json")
+	ems := out["metrics"].([]any)
+	require.Len(t, ems, 2)
+
+	got := ems[0].(map[string]any)
+	want := map[string]any{
+		"timestamp_ms": float64(1700000000000),
+		"kind":         "cumulative",
+		"latency_unit": "ms",
+		"labels":       map[string]any{"probe": "p1", "dst": "t1"},
+		"metrics": map[string]any{
+			"total":     float64(10),
+			"ratio":     0.5,
+			"resp-code": map[string]any{"200": float64(10)},
+			"version":   "v1",
+			"latency": map[string]any{
+				"count": float64(2),
+				"sum":   float64(25),
+				"buckets": []any{
+					map[string]any{"lower_bound": "-Inf", "count": float64(0)},
+					map[string]any{"lower_bound": "1", "count": float64(1)},
+					map[string]any{"lower_bound": "10", "count": float64(1)},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+
+	// Filter by probe.
+	ems = get("/metrics.json?probe=p2")["metrics"].([]any)
+	require.Len(t, ems, 1)
+	assert.Equal(t, map[string]any{"probe": "p2", "dst": "t2"}, ems[0].(map[string]any)["labels"])
+
+	// Expiration.
+	s.deleteExpired(time.Now().Add(time.Hour))
+	assert.Empty(t, get("/metrics.json")["metrics"])
+}
@@ -111,6 +111,11 @@ type SurfacerConf struct {
 	// Metric prefix to use for stackdriver metrics. If not specified, default
 	// is PTYPE_PROBE.
 	MetricsPrefix *SurfacerConf_MetricPrefix `protobuf:"varint,6,opt,name=metrics_prefix,json=metricsPrefix,enum=cloudprober.surfacer.stackdriver.SurfacerConf_MetricPrefix,def=2" json:"metrics_prefix,omitempty"`
+	// If set, distributions' raw sum and count of observations are also
+	// exported, as "<metric>_sum" and "<metric>_count" metrics. Stackdriver
+	// distributions carry only the mean and the count, and the sum derived
+	// from the mean may not be exact.
+	ExportDistributionSumCount *bool `protobuf:"varint,7,opt,name=export_distribution_sum_count,json=exportDistributionSumCount" json:"export_distribution_sum_count,omitempty"`
 }
 
 // Default values for SurfacerConf fields.
@@ -195,6 +200,13 @@ func (x *SurfacerConf) GetMetricsPrefix() SurfacerConf_MetricPrefix {
 	return Default_SurfacerConf_MetricsPrefix
 }
 
+func (x *SurfacerConf) GetExportDistributionSumCount() bool {
+	if x != nil && x.ExportDistributionSumCount != nil {
+		return *x.ExportDistributionSumCount
+	}
+	return false
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_internal_stackdriver_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_internal_stackdriver_proto_config_proto_rawDesc = []byte{
@@ -205,7 +217,7 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_stackdriver_proto
 	0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x20, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
 	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x61,
-	0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x22, 0xb1, 0x03, 0x0a, 0x0c, 0x53, 0x75, 0x72,
+	0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x22, 0xf4, 0x03, 0x0a, 0x0c, 0x53, 0x75, 0x72,
 	0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f,
 	0x6a, 0x65, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x6a,
 	0x65, 0x63, 0x74, 0x12, 0x2a, 0x0a, 0x0f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d,
@@ -229,15 +241,19 @@ var file_github_com_cloudprober_cloudprober_surfacers_internal_stackdriver_proto
 	0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x2e, 0x4d, 0x65,
 	0x74, 0x72, 0x69, 0x63, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x3a, 0x0b, 0x50, 0x54, 0x59, 0x50,
 	0x45, 0x5f, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
-	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x34, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
-	0x12, 0x09, 0x0a, 0x05, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x50,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x02, 0x42, 0x49, 0x5a, 0x47,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74,
-	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65,
-	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x41, 0x0a, 0x1d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x5f, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x75,
+	0x6d, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x75, 0x6d, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x34, 0x0a, 0x0c, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e,
+	0x45, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x01, 0x12, 0x0f,
+	0x0a, 0x0b, 0x50, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x02, 0x42,
+	0x49, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -35,7 +35,9 @@ import (
 	"google.golang.org/api/option"
 
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/flush"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	configpb "github.com/cloudprober/cloudprober/surfacers/internal/stackdriver/proto"
 )
 
@@ -63,6 +65,10 @@ type SDSurfacer struct {
 
 	// Channel for writing the data without blocking
 	writeChan chan *metrics.EventMetrics
+	queue     *queue.Queue
+
+	// Channel for the flush requests.
+	flushChan chan chan struct{}
 
 	// VM Information
 	onGCE       bool
@@ -92,12 +98,14 @@ func New(ctx context.Context, config *configpb.SurfacerConf, opts *options.Optio
 		cache:        make(map[string]*monitoring.TimeSeries),
 		knownMetrics: make(map[string]bool),
 		writeChan:    make(chan *metrics.EventMetrics, config.GetMetricsBufferSize()),
+		flushChan:    flush.NewChan(),
 		c:            config,
 		opts:         opts,
 		projectName:  config.GetProject(),
 		startTime:    time.Now(),
 		l:            l,
 	}
+	s.queue = queue.New(s.writeChan)
 
 	if s.c.GetAllowedMetricsRegex() != "" {
 		l.Warning("allowed_metrics_regex is now deprecated. Please use the common surfacer options: allow_metrics, ignore_metrics.")
@@ -157,13 +165,16 @@ func (s *SDSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
 	// Write inserts the data to be written into channel. This channel is
 	// watched by writeBatch and will make the necessary calls to the Stackdriver
 	// API to write the data from the channel.
-	select {
-	case s.writeChan <- em:
-	default:
+	if !s.queue.Enqueue(em) {
 		s.l.Errorf("SDSurfacer's write channel is full, dropping new data.")
 	}
 }
 
+// Queue returns the surfacer's queue.
+func (s *SDSurfacer) Queue() *queue.Queue {
+	return s.queue
+}
+
 // createMetricDescriptor creates metric descriptor for the given timeseries.
 // We create metric descriptors explicitly, instead of relying on auto-
 // creation by creating timeseries, because auto-creation doesn't add units to
@@ -217,52 +228,69 @@ func (s *SDSurfacer) writeBatch(ctx context.Context) {
 			// objects.
 			s.recordEventMetrics(em)
 		case <-batchTicker.C:
-			// Empty time series writes cause an error to be returned, so
-			// we skip any calls that write but wouldn't set any data.
-			if len(s.cache) == 0 {
-				break
+			s.writeCache()
+		case done := <-s.flushChan:
+			for len(s.writeChan) > 0 {
+				s.recordEventMetrics(<-s.writeChan)
 			}
+			s.writeCache()
+			close(done)
+		}
+	}
+}
 
-			var ts []*monitoring.TimeSeries
-			for _, v := range s.cache {
-				if !s.knownMetrics[v.Metric.Type] && v.Unit != "" {
-					if err := s.createMetricDescriptor(v); err != nil {
-						s.l.Warningf("Error creating metric descriptor for: %s, err: %v", v.Metric.Type, err)
-						continue
-					}
-					s.knownMetrics[v.Metric.Type] = true
-				}
-				ts = append(ts, v)
-			}
+// writeCache writes the cached time series to the Stackdriver API, and
+// empties the cache.
+func (s *SDSurfacer) writeCache() {
+	// Empty time series writes cause an error to be returned, so
+	// we skip any calls that write but wouldn't set any data.
+	if len(s.cache) == 0 {
+		return
+	}
 
-			// We batch the time series into appropriately-sized sets
-			// and write them
-			for i := 0; i < len(ts); i += batchSize {
-				endIndex := min(len(ts), i+batchSize)
-
-				s.l.Infof("Sending entries %d through %d of %d", i, endIndex, len(ts))
-
-				// Now that we've created the new metric, we can write the data. Making
-				// a time series create call will automatically register a new metric
-				// with the correct information if it does not already exist.
-				// Ref: https://cloud.google.com/monitoring/custom-metrics/creating-metrics#auto-creation
-				requestBody := monitoring.CreateTimeSeriesRequest{
-					TimeSeries: ts[i:endIndex],
-				}
-				if _, err := s.client.Projects.TimeSeries.Create("projects/"+s.projectName, &requestBody).Do(); err != nil {
-					s.failCnt++
-					s.l.Warningf("Unable to fulfill TimeSeries Create call. Err: %v", err)
-				}
+	var ts []*monitoring.TimeSeries
+	for _, v := range s.cache {
+		if !s.knownMetrics[v.Metric.Type] && v.Unit != "" {
+			if err := s.createMetricDescriptor(v); err != nil {
+				s.l.Warningf("Error creating metric descriptor for: %s, err: %v", v.Metric.Type, err)
+				continue
 			}
+			s.knownMetrics[v.Metric.Type] = true
+		}
+		ts = append(ts, v)
+	}
 
-			// Flush the cache after we've finished writing so we don't accidentally
-			// re-write metric values that haven't been written over several write
-			// cycles.
-			for k := range s.cache {
-				delete(s.cache, k)
-			}
+	// We batch the time series into appropriately-sized sets
+	// and write them
+	for i := 0; i < len(ts); i += batchSize {
+		endIndex := min(len(ts), i+batchSize)
+
+		s.l.Infof("Sending entries %d through %d of %d", i, endIndex, len(ts))
+
+		// Now that we've created the new metric, we can write the data. Making
+		// a time series create call will automatically register a new metric
+		// with the correct information if it does not already exist.
+		// Ref: https://cloud.google.com/monitoring/custom-metrics/creating-metrics#auto-creation
+		requestBody := monitoring.CreateTimeSeriesRequest{
+			TimeSeries: ts[i:endIndex],
+		}
+		if _, err := s.client.Projects.TimeSeries.Create("projects/"+s.projectName, &requestBody).Do(); err != nil {
+			s.failCnt++
+			s.l.Warningf("Unable to fulfill TimeSeries Create call. Err: %v", err)
 		}
 	}
+
+	// Flush the cache after we've finished writing so we don't accidentally
+	// re-write metric values that haven't been written over several write
+	// cycles.
+	for k := range s.cache {
+		delete(s.cache, k)
+	}
+}
+
+// Flush writes the pending metrics to the Stackdriver API.
+func (s *SDSurfacer) Flush(ctx context.Context) error {
+	return flush.Request(ctx, s.flushChan)
 }
 
 //-----------------------------------------------------------------------------
@@ -476,6 +504,15 @@ func (s *SDSurfacer) recordEventMetrics(em *metrics.EventMetrics) (ts []*monitor
 			ts = append(ts, recordMapValue(s, bm, val)...)
 
 		case *metrics.Distribution:
+			if s.c.GetExportDistributionSumCount() {
+				d := val.Data()
+				sbm, cbm := bm.Clone(), bm.Clone()
+				sbm.name, cbm.name = bm.name+"_sum", bm.name+"_count"
+				cbm.unit = "1"
+				ts = append(ts, s.recordTimeSeries(sbm, &monitoring.TypedValue{DoubleValue: &d.Sum}))
+				count := float64(d.Count)
+				ts = append(ts, s.recordTimeSeries(cbm, &monitoring.TypedValue{DoubleValue: &count}))
+			}
 			bm.valueType = "DISTRIBUTION"
 			ts = append(ts, s.recordTimeSeries(bm, val.StackdriverTypedValue()))
 
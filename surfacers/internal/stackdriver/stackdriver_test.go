@@ -16,7 +16,9 @@ package stackdriver
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -257,6 +259,37 @@ func TestTimeSeries(t *testing.T) {
 	}
 }
 
+func TestTimeSeriesDistributionSumCount(t *testing.T) {
+	d := metrics.NewDistribution([]float64{1, 10})
+	d.AddFloat64(2.5)
+	d.AddFloat64(12.25)
+	em := metrics.NewEventMetrics(time.Now()).AddMetric("latency", d)
+	em.LatencyUnit = time.Millisecond
+
+	for _, export := range []bool{false, true} {
+		t.Run(fmt.Sprintf("export=%v", export), func(t *testing.T) {
+			s := newTestSurfacer()
+			s.c = &configpb.SurfacerConf{ExportDistributionSumCount: proto.Bool(export)}
+
+			got := make(map[string]*monitoring.TimeSeries)
+			for _, ts := range s.recordEventMetrics(em) {
+				got[strings.TrimPrefix(ts.Metric.Type, "custom.googleapis.com/cloudprober/")] = ts
+			}
+
+			assert.Equal(t, "DISTRIBUTION", got["latency"].ValueType)
+			if !export {
+				assert.Len(t, got, 1)
+				return
+			}
+			assert.Len(t, got, 3)
+			assert.Equal(t, 14.75, *got["latency_sum"].Points[0].Value.DoubleValue)
+			assert.Equal(t, "ms", got["latency_sum"].Unit)
+			assert.Equal(t, float64(2), *got["latency_count"].Points[0].Value.DoubleValue)
+			assert.Equal(t, "1", got["latency_count"].Unit)
+		})
+	}
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name                    string
@@ -11,11 +11,14 @@ import (
 	proto5 "github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch/proto"
 	proto6 "github.com/cloudprober/cloudprober/surfacers/internal/datadog/proto"
 	proto2 "github.com/cloudprober/cloudprober/surfacers/internal/file/proto"
+	proto10 "github.com/cloudprober/cloudprober/surfacers/internal/mqtt/proto"
+	proto12 "github.com/cloudprober/cloudprober/surfacers/internal/opensearch/proto"
 	proto9 "github.com/cloudprober/cloudprober/surfacers/internal/otel/proto"
 	proto3 "github.com/cloudprober/cloudprober/surfacers/internal/postgres/proto"
 	proto7 "github.com/cloudprober/cloudprober/surfacers/internal/probestatus/proto"
 	proto "github.com/cloudprober/cloudprober/surfacers/internal/prometheus/proto"
 	proto4 "github.com/cloudprober/cloudprober/surfacers/internal/pubsub/proto"
+	proto11 "github.com/cloudprober/cloudprober/surfacers/internal/snapshot/proto"
 	proto1 "github.com/cloudprober/cloudprober/surfacers/internal/stackdriver/proto"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -34,18 +37,21 @@ const (
 type Type int32
 
 const (
-	Type_NONE         Type = 0
-	Type_PROMETHEUS   Type = 1
-	Type_STACKDRIVER  Type = 2
-	Type_FILE         Type = 3
-	Type_POSTGRES     Type = 4
-	Type_PUBSUB       Type = 5
-	Type_CLOUDWATCH   Type = 6 // Experimental mode.
-	Type_DATADOG      Type = 7 // Experimental mode.
-	Type_PROBESTATUS  Type = 8
-	Type_BIGQUERY     Type = 9 // Experimental mode.
-	Type_OTEL         Type = 10
-	Type_USER_DEFINED Type = 99
+	Type_NONE          Type = 0
+	Type_PROMETHEUS    Type = 1
+	Type_STACKDRIVER   Type = 2
+	Type_FILE          Type = 3
+	Type_POSTGRES      Type = 4
+	Type_PUBSUB        Type = 5
+	Type_CLOUDWATCH    Type = 6 // Experimental mode.
+	Type_DATADOG       Type = 7 // Experimental mode.
+	Type_PROBESTATUS   Type = 8
+	Type_BIGQUERY      Type = 9 // Experimental mode.
+	Type_OTEL          Type = 10
+	Type_MQTT          Type = 11 // Experimental mode.
+	Type_JSON_SNAPSHOT Type = 12
+	Type_OPENSEARCH    Type = 13 // Experimental mode.
+	Type_USER_DEFINED  Type = 99
 )
 
 // Enum value maps for Type.
@@ -62,21 +68,27 @@ var (
 		8:  "PROBESTATUS",
 		9:  "BIGQUERY",
 		10: "OTEL",
+		11: "MQTT",
+		12: "JSON_SNAPSHOT",
+		13: "OPENSEARCH",
 		99: "USER_DEFINED",
 	}
 	Type_value = map[string]int32{
-		"NONE":         0,
-		"PROMETHEUS":   1,
-		"STACKDRIVER":  2,
-		"FILE":         3,
-		"POSTGRES":     4,
-		"PUBSUB":       5,
-		"CLOUDWATCH":   6,
-		"DATADOG":      7,
-		"PROBESTATUS":  8,
-		"BIGQUERY":     9,
-		"OTEL":         10,
-		"USER_DEFINED": 99,
+		"NONE":          0,
+		"PROMETHEUS":    1,
+		"STACKDRIVER":   2,
+		"FILE":          3,
+		"POSTGRES":      4,
+		"PUBSUB":        5,
+		"CLOUDWATCH":    6,
+		"DATADOG":       7,
+		"PROBESTATUS":   8,
+		"BIGQUERY":      9,
+		"OTEL":          10,
+		"MQTT":          11,
+		"JSON_SNAPSHOT": 12,
+		"OPENSEARCH":    13,
+		"USER_DEFINED":  99,
 	}
 )
 
@@ -117,6 +129,71 @@ func (Type) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescGZIP(), []int{0}
 }
 
+type SurfacerDef_Sampling_Mode int32
+
+const (
+	// Sample each EventMetrics randomly. Since dropping cumulative
+	// EventMetrics randomly can break their consumers (e.g. rate
+	// computation over missing data points), only non-cumulative (GAUGE)
+	// EventMetrics are sampled in this mode, cumulative EventMetrics are
+	// always forwarded.
+	SurfacerDef_Sampling_RANDOM SurfacerDef_Sampling_Mode = 0
+	// Sample deterministically by target (probe and dst labels): either all
+	// or none of a target's EventMetrics are forwarded. As sampled targets'
+	// metrics are complete, this mode applies to all EventMetrics,
+	// including cumulative ones.
+	SurfacerDef_Sampling_TARGET_HASH SurfacerDef_Sampling_Mode = 1
+)
+
+// Enum value maps for SurfacerDef_Sampling_Mode.
+var (
+	SurfacerDef_Sampling_Mode_name = map[int32]string{
+		0: "RANDOM",
+		1: "TARGET_HASH",
+	}
+	SurfacerDef_Sampling_Mode_value = map[string]int32{
+		"RANDOM":      0,
+		"TARGET_HASH": 1,
+	}
+)
+
+func (x SurfacerDef_Sampling_Mode) Enum() *SurfacerDef_Sampling_Mode {
+	p := new(SurfacerDef_Sampling_Mode)
+	*p = x
+	return p
+}
+
+func (x SurfacerDef_Sampling_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SurfacerDef_Sampling_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_enumTypes[1].Descriptor()
+}
+
+func (SurfacerDef_Sampling_Mode) Type() protoreflect.EnumType {
+	return &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_enumTypes[1]
+}
+
+func (x SurfacerDef_Sampling_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SurfacerDef_Sampling_Mode) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SurfacerDef_Sampling_Mode(num)
+	return nil
+}
+
+// Deprecated: Use SurfacerDef_Sampling_Mode.Descriptor instead.
+func (SurfacerDef_Sampling_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescGZIP(), []int{1, 0, 0}
+}
+
 type LabelFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -188,7 +265,14 @@ type SurfacerDef struct {
 	// value should work in most cases. You may need to increase it on a busy
 	// system, but that's usually a sign that you metrics processing pipeline is
 	// slow for some reason, e.g. slow writes to a remote file.
-	// Note: Only file and pubsub surfacer supports this option right now.
+	// Note: This option is used by the file, pubsub, mqtt, opensearch and
+	// cloudwatch surfacers. Prometheus, stackdriver, postgres and bigquery
+	// surfacers have their own metrics_buffer_size option.
+	//
+	// Buffer usage is exported along with the system variables, with the
+	// "surfacer" label: surfacer_queue_depth and surfacer_queue_capacity
+	// (gauges), and surfacer_enqueued, surfacer_dequeued and surfacer_dropped
+	// (counters).
 	MetricsBufferSize *int64 `protobuf:"varint,3,opt,name=metrics_buffer_size,json=metricsBufferSize,def=10000" json:"metrics_buffer_size,omitempty"`
 	// If specified, only allow metrics that match any of these label filters.
 	// Example:
@@ -207,6 +291,20 @@ type SurfacerDef struct {
 	//	  value: "sysvars",
 	//	}
 	IgnoreMetricsWithLabel []*LabelFilter `protobuf:"bytes,5,rep,name=ignore_metrics_with_label,json=ignoreMetricsWithLabel" json:"ignore_metrics_with_label,omitempty"`
+	// Label selector to route metrics to this surfacer. If specified, surfacer
+	// receives only the EventMetrics that satisfy all the comma-separated
+	// requirements of the selector. Supported requirements are:
+	//
+	//	key=value (or key==value), key!=value, key (exists), !key (not exists)
+	//
+	// Example, to send only prod targets' metrics to a surfacer:
+	//
+	//	label_selector: "env=prod,probe!=sysvars"
+	//
+	// Label selector is applied in addition to the allow and ignore filters
+	// above. Use warn_on_unrouted_metrics in the top-level config to find
+	// metrics that are not selected by any surfacer.
+	LabelSelector *string `protobuf:"bytes,56,opt,name=label_selector,json=labelSelector" json:"label_selector,omitempty"`
 	// Allow and ignore metrics based on their names. You can specify regexes
 	// here. Ignore has precendence over allow.
 	// Examples:
@@ -234,13 +332,28 @@ type SurfacerDef struct {
 	// Latency metric name pattern, used to identify latency metrics, and add
 	// EventMetric's LatencyUnit to it.
 	LatencyMetricPattern *string `protobuf:"bytes,51,opt,name=latency_metric_pattern,json=latencyMetricPattern,def=^(.+_|)latency$" json:"latency_metric_pattern,omitempty"`
+	// If set, latency metrics (see latency_metric_pattern above) are converted
+	// to this unit before they are written to this surfacer, regardless of the
+	// probes' latency_unit. It's useful to export latencies consistently, in
+	// a single unit, when probes use different latency units. Valid values:
+	// "ns", "us", "ms", "s". Distribution bucket boundaries and sum, and
+	// summary quantiles and sum, are converted as well.
+	//
+	// Latency metrics are also renamed with the unit suffix, following the
+	// Prometheus naming conventions, e.g. "latency" becomes "latency_seconds"
+	// for "s". Set latency_unit_suffix to false to keep the original names.
+	LatencyUnit       *string `protobuf:"bytes,57,opt,name=latency_unit,json=latencyUnit" json:"latency_unit,omitempty"`
+	LatencyUnitSuffix *bool   `protobuf:"varint,58,opt,name=latency_unit_suffix,json=latencyUnitSuffix,def=1" json:"latency_unit_suffix,omitempty"`
 	// Environment variable containing additional labels to be added to all
 	// metrics exported by this surfacer.
 	// e.g. "CLOUDPROBER_ADDITIONAL_LABELS=env=prod,app=identity-service"
 	// You can disable this feature by setting this field to an empty string.
 	// Note: These additional labels have no effect if metrics already have the
 	// same label.
-	AdditionalLabelsEnvVar *string `protobuf:"bytes,52,opt,name=additional_labels_env_var,json=additionalLabelsEnvVar,def=CLOUDPROBER_ADDITIONAL_LABELS" json:"additional_labels_env_var,omitempty"`
+	AdditionalLabelsEnvVar *string                        `protobuf:"bytes,52,opt,name=additional_labels_env_var,json=additionalLabelsEnvVar,def=CLOUDPROBER_ADDITIONAL_LABELS" json:"additional_labels_env_var,omitempty"`
+	Sampling               *SurfacerDef_Sampling          `protobuf:"bytes,53,opt,name=sampling" json:"sampling,omitempty"`
+	DiskBuffer             *SurfacerDef_DiskBuffer        `protobuf:"bytes,54,opt,name=disk_buffer,json=diskBuffer" json:"disk_buffer,omitempty"`
+	MetricTransform        []*SurfacerDef_MetricTransform `protobuf:"bytes,55,rep,name=metric_transform,json=metricTransform" json:"metric_transform,omitempty"`
 	// Matching surfacer specific configuration (one for each type in the above
 	// enum)
 	//
@@ -256,6 +369,9 @@ type SurfacerDef struct {
 	//	*SurfacerDef_ProbestatusSurfacer
 	//	*SurfacerDef_BigquerySurfacer
 	//	*SurfacerDef_OtelSurfacer
+	//	*SurfacerDef_MqttSurfacer
+	//	*SurfacerDef_JsonSnapshotSurfacer
+	//	*SurfacerDef_OpensearchSurfacer
 	Surfacer isSurfacerDef_Surfacer `protobuf_oneof:"surfacer"`
 }
 
@@ -263,6 +379,7 @@ type SurfacerDef struct {
 const (
 	Default_SurfacerDef_MetricsBufferSize      = int64(10000)
 	Default_SurfacerDef_LatencyMetricPattern   = string("^(.+_|)latency$")
+	Default_SurfacerDef_LatencyUnitSuffix      = bool(true)
 	Default_SurfacerDef_AdditionalLabelsEnvVar = string("CLOUDPROBER_ADDITIONAL_LABELS")
 )
 
@@ -333,6 +450,13 @@ func (x *SurfacerDef) GetIgnoreMetricsWithLabel() []*LabelFilter {
 	return nil
 }
 
+func (x *SurfacerDef) GetLabelSelector() string {
+	if x != nil && x.LabelSelector != nil {
+		return *x.LabelSelector
+	}
+	return ""
+}
+
 func (x *SurfacerDef) GetAllowMetricsWithName() string {
 	if x != nil && x.AllowMetricsWithName != nil {
 		return *x.AllowMetricsWithName
@@ -368,6 +492,20 @@ func (x *SurfacerDef) GetLatencyMetricPattern() string {
 	return Default_SurfacerDef_LatencyMetricPattern
 }
 
+func (x *SurfacerDef) GetLatencyUnit() string {
+	if x != nil && x.LatencyUnit != nil {
+		return *x.LatencyUnit
+	}
+	return ""
+}
+
+func (x *SurfacerDef) GetLatencyUnitSuffix() bool {
+	if x != nil && x.LatencyUnitSuffix != nil {
+		return *x.LatencyUnitSuffix
+	}
+	return Default_SurfacerDef_LatencyUnitSuffix
+}
+
 func (x *SurfacerDef) GetAdditionalLabelsEnvVar() string {
 	if x != nil && x.AdditionalLabelsEnvVar != nil {
 		return *x.AdditionalLabelsEnvVar
@@ -375,6 +513,27 @@ func (x *SurfacerDef) GetAdditionalLabelsEnvVar() string {
 	return Default_SurfacerDef_AdditionalLabelsEnvVar
 }
 
+func (x *SurfacerDef) GetSampling() *SurfacerDef_Sampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
+func (x *SurfacerDef) GetDiskBuffer() *SurfacerDef_DiskBuffer {
+	if x != nil {
+		return x.DiskBuffer
+	}
+	return nil
+}
+
+func (x *SurfacerDef) GetMetricTransform() []*SurfacerDef_MetricTransform {
+	if x != nil {
+		return x.MetricTransform
+	}
+	return nil
+}
+
 func (m *SurfacerDef) GetSurfacer() isSurfacerDef_Surfacer {
 	if m != nil {
 		return m.Surfacer
@@ -452,6 +611,27 @@ func (x *SurfacerDef) GetOtelSurfacer() *proto9.SurfacerConf {
 	return nil
 }
 
+func (x *SurfacerDef) GetMqttSurfacer() *proto10.SurfacerConf {
+	if x, ok := x.GetSurfacer().(*SurfacerDef_MqttSurfacer); ok {
+		return x.MqttSurfacer
+	}
+	return nil
+}
+
+func (x *SurfacerDef) GetJsonSnapshotSurfacer() *proto11.SurfacerConf {
+	if x, ok := x.GetSurfacer().(*SurfacerDef_JsonSnapshotSurfacer); ok {
+		return x.JsonSnapshotSurfacer
+	}
+	return nil
+}
+
+func (x *SurfacerDef) GetOpensearchSurfacer() *proto12.SurfacerConf {
+	if x, ok := x.GetSurfacer().(*SurfacerDef_OpensearchSurfacer); ok {
+		return x.OpensearchSurfacer
+	}
+	return nil
+}
+
 type isSurfacerDef_Surfacer interface {
 	isSurfacerDef_Surfacer()
 }
@@ -496,6 +676,18 @@ type SurfacerDef_OtelSurfacer struct {
 	OtelSurfacer *proto9.SurfacerConf `protobuf:"bytes,19,opt,name=otel_surfacer,json=otelSurfacer,oneof"`
 }
 
+type SurfacerDef_MqttSurfacer struct {
+	MqttSurfacer *proto10.SurfacerConf `protobuf:"bytes,20,opt,name=mqtt_surfacer,json=mqttSurfacer,oneof"`
+}
+
+type SurfacerDef_JsonSnapshotSurfacer struct {
+	JsonSnapshotSurfacer *proto11.SurfacerConf `protobuf:"bytes,21,opt,name=json_snapshot_surfacer,json=jsonSnapshotSurfacer,oneof"`
+}
+
+type SurfacerDef_OpensearchSurfacer struct {
+	OpensearchSurfacer *proto12.SurfacerConf `protobuf:"bytes,22,opt,name=opensearch_surfacer,json=opensearchSurfacer,oneof"`
+}
+
 func (*SurfacerDef_PrometheusSurfacer) isSurfacerDef_Surfacer() {}
 
 func (*SurfacerDef_StackdriverSurfacer) isSurfacerDef_Surfacer() {}
@@ -516,6 +708,268 @@ func (*SurfacerDef_BigquerySurfacer) isSurfacerDef_Surfacer() {}
 
 func (*SurfacerDef_OtelSurfacer) isSurfacerDef_Surfacer() {}
 
+func (*SurfacerDef_MqttSurfacer) isSurfacerDef_Surfacer() {}
+
+func (*SurfacerDef_JsonSnapshotSurfacer) isSurfacerDef_Surfacer() {}
+
+func (*SurfacerDef_OpensearchSurfacer) isSurfacerDef_Surfacer() {}
+
+// Sampling configuration. Sampling reduces the volume of metrics exported
+// by a surfacer by forwarding only a fraction of EventMetrics to it. Each
+// surfacer samples independently.
+type SurfacerDef_Sampling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fraction of EventMetrics to forward, in the range (0, 1].
+	Rate *float32                   `protobuf:"fixed32,1,opt,name=rate,def=1" json:"rate,omitempty"`
+	Mode *SurfacerDef_Sampling_Mode `protobuf:"varint,2,opt,name=mode,enum=cloudprober.surfacer.SurfacerDef_Sampling_Mode" json:"mode,omitempty"`
+}
+
+// Default values for SurfacerDef_Sampling fields.
+const (
+	Default_SurfacerDef_Sampling_Rate = float32(1)
+)
+
+func (x *SurfacerDef_Sampling) Reset() {
+	*x = SurfacerDef_Sampling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerDef_Sampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerDef_Sampling) ProtoMessage() {}
+
+func (x *SurfacerDef_Sampling) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerDef_Sampling.ProtoReflect.Descriptor instead.
+func (*SurfacerDef_Sampling) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *SurfacerDef_Sampling) GetRate() float32 {
+	if x != nil && x.Rate != nil {
+		return *x.Rate
+	}
+	return Default_SurfacerDef_Sampling_Rate
+}
+
+func (x *SurfacerDef_Sampling) GetMode() SurfacerDef_Sampling_Mode {
+	if x != nil && x.Mode != nil {
+		return *x.Mode
+	}
+	return SurfacerDef_Sampling_RANDOM
+}
+
+// On-disk write-ahead buffer for the surfacer. If configured, EventMetrics
+// are first appended to the buffer on disk, and then forwarded to the
+// surfacer from there. Pending EventMetrics survive cloudprober restarts and
+// are replayed on the next start.
+//
+// For surfacers that can report write failures (currently only postgres),
+// failed writes are retried until the backend recovers. For other
+// surfacers, EventMetrics are considered delivered once they have been
+// handed over to the surfacer.
+//
+// Disk usage is bounded by max_size_mb; if the buffer grows beyond that,
+// oldest EventMetrics are dropped.
+type SurfacerDef_DiskBuffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Directory to store the buffer in. Each surfacer should use its own
+	// directory.
+	Dir *string `protobuf:"bytes,1,req,name=dir" json:"dir,omitempty"`
+	// Maximum size of the buffer on disk.
+	MaxSizeMb *int32 `protobuf:"varint,2,opt,name=max_size_mb,json=maxSizeMb,def=100" json:"max_size_mb,omitempty"`
+	// Maximum number of EventMetrics forwarded to the surfacer at a time.
+	BatchSize *int32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,def=100" json:"batch_size,omitempty"`
+	// How long to wait before retrying a failed write.
+	RetryIntervalSec *int32 `protobuf:"varint,4,opt,name=retry_interval_sec,json=retryIntervalSec,def=10" json:"retry_interval_sec,omitempty"`
+}
+
+// Default values for SurfacerDef_DiskBuffer fields.
+const (
+	Default_SurfacerDef_DiskBuffer_MaxSizeMb        = int32(100)
+	Default_SurfacerDef_DiskBuffer_BatchSize        = int32(100)
+	Default_SurfacerDef_DiskBuffer_RetryIntervalSec = int32(10)
+)
+
+func (x *SurfacerDef_DiskBuffer) Reset() {
+	*x = SurfacerDef_DiskBuffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerDef_DiskBuffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerDef_DiskBuffer) ProtoMessage() {}
+
+func (x *SurfacerDef_DiskBuffer) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerDef_DiskBuffer.ProtoReflect.Descriptor instead.
+func (*SurfacerDef_DiskBuffer) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *SurfacerDef_DiskBuffer) GetDir() string {
+	if x != nil && x.Dir != nil {
+		return *x.Dir
+	}
+	return ""
+}
+
+func (x *SurfacerDef_DiskBuffer) GetMaxSizeMb() int32 {
+	if x != nil && x.MaxSizeMb != nil {
+		return *x.MaxSizeMb
+	}
+	return Default_SurfacerDef_DiskBuffer_MaxSizeMb
+}
+
+func (x *SurfacerDef_DiskBuffer) GetBatchSize() int32 {
+	if x != nil && x.BatchSize != nil {
+		return *x.BatchSize
+	}
+	return Default_SurfacerDef_DiskBuffer_BatchSize
+}
+
+func (x *SurfacerDef_DiskBuffer) GetRetryIntervalSec() int32 {
+	if x != nil && x.RetryIntervalSec != nil {
+		return *x.RetryIntervalSec
+	}
+	return Default_SurfacerDef_DiskBuffer_RetryIntervalSec
+}
+
+// Metric value transformations, applied to numeric metrics before they are
+// written to this surfacer. Transformations don't affect other surfacers.
+// Expressions support numbers, +, -, *, /, parentheses, and the following
+// variables:
+//
+//	value:    current value of the metric.
+//	delta:    change in the value since the last EventMetrics with the same
+//	          metrics and labels.
+//	interval: time in seconds since the last EventMetrics with the same
+//	          metrics and labels.
+//
+// For example, to export a rate for a counter and bits for bytes:
+//
+//	metric_transform {
+//	  metric_name: "^total$"
+//	  expression: "delta / interval"
+//	  new_name: "total_per_sec"
+//	}
+//	metric_transform {
+//	  metric_name: "^bytes_rcvd$"
+//	  expression: "value * 8"
+//	}
+//
+// If an expression uses delta or interval, metric is skipped for the first
+// EventMetrics, as there is no previous value to compute them from. Previous
+// values that haven't been updated for an hour are discarded. Results
+// that are not finite numbers (e.g. division by zero) are skipped as well.
+// Transformations are applied after export_as_gauge conversion, and only
+// the first matching transformation is applied to a metric.
+type SurfacerDef_MetricTransform struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Regex to match metric names.
+	MetricName *string `protobuf:"bytes,1,req,name=metric_name,json=metricName" json:"metric_name,omitempty"`
+	// Transformation expression, e.g. "value * 8".
+	Expression *string `protobuf:"bytes,2,req,name=expression" json:"expression,omitempty"`
+	// If set, transformed value is exported as a new metric with this name,
+	// along with the original metric. By default, metric's value is replaced
+	// with the transformed value.
+	NewName *string `protobuf:"bytes,3,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (x *SurfacerDef_MetricTransform) Reset() {
+	*x = SurfacerDef_MetricTransform{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SurfacerDef_MetricTransform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SurfacerDef_MetricTransform) ProtoMessage() {}
+
+func (x *SurfacerDef_MetricTransform) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SurfacerDef_MetricTransform.ProtoReflect.Descriptor instead.
+func (*SurfacerDef_MetricTransform) Descriptor() ([]byte, []int) {
+	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *SurfacerDef_MetricTransform) GetMetricName() string {
+	if x != nil && x.MetricName != nil {
+		return *x.MetricName
+	}
+	return ""
+}
+
+func (x *SurfacerDef_MetricTransform) GetExpression() string {
+	if x != nil && x.Expression != nil {
+		return *x.Expression
+	}
+	return ""
+}
+
+func (x *SurfacerDef_MetricTransform) GetNewName() string {
+	if x != nil && x.NewName != nil {
+		return *x.NewName
+	}
+	return ""
+}
+
 var File_github_com_cloudprober_cloudprober_surfacers_proto_config_proto protoreflect.FileDescriptor
 
 var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDesc = []byte{
@@ -543,158 +997,243 @@ var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDesc
 	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
 	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
 	0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x74, 0x65, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x51, 0x67, 0x69,
+	0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6d, 0x71, 0x74, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x53, 0x67, 0x69,
 	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
 	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
 	0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
-	0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f,
+	0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x4d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x6f, 0x74, 0x65, 0x6c, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x51, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72,
+	0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x54, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x53, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c,
+	0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x4f,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x54, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
 	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
 	0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x53, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x51, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
 	0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c,
 	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f,
-	0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x4f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
-	0x6c, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x54, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f,
-	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x51, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c,
-	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xca, 0x0c, 0x0a, 0x0b,
-	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x44, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
-	0x35, 0x0a, 0x13, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65,
-	0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x3a, 0x05, 0x31, 0x30,
-	0x30, 0x30, 0x30, 0x52, 0x11, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x75, 0x66, 0x66,
-	0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x5a, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f,
-	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
-	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x15, 0x61, 0x6c, 0x6c,
-	0x6f, 0x77, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x12, 0x5c, 0x0a, 0x19, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x74,
-	0x72, 0x69, 0x63, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x16, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
-	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x12, 0x35, 0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57,
-	0x69, 0x74, 0x68, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x2c, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f,
-	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x64,
-	0x64, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x26,
-	0x0a, 0x0f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x61, 0x73, 0x5f, 0x67, 0x61, 0x75, 0x67,
-	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41,
-	0x73, 0x47, 0x61, 0x75, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x16, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63,
-	0x79, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
-	0x18, 0x33, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x0f, 0x5e, 0x28, 0x2e, 0x2b, 0x5f, 0x7c, 0x29, 0x6c,
-	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x24, 0x52, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
-	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x58, 0x0a,
-	0x19, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x76, 0x61, 0x72, 0x18, 0x34, 0x20, 0x01, 0x28, 0x09,
-	0x3a, 0x1d, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x52, 0x5f, 0x41, 0x44,
-	0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x53, 0x52,
-	0x16, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c,
-	0x73, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x12, 0x60, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x6d, 0x65,
-	0x74, 0x68, 0x65, 0x75, 0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x6d,
-	0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75,
-	0x73, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x14, 0x73, 0x74, 0x61,
-	0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
-	0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x73,
-	0x74, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x13, 0x73, 0x74, 0x61, 0x63, 0x6b,
-	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x4e,
-	0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x66, 0x69, 0x6c,
-	0x65, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00,
-	0x52, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x5a,
-	0x0a, 0x11, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
-	0x2e, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x10, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72,
-	0x65, 0x73, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x54, 0x0a, 0x0f, 0x70, 0x75,
-	0x62, 0x73, 0x75, 0x62, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0e, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75,
-	0x62, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00,
-	0x52, 0x0e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
-	0x12, 0x60, 0x0a, 0x13, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x72, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x2e,
-	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x12,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x12, 0x57, 0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x5f, 0x73, 0x75,
-	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63,
+	0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x62, 0x69, 0x67,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x51, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72,
+	0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x35, 0x0a, 0x0b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0xef, 0x14, 0x0a, 0x0b, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x44,
+	0x65, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x13, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x3a, 0x05, 0x31, 0x30, 0x30, 0x30, 0x30, 0x52, 0x11, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x5a, 0x0a,
+	0x18, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x77,
+	0x69, 0x74, 0x68, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x57, 0x69, 0x74, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x5c, 0x0a, 0x19, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68,
+	0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63,
 	0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x72, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x2e, 0x53, 0x75, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x61, 0x74, 0x61,
-	0x64, 0x6f, 0x67, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x14, 0x70,
-	0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61,
-	0x63, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
-	0x2e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x75, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x13, 0x70, 0x72, 0x6f,
-	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
-	0x12, 0x5a, 0x0a, 0x11, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x75, 0x72,
-	0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c,
+	0x63, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x16, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69,
+	0x74, 0x68, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x38, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x35,
+	0x0a, 0x17, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f,
+	0x77, 0x69, 0x74, 0x68, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69, 0x74,
+	0x68, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x18, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c,
+	0x0a, 0x12, 0x61, 0x64, 0x64, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x61, 0x64, 0x64, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x26, 0x0a, 0x0f,
+	0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x61, 0x73, 0x5f, 0x67, 0x61, 0x75, 0x67, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x41, 0x73, 0x47,
+	0x61, 0x75, 0x67, 0x65, 0x12, 0x45, 0x0a, 0x16, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x33,
+	0x20, 0x01, 0x28, 0x09, 0x3a, 0x0f, 0x5e, 0x28, 0x2e, 0x2b, 0x5f, 0x7c, 0x29, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x24, 0x52, 0x14, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6c,
+	0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x39, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x34,
+	0x0a, 0x13, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x73,
+	0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x3a, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75,
+	0x65, 0x52, 0x11, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x6e, 0x69, 0x74, 0x53, 0x75,
+	0x66, 0x66, 0x69, 0x78, 0x12, 0x58, 0x0a, 0x19, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x65, 0x6e, 0x76, 0x5f, 0x76, 0x61,
+	0x72, 0x18, 0x34, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x1d, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x50, 0x52,
+	0x4f, 0x42, 0x45, 0x52, 0x5f, 0x41, 0x44, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x5f,
+	0x4c, 0x41, 0x42, 0x45, 0x4c, 0x53, 0x52, 0x16, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x61, 0x6c, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x12, 0x46,
+	0x0a, 0x08, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x35, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x44, 0x65, 0x66, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x4d, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x62,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x36, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x63, 0x6c,
 	0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
-	0x65, 0x72, 0x2e, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x72, 0x66,
-	0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x10, 0x62, 0x69, 0x67, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0d,
-	0x6f, 0x74, 0x65, 0x6c, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x13, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
-	0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6f, 0x74, 0x65, 0x6c, 0x2e,
-	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0c,
-	0x6f, 0x74, 0x65, 0x6c, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x42, 0x0a, 0x0a, 0x08,
-	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2a, 0xad, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50,
-	0x52, 0x4f, 0x4d, 0x45, 0x54, 0x48, 0x45, 0x55, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53,
-	0x54, 0x41, 0x43, 0x4b, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04,
-	0x46, 0x49, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x4f, 0x53, 0x54, 0x47, 0x52,
-	0x45, 0x53, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42, 0x53, 0x55, 0x42, 0x10, 0x05,
-	0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4c, 0x4f, 0x55, 0x44, 0x57, 0x41, 0x54, 0x43, 0x48, 0x10, 0x06,
-	0x12, 0x0b, 0x0a, 0x07, 0x44, 0x41, 0x54, 0x41, 0x44, 0x4f, 0x47, 0x10, 0x07, 0x12, 0x0f, 0x0a,
-	0x0b, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x08, 0x12, 0x0c,
-	0x0a, 0x08, 0x42, 0x49, 0x47, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04,
-	0x4f, 0x54, 0x45, 0x4c, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x44,
-	0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x63, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73,
-	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x44, 0x65, 0x66, 0x2e, 0x44,
+	0x69, 0x73, 0x6b, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x5c, 0x0a, 0x10, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x37, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x44,
+	0x65, 0x66, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x52, 0x0f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x6f, 0x72, 0x6d, 0x12, 0x60, 0x0a, 0x13, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75,
+	0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65,
+	0x75, 0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48,
+	0x00, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x6d, 0x65, 0x74, 0x68, 0x65, 0x75, 0x73, 0x53, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x14, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x73, 0x74, 0x61, 0x63, 0x6b,
+	0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x13, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x64, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0d, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x53, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0c, 0x66, 0x69,
+	0x6c, 0x65, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x5a, 0x0a, 0x11, 0x70, 0x6f,
+	0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x6f, 0x73,
+	0x74, 0x67, 0x72, 0x65, 0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x48, 0x00, 0x52, 0x10, 0x70, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x53, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x54, 0x0a, 0x0f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62,
+	0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x29, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e, 0x53, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0e, 0x70, 0x75,
+	0x62, 0x73, 0x75, 0x62, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x60, 0x0a, 0x13,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x53, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x12, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x77, 0x61, 0x74, 0x63, 0x68, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x57,
+	0x0a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x61, 0x74, 0x61, 0x64, 0x6f, 0x67, 0x53,
+	0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x14, 0x70, 0x72, 0x6f, 0x62, 0x65,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x62, 0x65, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x13, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x5a, 0x0a, 0x11,
+	0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70,
+	0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x62,
+	0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x10, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0d, 0x6f, 0x74, 0x65, 0x6c,
+	0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6f, 0x74, 0x65, 0x6c, 0x2e, 0x53, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x74, 0x65, 0x6c,
+	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x0d, 0x6d, 0x71, 0x74, 0x74,
+	0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x27, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75,
+	0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x6d, 0x71, 0x74, 0x74, 0x2e, 0x53, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x0c, 0x6d, 0x71, 0x74, 0x74,
+	0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x63, 0x0a, 0x16, 0x6a, 0x73, 0x6f, 0x6e,
+	0x5f, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x72, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e,
+	0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x14, 0x6a, 0x73, 0x6f, 0x6e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x12, 0x60, 0x0a,
+	0x13, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x73, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x53, 0x75, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x48, 0x00, 0x52, 0x12, 0x6f, 0x70, 0x65,
+	0x6e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x1a,
+	0x8b, 0x01, 0x0a, 0x08, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x15, 0x0a, 0x04,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x3a, 0x01, 0x31, 0x52, 0x04, 0x72,
+	0x61, 0x74, 0x65, 0x12, 0x43, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2f, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2e,
+	0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72, 0x2e, 0x53, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x72, 0x44, 0x65, 0x66, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x23, 0x0a, 0x04, 0x4d, 0x6f, 0x64, 0x65,
+	0x12, 0x0a, 0x0a, 0x06, 0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x01, 0x1a, 0x99, 0x01,
+	0x0a, 0x0a, 0x44, 0x69, 0x73, 0x6b, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x64, 0x69, 0x72, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x03, 0x64, 0x69, 0x72, 0x12, 0x23,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x03, 0x31, 0x30, 0x30, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x69, 0x7a,
+	0x65, 0x4d, 0x62, 0x12, 0x22, 0x0a, 0x0a, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x03, 0x31, 0x30, 0x30, 0x52, 0x09, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a, 0x12, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x10, 0x72, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x1a, 0x6d, 0x0a, 0x0f, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x02, 0x28,
+	0x09, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x02, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a,
+	0x08, 0x6e, 0x65, 0x77, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6e, 0x65, 0x77, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x73, 0x75, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x72, 0x2a, 0xda, 0x01, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x08, 0x0a,
+	0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x50, 0x52, 0x4f, 0x4d, 0x45,
+	0x54, 0x48, 0x45, 0x55, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x41, 0x43, 0x4b,
+	0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45,
+	0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x4f, 0x53, 0x54, 0x47, 0x52, 0x45, 0x53, 0x10, 0x04,
+	0x12, 0x0a, 0x0a, 0x06, 0x50, 0x55, 0x42, 0x53, 0x55, 0x42, 0x10, 0x05, 0x12, 0x0e, 0x0a, 0x0a,
+	0x43, 0x4c, 0x4f, 0x55, 0x44, 0x57, 0x41, 0x54, 0x43, 0x48, 0x10, 0x06, 0x12, 0x0b, 0x0a, 0x07,
+	0x44, 0x41, 0x54, 0x41, 0x44, 0x4f, 0x47, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f,
+	0x42, 0x45, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x10, 0x08, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x49,
+	0x47, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04, 0x4f, 0x54, 0x45, 0x4c,
+	0x10, 0x0a, 0x12, 0x08, 0x0a, 0x04, 0x4d, 0x51, 0x54, 0x54, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d,
+	0x4a, 0x53, 0x4f, 0x4e, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x0c, 0x12,
+	0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x45, 0x4e, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x0d, 0x12,
+	0x10, 0x0a, 0x0c, 0x55, 0x53, 0x45, 0x52, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10,
+	0x63, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x72, 0x2f, 0x73, 0x75, 0x72, 0x66, 0x61, 0x63, 0x65, 0x72,
+	0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 }
 
 var (
@@ -709,42 +1248,56 @@ func file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDes
 	return file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDescData
 }
 
-var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_goTypes = []any{
-	(Type)(0),                   // 0: cloudprober.surfacer.Type
-	(*LabelFilter)(nil),         // 1: cloudprober.surfacer.LabelFilter
-	(*SurfacerDef)(nil),         // 2: cloudprober.surfacer.SurfacerDef
-	(*proto.SurfacerConf)(nil),  // 3: cloudprober.surfacer.prometheus.SurfacerConf
-	(*proto1.SurfacerConf)(nil), // 4: cloudprober.surfacer.stackdriver.SurfacerConf
-	(*proto2.SurfacerConf)(nil), // 5: cloudprober.surfacer.file.SurfacerConf
-	(*proto3.SurfacerConf)(nil), // 6: cloudprober.surfacer.postgres.SurfacerConf
-	(*proto4.SurfacerConf)(nil), // 7: cloudprober.surfacer.pubsub.SurfacerConf
-	(*proto5.SurfacerConf)(nil), // 8: cloudprober.surfacer.cloudwatch.SurfacerConf
-	(*proto6.SurfacerConf)(nil), // 9: cloudprober.surfacer.datadog.SurfacerConf
-	(*proto7.SurfacerConf)(nil), // 10: cloudprober.surfacer.probestatus.SurfacerConf
-	(*proto8.SurfacerConf)(nil), // 11: cloudprober.surfacer.bigquery.SurfacerConf
-	(*proto9.SurfacerConf)(nil), // 12: cloudprober.surfacer.otel.SurfacerConf
+	(Type)(0),                           // 0: cloudprober.surfacer.Type
+	(SurfacerDef_Sampling_Mode)(0),      // 1: cloudprober.surfacer.SurfacerDef.Sampling.Mode
+	(*LabelFilter)(nil),                 // 2: cloudprober.surfacer.LabelFilter
+	(*SurfacerDef)(nil),                 // 3: cloudprober.surfacer.SurfacerDef
+	(*SurfacerDef_Sampling)(nil),        // 4: cloudprober.surfacer.SurfacerDef.Sampling
+	(*SurfacerDef_DiskBuffer)(nil),      // 5: cloudprober.surfacer.SurfacerDef.DiskBuffer
+	(*SurfacerDef_MetricTransform)(nil), // 6: cloudprober.surfacer.SurfacerDef.MetricTransform
+	(*proto.SurfacerConf)(nil),          // 7: cloudprober.surfacer.prometheus.SurfacerConf
+	(*proto1.SurfacerConf)(nil),         // 8: cloudprober.surfacer.stackdriver.SurfacerConf
+	(*proto2.SurfacerConf)(nil),         // 9: cloudprober.surfacer.file.SurfacerConf
+	(*proto3.SurfacerConf)(nil),         // 10: cloudprober.surfacer.postgres.SurfacerConf
+	(*proto4.SurfacerConf)(nil),         // 11: cloudprober.surfacer.pubsub.SurfacerConf
+	(*proto5.SurfacerConf)(nil),         // 12: cloudprober.surfacer.cloudwatch.SurfacerConf
+	(*proto6.SurfacerConf)(nil),         // 13: cloudprober.surfacer.datadog.SurfacerConf
+	(*proto7.SurfacerConf)(nil),         // 14: cloudprober.surfacer.probestatus.SurfacerConf
+	(*proto8.SurfacerConf)(nil),         // 15: cloudprober.surfacer.bigquery.SurfacerConf
+	(*proto9.SurfacerConf)(nil),         // 16: cloudprober.surfacer.otel.SurfacerConf
+	(*proto10.SurfacerConf)(nil),        // 17: cloudprober.surfacer.mqtt.SurfacerConf
+	(*proto11.SurfacerConf)(nil),        // 18: cloudprober.surfacer.snapshot.SurfacerConf
+	(*proto12.SurfacerConf)(nil),        // 19: cloudprober.surfacer.opensearch.SurfacerConf
 }
 var file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_depIdxs = []int32{
 	0,  // 0: cloudprober.surfacer.SurfacerDef.type:type_name -> cloudprober.surfacer.Type
-	1,  // 1: cloudprober.surfacer.SurfacerDef.allow_metrics_with_label:type_name -> cloudprober.surfacer.LabelFilter
-	1,  // 2: cloudprober.surfacer.SurfacerDef.ignore_metrics_with_label:type_name -> cloudprober.surfacer.LabelFilter
-	3,  // 3: cloudprober.surfacer.SurfacerDef.prometheus_surfacer:type_name -> cloudprober.surfacer.prometheus.SurfacerConf
-	4,  // 4: cloudprober.surfacer.SurfacerDef.stackdriver_surfacer:type_name -> cloudprober.surfacer.stackdriver.SurfacerConf
-	5,  // 5: cloudprober.surfacer.SurfacerDef.file_surfacer:type_name -> cloudprober.surfacer.file.SurfacerConf
-	6,  // 6: cloudprober.surfacer.SurfacerDef.postgres_surfacer:type_name -> cloudprober.surfacer.postgres.SurfacerConf
-	7,  // 7: cloudprober.surfacer.SurfacerDef.pubsub_surfacer:type_name -> cloudprober.surfacer.pubsub.SurfacerConf
-	8,  // 8: cloudprober.surfacer.SurfacerDef.cloudwatch_surfacer:type_name -> cloudprober.surfacer.cloudwatch.SurfacerConf
-	9,  // 9: cloudprober.surfacer.SurfacerDef.datadog_surfacer:type_name -> cloudprober.surfacer.datadog.SurfacerConf
-	10, // 10: cloudprober.surfacer.SurfacerDef.probestatus_surfacer:type_name -> cloudprober.surfacer.probestatus.SurfacerConf
-	11, // 11: cloudprober.surfacer.SurfacerDef.bigquery_surfacer:type_name -> cloudprober.surfacer.bigquery.SurfacerConf
-	12, // 12: cloudprober.surfacer.SurfacerDef.otel_surfacer:type_name -> cloudprober.surfacer.otel.SurfacerConf
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	2,  // 1: cloudprober.surfacer.SurfacerDef.allow_metrics_with_label:type_name -> cloudprober.surfacer.LabelFilter
+	2,  // 2: cloudprober.surfacer.SurfacerDef.ignore_metrics_with_label:type_name -> cloudprober.surfacer.LabelFilter
+	4,  // 3: cloudprober.surfacer.SurfacerDef.sampling:type_name -> cloudprober.surfacer.SurfacerDef.Sampling
+	5,  // 4: cloudprober.surfacer.SurfacerDef.disk_buffer:type_name -> cloudprober.surfacer.SurfacerDef.DiskBuffer
+	6,  // 5: cloudprober.surfacer.SurfacerDef.metric_transform:type_name -> cloudprober.surfacer.SurfacerDef.MetricTransform
+	7,  // 6: cloudprober.surfacer.SurfacerDef.prometheus_surfacer:type_name -> cloudprober.surfacer.prometheus.SurfacerConf
+	8,  // 7: cloudprober.surfacer.SurfacerDef.stackdriver_surfacer:type_name -> cloudprober.surfacer.stackdriver.SurfacerConf
+	9,  // 8: cloudprober.surfacer.SurfacerDef.file_surfacer:type_name -> cloudprober.surfacer.file.SurfacerConf
+	10, // 9: cloudprober.surfacer.SurfacerDef.postgres_surfacer:type_name -> cloudprober.surfacer.postgres.SurfacerConf
+	11, // 10: cloudprober.surfacer.SurfacerDef.pubsub_surfacer:type_name -> cloudprober.surfacer.pubsub.SurfacerConf
+	12, // 11: cloudprober.surfacer.SurfacerDef.cloudwatch_surfacer:type_name -> cloudprober.surfacer.cloudwatch.SurfacerConf
+	13, // 12: cloudprober.surfacer.SurfacerDef.datadog_surfacer:type_name -> cloudprober.surfacer.datadog.SurfacerConf
+	14, // 13: cloudprober.surfacer.SurfacerDef.probestatus_surfacer:type_name -> cloudprober.surfacer.probestatus.SurfacerConf
+	15, // 14: cloudprober.surfacer.SurfacerDef.bigquery_surfacer:type_name -> cloudprober.surfacer.bigquery.SurfacerConf
+	16, // 15: cloudprober.surfacer.SurfacerDef.otel_surfacer:type_name -> cloudprober.surfacer.otel.SurfacerConf
+	17, // 16: cloudprober.surfacer.SurfacerDef.mqtt_surfacer:type_name -> cloudprober.surfacer.mqtt.SurfacerConf
+	18, // 17: cloudprober.surfacer.SurfacerDef.json_snapshot_surfacer:type_name -> cloudprober.surfacer.snapshot.SurfacerConf
+	19, // 18: cloudprober.surfacer.SurfacerDef.opensearch_surfacer:type_name -> cloudprober.surfacer.opensearch.SurfacerConf
+	1,  // 19: cloudprober.surfacer.SurfacerDef.Sampling.mode:type_name -> cloudprober.surfacer.SurfacerDef.Sampling.Mode
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_init() }
@@ -777,6 +1330,42 @@ func file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_init()
 				return nil
 			}
 		}
+		file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerDef_Sampling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerDef_DiskBuffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SurfacerDef_MetricTransform); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_msgTypes[1].OneofWrappers = []any{
 		(*SurfacerDef_PrometheusSurfacer)(nil),
@@ -789,14 +1378,17 @@ func file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_init()
 		(*SurfacerDef_ProbestatusSurfacer)(nil),
 		(*SurfacerDef_BigquerySurfacer)(nil),
 		(*SurfacerDef_OtelSurfacer)(nil),
+		(*SurfacerDef_MqttSurfacer)(nil),
+		(*SurfacerDef_JsonSnapshotSurfacer)(nil),
+		(*SurfacerDef_OpensearchSurfacer)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_cloudprober_cloudprober_surfacers_proto_config_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      2,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
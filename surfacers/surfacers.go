@@ -30,20 +30,26 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cloudprober/cloudprober/logger"
 	"github.com/cloudprober/cloudprober/metrics"
 	"github.com/cloudprober/cloudprober/surfacers/internal/bigquery"
 	"github.com/cloudprober/cloudprober/surfacers/internal/cloudwatch"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/diskbuffer"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/options"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	"github.com/cloudprober/cloudprober/surfacers/internal/common/transform"
 	"github.com/cloudprober/cloudprober/surfacers/internal/datadog"
 	"github.com/cloudprober/cloudprober/surfacers/internal/file"
+	"github.com/cloudprober/cloudprober/surfacers/internal/mqtt"
+	"github.com/cloudprober/cloudprober/surfacers/internal/opensearch"
 	"github.com/cloudprober/cloudprober/surfacers/internal/otel"
 	"github.com/cloudprober/cloudprober/surfacers/internal/postgres"
 	"github.com/cloudprober/cloudprober/surfacers/internal/probestatus"
 	"github.com/cloudprober/cloudprober/surfacers/internal/prometheus"
 	"github.com/cloudprober/cloudprober/surfacers/internal/pubsub"
+	"github.com/cloudprober/cloudprober/surfacers/internal/snapshot"
 	"github.com/cloudprober/cloudprober/surfacers/internal/stackdriver"
 	"github.com/cloudprober/cloudprober/web/formatutils"
 	"google.golang.org/protobuf/proto"
@@ -113,14 +119,56 @@ type Surfacer interface {
 	Write(ctx context.Context, em *metrics.EventMetrics)
 }
 
+// Flusher is implemented by the surfacers that buffer EventMetrics before
+// writing them out, e.g. to batch them. Flush writes out the buffered
+// EventMetrics, including the ones written just before the call. It's
+// called on graceful shutdown, so that the last batch of metrics is not lost.
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// queuedSurfacer is implemented by the surfacers that buffer the incoming
+// EventMetrics in a queue, until their write loop processes them.
+type queuedSurfacer interface {
+	Queue() *queue.Queue
+}
+
 type surfacerWrapper struct {
 	Surfacer
 	opts    *options.Options
 	lvCache map[string]*metrics.EventMetrics
+
+	// Internal surfacers (e.g. probestatus) rely on the original metric
+	// names, so we don't apply the metrics prefix for them.
+	skipMetricsPrefix bool
+
+	// If set, EventMetrics are written to the surfacer through this buffer.
+	buffer *diskbuffer.Buffer
+
+	// If set, metric values are transformed before writing to the surfacer.
+	valueTransformer *transform.ValueTransformer
+}
+
+// batchWriter is implemented by the surfacers that can write EventMetrics
+// synchronously and report failures. It's used for the disk buffer, to retry
+// failed writes.
+type batchWriter interface {
+	WriteBatch(ctx context.Context, ems []*metrics.EventMetrics) error
+}
+
+// deliver forwards EventMetrics from the disk buffer to the surfacer.
+func (sw *surfacerWrapper) deliver(ctx context.Context, ems []*metrics.EventMetrics) error {
+	if bw, ok := sw.Surfacer.(batchWriter); ok {
+		return bw.WriteBatch(ctx, ems)
+	}
+	for _, em := range ems {
+		sw.Surfacer.Write(ctx, em)
+	}
+	return nil
 }
 
 func (sw *surfacerWrapper) Write(ctx context.Context, em *metrics.EventMetrics) {
-	if !sw.opts.AllowEventMetrics(em) {
+	if !sw.opts.AllowEventMetrics(em) || !sw.opts.SampleEventMetrics(em) {
 		return
 	}
 
@@ -130,6 +178,10 @@ func (sw *surfacerWrapper) Write(ctx context.Context, em *metrics.EventMetrics)
 		}
 	}
 
+	if sw.opts.LatencyUnit != 0 {
+		em = transform.NormalizeLatency(em, sw.opts.LatencyUnit, sw.opts.LatencyUnitSuffix, sw.opts.IsLatencyMetric)
+	}
+
 	if sw.opts.Config.GetExportAsGauge() && em.Kind == metrics.CUMULATIVE {
 		newEM, err := transform.CumulativeToGauge(em, sw.lvCache, sw.opts.Logger)
 		if err != nil {
@@ -139,14 +191,36 @@ func (sw *surfacerWrapper) Write(ctx context.Context, em *metrics.EventMetrics)
 		em = newEM
 	}
 
+	if sw.valueTransformer != nil {
+		em = sw.valueTransformer.Transform(em)
+	}
+
 	// Apply additional labels
 	for _, label := range sw.opts.AdditionalLabels {
 		em.AddLabel(label[0], label[1])
 	}
 
+	if !sw.skipMetricsPrefix {
+		em = em.WithMetricsPrefix()
+	}
+
+	if sw.buffer != nil {
+		sw.buffer.Write(em)
+		return
+	}
+
 	sw.Surfacer.Write(ctx, em)
 }
 
+// Flush flushes the underlying surfacer, if it implements Flusher. Note that
+// the disk buffer, if configured, is not flushed, as it's persisted already.
+func (sw *surfacerWrapper) Flush(ctx context.Context) error {
+	if f, ok := sw.Surfacer.(Flusher); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
+
 // SurfacerInfo encapsulates a Surfacer and related info.
 type SurfacerInfo struct {
 	Surfacer
@@ -178,6 +252,12 @@ func inferType(s *surfacerpb.SurfacerDef) surfacerpb.Type {
 		return surfacerpb.Type_BIGQUERY
 	case *surfacerpb.SurfacerDef_OtelSurfacer:
 		return surfacerpb.Type_OTEL
+	case *surfacerpb.SurfacerDef_MqttSurfacer:
+		return surfacerpb.Type_MQTT
+	case *surfacerpb.SurfacerDef_JsonSnapshotSurfacer:
+		return surfacerpb.Type_JSON_SNAPSHOT
+	case *surfacerpb.SurfacerDef_OpensearchSurfacer:
+		return surfacerpb.Type_OPENSEARCH
 	}
 
 	return surfacerpb.Type_NONE
@@ -221,6 +301,12 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 		surfacer, err = bigquery.New(ctx, s.GetBigquerySurfacer(), opts, l)
 	case surfacerpb.Type_OTEL:
 		surfacer, err = otel.New(ctx, s.GetOtelSurfacer(), opts, l)
+	case surfacerpb.Type_MQTT:
+		surfacer, err = mqtt.New(ctx, s.GetMqttSurfacer(), opts, l)
+	case surfacerpb.Type_JSON_SNAPSHOT:
+		surfacer, err = snapshot.New(ctx, s.GetJsonSnapshotSurfacer(), opts, l)
+	case surfacerpb.Type_OPENSEARCH:
+		surfacer, err = opensearch.New(ctx, s.GetOpensearchSurfacer(), opts, l)
 	case surfacerpb.Type_USER_DEFINED:
 		userDefinedSurfacersMu.Lock()
 		defer userDefinedSurfacersMu.Unlock()
@@ -232,11 +318,86 @@ func initSurfacer(ctx context.Context, s *surfacerpb.SurfacerDef, sType surfacer
 		return nil, fmt.Errorf("unknown surfacer type: %s", s.GetType())
 	}
 
-	return &surfacerWrapper{
+	if err != nil {
+		return nil, err
+	}
+
+	sw := &surfacerWrapper{
 		Surfacer: surfacer,
 		opts:     opts,
 		lvCache:  make(map[string]*metrics.EventMetrics),
-	}, err
+
+		skipMetricsPrefix: sType == surfacerpb.Type_PROBESTATUS,
+	}
+
+	if sw.valueTransformer, err = transform.NewValueTransformer(s.GetMetricTransform()); err != nil {
+		return nil, err
+	}
+
+	if s.GetDiskBuffer() != nil {
+		if sw.buffer, err = diskbuffer.New(ctx, s.GetDiskBuffer(), sw.deliver, l); err != nil {
+			return nil, err
+		}
+	}
+
+	return sw, nil
+}
+
+// QueueStatsEventMetrics returns the EventMetrics for the surfacers' queue
+// stats: queue depth and capacity as GAUGE metrics, and enqueued, dequeued
+// and dropped EventMetrics counts as CUMULATIVE metrics. Surfacers that don't
+// queue EventMetrics are skipped.
+func QueueStatsEventMetrics(sis []*SurfacerInfo, ts time.Time) []*metrics.EventMetrics {
+	var ems []*metrics.EventMetrics
+	for _, si := range sis {
+		s := si.Surfacer
+		if sw, ok := s.(*surfacerWrapper); ok {
+			s = sw.Surfacer
+		}
+		qs, ok := s.(queuedSurfacer)
+		if !ok {
+			continue
+		}
+
+		name := si.Name
+		if name == "" {
+			name = strings.ToLower(si.Type)
+		}
+		stats := qs.Queue().Stats()
+
+		gaugeEM := metrics.NewEventMetrics(ts).
+			AddMetric("surfacer_queue_depth", metrics.NewInt(int64(stats.Depth))).
+			AddMetric("surfacer_queue_capacity", metrics.NewInt(int64(stats.Capacity))).
+			AddLabel("ptype", "sysvars").
+			AddLabel("probe", "sysvars").
+			AddLabel("surfacer", name)
+		gaugeEM.Kind = metrics.GAUGE
+
+		ems = append(ems, gaugeEM, metrics.NewEventMetrics(ts).
+			AddMetric("surfacer_enqueued", metrics.NewInt(stats.Enqueued)).
+			AddMetric("surfacer_dequeued", metrics.NewInt(stats.Dequeued)).
+			AddMetric("surfacer_dropped", metrics.NewInt(stats.Dropped)).
+			AddLabel("ptype", "sysvars").
+			AddLabel("probe", "sysvars").
+			AddLabel("surfacer", name))
+	}
+	return ems
+}
+
+// Routed returns true if EventMetrics is selected by at least one of the
+// given surfacers, based on their label filters and label selectors. Internal
+// surfacers (probestatus) are not considered.
+func Routed(sis []*SurfacerInfo, em *metrics.EventMetrics) bool {
+	for _, si := range sis {
+		if si.Type == surfacerpb.Type_PROBESTATUS.String() {
+			continue
+		}
+		sw, ok := si.Surfacer.(*surfacerWrapper)
+		if !ok || sw.opts.AllowEventMetrics(em) {
+			return true
+		}
+	}
+	return false
 }
 
 // Init initializes the surfacers from the config protobufs and returns them as
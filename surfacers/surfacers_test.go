@@ -16,13 +16,16 @@ package surfacers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/cloudprober/cloudprober/config/runconfig"
 	"github.com/cloudprober/cloudprober/metrics"
+	"github.com/cloudprober/cloudprober/surfacers/internal/common/queue"
 	surfacerpb "github.com/cloudprober/cloudprober/surfacers/proto"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/proto"
@@ -63,16 +66,19 @@ func TestEmptyConfig(t *testing.T) {
 
 func TestInferType(t *testing.T) {
 	typeToConf := map[string]*surfacerpb.SurfacerDef{
-		"CLOUDWATCH":  {Surfacer: &surfacerpb.SurfacerDef_CloudwatchSurfacer{}},
-		"DATADOG":     {Surfacer: &surfacerpb.SurfacerDef_DatadogSurfacer{}},
-		"FILE":        {Surfacer: &surfacerpb.SurfacerDef_FileSurfacer{}},
-		"POSTGRES":    {Surfacer: &surfacerpb.SurfacerDef_PostgresSurfacer{}},
-		"PROBESTATUS": {Surfacer: &surfacerpb.SurfacerDef_ProbestatusSurfacer{}},
-		"PROMETHEUS":  {Surfacer: &surfacerpb.SurfacerDef_PrometheusSurfacer{}},
-		"PUBSUB":      {Surfacer: &surfacerpb.SurfacerDef_PubsubSurfacer{}},
-		"STACKDRIVER": {Surfacer: &surfacerpb.SurfacerDef_StackdriverSurfacer{}},
-		"BIGQUERY":    {Surfacer: &surfacerpb.SurfacerDef_BigquerySurfacer{}},
-		"OTEL":        {Surfacer: &surfacerpb.SurfacerDef_OtelSurfacer{}},
+		"CLOUDWATCH":    {Surfacer: &surfacerpb.SurfacerDef_CloudwatchSurfacer{}},
+		"DATADOG":       {Surfacer: &surfacerpb.SurfacerDef_DatadogSurfacer{}},
+		"FILE":          {Surfacer: &surfacerpb.SurfacerDef_FileSurfacer{}},
+		"POSTGRES":      {Surfacer: &surfacerpb.SurfacerDef_PostgresSurfacer{}},
+		"PROBESTATUS":   {Surfacer: &surfacerpb.SurfacerDef_ProbestatusSurfacer{}},
+		"PROMETHEUS":    {Surfacer: &surfacerpb.SurfacerDef_PrometheusSurfacer{}},
+		"PUBSUB":        {Surfacer: &surfacerpb.SurfacerDef_PubsubSurfacer{}},
+		"STACKDRIVER":   {Surfacer: &surfacerpb.SurfacerDef_StackdriverSurfacer{}},
+		"BIGQUERY":      {Surfacer: &surfacerpb.SurfacerDef_BigquerySurfacer{}},
+		"OTEL":          {Surfacer: &surfacerpb.SurfacerDef_OtelSurfacer{}},
+		"MQTT":          {Surfacer: &surfacerpb.SurfacerDef_MqttSurfacer{}},
+		"JSON_SNAPSHOT": {Surfacer: &surfacerpb.SurfacerDef_JsonSnapshotSurfacer{}},
+		"OPENSEARCH":    {Surfacer: &surfacerpb.SurfacerDef_OpensearchSurfacer{}},
 	}
 
 	for k := range surfacerpb.Type_value {
@@ -173,6 +179,58 @@ func TestUserDefinedAndFiltering(t *testing.T) {
 	}
 }
 
+func TestLabelSelectorRouting(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	prodS, devS := &testSurfacer{}, &testSurfacer{}
+	Register("prod", prodS)
+	Register("dev", devS)
+
+	si, err := Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name:          proto.String("prod"),
+			Type:          surfacerpb.Type_USER_DEFINED.Enum(),
+			LabelSelector: proto.String("env=prod"),
+		},
+		{
+			Name:          proto.String("dev"),
+			Type:          surfacerpb.Type_USER_DEFINED.Enum(),
+			LabelSelector: proto.String("env=dev"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+
+	prodEM := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1)).AddLabel("env", "prod")
+	devEM := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1)).AddLabel("env", "dev")
+	otherEM := metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(1)).AddLabel("env", "qa")
+
+	for _, em := range []*metrics.EventMetrics{prodEM, devEM, otherEM} {
+		for _, s := range si {
+			s.Surfacer.Write(context.Background(), em)
+		}
+	}
+
+	assert.Equal(t, []*metrics.EventMetrics{prodEM}, prodS.received)
+	assert.Equal(t, []*metrics.EventMetrics{devEM}, devS.received)
+
+	assert.True(t, Routed(si, prodEM))
+	assert.True(t, Routed(si, devEM))
+	// Probestatus surfacer, added automatically, is not considered.
+	assert.False(t, Routed(si, otherEM))
+
+	// Invalid selector.
+	_, err = Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name:          proto.String("prod"),
+			Type:          surfacerpb.Type_USER_DEFINED.Enum(),
+			LabelSelector: proto.String("env=prod,"),
+		},
+	})
+	assert.Error(t, err)
+}
+
 func TestFailureMetric(t *testing.T) {
 	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
 
@@ -292,3 +350,254 @@ func TestAdditionalLabel(t *testing.T) {
 		}
 	}
 }
+
+func TestMetricsPrefix(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ts1 := &testSurfacer{}
+	Register("s1", ts1)
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(20)).
+		AddMetric("success", metrics.NewInt(18)).
+		AddLabel("ptype", "http")
+	em.MetricsPrefix = "http_"
+
+	si, err := Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name:             proto.String("s1"),
+			Type:             surfacerpb.Type_USER_DEFINED.Enum(),
+			AddFailureMetric: proto.Bool(true),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+
+	for _, s := range si {
+		s.Surfacer.Write(context.Background(), em)
+	}
+
+	assert.Equal(t, 1, len(ts1.received))
+	assert.Equal(t, []string{"http_total", "http_success", "http_failure"}, ts1.received[0].MetricsKeys())
+}
+
+func TestMetricTransform(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ts1, ts2 := &testSurfacer{}, &testSurfacer{}
+	Register("s1", ts1)
+	Register("s2", ts2)
+
+	si, err := Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name:             proto.String("s1"),
+			Type:             surfacerpb.Type_USER_DEFINED.Enum(),
+			AddFailureMetric: proto.Bool(false),
+			MetricTransform: []*surfacerpb.SurfacerDef_MetricTransform{
+				{
+					MetricName: proto.String("^bytes$"),
+					Expression: proto.String("value * 8"),
+				},
+			},
+		},
+		{
+			Name:             proto.String("s2"),
+			Type:             surfacerpb.Type_USER_DEFINED.Enum(),
+			AddFailureMetric: proto.Bool(false),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+
+	em := metrics.NewEventMetrics(time.Now()).
+		AddMetric("total", metrics.NewInt(20)).
+		AddMetric("bytes", metrics.NewInt(100)).
+		AddLabel("ptype", "http")
+	for _, s := range si {
+		s.Surfacer.Write(context.Background(), em)
+	}
+
+	assert.Equal(t, "800.000", ts1.received[0].Metric("bytes").String())
+	assert.Equal(t, "100", ts2.received[0].Metric("bytes").String(), "other surfacer")
+	assert.Equal(t, "100", em.Metric("bytes").String(), "original EventMetrics")
+
+	_, err = Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name: proto.String("s1"),
+			Type: surfacerpb.Type_USER_DEFINED.Enum(),
+			MetricTransform: []*surfacerpb.SurfacerDef_MetricTransform{
+				{
+					MetricName: proto.String("^bytes$"),
+					Expression: proto.String("bytes * 8"),
+				},
+			},
+		},
+	})
+	assert.Error(t, err, "unknown variable in expression")
+}
+
+func TestLatencyUnit(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ts1 := &testSurfacer{}
+	Register("s1", ts1)
+
+	si, err := Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name:             proto.String("s1"),
+			Type:             surfacerpb.Type_USER_DEFINED.Enum(),
+			AddFailureMetric: proto.Bool(false),
+			LatencyUnit:      proto.String("s"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+
+	for _, unit := range []time.Duration{time.Millisecond, time.Microsecond} {
+		em := metrics.NewEventMetrics(time.Now()).
+			AddMetric("total", metrics.NewInt(20)).
+			AddMetric("latency", metrics.NewFloat(float64(250*time.Millisecond/unit))).
+			AddLabel("ptype", "http")
+		em.LatencyUnit = unit
+		for _, s := range si {
+			s.Surfacer.Write(context.Background(), em)
+		}
+	}
+
+	assert.Len(t, ts1.received, 2)
+	for _, em := range ts1.received {
+		assert.Equal(t, []string{"total", "latency_seconds"}, em.MetricsKeys())
+		assert.Equal(t, "0.250", em.Metric("latency_seconds").String())
+		assert.Equal(t, time.Second, em.LatencyUnit)
+	}
+}
+
+// testQueuedSurfacer queues EventMetrics, but doesn't process them.
+type testQueuedSurfacer struct {
+	q *queue.Queue
+}
+
+func (s *testQueuedSurfacer) Write(_ context.Context, em *metrics.EventMetrics) {
+	s.q.Enqueue(em)
+}
+
+func (s *testQueuedSurfacer) Queue() *queue.Queue {
+	return s.q
+}
+
+func TestQueueStatsEventMetrics(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ch := make(chan *metrics.EventMetrics, 3)
+	Register("queued", &testQueuedSurfacer{q: queue.New(ch)})
+	Register("not_queued", &testSurfacer{})
+
+	si, err := Init(context.Background(), []*surfacerpb.SurfacerDef{
+		{
+			Name: proto.String("queued"),
+			Type: surfacerpb.Type_USER_DEFINED.Enum(),
+		},
+		{
+			Name: proto.String("not_queued"),
+			Type: surfacerpb.Type_USER_DEFINED.Enum(),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected initialization error: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		si[0].Write(context.Background(), metrics.NewEventMetrics(time.Now()).AddMetric("total", metrics.NewInt(int64(i))))
+	}
+	<-ch
+
+	ems := QueueStatsEventMetrics(si, time.Now())
+
+	// Stats are reported by the queued surfacer and by the probestatus surfacer
+	// (added by default), but not by the not_queued surfacer.
+	var queuedEMs []*metrics.EventMetrics
+	for _, em := range ems {
+		if em.Label("surfacer") == "queued" {
+			queuedEMs = append(queuedEMs, em)
+		}
+	}
+	assert.Len(t, ems, 4)
+	assert.Len(t, queuedEMs, 2)
+
+	assert.Equal(t, metrics.Kind(metrics.GAUGE), queuedEMs[0].Kind)
+	assert.Equal(t, "2", queuedEMs[0].Metric("surfacer_queue_depth").String())
+	assert.Equal(t, "3", queuedEMs[0].Metric("surfacer_queue_capacity").String())
+
+	assert.Equal(t, metrics.Kind(metrics.CUMULATIVE), queuedEMs[1].Kind)
+	assert.Equal(t, "3", queuedEMs[1].Metric("surfacer_enqueued").String())
+	assert.Equal(t, "1", queuedEMs[1].Metric("surfacer_dequeued").String())
+	assert.Equal(t, "2", queuedEMs[1].Metric("surfacer_dropped").String())
+}
+
+type testBatchSurfacer struct {
+	mu       sync.Mutex
+	received []*metrics.EventMetrics
+	fail     bool
+}
+
+func (ts *testBatchSurfacer) Write(ctx context.Context, em *metrics.EventMetrics) {
+	panic("Write shouldn't be called with the disk buffer")
+}
+
+func (ts *testBatchSurfacer) WriteBatch(ctx context.Context, ems []*metrics.EventMetrics) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.fail {
+		return errors.New("backend down")
+	}
+	ts.received = append(ts.received, ems...)
+	return nil
+}
+
+func (ts *testBatchSurfacer) numReceived() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return len(ts.received)
+}
+
+func TestDiskBuffer(t *testing.T) {
+	runconfig.SetDefaultHTTPServeMux(http.NewServeMux())
+
+	ts := &testBatchSurfacer{fail: true}
+	Register("s-buffered", ts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	si, err := Init(ctx, []*surfacerpb.SurfacerDef{
+		{
+			Name: proto.String("s-buffered"),
+			Type: surfacerpb.Type_USER_DEFINED.Enum(),
+			DiskBuffer: &surfacerpb.SurfacerDef_DiskBuffer{
+				Dir:              proto.String(t.TempDir()),
+				RetryIntervalSec: proto.Int32(1),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	for _, em := range testEventMetrics {
+		si[0].Surfacer.Write(ctx, em)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 0, ts.numReceived())
+
+	// Backend recovers.
+	ts.mu.Lock()
+	ts.fail = false
+	ts.mu.Unlock()
+
+	assert.Eventually(t, func() bool { return ts.numReceived() == len(testEventMetrics) }, 5*time.Second, 10*time.Millisecond)
+	for i, em := range testEventMetrics {
+		assert.Equal(t, em.String(), ts.received[i].String())
+	}
+}
@@ -36,6 +36,11 @@ type Endpoint struct {
 	LastUpdated time.Time
 	Port        int
 	IP          net.IP
+
+	// TypedLabels contain typed values (int64, float64 or bool) for some of the
+	// labels, if targets provider declares label types. These labels are
+	// always present in Labels as well.
+	TypedLabels map[string]any
 }
 
 // Key returns a string key that uniquely identifies that endpoint.
@@ -56,6 +61,48 @@ func (ep *Endpoint) Key() string {
 	return strings.Join(append([]string{ep.Name, ip, strconv.Itoa(ep.Port)}, labelSlice...), "_")
 }
 
+// IPLabel is the label that carries the specific IP address for the
+// endpoints expanded from an endpoint with multiple IP addresses.
+const IPLabel = "ip"
+
+// ExpandIPs returns one endpoint per IP address, each with the endpoint's
+// name and port, and a copy of its labels with the IP address added in the
+// IPLabel label. As expanded endpoints share the name, probes should use ID
+// to tell them apart, and Resolve to get their IP address.
+func (ep *Endpoint) ExpandIPs(ips []net.IP) []Endpoint {
+	result := make([]Endpoint, len(ips))
+	for i, ip := range ips {
+		labels := make(map[string]string, len(ep.Labels)+1)
+		for k, v := range ep.Labels {
+			labels[k] = v
+		}
+		labels[IPLabel] = ip.String()
+
+		result[i] = *ep
+		result[i].IP = ip
+		result[i].Labels = labels
+	}
+	return result
+}
+
+// ExpandedIP returns the IP address of an endpoint created by ExpandIPs, and
+// an empty string for other endpoints.
+func (ep *Endpoint) ExpandedIP() string {
+	if ep.IP == nil || ep.Labels[IPLabel] == "" || ep.Labels[IPLabel] != ep.IP.String() {
+		return ""
+	}
+	return ep.Labels[IPLabel]
+}
+
+// ID returns the endpoint's identifier among the probe's endpoints: its name,
+// or, for the endpoints created by ExpandIPs, name and IP address.
+func (ep *Endpoint) ID() string {
+	if ip := ep.ExpandedIP(); ip != "" {
+		return ep.Name + "/" + ip
+	}
+	return ep.Name
+}
+
 // Lister should implement the ListEndpoints method.
 type Lister interface {
 	// ListEndpoints returns list of endpoints (name, port tupples).
@@ -181,12 +228,26 @@ func FromProtoMessage(endpointspb []*endpointpb.Endpoint) ([]Endpoint, error) {
 				ep.Port = port
 			}
 		}
-		epKey := ep.Key()
-		if seen[epKey] {
-			return nil, fmt.Errorf("duplicate endpoint: %s", ep.Key())
+
+		eps := []Endpoint{ep}
+		if len(pb.GetIps()) > 0 {
+			ips := make([]net.IP, len(pb.GetIps()))
+			for i, s := range pb.GetIps() {
+				if ips[i] = net.ParseIP(s); ips[i] == nil {
+					return nil, fmt.Errorf("invalid IP address (%s) for endpoint: %s", s, ep.Name)
+				}
+			}
+			eps = ep.ExpandIPs(ips)
+		}
+
+		for _, ep := range eps {
+			epKey := ep.Key()
+			if seen[epKey] {
+				return nil, fmt.Errorf("duplicate endpoint: %s", ep.Key())
+			}
+			seen[epKey] = true
+			endpoints = append(endpoints, ep)
 		}
-		seen[epKey] = true
-		endpoints = append(endpoints, ep)
 	}
 
 	return endpoints, nil
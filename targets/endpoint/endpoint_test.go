@@ -262,6 +262,52 @@ func TestFromProtoMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multiple ips",
+			endpointspb: []*endpointpb.Endpoint{
+				{
+					Name:   proto.String("rr.example.com"),
+					Ip:     proto.String("10.0.0.9"),
+					Ips:    []string{"10.0.0.1", "2001:db8::1"},
+					Port:   proto.Int32(443),
+					Labels: map[string]string{"app": "web"},
+				},
+			},
+			want: []Endpoint{
+				{
+					Name:   "rr.example.com",
+					IP:     net.ParseIP("10.0.0.1"),
+					Port:   443,
+					Labels: map[string]string{"app": "web", "ip": "10.0.0.1"},
+				},
+				{
+					Name:   "rr.example.com",
+					IP:     net.ParseIP("2001:db8::1"),
+					Port:   443,
+					Labels: map[string]string{"app": "web", "ip": "2001:db8::1"},
+				},
+			},
+		},
+		{
+			name: "invalid ip in ips",
+			endpointspb: []*endpointpb.Endpoint{
+				{
+					Name: proto.String("rr.example.com"),
+					Ips:  []string{"10.0.0.1", "rr2.example.com"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate ip in ips",
+			endpointspb: []*endpointpb.Endpoint{
+				{
+					Name: proto.String("rr.example.com"),
+					Ips:  []string{"10.0.0.1", "10.0.0.1"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -352,3 +398,19 @@ func TestEndpointResolve(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointID(t *testing.T) {
+	ep := &Endpoint{Name: "web-1", Port: 80, Labels: map[string]string{"zone": "a"}}
+	assert.Equal(t, "web-1", ep.ID())
+	assert.Equal(t, "", ep.ExpandedIP())
+
+	eps := ep.ExpandIPs([]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")})
+	for i, want := range []string{"10.0.0.1", "10.0.0.2"} {
+		assert.Equal(t, want, eps[i].ExpandedIP())
+		assert.Equal(t, "web-1/"+want, eps[i].ID())
+	}
+
+	// An "ip" label alone doesn't make an endpoint expanded.
+	ep = &Endpoint{Name: "web-2", IP: net.ParseIP("10.0.0.3"), Labels: map[string]string{IPLabel: "10.0.0.4"}}
+	assert.Equal(t, "web-2", ep.ID())
+}
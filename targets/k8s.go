@@ -92,6 +92,11 @@ func parseConfig(pb *targetspb.K8STargets) (*k8sconfigpb.ProviderConfig, string,
 	case *targetspb.K8STargets_Pods:
 		pc.Pods = &k8sconfigpb.Pods{}
 		return pc, "pods", pb.GetPods()
+	case *targetspb.K8STargets_Endpointslices:
+		pc.Endpointslices = &k8sconfigpb.EndpointSlices{
+			IncludeNotReady: proto.Bool(pb.GetIncludeNotReady()),
+		}
+		return pc, "endpointslices", pb.GetEndpointslices()
 	}
 
 	return nil, "", ""
@@ -141,7 +146,13 @@ func k8sTargets(pb *targetspb.K8STargets, l *logger.Logger) (*rdsclient.Client,
 		return rdsclient.New(conf, nil, l)
 	}
 
-	s, err := initRDSServer(key(pb.GetNamespace(), pb.GetLabelSelector(), resources), pc, l)
+	// RDS servers are shared between targets with the same key, so the key
+	// should capture all the provider options.
+	serverKey := key(pb.GetNamespace(), pb.GetLabelSelector(), resources)
+	if pb.GetIncludeNotReady() {
+		serverKey += "+include_not_ready"
+	}
+	s, err := initRDSServer(serverKey, pc, l)
 	if err != nil {
 		return nil, fmt.Errorf("k8s: error creating resource discovery server: %v", err)
 	}
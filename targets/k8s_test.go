@@ -73,6 +73,19 @@ func Test_parseConfig(t *testing.T) {
 			wantName:  "endpoints",
 			wantValue: ".*-service",
 		},
+		{
+			cfg: `endpointslices:"web"
+			      include_not_ready: true`,
+			wantPC: &k8sconfigpb.ProviderConfig{
+				Namespace: proto.String(""),
+				Endpointslices: &k8sconfigpb.EndpointSlices{
+					IncludeNotReady: proto.Bool(true),
+				},
+				ReEvalSec: proto.Int32(30),
+			},
+			wantName:  "endpointslices",
+			wantValue: "web",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.cfg, func(t *testing.T) {
@@ -250,26 +250,42 @@ func parseOverrideAddress(dnsResolverOverride string) (string, string, error) {
 	return networkOverride, dnsResolverOverride, nil
 }
 
-func NewWithOverrideResolver(dnsResolverOverride string) (*Resolver, error) {
-	networkOverride, dnsResolverOverride, err := parseOverrideAddress(dnsResolverOverride)
+// NewNetResolver returns a Go resolver that sends DNS queries to the given
+// DNS server, specified as [network://]ip[:port]. Connections to the DNS
+// server time out after the given timeout.
+func NewNetResolver(dnsServer string, timeout time.Duration) (*net.Resolver, error) {
+	networkOverride, dnsServer, err := parseOverrideAddress(dnsServer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			d := net.Dialer{
+				Timeout: timeout,
+			}
+			if networkOverride != "" {
+				network = networkOverride
+			}
+			return d.DialContext(ctx, network, dnsServer)
+		},
+	}, nil
+}
+
+// NewWithOverrideResolver returns a new Resolver that uses the given DNS
+// server (see NewNetResolver) instead of the system resolver. Lookups time
+// out after the given timeout.
+func NewWithOverrideResolver(dnsResolverOverride string, timeout time.Duration) (*Resolver, error) {
+	r, err := NewNetResolver(dnsResolverOverride, timeout)
 	if err != nil {
 		return nil, err
 	}
 
 	resolveFunc := func(host string) ([]net.IP, error) {
-		r := &net.Resolver{
-			PreferGo: true,
-			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-				d := net.Dialer{
-					Timeout: defaultMaxAge,
-				}
-				if networkOverride != "" {
-					network = networkOverride
-				}
-				return d.DialContext(ctx, network, dnsResolverOverride)
-			},
-		}
-		return r.LookupIP(context.Background(), "ip", host)
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return r.LookupIP(ctx, "ip", host)
 	}
 
 	return NewWithResolve(resolveFunc), nil
@@ -473,7 +473,7 @@ func TestNewWithOverrideResolver(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r, err := NewWithOverrideResolver(tt.dnsResolverOverride)
+			r, err := NewWithOverrideResolver(tt.dnsResolverOverride, 5*time.Second)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewWithOverrideResolver() error = %v, wantErr %v", err, tt.wantErr)
 				return
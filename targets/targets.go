@@ -23,12 +23,15 @@
 package targets
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cloudprober/cloudprober/config/runconfig"
@@ -123,9 +126,32 @@ type targets struct {
 	resolver        endpoint.Resolver
 	staticEndpoints []endpoint.Endpoint
 	re              *regexp.Regexp
+	portsLabel      string
+	disabledLabel   string
 	ldLister        endpoint.Lister
 	l               *logger.Logger
 	resolverIP      string // Used for testing
+
+	// Set only if targets use a custom DNS server.
+	dnsResolver *net.Resolver
+
+	// Empty targets list handling, see empty_targets_as_error and
+	// keep_targets_on_empty in targets.proto.
+	emptyAsError bool
+	keepOnEmpty  bool
+	emptyCount   atomic.Int64
+	emptyMu      sync.Mutex
+	lastNonEmpty []endpoint.Endpoint
+}
+
+// DNSResolver returns the DNS resolver for the targets if they are configured
+// to use a custom DNS server (dns_server), otherwise nil. Probes use it to
+// resolve target names while connecting to them.
+func DNSResolver(t Targets) *net.Resolver {
+	if tt, ok := t.(*targets); ok {
+		return tt.dnsResolver
+	}
+	return nil
 }
 
 // Resolve either resolves a target using the core resolver, or returns an error
@@ -178,7 +204,8 @@ func (t *targets) includeInResult(ep endpoint.Endpoint, ldMap map[string]endpoin
 // consists of a name and associated metadata like port and target labels.
 //
 // It gets the list of targets from the configured targets type, filters them
-// by the configured regex, excludes lame ducks and returns the resultant list.
+// by the configured regex, excludes lame ducks and disabled targets, and
+// returns the resultant list.
 //
 // This method should be concurrency safe as it doesn't modify any shared
 // variables and doesn't rely on multiple accesses to same variable being
@@ -188,12 +215,79 @@ func (t *targets) includeInResult(ep endpoint.Endpoint, ldMap map[string]endpoin
 // associated metadata at all, those endpoint fields are left empty in that
 // case.
 func (t *targets) ListEndpoints() []endpoint.Endpoint {
+	list, _ := t.listEndpoints()
+	return t.handleEmpty(list)
+}
+
+// handleEmpty handles the empty targets list as per the
+// empty_targets_as_error and keep_targets_on_empty options. It returns the
+// list that should be used by the probes.
+func (t *targets) handleEmpty(list []endpoint.Endpoint) []endpoint.Endpoint {
+	if !t.emptyAsError && !t.keepOnEmpty {
+		return list
+	}
+
+	t.emptyMu.Lock()
+	defer t.emptyMu.Unlock()
+
+	if len(list) != 0 {
+		t.lastNonEmpty = list
+		return list
+	}
+
+	if t.emptyAsError {
+		t.emptyCount.Add(1)
+		t.l.Warning("targets: got empty targets list")
+	}
+	if t.keepOnEmpty && len(t.lastNonEmpty) != 0 {
+		t.l.Warningf("targets: keeping the previous %d targets as the new targets list is empty", len(t.lastNonEmpty))
+		return t.lastNonEmpty
+	}
+	return list
+}
+
+// EmptyTargetsCount returns the number of times the targets list was found
+// empty, and whether the targets are configured to treat an empty targets
+// list as an error (empty_targets_as_error). Note that the targets list is
+// counted as empty even if the previous targets were kept because of the
+// keep_targets_on_empty option.
+func EmptyTargetsCount(t Targets) (int64, bool) {
+	if tt, ok := t.(*targets); ok && tt.emptyAsError {
+		return tt.emptyCount.Load(), true
+	}
+	return 0, false
+}
+
+// DisabledEndpoints returns the targets that are not probed because they are
+// disabled through the disabled_label. It returns nil if disabled_label is
+// not configured.
+func DisabledEndpoints(t Targets) []endpoint.Endpoint {
+	if tt, ok := t.(*targets); ok && tt.disabledLabel != "" {
+		_, disabled := tt.listEndpoints()
+		return disabled
+	}
+	return nil
+}
+
+// isDisabled returns true if the endpoint is disabled through the
+// disabled_label.
+func (t *targets) isDisabled(ep endpoint.Endpoint) bool {
+	if t.disabledLabel == "" {
+		return false
+	}
+	disabled, _ := strconv.ParseBool(ep.Labels[t.disabledLabel])
+	return disabled
+}
+
+// listEndpoints returns the filtered list of target endpoints, along with the
+// endpoints that were filtered out because they are disabled.
+func (t *targets) listEndpoints() (list, disabled []endpoint.Endpoint) {
 	if t.lister == nil && len(t.staticEndpoints) == 0 {
 		t.l.Error("no lister or static endpoints")
-		return []endpoint.Endpoint{}
+		return []endpoint.Endpoint{}, nil
 	}
 
-	list := append([]endpoint.Endpoint{}, t.staticEndpoints...)
+	list = append([]endpoint.Endpoint{}, t.staticEndpoints...)
 	if t.lister != nil {
 		list = append(list, t.lister.ListEndpoints()...)
 	}
@@ -209,7 +303,57 @@ func (t *targets) ListEndpoints() []endpoint.Endpoint {
 		list = result
 	}
 
-	return list
+	if t.disabledLabel != "" {
+		var result []endpoint.Endpoint
+		for _, ep := range list {
+			if t.isDisabled(ep) {
+				disabled = append(disabled, ep)
+				continue
+			}
+			result = append(result, ep)
+		}
+		list = result
+	}
+
+	if t.portsLabel != "" {
+		list = expandPorts(list, t.portsLabel, t.l)
+	}
+
+	return list, disabled
+}
+
+// expandPorts expands endpoints that have the ports label into one endpoint
+// per port.
+func expandPorts(list []endpoint.Endpoint, portsLabel string, l *logger.Logger) []endpoint.Endpoint {
+	var result []endpoint.Endpoint
+	for _, ep := range list {
+		portsStr, ok := ep.Labels[portsLabel]
+		if !ok {
+			result = append(result, ep)
+			continue
+		}
+
+		var ports []int
+		for _, s := range strings.Split(portsStr, ",") {
+			port, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil || port <= 0 || port > 65535 {
+				l.Warningf("Invalid port (%s) in the label %s for target %s", s, portsLabel, ep.Name)
+				continue
+			}
+			ports = append(ports, port)
+		}
+		if len(ports) == 0 {
+			result = append(result, ep)
+			continue
+		}
+
+		for _, port := range ports {
+			newEP := ep
+			newEP.Port = port
+			result = append(result, newEP)
+		}
+	}
+	return result
 }
 
 // baseTargets constructs a targets instance with no lister or resolver. It
@@ -234,6 +378,11 @@ func baseTargets(targetsDef *targetspb.TargetsDef, ldLister endpoint.Lister, l *
 		return tgts, nil
 	}
 
+	tgts.portsLabel = targetsDef.GetPortsLabel()
+	tgts.disabledLabel = targetsDef.GetDisabledLabel()
+	tgts.emptyAsError = targetsDef.GetEmptyTargetsAsError()
+	tgts.keepOnEmpty = targetsDef.GetKeepTargetsOnEmpty()
+
 	if targetsDef.GetRegex() != "" {
 		var err error
 		if tgts.re, err = regexp.Compile(targetsDef.GetRegex()); err != nil {
@@ -302,6 +451,9 @@ func rdsClientConf(pb *targetspb.RDSTargets, globalOpts *targetspb.GlobalTargets
 			Filter:       pb.GetFilter(),
 			IpConfig:     pb.GetIpConfig(),
 		},
+		ReportChanges: pb.ReportChanges,
+		SampleSize:    pb.SampleSize,
+		SampleSeed:    pb.SampleSeed,
 	}, nil
 }
 
@@ -325,10 +477,17 @@ func New(targetsDef *targetspb.TargetsDef, ldLister endpoint.Lister, globalOpts
 	resolver := globalResolver
 	if ip := targetsDef.GetDnsServer(); ip != "" {
 		l.Infof("Overriding default resolver with: %s", ip)
-		resolver, err = dnsRes.NewWithOverrideResolver(ip)
+		if targetsDef.GetDnsServerTimeoutMsec() <= 0 {
+			return nil, fmt.Errorf("targets.New(): invalid dns_server_timeout_msec: %d", targetsDef.GetDnsServerTimeoutMsec())
+		}
+		timeout := time.Duration(targetsDef.GetDnsServerTimeoutMsec()) * time.Millisecond
+		resolver, err = dnsRes.NewWithOverrideResolver(ip, timeout)
 		if err != nil {
 			return nil, fmt.Errorf("targets.New(): error creating resolver with override: %v", err)
 		}
+		if t.dnsResolver, err = dnsRes.NewNetResolver(ip, timeout); err != nil {
+			return nil, fmt.Errorf("targets.New(): error creating resolver with override: %v", err)
+		}
 		t.resolverIP = ip
 	}
 	t.resolver = resolver
@@ -431,6 +590,25 @@ func RegisterTargetsType(extensionFieldNo int, newTargetsFunc func(interface{},
 	extensionMap[extensionFieldNo] = newTargetsFunc
 }
 
+// RDSResource is a resource discovered through the resource discovery
+// service (RDS), e.g. a kubernetes pod or a GCE instance.
+type RDSResource = rdspb.Resource
+
+// RegisterResourceTransform registers a function to post-process the
+// resources discovered through RDS (rds_targets, k8s and file targets), e.g.
+// to enrich them with labels from a CMDB, before they become targets. It is
+// useful when embedding cloudprober, and should be called before creating
+// the targets.
+//
+// Transform is called after each refresh, with the provider name and a copy
+// of the resources that it can modify in place, and returns the resources to
+// use. It's bounded by the refresh timeout, and if it fails or times out,
+// last successfully transformed resources continue to be used. Targets can
+// be listed while transforms are running.
+func RegisterResourceTransform(name string, f func(ctx context.Context, provider string, resources []*RDSResource) ([]*RDSResource, error)) {
+	rdsclient.RegisterResourceTransform(name, f)
+}
+
 // SetSharedTargets adds given targets to an internal map. These targets can
 // then be referred by multiple probes through "shared_targets" option.
 func SetSharedTargets(name string, tgts Targets) {
@@ -108,6 +108,97 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListWithPortsLabel(t *testing.T) {
+	targetsDef := &targetspb.TargetsDef{
+		PortsLabel: proto.String("ports"),
+	}
+	bt, err := baseTargets(targetsDef, nil, nil)
+	assert.NoError(t, err, "Unexpected error building targets")
+
+	bt.lister = &mockLister{[]endpoint.Endpoint{
+		{Name: "a", Port: 8080},
+		{Name: "b", Port: 8080, Labels: map[string]string{"ports": "80, 443"}},
+		{Name: "c", Port: 8080, Labels: map[string]string{"ports": "80,invalid"}},
+		{Name: "d", Port: 8080, Labels: map[string]string{"ports": "invalid"}},
+	}}
+
+	var got []string
+	for _, ep := range bt.ListEndpoints() {
+		got = append(got, fmt.Sprintf("%s:%d", ep.Name, ep.Port))
+	}
+	assert.Equal(t, []string{"a:8080", "b:80", "b:443", "c:80", "d:8080"}, got)
+}
+
+func TestListWithDisabledLabel(t *testing.T) {
+	targetsDef := &targetspb.TargetsDef{
+		DisabledLabel: proto.String("disabled"),
+	}
+	bt, err := baseTargets(targetsDef, nil, nil)
+	assert.NoError(t, err, "Unexpected error building targets")
+
+	lister := &mockLister{[]endpoint.Endpoint{
+		{Name: "a"},
+		{Name: "b", Labels: map[string]string{"disabled": "true"}},
+		{Name: "c", Labels: map[string]string{"disabled": "false"}},
+		{Name: "d", Labels: map[string]string{"disabled": "invalid"}},
+	}}
+	bt.lister = lister
+
+	assert.Equal(t, []string{"a", "c", "d"}, endpoint.NamesFromEndpoints(bt.ListEndpoints()))
+	assert.Equal(t, []string{"b"}, endpoint.NamesFromEndpoints(DisabledEndpoints(bt)))
+
+	// Re-enable the target.
+	lister.list[1].Labels["disabled"] = "false"
+	assert.Equal(t, []string{"a", "b", "c", "d"}, endpoint.NamesFromEndpoints(bt.ListEndpoints()))
+	assert.Empty(t, DisabledEndpoints(bt))
+
+	// No disabled_label.
+	assert.Nil(t, DisabledEndpoints(StaticTargets("a,b")))
+}
+
+func TestListWithEmptyTargets(t *testing.T) {
+	for _, keep := range []bool{false, true} {
+		t.Run(fmt.Sprintf("keep_targets_on_empty=%v", keep), func(t *testing.T) {
+			targetsDef := &targetspb.TargetsDef{
+				Regex:               proto.String("web-.*"),
+				EmptyTargetsAsError: proto.Bool(true),
+				KeepTargetsOnEmpty:  proto.Bool(keep),
+			}
+			bt, err := baseTargets(targetsDef, nil, nil)
+			assert.NoError(t, err, "Unexpected error building targets")
+
+			lister := &mockLister{}
+			bt.lister = lister
+
+			// Empty list before any targets were found.
+			assert.Empty(t, bt.ListEndpoints())
+
+			lister.list = endpoint.EndpointsFromNames([]string{"web-1", "web-2"})
+			assert.Equal(t, []string{"web-1", "web-2"}, endpoint.NamesFromEndpoints(bt.ListEndpoints()))
+
+			// Empty after filtering.
+			lister.list = endpoint.EndpointsFromNames([]string{"db-1"})
+			if keep {
+				assert.Equal(t, []string{"web-1", "web-2"}, endpoint.NamesFromEndpoints(bt.ListEndpoints()))
+			} else {
+				assert.Empty(t, bt.ListEndpoints())
+			}
+
+			count, ok := EmptyTargetsCount(bt)
+			assert.True(t, ok)
+			assert.Equal(t, int64(2), count)
+		})
+	}
+
+	// empty_targets_as_error is not set.
+	bt, err := baseTargets(&targetspb.TargetsDef{KeepTargetsOnEmpty: proto.Bool(true)}, nil, nil)
+	assert.NoError(t, err, "Unexpected error building targets")
+	bt.lister = &mockLister{}
+	assert.Empty(t, bt.ListEndpoints())
+	_, ok := EmptyTargetsCount(bt)
+	assert.False(t, ok)
+}
+
 func TestDummyTargets(t *testing.T) {
 	targetsDef := &targetspb.TargetsDef{
 		Type: &targetspb.TargetsDef_DummyTargets{
@@ -389,4 +480,13 @@ func TestNewResolver(t *testing.T) {
 		t.Fatalf("Casting of targets object using override resolver failed")
 	}
 	assert.Equal(t, targetOverrideResolverCast.resolverIP, "1.1.1.1")
+
+	// DNS resolver for the probes is exposed only for the override resolver.
+	assert.Nil(t, DNSResolver(targetGlobalResolver))
+	assert.NotNil(t, DNSResolver(targetOverrideResolver))
+	assert.Nil(t, DNSResolver(&dummy{}))
+
+	targetsDefOverrideResolver.DnsServerTimeoutMsec = proto.Int32(0)
+	_, err = New(targetsDefOverrideResolver, nil, nil, nil, nil)
+	assert.Error(t, err, "invalid dns_server_timeout_msec")
 }
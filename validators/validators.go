@@ -32,7 +32,7 @@ import (
 // validationFailure map is updated in place and should already be initialized,
 // ideally using ValidationFailureMap, before calling this function.
 func RunValidators(opts *options.Options, response []byte, validationFailure *metrics.Map[int64], l *logger.Logger) []string {
-	return validators.RunValidators(opts.Validators, &validators.Input{ResponseBody: response}, validationFailure, l)
+	return validators.RunValidators(opts.Validators, &validators.Input{ResponseBody: response}, validationFailure, nil, l)
 }
 
 // ValidationFailureMap returns an initialized validation failures map.
@@ -0,0 +1,163 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/cloudprober/cloudprober/probes"
+	"github.com/cloudprober/cloudprober/targets"
+	"github.com/cloudprober/cloudprober/targets/endpoint"
+)
+
+const defaultTargetsPageLimit = 1000
+
+type targetInfo struct {
+	Name   string            `json:"name"`
+	IP     string            `json:"ip,omitempty"`
+	Port   int               `json:"port,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type probeTargets struct {
+	Probe   string        `json:"probe"`
+	Total   int           `json:"total"`
+	Offset  int           `json:"offset"`
+	Targets []*targetInfo `json:"targets"`
+
+	// Targets that are not probed because they are disabled through the
+	// targets' disabled_label. These are not paginated.
+	Disabled []*targetInfo `json:"disabled,omitempty"`
+}
+
+func newTargetInfo(pi *probes.ProbeInfo, ep endpoint.Endpoint, resolve bool) *targetInfo {
+	ti := &targetInfo{Name: ep.Name, Port: ep.Port, Labels: ep.Labels}
+	if ep.IP != nil {
+		ti.IP = ep.IP.String()
+	} else if resolve {
+		if ip, err := pi.Options.Targets.Resolve(ep.Name, pi.Options.IPVersion); err == nil {
+			ti.IP = ip.String()
+		}
+	}
+	return ti
+}
+
+func intQueryParam(r *http.Request, name string, defaultValue int) (int, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return defaultValue, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid value for %s: %s", name, s)
+	}
+	return v, nil
+}
+
+// probesTargets returns the current targets for the probes. If probeName is
+// not empty, only that probe's targets are returned. Targets are sorted by
+// name, and offset and limit are applied to each probe's targets
+// independently. If resolve is true, targets without an IP address are
+// resolved using the probe's resolver. Disabled targets are included
+// separately.
+func probesTargets(probeInfo map[string]*probes.ProbeInfo, probeName string, offset, limit int, resolve bool) ([]*probeTargets, error) {
+	var names []string
+	for name := range probeInfo {
+		if probeName == "" || name == probeName {
+			names = append(names, name)
+		}
+	}
+	if probeName != "" && len(names) == 0 {
+		return nil, fmt.Errorf("probe %s not found", probeName)
+	}
+	sort.Strings(names)
+
+	var result []*probeTargets
+	for _, name := range names {
+		pi := probeInfo[name]
+		pt := &probeTargets{Probe: name, Offset: offset, Targets: []*targetInfo{}}
+		result = append(result, pt)
+
+		if pi.Options == nil || pi.Options.Targets == nil {
+			continue
+		}
+
+		disabled := targets.DisabledEndpoints(pi.Options.Targets)
+		sort.SliceStable(disabled, func(i, j int) bool { return disabled[i].Name < disabled[j].Name })
+		for _, ep := range disabled {
+			pt.Disabled = append(pt.Disabled, newTargetInfo(pi, ep, resolve))
+		}
+
+		eps := pi.Options.Targets.ListEndpoints()
+		sort.SliceStable(eps, func(i, j int) bool { return eps[i].Name < eps[j].Name })
+		pt.Total = len(eps)
+
+		if offset >= len(eps) {
+			continue
+		}
+		eps = eps[offset:]
+		if limit > 0 && len(eps) > limit {
+			eps = eps[:limit]
+		}
+
+		for _, ep := range eps {
+			pt.Targets = append(pt.Targets, newTargetInfo(pi, ep, resolve))
+		}
+	}
+
+	return result, nil
+}
+
+// targetsHandler serves the current (expanded) targets for all probes, in
+// JSON format. Supported query parameters:
+//
+//	probe:   return targets only for this probe.
+//	offset:  skip these many targets for each probe (default 0).
+//	limit:   return at most these many targets per probe (default 1000, 0 means
+//	         no limit).
+//	resolve: if "true", resolve targets that don't have an IP address.
+func targetsHandler(fn DataFuncs) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, err := intQueryParam(r, "offset", 0)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		limit, err := intQueryParam(r, "limit", defaultTargetsPageLimit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resolve, _ := strconv.ParseBool(r.URL.Query().Get("resolve"))
+
+		probeInfo, _, _ := fn.GetInfo()
+		result, err := probesTargets(probeInfo, r.URL.Query().Get("probe"), offset, limit, resolve)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 The Cloudprober Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudprober/cloudprober/internal/servers"
+	"github.com/cloudprober/cloudprober/probes"
+	"github.com/cloudprober/cloudprober/probes/options"
+	"github.com/cloudprober/cloudprober/surfacers"
+	"github.com/cloudprober/cloudprober/targets"
+	endpointpb "github.com/cloudprober/cloudprober/targets/endpoint/proto"
+	targetspb "github.com/cloudprober/cloudprober/targets/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTargetsHandler(t *testing.T) {
+	probeInfo := map[string]*probes.ProbeInfo{
+		"p1": {Options: &options.Options{Targets: targets.StaticTargets("c.com,a.com,10.1.1.1")}},
+		"p2": {Options: &options.Options{Targets: targets.StaticTargets("b.com")}},
+	}
+	fn := DataFuncs{
+		GetInfo: func() (map[string]*probes.ProbeInfo, []*surfacers.SurfacerInfo, []*servers.ServerInfo) {
+			return probeInfo, nil, nil
+		},
+	}
+
+	tests := []struct {
+		query     string
+		wantCode  int
+		wantNames map[string][]string
+		wantTotal map[string]int
+	}{
+		{
+			query:     "",
+			wantCode:  http.StatusOK,
+			wantNames: map[string][]string{"p1": {"10.1.1.1", "a.com", "c.com"}, "p2": {"b.com"}},
+			wantTotal: map[string]int{"p1": 3, "p2": 1},
+		},
+		{
+			query:     "?probe=p1&offset=1&limit=1",
+			wantCode:  http.StatusOK,
+			wantNames: map[string][]string{"p1": {"a.com"}},
+			wantTotal: map[string]int{"p1": 3},
+		},
+		{
+			query:    "?probe=p3",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			query:    "?limit=x",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			targetsHandler(fn)(w, httptest.NewRequest("GET", "/targets"+test.query, nil))
+
+			assert.Equal(t, test.wantCode, w.Code)
+			if test.wantCode != http.StatusOK {
+				return
+			}
+
+			var result []*probeTargets
+			if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+				t.Fatalf("Error parsing response: %v, response: %s", err, w.Body.String())
+			}
+
+			gotNames, gotTotal := map[string][]string{}, map[string]int{}
+			for _, pt := range result {
+				gotTotal[pt.Probe] = pt.Total
+				gotNames[pt.Probe] = []string{}
+				for _, ti := range pt.Targets {
+					gotNames[pt.Probe] = append(gotNames[pt.Probe], ti.Name)
+				}
+			}
+			assert.Equal(t, test.wantNames, gotNames)
+			assert.Equal(t, test.wantTotal, gotTotal)
+		})
+	}
+}
+
+func TestTargetsHandlerDisabled(t *testing.T) {
+	tgts, err := targets.New(&targetspb.TargetsDef{
+		Endpoint: []*endpointpb.Endpoint{
+			{Name: proto.String("b.com"), Labels: map[string]string{"disabled": "true"}},
+			{Name: proto.String("a.com")},
+		},
+		DisabledLabel: proto.String("disabled"),
+	}, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	probeInfo := map[string]*probes.ProbeInfo{
+		"p1": {Options: &options.Options{Targets: tgts}},
+	}
+	result, err := probesTargets(probeInfo, "", 0, 0, false)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+
+	assert.Equal(t, 1, result[0].Total)
+	assert.Equal(t, "a.com", result[0].Targets[0].Name)
+	require.Len(t, result[0].Disabled, 1)
+	assert.Equal(t, "b.com", result[0].Disabled[0].Name)
+	assert.Equal(t, map[string]string{"disabled": "true"}, result[0].Disabled[0].Labels)
+}
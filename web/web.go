@@ -119,7 +119,7 @@ var secretConfigRunningMsg = `
 // InitWithDataFuncs initializes cloudprober web interface handler.
 func InitWithDataFuncs(fn DataFuncs) error {
 	srvMux := runconfig.DefaultHTTPServeMux()
-	for _, url := range []string{"/config", "/config-running", "/static/"} {
+	for _, url := range []string{"/config", "/config-running", "/targets", "/static/"} {
 		if webutils.IsHandled(srvMux, url) {
 			return fmt.Errorf("url %s is already handled", url)
 		}
@@ -148,6 +148,7 @@ func InitWithDataFuncs(fn DataFuncs) error {
 	srvMux.HandleFunc("/alerts", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprint(w, alertsState())
 	})
+	srvMux.HandleFunc("/targets", targetsHandler(fn))
 	srvMux.Handle("/static/", http.FileServer(http.FS(content)))
 	return nil
 }